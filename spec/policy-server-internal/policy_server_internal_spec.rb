@@ -93,12 +93,22 @@ module Bosh::Template::Test
             'require_ssl' => true,
             'ca_cert' => '/var/vcap/jobs/policy-server-internal/config/certs/database_ca.crt',
           },
+          'database_replicas' => [],
+          'database_replica_max_lag_seconds' => 5,
+          'database_replica_check_interval_seconds' => 10,
           'max_idle_connections' => 4,
           'max_open_connections' => 5,
           'connections_max_lifetime_seconds' => 54,
           'tag_length' => 1,
           'metron_address' => '127.0.0.1:4567',
           'log_level' => 'error',
+          'tls_reload_interval_seconds' => 60,
+          'ca_rotation_overlap_seconds' => 3600,
+          'client_identities' => [],
+          'server_read_timeout_seconds' => 30,
+          'server_write_timeout_seconds' => 60,
+          'server_idle_timeout_seconds' => 120,
+          'drain_timeout_seconds' => 30,
 
           # hard-coded values, not exposed as bosh spec properties
           'debug_server_host' => '127.0.0.1',
@@ -127,6 +137,49 @@ module Bosh::Template::Test
           end
         end
       end
+
+      context 'when resolve_app_orgs is true' do
+        before do
+          merged_manifest_properties['resolve_app_orgs'] = true
+          merged_manifest_properties['uaa_client_secret'] = 'some-uaa-secret'
+        end
+
+        it 'renders the uaa and cloud controller properties' do
+          config = JSON.parse(template.render(merged_manifest_properties, consumes: links))
+          expect(config).to include(
+            'uaa_client' => 'network-policy',
+            'uaa_client_secret' => 'some-uaa-secret',
+            'uaa_ca' => '/var/vcap/jobs/policy-server-internal/config/certs/uaa_ca.crt',
+            'uaa_url' => 'https://uaa.service.cf.internal',
+            'uaa_port' => 8443,
+            'cc_url' => 'http://cloud-controller-ng.service.cf.internal:9022',
+            'cc_ca_cert' => '/var/vcap/jobs/policy-server-internal/config/certs/cc_ca.crt',
+            'skip_ssl_validation' => false,
+            'app_org_cache_seconds' => 300,
+          )
+        end
+
+        context 'when there is a cloud_controller_https_endpoint link' do
+          let(:cc_link) do
+            Link.new(
+              name: 'cloud_controller_https_endpoint',
+              instances: [LinkInstance.new()],
+              properties: {
+                'cc' => {
+                  'internal_service_hostname' => 'cloud-controller-ng.service.cf.internal',
+                  'public_tls' => {'port' => 9023, 'ca_cert' => 'some-cc-ca'},
+                }
+              }
+            )
+          end
+          let(:links) {[dbconn_link, tag_link, db_link, cc_link]}
+
+          it 'uses the https endpoint' do
+            config = JSON.parse(template.render(merged_manifest_properties, consumes: links))
+            expect(config['cc_url']).to eq('https://cloud-controller-ng.service.cf.internal:9023')
+          end
+        end
+      end
     end
   end
 end
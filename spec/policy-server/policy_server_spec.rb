@@ -86,6 +86,9 @@ module Bosh::Template::Test
             'require_ssl' => true,
             'ca_cert' => '/var/vcap/jobs/policy-server/config/certs/database_ca.crt'
           },
+          'database_replicas' => [],
+          'database_replica_max_lag_seconds' => 5,
+          'database_replica_check_interval_seconds' => 10,
           'database_migration_timeout' => 600,
           'max_idle_connections' => 4,
           'max_open_connections' => 5,
@@ -94,9 +97,21 @@ module Bosh::Template::Test
           'metron_address' => '127.0.0.1:6789',
           'log_level' => 'debug',
           'cleanup_interval' => 60,
+          'enable_tag_reclamation' => false,
+          'tag_reclaim_quarantine_seconds' => 86400,
+          'tag_utilisation_warning_percent' => 80,
           'max_policies' => 2,
           'enable_space_developer_self_service' => true,
+          'enable_egress_self_service' => false,
           'allowed_cors_domains' => ['some-cors-domain'],
+          'rate_limits' => {},
+          'forbidden_egress_cidrs' => [],
+          'max_batch_size' => 100,
+          'server_read_timeout_seconds' => 30,
+          'server_write_timeout_seconds' => 60,
+          'server_idle_timeout_seconds' => 120,
+          'drain_timeout_seconds' => 30,
+          'drain_delay_seconds' => 5,
           'uaa_ca' => '/var/vcap/jobs/policy-server/config/certs/uaa_ca.crt',
           'request_timeout' => 5,
         })
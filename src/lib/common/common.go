@@ -3,15 +3,17 @@ package common
 import (
 	"crypto/tls"
 	"fmt"
+	"lib/httpserver"
+	"policy-server/db"
 	"policy-server/server_metrics"
 	"policy-server/store"
 	"time"
 
+	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerflags"
 	"github.com/tedsuo/ifrit"
-	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/rata"
 )
 
@@ -29,21 +31,52 @@ func GetLagerConfig() lagerflags.LagerConfig {
 	return lagerConfig
 }
 
-func InitMetricsEmitter(logger lager.Logger, wrappedStore *store.MetricsWrapper) *metrics.MetricsEmitter {
+func InitMetricsEmitter(logger lager.Logger, wrappedStore *store.MetricsWrapper, extraSources ...metrics.MetricSource) *metrics.MetricsEmitter {
 	totalPoliciesSource := server_metrics.NewTotalPoliciesSource(wrappedStore)
 	uptimeSource := metrics.NewUptimeSource()
-	return metrics.NewMetricsEmitter(logger, emitInterval, uptimeSource, totalPoliciesSource)
+	sources := append([]metrics.MetricSource{uptimeSource, totalPoliciesSource}, extraSources...)
+	return metrics.NewMetricsEmitter(logger, emitInterval, sources...)
 }
 
-func InitServer(logger lager.Logger, tlsConfig *tls.Config, host string, port int, handlers rata.Handlers, routes rata.Routes) ifrit.Runner {
+// InitReplicaRouter opens a connection pool per configured replica and returns
+// a router that spreads reads across them. A replica that cannot be connected
+// to is logged and left out, so that reads fall back to the primary rather
+// than the job failing to start. The replicas are checked once before
+// returning so that reads start going to them straight away.
+func InitReplicaRouter(logger lager.Logger, primary *db.ConnWrapper, replicaConfs []dbHelper.Config, maxOpenConnections, maxIdleConnections int,
+	connMaxLifetime, maxLag time.Duration, metricsSender *metrics.MetricsSender, logPrefix, jobPrefix string) (*db.ReplicaRouter, []*db.ConnWrapper) {
+	var replicaPools []*db.ConnWrapper
+	var replicas []db.Conn
+	for _, replicaConf := range replicaConfs {
+		replicaLogger := logger.Session("replica", lager.Data{"host": replicaConf.Host})
+		replicaPool, err := db.NewErroringConnectionPool(
+			replicaConf,
+			maxOpenConnections,
+			maxIdleConnections,
+			connMaxLifetime,
+			logPrefix,
+			jobPrefix,
+			replicaLogger,
+		)
+		if err != nil {
+			replicaLogger.Error("connect-to-replica", err)
+			continue
+		}
+		replicaPools = append(replicaPools, replicaPool)
+		replicas = append(replicas, replicaPool)
+	}
+
+	router := db.NewReplicaRouter(primary, replicas, maxLag, metricsSender, logger.Session("replica-router"))
+	router.CheckReplicas()
+	return router, replicaPools
+}
+
+func InitServer(logger lager.Logger, tlsConfig *tls.Config, host string, port int, handlers rata.Handlers, routes rata.Routes, options httpserver.Options) ifrit.Runner {
 	router, err := rata.NewRouter(routes, handlers)
 	if err != nil {
 		logger.Fatal("create-rata-router", err) // not tested
 	}
 
 	addr := fmt.Sprintf("%s:%d", host, port)
-	if tlsConfig != nil {
-		return http_server.NewTLSServer(addr, router, tlsConfig)
-	}
-	return http_server.New(addr, router)
+	return httpserver.New(logger, addr, router, tlsConfig, options)
 }
@@ -0,0 +1,123 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Options are the timeouts of a Server. A zero timeout means none, except for
+// DrainTimeout, where it means in-flight requests are not waited for.
+type Options struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	DrainTimeout time.Duration
+
+	// DrainDelay is how long the server keeps accepting connections after
+	// DrainState is marked, so that load balancers polling a health check on
+	// the same listener see it fail and stop sending requests before the
+	// listener closes.
+	DrainDelay time.Duration
+
+	// DrainState, if set, is marked as draining when the server starts to
+	// shut down, so that health checks can report it as not ready.
+	DrainState *DrainState
+}
+
+// DrainState records that a server has started to shut down. It is safe to
+// share between servers and handlers.
+type DrainState struct {
+	draining int32
+}
+
+func (d *DrainState) Begin() {
+	atomic.StoreInt32(&d.draining, 1)
+}
+
+func (d *DrainState) Draining() bool {
+	return atomic.LoadInt32(&d.draining) == 1
+}
+
+// Server is an ifrit runner for an HTTP server that, when signalled, waits
+// DrainDelay, stops accepting connections and waits up to DrainTimeout for
+// in-flight requests to finish before closing the rest.
+type Server struct {
+	Logger    lager.Logger
+	Address   string
+	Handler   http.Handler
+	TLSConfig *tls.Config
+	Options   Options
+}
+
+func New(logger lager.Logger, address string, handler http.Handler, tlsConfig *tls.Config, options Options) *Server {
+	return &Server{
+		Logger:    logger,
+		Address:   address,
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+		Options:   options,
+	}
+}
+
+func (s *Server) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	server := &http.Server{
+		Handler:      s.Handler,
+		TLSConfig:    s.TLSConfig,
+		ReadTimeout:  s.Options.ReadTimeout,
+		WriteTimeout: s.Options.WriteTimeout,
+		IdleTimeout:  s.Options.IdleTimeout,
+	}
+
+	listener, err := net.Listen("tcp", s.Address)
+	if err != nil {
+		return err
+	}
+	if s.TLSConfig != nil {
+		listener = tls.NewListener(listener, s.TLSConfig)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	close(ready)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-signals:
+		return s.drain(server)
+	}
+}
+
+func (s *Server) drain(server *http.Server) error {
+	if s.Options.DrainState != nil {
+		s.Options.DrainState.Begin()
+	}
+
+	logger := s.Logger.Session("drain", lager.Data{"address": s.Address})
+	logger.Info("started", lager.Data{"timeout": s.Options.DrainTimeout.String(), "delay": s.Options.DrainDelay.String()})
+
+	time.Sleep(s.Options.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.Options.DrainTimeout)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		logger.Error("timed-out", err)
+		server.Close()
+		return nil
+	}
+
+	logger.Info("complete")
+	return nil
+}
@@ -0,0 +1,161 @@
+package httpserver_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"lib/httpserver"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		server     *httpserver.Server
+		drainState *httpserver.DrainState
+		logger     *lagertest.TestLogger
+		address    string
+		started    chan struct{}
+		release    chan struct{}
+		process    ifrit.Process
+	)
+
+	freeAddress := func() string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+		return listener.Addr().String()
+	}
+
+	get := func(path string) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+		return client.Get(fmt.Sprintf("http://%s%s", address, path))
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		drainState = &httpserver.DrainState{}
+		address = freeAddress()
+		started = make(chan struct{}, 1)
+		release = make(chan struct{})
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/slow" {
+				started <- struct{}{}
+				<-release
+			}
+			w.Write([]byte("done"))
+		})
+
+		server = httpserver.New(logger, address, handler, nil, httpserver.Options{
+			ReadTimeout:  time.Second,
+			WriteTimeout: 5 * time.Second,
+			IdleTimeout:  time.Second,
+			DrainTimeout: 2 * time.Second,
+			DrainState:   drainState,
+		})
+		process = ifrit.Invoke(server)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Kill)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("serves requests", func() {
+		resp, err := get("/")
+		Expect(err).NotTo(HaveOccurred())
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal("done"))
+		Expect(drainState.Draining()).To(BeFalse())
+	})
+
+	Context("when signalled", func() {
+		var slowResponse chan string
+
+		BeforeEach(func() {
+			slowResponse = make(chan string, 1)
+			go func() {
+				defer GinkgoRecover()
+				resp, err := get("/slow")
+				if err != nil {
+					slowResponse <- err.Error()
+					return
+				}
+				body, err := ioutil.ReadAll(resp.Body)
+				Expect(err).NotTo(HaveOccurred())
+				slowResponse <- string(body)
+			}()
+			Eventually(started).Should(Receive())
+
+			process.Signal(os.Interrupt)
+		})
+
+		It("marks the drain state and stops accepting connections", func() {
+			Eventually(drainState.Draining).Should(BeTrue())
+			Eventually(func() error {
+				_, err := get("/")
+				return err
+			}).Should(HaveOccurred())
+			close(release)
+		})
+
+		It("finishes in-flight requests before exiting", func() {
+			Consistently(process.Wait(), "200ms").ShouldNot(Receive())
+
+			close(release)
+			Eventually(slowResponse).Should(Receive(Equal("done")))
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(logger).To(gbytes.Say("test.drain.started"))
+			Expect(logger).To(gbytes.Say("test.drain.complete"))
+		})
+
+		It("closes the connections left once the drain timeout expires", func() {
+			Eventually(process.Wait(), "3s").Should(Receive(BeNil()))
+			Expect(logger).To(gbytes.Say("test.drain.timed-out"))
+			Eventually(slowResponse).Should(Receive(ContainSubstring("EOF")))
+			close(release)
+		})
+	})
+
+	Context("when signalled with a drain delay", func() {
+		It("keeps serving requests while marked as draining until the delay is over", func() {
+			delayedAddress := freeAddress()
+			delayed := httpserver.New(logger, delayedAddress, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Write([]byte("done"))
+			}), nil, httpserver.Options{
+				DrainTimeout: time.Second,
+				DrainDelay:   500 * time.Millisecond,
+				DrainState:   drainState,
+			})
+			delayedProcess := ifrit.Invoke(delayed)
+			delayedProcess.Signal(os.Interrupt)
+
+			Eventually(drainState.Draining).Should(BeTrue())
+			client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+			resp, err := client.Get(fmt.Sprintf("http://%s/", delayedAddress))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Eventually(delayedProcess.Wait(), "2s").Should(Receive(BeNil()))
+			_, err = client.Get(fmt.Sprintf("http://%s/", delayedAddress))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when the address is in use", func() {
+		It("returns an error", func() {
+			conflicting := httpserver.New(logger, address, http.NotFoundHandler(), nil, httpserver.Options{})
+			err := conflicting.Run(make(chan os.Signal), make(chan struct{}))
+			Expect(err).To(MatchError(ContainSubstring("address already in use")))
+		})
+	})
+})
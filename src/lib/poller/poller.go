@@ -2,6 +2,7 @@ package poller
 
 import (
 	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -12,16 +13,50 @@ type Poller struct {
 	PollInterval time.Duration
 
 	SingleCycleFunc func() error
+
+	lock            sync.Mutex
+	intervalChanged chan struct{}
+}
+
+// SetPollInterval changes the interval and restarts the wait for the next
+// cycle, so that a shorter interval takes effect straight away.
+func (m *Poller) SetPollInterval(pollInterval time.Duration) {
+	m.lock.Lock()
+	m.PollInterval = pollInterval
+	m.lock.Unlock()
+
+	select {
+	case m.intervalChanges() <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Poller) pollInterval() time.Duration {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.PollInterval
+}
+
+func (m *Poller) intervalChanges() chan struct{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.intervalChanged == nil {
+		m.intervalChanged = make(chan struct{}, 1)
+	}
+	return m.intervalChanged
 }
 
 func (m *Poller) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	close(ready)
 
+	intervalChanged := m.intervalChanges()
 	for {
 		select {
 		case <-signals:
 			return nil
-		case <-time.After(m.PollInterval):
+		case <-intervalChanged:
+			continue
+		case <-time.After(m.pollInterval()):
 			if err := m.SingleCycleFunc(); err != nil {
 				m.Logger.Error("poll-cycle", err)
 				continue
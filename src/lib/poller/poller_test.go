@@ -61,6 +61,31 @@ var _ = Describe("Poller", func() {
 			Eventually(retChan).Should(Receive(nil))
 		})
 
+		Context("when the poll interval is changed", func() {
+			BeforeEach(func() {
+				p.PollInterval = time.Hour
+			})
+
+			It("uses the new interval straight away", func() {
+				go func() {
+					retChan <- p.Run(signals, ready)
+				}()
+
+				Eventually(ready).Should(BeClosed())
+				Consistently(func() uint64 {
+					return atomic.LoadUint64(&cycleCount)
+				}, "50ms").Should(BeZero())
+
+				p.SetPollInterval(10 * time.Millisecond)
+				Eventually(func() uint64 {
+					return atomic.LoadUint64(&cycleCount)
+				}).Should(BeNumerically(">", 0))
+
+				signals <- os.Interrupt
+				Eventually(retChan).Should(Receive(nil))
+			})
+		})
+
 		Context("when the cycle func errors", func() {
 			BeforeEach(func() {
 				p.SingleCycleFunc = func() error { return errors.New("banana") }
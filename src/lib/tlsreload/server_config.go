@@ -0,0 +1,146 @@
+package tlsreload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/mutualtls"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+// ServerConfig serves a mutual TLS identity that is read again from its cert,
+// key and CA files whenever they change. New connections get the new identity;
+// established connections keep the one they were accepted with.
+//
+// When the CA file changes, the CAs it replaced stay trusted for CAOverlap, so
+// that clients still holding a cert from the old CA are not cut off while the
+// rotation rolls out.
+type ServerConfig struct {
+	Logger     lager.Logger
+	Clock      clock.Clock
+	CertFile   string
+	KeyFile    string
+	CACertFile string
+	CAOverlap  time.Duration
+
+	lock             sync.RWMutex
+	fingerprint      [sha256.Size]byte
+	caPEM            []byte
+	previousCAPEM    []byte
+	previousCAsUntil time.Time
+	config           *tls.Config
+	overlapConfig    *tls.Config
+}
+
+func NewServerConfig(logger lager.Logger, clock clock.Clock, certFile, keyFile, caCertFile string, caOverlap time.Duration) (*ServerConfig, error) {
+	s := &ServerConfig{
+		Logger:     logger,
+		Clock:      clock,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		CACertFile: caCertFile,
+		CAOverlap:  caOverlap,
+	}
+	err := s.ReloadIfChanged()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// TLSConfig returns the config to give to the server. It looks up the current
+// identity for every new connection.
+func (s *ServerConfig) TLSConfig() *tls.Config {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	c := s.config.Clone()
+	c.GetConfigForClient = s.configForClient
+	return c
+}
+
+// ReloadIfChanged reads the files and applies them if they differ from the
+// ones in use. Files that cannot be loaded, for instance a cert that has been
+// replaced before its key, are not applied and the current identity is kept.
+func (s *ServerConfig) ReloadIfChanged() error {
+	caPEM, fingerprint, err := s.readFiles()
+	if err != nil {
+		return err
+	}
+
+	s.lock.RLock()
+	unchanged := s.config != nil && fingerprint == s.fingerprint
+	s.lock.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	config, err := mutualtls.NewServerTLSConfig(s.CertFile, s.KeyFile, s.CACertFile)
+	if err != nil {
+		return fmt.Errorf("loading tls config: %s", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.Clock.Now()
+	if s.caPEM != nil && !bytes.Equal(s.caPEM, caPEM) && s.CAOverlap > 0 {
+		s.previousCAPEM = s.caPEM
+		s.previousCAsUntil = now.Add(s.CAOverlap)
+	}
+
+	s.overlapConfig = nil
+	if s.previousCAPEM != nil && now.Before(s.previousCAsUntil) {
+		overlapPool := x509.NewCertPool()
+		overlapPool.AppendCertsFromPEM(caPEM)
+		overlapPool.AppendCertsFromPEM(s.previousCAPEM)
+		s.overlapConfig = config.Clone()
+		s.overlapConfig.ClientCAs = overlapPool
+	}
+
+	s.config = config
+	s.caPEM = caPEM
+	s.fingerprint = fingerprint
+
+	s.Logger.Info("loaded-tls-config", lager.Data{
+		"cert_file":             s.CertFile,
+		"ca_cert_file":          s.CACertFile,
+		"overlapping_cas_until": s.previousCAsUntil,
+	})
+	return nil
+}
+
+func (s *ServerConfig) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.overlapConfig != nil && s.Clock.Now().Before(s.previousCAsUntil) {
+		return s.overlapConfig, nil
+	}
+	return s.config, nil
+}
+
+func (s *ServerConfig) readFiles() ([]byte, [sha256.Size]byte, error) {
+	certPEM, err := ioutil.ReadFile(s.CertFile)
+	if err != nil {
+		return nil, [sha256.Size]byte{}, fmt.Errorf("reading cert file: %s", err)
+	}
+	keyPEM, err := ioutil.ReadFile(s.KeyFile)
+	if err != nil {
+		return nil, [sha256.Size]byte{}, fmt.Errorf("reading key file: %s", err)
+	}
+	caPEM, err := ioutil.ReadFile(s.CACertFile)
+	if err != nil {
+		return nil, [sha256.Size]byte{}, fmt.Errorf("reading ca cert file: %s", err)
+	}
+
+	fingerprint := sha256.Sum256(bytes.Join([][]byte{certPEM, keyPEM, caPEM}, []byte{0}))
+	return caPEM, fingerprint, nil
+}
@@ -0,0 +1,226 @@
+package tlsreload_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"lib/tlsreload"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(name string) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+
+	return testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (ca testCA) issue(name string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+var _ = Describe("ServerConfig", func() {
+	var (
+		dir          string
+		certFile     string
+		keyFile      string
+		caFile       string
+		oldCA, newCA testCA
+		logger       *lagertest.TestLogger
+		fakeClock    *fakeclock.FakeClock
+		serverConfig *tlsreload.ServerConfig
+	)
+
+	writeIdentity := func(ca testCA, name string, trusted ...testCA) {
+		certPEM, keyPEM := ca.issue(name)
+		Expect(ioutil.WriteFile(certFile, certPEM, 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(keyFile, keyPEM, 0600)).To(Succeed())
+
+		var caPEM []byte
+		for _, t := range trusted {
+			caPEM = append(caPEM, t.pem...)
+		}
+		Expect(ioutil.WriteFile(caFile, caPEM, 0600)).To(Succeed())
+	}
+
+	currentConfig := func() *tls.Config {
+		c, err := serverConfig.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	servedName := func() string {
+		cert, err := x509.ParseCertificate(currentConfig().Certificates[0].Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		return cert.Subject.CommonName
+	}
+
+	trusts := func(ca testCA) bool {
+		clientCertPEM, _ := ca.issue("client")
+		block, _ := pem.Decode(clientCertPEM)
+		clientCert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = clientCert.Verify(x509.VerifyOptions{
+			Roots:     currentConfig().ClientCAs,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		return err == nil
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "tlsreload")
+		Expect(err).NotTo(HaveOccurred())
+		certFile = filepath.Join(dir, "server.crt")
+		keyFile = filepath.Join(dir, "server.key")
+		caFile = filepath.Join(dir, "ca.crt")
+
+		oldCA = newTestCA("old-ca")
+		newCA = newTestCA("new-ca")
+		writeIdentity(oldCA, "old-server", oldCA)
+
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		serverConfig, err = tlsreload.NewServerConfig(logger, fakeClock, certFile, keyFile, caFile, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("requires and verifies client certs", func() {
+		tlsConfig := serverConfig.TLSConfig()
+		Expect(tlsConfig.ClientAuth).To(Equal(tls.RequireAndVerifyClientCert))
+		Expect(tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS12)))
+
+		Expect(servedName()).To(Equal("old-server"))
+		Expect(trusts(oldCA)).To(BeTrue())
+		Expect(trusts(newCA)).To(BeFalse())
+	})
+
+	It("serves the new identity to new connections once the files change", func() {
+		tlsConfig := serverConfig.TLSConfig()
+		writeIdentity(newCA, "new-server", newCA)
+
+		Expect(serverConfig.ReloadIfChanged()).To(Succeed())
+
+		c, err := tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(c.Certificates[0].Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.Subject.CommonName).To(Equal("new-server"))
+		Expect(logger).To(gbytes.Say("loaded-tls-config"))
+	})
+
+	It("keeps trusting the replaced CAs during the overlap", func() {
+		writeIdentity(newCA, "new-server", newCA)
+		Expect(serverConfig.ReloadIfChanged()).To(Succeed())
+
+		Expect(trusts(newCA)).To(BeTrue())
+		Expect(trusts(oldCA)).To(BeTrue())
+
+		fakeClock.Increment(time.Hour)
+		Expect(trusts(newCA)).To(BeTrue())
+		Expect(trusts(oldCA)).To(BeFalse())
+	})
+
+	It("trusts every CA in the CA file", func() {
+		writeIdentity(oldCA, "old-server", oldCA, newCA)
+		Expect(serverConfig.ReloadIfChanged()).To(Succeed())
+
+		Expect(trusts(oldCA)).To(BeTrue())
+		Expect(trusts(newCA)).To(BeTrue())
+	})
+
+	It("does nothing when the files have not changed", func() {
+		Expect(serverConfig.ReloadIfChanged()).To(Succeed())
+		Expect(logger.LogMessages()).To(HaveLen(1))
+	})
+
+	Context("when the new cert does not match the key", func() {
+		BeforeEach(func() {
+			certPEM, _ := newCA.issue("new-server")
+			Expect(ioutil.WriteFile(certFile, certPEM, 0600)).To(Succeed())
+		})
+
+		It("keeps serving the current identity", func() {
+			err := serverConfig.ReloadIfChanged()
+			Expect(err).To(MatchError(HavePrefix("loading tls config: unable to load cert or key")))
+			Expect(servedName()).To(Equal("old-server"))
+		})
+	})
+
+	Context("when a file cannot be read", func() {
+		BeforeEach(func() {
+			Expect(os.Remove(keyFile)).To(Succeed())
+		})
+
+		It("returns a meaningful error", func() {
+			err := serverConfig.ReloadIfChanged()
+			Expect(err).To(MatchError(HavePrefix("reading key file:")))
+		})
+
+		It("fails to construct", func() {
+			_, err := tlsreload.NewServerConfig(logger, fakeClock, certFile, keyFile, caFile, time.Hour)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"fmt"
+	"policy-server/asg"
+	"policy-server/psclient"
+	"strconv"
+)
+
+func (c *CLI) importASGs(args []string) error {
+	var dryRun bool
+	flags := newFlagSet("asgs import")
+	flags.BoolVar(&dryRun, "dry-run", false, "report what would be imported without creating anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := c.Client.ImportASGs(dryRun)
+	if err != nil {
+		return fmt.Errorf("import asgs: %s", err)
+	}
+	if c.Output == OutputJSON {
+		return c.printJSON(report)
+	}
+
+	w := c.tableWriter()
+	fmt.Fprintln(w, "DESTINATION\tACTION\tSECURITY GROUP\tPROTOCOL\tIPS\tPORTS")
+	for _, destination := range report.Destinations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			destination.Name,
+			destination.Action,
+			destination.SecurityGroup,
+			destination.Protocol,
+			formatIPRange(psclient.IPRange{Start: destination.StartIP, End: destination.EndIP}),
+			formatASGPorts(destination),
+		)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "SOURCE\tLIFECYCLE\tDESTINATION\tACTION")
+	for _, policy := range report.EgressPolicies {
+		source := policy.SpaceGUID
+		if policy.SourceType == "default" {
+			source = "default"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", source, policy.AppLifecycle, policy.DestinationName, policy.Action)
+	}
+	if len(report.Skipped) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "SKIPPED\tRULE\tREASON")
+		for _, skipped := range report.Skipped {
+			rule := "-"
+			if skipped.Rule != nil {
+				rule = strconv.Itoa(*skipped.Rule)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", skipped.SecurityGroup, rule, skipped.Reason)
+		}
+	}
+	return w.Flush()
+}
+
+func formatASGPorts(destination asg.DestinationChange) string {
+	if destination.StartPort == 0 && destination.EndPort == 0 {
+		return "-"
+	}
+	return formatPorts(destination.StartPort, destination.EndPort)
+}
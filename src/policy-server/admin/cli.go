@@ -0,0 +1,227 @@
+package admin
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"policy-server/api"
+	"policy-server/asg"
+	"policy-server/psclient"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+//go:generate counterfeiter -o fakes/policy_client.go --fake-name PolicyClient . policyClient
+type policyClient interface {
+	ListPolicies(psclient.PolicyFilter) ([]api.Policy, error)
+	CreatePolicies([]api.Policy) error
+	DeletePolicies([]api.Policy) error
+	ListEgressPolicies() (psclient.EgressPolicyList, error)
+	ListDestinations() ([]psclient.Destination, error)
+	CreateDestinations([]psclient.Destination) ([]psclient.Destination, error)
+	DeleteDestination(string) (psclient.Destination, error)
+	ListDestinationOverlaps() ([]psclient.DestinationOverlap, error)
+	ListTags() ([]api.Tag, error)
+	Cleanup(dryRun bool) (api.PolicyCollectionPayload, error)
+	ImportASGs(dryRun bool) (asg.Report, error)
+	EffectiveEgress(appGUID string) (api.EffectiveEgressPayload, error)
+}
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+const Usage = `usage: cfnet-admin [-config <path>] [-output table|json] <command> [flags]
+
+commands:
+  policies list [-id <guid>] [-source-id <guid>] [-dest-id <guid>]
+                              list c2c policies, optionally filtered by app
+  policies create -source <guid> -dest <guid> -protocol tcp|udp -ports <port>[-<port>]
+                              allow traffic from one app to another
+  policies delete -source <guid> -dest <guid> -protocol tcp|udp -ports <port>[-<port>]
+                              remove a c2c policy
+  destinations list           list egress destinations
+  destinations create -name <name> -protocol tcp|udp|icmp|all -ips <ip>[-<ip>]|<cidr>
+                      [-ports <port>[-<port>]] [-icmp-type <n>] [-icmp-code <n>] [-description <text>]
+                              create an egress destination
+  destinations delete <guid>  delete an egress destination that no egress policy uses
+  destinations overlaps       list destinations that reach a forbidden range or overlap another
+  tags list                   list the tags assigned to apps and spaces
+  cleanup [-dry-run]          delete policies for apps that no longer exist
+  asgs import [-dry-run]      create egress destinations and policies from the
+                              application security groups bound to spaces
+  reachability -source <guid> (-dest <guid> | -ip <ip>) -protocol tcp|udp -port <port>
+                              show the policies, if any, that allow the traffic
+  export                      print all policies, egress policies, destinations and tags as JSON
+`
+
+// CLI runs one command against the policy server and prints its result to
+// Out as a table or as JSON.
+type CLI struct {
+	Client policyClient
+	Out    io.Writer
+	Output string
+}
+
+type command func(c *CLI, args []string) error
+
+var commands = map[string]command{
+	"policies list":         (*CLI).listPolicies,
+	"policies create":       (*CLI).createPolicy,
+	"policies delete":       (*CLI).deletePolicy,
+	"destinations list":     (*CLI).listDestinations,
+	"destinations create":   (*CLI).createDestination,
+	"destinations delete":   (*CLI).deleteDestination,
+	"destinations overlaps": (*CLI).listDestinationOverlaps,
+	"tags list":             (*CLI).listTags,
+	"cleanup":               (*CLI).cleanup,
+	"asgs import":           (*CLI).importASGs,
+	"reachability":          (*CLI).reachability,
+	"export":                (*CLI).export,
+}
+
+// Run looks up the command named by the first one or two arguments and runs
+// it with the rest.
+func (c *CLI) Run(args []string) error {
+	if c.Output != OutputTable && c.Output != OutputJSON {
+		return fmt.Errorf("unknown output format %q, must be %s or %s", c.Output, OutputTable, OutputJSON)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("no command given\n%s", Usage)
+	}
+	if cmd, ok := commands[args[0]]; ok {
+		return cmd(c, args[1:])
+	}
+	if len(args) > 1 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd(c, args[2:])
+		}
+	}
+	return fmt.Errorf("unknown command %q\n%s", strings.Join(args, " "), Usage)
+}
+
+func (c *CLI) export(args []string) error {
+	flags := newFlagSet("export")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	policies, err := c.Client.ListPolicies(psclient.PolicyFilter{})
+	if err != nil {
+		return fmt.Errorf("list policies: %s", err)
+	}
+	egressPolicies, err := c.Client.ListEgressPolicies()
+	if err != nil {
+		return fmt.Errorf("list egress policies: %s", err)
+	}
+	destinations, err := c.Client.ListDestinations()
+	if err != nil {
+		return fmt.Errorf("list destinations: %s", err)
+	}
+	tags, err := c.Client.ListTags()
+	if err != nil {
+		return fmt.Errorf("list tags: %s", err)
+	}
+
+	return c.printJSON(struct {
+		Policies       []api.Policy            `json:"policies"`
+		EgressPolicies []psclient.EgressPolicy `json:"egress_policies"`
+		Destinations   []psclient.Destination  `json:"destinations"`
+		Tags           []api.Tag               `json:"tags"`
+	}{
+		Policies:       nonNilPolicies(policies),
+		EgressPolicies: egressPolicies.EgressPolicies,
+		Destinations:   destinations,
+		Tags:           tags,
+	})
+}
+
+func (c *CLI) listTags(args []string) error {
+	flags := newFlagSet("tags list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	tags, err := c.Client.ListTags()
+	if err != nil {
+		return fmt.Errorf("list tags: %s", err)
+	}
+	if c.Output == OutputJSON {
+		return c.printJSON(tags)
+	}
+
+	w := c.tableWriter()
+	fmt.Fprintln(w, "ID\tTYPE\tTAG")
+	for _, tag := range tags {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", tag.ID, tag.Type, tag.Tag)
+	}
+	return w.Flush()
+}
+
+func (c *CLI) printJSON(value interface{}) error {
+	valueBytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal output: %s", err)
+	}
+	_, err = fmt.Fprintln(c.Out, string(valueBytes))
+	return err
+}
+
+func (c *CLI) tableWriter() *tabwriter.Writer {
+	return tabwriter.NewWriter(c.Out, 0, 8, 2, ' ', 0)
+}
+
+// newFlagSet returns a flag set that reports parse errors instead of
+// printing them, so that they reach the caller like any other error.
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	return flags
+}
+
+func requireFlags(values map[string]string) error {
+	for name, value := range values {
+		if value == "" {
+			return fmt.Errorf("-%s is required", name)
+		}
+	}
+	return nil
+}
+
+// parsePorts reads a single port or a range such as 8080-8090.
+func parsePorts(value string) (int, int, error) {
+	parts := strings.SplitN(value, "-", 2)
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port %q", value)
+	}
+	end := start
+	if len(parts) == 2 {
+		end, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid port %q", value)
+		}
+	}
+	if start < 1 || end > 65535 || start > end {
+		return 0, 0, fmt.Errorf("invalid port range %q", value)
+	}
+	return start, end, nil
+}
+
+func formatPorts(start, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+func nonNilPolicies(policies []api.Policy) []api.Policy {
+	if policies == nil {
+		return []api.Policy{}
+	}
+	return policies
+}
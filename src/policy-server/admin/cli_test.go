@@ -0,0 +1,312 @@
+package admin_test
+
+import (
+	"bytes"
+	"errors"
+	"policy-server/admin"
+	"policy-server/admin/fakes"
+	"policy-server/api"
+	"policy-server/asg"
+	"policy-server/psclient"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CLI", func() {
+	var (
+		cli        *admin.CLI
+		fakeClient *fakes.PolicyClient
+		out        *bytes.Buffer
+		policies   []api.Policy
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.PolicyClient{}
+		out = &bytes.Buffer{}
+		cli = &admin.CLI{
+			Client: fakeClient,
+			Out:    out,
+			Output: admin.OutputTable,
+		}
+
+		policies = []api.Policy{{
+			Source: api.Source{ID: "app-a"},
+			Destination: api.Destination{
+				ID:       "app-b",
+				Protocol: "tcp",
+				Ports:    api.Ports{Start: 8080, End: 8090},
+			},
+		}}
+		fakeClient.ListPoliciesReturns(policies, nil)
+	})
+
+	Describe("policies list", func() {
+		It("lists policies as a table", func() {
+			Expect(cli.Run([]string{"policies", "list"})).To(Succeed())
+			Expect(fakeClient.ListPoliciesArgsForCall(0)).To(Equal(psclient.PolicyFilter{}))
+			Expect(out.String()).To(Equal(
+				"SOURCE  DESTINATION  PROTOCOL  PORTS\n" +
+					"app-a   app-b        tcp       8080-8090\n"))
+		})
+
+		It("filters by app", func() {
+			Expect(cli.Run([]string{"policies", "list", "-id", "app-a", "-source-id", "app-c", "-dest-id", "app-d"})).To(Succeed())
+			Expect(fakeClient.ListPoliciesArgsForCall(0)).To(Equal(psclient.PolicyFilter{
+				IDs:       []string{"app-a"},
+				SourceIDs: []string{"app-c"},
+				DestIDs:   []string{"app-d"},
+			}))
+		})
+
+		It("lists policies as json", func() {
+			cli.Output = admin.OutputJSON
+			Expect(cli.Run([]string{"policies", "list"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`[{
+				"source": {"id": "app-a"},
+				"destination": {"id": "app-b", "protocol": "tcp", "ports": {"start": 8080, "end": 8090}}
+			}]`))
+		})
+
+		Context("when listing fails", func() {
+			It("returns the error", func() {
+				fakeClient.ListPoliciesReturns(nil, errors.New("banana"))
+				Expect(cli.Run([]string{"policies", "list"})).To(MatchError("list policies: banana"))
+			})
+		})
+	})
+
+	Describe("policies create", func() {
+		It("creates the policy", func() {
+			Expect(cli.Run([]string{"policies", "create", "-source", "app-a", "-dest", "app-b", "-protocol", "udp", "-ports", "53"})).To(Succeed())
+			Expect(fakeClient.CreatePoliciesArgsForCall(0)).To(Equal([]api.Policy{{
+				Source: api.Source{ID: "app-a"},
+				Destination: api.Destination{
+					ID:       "app-b",
+					Protocol: "udp",
+					Ports:    api.Ports{Start: 53, End: 53},
+				},
+			}}))
+			Expect(out.String()).To(ContainSubstring("app-a   app-b        udp       53"))
+		})
+
+		It("requires a source, destination and ports", func() {
+			Expect(cli.Run([]string{"policies", "create", "-source", "app-a", "-dest", "app-b"})).To(MatchError("-ports is required"))
+			Expect(fakeClient.CreatePoliciesCallCount()).To(Equal(0))
+		})
+
+		It("rejects invalid port ranges", func() {
+			err := cli.Run([]string{"policies", "create", "-source", "a", "-dest", "b", "-ports", "9000-8000"})
+			Expect(err).To(MatchError(`invalid port range "9000-8000"`))
+			err = cli.Run([]string{"policies", "create", "-source", "a", "-dest", "b", "-ports", "http"})
+			Expect(err).To(MatchError(`invalid port "http"`))
+		})
+	})
+
+	Describe("policies delete", func() {
+		It("deletes the policy", func() {
+			Expect(cli.Run([]string{"policies", "delete", "-source", "app-a", "-dest", "app-b", "-ports", "8080-8090"})).To(Succeed())
+			Expect(fakeClient.DeletePoliciesArgsForCall(0)).To(Equal(policies))
+		})
+
+		Context("when deleting fails", func() {
+			It("returns the error", func() {
+				fakeClient.DeletePoliciesReturns(errors.New("banana"))
+				err := cli.Run([]string{"policies", "delete", "-source", "app-a", "-dest", "app-b", "-ports", "8080"})
+				Expect(err).To(MatchError("delete policy: banana"))
+			})
+		})
+	})
+
+	Describe("destinations", func() {
+		var destination psclient.Destination
+
+		BeforeEach(func() {
+			destination = psclient.Destination{
+				GUID:     "some-guid",
+				Name:     "dns",
+				Protocol: "udp",
+				IPs:      []psclient.IPRange{{Start: "10.0.0.1", End: "10.0.0.9"}},
+				Ports:    []psclient.Port{{Start: 53, End: 53}},
+			}
+			fakeClient.ListDestinationsReturns([]psclient.Destination{destination}, nil)
+			fakeClient.CreateDestinationsReturns([]psclient.Destination{destination}, nil)
+			fakeClient.DeleteDestinationReturns(destination, nil)
+		})
+
+		It("lists destinations", func() {
+			Expect(cli.Run([]string{"destinations", "list"})).To(Succeed())
+			Expect(out.String()).To(Equal(
+				"ID         NAME  PROTOCOL  IPS                PORTS  DESCRIPTION\n" +
+					"some-guid  dns   udp       10.0.0.1-10.0.0.9  53     -\n"))
+		})
+
+		It("creates a destination", func() {
+			Expect(cli.Run([]string{"destinations", "create", "-name", "dns", "-protocol", "udp", "-ips", "10.0.0.1-10.0.0.9", "-ports", "53"})).To(Succeed())
+			Expect(fakeClient.CreateDestinationsArgsForCall(0)).To(Equal([]psclient.Destination{{
+				Name:     "dns",
+				Protocol: "udp",
+				IPs:      []psclient.IPRange{{Start: "10.0.0.1", End: "10.0.0.9"}},
+				Ports:    []psclient.Port{{Start: 53, End: 53}},
+			}}))
+			Expect(out.String()).To(ContainSubstring("some-guid"))
+		})
+
+		It("creates an icmp destination", func() {
+			Expect(cli.Run([]string{"destinations", "create", "-name", "ping", "-protocol", "icmp", "-ips", "10.0.0.1", "-icmp-type", "8", "-icmp-code", "0"})).To(Succeed())
+			created := fakeClient.CreateDestinationsArgsForCall(0)[0]
+			Expect(created.IPs).To(Equal([]psclient.IPRange{{Start: "10.0.0.1", End: "10.0.0.1"}}))
+			Expect(*created.ICMPType).To(Equal(8))
+			Expect(*created.ICMPCode).To(Equal(0))
+		})
+
+		It("creates a destination from a cidr", func() {
+			Expect(cli.Run([]string{"destinations", "create", "-name", "v6", "-protocol", "tcp", "-ips", "2001:db8::/64"})).To(Succeed())
+			Expect(fakeClient.CreateDestinationsArgsForCall(0)[0].IPs).To(Equal([]psclient.IPRange{{CIDR: "2001:db8::/64"}}))
+		})
+
+		It("rejects invalid ips", func() {
+			err := cli.Run([]string{"destinations", "create", "-name", "dns", "-protocol", "udp", "-ips", "10.0.0.1-nope"})
+			Expect(err).To(MatchError(`invalid ip range "10.0.0.1-nope"`))
+		})
+
+		It("deletes a destination", func() {
+			cli.Output = admin.OutputJSON
+			Expect(cli.Run([]string{"destinations", "delete", "some-guid"})).To(Succeed())
+			Expect(fakeClient.DeleteDestinationArgsForCall(0)).To(Equal("some-guid"))
+			Expect(out.String()).To(MatchJSON(`[{
+				"id": "some-guid",
+				"name": "dns",
+				"protocol": "udp",
+				"ips": [{"start": "10.0.0.1", "end": "10.0.0.9"}],
+				"ports": [{"start": 53, "end": 53}]
+			}]`))
+		})
+
+		It("requires a guid to delete", func() {
+			Expect(cli.Run([]string{"destinations", "delete"})).To(MatchError("destinations delete takes exactly one destination guid"))
+		})
+
+		It("lists destination overlaps", func() {
+			fakeClient.ListDestinationOverlapsReturns([]psclient.DestinationOverlap{
+				{Duplicate: true, Destinations: []psclient.Destination{destination, {GUID: "other-guid", Name: "dns-copy"}}},
+				{ForbiddenCIDR: "10.255.0.0/16", Destinations: []psclient.Destination{destination}},
+			}, nil)
+			Expect(cli.Run([]string{"destinations", "overlaps"})).To(Succeed())
+			Expect(out.String()).To(Equal(
+				"DESTINATIONS                            DUPLICATE  FORBIDDEN CIDR\n" +
+					"dns (some-guid), dns-copy (other-guid)  yes        -\n" +
+					"dns (some-guid)                         no         10.255.0.0/16\n"))
+		})
+	})
+
+	Describe("tags list", func() {
+		It("lists tags", func() {
+			fakeClient.ListTagsReturns([]api.Tag{{ID: "app-a", Tag: "0001", Type: "app"}}, nil)
+			Expect(cli.Run([]string{"tags", "list"})).To(Succeed())
+			Expect(out.String()).To(Equal(
+				"ID     TYPE  TAG\n" +
+					"app-a  app   0001\n"))
+		})
+	})
+
+	Describe("cleanup", func() {
+		BeforeEach(func() {
+			fakeClient.CleanupReturns(api.PolicyCollectionPayload{TotalPolicies: 1, Policies: policies}, nil)
+		})
+
+		It("prints the policies cleaned up", func() {
+			Expect(cli.Run([]string{"cleanup"})).To(Succeed())
+			Expect(fakeClient.CleanupArgsForCall(0)).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring("app-a   app-b"))
+		})
+
+		It("passes dry run through", func() {
+			Expect(cli.Run([]string{"cleanup", "-dry-run"})).To(Succeed())
+			Expect(fakeClient.CleanupArgsForCall(0)).To(BeTrue())
+		})
+	})
+
+	Describe("asgs import", func() {
+		BeforeEach(func() {
+			rule := 1
+			fakeClient.ImportASGsReturns(asg.Report{
+				Destinations: []asg.DestinationChange{{
+					Action:        asg.ActionCreate,
+					Name:          "asg-dns-1",
+					SecurityGroup: "dns",
+					Protocol:      "udp",
+					StartIP:       "10.0.0.2",
+					EndIP:         "10.0.0.2",
+					StartPort:     53,
+					EndPort:       53,
+				}},
+				EgressPolicies: []asg.PolicyChange{
+					{Action: asg.ActionCreate, SourceType: "space", SpaceGUID: "space-1", AppLifecycle: "running", DestinationName: "asg-dns-1"},
+					{Action: asg.ActionCreate, SourceType: "default", AppLifecycle: "staging", DestinationName: "asg-dns-1"},
+				},
+				Skipped: []asg.Skipped{{SecurityGroup: "dns", Rule: &rule, Reason: `unsupported protocol "sctp"`}},
+			}, nil)
+		})
+
+		It("prints the report", func() {
+			Expect(cli.Run([]string{"asgs", "import", "-dry-run"})).To(Succeed())
+			Expect(fakeClient.ImportASGsArgsForCall(0)).To(BeTrue())
+			Expect(out.String()).To(ContainSubstring("asg-dns-1    create  dns             udp       10.0.0.2  53"))
+			Expect(out.String()).To(ContainSubstring("space-1  running    asg-dns-1    create"))
+			Expect(out.String()).To(ContainSubstring("default  staging    asg-dns-1    create"))
+			Expect(out.String()).To(ContainSubstring(`dns      1     unsupported protocol "sctp"`))
+		})
+
+		It("returns the error when the import fails", func() {
+			fakeClient.ImportASGsReturns(asg.Report{}, errors.New("banana"))
+			Expect(cli.Run([]string{"asgs", "import"})).To(MatchError("import asgs: banana"))
+		})
+	})
+
+	Describe("export", func() {
+		It("prints everything as json", func() {
+			fakeClient.ListEgressPoliciesReturns(psclient.EgressPolicyList{
+				EgressPolicies: []psclient.EgressPolicy{{
+					GUID:        "egress-guid",
+					Source:      psclient.EgressPolicySource{ID: "app-a"},
+					Destination: psclient.EgressPolicyDestination{ID: "dest-guid"},
+				}},
+			}, nil)
+			fakeClient.ListDestinationsReturns([]psclient.Destination{{GUID: "dest-guid", Protocol: "tcp", IPs: []psclient.IPRange{{Start: "1.1.1.1", End: "1.1.1.1"}}}}, nil)
+			fakeClient.ListTagsReturns([]api.Tag{{ID: "app-a", Tag: "0001", Type: "app"}}, nil)
+
+			Expect(cli.Run([]string{"export"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{
+				"policies": [{
+					"source": {"id": "app-a"},
+					"destination": {"id": "app-b", "protocol": "tcp", "ports": {"start": 8080, "end": 8090}}
+				}],
+				"egress_policies": [{"id": "egress-guid", "source": {"id": "app-a"}, "destination": {"id": "dest-guid"}}],
+				"destinations": [{"id": "dest-guid", "protocol": "tcp", "ips": [{"start": "1.1.1.1", "end": "1.1.1.1"}]}],
+				"tags": [{"id": "app-a", "tag": "0001", "type": "app"}]
+			}`))
+		})
+
+		Context("when listing tags fails", func() {
+			It("returns the error", func() {
+				fakeClient.ListTagsReturns(nil, errors.New("banana"))
+				Expect(cli.Run([]string{"export"})).To(MatchError("list tags: banana"))
+			})
+		})
+	})
+
+	It("rejects unknown commands", func() {
+		Expect(cli.Run([]string{"policies", "frobnicate"})).To(MatchError(HavePrefix(`unknown command "policies frobnicate"`)))
+		Expect(cli.Run(nil)).To(MatchError(HavePrefix("no command given")))
+	})
+
+	It("rejects unknown output formats", func() {
+		cli.Output = "yaml"
+		Expect(cli.Run([]string{"tags", "list"})).To(MatchError(`unknown output format "yaml", must be table or json`))
+	})
+
+	It("returns flag errors", func() {
+		Expect(cli.Run([]string{"cleanup", "-bogus"})).To(MatchError("flag provided but not defined: -bogus"))
+	})
+})
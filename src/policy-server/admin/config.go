@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+// Config holds the policy server to talk to and the credentials to use. A
+// token is used as is. Otherwise a token is fetched from UAA with the client
+// credentials, and fetched again when it expires.
+type Config struct {
+	APIURL            string `json:"api_url"`
+	Token             string `json:"token"`
+	UAAURL            string `json:"uaa_url"`
+	ClientID          string `json:"client_id"`
+	ClientSecret      string `json:"client_secret"`
+	CACertFile        string `json:"ca_cert_file"`
+	SkipSSLValidation bool   `json:"skip_ssl_validation"`
+}
+
+// Environment variables override the values in the config file.
+const (
+	EnvConfigFile        = "CFNET_ADMIN_CONFIG"
+	EnvAPIURL            = "CFNET_ADMIN_API_URL"
+	EnvToken             = "CFNET_ADMIN_TOKEN"
+	EnvUAAURL            = "CFNET_ADMIN_UAA_URL"
+	EnvClientID          = "CFNET_ADMIN_CLIENT_ID"
+	EnvClientSecret      = "CFNET_ADMIN_CLIENT_SECRET"
+	EnvCACertFile        = "CFNET_ADMIN_CA_CERT_FILE"
+	EnvSkipSSLValidation = "CFNET_ADMIN_SKIP_SSL_VALIDATION"
+)
+
+// LoadConfig reads the config file at path, if any, and then the environment.
+func LoadConfig(path string, getenv func(string) string) (Config, error) {
+	var conf Config
+	if path == "" {
+		path = getenv(EnvConfigFile)
+	}
+	if path != "" {
+		configBytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("reading config file: %s", err)
+		}
+		err = json.Unmarshal(configBytes, &conf)
+		if err != nil {
+			return Config{}, fmt.Errorf("parsing config file: %s", err)
+		}
+	}
+
+	for env, field := range map[string]*string{
+		EnvAPIURL:       &conf.APIURL,
+		EnvToken:        &conf.Token,
+		EnvUAAURL:       &conf.UAAURL,
+		EnvClientID:     &conf.ClientID,
+		EnvClientSecret: &conf.ClientSecret,
+		EnvCACertFile:   &conf.CACertFile,
+	} {
+		if value := getenv(env); value != "" {
+			*field = value
+		}
+	}
+	if value := getenv(EnvSkipSSLValidation); value != "" {
+		skip, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("parsing %s: %s", EnvSkipSSLValidation, err)
+		}
+		conf.SkipSSLValidation = skip
+	}
+
+	return conf, conf.validate()
+}
+
+func (c Config) validate() error {
+	if c.APIURL == "" {
+		return fmt.Errorf("api_url is required, set it in the config file or %s", EnvAPIURL)
+	}
+	if c.Token != "" {
+		return nil
+	}
+	if c.UAAURL == "" || c.ClientID == "" || c.ClientSecret == "" {
+		return fmt.Errorf("either a token or a uaa_url, client_id and client_secret are required")
+	}
+	return nil
+}
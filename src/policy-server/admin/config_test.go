@@ -0,0 +1,119 @@
+package admin_test
+
+import (
+	"io/ioutil"
+	"os"
+	"policy-server/admin"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadConfig", func() {
+	var (
+		configFile *os.File
+		env        map[string]string
+		getenv     func(string) string
+	)
+
+	BeforeEach(func() {
+		var err error
+		configFile, err = ioutil.TempFile("", "cfnet-admin-config")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = configFile.WriteString(`{
+			"api_url": "https://api.example.com",
+			"uaa_url": "https://uaa.example.com",
+			"client_id": "some-client",
+			"client_secret": "some-secret"
+		}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+
+		env = map[string]string{}
+		getenv = func(key string) string { return env[key] }
+	})
+
+	AfterEach(func() {
+		os.Remove(configFile.Name())
+	})
+
+	It("reads the config file", func() {
+		conf, err := admin.LoadConfig(configFile.Name(), getenv)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(Equal(admin.Config{
+			APIURL:       "https://api.example.com",
+			UAAURL:       "https://uaa.example.com",
+			ClientID:     "some-client",
+			ClientSecret: "some-secret",
+		}))
+	})
+
+	It("lets the environment override the config file", func() {
+		env[admin.EnvAPIURL] = "https://other-api.example.com"
+		env[admin.EnvToken] = "some-token"
+		env[admin.EnvSkipSSLValidation] = "true"
+
+		conf, err := admin.LoadConfig(configFile.Name(), getenv)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.APIURL).To(Equal("https://other-api.example.com"))
+		Expect(conf.Token).To(Equal("some-token"))
+		Expect(conf.SkipSSLValidation).To(BeTrue())
+		Expect(conf.ClientID).To(Equal("some-client"))
+	})
+
+	It("reads the config file named by the environment", func() {
+		env[admin.EnvConfigFile] = configFile.Name()
+
+		conf, err := admin.LoadConfig("", getenv)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.APIURL).To(Equal("https://api.example.com"))
+	})
+
+	It("needs no config file when the environment has everything", func() {
+		env[admin.EnvAPIURL] = "https://api.example.com"
+		env[admin.EnvToken] = "some-token"
+
+		conf, err := admin.LoadConfig("", getenv)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Token).To(Equal("some-token"))
+	})
+
+	Context("when the api url is missing", func() {
+		It("returns an error", func() {
+			env[admin.EnvToken] = "some-token"
+			_, err := admin.LoadConfig("", getenv)
+			Expect(err).To(MatchError("api_url is required, set it in the config file or CFNET_ADMIN_API_URL"))
+		})
+	})
+
+	Context("when there are no credentials", func() {
+		It("returns an error", func() {
+			env[admin.EnvAPIURL] = "https://api.example.com"
+			_, err := admin.LoadConfig("", getenv)
+			Expect(err).To(MatchError("either a token or a uaa_url, client_id and client_secret are required"))
+		})
+	})
+
+	Context("when the config file does not exist", func() {
+		It("returns an error", func() {
+			_, err := admin.LoadConfig("/does/not/exist", getenv)
+			Expect(err).To(MatchError(ContainSubstring("reading config file:")))
+		})
+	})
+
+	Context("when the config file is not json", func() {
+		It("returns an error", func() {
+			Expect(ioutil.WriteFile(configFile.Name(), []byte("nope"), 0600)).To(Succeed())
+			_, err := admin.LoadConfig(configFile.Name(), getenv)
+			Expect(err).To(MatchError(ContainSubstring("parsing config file:")))
+		})
+	})
+
+	Context("when skip ssl validation is not a bool", func() {
+		It("returns an error", func() {
+			env[admin.EnvSkipSSLValidation] = "maybe"
+			_, err := admin.LoadConfig(configFile.Name(), getenv)
+			Expect(err).To(MatchError(ContainSubstring("parsing CFNET_ADMIN_SKIP_SSL_VALIDATION:")))
+		})
+	})
+})
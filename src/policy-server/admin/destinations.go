@@ -0,0 +1,187 @@
+package admin
+
+import (
+	"fmt"
+	"net"
+	"policy-server/psclient"
+	"strings"
+)
+
+func (c *CLI) listDestinations(args []string) error {
+	flags := newFlagSet("destinations list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	destinations, err := c.Client.ListDestinations()
+	if err != nil {
+		return fmt.Errorf("list destinations: %s", err)
+	}
+	return c.printDestinations(destinations)
+}
+
+func (c *CLI) createDestination(args []string) error {
+	var name, description, protocol, ips, ports string
+	var icmpType, icmpCode int
+	flags := newFlagSet("destinations create")
+	flags.StringVar(&name, "name", "", "destination name")
+	flags.StringVar(&description, "description", "", "destination description")
+	flags.StringVar(&protocol, "protocol", "", "tcp, udp, icmp or all")
+	flags.StringVar(&ips, "ips", "", "ip, ip range or cidr, such as 10.0.0.1-10.0.0.9 or 2001:db8::/64")
+	flags.StringVar(&ports, "ports", "", "port or port range, for tcp and udp")
+	flags.IntVar(&icmpType, "icmp-type", -1, "icmp type, for icmp")
+	flags.IntVar(&icmpCode, "icmp-code", -1, "icmp code, for icmp")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	err := requireFlags(map[string]string{"name": name, "protocol": protocol, "ips": ips})
+	if err != nil {
+		return err
+	}
+
+	destination := psclient.Destination{
+		Name:        name,
+		Description: description,
+		Protocol:    protocol,
+	}
+	ipRange, err := parseIPRange(ips)
+	if err != nil {
+		return err
+	}
+	destination.IPs = []psclient.IPRange{ipRange}
+	if ports != "" {
+		start, end, err := parsePorts(ports)
+		if err != nil {
+			return err
+		}
+		destination.Ports = []psclient.Port{{Start: start, End: end}}
+	}
+	if icmpType >= 0 {
+		destination.ICMPType = &icmpType
+	}
+	if icmpCode >= 0 {
+		destination.ICMPCode = &icmpCode
+	}
+
+	created, err := c.Client.CreateDestinations([]psclient.Destination{destination})
+	if err != nil {
+		return fmt.Errorf("create destination: %s", err)
+	}
+	return c.printDestinations(created)
+}
+
+func (c *CLI) deleteDestination(args []string) error {
+	flags := newFlagSet("destinations delete")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("destinations delete takes exactly one destination guid")
+	}
+
+	deleted, err := c.Client.DeleteDestination(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("delete destination: %s", err)
+	}
+	return c.printDestinations([]psclient.Destination{deleted})
+}
+
+func (c *CLI) listDestinationOverlaps(args []string) error {
+	flags := newFlagSet("destinations overlaps")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	overlaps, err := c.Client.ListDestinationOverlaps()
+	if err != nil {
+		return fmt.Errorf("list destination overlaps: %s", err)
+	}
+	if c.Output == OutputJSON {
+		if overlaps == nil {
+			overlaps = []psclient.DestinationOverlap{}
+		}
+		return c.printJSON(overlaps)
+	}
+
+	w := c.tableWriter()
+	fmt.Fprintln(w, "DESTINATIONS\tDUPLICATE\tFORBIDDEN CIDR")
+	for _, overlap := range overlaps {
+		var names []string
+		for _, destination := range overlap.Destinations {
+			names = append(names, destination.Name+" ("+destination.GUID+")")
+		}
+		duplicate := "no"
+		if overlap.Duplicate {
+			duplicate = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.Join(names, ", "), duplicate, orDash(overlap.ForbiddenCIDR))
+	}
+	return w.Flush()
+}
+
+func (c *CLI) printDestinations(destinations []psclient.Destination) error {
+	if c.Output == OutputJSON {
+		if destinations == nil {
+			destinations = []psclient.Destination{}
+		}
+		return c.printJSON(destinations)
+	}
+
+	w := c.tableWriter()
+	fmt.Fprintln(w, "ID\tNAME\tPROTOCOL\tIPS\tPORTS\tDESCRIPTION")
+	for _, destination := range destinations {
+		var ips, ports []string
+		for _, ipRange := range destination.IPs {
+			ips = append(ips, formatIPRange(ipRange))
+		}
+		for _, port := range destination.Ports {
+			ports = append(ports, formatPorts(port.Start, port.End))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			destination.GUID,
+			destination.Name,
+			destination.Protocol,
+			strings.Join(ips, ","),
+			orDash(strings.Join(ports, ",")),
+			orDash(destination.Description),
+		)
+	}
+	return w.Flush()
+}
+
+// parseIPRange reads a single ip, a range such as 10.0.0.1-10.0.0.9 or a
+// CIDR such as 2001:db8::/64.
+func parseIPRange(value string) (psclient.IPRange, error) {
+	if strings.Contains(value, "/") {
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return psclient.IPRange{}, fmt.Errorf("invalid cidr %q", value)
+		}
+		return psclient.IPRange{CIDR: value}, nil
+	}
+	parts := strings.SplitN(value, "-", 2)
+	ipRange := psclient.IPRange{Start: parts[0], End: parts[0]}
+	if len(parts) == 2 {
+		ipRange.End = parts[1]
+	}
+	if net.ParseIP(ipRange.Start) == nil || net.ParseIP(ipRange.End) == nil {
+		return psclient.IPRange{}, fmt.Errorf("invalid ip range %q", value)
+	}
+	return ipRange, nil
+}
+
+func formatIPRange(ipRange psclient.IPRange) string {
+	if ipRange.CIDR != "" {
+		return ipRange.CIDR
+	}
+	if ipRange.Start == ipRange.End {
+		return ipRange.Start
+	}
+	return ipRange.Start + "-" + ipRange.End
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
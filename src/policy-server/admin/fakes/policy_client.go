@@ -0,0 +1,953 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/api"
+	"policy-server/asg"
+	"policy-server/psclient"
+	"sync"
+)
+
+type PolicyClient struct {
+	CleanupStub        func(bool) (api.PolicyCollectionPayload, error)
+	cleanupMutex       sync.RWMutex
+	cleanupArgsForCall []struct {
+		arg1 bool
+	}
+	cleanupReturns struct {
+		result1 api.PolicyCollectionPayload
+		result2 error
+	}
+	cleanupReturnsOnCall map[int]struct {
+		result1 api.PolicyCollectionPayload
+		result2 error
+	}
+	CreateDestinationsStub        func([]psclient.Destination) ([]psclient.Destination, error)
+	createDestinationsMutex       sync.RWMutex
+	createDestinationsArgsForCall []struct {
+		arg1 []psclient.Destination
+	}
+	createDestinationsReturns struct {
+		result1 []psclient.Destination
+		result2 error
+	}
+	createDestinationsReturnsOnCall map[int]struct {
+		result1 []psclient.Destination
+		result2 error
+	}
+	CreatePoliciesStub        func([]api.Policy) error
+	createPoliciesMutex       sync.RWMutex
+	createPoliciesArgsForCall []struct {
+		arg1 []api.Policy
+	}
+	createPoliciesReturns struct {
+		result1 error
+	}
+	createPoliciesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteDestinationStub        func(string) (psclient.Destination, error)
+	deleteDestinationMutex       sync.RWMutex
+	deleteDestinationArgsForCall []struct {
+		arg1 string
+	}
+	deleteDestinationReturns struct {
+		result1 psclient.Destination
+		result2 error
+	}
+	deleteDestinationReturnsOnCall map[int]struct {
+		result1 psclient.Destination
+		result2 error
+	}
+	DeletePoliciesStub        func([]api.Policy) error
+	deletePoliciesMutex       sync.RWMutex
+	deletePoliciesArgsForCall []struct {
+		arg1 []api.Policy
+	}
+	deletePoliciesReturns struct {
+		result1 error
+	}
+	deletePoliciesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EffectiveEgressStub        func(string) (api.EffectiveEgressPayload, error)
+	effectiveEgressMutex       sync.RWMutex
+	effectiveEgressArgsForCall []struct {
+		arg1 string
+	}
+	effectiveEgressReturns struct {
+		result1 api.EffectiveEgressPayload
+		result2 error
+	}
+	effectiveEgressReturnsOnCall map[int]struct {
+		result1 api.EffectiveEgressPayload
+		result2 error
+	}
+	ImportASGsStub        func(bool) (asg.Report, error)
+	importASGsMutex       sync.RWMutex
+	importASGsArgsForCall []struct {
+		arg1 bool
+	}
+	importASGsReturns struct {
+		result1 asg.Report
+		result2 error
+	}
+	importASGsReturnsOnCall map[int]struct {
+		result1 asg.Report
+		result2 error
+	}
+	ListDestinationOverlapsStub        func() ([]psclient.DestinationOverlap, error)
+	listDestinationOverlapsMutex       sync.RWMutex
+	listDestinationOverlapsArgsForCall []struct {
+	}
+	listDestinationOverlapsReturns struct {
+		result1 []psclient.DestinationOverlap
+		result2 error
+	}
+	listDestinationOverlapsReturnsOnCall map[int]struct {
+		result1 []psclient.DestinationOverlap
+		result2 error
+	}
+	ListDestinationsStub        func() ([]psclient.Destination, error)
+	listDestinationsMutex       sync.RWMutex
+	listDestinationsArgsForCall []struct {
+	}
+	listDestinationsReturns struct {
+		result1 []psclient.Destination
+		result2 error
+	}
+	listDestinationsReturnsOnCall map[int]struct {
+		result1 []psclient.Destination
+		result2 error
+	}
+	ListEgressPoliciesStub        func() (psclient.EgressPolicyList, error)
+	listEgressPoliciesMutex       sync.RWMutex
+	listEgressPoliciesArgsForCall []struct {
+	}
+	listEgressPoliciesReturns struct {
+		result1 psclient.EgressPolicyList
+		result2 error
+	}
+	listEgressPoliciesReturnsOnCall map[int]struct {
+		result1 psclient.EgressPolicyList
+		result2 error
+	}
+	ListPoliciesStub        func(psclient.PolicyFilter) ([]api.Policy, error)
+	listPoliciesMutex       sync.RWMutex
+	listPoliciesArgsForCall []struct {
+		arg1 psclient.PolicyFilter
+	}
+	listPoliciesReturns struct {
+		result1 []api.Policy
+		result2 error
+	}
+	listPoliciesReturnsOnCall map[int]struct {
+		result1 []api.Policy
+		result2 error
+	}
+	ListTagsStub        func() ([]api.Tag, error)
+	listTagsMutex       sync.RWMutex
+	listTagsArgsForCall []struct {
+	}
+	listTagsReturns struct {
+		result1 []api.Tag
+		result2 error
+	}
+	listTagsReturnsOnCall map[int]struct {
+		result1 []api.Tag
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PolicyClient) Cleanup(arg1 bool) (api.PolicyCollectionPayload, error) {
+	fake.cleanupMutex.Lock()
+	ret, specificReturn := fake.cleanupReturnsOnCall[len(fake.cleanupArgsForCall)]
+	fake.cleanupArgsForCall = append(fake.cleanupArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.CleanupStub
+	fakeReturns := fake.cleanupReturns
+	fake.recordInvocation("Cleanup", []interface{}{arg1})
+	fake.cleanupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) CleanupCallCount() int {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	return len(fake.cleanupArgsForCall)
+}
+
+func (fake *PolicyClient) CleanupCalls(stub func(bool) (api.PolicyCollectionPayload, error)) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = stub
+}
+
+func (fake *PolicyClient) CleanupArgsForCall(i int) bool {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	argsForCall := fake.cleanupArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) CleanupReturns(result1 api.PolicyCollectionPayload, result2 error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = nil
+	fake.cleanupReturns = struct {
+		result1 api.PolicyCollectionPayload
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) CleanupReturnsOnCall(i int, result1 api.PolicyCollectionPayload, result2 error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = nil
+	if fake.cleanupReturnsOnCall == nil {
+		fake.cleanupReturnsOnCall = make(map[int]struct {
+			result1 api.PolicyCollectionPayload
+			result2 error
+		})
+	}
+	fake.cleanupReturnsOnCall[i] = struct {
+		result1 api.PolicyCollectionPayload
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) CreateDestinations(arg1 []psclient.Destination) ([]psclient.Destination, error) {
+	var arg1Copy []psclient.Destination
+	if arg1 != nil {
+		arg1Copy = make([]psclient.Destination, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createDestinationsMutex.Lock()
+	ret, specificReturn := fake.createDestinationsReturnsOnCall[len(fake.createDestinationsArgsForCall)]
+	fake.createDestinationsArgsForCall = append(fake.createDestinationsArgsForCall, struct {
+		arg1 []psclient.Destination
+	}{arg1Copy})
+	stub := fake.CreateDestinationsStub
+	fakeReturns := fake.createDestinationsReturns
+	fake.recordInvocation("CreateDestinations", []interface{}{arg1Copy})
+	fake.createDestinationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) CreateDestinationsCallCount() int {
+	fake.createDestinationsMutex.RLock()
+	defer fake.createDestinationsMutex.RUnlock()
+	return len(fake.createDestinationsArgsForCall)
+}
+
+func (fake *PolicyClient) CreateDestinationsCalls(stub func([]psclient.Destination) ([]psclient.Destination, error)) {
+	fake.createDestinationsMutex.Lock()
+	defer fake.createDestinationsMutex.Unlock()
+	fake.CreateDestinationsStub = stub
+}
+
+func (fake *PolicyClient) CreateDestinationsArgsForCall(i int) []psclient.Destination {
+	fake.createDestinationsMutex.RLock()
+	defer fake.createDestinationsMutex.RUnlock()
+	argsForCall := fake.createDestinationsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) CreateDestinationsReturns(result1 []psclient.Destination, result2 error) {
+	fake.createDestinationsMutex.Lock()
+	defer fake.createDestinationsMutex.Unlock()
+	fake.CreateDestinationsStub = nil
+	fake.createDestinationsReturns = struct {
+		result1 []psclient.Destination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) CreateDestinationsReturnsOnCall(i int, result1 []psclient.Destination, result2 error) {
+	fake.createDestinationsMutex.Lock()
+	defer fake.createDestinationsMutex.Unlock()
+	fake.CreateDestinationsStub = nil
+	if fake.createDestinationsReturnsOnCall == nil {
+		fake.createDestinationsReturnsOnCall = make(map[int]struct {
+			result1 []psclient.Destination
+			result2 error
+		})
+	}
+	fake.createDestinationsReturnsOnCall[i] = struct {
+		result1 []psclient.Destination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) CreatePolicies(arg1 []api.Policy) error {
+	var arg1Copy []api.Policy
+	if arg1 != nil {
+		arg1Copy = make([]api.Policy, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createPoliciesMutex.Lock()
+	ret, specificReturn := fake.createPoliciesReturnsOnCall[len(fake.createPoliciesArgsForCall)]
+	fake.createPoliciesArgsForCall = append(fake.createPoliciesArgsForCall, struct {
+		arg1 []api.Policy
+	}{arg1Copy})
+	stub := fake.CreatePoliciesStub
+	fakeReturns := fake.createPoliciesReturns
+	fake.recordInvocation("CreatePolicies", []interface{}{arg1Copy})
+	fake.createPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PolicyClient) CreatePoliciesCallCount() int {
+	fake.createPoliciesMutex.RLock()
+	defer fake.createPoliciesMutex.RUnlock()
+	return len(fake.createPoliciesArgsForCall)
+}
+
+func (fake *PolicyClient) CreatePoliciesCalls(stub func([]api.Policy) error) {
+	fake.createPoliciesMutex.Lock()
+	defer fake.createPoliciesMutex.Unlock()
+	fake.CreatePoliciesStub = stub
+}
+
+func (fake *PolicyClient) CreatePoliciesArgsForCall(i int) []api.Policy {
+	fake.createPoliciesMutex.RLock()
+	defer fake.createPoliciesMutex.RUnlock()
+	argsForCall := fake.createPoliciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) CreatePoliciesReturns(result1 error) {
+	fake.createPoliciesMutex.Lock()
+	defer fake.createPoliciesMutex.Unlock()
+	fake.CreatePoliciesStub = nil
+	fake.createPoliciesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyClient) CreatePoliciesReturnsOnCall(i int, result1 error) {
+	fake.createPoliciesMutex.Lock()
+	defer fake.createPoliciesMutex.Unlock()
+	fake.CreatePoliciesStub = nil
+	if fake.createPoliciesReturnsOnCall == nil {
+		fake.createPoliciesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createPoliciesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyClient) DeleteDestination(arg1 string) (psclient.Destination, error) {
+	fake.deleteDestinationMutex.Lock()
+	ret, specificReturn := fake.deleteDestinationReturnsOnCall[len(fake.deleteDestinationArgsForCall)]
+	fake.deleteDestinationArgsForCall = append(fake.deleteDestinationArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteDestinationStub
+	fakeReturns := fake.deleteDestinationReturns
+	fake.recordInvocation("DeleteDestination", []interface{}{arg1})
+	fake.deleteDestinationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) DeleteDestinationCallCount() int {
+	fake.deleteDestinationMutex.RLock()
+	defer fake.deleteDestinationMutex.RUnlock()
+	return len(fake.deleteDestinationArgsForCall)
+}
+
+func (fake *PolicyClient) DeleteDestinationCalls(stub func(string) (psclient.Destination, error)) {
+	fake.deleteDestinationMutex.Lock()
+	defer fake.deleteDestinationMutex.Unlock()
+	fake.DeleteDestinationStub = stub
+}
+
+func (fake *PolicyClient) DeleteDestinationArgsForCall(i int) string {
+	fake.deleteDestinationMutex.RLock()
+	defer fake.deleteDestinationMutex.RUnlock()
+	argsForCall := fake.deleteDestinationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) DeleteDestinationReturns(result1 psclient.Destination, result2 error) {
+	fake.deleteDestinationMutex.Lock()
+	defer fake.deleteDestinationMutex.Unlock()
+	fake.DeleteDestinationStub = nil
+	fake.deleteDestinationReturns = struct {
+		result1 psclient.Destination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) DeleteDestinationReturnsOnCall(i int, result1 psclient.Destination, result2 error) {
+	fake.deleteDestinationMutex.Lock()
+	defer fake.deleteDestinationMutex.Unlock()
+	fake.DeleteDestinationStub = nil
+	if fake.deleteDestinationReturnsOnCall == nil {
+		fake.deleteDestinationReturnsOnCall = make(map[int]struct {
+			result1 psclient.Destination
+			result2 error
+		})
+	}
+	fake.deleteDestinationReturnsOnCall[i] = struct {
+		result1 psclient.Destination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) DeletePolicies(arg1 []api.Policy) error {
+	var arg1Copy []api.Policy
+	if arg1 != nil {
+		arg1Copy = make([]api.Policy, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.deletePoliciesMutex.Lock()
+	ret, specificReturn := fake.deletePoliciesReturnsOnCall[len(fake.deletePoliciesArgsForCall)]
+	fake.deletePoliciesArgsForCall = append(fake.deletePoliciesArgsForCall, struct {
+		arg1 []api.Policy
+	}{arg1Copy})
+	stub := fake.DeletePoliciesStub
+	fakeReturns := fake.deletePoliciesReturns
+	fake.recordInvocation("DeletePolicies", []interface{}{arg1Copy})
+	fake.deletePoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PolicyClient) DeletePoliciesCallCount() int {
+	fake.deletePoliciesMutex.RLock()
+	defer fake.deletePoliciesMutex.RUnlock()
+	return len(fake.deletePoliciesArgsForCall)
+}
+
+func (fake *PolicyClient) DeletePoliciesCalls(stub func([]api.Policy) error) {
+	fake.deletePoliciesMutex.Lock()
+	defer fake.deletePoliciesMutex.Unlock()
+	fake.DeletePoliciesStub = stub
+}
+
+func (fake *PolicyClient) DeletePoliciesArgsForCall(i int) []api.Policy {
+	fake.deletePoliciesMutex.RLock()
+	defer fake.deletePoliciesMutex.RUnlock()
+	argsForCall := fake.deletePoliciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) DeletePoliciesReturns(result1 error) {
+	fake.deletePoliciesMutex.Lock()
+	defer fake.deletePoliciesMutex.Unlock()
+	fake.DeletePoliciesStub = nil
+	fake.deletePoliciesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyClient) DeletePoliciesReturnsOnCall(i int, result1 error) {
+	fake.deletePoliciesMutex.Lock()
+	defer fake.deletePoliciesMutex.Unlock()
+	fake.DeletePoliciesStub = nil
+	if fake.deletePoliciesReturnsOnCall == nil {
+		fake.deletePoliciesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePoliciesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyClient) EffectiveEgress(arg1 string) (api.EffectiveEgressPayload, error) {
+	fake.effectiveEgressMutex.Lock()
+	ret, specificReturn := fake.effectiveEgressReturnsOnCall[len(fake.effectiveEgressArgsForCall)]
+	fake.effectiveEgressArgsForCall = append(fake.effectiveEgressArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.EffectiveEgressStub
+	fakeReturns := fake.effectiveEgressReturns
+	fake.recordInvocation("EffectiveEgress", []interface{}{arg1})
+	fake.effectiveEgressMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) EffectiveEgressCallCount() int {
+	fake.effectiveEgressMutex.RLock()
+	defer fake.effectiveEgressMutex.RUnlock()
+	return len(fake.effectiveEgressArgsForCall)
+}
+
+func (fake *PolicyClient) EffectiveEgressCalls(stub func(string) (api.EffectiveEgressPayload, error)) {
+	fake.effectiveEgressMutex.Lock()
+	defer fake.effectiveEgressMutex.Unlock()
+	fake.EffectiveEgressStub = stub
+}
+
+func (fake *PolicyClient) EffectiveEgressArgsForCall(i int) string {
+	fake.effectiveEgressMutex.RLock()
+	defer fake.effectiveEgressMutex.RUnlock()
+	argsForCall := fake.effectiveEgressArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) EffectiveEgressReturns(result1 api.EffectiveEgressPayload, result2 error) {
+	fake.effectiveEgressMutex.Lock()
+	defer fake.effectiveEgressMutex.Unlock()
+	fake.EffectiveEgressStub = nil
+	fake.effectiveEgressReturns = struct {
+		result1 api.EffectiveEgressPayload
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) EffectiveEgressReturnsOnCall(i int, result1 api.EffectiveEgressPayload, result2 error) {
+	fake.effectiveEgressMutex.Lock()
+	defer fake.effectiveEgressMutex.Unlock()
+	fake.EffectiveEgressStub = nil
+	if fake.effectiveEgressReturnsOnCall == nil {
+		fake.effectiveEgressReturnsOnCall = make(map[int]struct {
+			result1 api.EffectiveEgressPayload
+			result2 error
+		})
+	}
+	fake.effectiveEgressReturnsOnCall[i] = struct {
+		result1 api.EffectiveEgressPayload
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ImportASGs(arg1 bool) (asg.Report, error) {
+	fake.importASGsMutex.Lock()
+	ret, specificReturn := fake.importASGsReturnsOnCall[len(fake.importASGsArgsForCall)]
+	fake.importASGsArgsForCall = append(fake.importASGsArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.ImportASGsStub
+	fakeReturns := fake.importASGsReturns
+	fake.recordInvocation("ImportASGs", []interface{}{arg1})
+	fake.importASGsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) ImportASGsCallCount() int {
+	fake.importASGsMutex.RLock()
+	defer fake.importASGsMutex.RUnlock()
+	return len(fake.importASGsArgsForCall)
+}
+
+func (fake *PolicyClient) ImportASGsCalls(stub func(bool) (asg.Report, error)) {
+	fake.importASGsMutex.Lock()
+	defer fake.importASGsMutex.Unlock()
+	fake.ImportASGsStub = stub
+}
+
+func (fake *PolicyClient) ImportASGsArgsForCall(i int) bool {
+	fake.importASGsMutex.RLock()
+	defer fake.importASGsMutex.RUnlock()
+	argsForCall := fake.importASGsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) ImportASGsReturns(result1 asg.Report, result2 error) {
+	fake.importASGsMutex.Lock()
+	defer fake.importASGsMutex.Unlock()
+	fake.ImportASGsStub = nil
+	fake.importASGsReturns = struct {
+		result1 asg.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ImportASGsReturnsOnCall(i int, result1 asg.Report, result2 error) {
+	fake.importASGsMutex.Lock()
+	defer fake.importASGsMutex.Unlock()
+	fake.ImportASGsStub = nil
+	if fake.importASGsReturnsOnCall == nil {
+		fake.importASGsReturnsOnCall = make(map[int]struct {
+			result1 asg.Report
+			result2 error
+		})
+	}
+	fake.importASGsReturnsOnCall[i] = struct {
+		result1 asg.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListDestinationOverlaps() ([]psclient.DestinationOverlap, error) {
+	fake.listDestinationOverlapsMutex.Lock()
+	ret, specificReturn := fake.listDestinationOverlapsReturnsOnCall[len(fake.listDestinationOverlapsArgsForCall)]
+	fake.listDestinationOverlapsArgsForCall = append(fake.listDestinationOverlapsArgsForCall, struct {
+	}{})
+	stub := fake.ListDestinationOverlapsStub
+	fakeReturns := fake.listDestinationOverlapsReturns
+	fake.recordInvocation("ListDestinationOverlaps", []interface{}{})
+	fake.listDestinationOverlapsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) ListDestinationOverlapsCallCount() int {
+	fake.listDestinationOverlapsMutex.RLock()
+	defer fake.listDestinationOverlapsMutex.RUnlock()
+	return len(fake.listDestinationOverlapsArgsForCall)
+}
+
+func (fake *PolicyClient) ListDestinationOverlapsCalls(stub func() ([]psclient.DestinationOverlap, error)) {
+	fake.listDestinationOverlapsMutex.Lock()
+	defer fake.listDestinationOverlapsMutex.Unlock()
+	fake.ListDestinationOverlapsStub = stub
+}
+
+func (fake *PolicyClient) ListDestinationOverlapsReturns(result1 []psclient.DestinationOverlap, result2 error) {
+	fake.listDestinationOverlapsMutex.Lock()
+	defer fake.listDestinationOverlapsMutex.Unlock()
+	fake.ListDestinationOverlapsStub = nil
+	fake.listDestinationOverlapsReturns = struct {
+		result1 []psclient.DestinationOverlap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListDestinationOverlapsReturnsOnCall(i int, result1 []psclient.DestinationOverlap, result2 error) {
+	fake.listDestinationOverlapsMutex.Lock()
+	defer fake.listDestinationOverlapsMutex.Unlock()
+	fake.ListDestinationOverlapsStub = nil
+	if fake.listDestinationOverlapsReturnsOnCall == nil {
+		fake.listDestinationOverlapsReturnsOnCall = make(map[int]struct {
+			result1 []psclient.DestinationOverlap
+			result2 error
+		})
+	}
+	fake.listDestinationOverlapsReturnsOnCall[i] = struct {
+		result1 []psclient.DestinationOverlap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListDestinations() ([]psclient.Destination, error) {
+	fake.listDestinationsMutex.Lock()
+	ret, specificReturn := fake.listDestinationsReturnsOnCall[len(fake.listDestinationsArgsForCall)]
+	fake.listDestinationsArgsForCall = append(fake.listDestinationsArgsForCall, struct {
+	}{})
+	stub := fake.ListDestinationsStub
+	fakeReturns := fake.listDestinationsReturns
+	fake.recordInvocation("ListDestinations", []interface{}{})
+	fake.listDestinationsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) ListDestinationsCallCount() int {
+	fake.listDestinationsMutex.RLock()
+	defer fake.listDestinationsMutex.RUnlock()
+	return len(fake.listDestinationsArgsForCall)
+}
+
+func (fake *PolicyClient) ListDestinationsCalls(stub func() ([]psclient.Destination, error)) {
+	fake.listDestinationsMutex.Lock()
+	defer fake.listDestinationsMutex.Unlock()
+	fake.ListDestinationsStub = stub
+}
+
+func (fake *PolicyClient) ListDestinationsReturns(result1 []psclient.Destination, result2 error) {
+	fake.listDestinationsMutex.Lock()
+	defer fake.listDestinationsMutex.Unlock()
+	fake.ListDestinationsStub = nil
+	fake.listDestinationsReturns = struct {
+		result1 []psclient.Destination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListDestinationsReturnsOnCall(i int, result1 []psclient.Destination, result2 error) {
+	fake.listDestinationsMutex.Lock()
+	defer fake.listDestinationsMutex.Unlock()
+	fake.ListDestinationsStub = nil
+	if fake.listDestinationsReturnsOnCall == nil {
+		fake.listDestinationsReturnsOnCall = make(map[int]struct {
+			result1 []psclient.Destination
+			result2 error
+		})
+	}
+	fake.listDestinationsReturnsOnCall[i] = struct {
+		result1 []psclient.Destination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListEgressPolicies() (psclient.EgressPolicyList, error) {
+	fake.listEgressPoliciesMutex.Lock()
+	ret, specificReturn := fake.listEgressPoliciesReturnsOnCall[len(fake.listEgressPoliciesArgsForCall)]
+	fake.listEgressPoliciesArgsForCall = append(fake.listEgressPoliciesArgsForCall, struct {
+	}{})
+	stub := fake.ListEgressPoliciesStub
+	fakeReturns := fake.listEgressPoliciesReturns
+	fake.recordInvocation("ListEgressPolicies", []interface{}{})
+	fake.listEgressPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) ListEgressPoliciesCallCount() int {
+	fake.listEgressPoliciesMutex.RLock()
+	defer fake.listEgressPoliciesMutex.RUnlock()
+	return len(fake.listEgressPoliciesArgsForCall)
+}
+
+func (fake *PolicyClient) ListEgressPoliciesCalls(stub func() (psclient.EgressPolicyList, error)) {
+	fake.listEgressPoliciesMutex.Lock()
+	defer fake.listEgressPoliciesMutex.Unlock()
+	fake.ListEgressPoliciesStub = stub
+}
+
+func (fake *PolicyClient) ListEgressPoliciesReturns(result1 psclient.EgressPolicyList, result2 error) {
+	fake.listEgressPoliciesMutex.Lock()
+	defer fake.listEgressPoliciesMutex.Unlock()
+	fake.ListEgressPoliciesStub = nil
+	fake.listEgressPoliciesReturns = struct {
+		result1 psclient.EgressPolicyList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListEgressPoliciesReturnsOnCall(i int, result1 psclient.EgressPolicyList, result2 error) {
+	fake.listEgressPoliciesMutex.Lock()
+	defer fake.listEgressPoliciesMutex.Unlock()
+	fake.ListEgressPoliciesStub = nil
+	if fake.listEgressPoliciesReturnsOnCall == nil {
+		fake.listEgressPoliciesReturnsOnCall = make(map[int]struct {
+			result1 psclient.EgressPolicyList
+			result2 error
+		})
+	}
+	fake.listEgressPoliciesReturnsOnCall[i] = struct {
+		result1 psclient.EgressPolicyList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListPolicies(arg1 psclient.PolicyFilter) ([]api.Policy, error) {
+	fake.listPoliciesMutex.Lock()
+	ret, specificReturn := fake.listPoliciesReturnsOnCall[len(fake.listPoliciesArgsForCall)]
+	fake.listPoliciesArgsForCall = append(fake.listPoliciesArgsForCall, struct {
+		arg1 psclient.PolicyFilter
+	}{arg1})
+	stub := fake.ListPoliciesStub
+	fakeReturns := fake.listPoliciesReturns
+	fake.recordInvocation("ListPolicies", []interface{}{arg1})
+	fake.listPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) ListPoliciesCallCount() int {
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	return len(fake.listPoliciesArgsForCall)
+}
+
+func (fake *PolicyClient) ListPoliciesCalls(stub func(psclient.PolicyFilter) ([]api.Policy, error)) {
+	fake.listPoliciesMutex.Lock()
+	defer fake.listPoliciesMutex.Unlock()
+	fake.ListPoliciesStub = stub
+}
+
+func (fake *PolicyClient) ListPoliciesArgsForCall(i int) psclient.PolicyFilter {
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	argsForCall := fake.listPoliciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyClient) ListPoliciesReturns(result1 []api.Policy, result2 error) {
+	fake.listPoliciesMutex.Lock()
+	defer fake.listPoliciesMutex.Unlock()
+	fake.ListPoliciesStub = nil
+	fake.listPoliciesReturns = struct {
+		result1 []api.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListPoliciesReturnsOnCall(i int, result1 []api.Policy, result2 error) {
+	fake.listPoliciesMutex.Lock()
+	defer fake.listPoliciesMutex.Unlock()
+	fake.ListPoliciesStub = nil
+	if fake.listPoliciesReturnsOnCall == nil {
+		fake.listPoliciesReturnsOnCall = make(map[int]struct {
+			result1 []api.Policy
+			result2 error
+		})
+	}
+	fake.listPoliciesReturnsOnCall[i] = struct {
+		result1 []api.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListTags() ([]api.Tag, error) {
+	fake.listTagsMutex.Lock()
+	ret, specificReturn := fake.listTagsReturnsOnCall[len(fake.listTagsArgsForCall)]
+	fake.listTagsArgsForCall = append(fake.listTagsArgsForCall, struct {
+	}{})
+	stub := fake.ListTagsStub
+	fakeReturns := fake.listTagsReturns
+	fake.recordInvocation("ListTags", []interface{}{})
+	fake.listTagsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyClient) ListTagsCallCount() int {
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	return len(fake.listTagsArgsForCall)
+}
+
+func (fake *PolicyClient) ListTagsCalls(stub func() ([]api.Tag, error)) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = stub
+}
+
+func (fake *PolicyClient) ListTagsReturns(result1 []api.Tag, result2 error) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = nil
+	fake.listTagsReturns = struct {
+		result1 []api.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) ListTagsReturnsOnCall(i int, result1 []api.Tag, result2 error) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = nil
+	if fake.listTagsReturnsOnCall == nil {
+		fake.listTagsReturnsOnCall = make(map[int]struct {
+			result1 []api.Tag
+			result2 error
+		})
+	}
+	fake.listTagsReturnsOnCall[i] = struct {
+		result1 []api.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	fake.createDestinationsMutex.RLock()
+	defer fake.createDestinationsMutex.RUnlock()
+	fake.createPoliciesMutex.RLock()
+	defer fake.createPoliciesMutex.RUnlock()
+	fake.deleteDestinationMutex.RLock()
+	defer fake.deleteDestinationMutex.RUnlock()
+	fake.deletePoliciesMutex.RLock()
+	defer fake.deletePoliciesMutex.RUnlock()
+	fake.effectiveEgressMutex.RLock()
+	defer fake.effectiveEgressMutex.RUnlock()
+	fake.importASGsMutex.RLock()
+	defer fake.importASGsMutex.RUnlock()
+	fake.listDestinationOverlapsMutex.RLock()
+	defer fake.listDestinationOverlapsMutex.RUnlock()
+	fake.listDestinationsMutex.RLock()
+	defer fake.listDestinationsMutex.RUnlock()
+	fake.listEgressPoliciesMutex.RLock()
+	defer fake.listEgressPoliciesMutex.RUnlock()
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PolicyClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"fmt"
+	"policy-server/api"
+	"policy-server/psclient"
+)
+
+func (c *CLI) listPolicies(args []string) error {
+	var id, sourceID, destID string
+	flags := newFlagSet("policies list")
+	flags.StringVar(&id, "id", "", "only policies from or to this app")
+	flags.StringVar(&sourceID, "source-id", "", "only policies from this app")
+	flags.StringVar(&destID, "dest-id", "", "only policies to this app")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	filter := psclient.PolicyFilter{}
+	if id != "" {
+		filter.IDs = []string{id}
+	}
+	if sourceID != "" {
+		filter.SourceIDs = []string{sourceID}
+	}
+	if destID != "" {
+		filter.DestIDs = []string{destID}
+	}
+
+	policies, err := c.Client.ListPolicies(filter)
+	if err != nil {
+		return fmt.Errorf("list policies: %s", err)
+	}
+	return c.printPolicies(policies)
+}
+
+func (c *CLI) createPolicy(args []string) error {
+	policy, err := parsePolicy("policies create", args)
+	if err != nil {
+		return err
+	}
+	err = c.Client.CreatePolicies([]api.Policy{policy})
+	if err != nil {
+		return fmt.Errorf("create policy: %s", err)
+	}
+	return c.printPolicies([]api.Policy{policy})
+}
+
+func (c *CLI) deletePolicy(args []string) error {
+	policy, err := parsePolicy("policies delete", args)
+	if err != nil {
+		return err
+	}
+	err = c.Client.DeletePolicies([]api.Policy{policy})
+	if err != nil {
+		return fmt.Errorf("delete policy: %s", err)
+	}
+	return c.printPolicies([]api.Policy{policy})
+}
+
+func parsePolicy(name string, args []string) (api.Policy, error) {
+	var source, dest, protocol, ports string
+	flags := newFlagSet(name)
+	flags.StringVar(&source, "source", "", "source app guid")
+	flags.StringVar(&dest, "dest", "", "destination app guid")
+	flags.StringVar(&protocol, "protocol", "tcp", "tcp or udp")
+	flags.StringVar(&ports, "ports", "", "port or port range, such as 8080 or 8080-8090")
+	if err := flags.Parse(args); err != nil {
+		return api.Policy{}, err
+	}
+	err := requireFlags(map[string]string{"source": source, "dest": dest, "ports": ports})
+	if err != nil {
+		return api.Policy{}, err
+	}
+
+	start, end, err := parsePorts(ports)
+	if err != nil {
+		return api.Policy{}, err
+	}
+	return api.Policy{
+		Source: api.Source{ID: source},
+		Destination: api.Destination{
+			ID:       dest,
+			Protocol: protocol,
+			Ports:    api.Ports{Start: start, End: end},
+		},
+	}, nil
+}
+
+func (c *CLI) printPolicies(policies []api.Policy) error {
+	if c.Output == OutputJSON {
+		return c.printJSON(nonNilPolicies(policies))
+	}
+
+	w := c.tableWriter()
+	fmt.Fprintln(w, "SOURCE\tDESTINATION\tPROTOCOL\tPORTS")
+	for _, policy := range policies {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			policy.Source.ID,
+			policy.Destination.ID,
+			policy.Destination.Protocol,
+			formatPorts(policy.Destination.Ports.Start, policy.Destination.Ports.End),
+		)
+	}
+	return w.Flush()
+}
+
+func (c *CLI) cleanup(args []string) error {
+	var dryRun bool
+	flags := newFlagSet("cleanup")
+	flags.BoolVar(&dryRun, "dry-run", false, "list the stale policies without deleting them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	stale, err := c.Client.Cleanup(dryRun)
+	if err != nil {
+		return fmt.Errorf("cleanup: %s", err)
+	}
+	return c.printPolicies(stale.Policies)
+}
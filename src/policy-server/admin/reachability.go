@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"policy-server/api"
+	"policy-server/psclient"
+	"strings"
+)
+
+// Reachability is whether traffic from an app can reach another app, or an
+// ip outside the platform, and which policies or effective egress rules
+// allow it.
+type Reachability struct {
+	Reachable   bool                      `json:"reachable"`
+	Policies    []api.Policy              `json:"policies,omitempty"`
+	EgressRules []api.EffectiveEgressRule `json:"egress_rules,omitempty"`
+}
+
+func (c *CLI) reachability(args []string) error {
+	var source, dest, ip, protocol string
+	var port int
+	flags := newFlagSet("reachability")
+	flags.StringVar(&source, "source", "", "source app guid")
+	flags.StringVar(&dest, "dest", "", "destination app guid")
+	flags.StringVar(&ip, "ip", "", "destination ip, for egress traffic")
+	flags.StringVar(&protocol, "protocol", "tcp", "tcp or udp")
+	flags.IntVar(&port, "port", 0, "destination port")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(map[string]string{"source": source}); err != nil {
+		return err
+	}
+	if (dest == "") == (ip == "") {
+		return fmt.Errorf("exactly one of -dest or -ip is required")
+	}
+
+	var reachability Reachability
+	var err error
+	if dest != "" {
+		reachability, err = c.appReachability(source, dest, protocol, port)
+	} else {
+		reachability, err = c.egressReachability(source, ip, protocol, port)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Output == OutputJSON {
+		return c.printJSON(reachability)
+	}
+	if !reachability.Reachable {
+		_, err = fmt.Fprintln(c.Out, "not reachable: no policy allows this traffic")
+		return err
+	}
+	fmt.Fprintln(c.Out, "reachable, allowed by:")
+	if len(reachability.Policies) > 0 {
+		return c.printPolicies(reachability.Policies)
+	}
+	w := c.tableWriter()
+	fmt.Fprintln(w, "POLICIES\tDESTINATIONS\tSOURCES")
+	for _, rule := range reachability.EgressRules {
+		var sources []string
+		for _, source := range rule.Sources {
+			sources = append(sources, strings.TrimSuffix(source.Type+":"+source.ID, ":"))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.Join(rule.PolicyGUIDs, " "), strings.Join(rule.Destinations, " "), strings.Join(sources, " "))
+	}
+	return w.Flush()
+}
+
+func (c *CLI) appReachability(source, dest, protocol string, port int) (Reachability, error) {
+	policies, err := c.Client.ListPolicies(psclient.PolicyFilter{
+		SourceIDs: []string{source},
+		DestIDs:   []string{dest},
+	})
+	if err != nil {
+		return Reachability{}, fmt.Errorf("list policies: %s", err)
+	}
+
+	reachability := Reachability{}
+	for _, policy := range policies {
+		if policy.Source.ID != source || policy.Destination.ID != dest {
+			continue
+		}
+		if policy.Destination.Protocol != protocol {
+			continue
+		}
+		if port < policy.Destination.Ports.Start || port > policy.Destination.Ports.End {
+			continue
+		}
+		reachability.Reachable = true
+		reachability.Policies = append(reachability.Policies, policy)
+	}
+	return reachability, nil
+}
+
+// egressReachability matches the effective egress rules of the app, which
+// include the egress policies of its space, its org and the default source,
+// as they apply to the running app.
+func (c *CLI) egressReachability(source, ip, protocol string, port int) (Reachability, error) {
+	target := net.ParseIP(ip)
+	if target == nil {
+		return Reachability{}, fmt.Errorf("invalid ip %q", ip)
+	}
+
+	effectiveEgress, err := c.Client.EffectiveEgress(source)
+	if err != nil {
+		return Reachability{}, fmt.Errorf("effective egress: %s", err)
+	}
+
+	reachability := Reachability{}
+	for _, rule := range effectiveEgress.Rules {
+		if rule.AppLifecycle == "staging" || !ruleAllows(rule, target, protocol, port) {
+			continue
+		}
+		reachability.Reachable = true
+		reachability.EgressRules = append(reachability.EgressRules, rule)
+	}
+	return reachability, nil
+}
+
+// ruleAllows matches an ip, protocol and port against an effective egress
+// rule. A rule without ports allows every port.
+func ruleAllows(rule api.EffectiveEgressRule, ip net.IP, protocol string, port int) bool {
+	if rule.Protocol != "all" && rule.Protocol != protocol {
+		return false
+	}
+
+	start, end, err := rule.IPRange.Bounds()
+	if err != nil || api.IPFamily(start) != api.IPFamily(ip) {
+		return false
+	}
+	if bytes.Compare(ip.To16(), start.To16()) < 0 || bytes.Compare(ip.To16(), end.To16()) > 0 {
+		return false
+	}
+
+	if len(rule.Ports) == 0 {
+		return true
+	}
+	for _, ports := range rule.Ports {
+		if port >= ports.Start && port <= ports.End {
+			return true
+		}
+	}
+	return false
+}
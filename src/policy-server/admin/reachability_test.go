@@ -0,0 +1,199 @@
+package admin_test
+
+import (
+	"bytes"
+	"errors"
+	"policy-server/admin"
+	"policy-server/admin/fakes"
+	"policy-server/api"
+	"policy-server/psclient"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("reachability", func() {
+	var (
+		cli        *admin.CLI
+		fakeClient *fakes.PolicyClient
+		out        *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.PolicyClient{}
+		out = &bytes.Buffer{}
+		cli = &admin.CLI{
+			Client: fakeClient,
+			Out:    out,
+			Output: admin.OutputJSON,
+		}
+	})
+
+	Describe("between apps", func() {
+		BeforeEach(func() {
+			fakeClient.ListPoliciesReturns([]api.Policy{
+				{
+					Source:      api.Source{ID: "app-a"},
+					Destination: api.Destination{ID: "app-b", Protocol: "tcp", Ports: api.Ports{Start: 8080, End: 8090}},
+				},
+				{
+					Source:      api.Source{ID: "app-b"},
+					Destination: api.Destination{ID: "app-a", Protocol: "tcp", Ports: api.Ports{Start: 8080, End: 8090}},
+				},
+			}, nil)
+		})
+
+		It("finds the policy that allows the traffic", func() {
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-port", "8085"})).To(Succeed())
+			Expect(fakeClient.ListPoliciesArgsForCall(0)).To(Equal(psclient.PolicyFilter{
+				SourceIDs: []string{"app-a"},
+				DestIDs:   []string{"app-b"},
+			}))
+			Expect(out.String()).To(MatchJSON(`{
+				"reachable": true,
+				"policies": [{
+					"source": {"id": "app-a"},
+					"destination": {"id": "app-b", "protocol": "tcp", "ports": {"start": 8080, "end": 8090}}
+				}]
+			}`))
+		})
+
+		It("is not reachable on another port or protocol", func() {
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-port", "9000"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{"reachable": false}`))
+
+			out.Reset()
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-protocol", "udp", "-port", "8085"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{"reachable": false}`))
+		})
+
+		It("prints a table", func() {
+			cli.Output = admin.OutputTable
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-port", "8080"})).To(Succeed())
+			Expect(out.String()).To(HavePrefix("reachable, allowed by:\n"))
+
+			out.Reset()
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-port", "1"})).To(Succeed())
+			Expect(out.String()).To(Equal("not reachable: no policy allows this traffic\n"))
+		})
+
+		Context("when listing policies fails", func() {
+			It("returns the error", func() {
+				fakeClient.ListPoliciesReturns(nil, errors.New("banana"))
+				err := cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-port", "8080"})
+				Expect(err).To(MatchError("list policies: banana"))
+			})
+		})
+	})
+
+	Describe("to an ip", func() {
+		BeforeEach(func() {
+			fakeClient.EffectiveEgressReturns(api.EffectiveEgressPayload{
+				AppGUID: "app-a",
+				Rules: []api.EffectiveEgressRule{
+					{
+						Protocol:     "tcp",
+						IPRange:      api.IPRange{Start: "10.0.0.1", End: "10.0.0.9", Family: "ipv4"},
+						Ports:        []api.Ports{{Start: 443, End: 443}},
+						AppLifecycle: "all",
+						PolicyGUIDs:  []string{"egress-1"},
+						Destinations: []string{"web"},
+						Sources:      []api.EgressSource{{ID: "space-a", Type: "space"}},
+					},
+					{
+						Protocol:     "all",
+						IPRange:      api.IPRange{Start: "10.0.0.5", End: "10.0.0.5", Family: "ipv4"},
+						AppLifecycle: "running",
+						PolicyGUIDs:  []string{"egress-2"},
+						Destinations: []string{"everything"},
+						Sources:      []api.EgressSource{{Type: "default"}},
+					},
+					{
+						Protocol:     "tcp",
+						IPRange:      api.IPRange{Start: "10.0.0.5", End: "10.0.0.5", Family: "ipv4"},
+						AppLifecycle: "staging",
+						PolicyGUIDs:  []string{"egress-3"},
+						Destinations: []string{"buildpacks"},
+						Sources:      []api.EgressSource{{ID: "app-a", Type: "app"}},
+					},
+					{
+						Protocol:     "tcp",
+						IPRange:      api.IPRange{Start: "::", End: "ff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", Family: "ipv6"},
+						AppLifecycle: "all",
+						PolicyGUIDs:  []string{"egress-4"},
+						Destinations: []string{"ipv6"},
+						Sources:      []api.EgressSource{{ID: "org-a", Type: "org"}},
+					},
+				},
+			}, nil)
+		})
+
+		It("finds the effective egress rules that allow the traffic of the running app", func() {
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-ip", "10.0.0.5", "-port", "443"})).To(Succeed())
+			Expect(fakeClient.EffectiveEgressArgsForCall(0)).To(Equal("app-a"))
+			Expect(out.String()).To(MatchJSON(`{
+				"reachable": true,
+				"egress_rules": [
+					{
+						"protocol": "tcp",
+						"ips": {"start": "10.0.0.1", "end": "10.0.0.9", "family": "ipv4"},
+						"ports": [{"start": 443, "end": 443}],
+						"app_lifecycle": "all",
+						"policy_ids": ["egress-1"],
+						"destinations": ["web"],
+						"sources": [{"id": "space-a", "type": "space"}]
+					},
+					{
+						"protocol": "all",
+						"ips": {"start": "10.0.0.5", "end": "10.0.0.5", "family": "ipv4"},
+						"app_lifecycle": "running",
+						"policy_ids": ["egress-2"],
+						"destinations": ["everything"],
+						"sources": [{"type": "default"}]
+					}
+				]
+			}`))
+		})
+
+		It("prints the policies, destinations and sources of the rules", func() {
+			cli.Output = admin.OutputTable
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-ip", "10.0.0.5", "-port", "443"})).To(Succeed())
+			Expect(out.String()).To(Equal("reachable, allowed by:\n" +
+				"POLICIES  DESTINATIONS  SOURCES\n" +
+				"egress-1  web           space:space-a\n" +
+				"egress-2  everything    default\n"))
+		})
+
+		It("is not reachable outside the ip and port ranges", func() {
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-ip", "10.0.0.10", "-port", "443"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{"reachable": false}`))
+
+			out.Reset()
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-ip", "10.0.0.2", "-port", "80"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{"reachable": false}`))
+		})
+
+		It("does not match an ipv4 address against an ipv6 range", func() {
+			Expect(cli.Run([]string{"reachability", "-source", "app-a", "-ip", "10.0.0.20", "-port", "443"})).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{"reachable": false}`))
+		})
+
+		It("returns the error of the effective egress", func() {
+			fakeClient.EffectiveEgressReturns(api.EffectiveEgressPayload{}, errors.New("banana"))
+			err := cli.Run([]string{"reachability", "-source", "app-a", "-ip", "10.0.0.5", "-port", "443"})
+			Expect(err).To(MatchError("effective egress: banana"))
+		})
+
+		It("rejects an invalid ip", func() {
+			err := cli.Run([]string{"reachability", "-source", "app-a", "-ip", "nope", "-port", "443"})
+			Expect(err).To(MatchError(`invalid ip "nope"`))
+		})
+	})
+
+	It("requires exactly one destination", func() {
+		err := cli.Run([]string{"reachability", "-source", "app-a", "-dest", "app-b", "-ip", "10.0.0.1"})
+		Expect(err).To(MatchError("exactly one of -dest or -ip is required"))
+		err = cli.Run([]string{"reachability", "-source", "app-a"})
+		Expect(err).To(MatchError("exactly one of -dest or -ip is required"))
+	})
+})
@@ -22,7 +22,7 @@ type PolicyCollectionPayload struct {
 
 type PoliciesPayload struct {
 	TotalPolicies int      `json:"total_policies"`
-	Policies      []Policy `json:"policies"`
+	Policies      []Policy `json:"policies" openapi:"required,minItems=1"`
 }
 
 type EgressPoliciesPayload struct {
@@ -30,26 +30,32 @@ type EgressPoliciesPayload struct {
 	EgressPolicies      []EgressPolicy `json:"egress_policies,omitempty"`
 }
 
+// The openapi tags constrain the fields in the OpenAPI document, which request
+// bodies are validated against. See openapi.SchemaFor.
+
 type Policy struct {
-	Source      Source      `json:"source"`
-	Destination Destination `json:"destination"`
+	Source      Source      `json:"source" openapi:"required"`
+	Destination Destination `json:"destination" openapi:"required"`
+	Log         bool        `json:"log,omitempty"`
 }
 
 type EgressPolicy struct {
-	Source      *EgressSource      `json:"source"`
-	Destination *EgressDestination `json:"destination"`
+	Source       *EgressSource      `json:"source" openapi:"required"`
+	Destination  *EgressDestination `json:"destination" openapi:"required"`
+	Log          bool               `json:"log,omitempty"`
+	AppLifecycle string             `json:"app_lifecycle,omitempty" openapi:"enum=running|staging|all"`
 }
 
 type EgressSource struct {
-	ID   string `json:"id"`
-	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty" openapi:"enum=app|space|org|default"`
 }
 
 type EgressDestination struct {
 	GUID        string    `json:"id,omitempty"`
 	Name        string    `json:"name,omitempty"`
 	Description string    `json:"description,omitempty"`
-	Protocol    string    `json:"protocol"`
+	Protocol    string    `json:"protocol" openapi:"enum=tcp|udp|icmp|all"`
 	Ports       []Ports   `json:"ports,omitempty"`
 	IPRanges    []IPRange `json:"ips"`
 	ICMPType    *int      `json:"icmp_type,omitempty"`
@@ -57,28 +63,32 @@ type EgressDestination struct {
 }
 
 type Source struct {
-	ID   string `json:"id"`
+	ID   string `json:"id" openapi:"required"`
 	Tag  string `json:"tag,omitempty"`
 	Type string `json:"type,omitempty"`
 }
 
 type Destination struct {
-	ID       string    `json:"id"`
+	ID       string    `json:"id" openapi:"required"`
 	Tag      string    `json:"tag,omitempty"`
-	Protocol string    `json:"protocol"`
-	Ports    Ports     `json:"ports"`
+	Protocol string    `json:"protocol" openapi:"required,enum=tcp|udp"`
+	Ports    Ports     `json:"ports" openapi:"required"`
 	Type     string    `json:"type,omitempty"`
 	IPs      []IPRange `json:"ips,omitempty"`
 }
 
+// IPRange is given either as a start and end address or as a CIDR, of
+// either address family. Family is only set on responses.
 type IPRange struct {
-	Start string `json:"start"`
-	End   string `json:"end"`
+	Start  string `json:"start,omitempty" openapi:"format=ip"`
+	End    string `json:"end,omitempty" openapi:"format=ip"`
+	CIDR   string `json:"cidr,omitempty" openapi:"format=cidr"`
+	Family string `json:"family,omitempty"`
 }
 
 type Ports struct {
-	Start int `json:"start"`
-	End   int `json:"end"`
+	Start int `json:"start" openapi:"required,minimum=1,maximum=65535"`
+	End   int `json:"end" openapi:"required,minimum=1,maximum=65535"`
 }
 
 type Tag struct {
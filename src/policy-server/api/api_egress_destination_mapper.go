@@ -12,12 +12,19 @@ type EgressDestinationMapper struct {
 	Marshaler marshal.Marshaler
 }
 
+// DestinationsPayload carries warnings only in the response to creating
+// destinations.
 type DestinationsPayload struct {
 	TotalDestinations  int                 `json:"total_destinations"`
 	EgressDestinations []EgressDestination `json:"destinations"`
+	Warnings           []string            `json:"warnings,omitempty"`
 }
 
 func (p *EgressDestinationMapper) AsBytes(egressDestinations []store.EgressDestination) ([]byte, error) {
+	return p.AsBytesWithWarnings(egressDestinations, nil)
+}
+
+func (p *EgressDestinationMapper) AsBytesWithWarnings(egressDestinations []store.EgressDestination, warnings []string) ([]byte, error) {
 	apiEgressDestinations := make([]EgressDestination, len(egressDestinations))
 
 	for i, storeEgressDestination := range egressDestinations {
@@ -27,6 +34,7 @@ func (p *EgressDestinationMapper) AsBytes(egressDestinations []store.EgressDesti
 	payload := &DestinationsPayload{
 		TotalDestinations:  len(apiEgressDestinations),
 		EgressDestinations: apiEgressDestinations,
+		Warnings:           warnings,
 	}
 
 	bytes, err := p.Marshaler.Marshal(payload)
@@ -40,15 +48,30 @@ func (p *EgressDestinationMapper) AsEgressDestinations(egressDestinations []byte
 	var payload DestinationsPayload
 	err := json.Unmarshal(egressDestinations, &payload)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("unmarshal json: %s", err)
 	}
 	storeEgressDestinations := make([]store.EgressDestination, len(payload.EgressDestinations))
 	for i, apiDest := range payload.EgressDestinations {
-		storeEgressDestinations[i] = apiDest.asStoreEgressDestination()
+		storeEgressDestinations[i], err = validatedStoreEgressDestination(apiDest)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return storeEgressDestinations, nil
 }
 
+func validatedStoreEgressDestination(apiDest EgressDestination) (store.EgressDestination, error) {
+	err := validateIPRanges(apiDest.IPRanges)
+	if err != nil {
+		return store.EgressDestination{}, fmt.Errorf("validate destinations: %s", err)
+	}
+	err = validateProtocolShape(apiDest)
+	if err != nil {
+		return store.EgressDestination{}, fmt.Errorf("validate destinations: %s", err)
+	}
+	return apiDest.asStoreEgressDestination(), nil
+}
+
 func asApiEgressDestination(storeEgressDestination store.EgressDestination) EgressDestination {
 	var ports []Ports
 
@@ -85,9 +108,10 @@ func asApiEgressDestination(storeEgressDestination store.EgressDestination) Egre
 func (d *EgressDestination) asStoreEgressDestination() store.EgressDestination {
 	ipRanges := []store.IPRange{}
 	for _, apiIPRange := range d.IPRanges {
+		normalized, _ := apiIPRange.Normalized()
 		ipRanges = append(ipRanges, store.IPRange{
-			Start: apiIPRange.Start,
-			End:   apiIPRange.End,
+			Start: normalized.Start,
+			End:   normalized.End,
 		})
 	}
 	ports := []store.Ports{}
@@ -166,5 +166,49 @@ var _ = Describe("ApiEgressDestinationMapper", func() {
 				}),
 			)
 		})
+
+		It("stores ipv6 ranges and cidrs as canonical start and end addresses", func() {
+			payload, err := mapper.AsEgressDestinations([]byte(`{
+				"destinations": [
+					{"protocol": "tcp", "ips": [{"start": "2001:DB8:0::1", "end": "2001:db8::00ff"}]},
+					{"protocol": "tcp", "ips": [{"cidr": "2001:db8::/120"}]},
+					{"protocol": "tcp", "ips": [{"cidr": "10.1.2.0/24"}]}
+				]
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload[0].IPRanges).To(Equal([]store.IPRange{{Start: "2001:db8::1", End: "2001:db8::ff"}}))
+			Expect(payload[1].IPRanges).To(Equal([]store.IPRange{{Start: "2001:db8::", End: "2001:db8::ff"}}))
+			Expect(payload[2].IPRanges).To(Equal([]store.IPRange{{Start: "10.1.2.0", End: "10.1.2.255"}}))
+		})
+
+		Context("when an ip range is invalid", func() {
+			It("returns an error", func() {
+				_, err := mapper.AsEgressDestinations([]byte(`{
+					"destinations": [{"protocol": "tcp", "ips": [{"start": "10.0.0.9", "end": "10.0.0.1"}]}]
+				}`))
+				Expect(err).To(MatchError("validate destinations: start ip address should be before end ip address: start: 10.0.0.9 end: 10.0.0.1"))
+			})
+		})
+
+		Context("when a destination does not fit its protocol", func() {
+			It("returns an error", func() {
+				_, err := mapper.AsEgressDestinations([]byte(`{
+					"destinations": [{"protocol": "icmp", "ips": [{"start": "10.0.0.1", "end": "10.0.0.1"}]}]
+				}`))
+				Expect(err).To(MatchError("validate destinations: missing icmp type"))
+
+				_, err = mapper.AsEgressDestinations([]byte(`{
+					"destinations": [{"protocol": "udp", "icmp_type": 8, "ips": [{"start": "10.0.0.1", "end": "10.0.0.1"}]}]
+				}`))
+				Expect(err).To(MatchError("validate destinations: icmp type and code can not be defined with udp"))
+			})
+		})
+
+		Context("when the json is invalid", func() {
+			It("returns an error", func() {
+				_, err := mapper.AsEgressDestinations([]byte("{"))
+				Expect(err).To(MatchError(HavePrefix("unmarshal json:")))
+			})
+		})
 	})
 })
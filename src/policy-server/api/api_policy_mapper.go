@@ -85,6 +85,7 @@ func (p *Policy) asStorePolicy() store.Policy {
 				End:   p.Destination.Ports.End,
 			},
 		},
+		Log: p.Log,
 	}
 }
 
@@ -103,6 +104,7 @@ func mapStorePolicy(storePolicy store.Policy) Policy {
 				End:   storePolicy.Destination.Ports.End,
 			},
 		},
+		Log: storePolicy.Log,
 	}
 }
 
@@ -116,6 +116,23 @@ var _ = Describe("ApiPolicyMapper", func() {
 			}))
 		})
 
+		It("maps the log attribute", func() {
+			policies, err := mapper.AsStorePolicy([]byte(`{
+				"policies": [{
+					"source": { "id": "some-src-id" },
+					"destination": {
+						"id": "some-dst-id",
+						"protocol": "tcp",
+						"ports": { "start": 8080, "end": 8080 }
+					},
+					"log": true
+				}]
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].Log).To(BeTrue())
+		})
+
 		Context("when unmarshalling fails", func() {
 			BeforeEach(func() {
 				fakeUnmarshaler.UnmarshalReturns(errors.New("banana"))
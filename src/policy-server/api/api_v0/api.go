@@ -6,20 +6,20 @@ type Policies struct {
 }
 
 type Policy struct {
-	Source      Source      `json:"source"`
-	Destination Destination `json:"destination"`
+	Source      Source      `json:"source" openapi:"required"`
+	Destination Destination `json:"destination" openapi:"required"`
 }
 
 type Source struct {
-	ID  string `json:"id"`
+	ID  string `json:"id" openapi:"required"`
 	Tag string `json:"tag,omitempty"`
 }
 
 type Destination struct {
-	ID       string `json:"id"`
+	ID       string `json:"id" openapi:"required"`
 	Tag      string `json:"tag,omitempty"`
-	Protocol string `json:"protocol"`
-	Port     int    `json:"port"`
+	Protocol string `json:"protocol" openapi:"required,enum=tcp|udp"`
+	Port     int    `json:"port" openapi:"required,minimum=1,maximum=65535"`
 }
 
 type Tag struct {
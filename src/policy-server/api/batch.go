@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+)
+
+const (
+	// BatchModeAllOrNothing applies every item of a batch or none of them.
+	BatchModeAllOrNothing = "all_or_nothing"
+	// BatchModeBestEffort applies every item of a batch that it can.
+	BatchModeBestEffort = "best_effort"
+
+	BatchStatusCreated = "created"
+	BatchStatusDeleted = "deleted"
+	BatchStatusFailed  = "failed"
+	// BatchStatusSkipped marks a valid item that was not applied because
+	// another item of an all_or_nothing batch failed.
+	BatchStatusSkipped = "skipped"
+)
+
+// BatchRequest holds destinations or egress policies to create, in the same
+// format as the single create endpoints, or the ids of those to delete.
+// Items are kept raw so that each can be read, and fail, on its own.
+type BatchRequest struct {
+	Mode           string            `json:"mode"`
+	Destinations   []json.RawMessage `json:"destinations,omitempty"`
+	EgressPolicies []json.RawMessage `json:"egress_policies,omitempty"`
+	IDs            []string          `json:"ids,omitempty"`
+}
+
+type BatchItemResult struct {
+	Index    int      `json:"index"`
+	ID       string   `json:"id,omitempty"`
+	Status   string   `json:"status"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type BatchPayload struct {
+	Mode      string            `json:"mode"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// NewBatchPayload returns a payload for total items, each skipped until it
+// succeeds or fails.
+func NewBatchPayload(mode string, total int) *BatchPayload {
+	payload := &BatchPayload{
+		Mode:    mode,
+		Total:   total,
+		Results: make([]BatchItemResult, total),
+	}
+	for i := range payload.Results {
+		payload.Results[i] = BatchItemResult{Index: i, Status: BatchStatusSkipped}
+	}
+	return payload
+}
+
+func (p *BatchPayload) Succeed(index int, id, status string, warnings []string) {
+	p.Results[index] = BatchItemResult{Index: index, ID: id, Status: status, Warnings: warnings}
+	p.count()
+}
+
+func (p *BatchPayload) Fail(index int, id string, err error) {
+	p.Results[index] = BatchItemResult{Index: index, ID: id, Status: BatchStatusFailed, Error: err.Error()}
+	p.count()
+}
+
+func (p *BatchPayload) count() {
+	p.Succeeded, p.Failed = 0, 0
+	for _, result := range p.Results {
+		switch result.Status {
+		case BatchStatusFailed:
+			p.Failed++
+		case BatchStatusSkipped:
+		default:
+			p.Succeeded++
+		}
+	}
+}
+
+// BatchMapper reads batch requests and their items, and writes their results.
+type BatchMapper struct {
+	Marshaler marshal.Marshaler
+}
+
+func (m *BatchMapper) AsBatchRequest(bytes []byte) (BatchRequest, error) {
+	var request BatchRequest
+	err := json.Unmarshal(bytes, &request)
+	if err != nil {
+		return BatchRequest{}, fmt.Errorf("unmarshal json: %s", err)
+	}
+	switch request.Mode {
+	case "":
+		request.Mode = BatchModeAllOrNothing
+	case BatchModeAllOrNothing, BatchModeBestEffort:
+	default:
+		return BatchRequest{}, fmt.Errorf("mode must be %s or %s", BatchModeAllOrNothing, BatchModeBestEffort)
+	}
+	return request, nil
+}
+
+func (m *BatchMapper) AsEgressDestination(item []byte) (store.EgressDestination, error) {
+	var apiDest EgressDestination
+	err := json.Unmarshal(item, &apiDest)
+	if err != nil {
+		return store.EgressDestination{}, fmt.Errorf("unmarshal json: %s", err)
+	}
+	return validatedStoreEgressDestination(apiDest)
+}
+
+func (m *BatchMapper) AsEgressPolicy(item []byte) (store.EgressPolicy, error) {
+	var apiEgressPolicy EgressPolicy
+	err := json.Unmarshal(item, &apiEgressPolicy)
+	if err != nil {
+		return store.EgressPolicy{}, fmt.Errorf("unmarshal json: %s", err)
+	}
+	return validatedStoreEgressPolicy(apiEgressPolicy)
+}
+
+func (m *BatchMapper) AsBytes(payload *BatchPayload) ([]byte, error) {
+	bytes, err := m.Marshaler.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal json: %s", err)
+	}
+	return bytes, nil
+}
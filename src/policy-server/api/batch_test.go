@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BatchMapper", func() {
+	var mapper *api.BatchMapper
+
+	BeforeEach(func() {
+		mapper = &api.BatchMapper{
+			Marshaler: marshal.MarshalFunc(json.Marshal),
+		}
+	})
+
+	Describe("AsBatchRequest", func() {
+		It("reads the mode and the raw items", func() {
+			request, err := mapper.AsBatchRequest([]byte(`{"mode": "best_effort", "destinations": [{"name": "a"}, {"name": "b"}]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.Mode).To(Equal(api.BatchModeBestEffort))
+			Expect(request.Destinations).To(HaveLen(2))
+			Expect(string(request.Destinations[1])).To(Equal(`{"name": "b"}`))
+		})
+
+		It("defaults to all or nothing", func() {
+			request, err := mapper.AsBatchRequest([]byte(`{"ids": ["some-id"]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.Mode).To(Equal(api.BatchModeAllOrNothing))
+			Expect(request.IDs).To(Equal([]string{"some-id"}))
+		})
+
+		It("rejects an unknown mode", func() {
+			_, err := mapper.AsBatchRequest([]byte(`{"mode": "some"}`))
+			Expect(err).To(MatchError("mode must be all_or_nothing or best_effort"))
+		})
+
+		It("rejects invalid json", func() {
+			_, err := mapper.AsBatchRequest([]byte(`{`))
+			Expect(err).To(MatchError(ContainSubstring("unmarshal json")))
+		})
+	})
+
+	Describe("AsEgressDestination", func() {
+		It("reads and validates one destination", func() {
+			destination, err := mapper.AsEgressDestination([]byte(`{"name": "db", "protocol": "tcp", "ips": [{"cidr": "10.0.0.0/24"}], "ports": [{"start": 5432, "end": 5432}]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destination).To(Equal(store.EgressDestination{
+				Name:     "db",
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.0", End: "10.0.0.255"}},
+				Ports:    []store.Ports{{Start: 5432, End: 5432}},
+			}))
+		})
+
+		It("returns an error for an invalid destination", func() {
+			_, err := mapper.AsEgressDestination([]byte(`{"name": "db", "protocol": "tcp", "ips": []}`))
+			Expect(err).To(MatchError("validate destinations: expected exactly one iprange"))
+		})
+	})
+
+	Describe("AsEgressPolicy", func() {
+		It("reads and validates one egress policy", func() {
+			policy, err := mapper.AsEgressPolicy([]byte(`{"source": {"id": "some-app-guid"}, "destination": {"name": "db"}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.Source).To(Equal(store.EgressSource{ID: "some-app-guid"}))
+			Expect(policy.Destination.Name).To(Equal("db"))
+		})
+
+		It("returns an error for an invalid egress policy", func() {
+			_, err := mapper.AsEgressPolicy([]byte(`{"destination": {"name": "db"}}`))
+			Expect(err).To(MatchError("validate egress policies: missing egress source"))
+		})
+	})
+
+	Describe("AsBytes", func() {
+		It("writes the result of each item and the counts", func() {
+			payload := api.NewBatchPayload(api.BatchModeBestEffort, 3)
+			payload.Succeed(0, "some-guid", api.BatchStatusCreated, []string{"some warning"})
+			payload.Fail(2, "", errors.New("some error"))
+
+			bytes, err := mapper.AsBytes(payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bytes).To(MatchJSON(`{
+				"mode": "best_effort",
+				"total": 3,
+				"succeeded": 1,
+				"failed": 1,
+				"results": [
+					{"index": 0, "id": "some-guid", "status": "created", "warnings": ["some warning"]},
+					{"index": 1, "status": "skipped"},
+					{"index": 2, "status": "failed", "error": "some error"}
+				]
+			}`))
+		})
+	})
+})
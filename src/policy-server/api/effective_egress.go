@@ -0,0 +1,312 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"policy-server/store"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EffectiveEgressRule is traffic that an app may send, merged from every
+// egress policy that allows it, with the policies and sources that do.
+type EffectiveEgressRule struct {
+	Protocol     string         `json:"protocol"`
+	IPRange      IPRange        `json:"ips"`
+	Ports        []Ports        `json:"ports,omitempty"`
+	ICMPType     *int           `json:"icmp_type,omitempty"`
+	ICMPCode     *int           `json:"icmp_code,omitempty"`
+	AppLifecycle string         `json:"app_lifecycle"`
+	PolicyGUIDs  []string       `json:"policy_ids"`
+	Destinations []string       `json:"destinations"`
+	Sources      []EgressSource `json:"sources"`
+}
+
+type EffectiveEgressPayload struct {
+	AppGUID    string                `json:"app_id"`
+	TotalRules int                   `json:"total_rules"`
+	Rules      []EffectiveEgressRule `json:"rules"`
+}
+
+type EffectiveEgressMapper struct{}
+
+func (m *EffectiveEgressMapper) AsBytes(appGUID string, policies []store.EgressPolicy) ([]byte, error) {
+	rules := EffectiveEgressRules(policies)
+	bytes, err := json.Marshal(EffectiveEgressPayload{
+		AppGUID:    appGUID,
+		TotalRules: len(rules),
+		Rules:      rules,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal json: %s", err)
+	}
+	return bytes, nil
+}
+
+// AsCSV writes one row per rule, with the policy ids, destinations and
+// sources of a rule separated by spaces.
+func (m *EffectiveEgressMapper) AsCSV(appGUID string, policies []store.EgressPolicy) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"app_id", "protocol", "start_ip", "end_ip", "ports", "icmp_type", "icmp_code", "app_lifecycle", "policy_ids", "destinations", "sources"})
+	for _, rule := range EffectiveEgressRules(policies) {
+		var ports, sources []string
+		for _, port := range rule.Ports {
+			ports = append(ports, fmt.Sprintf("%d-%d", port.Start, port.End))
+		}
+		for _, source := range rule.Sources {
+			sources = append(sources, strings.TrimSuffix(source.Type+":"+source.ID, ":"))
+		}
+		writer.Write([]string{
+			appGUID,
+			rule.Protocol,
+			rule.IPRange.Start,
+			rule.IPRange.End,
+			strings.Join(ports, " "),
+			optionalInt(rule.ICMPType),
+			optionalInt(rule.ICMPCode),
+			rule.AppLifecycle,
+			strings.Join(rule.PolicyGUIDs, " "),
+			strings.Join(rule.Destinations, " "),
+			strings.Join(sources, " "),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("write csv: %s", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// EffectiveEgressRules splits the ip ranges of policies that allow the same
+// protocol, ports, icmp type and code in the same app lifecycle at the start
+// and end of each range, so that every rule credits exactly the policies that
+// allow all of its addresses. Neighbouring pieces allowed by the same policies
+// are joined again. Rules are sorted by protocol and start address.
+func EffectiveEgressRules(policies []store.EgressPolicy) []EffectiveEgressRule {
+	type span struct {
+		start, end net.IP
+		policy     int
+	}
+	groups := map[string][]span{}
+	var keys []string
+	for n, policy := range policies {
+		for _, ipRange := range policy.Destination.IPRanges {
+			start, end := net.ParseIP(ipRange.Start), net.ParseIP(ipRange.End)
+			if start == nil || end == nil {
+				continue
+			}
+			key := effectiveEgressKey(policy, start)
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], span{start: start.To16(), end: end.To16(), policy: n})
+		}
+	}
+
+	rules := []EffectiveEgressRule{}
+	for _, key := range keys {
+		spans := groups[key]
+		sort.SliceStable(spans, func(i, j int) bool {
+			return bytes.Compare(spans[i].start, spans[j].start) < 0
+		})
+
+		var boundaries []net.IP
+		for _, s := range spans {
+			boundaries = append(boundaries, s.start)
+			if next, ok := nextIP(s.end); ok {
+				boundaries = append(boundaries, next)
+			}
+		}
+		sort.Slice(boundaries, func(i, j int) bool {
+			return bytes.Compare(boundaries[i], boundaries[j]) < 0
+		})
+
+		var rule *EffectiveEgressRule
+		var rulePolicies string
+		for i, pieceStart := range boundaries {
+			if i > 0 && bytes.Equal(pieceStart, boundaries[i-1]) {
+				continue
+			}
+			var covering []int
+			var pieceEnd net.IP
+			for _, s := range spans {
+				if bytes.Compare(s.start, pieceStart) <= 0 && bytes.Compare(pieceStart, s.end) <= 0 {
+					covering = append(covering, s.policy)
+					pieceEnd = s.end
+				}
+			}
+			if len(covering) == 0 {
+				if rule != nil {
+					rules = append(rules, *rule)
+					rule = nil
+				}
+				continue
+			}
+			for _, next := range boundaries[i+1:] {
+				if !bytes.Equal(next, pieceStart) {
+					pieceEnd = prevIP(next)
+					break
+				}
+			}
+
+			piecePolicies := policySet(covering)
+			if rule != nil && piecePolicies == rulePolicies {
+				rule.IPRange.End = pieceEnd.String()
+				continue
+			}
+			if rule != nil {
+				rules = append(rules, *rule)
+			}
+			rule = newEffectiveEgressRule(policies[covering[0]], pieceStart, pieceEnd)
+			for _, n := range covering[1:] {
+				addEffectiveEgressPolicy(rule, policies[n])
+			}
+			rulePolicies = piecePolicies
+		}
+		if rule != nil {
+			rules = append(rules, *rule)
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Protocol != rules[j].Protocol {
+			return rules[i].Protocol < rules[j].Protocol
+		}
+		return bytes.Compare(net.ParseIP(rules[i].IPRange.Start).To16(), net.ParseIP(rules[j].IPRange.Start).To16()) < 0
+	})
+	return rules
+}
+
+// policySet identifies the policies that allow a piece of a range, so that
+// neighbouring pieces allowed by the same policies can be joined.
+func policySet(policies []int) string {
+	sorted := append([]int{}, policies...)
+	sort.Ints(sorted)
+	var set []string
+	for i, n := range sorted {
+		if i == 0 || n != sorted[i-1] {
+			set = append(set, strconv.Itoa(n))
+		}
+	}
+	return strings.Join(set, ",")
+}
+
+// nextIP is the address after ip, or false when ip is the last address.
+func nextIP(ip net.IP) (net.IP, bool) {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next, true
+		}
+	}
+	return nil, false
+}
+
+// prevIP is the address before ip, which must not be the first address.
+func prevIP(ip net.IP) net.IP {
+	prev := make(net.IP, len(ip))
+	copy(prev, ip)
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 0xff {
+			break
+		}
+	}
+	return prev
+}
+
+func effectiveEgressKey(policy store.EgressPolicy, start net.IP) string {
+	destination := policy.Destination
+	var ports []string
+	for _, port := range destination.Ports {
+		ports = append(ports, fmt.Sprintf("%d-%d", port.Start, port.End))
+	}
+	appLifecycle := policy.AppLifecycle
+	if appLifecycle == "" {
+		appLifecycle = store.AppLifecycleAll
+	}
+	return strings.Join([]string{
+		IPFamily(start),
+		destination.Protocol,
+		strings.Join(ports, ","),
+		strconv.Itoa(destination.ICMPType),
+		strconv.Itoa(destination.ICMPCode),
+		appLifecycle,
+	}, "/")
+}
+
+func newEffectiveEgressRule(policy store.EgressPolicy, start, end net.IP) *EffectiveEgressRule {
+	destination := policy.Destination
+	rule := &EffectiveEgressRule{
+		Protocol: destination.Protocol,
+		IPRange: IPRange{
+			Start:  start.String(),
+			End:    end.String(),
+			Family: IPFamily(start),
+		},
+		AppLifecycle: policy.AppLifecycle,
+		PolicyGUIDs:  []string{},
+		Destinations: []string{},
+		Sources:      []EgressSource{},
+	}
+	if rule.AppLifecycle == "" {
+		rule.AppLifecycle = store.AppLifecycleAll
+	}
+	for _, port := range destination.Ports {
+		rule.Ports = append(rule.Ports, Ports{Start: port.Start, End: port.End})
+	}
+	if destination.Protocol == "icmp" {
+		icmpType, icmpCode := destination.ICMPType, destination.ICMPCode
+		rule.ICMPType, rule.ICMPCode = &icmpType, &icmpCode
+	}
+	addEffectiveEgressPolicy(rule, policy)
+	return rule
+}
+
+func addEffectiveEgressPolicy(rule *EffectiveEgressRule, policy store.EgressPolicy) {
+	if policy.ID != "" && !containsString(rule.PolicyGUIDs, policy.ID) {
+		rule.PolicyGUIDs = append(rule.PolicyGUIDs, policy.ID)
+		sort.Strings(rule.PolicyGUIDs)
+	}
+	name := policy.Destination.Name
+	if name == "" {
+		name = policy.Destination.GUID
+	}
+	if name != "" && !containsString(rule.Destinations, name) {
+		rule.Destinations = append(rule.Destinations, name)
+		sort.Strings(rule.Destinations)
+	}
+	source := EgressSource{ID: policy.Source.ID, Type: policy.Source.Type}
+	if source.Type == "" {
+		source.Type = "app"
+	}
+	for _, existing := range rule.Sources {
+		if existing == source {
+			return
+		}
+	}
+	rule.Sources = append(rule.Sources, source)
+}
+
+func optionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package api_test
+
+import (
+	"policy-server/api"
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EffectiveEgressMapper", func() {
+	var (
+		mapper   *api.EffectiveEgressMapper
+		policies []store.EgressPolicy
+	)
+
+	policy := func(id string, source store.EgressSource, name, start, end string, ports []store.Ports) store.EgressPolicy {
+		return store.EgressPolicy{
+			ID:     id,
+			Source: source,
+			Destination: store.EgressDestination{
+				GUID:     name + "-guid",
+				Name:     name,
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: start, End: end}},
+				Ports:    ports,
+			},
+			AppLifecycle: "all",
+		}
+	}
+
+	BeforeEach(func() {
+		mapper = &api.EffectiveEgressMapper{}
+
+		app := store.EgressSource{ID: "some-app-guid", Type: "app"}
+		space := store.EgressSource{ID: "some-space-guid", Type: "space"}
+		https := []store.Ports{{Start: 443, End: 443}}
+		policies = []store.EgressPolicy{
+			policy("policy-2", space, "wide", "10.0.0.0", "10.0.0.255", https),
+			policy("policy-1", app, "narrow", "10.0.0.5", "10.0.1.9", https),
+			policy("policy-3", app, "apart", "10.0.2.0", "10.0.2.255", https),
+			policy("policy-4", space, "other-port", "10.0.0.0", "10.0.0.255", []store.Ports{{Start: 80, End: 80}}),
+			{
+				ID:     "policy-5",
+				Source: store.EgressSource{Type: "default"},
+				Destination: store.EgressDestination{
+					Name:     "ping",
+					Protocol: "icmp",
+					IPRanges: []store.IPRange{{Start: "2001:db8::1", End: "2001:db8::ff"}},
+					ICMPType: 8,
+					ICMPCode: 0,
+				},
+				AppLifecycle: "running",
+			},
+		}
+	})
+
+	It("splits partially overlapping ranges of the same traffic at each range's start and end", func() {
+		rules := api.EffectiveEgressRules(policies)
+
+		Expect(rules).To(HaveLen(6))
+		Expect(rules[0].Protocol).To(Equal("icmp"))
+		Expect(rules[0].IPRange).To(Equal(api.IPRange{Start: "2001:db8::1", End: "2001:db8::ff", Family: "ipv6"}))
+		Expect(*rules[0].ICMPType).To(Equal(8))
+		Expect(rules[0].AppLifecycle).To(Equal("running"))
+		Expect(rules[0].Sources).To(Equal([]api.EgressSource{{Type: "default"}}))
+
+		Expect(rules[1].IPRange).To(Equal(api.IPRange{Start: "10.0.0.0", End: "10.0.0.4", Family: "ipv4"}))
+		Expect(rules[1].Ports).To(Equal([]api.Ports{{Start: 443, End: 443}}))
+		Expect(rules[1].PolicyGUIDs).To(Equal([]string{"policy-2"}))
+		Expect(rules[1].Destinations).To(Equal([]string{"wide"}))
+		Expect(rules[1].Sources).To(Equal([]api.EgressSource{{ID: "some-space-guid", Type: "space"}}))
+
+		Expect(rules[2].PolicyGUIDs).To(Equal([]string{"policy-4"}))
+		Expect(rules[2].Ports).To(Equal([]api.Ports{{Start: 80, End: 80}}))
+
+		Expect(rules[3].IPRange).To(Equal(api.IPRange{Start: "10.0.0.5", End: "10.0.0.255", Family: "ipv4"}))
+		Expect(rules[3].PolicyGUIDs).To(Equal([]string{"policy-1", "policy-2"}))
+		Expect(rules[3].Destinations).To(Equal([]string{"narrow", "wide"}))
+		Expect(rules[3].Sources).To(Equal([]api.EgressSource{
+			{ID: "some-space-guid", Type: "space"},
+			{ID: "some-app-guid", Type: "app"},
+		}))
+
+		Expect(rules[4].IPRange).To(Equal(api.IPRange{Start: "10.0.1.0", End: "10.0.1.9", Family: "ipv4"}))
+		Expect(rules[4].PolicyGUIDs).To(Equal([]string{"policy-1"}))
+		Expect(rules[4].Sources).To(Equal([]api.EgressSource{{ID: "some-app-guid", Type: "app"}}))
+
+		Expect(rules[5].PolicyGUIDs).To(Equal([]string{"policy-3"}))
+	})
+
+	It("splits a range around a range it contains", func() {
+		https := []store.Ports{{Start: 443, End: 443}}
+		app := store.EgressSource{ID: "some-app-guid", Type: "app"}
+		rules := api.EffectiveEgressRules([]store.EgressPolicy{
+			policy("outer", app, "outer", "10.0.0.0", "10.0.0.255", https),
+			policy("inner", app, "inner", "10.0.0.10", "10.0.0.20", https),
+		})
+
+		Expect(rules).To(HaveLen(3))
+		Expect(rules[0].IPRange).To(Equal(api.IPRange{Start: "10.0.0.0", End: "10.0.0.9", Family: "ipv4"}))
+		Expect(rules[0].PolicyGUIDs).To(Equal([]string{"outer"}))
+		Expect(rules[1].IPRange).To(Equal(api.IPRange{Start: "10.0.0.10", End: "10.0.0.20", Family: "ipv4"}))
+		Expect(rules[1].PolicyGUIDs).To(Equal([]string{"inner", "outer"}))
+		Expect(rules[2].IPRange).To(Equal(api.IPRange{Start: "10.0.0.21", End: "10.0.0.255", Family: "ipv4"}))
+		Expect(rules[2].PolicyGUIDs).To(Equal([]string{"outer"}))
+	})
+
+	It("joins neighbouring ranges of the same policies", func() {
+		joined := policy("policy-1", store.EgressSource{ID: "some-app-guid", Type: "app"}, "split", "10.0.0.0", "10.0.0.9", nil)
+		joined.Destination.IPRanges = append(joined.Destination.IPRanges, store.IPRange{Start: "10.0.0.10", End: "10.0.0.19"})
+
+		rules := api.EffectiveEgressRules([]store.EgressPolicy{joined})
+
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].IPRange).To(Equal(api.IPRange{Start: "10.0.0.0", End: "10.0.0.19", Family: "ipv4"}))
+	})
+
+	It("splits ranges that end at the last address", func() {
+		app := store.EgressSource{ID: "some-app-guid", Type: "app"}
+		rules := api.EffectiveEgressRules([]store.EgressPolicy{
+			policy("all-v4", app, "all-v4", "0.0.0.0", "255.255.255.255", nil),
+			policy("top-v4", app, "top-v4", "255.255.255.0", "255.255.255.255", nil),
+			policy("all-v6", app, "all-v6", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", nil),
+		})
+
+		Expect(rules).To(HaveLen(3))
+		Expect(rules[0].IPRange).To(Equal(api.IPRange{Start: "::", End: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", Family: "ipv6"}))
+		Expect(rules[1].IPRange).To(Equal(api.IPRange{Start: "0.0.0.0", End: "255.255.254.255", Family: "ipv4"}))
+		Expect(rules[1].PolicyGUIDs).To(Equal([]string{"all-v4"}))
+		Expect(rules[2].IPRange).To(Equal(api.IPRange{Start: "255.255.255.0", End: "255.255.255.255", Family: "ipv4"}))
+		Expect(rules[2].PolicyGUIDs).To(Equal([]string{"all-v4", "top-v4"}))
+	})
+
+	It("writes the rules as json", func() {
+		bytes, err := mapper.AsBytes("some-app-guid", policies[2:3])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes).To(MatchJSON(`{
+			"app_id": "some-app-guid",
+			"total_rules": 1,
+			"rules": [{
+				"protocol": "tcp",
+				"ips": {"start": "10.0.2.0", "end": "10.0.2.255", "family": "ipv4"},
+				"ports": [{"start": 443, "end": 443}],
+				"app_lifecycle": "all",
+				"policy_ids": ["policy-3"],
+				"destinations": ["apart"],
+				"sources": [{"id": "some-app-guid", "type": "app"}]
+			}]
+		}`))
+	})
+
+	It("writes the rules as csv", func() {
+		bytes, err := mapper.AsCSV("some-app-guid", policies[:2])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bytes)).To(Equal(
+			"app_id,protocol,start_ip,end_ip,ports,icmp_type,icmp_code,app_lifecycle,policy_ids,destinations,sources\n" +
+				"some-app-guid,tcp,10.0.0.0,10.0.0.4,443-443,,,all,policy-2,wide,space:some-space-guid\n" +
+				"some-app-guid,tcp,10.0.0.5,10.0.0.255,443-443,,,all,policy-1 policy-2,narrow wide,space:some-space-guid app:some-app-guid\n" +
+				"some-app-guid,tcp,10.0.1.0,10.0.1.9,443-443,,,all,policy-1,narrow,app:some-app-guid\n"))
+	})
+
+	It("returns no rules for no policies", func() {
+		bytes, err := mapper.AsBytes("some-app-guid", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes).To(MatchJSON(`{"app_id": "some-app-guid", "total_rules": 0, "rules": []}`))
+	})
+})
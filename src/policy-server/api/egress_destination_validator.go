@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"policy-server/store"
+	"strings"
+)
+
+// EgressDestinationValidator rejects egress destinations that reach a
+// forbidden range, such as the overlay network or the infrastructure
+// network, or that allow the same traffic as an existing destination.
+type EgressDestinationValidator struct {
+	ForbiddenRanges []IPRange
+}
+
+// DestinationOverlap is two destinations that allow some of the same
+// traffic, or one destination that reaches a forbidden CIDR.
+type DestinationOverlap struct {
+	Duplicate     bool                `json:"duplicate"`
+	ForbiddenCIDR string              `json:"forbidden_cidr,omitempty"`
+	Destinations  []EgressDestination `json:"destinations"`
+}
+
+type DestinationOverlapsPayload struct {
+	TotalOverlaps int                  `json:"total_overlaps"`
+	Overlaps      []DestinationOverlap `json:"overlaps"`
+}
+
+func NewEgressDestinationValidator(forbiddenCIDRs []string) (*EgressDestinationValidator, error) {
+	validator := &EgressDestinationValidator{}
+	for _, cidr := range forbiddenCIDRs {
+		ipRange := IPRange{CIDR: cidr}
+		if _, _, err := ipRange.Bounds(); err != nil {
+			return nil, fmt.Errorf("forbidden cidr: %s", err)
+		}
+		validator.ForbiddenRanges = append(validator.ForbiddenRanges, ipRange)
+	}
+	return validator, nil
+}
+
+// ValidateEgressDestinations returns an error for a destination whose name is
+// taken, that reaches a forbidden range or that duplicates another, and a
+// warning for each other destination that a new one partly overlaps.
+func (v *EgressDestinationValidator) ValidateEgressDestinations(destinations, existing []store.EgressDestination) ([]string, error) {
+	warnings := []string{}
+	for i, destination := range destinations {
+		if err := validateDestinationName(destination, destinations[:i], existing); err != nil {
+			return nil, err
+		}
+		if cidr, ok := v.forbiddenCIDR(destination); ok {
+			return nil, fmt.Errorf("destination %s overlaps forbidden range %s", destinationLabel(destination), cidr)
+		}
+		for _, other := range destinations[:i] {
+			if destination.SameTraffic(other) {
+				return nil, fmt.Errorf("destination %s duplicates destination %s in the same request", destinationLabel(destination), destinationLabel(other))
+			}
+			if destination.OverlapsTraffic(other) {
+				warnings = append(warnings, fmt.Sprintf("destination %s overlaps destination %s in the same request", destinationLabel(destination), destinationLabel(other)))
+			}
+		}
+		for _, other := range existing {
+			if destination.SameTraffic(other) {
+				return nil, fmt.Errorf("destination %s duplicates existing destination %s", destinationLabel(destination), destinationLabel(other))
+			}
+			if destination.OverlapsTraffic(other) {
+				warnings = append(warnings, fmt.Sprintf("destination %s overlaps existing destination %s", destinationLabel(destination), destinationLabel(other)))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// Overlaps lists the destinations that reach a forbidden range, then each
+// pair of destinations that allow some of the same traffic.
+func (v *EgressDestinationValidator) Overlaps(destinations []store.EgressDestination) []DestinationOverlap {
+	overlaps := []DestinationOverlap{}
+	for _, destination := range destinations {
+		if cidr, ok := v.forbiddenCIDR(destination); ok {
+			overlaps = append(overlaps, DestinationOverlap{
+				ForbiddenCIDR: cidr,
+				Destinations:  []EgressDestination{asApiEgressDestination(destination)},
+			})
+		}
+	}
+	for i, destination := range destinations {
+		for _, other := range destinations[i+1:] {
+			if destination.OverlapsTraffic(other) {
+				overlaps = append(overlaps, DestinationOverlap{
+					Duplicate:    destination.SameTraffic(other),
+					Destinations: []EgressDestination{asApiEgressDestination(destination), asApiEgressDestination(other)},
+				})
+			}
+		}
+	}
+	return overlaps
+}
+
+// forbiddenCIDR only compares ranges of the same family, since an ipv6 range
+// such as ::/8 spans the ipv4-mapped form of every ipv4 address.
+func (v *EgressDestinationValidator) forbiddenCIDR(destination store.EgressDestination) (string, bool) {
+	for _, ipRange := range destination.IPRanges {
+		start, end := net.ParseIP(ipRange.Start), net.ParseIP(ipRange.End)
+		if start == nil || end == nil {
+			continue
+		}
+		for _, forbidden := range v.ForbiddenRanges {
+			forbiddenStart, forbiddenEnd, _ := forbidden.Bounds()
+			if IPFamily(start) != IPFamily(forbiddenStart) {
+				continue
+			}
+			if bytes.Compare(start.To16(), forbiddenEnd.To16()) <= 0 && bytes.Compare(forbiddenStart.To16(), end.To16()) <= 0 {
+				return forbidden.CIDR, true
+			}
+		}
+	}
+	return "", false
+}
+
+// validateDestinationName rejects a name that another destination, existing
+// or earlier in the same request, already has. Names are compared ignoring
+// case, as mysql compares them, so that a name taken on one database is
+// taken on all of them.
+func validateDestinationName(destination store.EgressDestination, earlier, existing []store.EgressDestination) error {
+	if destination.Name == "" {
+		return nil
+	}
+	for _, other := range earlier {
+		if strings.EqualFold(other.Name, destination.Name) {
+			return fmt.Errorf("destination name %s is used twice in the same request", destination.Name)
+		}
+	}
+	for _, other := range existing {
+		if strings.EqualFold(other.Name, destination.Name) {
+			return fmt.Errorf("destination name %s is already taken", destination.Name)
+		}
+	}
+	return nil
+}
+
+func destinationLabel(destination store.EgressDestination) string {
+	if destination.Name != "" {
+		return destination.Name
+	}
+	if len(destination.IPRanges) > 0 {
+		return fmt.Sprintf("%s-%s", destination.IPRanges[0].Start, destination.IPRanges[0].End)
+	}
+	return destination.GUID
+}
@@ -0,0 +1,158 @@
+package api_test
+
+import (
+	"policy-server/api"
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EgressDestinationValidator", func() {
+	var (
+		validator *api.EgressDestinationValidator
+		existing  []store.EgressDestination
+	)
+
+	destination := func(name, start, end string, startPort, endPort int) store.EgressDestination {
+		return store.EgressDestination{
+			Name:     name,
+			Protocol: "tcp",
+			IPRanges: []store.IPRange{{Start: start, End: end}},
+			Ports:    []store.Ports{{Start: startPort, End: endPort}},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		validator, err = api.NewEgressDestinationValidator([]string{"10.255.0.0/16", "fe80::/10"})
+		Expect(err).NotTo(HaveOccurred())
+
+		existing = []store.EgressDestination{
+			destination("existing", "10.0.0.1", "10.0.0.9", 80, 80),
+		}
+	})
+
+	Describe("NewEgressDestinationValidator", func() {
+		It("rejects an invalid cidr", func() {
+			_, err := api.NewEgressDestinationValidator([]string{"10.255.0.0/99"})
+			Expect(err).To(MatchError("forbidden cidr: invalid cidr for ip range: 10.255.0.0/99"))
+		})
+	})
+
+	Describe("ValidateEgressDestinations", func() {
+		It("accepts destinations that overlap nothing", func() {
+			warnings, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("other-ips", "10.0.1.1", "10.0.1.9", 80, 80),
+				destination("other-ports", "10.0.0.1", "10.0.0.9", 443, 443),
+			}, existing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("rejects a destination that reaches a forbidden range", func() {
+			_, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("overlay", "10.254.255.0", "10.255.0.1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination overlay overlaps forbidden range 10.255.0.0/16"))
+
+			_, err = validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("link-local", "fe80::1", "fe80::1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination link-local overlaps forbidden range fe80::/10"))
+		})
+
+		It("only compares forbidden ranges with destinations of their family", func() {
+			validator, err := api.NewEgressDestinationValidator([]string{"::/8", "0.0.0.0/8"})
+			Expect(err).NotTo(HaveOccurred())
+
+			warnings, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("ipv4", "10.0.1.1", "10.0.1.9", 80, 80),
+				destination("ipv6", "2001:db8::1", "2001:db8::1", 80, 80),
+			}, existing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			_, err = validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("loopback", "::1", "::1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination loopback overlaps forbidden range ::/8"))
+		})
+
+		It("rejects a duplicate of an existing destination", func() {
+			_, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("copy", "10.0.0.1", "10.0.0.9", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination copy duplicates existing destination existing"))
+		})
+
+		It("rejects duplicates in the same request", func() {
+			_, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("a", "10.0.2.1", "10.0.2.1", 80, 80),
+				destination("b", "10.0.2.1", "10.0.2.1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination b duplicates destination a in the same request"))
+		})
+
+		It("rejects a name that is already taken", func() {
+			_, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("existing", "10.0.3.1", "10.0.3.1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination name existing is already taken"))
+
+			_, err = validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("twice", "10.0.3.1", "10.0.3.1", 80, 80),
+				destination("twice", "10.0.4.1", "10.0.4.1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination name twice is used twice in the same request"))
+		})
+
+		It("compares names ignoring case", func() {
+			_, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("Existing", "10.0.3.1", "10.0.3.1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination name Existing is already taken"))
+
+			_, err = validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("twice", "10.0.3.1", "10.0.3.1", 80, 80),
+				destination("TWICE", "10.0.4.1", "10.0.4.1", 80, 80),
+			}, existing)
+			Expect(err).To(MatchError("destination name TWICE is used twice in the same request"))
+		})
+
+		It("warns about partial overlaps", func() {
+			warnings, err := validator.ValidateEgressDestinations([]store.EgressDestination{
+				destination("wider", "10.0.0.0", "10.0.0.255", 1, 1000),
+				destination("narrower", "10.0.0.5", "10.0.0.5", 443, 443),
+			}, existing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal([]string{
+				"destination wider overlaps existing destination existing",
+				"destination narrower overlaps destination wider in the same request",
+			}))
+		})
+	})
+
+	Describe("Overlaps", func() {
+		It("lists forbidden destinations and overlapping pairs", func() {
+			overlaps := validator.Overlaps([]store.EgressDestination{
+				destination("a", "10.0.0.1", "10.0.0.9", 80, 80),
+				destination("b", "10.0.0.1", "10.0.0.9", 80, 80),
+				destination("c", "10.0.0.5", "10.0.0.20", 80, 90),
+				destination("d", "10.255.1.1", "10.255.1.1", 80, 80),
+			})
+
+			Expect(overlaps).To(HaveLen(4))
+			Expect(overlaps[0].ForbiddenCIDR).To(Equal("10.255.0.0/16"))
+			Expect(overlaps[0].Destinations[0].Name).To(Equal("d"))
+
+			var pairs [][2]string
+			for _, overlap := range overlaps[1:] {
+				pairs = append(pairs, [2]string{overlap.Destinations[0].Name, overlap.Destinations[1].Name})
+			}
+			Expect(pairs).To(Equal([][2]string{{"a", "b"}, {"a", "c"}, {"b", "c"}}))
+			Expect(overlaps[1].Duplicate).To(BeTrue())
+			Expect(overlaps[2].Duplicate).To(BeFalse())
+		})
+	})
+})
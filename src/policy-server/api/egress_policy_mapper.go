@@ -17,8 +17,10 @@ type payload struct {
 }
 
 type EgressPolicyPtr struct {
-	Source      *EgressSource         `json:"source"`
-	Destination *EgressDestinationPtr `json:"destination"`
+	Source       *EgressSource         `json:"source"`
+	Destination  *EgressDestinationPtr `json:"destination"`
+	Log          bool                  `json:"log,omitempty"`
+	AppLifecycle string                `json:"app_lifecycle,omitempty"`
 }
 type EgressDestinationPtr struct {
 	GUID string `json:"id,omitempty"`
@@ -52,12 +54,32 @@ func (p *EgressPolicyMapper) AsStoreEgressPolicy(bytes []byte) ([]store.EgressPo
 
 	var storeEgressPolicies []store.EgressPolicy
 	for _, apiEgressPolicy := range payload.EgressPolicies {
-		storeEgressPolicies = append(storeEgressPolicies, asStoreEgressPolicy(apiEgressPolicy))
+		storeEgressPolicy, err := validatedStoreEgressPolicy(apiEgressPolicy)
+		if err != nil {
+			return []store.EgressPolicy{}, err
+		}
+		storeEgressPolicies = append(storeEgressPolicies, storeEgressPolicy)
 	}
 
 	return storeEgressPolicies, nil
 }
 
+func validatedStoreEgressPolicy(apiEgressPolicy EgressPolicy) (store.EgressPolicy, error) {
+	if apiEgressPolicy.Source == nil {
+		return store.EgressPolicy{}, fmt.Errorf("validate egress policies: missing egress source")
+	}
+	if err := validateEgressSource(apiEgressPolicy.Source); err != nil {
+		return store.EgressPolicy{}, fmt.Errorf("validate egress policies: %s", err)
+	}
+	if !validAppLifecycle(apiEgressPolicy.AppLifecycle) {
+		return store.EgressPolicy{}, fmt.Errorf("validate egress policies: app lifecycle must be running, staging or all")
+	}
+	if apiEgressPolicy.Destination == nil || (apiEgressPolicy.Destination.GUID == "" && apiEgressPolicy.Destination.Name == "") {
+		return store.EgressPolicy{}, fmt.Errorf("validate egress policies: missing egress destination id or name")
+	}
+	return asStoreEgressPolicy(apiEgressPolicy), nil
+}
+
 func asApiEgressPolicyPtr(storeEgressPolicy store.EgressPolicy) EgressPolicyPtr {
 	return EgressPolicyPtr{
 		Destination: &EgressDestinationPtr{
@@ -67,6 +89,8 @@ func asApiEgressPolicyPtr(storeEgressPolicy store.EgressPolicy) EgressPolicyPtr
 			ID:   storeEgressPolicy.Source.ID,
 			Type: storeEgressPolicy.Source.Type,
 		},
+		Log:          storeEgressPolicy.Log,
+		AppLifecycle: storeEgressPolicy.AppLifecycle,
 	}
 }
 
@@ -74,10 +98,13 @@ func asStoreEgressPolicy(apiEgressPolicy EgressPolicy) store.EgressPolicy {
 	return store.EgressPolicy{
 		Destination: store.EgressDestination{
 			GUID: apiEgressPolicy.Destination.GUID,
+			Name: apiEgressPolicy.Destination.Name,
 		},
 		Source: store.EgressSource{
 			ID:   apiEgressPolicy.Source.ID,
 			Type: apiEgressPolicy.Source.Type,
 		},
+		Log:          apiEgressPolicy.Log,
+		AppLifecycle: apiEgressPolicy.AppLifecycle,
 	}
 }
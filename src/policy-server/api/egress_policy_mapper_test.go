@@ -48,6 +48,61 @@ var _ = Describe("EgressPolicyMapper", func() {
 			Expect(policies[1].Destination.GUID).To(Equal("some-dst-id-2"))
 		})
 
+		It("maps the app lifecycle", func() {
+			policies, err := mapper.AsStoreEgressPolicy([]byte(`{"egress_policies": [{"source": {"id": "some-src-id"}, "destination": {"id": "some-dst-id"}, "app_lifecycle": "staging"}]}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies[0].AppLifecycle).To(Equal("staging"))
+		})
+
+		It("maps org and default sources", func() {
+			payloadBytes := []byte(`{
+				"egress_policies": [
+					{
+						"source": { "id": "some-org-id", "type": "org" },
+						"destination": { "id": "some-dst-id" }
+					},
+					{
+						"source": { "type": "default" },
+						"destination": { "id": "some-dst-id-2" }
+					}
+				]
+			}`)
+
+			policies, err := mapper.AsStoreEgressPolicy(payloadBytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(2))
+			Expect(policies[0].Source).To(Equal(store.EgressSource{ID: "some-org-id", Type: "org"}))
+			Expect(policies[1].Source).To(Equal(store.EgressSource{Type: "default"}))
+		})
+
+		It("maps a destination given by name", func() {
+			policies, err := mapper.AsStoreEgressPolicy([]byte(`{"egress_policies": [{"source": {"id": "some-src-id"}, "destination": {"name": "some-dst-name"}}]}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies[0].Destination).To(Equal(store.EgressDestination{Name: "some-dst-name"}))
+		})
+
+		Context("when a policy is invalid", func() {
+			It("returns an error when a destination has neither id nor name", func() {
+				_, err := mapper.AsStoreEgressPolicy([]byte(`{"egress_policies": [{"source": {"id": "some-id"}, "destination": {}}]}`))
+				Expect(err).To(MatchError("validate egress policies: missing egress destination id or name"))
+			})
+
+			It("returns an error when a source has no id", func() {
+				_, err := mapper.AsStoreEgressPolicy([]byte(`{"egress_policies": [{"source": {"type": "org"}, "destination": {"id": "some-dst-id"}}]}`))
+				Expect(err).To(MatchError("validate egress policies: missing egress source ID"))
+			})
+
+			It("returns an error when the app lifecycle is unknown", func() {
+				_, err := mapper.AsStoreEgressPolicy([]byte(`{"egress_policies": [{"source": {"id": "some-id"}, "destination": {"id": "some-dst-id"}, "app_lifecycle": "sometimes"}]}`))
+				Expect(err).To(MatchError("validate egress policies: app lifecycle must be running, staging or all"))
+			})
+
+			It("returns an error when a default source has an id", func() {
+				_, err := mapper.AsStoreEgressPolicy([]byte(`{"egress_policies": [{"source": {"id": "some-id", "type": "default"}, "destination": {"id": "some-dst-id"}}]}`))
+				Expect(err).To(MatchError("validate egress policies: default egress source cannot have an ID"))
+			})
+		})
+
 		Context("when unmarshalling fails", func() {
 			It("wraps and returns an error", func() {
 				_, err := mapper.AsStoreEgressPolicy([]byte("garbage"))
@@ -1,10 +1,9 @@
 package api
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"net"
+	"policy-server/store"
 	"sort"
 	"strings"
 
@@ -20,6 +19,7 @@ type egressValidator interface {
 type ccClient interface {
 	GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error)
 	GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveOrgGUIDs(token string, orgGUIDs []string) (map[string]struct{}, error)
 }
 
 //go:generate counterfeiter -o fakes/uua_client.go --fake-name UAAClient . uaaClient
@@ -37,11 +37,11 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 		if policy.Source == nil {
 			return policyMetadataError("missing egress source", policy)
 		}
-		if policy.Source.ID == "" {
-			return policyMetadataError("missing egress source ID", policy)
+		if err := validateEgressSource(policy.Source); err != nil {
+			return policyMetadataError(err.Error(), policy)
 		}
-		if policy.Source.Type != "" && policy.Source.Type != "app" && policy.Source.Type != "space" {
-			return policyMetadataError("source type must be app or space", policy)
+		if !validAppLifecycle(policy.AppLifecycle) {
+			return policyMetadataError("app lifecycle must be running, staging or all", policy)
 		}
 		if policy.Destination == nil {
 			return policyMetadataError("missing egress destination", policy)
@@ -49,41 +49,11 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 		if policy.Destination.Protocol == "" {
 			return policyMetadataError("missing egress destination protocol", policy)
 		}
-		if len(policy.Destination.IPRanges) != 1 {
-			return policyMetadataError("expected exactly one iprange", policy)
+		if err := validateIPRanges(policy.Destination.IPRanges); err != nil {
+			return policyMetadataError(err.Error(), policy)
 		}
-		if policy.Destination.IPRanges[0].Start == "" {
-			return policyMetadataError("missing egress destination iprange start", policy)
-		}
-		startIP := policy.Destination.IPRanges[0].Start
-		parsedStartIP := net.ParseIP(startIP)
-		if parsedStartIP == nil || parsedStartIP.To4() == nil {
-			return policyMetadataError(fmt.Sprintf("invalid ipv4 start ip address for ip range: %v", startIP), policy)
-		}
-		endIP := policy.Destination.IPRanges[0].End
-		parsedEndIP := net.ParseIP(endIP)
-		if parsedEndIP == nil || parsedEndIP.To4() == nil {
-			return policyMetadataError(fmt.Sprintf("invalid ipv4 end ip address for ip range: %v", endIP), policy)
-		}
-
-		if bytes.Compare(parsedStartIP, parsedEndIP) > 0 {
-			return policyMetadataError(fmt.Sprintf("start ip address should be before end ip address: start: %v end: %v", startIP, endIP), policy)
-		}
-
-		if policy.Destination.Protocol != "icmp" && policy.Destination.Protocol != "tcp" && policy.Destination.Protocol != "udp" {
-			return policyMetadataError("protocol must be tcp, udp, or icmp", policy)
-		}
-
-		if policy.Destination.Protocol == "icmp" {
-			if policy.Destination.ICMPType == nil {
-				return policyMetadataError("missing icmp type", policy)
-			}
-			if policy.Destination.ICMPCode == nil {
-				return policyMetadataError("missing icmp code", policy)
-			}
-			if policy.Destination.Ports != nil {
-				return policyMetadataError("ports can not be defined with icmp", policy)
-			}
+		if err := validateProtocolShape(*policy.Destination); err != nil {
+			return policyMetadataError(err.Error(), policy)
 		}
 	}
 
@@ -122,9 +92,59 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 		}
 	}
 
+	orgGUIDSet := sourceOrgGUIDs(policies)
+
+	if len(orgGUIDSet) > 0 {
+		liveOrgGUIDs, err := v.CCClient.GetLiveOrgGUIDs(token, keys(orgGUIDSet))
+		if err != nil {
+			return fmt.Errorf("failed to get live org guids: %s", err)
+		}
+
+		missingOrgGUIDs := relativeComplement(orgGUIDSet, liveOrgGUIDs)
+
+		if len(missingOrgGUIDs) > 0 {
+			return fmt.Errorf("org guids not found: [%s]", strings.Join(missingOrgGUIDs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateEgressSource checks the source type, and that every source but the
+// default, which stands for every app, has an id.
+func validateEgressSource(source *EgressSource) error {
+	switch source.Type {
+	case "", "app", "space", "org":
+		if source.ID == "" {
+			return errors.New("missing egress source ID")
+		}
+	case "default":
+		if source.ID != "" {
+			return errors.New("default egress source cannot have an ID")
+		}
+	default:
+		return errors.New("source type must be app, space, org or default")
+	}
 	return nil
 }
 
+// validateProtocolShape checks that the icmp type and code are given with
+// icmp and only with icmp, then leaves the rest of the shape of the protocol
+// to the store.
+func validateProtocolShape(destination EgressDestination) error {
+	if destination.Protocol == "icmp" {
+		if destination.ICMPType == nil {
+			return errors.New("missing icmp type")
+		}
+		if destination.ICMPCode == nil {
+			return errors.New("missing icmp code")
+		}
+	} else if destination.ICMPType != nil || destination.ICMPCode != nil {
+		return fmt.Errorf("icmp type and code can not be defined with %s", destination.Protocol)
+	}
+	return destination.asStoreEgressDestination().ValidateProtocolShape()
+}
+
 func policyMetadataError(message string, policy EgressPolicy) error {
 	policyAsMap := map[string]interface{}{"bad_egress_policy": policy}
 	return httperror.NewMetadataError(errors.New(message), policyAsMap)
@@ -150,6 +170,16 @@ func sourceSpaceGUIDs(policies []EgressPolicy) map[string]struct{} {
 	return guidSet
 }
 
+func sourceOrgGUIDs(policies []EgressPolicy) map[string]struct{} {
+	guidSet := make(map[string]struct{})
+	for _, policy := range policies {
+		if policy.Source.Type == "org" {
+			guidSet[policy.Source.ID] = struct{}{}
+		}
+	}
+	return guidSet
+}
+
 func keys(set map[string]struct{}) []string {
 	var keys []string
 	for key, _ := range set {
@@ -169,3 +199,11 @@ func relativeComplement(a map[string]struct{}, b map[string]struct{}) []string {
 	sort.Strings(result)
 	return result
 }
+
+func validAppLifecycle(appLifecycle string) bool {
+	switch appLifecycle {
+	case "", store.AppLifecycleRunning, store.AppLifecycleStaging, store.AppLifecycleAll:
+		return true
+	}
+	return false
+}
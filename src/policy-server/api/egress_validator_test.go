@@ -32,6 +32,9 @@ var _ = Describe("Egress Validator", func() {
 		ccClient.GetLiveSpaceGUIDsReturns(map[string]struct{}{
 			"source-space-id": struct{}{},
 		}, nil)
+		ccClient.GetLiveOrgGUIDsReturns(map[string]struct{}{
+			"source-org-id": struct{}{},
+		}, nil)
 		uaaClient.GetTokenReturns("valid-token", nil)
 
 		egressPolicies = []api.EgressPolicy{
@@ -172,13 +175,13 @@ var _ = Describe("Egress Validator", func() {
 			Expect(err).To(MatchError(ContainSubstring("failed to get uaa token: kilo")))
 		})
 
-		It("type must be app, space or empty", func() {
+		It("type must be app, space, org, default or empty", func() {
 			egressPolicies[0].Source.Type = "invalid"
 
 			err := validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("source type must be app or space")))
+			Expect(err).To(MatchError(ContainSubstring("source type must be app, space, org or default")))
 
-			for _, validType := range []string{"app", "space", ""} {
+			for _, validType := range []string{"app", "space", "org", ""} {
 				egressPolicies[0].Source.Type = validType
 				egressPolicies[0].Source.ID = "source-" + validType + "-id"
 				err := validator.ValidateEgressPolicies(egressPolicies)
@@ -186,6 +189,48 @@ var _ = Describe("Egress Validator", func() {
 			}
 		})
 
+		It("requires the default source to have no guid", func() {
+			egressPolicies[0].Source.Type = "default"
+
+			err := validator.ValidateEgressPolicies(egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("default egress source cannot have an ID")))
+
+			egressPolicies[0].Source.ID = ""
+			Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+			Expect(ccClient.GetLiveAppGUIDsCallCount()).To(Equal(0))
+			Expect(ccClient.GetLiveOrgGUIDsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if an org does not exist", func() {
+			egressPolicies[0].Source = &api.EgressSource{Type: "org", ID: "non-existent-org"}
+
+			err := validator.ValidateEgressPolicies(egressPolicies)
+			Expect(err).To(MatchError("org guids not found: [non-existent-org]"))
+
+			_, passedOrgGUIDs := ccClient.GetLiveOrgGUIDsArgsForCall(0)
+			Expect(passedOrgGUIDs).To(Equal([]string{"non-existent-org"}))
+		})
+
+		It("returns an error if it can't query live org guids", func() {
+			egressPolicies[0].Source = &api.EgressSource{Type: "org", ID: "source-org-id"}
+
+			ccClient.GetLiveOrgGUIDsReturns(nil, errors.New("oscar"))
+			err := validator.ValidateEgressPolicies(egressPolicies)
+			Expect(err).To(MatchError("failed to get live org guids: oscar"))
+		})
+
+		It("requires the app lifecycle to be running, staging, all or empty", func() {
+			egressPolicies[0].AppLifecycle = "sometimes"
+
+			err := validator.ValidateEgressPolicies(egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("app lifecycle must be running, staging or all")))
+
+			for _, validAppLifecycle := range []string{"running", "staging", "all", ""} {
+				egressPolicies[0].AppLifecycle = validAppLifecycle
+				Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+			}
+		})
+
 		It("requires a source guid", func() {
 			egressPolicies[0].Source.ID = ""
 
@@ -207,11 +252,33 @@ var _ = Describe("Egress Validator", func() {
 			Expect(err).To(MatchError(ContainSubstring("missing egress destination protocol")))
 		})
 
-		It("requires protocol to be tcp, udp, or icmp", func() {
+		It("requires protocol to be tcp, udp, icmp or all", func() {
 			egressPolicies[0].Destination.Protocol = "invalid"
 
 			err := validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("protocol must be tcp, udp, or icmp")))
+			Expect(err).To(MatchError(ContainSubstring("protocol must be tcp, udp, icmp or all")))
+		})
+
+		Context("when protocol is tcp or udp", func() {
+			It("does not allow icmp type or code to be defined", func() {
+				i := 0
+				egressPolicies[0].Destination.ICMPCode = &i
+
+				err := validator.ValidateEgressPolicies(egressPolicies)
+				Expect(err).To(MatchError(ContainSubstring("icmp type and code can not be defined with tcp")))
+			})
+
+			It("allows only one valid port range", func() {
+				egressPolicies[0].Destination.Ports = []api.Ports{{Start: 80, End: 80}, {Start: 443, End: 443}}
+
+				err := validator.ValidateEgressPolicies(egressPolicies)
+				Expect(err).To(MatchError(ContainSubstring("only one port range can be defined")))
+
+				egressPolicies[0].Destination.Ports = []api.Ports{{Start: 443, End: 80}}
+
+				err = validator.ValidateEgressPolicies(egressPolicies)
+				Expect(err).To(MatchError(ContainSubstring("invalid port range 443-80")))
+			})
 		})
 
 		Context("when protocol is icmp", func() {
@@ -246,6 +313,39 @@ var _ = Describe("Egress Validator", func() {
 				err := validator.ValidateEgressPolicies(egressPolicies)
 				Expect(err).To(MatchError(ContainSubstring("ports can not be defined with icmp")))
 			})
+
+			It("allows -1 as every icmp type only with every icmp code", func() {
+				allICMP, code := -1, 3
+				egressPolicies[0].Destination.ICMPType = &allICMP
+				egressPolicies[0].Destination.ICMPCode = &code
+
+				err := validator.ValidateEgressPolicies(egressPolicies)
+				Expect(err).To(MatchError(ContainSubstring("icmp code must be -1 when icmp type is -1")))
+
+				egressPolicies[0].Destination.ICMPCode = &allICMP
+				Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+			})
+		})
+
+		Context("when protocol is all", func() {
+			BeforeEach(func() {
+				egressPolicies[0].Destination.Protocol = "all"
+				egressPolicies[0].Destination.Ports = nil
+			})
+
+			It("allows neither ports nor icmp type and code", func() {
+				Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+
+				egressPolicies[0].Destination.Ports = []api.Ports{{Start: 80, End: 80}}
+				err := validator.ValidateEgressPolicies(egressPolicies)
+				Expect(err).To(MatchError(ContainSubstring("ports can not be defined with all")))
+
+				i := 8
+				egressPolicies[0].Destination.Ports = nil
+				egressPolicies[0].Destination.ICMPType = &i
+				err = validator.ValidateEgressPolicies(egressPolicies)
+				Expect(err).To(MatchError(ContainSubstring("icmp type and code can not be defined with all")))
+			})
 		})
 
 		It("requires ip range", func() {
@@ -265,28 +365,46 @@ var _ = Describe("Egress Validator", func() {
 			Expect(err).To(MatchError(ContainSubstring("expected exactly one iprange")))
 		})
 
-		It("requires valid start v4 ip addresses", func() {
+		It("requires valid start ip addresses", func() {
 			egressPolicies[0].Destination.IPRanges[0].Start = "1"
 
 			err := validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("invalid ipv4 start ip address for ip range: 1")))
+			Expect(err).To(MatchError(ContainSubstring("invalid start ip address for ip range: 1")))
+		})
 
-			egressPolicies[0].Destination.IPRanges[0].Start = "2001:db8:85a3:0:0:8a2e:370:7334"
+		It("requires valid end ip addresses", func() {
+			egressPolicies[0].Destination.IPRanges[0].End = "255.255.255.256"
 
-			err = validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("invalid ipv4 start ip address for ip range: 2001:db8:85a3:0:0:8a2e:370:7334")))
+			err := validator.ValidateEgressPolicies(egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("invalid end ip address for ip range: 255.255.255.256")))
 		})
 
-		It("requires valid end v4 ip addresses", func() {
-			egressPolicies[0].Destination.IPRanges[0].End = "255.255.255.256"
+		It("allows ipv6 ranges", func() {
+			egressPolicies[0].Destination.IPRanges[0] = api.IPRange{Start: "2001:db8::1", End: "2001:db8::ff"}
+
+			Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+		})
+
+		It("allows cidrs", func() {
+			egressPolicies[0].Destination.IPRanges[0] = api.IPRange{CIDR: "2001:db8::/64"}
+			Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+
+			egressPolicies[0].Destination.IPRanges[0] = api.IPRange{CIDR: "10.0.0.0/8"}
+			Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+		})
+
+		It("requires valid cidrs", func() {
+			egressPolicies[0].Destination.IPRanges[0] = api.IPRange{CIDR: "10.0.0.0/33"}
 
 			err := validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("invalid ipv4 end ip address for ip range: 255.255.255.256")))
+			Expect(err).To(MatchError(ContainSubstring("invalid cidr for ip range: 10.0.0.0/33")))
+		})
 
-			egressPolicies[0].Destination.IPRanges[0].End = "2001:db8:85a3:0:0:8a2e:370:7334"
+		It("requires both ends of a range to be of the same family", func() {
+			egressPolicies[0].Destination.IPRanges[0] = api.IPRange{Start: "1.2.3.4", End: "2001:db8::1"}
 
-			err = validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("invalid ipv4 end ip address for ip range: 2001:db8:85a3:0:0:8a2e:370:7334")))
+			err := validator.ValidateEgressPolicies(egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("start and end ip address must be of the same family: start: 1.2.3.4 end: 2001:db8::1")))
 		})
 
 		It("requires start ip address to be before end", func() {
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+)
+
+const (
+	FamilyIPv4 = "ipv4"
+	FamilyIPv6 = "ipv6"
+)
+
+// Bounds returns the first and last address of the range, which is given
+// either as a start and end address or as a CIDR. Both ends must be of the
+// same address family.
+func (r IPRange) Bounds() (net.IP, net.IP, error) {
+	if r.CIDR != "" {
+		if r.Start != "" || r.End != "" {
+			return nil, nil, fmt.Errorf("ip range must have either a cidr or a start and end, not both")
+		}
+		_, network, err := net.ParseCIDR(r.CIDR)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cidr for ip range: %v", r.CIDR)
+		}
+		return network.IP, lastIP(network), nil
+	}
+
+	if r.Start == "" {
+		return nil, nil, fmt.Errorf("missing egress destination iprange start")
+	}
+	start := net.ParseIP(r.Start)
+	if start == nil {
+		return nil, nil, fmt.Errorf("invalid start ip address for ip range: %v", r.Start)
+	}
+	end := net.ParseIP(r.End)
+	if end == nil {
+		return nil, nil, fmt.Errorf("invalid end ip address for ip range: %v", r.End)
+	}
+	if IPFamily(start) != IPFamily(end) {
+		return nil, nil, fmt.Errorf("start and end ip address must be of the same family: start: %v end: %v", r.Start, r.End)
+	}
+	if bytes.Compare(start.To16(), end.To16()) > 0 {
+		return nil, nil, fmt.Errorf("start ip address should be before end ip address: start: %v end: %v", r.Start, r.End)
+	}
+	return start, end, nil
+}
+
+// Normalized returns the range as canonical start and end addresses, which
+// is how ranges are stored.
+func (r IPRange) Normalized() (IPRange, error) {
+	start, end, err := r.Bounds()
+	if err != nil {
+		return IPRange{}, err
+	}
+	return IPRange{Start: start.String(), End: end.String()}, nil
+}
+
+// IPFamily is ipv4 for ipv4 addresses, including ipv4-mapped ipv6 ones, and
+// ipv6 for any other address.
+func IPFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return FamilyIPv4
+	}
+	return FamilyIPv6
+}
+
+func lastIP(network *net.IPNet) net.IP {
+	ip := make(net.IP, len(network.IP))
+	for i := range network.IP {
+		ip[i] = network.IP[i] | ^network.Mask[i]
+	}
+	return ip
+}
+
+func validateIPRanges(ipRanges []IPRange) error {
+	if len(ipRanges) != 1 {
+		return fmt.Errorf("expected exactly one iprange")
+	}
+	_, _, err := ipRanges[0].Bounds()
+	return err
+}
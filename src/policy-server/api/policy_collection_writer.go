@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"net"
 	"policy-server/store"
 
 	"code.cloudfoundry.org/cf-networking-helpers/marshal"
@@ -49,13 +50,24 @@ func (p *policyCollectionWriter) AsBytes(policies []store.Policy, egressPolicies
 	return bytes, nil
 }
 
+// mapStoreEgressPolicy marks the address family of each ip range, so that
+// agents know whether to write iptables or ip6tables rules for it. Agents
+// only need the traffic of a destination, so its id and name are left out.
 func mapStoreEgressPolicy(storeEgressPolicy store.EgressPolicy) EgressPolicy {
 	destination := asApiEgressDestination(storeEgressPolicy.Destination)
+	destination.GUID, destination.Name, destination.Description = "", "", ""
+	for i, ipRange := range destination.IPRanges {
+		if ip := net.ParseIP(ipRange.Start); ip != nil {
+			destination.IPRanges[i].Family = IPFamily(ip)
+		}
+	}
 	return EgressPolicy{
 		Source: &EgressSource{
 			ID:   storeEgressPolicy.Source.ID,
 			Type: storeEgressPolicy.Source.Type,
 		},
-		Destination: &destination,
+		Destination:  &destination,
+		Log:          storeEgressPolicy.Log,
+		AppLifecycle: storeEgressPolicy.AppLifecycle,
 	}
 }
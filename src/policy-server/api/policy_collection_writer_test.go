@@ -90,7 +90,7 @@ var _ = Describe("PolicyCollectionWriter", func() {
 						{
 							"source": {"id": "some-egress-app-guid", "type": "app"},
 							"destination": {
-								"ips": [{"start": "8.0.8.0", "end": "8.0.8.0"}],
+								"ips": [{"start": "8.0.8.0", "end": "8.0.8.0", "family": "ipv4"}],
 								"protocol": "tcp"
 							}
 						}
@@ -99,6 +99,104 @@ var _ = Describe("PolicyCollectionWriter", func() {
 			))
 		})
 
+		It("marks ipv6 ranges", func() {
+			egressPolicies := []store.EgressPolicy{{
+				Source: store.EgressSource{ID: "some-egress-app-guid", Type: "app"},
+				Destination: store.EgressDestination{
+					Protocol: "tcp",
+					IPRanges: []store.IPRange{{Start: "2001:db8::", End: "2001:db8::ff"}},
+				},
+			}}
+
+			payload, err := writer.AsBytes(nil, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{
+				"total_policies": 0,
+				"policies": [],
+				"total_egress_policies": 1,
+				"egress_policies": [{
+					"source": {"id": "some-egress-app-guid", "type": "app"},
+					"destination": {
+						"ips": [{"start": "2001:db8::", "end": "2001:db8::ff", "family": "ipv6"}],
+						"protocol": "tcp"
+					}
+				}]
+			}`))
+		})
+
+		Context("when policies have logging enabled", func() {
+			It("includes the log attribute", func() {
+				policies := []store.Policy{{
+					Source: store.Source{ID: "some-src-id"},
+					Destination: store.Destination{
+						ID:       "some-dst-id",
+						Protocol: "tcp",
+						Ports:    store.Ports{Start: 8080, End: 8080},
+					},
+					Log: true,
+				}}
+				egressPolicies := []store.EgressPolicy{{
+					Source: store.EgressSource{ID: "some-egress-app-guid", Type: "app"},
+					Destination: store.EgressDestination{
+						Protocol: "tcp",
+						IPRanges: []store.IPRange{{Start: "8.0.8.0", End: "8.0.8.0"}},
+					},
+					Log: true,
+				}}
+
+				payload, err := writer.AsBytes(policies, egressPolicies)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{
+					"total_policies": 1,
+					"policies": [{
+						"source": { "id": "some-src-id" },
+						"destination": {
+							"id": "some-dst-id",
+							"protocol": "tcp",
+							"ports": { "start": 8080, "end": 8080 }
+						},
+						"log": true
+					}],
+					"total_egress_policies": 1,
+					"egress_policies": [{
+						"source": {"id": "some-egress-app-guid", "type": "app"},
+						"destination": {
+							"ips": [{"start": "8.0.8.0", "end": "8.0.8.0", "family": "ipv4"}],
+							"protocol": "tcp"
+						},
+						"log": true
+					}]
+				}`))
+			})
+		})
+
+		It("includes the app lifecycle of egress policies", func() {
+			egressPolicies := []store.EgressPolicy{{
+				Source: store.EgressSource{ID: "some-egress-app-guid", Type: "app"},
+				Destination: store.EgressDestination{
+					Protocol: "tcp",
+					IPRanges: []store.IPRange{{Start: "8.0.8.0", End: "8.0.8.0"}},
+				},
+				AppLifecycle: "staging",
+			}}
+
+			payload, err := writer.AsBytes([]store.Policy{}, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{
+				"total_policies": 0,
+				"policies": [],
+				"total_egress_policies": 1,
+				"egress_policies": [{
+					"source": {"id": "some-egress-app-guid", "type": "app"},
+					"destination": {
+						"ips": [{"start": "8.0.8.0", "end": "8.0.8.0", "family": "ipv4"}],
+						"protocol": "tcp"
+					},
+					"app_lifecycle": "staging"
+				}]
+			}`))
+		})
+
 		Context("when marshalling fails", func() {
 			BeforeEach(func() {
 				fakeMarshaler.MarshalReturns(nil, errors.New("banana"))
@@ -0,0 +1,13 @@
+package asg_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAsg(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Asg Suite")
+}
@@ -0,0 +1,188 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store"
+	"sync"
+)
+
+type EgressDestinationStore struct {
+	AllStub        func() ([]store.EgressDestination, error)
+	allMutex       sync.RWMutex
+	allArgsForCall []struct {
+	}
+	allReturns struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
+	allReturnsOnCall map[int]struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
+	CreateStub        func([]store.EgressDestination) ([]store.EgressDestination, error)
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		arg1 []store.EgressDestination
+	}
+	createReturns struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
+	createReturnsOnCall map[int]struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EgressDestinationStore) All() ([]store.EgressDestination, error) {
+	fake.allMutex.Lock()
+	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+	}{})
+	stub := fake.AllStub
+	fakeReturns := fake.allReturns
+	fake.recordInvocation("All", []interface{}{})
+	fake.allMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressDestinationStore) AllCallCount() int {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return len(fake.allArgsForCall)
+}
+
+func (fake *EgressDestinationStore) AllCalls(stub func() ([]store.EgressDestination, error)) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = stub
+}
+
+func (fake *EgressDestinationStore) AllReturns(result1 []store.EgressDestination, result2 error) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = nil
+	fake.allReturns = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationStore) AllReturnsOnCall(i int, result1 []store.EgressDestination, result2 error) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = nil
+	if fake.allReturnsOnCall == nil {
+		fake.allReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressDestination
+			result2 error
+		})
+	}
+	fake.allReturnsOnCall[i] = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationStore) Create(arg1 []store.EgressDestination) ([]store.EgressDestination, error) {
+	var arg1Copy []store.EgressDestination
+	if arg1 != nil {
+		arg1Copy = make([]store.EgressDestination, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		arg1 []store.EgressDestination
+	}{arg1Copy})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
+	fake.recordInvocation("Create", []interface{}{arg1Copy})
+	fake.createMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressDestinationStore) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *EgressDestinationStore) CreateCalls(stub func([]store.EgressDestination) ([]store.EgressDestination, error)) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
+func (fake *EgressDestinationStore) CreateArgsForCall(i int) []store.EgressDestination {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EgressDestinationStore) CreateReturns(result1 []store.EgressDestination, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	fake.createReturns = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationStore) CreateReturnsOnCall(i int, result1 []store.EgressDestination, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressDestination
+			result2 error
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *EgressDestinationStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,125 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store"
+	"sync"
+)
+
+type EgressDestinationValidator struct {
+	ValidateEgressDestinationsStub        func([]store.EgressDestination, []store.EgressDestination) ([]string, error)
+	validateEgressDestinationsMutex       sync.RWMutex
+	validateEgressDestinationsArgsForCall []struct {
+		arg1 []store.EgressDestination
+		arg2 []store.EgressDestination
+	}
+	validateEgressDestinationsReturns struct {
+		result1 []string
+		result2 error
+	}
+	validateEgressDestinationsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EgressDestinationValidator) ValidateEgressDestinations(arg1 []store.EgressDestination, arg2 []store.EgressDestination) ([]string, error) {
+	var arg1Copy []store.EgressDestination
+	if arg1 != nil {
+		arg1Copy = make([]store.EgressDestination, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []store.EgressDestination
+	if arg2 != nil {
+		arg2Copy = make([]store.EgressDestination, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.validateEgressDestinationsMutex.Lock()
+	ret, specificReturn := fake.validateEgressDestinationsReturnsOnCall[len(fake.validateEgressDestinationsArgsForCall)]
+	fake.validateEgressDestinationsArgsForCall = append(fake.validateEgressDestinationsArgsForCall, struct {
+		arg1 []store.EgressDestination
+		arg2 []store.EgressDestination
+	}{arg1Copy, arg2Copy})
+	stub := fake.ValidateEgressDestinationsStub
+	fakeReturns := fake.validateEgressDestinationsReturns
+	fake.recordInvocation("ValidateEgressDestinations", []interface{}{arg1Copy, arg2Copy})
+	fake.validateEgressDestinationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressDestinationValidator) ValidateEgressDestinationsCallCount() int {
+	fake.validateEgressDestinationsMutex.RLock()
+	defer fake.validateEgressDestinationsMutex.RUnlock()
+	return len(fake.validateEgressDestinationsArgsForCall)
+}
+
+func (fake *EgressDestinationValidator) ValidateEgressDestinationsCalls(stub func([]store.EgressDestination, []store.EgressDestination) ([]string, error)) {
+	fake.validateEgressDestinationsMutex.Lock()
+	defer fake.validateEgressDestinationsMutex.Unlock()
+	fake.ValidateEgressDestinationsStub = stub
+}
+
+func (fake *EgressDestinationValidator) ValidateEgressDestinationsArgsForCall(i int) ([]store.EgressDestination, []store.EgressDestination) {
+	fake.validateEgressDestinationsMutex.RLock()
+	defer fake.validateEgressDestinationsMutex.RUnlock()
+	argsForCall := fake.validateEgressDestinationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressDestinationValidator) ValidateEgressDestinationsReturns(result1 []string, result2 error) {
+	fake.validateEgressDestinationsMutex.Lock()
+	defer fake.validateEgressDestinationsMutex.Unlock()
+	fake.ValidateEgressDestinationsStub = nil
+	fake.validateEgressDestinationsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationValidator) ValidateEgressDestinationsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.validateEgressDestinationsMutex.Lock()
+	defer fake.validateEgressDestinationsMutex.Unlock()
+	fake.ValidateEgressDestinationsStub = nil
+	if fake.validateEgressDestinationsReturnsOnCall == nil {
+		fake.validateEgressDestinationsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.validateEgressDestinationsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateEgressDestinationsMutex.RLock()
+	defer fake.validateEgressDestinationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *EgressDestinationValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
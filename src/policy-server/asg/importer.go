@@ -0,0 +1,347 @@
+package asg
+
+import (
+	"fmt"
+	"policy-server/cc_client"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/uaa_client.go --fake-name UAAClient . uaaClient
+type uaaClient interface {
+	GetToken() (string, error)
+}
+
+//go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
+type ccClient interface {
+	GetSecurityGroups(token string) ([]cc_client.SecurityGroup, error)
+}
+
+//go:generate counterfeiter -o fakes/egress_destination_store.go --fake-name EgressDestinationStore . egressDestinationStore
+type egressDestinationStore interface {
+	All() ([]store.EgressDestination, error)
+	Create([]store.EgressDestination) ([]store.EgressDestination, error)
+}
+
+//go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
+type egressPolicyStore interface {
+	All() ([]store.EgressPolicy, error)
+	Create([]store.EgressPolicy) ([]store.EgressPolicy, error)
+}
+
+//go:generate counterfeiter -o fakes/egress_destination_validator.go --fake-name EgressDestinationValidator . egressDestinationValidator
+type egressDestinationValidator interface {
+	ValidateEgressDestinations(destinations, existing []store.EgressDestination) ([]string, error)
+}
+
+const (
+	ActionCreate    = "create"
+	ActionUnchanged = "unchanged"
+	ActionConflict  = "conflict"
+)
+
+// Report lists what an import creates, what already exists, and what it
+// cannot convert.
+type Report struct {
+	DryRun         bool                `json:"dry_run"`
+	Destinations   []DestinationChange `json:"destinations"`
+	EgressPolicies []PolicyChange      `json:"egress_policies"`
+	Skipped        []Skipped           `json:"skipped"`
+}
+
+// DestinationChange is a destination for one ASG rule. A conflict is a
+// destination of the same name that allows different traffic, which the
+// import leaves alone.
+type DestinationChange struct {
+	Action        string `json:"action"`
+	GUID          string `json:"id,omitempty"`
+	Name          string `json:"name"`
+	SecurityGroup string `json:"security_group"`
+	Protocol      string `json:"protocol"`
+	StartIP       string `json:"start_ip"`
+	EndIP         string `json:"end_ip"`
+	StartPort     int    `json:"start_port,omitempty"`
+	EndPort       int    `json:"end_port,omitempty"`
+	ICMPType      *int   `json:"icmp_type,omitempty"`
+	ICMPCode      *int   `json:"icmp_code,omitempty"`
+}
+
+// PolicyChange is an egress policy from a space the group is bound to, or
+// from the default source for a globally enabled group, which has no space.
+type PolicyChange struct {
+	Action          string `json:"action"`
+	SourceType      string `json:"source_type"`
+	SpaceGUID       string `json:"space_id,omitempty"`
+	AppLifecycle    string `json:"app_lifecycle"`
+	DestinationName string `json:"destination_name"`
+	DestinationGUID string `json:"destination_id,omitempty"`
+}
+
+// Skipped is a rule, or a whole security group when Rule is nil, that is
+// not imported. Rule is the index of the rule in the security group.
+type Skipped struct {
+	SecurityGroup string `json:"security_group"`
+	Rule          *int   `json:"rule,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// Importer converts the application security groups in Cloud Controller to
+// egress destinations, one or more per rule, and to egress policies from the
+// spaces each group is bound to, for running or staging apps, and from the
+// default source when the group is globally enabled. Destinations are named
+// asg-<group>-<rule>, so that running it again only creates what is missing.
+// New destinations are validated like those created through the API, and a
+// rule whose destination is forbidden or a duplicate is skipped.
+type Importer struct {
+	Logger                     lager.Logger
+	UAAClient                  uaaClient
+	CCClient                   ccClient
+	EgressDestinationStore     egressDestinationStore
+	EgressPolicyStore          egressPolicyStore
+	EgressDestinationValidator egressDestinationValidator
+}
+
+// Import creates the missing destinations and policies and reports them, or
+// only reports them when dryRun is set. Destinations are created before
+// policies, in separate transactions, so an import that fails part way is
+// finished by running it again.
+func (i *Importer) Import(dryRun bool) (Report, error) {
+	token, err := i.UAAClient.GetToken()
+	if err != nil {
+		return Report{}, fmt.Errorf("get uaa token: %s", err)
+	}
+	securityGroups, err := i.CCClient.GetSecurityGroups(token)
+	if err != nil {
+		return Report{}, fmt.Errorf("get security groups: %s", err)
+	}
+	existingDestinations, err := i.EgressDestinationStore.All()
+	if err != nil {
+		return Report{}, fmt.Errorf("list egress destinations: %s", err)
+	}
+	existingPolicies, err := i.EgressPolicyStore.All()
+	if err != nil {
+		return Report{}, fmt.Errorf("list egress policies: %s", err)
+	}
+
+	p := newPlan(securityGroups, existingDestinations, existingPolicies, i.EgressDestinationValidator)
+	p.report.DryRun = dryRun
+	if dryRun {
+		return p.report, nil
+	}
+
+	if len(p.newDestinations) > 0 {
+		created, err := i.EgressDestinationStore.Create(p.newDestinations)
+		if err != nil {
+			return Report{}, fmt.Errorf("create egress destinations: %s", err)
+		}
+		for _, destination := range created {
+			p.destinationGUIDs[destination.Name] = destination.GUID
+		}
+	}
+
+	var newPolicies []store.EgressPolicy
+	for n, change := range p.report.EgressPolicies {
+		if change.Action != ActionCreate {
+			continue
+		}
+		p.report.EgressPolicies[n].DestinationGUID = p.destinationGUIDs[change.DestinationName]
+		newPolicies = append(newPolicies, store.EgressPolicy{
+			Source:       store.EgressSource{ID: change.SpaceGUID, Type: change.SourceType},
+			Destination:  store.EgressDestination{GUID: p.destinationGUIDs[change.DestinationName]},
+			AppLifecycle: change.AppLifecycle,
+		})
+	}
+	for n, change := range p.report.Destinations {
+		if change.Action == ActionCreate {
+			p.report.Destinations[n].GUID = p.destinationGUIDs[change.Name]
+		}
+	}
+
+	if len(newPolicies) > 0 {
+		_, err = i.EgressPolicyStore.Create(newPolicies)
+		if err != nil {
+			return Report{}, fmt.Errorf("create egress policies: %s", err)
+		}
+	}
+
+	i.Logger.Info("imported-security-groups", lager.Data{
+		"destinations":    len(p.newDestinations),
+		"egress-policies": len(newPolicies),
+		"skipped":         len(p.report.Skipped),
+	})
+	return p.report, nil
+}
+
+type plan struct {
+	report           Report
+	newDestinations  []store.EgressDestination
+	destinationGUIDs map[string]string
+}
+
+func newPlan(securityGroups []cc_client.SecurityGroup, existingDestinations []store.EgressDestination, existingPolicies []store.EgressPolicy, validator egressDestinationValidator) *plan {
+	p := &plan{
+		report: Report{
+			Destinations:   []DestinationChange{},
+			EgressPolicies: []PolicyChange{},
+			Skipped:        []Skipped{},
+		},
+		destinationGUIDs: map[string]string{},
+	}
+
+	existingByName := map[string]store.EgressDestination{}
+	for _, destination := range existingDestinations {
+		existingByName[destination.Name] = destination
+	}
+	existingLifecycles := map[policySource][]string{}
+	for _, policy := range existingPolicies {
+		if policy.Source.Type == "space" || policy.Source.Type == "default" {
+			source := policySource{policy.Source.Type, policy.Source.ID, policy.Destination.GUID}
+			existingLifecycles[source] = append(existingLifecycles[source], policy.AppLifecycle)
+		}
+	}
+
+	for _, securityGroup := range securityGroups {
+		var usable []string
+		for ruleIndex, rule := range securityGroup.Rules {
+			destinations, err := convertRule(rule)
+			if err != nil {
+				index := ruleIndex
+				p.skip(securityGroup, &index, err.Error())
+				continue
+			}
+
+			for n, destination := range destinations {
+				destination.Name = destinationName(securityGroup.Name, ruleIndex, n, len(destinations))
+				destination.Description = fmt.Sprintf("imported from security group %s", securityGroup.Name)
+				if rule.Description != "" {
+					destination.Description += ": " + rule.Description
+				}
+
+				change := destinationChange(securityGroup.Name, destination)
+				if existing, ok := existingByName[destination.Name]; ok {
+					change.GUID = existing.GUID
+					change.Action = ActionUnchanged
+					if !existing.SameTraffic(destination) {
+						change.Action = ActionConflict
+					}
+				} else {
+					known := append(append([]store.EgressDestination{}, existingDestinations...), p.newDestinations...)
+					if _, err := validator.ValidateEgressDestinations([]store.EgressDestination{destination}, known); err != nil {
+						index := ruleIndex
+						p.skip(securityGroup, &index, err.Error())
+						continue
+					}
+					p.newDestinations = append(p.newDestinations, destination)
+				}
+				p.report.Destinations = append(p.report.Destinations, change)
+
+				if change.Action != ActionConflict {
+					p.destinationGUIDs[destination.Name] = change.GUID
+					usable = append(usable, destination.Name)
+				}
+			}
+		}
+
+		for _, source := range policySources(securityGroup) {
+			for _, name := range usable {
+				change := PolicyChange{
+					Action:          ActionCreate,
+					SourceType:      source.sourceType,
+					SpaceGUID:       source.spaceGUID,
+					AppLifecycle:    source.appLifecycle,
+					DestinationName: name,
+					DestinationGUID: p.destinationGUIDs[name],
+				}
+				existing := existingLifecycles[policySource{source.sourceType, source.spaceGUID, change.DestinationGUID}]
+				if change.DestinationGUID != "" && coversLifecycle(existing, source.appLifecycle) {
+					change.Action = ActionUnchanged
+				}
+				p.report.EgressPolicies = append(p.report.EgressPolicies, change)
+			}
+		}
+	}
+	return p
+}
+
+type policySource struct {
+	sourceType      string
+	sourceID        string
+	destinationGUID string
+}
+
+type groupSource struct {
+	sourceType   string
+	spaceGUID    string
+	appLifecycle string
+}
+
+// policySources are the spaces a group is bound to for running and for
+// staging apps, and the default source for each lifecycle the group is
+// globally enabled for.
+func policySources(securityGroup cc_client.SecurityGroup) []groupSource {
+	var sources []groupSource
+	for _, spaceGUID := range securityGroup.Relationships.RunningSpaces.GUIDs() {
+		sources = append(sources, groupSource{"space", spaceGUID, store.AppLifecycleRunning})
+	}
+	for _, spaceGUID := range securityGroup.Relationships.StagingSpaces.GUIDs() {
+		sources = append(sources, groupSource{"space", spaceGUID, store.AppLifecycleStaging})
+	}
+	if securityGroup.GloballyEnabled.Running {
+		sources = append(sources, groupSource{"default", "", store.AppLifecycleRunning})
+	}
+	if securityGroup.GloballyEnabled.Staging {
+		sources = append(sources, groupSource{"default", "", store.AppLifecycleStaging})
+	}
+	return sources
+}
+
+// coversLifecycle is true when one of the existing policies applies to apps
+// in the lifecycle. Policies created before lifecycles existed apply to all.
+func coversLifecycle(existing []string, appLifecycle string) bool {
+	for _, lifecycle := range existing {
+		if lifecycle == appLifecycle || lifecycle == store.AppLifecycleAll || lifecycle == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *plan) skip(securityGroup cc_client.SecurityGroup, rule *int, reason string) {
+	p.report.Skipped = append(p.report.Skipped, Skipped{
+		SecurityGroup: securityGroup.Name,
+		Rule:          rule,
+		Reason:        reason,
+	})
+}
+
+// destinationName numbers rules from one, and the destinations of a rule
+// that needs more than one by a further suffix.
+func destinationName(securityGroupName string, ruleIndex, n, count int) string {
+	name := fmt.Sprintf("asg-%s-%d", securityGroupName, ruleIndex+1)
+	if count > 1 {
+		name = fmt.Sprintf("%s-%d", name, n+1)
+	}
+	return name
+}
+
+func destinationChange(securityGroupName string, destination store.EgressDestination) DestinationChange {
+	change := DestinationChange{
+		Action:        ActionCreate,
+		Name:          destination.Name,
+		SecurityGroup: securityGroupName,
+		Protocol:      destination.Protocol,
+		StartIP:       destination.IPRanges[0].Start,
+		EndIP:         destination.IPRanges[0].End,
+	}
+	if len(destination.Ports) > 0 {
+		change.StartPort = destination.Ports[0].Start
+		change.EndPort = destination.Ports[0].End
+	}
+	if destination.Protocol == "icmp" {
+		icmpType, icmpCode := destination.ICMPType, destination.ICMPCode
+		change.ICMPType = &icmpType
+		change.ICMPCode = &icmpCode
+	}
+	return change
+}
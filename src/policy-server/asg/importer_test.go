@@ -0,0 +1,466 @@
+package asg_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"lib/testsupport"
+	"policy-server/api"
+	"policy-server/asg"
+	"policy-server/asg/fakes"
+	"policy-server/cc_client"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Importer", func() {
+	var (
+		importer             *asg.Importer
+		fakeUAAClient        *fakes.UAAClient
+		fakeCCClient         *fakes.CCClient
+		fakeDestinationStore *fakes.EgressDestinationStore
+		fakePolicyStore      *fakes.EgressPolicyStore
+		fakeValidator        *fakes.EgressDestinationValidator
+		securityGroups       []cc_client.SecurityGroup
+	)
+
+	securityGroup := func(name string, runningSpaces []string, rules ...cc_client.SecurityGroupRule) cc_client.SecurityGroup {
+		var group cc_client.SecurityGroup
+		group.GUID = name + "-guid"
+		group.Name = name
+		group.Rules = rules
+		for _, space := range runningSpaces {
+			group.Relationships.RunningSpaces.Data = append(group.Relationships.RunningSpaces.Data, struct {
+				GUID string `json:"guid"`
+			}{GUID: space})
+		}
+		return group
+	}
+
+	BeforeEach(func() {
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+		fakeCCClient = &fakes.CCClient{}
+		fakeDestinationStore = &fakes.EgressDestinationStore{}
+		fakeDestinationStore.CreateStub = func(destinations []store.EgressDestination) ([]store.EgressDestination, error) {
+			var created []store.EgressDestination
+			for i, destination := range destinations {
+				destination.GUID = fmt.Sprintf("new-guid-%d", i)
+				created = append(created, destination)
+			}
+			return created, nil
+		}
+		fakePolicyStore = &fakes.EgressPolicyStore{}
+		fakeValidator = &fakes.EgressDestinationValidator{}
+
+		securityGroups = []cc_client.SecurityGroup{
+			securityGroup("dns", []string{"space-1", "space-2"}, cc_client.SecurityGroupRule{
+				Protocol:    "udp",
+				Destination: "10.0.0.2-10.0.0.3",
+				Ports:       "53",
+				Description: "internal dns",
+			}),
+		}
+		fakeCCClient.GetSecurityGroupsStub = func(string) ([]cc_client.SecurityGroup, error) {
+			return securityGroups, nil
+		}
+
+		importer = &asg.Importer{
+			Logger:                     lagertest.NewTestLogger("test"),
+			UAAClient:                  fakeUAAClient,
+			CCClient:                   fakeCCClient,
+			EgressDestinationStore:     fakeDestinationStore,
+			EgressPolicyStore:          fakePolicyStore,
+			EgressDestinationValidator: fakeValidator,
+		}
+	})
+
+	It("creates a destination per rule and a policy per running space", func() {
+		report, err := importer.Import(false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeCCClient.GetSecurityGroupsArgsForCall(0)).To(Equal("some-token"))
+
+		Expect(fakeDestinationStore.CreateArgsForCall(0)).To(Equal([]store.EgressDestination{{
+			Name:        "asg-dns-1",
+			Description: "imported from security group dns: internal dns",
+			Protocol:    "udp",
+			IPRanges:    []store.IPRange{{Start: "10.0.0.2", End: "10.0.0.3"}},
+			Ports:       []store.Ports{{Start: 53, End: 53}},
+		}}))
+		Expect(fakePolicyStore.CreateArgsForCall(0)).To(Equal([]store.EgressPolicy{
+			{
+				Source:       store.EgressSource{ID: "space-1", Type: "space"},
+				Destination:  store.EgressDestination{GUID: "new-guid-0"},
+				AppLifecycle: "running",
+			},
+			{
+				Source:       store.EgressSource{ID: "space-2", Type: "space"},
+				Destination:  store.EgressDestination{GUID: "new-guid-0"},
+				AppLifecycle: "running",
+			},
+		}))
+
+		reportJSON, err := json.Marshal(report)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reportJSON).To(MatchJSON(`{
+			"dry_run": false,
+			"destinations": [{
+				"action": "create",
+				"id": "new-guid-0",
+				"name": "asg-dns-1",
+				"security_group": "dns",
+				"protocol": "udp",
+				"start_ip": "10.0.0.2",
+				"end_ip": "10.0.0.3",
+				"start_port": 53,
+				"end_port": 53
+			}],
+			"egress_policies": [
+				{"action": "create", "source_type": "space", "space_id": "space-1", "app_lifecycle": "running", "destination_name": "asg-dns-1", "destination_id": "new-guid-0"},
+				{"action": "create", "source_type": "space", "space_id": "space-2", "app_lifecycle": "running", "destination_name": "asg-dns-1", "destination_id": "new-guid-0"}
+			],
+			"skipped": []
+		}`))
+	})
+
+	Context("when it is a dry run", func() {
+		It("reports without creating anything", func() {
+			report, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.Destinations).To(HaveLen(1))
+			Expect(report.Destinations[0].Action).To(Equal(asg.ActionCreate))
+			Expect(report.EgressPolicies).To(HaveLen(2))
+
+			Expect(fakeDestinationStore.CreateCallCount()).To(Equal(0))
+			Expect(fakePolicyStore.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the destinations and policies already exist", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{{
+				GUID:     "existing-guid",
+				Name:     "asg-dns-1",
+				Protocol: "udp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.2", End: "10.0.0.3"}},
+				Ports:    []store.Ports{{Start: 53, End: 53}},
+			}}, nil)
+			fakePolicyStore.AllReturns([]store.EgressPolicy{{
+				Source:      store.EgressSource{ID: "space-1", Type: "space"},
+				Destination: store.EgressDestination{GUID: "existing-guid"},
+			}}, nil)
+		})
+
+		It("only creates what is missing", func() {
+			report, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDestinationStore.CreateCallCount()).To(Equal(0))
+			Expect(fakePolicyStore.CreateArgsForCall(0)).To(Equal([]store.EgressPolicy{{
+				Source:       store.EgressSource{ID: "space-2", Type: "space"},
+				Destination:  store.EgressDestination{GUID: "existing-guid"},
+				AppLifecycle: "running",
+			}}))
+
+			Expect(report.Destinations[0].Action).To(Equal(asg.ActionUnchanged))
+			Expect(report.Destinations[0].GUID).To(Equal("existing-guid"))
+			Expect(report.EgressPolicies).To(Equal([]asg.PolicyChange{
+				{Action: asg.ActionUnchanged, SourceType: "space", SpaceGUID: "space-1", AppLifecycle: "running", DestinationName: "asg-dns-1", DestinationGUID: "existing-guid"},
+				{Action: asg.ActionCreate, SourceType: "space", SpaceGUID: "space-2", AppLifecycle: "running", DestinationName: "asg-dns-1", DestinationGUID: "existing-guid"},
+			}))
+		})
+
+		It("does not count an existing policy for staging apps only", func() {
+			fakePolicyStore.AllReturns([]store.EgressPolicy{{
+				Source:       store.EgressSource{ID: "space-1", Type: "space"},
+				Destination:  store.EgressDestination{GUID: "existing-guid"},
+				AppLifecycle: "staging",
+			}}, nil)
+
+			report, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.EgressPolicies[0].Action).To(Equal(asg.ActionCreate))
+		})
+	})
+
+	Context("when a destination of the same name allows different traffic", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{{
+				GUID:     "existing-guid",
+				Name:     "asg-dns-1",
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.2", End: "10.0.0.3"}},
+			}}, nil)
+		})
+
+		It("reports a conflict and leaves it alone", func() {
+			report, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(report.Destinations[0].Action).To(Equal(asg.ActionConflict))
+			Expect(report.EgressPolicies).To(BeEmpty())
+			Expect(fakeDestinationStore.CreateCallCount()).To(Equal(0))
+			Expect(fakePolicyStore.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when validating the new destinations", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{{
+				GUID:     "existing-guid",
+				Name:     "some-destination",
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.9", End: "10.0.0.9"}},
+			}}, nil)
+			securityGroups = []cc_client.SecurityGroup{
+				securityGroup("web", []string{"space-1"},
+					cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"},
+					cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.2", Ports: "443"},
+				),
+			}
+		})
+
+		It("validates each one against the existing and earlier new destinations", func() {
+			_, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeValidator.ValidateEgressDestinationsCallCount()).To(Equal(2))
+			destinations, existing := fakeValidator.ValidateEgressDestinationsArgsForCall(1)
+			Expect(destinations).To(HaveLen(1))
+			Expect(destinations[0].Name).To(Equal("asg-web-2"))
+			Expect(existing).To(HaveLen(2))
+			Expect(existing[0].Name).To(Equal("some-destination"))
+			Expect(existing[1].Name).To(Equal("asg-web-1"))
+		})
+
+		It("skips the rules of rejected destinations and creates no policies for them", func() {
+			fakeValidator.ValidateEgressDestinationsStub = func(destinations, _ []store.EgressDestination) ([]string, error) {
+				if destinations[0].Name == "asg-web-1" {
+					return nil, errors.New("banana")
+				}
+				return nil, nil
+			}
+
+			report, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(report.Skipped).To(HaveLen(1))
+			Expect(report.Skipped[0].SecurityGroup).To(Equal("web"))
+			Expect(*report.Skipped[0].Rule).To(Equal(0))
+			Expect(report.Skipped[0].Reason).To(Equal("banana"))
+
+			Expect(report.Destinations).To(HaveLen(1))
+			Expect(report.Destinations[0].Name).To(Equal("asg-web-2"))
+			Expect(fakeDestinationStore.CreateArgsForCall(0)).To(HaveLen(1))
+			Expect(report.EgressPolicies).To(HaveLen(1))
+			Expect(report.EgressPolicies[0].DestinationName).To(Equal("asg-web-2"))
+		})
+
+		It("skips forbidden and duplicate rules with the api validator", func() {
+			validator, err := api.NewEgressDestinationValidator([]string{"10.0.0.0/31"})
+			Expect(err).NotTo(HaveOccurred())
+			importer.EgressDestinationValidator = validator
+			securityGroups = []cc_client.SecurityGroup{
+				securityGroup("web", []string{"space-1"},
+					cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"},
+					cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.9"},
+					cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.2", Ports: "443"},
+					cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.2", Ports: "443"},
+				),
+			}
+
+			report, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(report.Skipped).To(HaveLen(3))
+			Expect(*report.Skipped[0].Rule).To(Equal(0))
+			Expect(report.Skipped[0].Reason).To(ContainSubstring("overlaps forbidden range 10.0.0.0/31"))
+			Expect(*report.Skipped[1].Rule).To(Equal(1))
+			Expect(report.Skipped[1].Reason).To(ContainSubstring("duplicates existing destination"))
+			Expect(*report.Skipped[2].Rule).To(Equal(3))
+			Expect(report.Skipped[2].Reason).To(ContainSubstring("duplicates existing destination"))
+
+			Expect(report.Destinations).To(HaveLen(1))
+			Expect(report.Destinations[0].Name).To(Equal("asg-web-3"))
+		})
+	})
+
+	Describe("converting rules", func() {
+		convert := func(rules ...cc_client.SecurityGroupRule) asg.Report {
+			securityGroups = []cc_client.SecurityGroup{securityGroup("group", nil, rules...)}
+			report, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+			return report
+		}
+
+		It("splits lists of destinations and ports", func() {
+			report := convert(cc_client.SecurityGroupRule{
+				Protocol:    "tcp",
+				Destination: "10.0.0.1,2001:db8::/120",
+				Ports:       "80,8080-8090",
+			})
+			Expect(report.Destinations).To(HaveLen(4))
+			Expect(report.Destinations[0]).To(matchDestination("asg-group-1-1", "tcp", "10.0.0.1", "10.0.0.1", 80, 80))
+			Expect(report.Destinations[1]).To(matchDestination("asg-group-1-2", "tcp", "10.0.0.1", "10.0.0.1", 8080, 8090))
+			Expect(report.Destinations[2]).To(matchDestination("asg-group-1-3", "tcp", "2001:db8::", "2001:db8::ff", 80, 80))
+			Expect(report.Destinations[3]).To(matchDestination("asg-group-1-4", "tcp", "2001:db8::", "2001:db8::ff", 8080, 8090))
+		})
+
+		It("converts a rule for all protocols to tcp, udp and icmp destinations", func() {
+			report := convert(cc_client.SecurityGroupRule{Protocol: "all", Destination: "0.0.0.0-9.255.255.255"})
+			Expect(report.Destinations).To(HaveLen(3))
+			Expect(report.Destinations[0]).To(matchDestination("asg-group-1-1", "tcp", "0.0.0.0", "9.255.255.255", 0, 0))
+			Expect(report.Destinations[1]).To(matchDestination("asg-group-1-2", "udp", "0.0.0.0", "9.255.255.255", 0, 0))
+			Expect(report.Destinations[2].Protocol).To(Equal("icmp"))
+			Expect(*report.Destinations[2].ICMPType).To(Equal(-1))
+			Expect(*report.Destinations[2].ICMPCode).To(Equal(-1))
+		})
+
+		It("keeps the icmp type and code", func() {
+			icmpType, icmpCode := 8, 0
+			report := convert(cc_client.SecurityGroupRule{Protocol: "icmp", Destination: "10.0.0.0/24", Type: &icmpType, Code: &icmpCode})
+			Expect(report.Destinations).To(HaveLen(1))
+			Expect(report.Destinations[0].StartIP).To(Equal("10.0.0.0"))
+			Expect(report.Destinations[0].EndIP).To(Equal("10.0.0.255"))
+			Expect(*report.Destinations[0].ICMPType).To(Equal(8))
+			Expect(*report.Destinations[0].ICMPCode).To(Equal(0))
+		})
+
+		It("skips rules it cannot convert", func() {
+			report := convert(
+				cc_client.SecurityGroupRule{Protocol: "sctp", Destination: "10.0.0.1"},
+				cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.9-10.0.0.1"},
+				cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "http"},
+			)
+			Expect(report.Destinations).To(BeEmpty())
+			Expect(report.Skipped).To(HaveLen(3))
+			Expect(report.Skipped[0].Reason).To(Equal(`unsupported protocol "sctp"`))
+			Expect(*report.Skipped[0].Rule).To(Equal(0))
+			Expect(report.Skipped[1].Reason).To(Equal(`destination "10.0.0.9-10.0.0.1": start ip address should be before end ip address: start: 10.0.0.9 end: 10.0.0.1`))
+			Expect(report.Skipped[2].Reason).To(Equal(`invalid ports "http"`))
+		})
+
+		It("converts the rules of an asg definition", func() {
+			var rules []cc_client.SecurityGroupRule
+			Expect(json.Unmarshal([]byte(testsupport.BuildASG(300)), &rules)).To(Succeed())
+
+			report := convert(rules...)
+			Expect(report.Skipped).To(BeEmpty())
+			Expect(report.Destinations).To(HaveLen(300))
+			Expect(report.Destinations[299]).To(matchDestination("asg-group-300", "tcp", "169.254.1.46", "169.254.1.46", 80, 80))
+		})
+	})
+
+	Context("when a group is bound to spaces for staging or is globally enabled", func() {
+		BeforeEach(func() {
+			group := securityGroup("public", []string{"space-1"}, cc_client.SecurityGroupRule{Protocol: "tcp", Destination: "1.1.1.1"})
+			group.Relationships.StagingSpaces.Data = append(group.Relationships.StagingSpaces.Data, struct {
+				GUID string `json:"guid"`
+			}{GUID: "space-3"})
+			group.GloballyEnabled.Running = true
+			group.GloballyEnabled.Staging = true
+			securityGroups = []cc_client.SecurityGroup{group}
+		})
+
+		It("creates staging policies for the staging spaces and default policies for each lifecycle", func() {
+			report, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Skipped).To(BeEmpty())
+
+			Expect(fakePolicyStore.CreateArgsForCall(0)).To(Equal([]store.EgressPolicy{
+				{
+					Source:       store.EgressSource{ID: "space-1", Type: "space"},
+					Destination:  store.EgressDestination{GUID: "new-guid-0"},
+					AppLifecycle: "running",
+				},
+				{
+					Source:       store.EgressSource{ID: "space-3", Type: "space"},
+					Destination:  store.EgressDestination{GUID: "new-guid-0"},
+					AppLifecycle: "staging",
+				},
+				{
+					Source:       store.EgressSource{Type: "default"},
+					Destination:  store.EgressDestination{GUID: "new-guid-0"},
+					AppLifecycle: "running",
+				},
+				{
+					Source:       store.EgressSource{Type: "default"},
+					Destination:  store.EgressDestination{GUID: "new-guid-0"},
+					AppLifecycle: "staging",
+				},
+			}))
+
+			reportJSON, err := json.Marshal(report.EgressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reportJSON).To(MatchJSON(`[
+				{"action": "create", "source_type": "space", "space_id": "space-1", "app_lifecycle": "running", "destination_name": "asg-public-1", "destination_id": "new-guid-0"},
+				{"action": "create", "source_type": "space", "space_id": "space-3", "app_lifecycle": "staging", "destination_name": "asg-public-1", "destination_id": "new-guid-0"},
+				{"action": "create", "source_type": "default", "app_lifecycle": "running", "destination_name": "asg-public-1", "destination_id": "new-guid-0"},
+				{"action": "create", "source_type": "default", "app_lifecycle": "staging", "destination_name": "asg-public-1", "destination_id": "new-guid-0"}
+			]`))
+		})
+
+		It("counts existing default and all lifecycle policies", func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{{
+				GUID:     "existing-guid",
+				Name:     "asg-public-1",
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "1.1.1.1", End: "1.1.1.1"}},
+			}}, nil)
+			fakePolicyStore.AllReturns([]store.EgressPolicy{
+				{
+					Source:       store.EgressSource{ID: "space-3", Type: "space"},
+					Destination:  store.EgressDestination{GUID: "existing-guid"},
+					AppLifecycle: "all",
+				},
+				{
+					Source:       store.EgressSource{Type: "default"},
+					Destination:  store.EgressDestination{GUID: "existing-guid"},
+					AppLifecycle: "running",
+				},
+			}, nil)
+
+			report, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+			var actions []string
+			for _, change := range report.EgressPolicies {
+				actions = append(actions, change.Action)
+			}
+			Expect(actions).To(Equal([]string{asg.ActionCreate, asg.ActionUnchanged, asg.ActionUnchanged, asg.ActionCreate}))
+		})
+	})
+
+	DescribeTable("errors",
+		func(setup func(), message string) {
+			setup()
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("getting a token", func() { fakeUAAClient.GetTokenReturns("", errors.New("banana")) }, "get uaa token: banana"),
+		Entry("getting security groups", func() {
+			fakeCCClient.GetSecurityGroupsStub = nil
+			fakeCCClient.GetSecurityGroupsReturns(nil, errors.New("banana"))
+		}, "get security groups: banana"),
+		Entry("listing destinations", func() { fakeDestinationStore.AllReturns(nil, errors.New("banana")) }, "list egress destinations: banana"),
+		Entry("listing policies", func() { fakePolicyStore.AllReturns(nil, errors.New("banana")) }, "list egress policies: banana"),
+		Entry("creating destinations", func() {
+			fakeDestinationStore.CreateStub = nil
+			fakeDestinationStore.CreateReturns(nil, errors.New("banana"))
+		}, "create egress destinations: banana"),
+		Entry("creating policies", func() { fakePolicyStore.CreateReturns(nil, errors.New("banana")) }, "create egress policies: banana"),
+	)
+})
+
+func matchDestination(name, protocol, startIP, endIP string, startPort, endPort int) OmegaMatcher {
+	return And(
+		WithTransform(func(c asg.DestinationChange) string { return c.Name }, Equal(name)),
+		WithTransform(func(c asg.DestinationChange) []interface{} {
+			return []interface{}{c.Protocol, c.StartIP, c.EndIP, c.StartPort, c.EndPort}
+		}, Equal([]interface{}{protocol, startIP, endIP, startPort, endPort})),
+	)
+}
@@ -0,0 +1,114 @@
+package asg
+
+import (
+	"fmt"
+	"policy-server/api"
+	"policy-server/cc_client"
+	"policy-server/store"
+	"strconv"
+	"strings"
+)
+
+// convertRule returns the egress destinations equivalent to an ASG rule. An
+// egress destination has one ip range and one port range, so a rule with a
+// list of either becomes several destinations. A rule for all protocols
+// becomes one destination each for tcp, udp and icmp.
+func convertRule(rule cc_client.SecurityGroupRule) ([]store.EgressDestination, error) {
+	var ipRanges []store.IPRange
+	for _, destination := range strings.Split(rule.Destination, ",") {
+		ipRange, err := parseDestination(strings.TrimSpace(destination))
+		if err != nil {
+			return nil, err
+		}
+		ipRanges = append(ipRanges, ipRange)
+	}
+
+	var protocols []string
+	switch rule.Protocol {
+	case "tcp", "udp", "icmp":
+		protocols = []string{rule.Protocol}
+	case "all":
+		protocols = []string{"tcp", "udp", "icmp"}
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", rule.Protocol)
+	}
+
+	var portRanges [][]store.Ports
+	if rule.Ports == "" || rule.Protocol == "icmp" || rule.Protocol == "all" {
+		portRanges = [][]store.Ports{nil}
+	} else {
+		for _, ports := range strings.Split(rule.Ports, ",") {
+			portRange, err := parsePorts(strings.TrimSpace(ports))
+			if err != nil {
+				return nil, err
+			}
+			portRanges = append(portRanges, []store.Ports{portRange})
+		}
+	}
+
+	var destinations []store.EgressDestination
+	for _, ipRange := range ipRanges {
+		for _, protocol := range protocols {
+			if protocol == "icmp" {
+				destination := store.EgressDestination{
+					Protocol: protocol,
+					IPRanges: []store.IPRange{ipRange},
+					ICMPType: store.AllICMP,
+					ICMPCode: store.AllICMP,
+				}
+				if rule.Protocol == "icmp" && rule.Type != nil {
+					destination.ICMPType = *rule.Type
+				}
+				if rule.Protocol == "icmp" && rule.Code != nil {
+					destination.ICMPCode = *rule.Code
+				}
+				destinations = append(destinations, destination)
+				continue
+			}
+			for _, ports := range portRanges {
+				destinations = append(destinations, store.EgressDestination{
+					Protocol: protocol,
+					IPRanges: []store.IPRange{ipRange},
+					Ports:    ports,
+				})
+			}
+		}
+	}
+	return destinations, nil
+}
+
+// parseDestination reads an ip, an ip range such as 10.0.0.1-10.0.0.9, or a
+// CIDR, and validates it as the egress destinations API would.
+func parseDestination(destination string) (store.IPRange, error) {
+	ipRange := api.IPRange{Start: destination, End: destination}
+	if strings.Contains(destination, "/") {
+		ipRange = api.IPRange{CIDR: destination}
+	} else if parts := strings.SplitN(destination, "-", 2); len(parts) == 2 {
+		ipRange = api.IPRange{Start: parts[0], End: parts[1]}
+	}
+
+	normalized, err := ipRange.Normalized()
+	if err != nil {
+		return store.IPRange{}, fmt.Errorf("destination %q: %s", destination, err)
+	}
+	return store.IPRange{Start: normalized.Start, End: normalized.End}, nil
+}
+
+func parsePorts(ports string) (store.Ports, error) {
+	parts := strings.SplitN(ports, "-", 2)
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return store.Ports{}, fmt.Errorf("invalid ports %q", ports)
+	}
+	end := start
+	if len(parts) == 2 {
+		end, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return store.Ports{}, fmt.Errorf("invalid ports %q", ports)
+		}
+	}
+	if start < 1 || end > 65535 || start > end {
+		return store.Ports{}, fmt.Errorf("invalid ports %q", ports)
+	}
+	return store.Ports{Start: start, End: end}, nil
+}
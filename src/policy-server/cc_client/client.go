@@ -58,6 +58,15 @@ type SpacesV3Response struct {
 	} `json:"resources"`
 }
 
+type OrgsV3Response struct {
+	Pagination struct {
+		TotalPages int `json:"total_pages"`
+	} `json:"pagination"`
+	Resources []struct {
+		GUID string `json:"guid"`
+	} `json:"resources"`
+}
+
 type SpaceResponse struct {
 	Entity struct {
 		Name             string `json:"name"`
@@ -77,6 +86,86 @@ type SpacesResponse struct {
 	} `json:"resources"`
 }
 
+// SecurityGroup is an application security group, with the spaces it is
+// bound to for running and for staging apps.
+type SecurityGroup struct {
+	GUID            string              `json:"guid"`
+	Name            string              `json:"name"`
+	GloballyEnabled SecurityGroupToggle `json:"globally_enabled"`
+	Rules           []SecurityGroupRule `json:"rules"`
+	Relationships   struct {
+		RunningSpaces SecurityGroupSpaces `json:"running_spaces"`
+		StagingSpaces SecurityGroupSpaces `json:"staging_spaces"`
+	} `json:"relationships"`
+}
+
+type SecurityGroupToggle struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+// SecurityGroupRule is a rule as Cloud Controller stores it. Destination is
+// an ip, an ip range or a CIDR, or a comma separated list of them, and Ports
+// is a port, a port range, or a comma separated list of them.
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int   `json:"type,omitempty"`
+	Code        *int   `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Log         bool   `json:"log,omitempty"`
+}
+
+type SecurityGroupSpaces struct {
+	Data []struct {
+		GUID string `json:"guid"`
+	} `json:"data"`
+}
+
+func (s SecurityGroupSpaces) GUIDs() []string {
+	guids := []string{}
+	for _, space := range s.Data {
+		guids = append(guids, space.GUID)
+	}
+	return guids
+}
+
+type SecurityGroupsV3Response struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []SecurityGroup `json:"resources"`
+}
+
+func (c *Client) GetSecurityGroups(token string) ([]SecurityGroup, error) {
+	token = fmt.Sprintf("bearer %s", token)
+
+	securityGroups := []SecurityGroup{}
+	route := "/v3/security_groups"
+	for route != "" {
+		var response SecurityGroupsV3Response
+		err := c.JSONClient.Do("GET", route, nil, &response, token)
+		if err != nil {
+			return nil, fmt.Errorf("json client do: %s", err)
+		}
+		securityGroups = append(securityGroups, response.Resources...)
+
+		route = ""
+		if response.Pagination.Next != nil && response.Pagination.Next.Href != "" {
+			parts := strings.SplitN(response.Pagination.Next.Href, "?", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("unexpected next page: %s", response.Pagination.Next.Href)
+			}
+			route = "/v3/security_groups?" + parts[1]
+		}
+	}
+
+	return securityGroups, nil
+}
+
 func (c *Client) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
 	token = fmt.Sprintf("bearer %s", token)
 
@@ -155,6 +244,33 @@ func (c *Client) GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[strin
 	return liveSpaceGUIDs, nil
 }
 
+func (c *Client) GetLiveOrgGUIDs(token string, orgGUIDs []string) (map[string]struct{}, error) {
+	token = fmt.Sprintf("bearer %s", token)
+
+	values := url.Values{}
+	values.Add("guids", strings.Join(orgGUIDs, ","))
+	values.Add("per_page", strconv.Itoa(len(orgGUIDs)))
+
+	route := fmt.Sprintf("/v3/organizations?%s", values.Encode())
+
+	var response OrgsV3Response
+	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	if err != nil {
+		return nil, fmt.Errorf("json client do: %s", err)
+	}
+
+	if response.Pagination.TotalPages > 1 {
+		return nil, fmt.Errorf("pagination support not yet implemented")
+	}
+
+	set := make(map[string]struct{})
+	for _, r := range response.Resources {
+		set[r.GUID] = struct{}{}
+	}
+
+	return set, nil
+}
+
 func (c *Client) getAllSpaceGUIDs(token string) (map[string]struct{}, error) {
 	allSpaceGUIDs := make(map[string]struct{})
 
@@ -300,3 +416,12 @@ func (c *Client) GetUserSpaces(token, userGUID string) (map[string]struct{}, err
 
 	return userSpaces, nil
 }
+
+func (c *Client) CheckReachable() error {
+	var response map[string]interface{}
+	err := c.JSONClient.Do("GET", "/v2/info", nil, &response, "")
+	if err != nil {
+		return fmt.Errorf("json client do: %s", err)
+	}
+	return nil
+}
@@ -178,6 +178,54 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("GetSecurityGroups", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				if route == "/v3/security_groups?page=2&per_page=1" {
+					return json.Unmarshal([]byte(fixtures.SecurityGroupsV3Pg2), respData)
+				}
+				return json.Unmarshal([]byte(fixtures.SecurityGroupsV3), respData)
+			}
+		})
+
+		It("returns the security groups from every page", func() {
+			securityGroups, err := client.GetSecurityGroups("some-token")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+			method, route, _, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/security_groups"))
+			Expect(token).To(Equal("bearer some-token"))
+			_, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+			Expect(route).To(Equal("/v3/security_groups?page=2&per_page=1"))
+
+			Expect(securityGroups).To(HaveLen(2))
+			Expect(securityGroups[0].GUID).To(Equal("asg-1-guid"))
+			Expect(securityGroups[0].Name).To(Equal("dns"))
+			Expect(securityGroups[0].Rules).To(HaveLen(2))
+			Expect(securityGroups[0].Rules[0]).To(Equal(cc_client.SecurityGroupRule{
+				Protocol:    "udp",
+				Destination: "10.0.0.2-10.0.0.3",
+				Ports:       "53",
+				Description: "internal dns",
+			}))
+			Expect(*securityGroups[0].Rules[1].Type).To(Equal(8))
+			Expect(securityGroups[0].Relationships.RunningSpaces.GUIDs()).To(Equal([]string{"space-1-guid", "space-2-guid"}))
+			Expect(securityGroups[0].Relationships.StagingSpaces.GUIDs()).To(Equal([]string{"space-3-guid"}))
+			Expect(securityGroups[1].GloballyEnabled.Running).To(BeTrue())
+		})
+
+		Context("when the json client fails", func() {
+			It("returns the error", func() {
+				fakeJSONClient.DoStub = nil
+				fakeJSONClient.DoReturns(errors.New("banana"))
+				_, err := client.GetSecurityGroups("some-token")
+				Expect(err).To(MatchError("json client do: banana"))
+			})
+		})
+	})
+
 	Describe("GetLiveSpaceGUIDs", func() {
 		var (
 			passedToken string
@@ -218,6 +266,56 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("GetLiveOrgGUIDs", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				_ = json.Unmarshal([]byte(fixtures.OrgsV3), respData)
+				return nil
+			}
+		})
+
+		It("returns the org guids that still exist", func() {
+			liveOrgGUIDs, err := client.GetLiveOrgGUIDs("some-token", []string{"live-org-1-guid", "live-org-2-guid", "dead-org-1-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(liveOrgGUIDs).To(Equal(map[string]struct{}{
+				"live-org-1-guid": {},
+				"live-org-2-guid": {},
+			}))
+
+			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/organizations?guids=live-org-1-guid%2Clive-org-2-guid%2Cdead-org-1-guid&per_page=3"))
+			Expect(reqData).To(BeNil())
+			Expect(token).To(Equal("bearer some-token"))
+		})
+
+		Context("when there is more than one page", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+					_ = json.Unmarshal([]byte(`{"pagination": {"total_pages": 2}}`), respData)
+					return nil
+				}
+			})
+
+			It("returns an error", func() {
+				_, err := client.GetLiveOrgGUIDs("some-token", []string{"live-org-1-guid"})
+				Expect(err).To(MatchError("pagination support not yet implemented"))
+			})
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoStub = nil
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, err := client.GetLiveOrgGUIDs("some-token", []string{"live-org-1-guid"})
+				Expect(err).To(MatchError("json client do: banana"))
+			})
+		})
+	})
+
 	Describe("GetSpaceGUIDs", func() {
 		BeforeEach(func() {
 			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
@@ -527,4 +625,29 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("CheckReachable", func() {
+		It("gets the unauthenticated info endpoint", func() {
+			err := client.CheckReachable()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
+			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v2/info"))
+			Expect(reqData).To(BeNil())
+			Expect(token).To(BeEmpty())
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns a helpful error", func() {
+				err := client.CheckReachable()
+				Expect(err).To(MatchError("json client do: banana"))
+			})
+		})
+	})
 })
@@ -0,0 +1,30 @@
+package fixtures
+
+const OrgsV3 = `{
+   "pagination": {
+      "total_results": 2,
+      "total_pages": 1,
+      "first": {
+         "href": "https://api.example.org/v3/organizations?guids=live-org-1-guid%2Clive-org-2-guid%2Cdead-org-1-guid&page=1&per_page=3"
+      },
+      "last": {
+         "href": "https://api.example.org/v3/organizations?guids=live-org-1-guid%2Clive-org-2-guid%2Cdead-org-1-guid&page=1&per_page=3"
+      },
+      "next": null,
+      "previous": null
+   },
+   "resources": [
+      {
+         "guid": "live-org-1-guid",
+         "created_at": "2018-07-24T17:49:02Z",
+         "updated_at": "2018-07-24T17:49:02Z",
+         "name": "org-1"
+      },
+      {
+         "guid": "live-org-2-guid",
+         "created_at": "2018-07-24T17:49:02Z",
+         "updated_at": "2018-07-24T17:49:02Z",
+         "name": "org-2"
+      }
+   ]
+}`
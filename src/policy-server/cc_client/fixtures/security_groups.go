@@ -0,0 +1,87 @@
+package fixtures
+
+const SecurityGroupsV3 = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 2,
+    "first": {
+      "href": "https://api.[your-domain.com]/v3/security_groups?page=1&per_page=1"
+    },
+    "last": {
+      "href": "https://api.[your-domain.com]/v3/security_groups?page=2&per_page=1"
+    },
+    "next": {
+      "href": "https://api.[your-domain.com]/v3/security_groups?page=2&per_page=1"
+    }
+  },
+  "resources": [
+    {
+      "guid": "asg-1-guid",
+      "name": "dns",
+      "globally_enabled": {
+        "running": false,
+        "staging": false
+      },
+      "rules": [
+        {
+          "protocol": "udp",
+          "destination": "10.0.0.2-10.0.0.3",
+          "ports": "53",
+          "description": "internal dns"
+        },
+        {
+          "protocol": "icmp",
+          "destination": "10.0.0.0/24",
+          "type": 8,
+          "code": 0
+        }
+      ],
+      "relationships": {
+        "running_spaces": {
+          "data": [{"guid": "space-1-guid"}, {"guid": "space-2-guid"}]
+        },
+        "staging_spaces": {
+          "data": [{"guid": "space-3-guid"}]
+        }
+      }
+    }
+  ]
+}`
+
+const SecurityGroupsV3Pg2 = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 2,
+    "first": {
+      "href": "https://api.[your-domain.com]/v3/security_groups?page=1&per_page=1"
+    },
+    "last": {
+      "href": "https://api.[your-domain.com]/v3/security_groups?page=2&per_page=1"
+    },
+    "next": null
+  },
+  "resources": [
+    {
+      "guid": "asg-2-guid",
+      "name": "public_networks",
+      "globally_enabled": {
+        "running": true,
+        "staging": true
+      },
+      "rules": [
+        {
+          "protocol": "all",
+          "destination": "0.0.0.0-9.255.255.255"
+        }
+      ],
+      "relationships": {
+        "running_spaces": {
+          "data": []
+        },
+        "staging_spaces": {
+          "data": []
+        }
+      }
+    }
+  ]
+}`
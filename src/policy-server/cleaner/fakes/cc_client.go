@@ -6,11 +6,11 @@ import (
 )
 
 type CCClient struct {
-	GetLiveAppGUIDsStub        func(token string, appGUIDs []string) (map[string]struct{}, error)
+	GetLiveAppGUIDsStub        func(string, []string) (map[string]struct{}, error)
 	getLiveAppGUIDsMutex       sync.RWMutex
 	getLiveAppGUIDsArgsForCall []struct {
-		token    string
-		appGUIDs []string
+		arg1 string
+		arg2 []string
 	}
 	getLiveAppGUIDsReturns struct {
 		result1 map[string]struct{}
@@ -20,11 +20,25 @@ type CCClient struct {
 		result1 map[string]struct{}
 		result2 error
 	}
-	GetLiveSpaceGUIDsStub        func(token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveOrgGUIDsStub        func(string, []string) (map[string]struct{}, error)
+	getLiveOrgGUIDsMutex       sync.RWMutex
+	getLiveOrgGUIDsArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	getLiveOrgGUIDsReturns struct {
+		result1 map[string]struct{}
+		result2 error
+	}
+	getLiveOrgGUIDsReturnsOnCall map[int]struct {
+		result1 map[string]struct{}
+		result2 error
+	}
+	GetLiveSpaceGUIDsStub        func(string, []string) (map[string]struct{}, error)
 	getLiveSpaceGUIDsMutex       sync.RWMutex
 	getLiveSpaceGUIDsArgsForCall []struct {
-		token      string
-		spaceGUIDs []string
+		arg1 string
+		arg2 []string
 	}
 	getLiveSpaceGUIDsReturns struct {
 		result1 map[string]struct{}
@@ -38,27 +52,29 @@ type CCClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CCClient) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
-	var appGUIDsCopy []string
-	if appGUIDs != nil {
-		appGUIDsCopy = make([]string, len(appGUIDs))
-		copy(appGUIDsCopy, appGUIDs)
+func (fake *CCClient) GetLiveAppGUIDs(arg1 string, arg2 []string) (map[string]struct{}, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.getLiveAppGUIDsMutex.Lock()
 	ret, specificReturn := fake.getLiveAppGUIDsReturnsOnCall[len(fake.getLiveAppGUIDsArgsForCall)]
 	fake.getLiveAppGUIDsArgsForCall = append(fake.getLiveAppGUIDsArgsForCall, struct {
-		token    string
-		appGUIDs []string
-	}{token, appGUIDsCopy})
-	fake.recordInvocation("GetLiveAppGUIDs", []interface{}{token, appGUIDsCopy})
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetLiveAppGUIDsStub
+	fakeReturns := fake.getLiveAppGUIDsReturns
+	fake.recordInvocation("GetLiveAppGUIDs", []interface{}{arg1, arg2Copy})
 	fake.getLiveAppGUIDsMutex.Unlock()
-	if fake.GetLiveAppGUIDsStub != nil {
-		return fake.GetLiveAppGUIDsStub(token, appGUIDs)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getLiveAppGUIDsReturns.result1, fake.getLiveAppGUIDsReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *CCClient) GetLiveAppGUIDsCallCount() int {
@@ -67,13 +83,22 @@ func (fake *CCClient) GetLiveAppGUIDsCallCount() int {
 	return len(fake.getLiveAppGUIDsArgsForCall)
 }
 
+func (fake *CCClient) GetLiveAppGUIDsCalls(stub func(string, []string) (map[string]struct{}, error)) {
+	fake.getLiveAppGUIDsMutex.Lock()
+	defer fake.getLiveAppGUIDsMutex.Unlock()
+	fake.GetLiveAppGUIDsStub = stub
+}
+
 func (fake *CCClient) GetLiveAppGUIDsArgsForCall(i int) (string, []string) {
 	fake.getLiveAppGUIDsMutex.RLock()
 	defer fake.getLiveAppGUIDsMutex.RUnlock()
-	return fake.getLiveAppGUIDsArgsForCall[i].token, fake.getLiveAppGUIDsArgsForCall[i].appGUIDs
+	argsForCall := fake.getLiveAppGUIDsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *CCClient) GetLiveAppGUIDsReturns(result1 map[string]struct{}, result2 error) {
+	fake.getLiveAppGUIDsMutex.Lock()
+	defer fake.getLiveAppGUIDsMutex.Unlock()
 	fake.GetLiveAppGUIDsStub = nil
 	fake.getLiveAppGUIDsReturns = struct {
 		result1 map[string]struct{}
@@ -82,6 +107,8 @@ func (fake *CCClient) GetLiveAppGUIDsReturns(result1 map[string]struct{}, result
 }
 
 func (fake *CCClient) GetLiveAppGUIDsReturnsOnCall(i int, result1 map[string]struct{}, result2 error) {
+	fake.getLiveAppGUIDsMutex.Lock()
+	defer fake.getLiveAppGUIDsMutex.Unlock()
 	fake.GetLiveAppGUIDsStub = nil
 	if fake.getLiveAppGUIDsReturnsOnCall == nil {
 		fake.getLiveAppGUIDsReturnsOnCall = make(map[int]struct {
@@ -95,27 +122,99 @@ func (fake *CCClient) GetLiveAppGUIDsReturnsOnCall(i int, result1 map[string]str
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error) {
-	var spaceGUIDsCopy []string
-	if spaceGUIDs != nil {
-		spaceGUIDsCopy = make([]string, len(spaceGUIDs))
-		copy(spaceGUIDsCopy, spaceGUIDs)
+func (fake *CCClient) GetLiveOrgGUIDs(arg1 string, arg2 []string) (map[string]struct{}, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getLiveOrgGUIDsMutex.Lock()
+	ret, specificReturn := fake.getLiveOrgGUIDsReturnsOnCall[len(fake.getLiveOrgGUIDsArgsForCall)]
+	fake.getLiveOrgGUIDsArgsForCall = append(fake.getLiveOrgGUIDsArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetLiveOrgGUIDsStub
+	fakeReturns := fake.getLiveOrgGUIDsReturns
+	fake.recordInvocation("GetLiveOrgGUIDs", []interface{}{arg1, arg2Copy})
+	fake.getLiveOrgGUIDsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsCallCount() int {
+	fake.getLiveOrgGUIDsMutex.RLock()
+	defer fake.getLiveOrgGUIDsMutex.RUnlock()
+	return len(fake.getLiveOrgGUIDsArgsForCall)
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsCalls(stub func(string, []string) (map[string]struct{}, error)) {
+	fake.getLiveOrgGUIDsMutex.Lock()
+	defer fake.getLiveOrgGUIDsMutex.Unlock()
+	fake.GetLiveOrgGUIDsStub = stub
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsArgsForCall(i int) (string, []string) {
+	fake.getLiveOrgGUIDsMutex.RLock()
+	defer fake.getLiveOrgGUIDsMutex.RUnlock()
+	argsForCall := fake.getLiveOrgGUIDsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsReturns(result1 map[string]struct{}, result2 error) {
+	fake.getLiveOrgGUIDsMutex.Lock()
+	defer fake.getLiveOrgGUIDsMutex.Unlock()
+	fake.GetLiveOrgGUIDsStub = nil
+	fake.getLiveOrgGUIDsReturns = struct {
+		result1 map[string]struct{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsReturnsOnCall(i int, result1 map[string]struct{}, result2 error) {
+	fake.getLiveOrgGUIDsMutex.Lock()
+	defer fake.getLiveOrgGUIDsMutex.Unlock()
+	fake.GetLiveOrgGUIDsStub = nil
+	if fake.getLiveOrgGUIDsReturnsOnCall == nil {
+		fake.getLiveOrgGUIDsReturnsOnCall = make(map[int]struct {
+			result1 map[string]struct{}
+			result2 error
+		})
+	}
+	fake.getLiveOrgGUIDsReturnsOnCall[i] = struct {
+		result1 map[string]struct{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetLiveSpaceGUIDs(arg1 string, arg2 []string) (map[string]struct{}, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.getLiveSpaceGUIDsMutex.Lock()
 	ret, specificReturn := fake.getLiveSpaceGUIDsReturnsOnCall[len(fake.getLiveSpaceGUIDsArgsForCall)]
 	fake.getLiveSpaceGUIDsArgsForCall = append(fake.getLiveSpaceGUIDsArgsForCall, struct {
-		token      string
-		spaceGUIDs []string
-	}{token, spaceGUIDsCopy})
-	fake.recordInvocation("GetLiveSpaceGUIDs", []interface{}{token, spaceGUIDsCopy})
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.GetLiveSpaceGUIDsStub
+	fakeReturns := fake.getLiveSpaceGUIDsReturns
+	fake.recordInvocation("GetLiveSpaceGUIDs", []interface{}{arg1, arg2Copy})
 	fake.getLiveSpaceGUIDsMutex.Unlock()
-	if fake.GetLiveSpaceGUIDsStub != nil {
-		return fake.GetLiveSpaceGUIDsStub(token, spaceGUIDs)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getLiveSpaceGUIDsReturns.result1, fake.getLiveSpaceGUIDsReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *CCClient) GetLiveSpaceGUIDsCallCount() int {
@@ -124,13 +223,22 @@ func (fake *CCClient) GetLiveSpaceGUIDsCallCount() int {
 	return len(fake.getLiveSpaceGUIDsArgsForCall)
 }
 
+func (fake *CCClient) GetLiveSpaceGUIDsCalls(stub func(string, []string) (map[string]struct{}, error)) {
+	fake.getLiveSpaceGUIDsMutex.Lock()
+	defer fake.getLiveSpaceGUIDsMutex.Unlock()
+	fake.GetLiveSpaceGUIDsStub = stub
+}
+
 func (fake *CCClient) GetLiveSpaceGUIDsArgsForCall(i int) (string, []string) {
 	fake.getLiveSpaceGUIDsMutex.RLock()
 	defer fake.getLiveSpaceGUIDsMutex.RUnlock()
-	return fake.getLiveSpaceGUIDsArgsForCall[i].token, fake.getLiveSpaceGUIDsArgsForCall[i].spaceGUIDs
+	argsForCall := fake.getLiveSpaceGUIDsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *CCClient) GetLiveSpaceGUIDsReturns(result1 map[string]struct{}, result2 error) {
+	fake.getLiveSpaceGUIDsMutex.Lock()
+	defer fake.getLiveSpaceGUIDsMutex.Unlock()
 	fake.GetLiveSpaceGUIDsStub = nil
 	fake.getLiveSpaceGUIDsReturns = struct {
 		result1 map[string]struct{}
@@ -139,6 +247,8 @@ func (fake *CCClient) GetLiveSpaceGUIDsReturns(result1 map[string]struct{}, resu
 }
 
 func (fake *CCClient) GetLiveSpaceGUIDsReturnsOnCall(i int, result1 map[string]struct{}, result2 error) {
+	fake.getLiveSpaceGUIDsMutex.Lock()
+	defer fake.getLiveSpaceGUIDsMutex.Unlock()
 	fake.GetLiveSpaceGUIDsStub = nil
 	if fake.getLiveSpaceGUIDsReturnsOnCall == nil {
 		fake.getLiveSpaceGUIDsReturnsOnCall = make(map[int]struct {
@@ -157,6 +267,8 @@ func (fake *CCClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.getLiveAppGUIDsMutex.RLock()
 	defer fake.getLiveAppGUIDsMutex.RUnlock()
+	fake.getLiveOrgGUIDsMutex.RLock()
+	defer fake.getLiveOrgGUIDsMutex.RUnlock()
 	fake.getLiveSpaceGUIDsMutex.RLock()
 	defer fake.getLiveSpaceGUIDsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,414 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store"
+	"sync"
+	"time"
+)
+
+type TagReclaimStore struct {
+	ClearOrphanedTagsStub        func([]string) error
+	clearOrphanedTagsMutex       sync.RWMutex
+	clearOrphanedTagsArgsForCall []struct {
+		arg1 []string
+	}
+	clearOrphanedTagsReturns struct {
+		result1 error
+	}
+	clearOrphanedTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkOrphanedTagsStub        func([]string, time.Time) error
+	markOrphanedTagsMutex       sync.RWMutex
+	markOrphanedTagsArgsForCall []struct {
+		arg1 []string
+		arg2 time.Time
+	}
+	markOrphanedTagsReturns struct {
+		result1 error
+	}
+	markOrphanedTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReclaimOrphanedTagsStub        func(time.Time) ([]store.Tag, error)
+	reclaimOrphanedTagsMutex       sync.RWMutex
+	reclaimOrphanedTagsArgsForCall []struct {
+		arg1 time.Time
+	}
+	reclaimOrphanedTagsReturns struct {
+		result1 []store.Tag
+		result2 error
+	}
+	reclaimOrphanedTagsReturnsOnCall map[int]struct {
+		result1 []store.Tag
+		result2 error
+	}
+	TagUtilisationStub        func() (store.TagUtilisation, error)
+	tagUtilisationMutex       sync.RWMutex
+	tagUtilisationArgsForCall []struct {
+	}
+	tagUtilisationReturns struct {
+		result1 store.TagUtilisation
+		result2 error
+	}
+	tagUtilisationReturnsOnCall map[int]struct {
+		result1 store.TagUtilisation
+		result2 error
+	}
+	UnreferencedTagsStub        func() ([]store.Tag, error)
+	unreferencedTagsMutex       sync.RWMutex
+	unreferencedTagsArgsForCall []struct {
+	}
+	unreferencedTagsReturns struct {
+		result1 []store.Tag
+		result2 error
+	}
+	unreferencedTagsReturnsOnCall map[int]struct {
+		result1 []store.Tag
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TagReclaimStore) ClearOrphanedTags(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.clearOrphanedTagsMutex.Lock()
+	ret, specificReturn := fake.clearOrphanedTagsReturnsOnCall[len(fake.clearOrphanedTagsArgsForCall)]
+	fake.clearOrphanedTagsArgsForCall = append(fake.clearOrphanedTagsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.ClearOrphanedTagsStub
+	fakeReturns := fake.clearOrphanedTagsReturns
+	fake.recordInvocation("ClearOrphanedTags", []interface{}{arg1Copy})
+	fake.clearOrphanedTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TagReclaimStore) ClearOrphanedTagsCallCount() int {
+	fake.clearOrphanedTagsMutex.RLock()
+	defer fake.clearOrphanedTagsMutex.RUnlock()
+	return len(fake.clearOrphanedTagsArgsForCall)
+}
+
+func (fake *TagReclaimStore) ClearOrphanedTagsCalls(stub func([]string) error) {
+	fake.clearOrphanedTagsMutex.Lock()
+	defer fake.clearOrphanedTagsMutex.Unlock()
+	fake.ClearOrphanedTagsStub = stub
+}
+
+func (fake *TagReclaimStore) ClearOrphanedTagsArgsForCall(i int) []string {
+	fake.clearOrphanedTagsMutex.RLock()
+	defer fake.clearOrphanedTagsMutex.RUnlock()
+	argsForCall := fake.clearOrphanedTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TagReclaimStore) ClearOrphanedTagsReturns(result1 error) {
+	fake.clearOrphanedTagsMutex.Lock()
+	defer fake.clearOrphanedTagsMutex.Unlock()
+	fake.ClearOrphanedTagsStub = nil
+	fake.clearOrphanedTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagReclaimStore) ClearOrphanedTagsReturnsOnCall(i int, result1 error) {
+	fake.clearOrphanedTagsMutex.Lock()
+	defer fake.clearOrphanedTagsMutex.Unlock()
+	fake.ClearOrphanedTagsStub = nil
+	if fake.clearOrphanedTagsReturnsOnCall == nil {
+		fake.clearOrphanedTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearOrphanedTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagReclaimStore) MarkOrphanedTags(arg1 []string, arg2 time.Time) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.markOrphanedTagsMutex.Lock()
+	ret, specificReturn := fake.markOrphanedTagsReturnsOnCall[len(fake.markOrphanedTagsArgsForCall)]
+	fake.markOrphanedTagsArgsForCall = append(fake.markOrphanedTagsArgsForCall, struct {
+		arg1 []string
+		arg2 time.Time
+	}{arg1Copy, arg2})
+	stub := fake.MarkOrphanedTagsStub
+	fakeReturns := fake.markOrphanedTagsReturns
+	fake.recordInvocation("MarkOrphanedTags", []interface{}{arg1Copy, arg2})
+	fake.markOrphanedTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TagReclaimStore) MarkOrphanedTagsCallCount() int {
+	fake.markOrphanedTagsMutex.RLock()
+	defer fake.markOrphanedTagsMutex.RUnlock()
+	return len(fake.markOrphanedTagsArgsForCall)
+}
+
+func (fake *TagReclaimStore) MarkOrphanedTagsCalls(stub func([]string, time.Time) error) {
+	fake.markOrphanedTagsMutex.Lock()
+	defer fake.markOrphanedTagsMutex.Unlock()
+	fake.MarkOrphanedTagsStub = stub
+}
+
+func (fake *TagReclaimStore) MarkOrphanedTagsArgsForCall(i int) ([]string, time.Time) {
+	fake.markOrphanedTagsMutex.RLock()
+	defer fake.markOrphanedTagsMutex.RUnlock()
+	argsForCall := fake.markOrphanedTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TagReclaimStore) MarkOrphanedTagsReturns(result1 error) {
+	fake.markOrphanedTagsMutex.Lock()
+	defer fake.markOrphanedTagsMutex.Unlock()
+	fake.MarkOrphanedTagsStub = nil
+	fake.markOrphanedTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagReclaimStore) MarkOrphanedTagsReturnsOnCall(i int, result1 error) {
+	fake.markOrphanedTagsMutex.Lock()
+	defer fake.markOrphanedTagsMutex.Unlock()
+	fake.MarkOrphanedTagsStub = nil
+	if fake.markOrphanedTagsReturnsOnCall == nil {
+		fake.markOrphanedTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markOrphanedTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagReclaimStore) ReclaimOrphanedTags(arg1 time.Time) ([]store.Tag, error) {
+	fake.reclaimOrphanedTagsMutex.Lock()
+	ret, specificReturn := fake.reclaimOrphanedTagsReturnsOnCall[len(fake.reclaimOrphanedTagsArgsForCall)]
+	fake.reclaimOrphanedTagsArgsForCall = append(fake.reclaimOrphanedTagsArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	stub := fake.ReclaimOrphanedTagsStub
+	fakeReturns := fake.reclaimOrphanedTagsReturns
+	fake.recordInvocation("ReclaimOrphanedTags", []interface{}{arg1})
+	fake.reclaimOrphanedTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TagReclaimStore) ReclaimOrphanedTagsCallCount() int {
+	fake.reclaimOrphanedTagsMutex.RLock()
+	defer fake.reclaimOrphanedTagsMutex.RUnlock()
+	return len(fake.reclaimOrphanedTagsArgsForCall)
+}
+
+func (fake *TagReclaimStore) ReclaimOrphanedTagsCalls(stub func(time.Time) ([]store.Tag, error)) {
+	fake.reclaimOrphanedTagsMutex.Lock()
+	defer fake.reclaimOrphanedTagsMutex.Unlock()
+	fake.ReclaimOrphanedTagsStub = stub
+}
+
+func (fake *TagReclaimStore) ReclaimOrphanedTagsArgsForCall(i int) time.Time {
+	fake.reclaimOrphanedTagsMutex.RLock()
+	defer fake.reclaimOrphanedTagsMutex.RUnlock()
+	argsForCall := fake.reclaimOrphanedTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TagReclaimStore) ReclaimOrphanedTagsReturns(result1 []store.Tag, result2 error) {
+	fake.reclaimOrphanedTagsMutex.Lock()
+	defer fake.reclaimOrphanedTagsMutex.Unlock()
+	fake.ReclaimOrphanedTagsStub = nil
+	fake.reclaimOrphanedTagsReturns = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagReclaimStore) ReclaimOrphanedTagsReturnsOnCall(i int, result1 []store.Tag, result2 error) {
+	fake.reclaimOrphanedTagsMutex.Lock()
+	defer fake.reclaimOrphanedTagsMutex.Unlock()
+	fake.ReclaimOrphanedTagsStub = nil
+	if fake.reclaimOrphanedTagsReturnsOnCall == nil {
+		fake.reclaimOrphanedTagsReturnsOnCall = make(map[int]struct {
+			result1 []store.Tag
+			result2 error
+		})
+	}
+	fake.reclaimOrphanedTagsReturnsOnCall[i] = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagReclaimStore) TagUtilisation() (store.TagUtilisation, error) {
+	fake.tagUtilisationMutex.Lock()
+	ret, specificReturn := fake.tagUtilisationReturnsOnCall[len(fake.tagUtilisationArgsForCall)]
+	fake.tagUtilisationArgsForCall = append(fake.tagUtilisationArgsForCall, struct {
+	}{})
+	stub := fake.TagUtilisationStub
+	fakeReturns := fake.tagUtilisationReturns
+	fake.recordInvocation("TagUtilisation", []interface{}{})
+	fake.tagUtilisationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TagReclaimStore) TagUtilisationCallCount() int {
+	fake.tagUtilisationMutex.RLock()
+	defer fake.tagUtilisationMutex.RUnlock()
+	return len(fake.tagUtilisationArgsForCall)
+}
+
+func (fake *TagReclaimStore) TagUtilisationCalls(stub func() (store.TagUtilisation, error)) {
+	fake.tagUtilisationMutex.Lock()
+	defer fake.tagUtilisationMutex.Unlock()
+	fake.TagUtilisationStub = stub
+}
+
+func (fake *TagReclaimStore) TagUtilisationReturns(result1 store.TagUtilisation, result2 error) {
+	fake.tagUtilisationMutex.Lock()
+	defer fake.tagUtilisationMutex.Unlock()
+	fake.TagUtilisationStub = nil
+	fake.tagUtilisationReturns = struct {
+		result1 store.TagUtilisation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagReclaimStore) TagUtilisationReturnsOnCall(i int, result1 store.TagUtilisation, result2 error) {
+	fake.tagUtilisationMutex.Lock()
+	defer fake.tagUtilisationMutex.Unlock()
+	fake.TagUtilisationStub = nil
+	if fake.tagUtilisationReturnsOnCall == nil {
+		fake.tagUtilisationReturnsOnCall = make(map[int]struct {
+			result1 store.TagUtilisation
+			result2 error
+		})
+	}
+	fake.tagUtilisationReturnsOnCall[i] = struct {
+		result1 store.TagUtilisation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagReclaimStore) UnreferencedTags() ([]store.Tag, error) {
+	fake.unreferencedTagsMutex.Lock()
+	ret, specificReturn := fake.unreferencedTagsReturnsOnCall[len(fake.unreferencedTagsArgsForCall)]
+	fake.unreferencedTagsArgsForCall = append(fake.unreferencedTagsArgsForCall, struct {
+	}{})
+	stub := fake.UnreferencedTagsStub
+	fakeReturns := fake.unreferencedTagsReturns
+	fake.recordInvocation("UnreferencedTags", []interface{}{})
+	fake.unreferencedTagsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TagReclaimStore) UnreferencedTagsCallCount() int {
+	fake.unreferencedTagsMutex.RLock()
+	defer fake.unreferencedTagsMutex.RUnlock()
+	return len(fake.unreferencedTagsArgsForCall)
+}
+
+func (fake *TagReclaimStore) UnreferencedTagsCalls(stub func() ([]store.Tag, error)) {
+	fake.unreferencedTagsMutex.Lock()
+	defer fake.unreferencedTagsMutex.Unlock()
+	fake.UnreferencedTagsStub = stub
+}
+
+func (fake *TagReclaimStore) UnreferencedTagsReturns(result1 []store.Tag, result2 error) {
+	fake.unreferencedTagsMutex.Lock()
+	defer fake.unreferencedTagsMutex.Unlock()
+	fake.UnreferencedTagsStub = nil
+	fake.unreferencedTagsReturns = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagReclaimStore) UnreferencedTagsReturnsOnCall(i int, result1 []store.Tag, result2 error) {
+	fake.unreferencedTagsMutex.Lock()
+	defer fake.unreferencedTagsMutex.Unlock()
+	fake.UnreferencedTagsStub = nil
+	if fake.unreferencedTagsReturnsOnCall == nil {
+		fake.unreferencedTagsReturnsOnCall = make(map[int]struct {
+			result1 []store.Tag
+			result2 error
+		})
+	}
+	fake.unreferencedTagsReturnsOnCall[i] = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagReclaimStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.clearOrphanedTagsMutex.RLock()
+	defer fake.clearOrphanedTagsMutex.RUnlock()
+	fake.markOrphanedTagsMutex.RLock()
+	defer fake.markOrphanedTagsMutex.RUnlock()
+	fake.reclaimOrphanedTagsMutex.RLock()
+	defer fake.reclaimOrphanedTagsMutex.RUnlock()
+	fake.tagUtilisationMutex.RLock()
+	defer fake.tagUtilisationMutex.RUnlock()
+	fake.unreferencedTagsMutex.RLock()
+	defer fake.unreferencedTagsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TagReclaimStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -3,6 +3,7 @@ package cleaner
 import (
 	"fmt"
 	"policy-server/store"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -17,6 +18,7 @@ type uaaClient interface {
 type ccClient interface {
 	GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error)
 	GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveOrgGUIDs(token string, orgGUIDs []string) (map[string]struct{}, error)
 }
 
 //go:generate counterfeiter -o fakes/policy_store.go --fake-name PolicyStore . policyStore
@@ -39,6 +41,16 @@ type PolicyCleaner struct {
 	CCClient              ccClient
 	CCAppRequestChunkSize int
 	RequestTimeout        time.Duration
+
+	lastRunLock sync.Mutex
+	lastRun     RunResult
+}
+
+// RunResult records when the cleaner last ran and the error it returned, if
+// any. Time is zero until the first run.
+type RunResult struct {
+	Time  time.Time
+	Error error
 }
 
 func NewPolicyCleaner(logger lager.Logger, store policyStore, egressStore egressPolicyStore, uaaClient uaaClient,
@@ -55,6 +67,24 @@ func NewPolicyCleaner(logger lager.Logger, store policyStore, egressStore egress
 }
 
 func (p *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
+	policies, egressPolicies, err := p.deleteStalePolicies()
+
+	p.lastRunLock.Lock()
+	p.lastRun = RunResult{Time: time.Now(), Error: err}
+	p.lastRunLock.Unlock()
+
+	return policies, egressPolicies, err
+}
+
+func (p *PolicyCleaner) LastRun() RunResult {
+	p.lastRunLock.Lock()
+	defer p.lastRunLock.Unlock()
+	return p.lastRun
+}
+
+// FindStalePolicies returns the policies that DeleteStalePolicies would
+// delete, without deleting them.
+func (p *PolicyCleaner) FindStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
 	policies, err := p.Store.All()
 	if err != nil {
 		p.Logger.Error("store-list-policies-failed", err)
@@ -83,6 +113,15 @@ func (p *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, []store.EgressPol
 		return []store.Policy{}, []store.EgressPolicy{}, err
 	}
 
+	return policiesToDelete, egressPoliciesToDelete, nil
+}
+
+func (p *PolicyCleaner) deleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
+	policiesToDelete, egressPoliciesToDelete, err := p.FindStalePolicies()
+	if err != nil {
+		return []store.Policy{}, []store.EgressPolicy{}, err
+	}
+
 	p.Logger.Info("deleting stale policies:", lager.Data{
 		"total_c2c_policies":    len(policiesToDelete),
 		"stale_c2c_policies":    policiesToDelete,
@@ -132,10 +171,11 @@ func (p *PolicyCleaner) getC2CPoliciesToDelete(policies []store.Policy, token st
 }
 
 func (p *PolicyCleaner) getEgressPoliciesToDelete(egressPolicies []store.EgressPolicy, token string) ([]store.EgressPolicy, error) {
-	var spaceEgressPolicyGUIDs, appEgressPolicyGUIDs []string
+	var spaceEgressPolicyGUIDs, appEgressPolicyGUIDs, orgEgressPolicyGUIDs []string
 	spaceEgressPolicies := make(map[string][]store.EgressPolicy)
 	var egressPoliciesToDelete []store.EgressPolicy
 	appEgressPolicies := make(map[string][]store.EgressPolicy)
+	orgEgressPolicies := make(map[string][]store.EgressPolicy)
 
 	for _, egressPolicy := range egressPolicies {
 		if egressPolicy.Source.Type == "space" {
@@ -146,6 +186,12 @@ func (p *PolicyCleaner) getEgressPoliciesToDelete(egressPolicies []store.EgressP
 			appEgressPolicyGUIDs = append(appEgressPolicyGUIDs, egressPolicy.Source.ID)
 			appEgressPolicies[egressPolicy.Source.ID] = append(appEgressPolicies[egressPolicy.Source.ID], egressPolicy)
 		}
+		if egressPolicy.Source.Type == "org" {
+			if _, ok := orgEgressPolicies[egressPolicy.Source.ID]; !ok {
+				orgEgressPolicyGUIDs = append(orgEgressPolicyGUIDs, egressPolicy.Source.ID)
+			}
+			orgEgressPolicies[egressPolicy.Source.ID] = append(orgEgressPolicies[egressPolicy.Source.ID], egressPolicy)
+		}
 	}
 
 	appGUIDchunks := getChunks(appEgressPolicyGUIDs, p.CCAppRequestChunkSize)
@@ -167,6 +213,18 @@ func (p *PolicyCleaner) getEgressPoliciesToDelete(egressPolicies []store.EgressP
 		return nil, fmt.Errorf("get live space guids failed: %s", err)
 	}
 	egressPoliciesToDelete = append(egressPoliciesToDelete, getStaleEgressSpacePolicies(spaceEgressPolicies, liveSpaceGUIDs)...)
+
+	for _, orgGUIDchunk := range getChunks(orgEgressPolicyGUIDs, p.CCAppRequestChunkSize) {
+		liveOrgGUIDs, err := p.CCClient.GetLiveOrgGUIDs(token, orgGUIDchunk)
+		if err != nil {
+			p.Logger.Error("get-live-org-guids-failed", err)
+			return nil, fmt.Errorf("get live org guids failed: %s", err)
+		}
+
+		staleOrgGUIDs := getStaleAppGUIDs(liveOrgGUIDs, orgGUIDchunk)
+		egressPoliciesToDelete = append(egressPoliciesToDelete, getStaleEgressAppPolicies(orgEgressPolicies, staleOrgGUIDs)...)
+	}
+
 	return egressPoliciesToDelete, nil
 }
 
@@ -191,6 +191,29 @@ var _ = Describe("PolicyCleaner", func() {
 		Expect(deletedEgressPolicies).To(Equal(staleEgressPolicies))
 	})
 
+	It("finds stale policies without deleting them or recording a run", func() {
+		stalePolicies, staleEgressPolicies, err := policyCleaner.FindStalePolicies()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stalePolicies).To(Equal(c2cPolicies[1:]))
+		Expect(staleEgressPolicies).To(Equal(egressPolicies[2:]))
+
+		Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+		Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+		Expect(policyCleaner.LastRun().Time).To(BeZero())
+	})
+
+	It("records the last run", func() {
+		Expect(policyCleaner.LastRun().Time).To(BeZero())
+
+		_, _, err := policyCleaner.DeleteStalePolicies()
+		Expect(err).NotTo(HaveOccurred())
+
+		lastRun := policyCleaner.LastRun()
+		Expect(lastRun.Time).To(BeTemporally("~", time.Now(), time.Second))
+		Expect(lastRun.Error).NotTo(HaveOccurred())
+	})
+
 	Context("when there are more apps with policies than the CC chunk size", func() {
 		BeforeEach(func() {
 			policyCleaner = &cleaner.PolicyCleaner{
@@ -241,6 +264,46 @@ var _ = Describe("PolicyCleaner", func() {
 		Expect(logger).To(gbytes.Say("get-live-space-guids-failed.*yankee"))
 	})
 
+	Context("when there are org and default egress policies", func() {
+		var orgAndDefaultPolicies []store.EgressPolicy
+
+		BeforeEach(func() {
+			destination := store.EgressDestination{
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "1.2.3.4", End: "1.2.3.4"}},
+			}
+			orgAndDefaultPolicies = []store.EgressPolicy{
+				{Source: store.EgressSource{ID: "live-org-guid", Type: "org"}, Destination: destination},
+				{Source: store.EgressSource{ID: "dead-org-guid", Type: "org"}, Destination: destination},
+				{Source: store.EgressSource{ID: "dead-org-guid", Type: "org"}, Destination: destination},
+				{Source: store.EgressSource{Type: "default"}, Destination: destination},
+			}
+			fakeEgressStore.AllReturns(orgAndDefaultPolicies, nil)
+			fakeCCClient.GetLiveOrgGUIDsReturns(map[string]struct{}{"live-org-guid": {}}, nil)
+		})
+
+		It("deletes the policies of orgs that do not exist and keeps default policies", func() {
+			_, deletedEgressPolicies, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveOrgGUIDsCallCount()).To(Equal(1))
+			token, orgGUIDs := fakeCCClient.GetLiveOrgGUIDsArgsForCall(0)
+			Expect(token).To(Equal("valid-token"))
+			Expect(orgGUIDs).To(Equal([]string{"live-org-guid", "dead-org-guid"}))
+
+			Expect(deletedEgressPolicies).To(Equal([]store.EgressPolicy{orgAndDefaultPolicies[1], orgAndDefaultPolicies[2]}))
+			Expect(fakeEgressStore.DeleteArgsForCall(0)).To(Equal(deletedEgressPolicies))
+		})
+
+		It("returns a helpful error when get live org guids call fails", func() {
+			fakeCCClient.GetLiveOrgGUIDsReturns(nil, errors.New("zulu"))
+
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).To(MatchError("get live org guids failed: zulu"))
+			Expect(logger).To(gbytes.Say("get-live-org-guids-failed.*zulu"))
+		})
+	})
+
 	Context("When retrieving policies from the db fails", func() {
 		BeforeEach(func() {
 			fakeStore.AllReturns([]store.Policy{}, errors.New("potato"))
@@ -287,6 +350,11 @@ var _ = Describe("PolicyCleaner", func() {
 			policyCleaner.DeleteStalePolicies()
 			Expect(logger).To(gbytes.Say("get-uaa-token-failed.*potato"))
 		})
+
+		It("records the failed run", func() {
+			policyCleaner.DeleteStalePolicies()
+			Expect(policyCleaner.LastRun().Error).To(MatchError("get UAA token failed: potato"))
+		})
 	})
 
 	Context("When getting the apps from the Cloud-Controller fails", func() {
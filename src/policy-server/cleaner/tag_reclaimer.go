@@ -0,0 +1,170 @@
+package cleaner
+
+import (
+	"fmt"
+	"policy-server/store"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/tag_reclaim_store.go --fake-name TagReclaimStore . tagReclaimStore
+type tagReclaimStore interface {
+	TagUtilisation() (store.TagUtilisation, error)
+	UnreferencedTags() ([]store.Tag, error)
+	MarkOrphanedTags([]string, time.Time) error
+	ClearOrphanedTags([]string) error
+	ReclaimOrphanedTags(time.Time) ([]store.Tag, error)
+}
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+}
+
+// TagReclaimer releases tags whose group has no policy and whose app or space
+// no longer exists in Cloud Controller. A tag is only released after it has
+// been orphaned for the whole Quarantine, so that a transient CC answer cannot
+// free a tag that is still in use. Groups of any other type are never
+// released, since there is no way to tell whether they are still in use.
+type TagReclaimer struct {
+	Logger                 lager.Logger
+	Store                  tagReclaimStore
+	UAAClient              uaaClient
+	CCClient               ccClient
+	MetricsSender          metricsSender
+	CCAppRequestChunkSize  int
+	Quarantine             time.Duration
+	ReclaimEnabled         bool
+	UtilisationWarnPercent int
+	Clock                  clock.Clock
+}
+
+func (r *TagReclaimer) ReclaimTags() ([]store.Tag, error) {
+	unreferenced, err := r.Store.UnreferencedTags()
+	if err != nil {
+		r.Logger.Error("store-list-unreferenced-tags-failed", err)
+		return nil, fmt.Errorf("database read failed for tags: %s", err)
+	}
+
+	token, err := r.UAAClient.GetToken()
+	if err != nil {
+		r.Logger.Error("get-uaa-token-failed", err)
+		return nil, fmt.Errorf("get UAA token failed: %s", err)
+	}
+
+	orphanedGUIDs, liveGUIDs, err := r.partitionByLiveness(unreferenced, token)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Store.ClearOrphanedTags(liveGUIDs)
+	if err != nil {
+		r.Logger.Error("store-clear-orphaned-tags-failed", err)
+		return nil, fmt.Errorf("database write failed: %s", err)
+	}
+
+	now := r.Clock.Now().UTC()
+	err = r.Store.MarkOrphanedTags(orphanedGUIDs, now)
+	if err != nil {
+		r.Logger.Error("store-mark-orphaned-tags-failed", err)
+		return nil, fmt.Errorf("database write failed: %s", err)
+	}
+
+	var reclaimed []store.Tag
+	if r.ReclaimEnabled {
+		reclaimed, err = r.Store.ReclaimOrphanedTags(now.Add(-r.Quarantine))
+		if err != nil {
+			r.Logger.Error("store-reclaim-orphaned-tags-failed", err)
+			return nil, fmt.Errorf("database write failed: %s", err)
+		}
+		for range reclaimed {
+			r.MetricsSender.IncrementCounter("TagsReclaimed")
+		}
+	}
+
+	r.Logger.Info("tracked-orphaned-tags", lager.Data{
+		"unreferenced_tags": len(unreferenced),
+		"orphaned_tags":     len(orphanedGUIDs),
+		"reclaimed_tags":    reclaimed,
+	})
+
+	r.checkUtilisation()
+
+	return reclaimed, nil
+}
+
+func (r *TagReclaimer) ReclaimTagsWrapper() error {
+	_, err := r.ReclaimTags()
+	return err
+}
+
+func (r *TagReclaimer) checkUtilisation() {
+	utilisation, err := r.Store.TagUtilisation()
+	if err != nil {
+		r.Logger.Error("store-tag-utilisation-failed", err)
+		return
+	}
+	if utilisation.Total == 0 || r.UtilisationWarnPercent <= 0 {
+		return
+	}
+
+	percent := utilisation.Allocated * 100 / utilisation.Total
+	if percent >= r.UtilisationWarnPercent {
+		r.MetricsSender.IncrementCounter("TagSpaceUtilisationWarning")
+		r.Logger.Info("tag-space-utilisation-high", lager.Data{
+			"allocated":           utilisation.Allocated,
+			"total":               utilisation.Total,
+			"orphaned":            utilisation.Orphaned,
+			"utilisation_percent": percent,
+		})
+	}
+}
+
+func (r *TagReclaimer) partitionByLiveness(tags []store.Tag, token string) ([]string, []string, error) {
+	var appGUIDs, spaceGUIDs []string
+	for _, tag := range tags {
+		switch tag.Type {
+		case "app":
+			appGUIDs = append(appGUIDs, tag.ID)
+		case "space":
+			spaceGUIDs = append(spaceGUIDs, tag.ID)
+		}
+	}
+
+	var orphaned, live []string
+	for _, appGUIDchunk := range getChunks(appGUIDs, r.CCAppRequestChunkSize) {
+		liveAppGUIDs, err := r.CCClient.GetLiveAppGUIDs(token, appGUIDchunk)
+		if err != nil {
+			r.Logger.Error("cc-get-app-guids-failed", err)
+			return nil, nil, fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
+		}
+		o, l := splitByLiveness(appGUIDchunk, liveAppGUIDs)
+		orphaned, live = append(orphaned, o...), append(live, l...)
+	}
+
+	for _, spaceGUIDchunk := range getChunks(spaceGUIDs, r.CCAppRequestChunkSize) {
+		liveSpaceGUIDs, err := r.CCClient.GetLiveSpaceGUIDs(token, spaceGUIDchunk)
+		if err != nil {
+			r.Logger.Error("get-live-space-guids-failed", err)
+			return nil, nil, fmt.Errorf("get live space guids failed: %s", err)
+		}
+		o, l := splitByLiveness(spaceGUIDchunk, liveSpaceGUIDs)
+		orphaned, live = append(orphaned, o...), append(live, l...)
+	}
+
+	return orphaned, live, nil
+}
+
+func splitByLiveness(guids []string, liveGUIDs map[string]struct{}) ([]string, []string) {
+	var orphaned, live []string
+	for _, guid := range guids {
+		if _, ok := liveGUIDs[guid]; ok {
+			live = append(live, guid)
+		} else {
+			orphaned = append(orphaned, guid)
+		}
+	}
+	return orphaned, live
+}
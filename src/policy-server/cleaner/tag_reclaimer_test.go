@@ -0,0 +1,192 @@
+package cleaner_test
+
+import (
+	"errors"
+	"policy-server/cleaner"
+	"policy-server/cleaner/fakes"
+	"policy-server/store"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("TagReclaimer", func() {
+	var (
+		tagReclaimer      *cleaner.TagReclaimer
+		fakeStore         *fakes.TagReclaimStore
+		fakeUAAClient     *fakes.UAAClient
+		fakeCCClient      *fakes.CCClient
+		fakeMetricsSender *fakes.MetricsSender
+		fakeClock         *fakeclock.FakeClock
+		logger            *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		fakeStore = &fakes.TagReclaimStore{}
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeCCClient = &fakes.CCClient{}
+		fakeMetricsSender = &fakes.MetricsSender{}
+		fakeClock = fakeclock.NewFakeClock(time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC))
+		logger = lagertest.NewTestLogger("test")
+
+		tagReclaimer = &cleaner.TagReclaimer{
+			Logger:                 logger,
+			Store:                  fakeStore,
+			UAAClient:              fakeUAAClient,
+			CCClient:               fakeCCClient,
+			MetricsSender:          fakeMetricsSender,
+			CCAppRequestChunkSize:  100,
+			Quarantine:             time.Hour,
+			ReclaimEnabled:         true,
+			UtilisationWarnPercent: 80,
+			Clock:                  fakeClock,
+		}
+
+		fakeStore.UnreferencedTagsReturns([]store.Tag{
+			{ID: "live-app-guid", Tag: "0001", Type: "app"},
+			{ID: "dead-app-guid", Tag: "0002", Type: "app"},
+			{ID: "live-space-guid", Tag: "0003", Type: "space"},
+			{ID: "dead-space-guid", Tag: "0004", Type: "space"},
+			{ID: "some-router-guid", Tag: "0005", Type: "router"},
+		}, nil)
+		fakeStore.ReclaimOrphanedTagsReturns([]store.Tag{
+			{ID: "dead-app-guid", Tag: "0002", Type: "app"},
+		}, nil)
+		fakeStore.TagUtilisationReturns(store.TagUtilisation{Total: 100, Allocated: 10}, nil)
+
+		fakeUAAClient.GetTokenReturns("valid-token", nil)
+		fakeCCClient.GetLiveAppGUIDsReturns(map[string]struct{}{"live-app-guid": {}}, nil)
+		fakeCCClient.GetLiveSpaceGUIDsReturns(map[string]struct{}{"live-space-guid": {}}, nil)
+	})
+
+	It("marks unreferenced tags of deleted apps and spaces as orphaned", func() {
+		_, err := tagReclaimer.ReclaimTags()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(1))
+		token, appGUIDs := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
+		Expect(token).To(Equal("valid-token"))
+		Expect(appGUIDs).To(ConsistOf("live-app-guid", "dead-app-guid"))
+
+		Expect(fakeCCClient.GetLiveSpaceGUIDsCallCount()).To(Equal(1))
+		_, spaceGUIDs := fakeCCClient.GetLiveSpaceGUIDsArgsForCall(0)
+		Expect(spaceGUIDs).To(ConsistOf("live-space-guid", "dead-space-guid"))
+
+		Expect(fakeStore.MarkOrphanedTagsCallCount()).To(Equal(1))
+		orphaned, orphanedAt := fakeStore.MarkOrphanedTagsArgsForCall(0)
+		Expect(orphaned).To(ConsistOf("dead-app-guid", "dead-space-guid"))
+		Expect(orphanedAt).To(Equal(fakeClock.Now().UTC()))
+	})
+
+	It("clears the orphaned mark of tags whose app or space is live", func() {
+		_, err := tagReclaimer.ReclaimTags()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeStore.ClearOrphanedTagsCallCount()).To(Equal(1))
+		Expect(fakeStore.ClearOrphanedTagsArgsForCall(0)).To(ConsistOf("live-app-guid", "live-space-guid"))
+	})
+
+	It("reclaims tags that have been orphaned for longer than the quarantine", func() {
+		reclaimed, err := tagReclaimer.ReclaimTags()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reclaimed).To(Equal([]store.Tag{{ID: "dead-app-guid", Tag: "0002", Type: "app"}}))
+
+		Expect(fakeStore.ReclaimOrphanedTagsCallCount()).To(Equal(1))
+		Expect(fakeStore.ReclaimOrphanedTagsArgsForCall(0)).To(Equal(fakeClock.Now().UTC().Add(-time.Hour)))
+
+		Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+		Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("TagsReclaimed"))
+	})
+
+	Context("when reclamation is disabled", func() {
+		BeforeEach(func() {
+			tagReclaimer.ReclaimEnabled = false
+		})
+
+		It("tracks orphaned tags without reclaiming them", func() {
+			reclaimed, err := tagReclaimer.ReclaimTags()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed).To(BeEmpty())
+
+			Expect(fakeStore.MarkOrphanedTagsCallCount()).To(Equal(1))
+			Expect(fakeStore.ReclaimOrphanedTagsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the tag space utilisation is above the warning threshold", func() {
+		BeforeEach(func() {
+			fakeStore.TagUtilisationReturns(store.TagUtilisation{Total: 100, Allocated: 85, Orphaned: 3}, nil)
+		})
+
+		It("logs a warning and emits a counter", func() {
+			_, err := tagReclaimer.ReclaimTags()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger).To(gbytes.Say("tag-space-utilisation-high.*\"utilisation_percent\":85"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(1)).To(Equal("TagSpaceUtilisationWarning"))
+		})
+	})
+
+	Context("when listing unreferenced tags fails", func() {
+		BeforeEach(func() {
+			fakeStore.UnreferencedTagsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns a meaningful error", func() {
+			_, err := tagReclaimer.ReclaimTags()
+			Expect(err).To(MatchError("database read failed for tags: potato"))
+			Expect(fakeStore.MarkOrphanedTagsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when getting the UAA token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("potato"))
+		})
+
+		It("returns a meaningful error", func() {
+			_, err := tagReclaimer.ReclaimTags()
+			Expect(err).To(MatchError("get UAA token failed: potato"))
+		})
+	})
+
+	Context("when CC fails to return live apps", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetLiveAppGUIDsReturns(nil, errors.New("potato"))
+		})
+
+		It("does not mark or reclaim anything", func() {
+			_, err := tagReclaimer.ReclaimTags()
+			Expect(err).To(MatchError("get app guids from Cloud-Controller failed: potato"))
+			Expect(fakeStore.MarkOrphanedTagsCallCount()).To(Equal(0))
+			Expect(fakeStore.ReclaimOrphanedTagsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when CC fails to return live spaces", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetLiveSpaceGUIDsReturns(nil, errors.New("potato"))
+		})
+
+		It("does not mark or reclaim anything", func() {
+			_, err := tagReclaimer.ReclaimTags()
+			Expect(err).To(MatchError("get live space guids failed: potato"))
+			Expect(fakeStore.MarkOrphanedTagsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when reclaiming fails", func() {
+		BeforeEach(func() {
+			fakeStore.ReclaimOrphanedTagsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns a meaningful error", func() {
+			err := tagReclaimer.ReclaimTagsWrapper()
+			Expect(err).To(MatchError("database write failed: potato"))
+		})
+	})
+})
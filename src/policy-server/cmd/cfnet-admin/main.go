@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"policy-server/admin"
+	"policy-server/psclient"
+	"policy-server/uaa_client"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const defaultConfigFile = ".cfnet-admin.json"
+
+func main() {
+	err := mainWithError()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cfnet-admin: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func mainWithError() error {
+	configFilePath := flag.String("config", "", "path to config file (default $CFNET_ADMIN_CONFIG or ~/"+defaultConfigFile+")")
+	output := flag.String("output", admin.OutputTable, "output format, table or json")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, admin.Usage) }
+	flag.Parse()
+
+	path := *configFilePath
+	if path == "" && os.Getenv(admin.EnvConfigFile) == "" {
+		path = homeConfigFile()
+	}
+	conf, err := admin.LoadConfig(path, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	logger := lager.NewLogger("cfnet-admin")
+	logger.RegisterSink(lager.NewWriterSink(os.Stderr, lager.INFO))
+
+	httpClient, err := newHTTPClient(conf)
+	if err != nil {
+		return err
+	}
+
+	var tokenFetcher psclient.TokenFetcher = psclient.StaticToken(conf.Token)
+	if conf.Token == "" {
+		tokenFetcher = &uaa_client.Client{
+			BaseURL:    conf.UAAURL,
+			Name:       conf.ClientID,
+			Secret:     conf.ClientSecret,
+			HTTPClient: httpClient,
+			Logger:     logger,
+		}
+	}
+
+	cli := &admin.CLI{
+		Client: psclient.NewClient(logger, httpClient, conf.APIURL, tokenFetcher),
+		Out:    os.Stdout,
+		Output: *output,
+	}
+	return cli.Run(flag.Args())
+}
+
+// homeConfigFile is the default config file, if it exists, so that the
+// environment alone can configure the CLI.
+func homeConfigFile() string {
+	home := os.Getenv("HOME")
+	if home == "" {
+		return ""
+	}
+	path := filepath.Join(home, defaultConfigFile)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func newHTTPClient(conf admin.Config) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: conf.SkipSSLValidation}
+	if conf.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(conf.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca cert file: %s", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("ca cert file %s has no certificates", conf.CACertFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
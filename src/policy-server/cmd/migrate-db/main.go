@@ -6,6 +6,7 @@ import (
 	"policy-server/config"
 	"policy-server/db"
 	"policy-server/store"
+	"text/tabwriter"
 	"time"
 
 	"flag"
@@ -15,6 +16,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerflags"
+	migrate "github.com/cf-container-networking/sql-migrate"
 )
 
 const (
@@ -22,6 +24,17 @@ const (
 	logPrefix = "cfnetworking"
 )
 
+const usage = `usage: migrate-db -config-file <path> [-dry-run [-driver <driver>]] [command]
+
+commands:
+  up                    run all pending migrations, populate the groups table and ip range keys (default)
+  status                list applied and pending migrations
+  down -target <id>     revert applied migrations after <id>; every one must define a reverse
+
+With -dry-run, the SQL is printed for the configured database, or for -driver:
+mysql, postgres, sqlite3 or all.
+`
+
 func main() {
 	err := mainWithError()
 	if err != nil {
@@ -31,10 +44,61 @@ func main() {
 }
 
 func mainWithError() error {
-	conf := parseConfig()
+	configFilePath := flag.String("config-file", "", "path to config file")
+	dryRun := flag.Bool("dry-run", false, "print the SQL that would run without running it")
+	driver := flag.String("driver", "", "with -dry-run, print the SQL for mysql, postgres, sqlite3 or all instead of the configured database")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	conf := parseConfig(*configFilePath)
 
 	logger, _ := lagerflags.NewFromConfig(fmt.Sprintf("%s.%s", logPrefix, jobPrefix), common.GetLagerConfig())
 
+	command := "up"
+	args := flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "up":
+		if *dryRun {
+			return withMigrator(logger, conf, func(migrator *migrations.Migrator, dbConn *db.ConnWrapper) error {
+				return printPendingMigrations(migrator, dbConn, *driver)
+			})
+		}
+		return migrateWithTimeout(logger, conf)
+	case "status":
+		return withMigrator(logger, conf, printStatus)
+	case "down":
+		downFlags := flag.NewFlagSet("down", flag.ContinueOnError)
+		target := downFlags.String("target", "", "id of the migration to revert to")
+		err := downFlags.Parse(args)
+		if err != nil {
+			return err
+		}
+		if *target == "" {
+			return fmt.Errorf("down requires -target")
+		}
+		return withMigrator(logger, conf, func(migrator *migrations.Migrator, dbConn *db.ConnWrapper) error {
+			return migrateDown(logger, migrator, dbConn, *target, *dryRun, *driver)
+		})
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func parseConfig(configFilePath string) *config.Config {
+	conf, err := config.New(configFilePath)
+	if err != nil {
+		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
+	}
+
+	return conf
+}
+
+func migrateWithTimeout(logger lager.Logger, conf *config.Config) error {
 	doneChan := make(chan bool, 1)
 	go func() {
 		for {
@@ -57,19 +121,7 @@ func mainWithError() error {
 	}
 }
 
-func parseConfig() *config.Config {
-	configFilePath := flag.String("config-file", "", "path to config file")
-	flag.Parse()
-
-	conf, err := config.New(*configFilePath)
-	if err != nil {
-		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
-	}
-
-	return conf
-}
-
-func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) error {
+func getConnection(logger lager.Logger, conf *config.Config) (*db.ConnWrapper, error) {
 	logger.Info("getting migration db connection")
 	dbConn, err := db.NewErroringConnectionPool(
 		conf.Database,
@@ -81,22 +133,32 @@ func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) err
 		logger,
 	)
 	if err != nil {
-		return fmt.Errorf("getting migration db connection: %s", err)
+		return nil, fmt.Errorf("getting migration db connection: %s", err)
 	}
+	logger.Info("migration db connection retrieved")
+	return dbConn, nil
+}
 
+func withMigrator(logger lager.Logger, conf *config.Config, f func(*migrations.Migrator, *db.ConnWrapper) error) error {
+	dbConn, err := getConnection(logger, conf)
+	if err != nil {
+		return err
+	}
 	defer dbConn.Close()
 
-	logger.Info("migration db connection retrieved")
+	return f(store.NewMigrator(dbConn), dbConn)
+}
 
-	migrator := &migrations.Migrator{
-		MigrateAdapter: &migrations.MigrateAdapter{},
-		MigrationsProvider: &migrations.MigrationsProvider{
-			Store: &store.MigrationsStore{
-				DBConn: dbConn,
-			},
-		},
+func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) error {
+	dbConn, err := getConnection(logger, conf)
+	if err != nil {
+		return err
 	}
 
+	defer dbConn.Close()
+
+	migrator := store.NewMigrator(dbConn)
+
 	tagPopulator := &store.TagPopulator{DBConnection: dbConn}
 
 	logger.Info("running migrations")
@@ -113,5 +175,98 @@ func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) err
 	}
 	logger.Info("finished populating groups table")
 
+	logger.Info("populating ip range keys")
+	ipRangeKeyPopulator := &store.IPRangeKeyPopulator{DBConnection: dbConn}
+	err = ipRangeKeyPopulator.PopulateKeys()
+	if err != nil {
+		return fmt.Errorf("populating ip range keys: %s", err)
+	}
+	logger.Info("finished populating ip range keys")
+
+	return nil
+}
+
+func printStatus(migrator *migrations.Migrator, dbConn *db.ConnWrapper) error {
+	statuses, err := migrator.Status(dbConn.DriverName(), dbConn)
+	if err != nil {
+		return fmt.Errorf("migration status: %s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tAPPLIED AT\tREVERSIBLE")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", status.Id, state, appliedAt, status.Reversible)
+	}
+	return w.Flush()
+}
+
+func printPendingMigrations(migrator *migrations.Migrator, dbConn *db.ConnWrapper, driver string) error {
+	pending, err := migrator.PendingMigrations(dbConn.DriverName(), dbConn)
+	if err != nil {
+		return fmt.Errorf("pending migrations: %s", err)
+	}
+	if len(pending) == 0 {
+		fmt.Println("-- no pending migrations")
+		return nil
+	}
+	return printMigrations(migrator, dryRunDrivers(driver, dbConn.DriverName()), pending, migrate.Up)
+}
+
+func migrateDown(logger lager.Logger, migrator *migrations.Migrator, dbConn *db.ConnWrapper, target string, dryRun bool, driver string) error {
+	if dryRun {
+		down, err := migrator.DownMigrations(dbConn.DriverName(), dbConn, target)
+		if err != nil {
+			return fmt.Errorf("down migrations: %s", err)
+		}
+		if len(down) == 0 {
+			fmt.Printf("-- already at migration %s\n", target)
+			return nil
+		}
+		return printMigrations(migrator, dryRunDrivers(driver, dbConn.DriverName()), down, migrate.Down)
+	}
+
+	logger.Info("reverting migrations", lager.Data{"target": target})
+	numMigrationsRun, err := migrator.PerformDownMigrations(dbConn.DriverName(), dbConn, target)
+	if err != nil {
+		return fmt.Errorf("perform down migrations: %s", err)
+	}
+	logger.Info("finished reverting migrations", lager.Data{"num-migrations-reverted": numMigrationsRun})
+	return nil
+}
+
+// dryRunDrivers returns the drivers to print SQL for: the one of the
+// configured database unless -driver names another, or all of them.
+func dryRunDrivers(driver, configuredDriver string) []string {
+	switch driver {
+	case "":
+		return []string{configuredDriver}
+	case "all":
+		return []string{"mysql", "postgres", db.SQLite}
+	default:
+		return []string{driver}
+	}
+}
+
+func printMigrations(migrator *migrations.Migrator, drivers []string, ms []*migrate.Migration, direction migrate.MigrationDirection) error {
+	for _, driverName := range drivers {
+		driverMigrations, err := migrator.ForDriver(ms, driverName)
+		if err != nil {
+			return fmt.Errorf("migrations for %s: %s", driverName, err)
+		}
+		for _, m := range driverMigrations {
+			statements := m.Up
+			if direction == migrate.Down {
+				statements = m.Down
+			}
+			fmt.Printf("-- migration %s (%s)\n", m.Id, driverName)
+			for _, statement := range statements {
+				fmt.Printf("%s;\n", statement)
+			}
+		}
+	}
 	return nil
 }
@@ -1,28 +1,37 @@
 package main
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"lib/common"
+	"lib/httpserver"
+	"lib/nonmutualtls"
+	"lib/poller"
+	"lib/tlsreload"
 	"log"
 	"net/http"
 	"os"
 	"time"
 
 	"policy-server/api"
+	"policy-server/cc_client"
 	"policy-server/config"
 	"policy-server/handlers"
+	"policy-server/health"
 	"policy-server/store"
+	"policy-server/uaa_client"
 
 	"policy-server/db"
 
 	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/cf-networking-helpers/json_client"
 	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
 	"code.cloudfoundry.org/cf-networking-helpers/middleware"
 	middlewareAdapter "code.cloudfoundry.org/cf-networking-helpers/middleware/adapter"
-	"code.cloudfoundry.org/cf-networking-helpers/mutualtls"
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/debugserver"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerflags"
@@ -66,8 +75,26 @@ func main() {
 		logger,
 	)
 
-	dataStore := store.New(
+	metricsSender := &metrics.MetricsSender{
+		Logger: logger.Session("time-metric-emitter"),
+	}
+
+	readConnection, replicaPools := common.InitReplicaRouter(
+		logger,
+		connectionPool,
+		conf.DatabaseReplicas,
+		conf.MaxOpenConnections,
+		conf.MaxIdleConnections,
+		time.Duration(conf.MaxConnectionsLifetimeSeconds)*time.Second,
+		time.Duration(conf.DatabaseReplicaMaxLagSeconds)*time.Second,
+		metricsSender,
+		logPrefix,
+		jobPrefix,
+	)
+
+	dataStore := store.NewWithReader(
 		connectionPool,
+		readConnection,
 		&store.GroupTable{},
 		&store.DestinationTable{},
 		&store.PolicyTable{},
@@ -81,11 +108,7 @@ func main() {
 		},
 	}
 
-	tagDataStore := store.NewTagStore(connectionPool, &store.GroupTable{}, conf.TagLength)
-
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
-	}
+	tagDataStore := store.NewTagStoreWithReader(connectionPool, readConnection, &store.GroupTable{}, conf.TagLength)
 
 	wrappedStore := &store.MetricsWrapper{
 		Store:         dataStore,
@@ -105,6 +128,11 @@ func main() {
 
 	internalPoliciesHandlerV1 := handlers.NewPoliciesIndexInternal(logger, wrappedStore,
 		wrappedEgressStore, policyCollectionWriter, errorResponse)
+	if conf.CCURL != "" {
+		internalPoliciesHandlerV1.OrgResolver = initAppOrgResolver(logger, conf)
+	} else {
+		logger.Info("app-org-resolution-disabled")
+	}
 
 	createTagsHandlerV1 := &handlers.TagsCreate{
 		Store:         wrappedStore,
@@ -127,12 +155,30 @@ func main() {
 		return logWrapper.LogWrap(logger, handler)
 	}
 
+	clientRoles := map[string]string{}
+	for _, clientIdentity := range conf.ClientIdentities {
+		clientRoles[clientIdentity.Identity] = clientIdentity.Role
+	}
+	if len(clientRoles) == 0 {
+		logger.Info("client-identity-authorization-disabled")
+	}
+	clientIdentityAuthorizer := &handlers.ClientIdentityAuthorizer{
+		Roles:         clientRoles,
+		ErrorResponse: errorResponse,
+	}
+	policiesRoleWrap := func(handler http.Handler) http.Handler {
+		return clientIdentityAuthorizer.Wrap(handler, handlers.ClientRoleCell, handlers.ClientRoleOperator)
+	}
+	tagsRoleWrap := func(handler http.Handler) http.Handler {
+		return clientIdentityAuthorizer.Wrap(handler, handlers.ClientRoleTagger, handlers.ClientRoleOperator)
+	}
+
 	err = dropsonde.Initialize(conf.MetronAddress, jobPrefix)
 	if err != nil {
 		log.Fatalf("%s.%s: initializing dropsonde: %s", logPrefix, jobPrefix, err)
 	}
 
-	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore)
+	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore, readConnection.LagSources()...)
 
 	internalRoutes := rata.Routes{
 		{Name: "internal_policies", Method: "GET", Path: "/networking/:version/internal/policies"},
@@ -140,41 +186,70 @@ func main() {
 	}
 
 	internalHandlers := rata.Handlers{
-		"internal_policies": metricsWrap("InternalPolicies", logWrap(internalPoliciesHandlerV1)),
-		"create_tags":       metricsWrap("CreateTags", logWrap(createTagsHandlerV1)),
+		"internal_policies": metricsWrap("InternalPolicies", logWrap(policiesRoleWrap(internalPoliciesHandlerV1))),
+		"create_tags":       metricsWrap("CreateTags", logWrap(tagsRoleWrap(createTagsHandlerV1))),
 	}
 
-	tlsConfig, err := mutualtls.NewServerTLSConfig(conf.ServerCertFile, conf.ServerKeyFile, conf.CACertFile)
+	serverTLSConfig, err := tlsreload.NewServerConfig(logger.Session("tls-config"), clock.NewClock(),
+		conf.ServerCertFile, conf.ServerKeyFile, conf.CACertFile,
+		time.Duration(conf.CARotationOverlapSeconds)*time.Second)
 	if err != nil {
 		log.Fatalf("%s.%s: mutual tls config: %s", logPrefix, jobPrefix, err) // not tested
 	}
 
-	internalServer := common.InitServer(logger, tlsConfig, conf.ListenHost, conf.InternalListenPort, internalHandlers, internalRoutes)
+	drainState := &httpserver.DrainState{}
+	serverOptions := httpserver.Options{
+		ReadTimeout:  time.Duration(conf.ServerReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(conf.ServerWriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(conf.ServerIdleTimeoutSeconds) * time.Second,
+		DrainTimeout: time.Duration(conf.DrainTimeoutSeconds) * time.Second,
+		DrainState:   drainState,
+	}
+
+	internalServer := common.InitServer(logger, serverTLSConfig.TLSConfig(), conf.ListenHost, conf.InternalListenPort, internalHandlers, internalRoutes, serverOptions)
 	debugServer := debugserver.Runner(fmt.Sprintf("%s:%d", conf.DebugServerHost, conf.DebugServerPort), reconfigurableSink)
 
 	uptimeHandler := &handlers.UptimeHandler{
 		StartTime: time.Now(),
 	}
-	healthHandler := handlers.NewHealth(wrappedStore, errorResponse)
+	healthChecker := &health.Checker{
+		Checks: []health.Check{
+			health.NewDatabaseCheck(wrappedStore, health.DefaultCheckTimeout),
+			health.NewMigrationsCheck(store.NewMigrator(connectionPool), connectionPool.DriverName(), connectionPool, health.DefaultCheckTimeout),
+			health.NewTagUtilisationCheck(tagDataStore, health.DefaultCheckTimeout),
+		},
+	}
+	healthHandler := handlers.NewHealth(wrappedStore, tagDataStore, healthChecker, errorResponse)
+	healthHandler.DrainState = drainState
+	readinessHandler := handlers.NewReadiness(healthChecker, errorResponse)
+	readinessHandler.DrainState = drainState
 
 	healthRoutes := rata.Routes{
 		{Name: "uptime", Method: "GET", Path: "/"},
 		{Name: "health", Method: "GET", Path: "/health"},
+		{Name: "liveness", Method: "GET", Path: "/health/liveness"},
+		{Name: "readiness", Method: "GET", Path: "/health/readiness"},
 	}
 
 	healthHandlers := rata.Handlers{
-		"uptime": metricsWrap("Uptime", logWrap(uptimeHandler)),
-		"health": metricsWrap("Health", logWrap(healthHandler)),
+		"uptime":    metricsWrap("Uptime", logWrap(uptimeHandler)),
+		"health":    metricsWrap("Health", logWrap(healthHandler)),
+		"liveness":  metricsWrap("Liveness", logWrap(&handlers.Liveness{})),
+		"readiness": metricsWrap("Readiness", logWrap(readinessHandler)),
 	}
 
 	healthCheckServer := common.InitServer(logger, nil, conf.ListenHost,
-		conf.HealthCheckPort, healthHandlers, healthRoutes)
+		conf.HealthCheckPort, healthHandlers, healthRoutes, serverOptions)
 
+	// Members stop in reverse order, so the health check server keeps
+	// reporting the internal server as draining until it has finished.
 	members := grouper.Members{
 		{"metrics-emitter", metricsEmitter},
+		{"health-check-server", healthCheckServer},
 		{"internal-http-server", internalServer},
 		{"debug-server", debugServer},
-		{"health-check-server", healthCheckServer},
+		{"replica-lag-poller", initReplicaPoller(logger, conf, readConnection)},
+		{"tls-reload-poller", initTLSReloadPoller(logger, conf, serverTLSConfig)},
 	}
 
 	logger.Info("starting internal server", lager.Data{"listen-address": conf.ListenHost, "port": conf.InternalListenPort})
@@ -186,6 +261,9 @@ func main() {
 	if connectionPool != nil {
 		connectionPool.Close()
 	}
+	for _, replicaPool := range replicaPools {
+		replicaPool.Close()
+	}
 	if err != nil {
 		logger.Error("exited-with-failure", err)
 		os.Exit(1)
@@ -193,3 +271,71 @@ func main() {
 
 	logger.Info("exited")
 }
+
+func initTLSReloadPoller(logger lager.Logger, conf *config.InternalConfig, serverTLSConfig *tlsreload.ServerConfig) ifrit.Runner {
+	pollInterval := time.Duration(conf.TLSReloadIntervalSeconds) * time.Second
+	if pollInterval == 0 {
+		pollInterval = time.Minute
+	}
+
+	return &poller.Poller{
+		Logger:          logger.Session("tls-reload-poller"),
+		PollInterval:    pollInterval,
+		SingleCycleFunc: serverTLSConfig.ReloadIfChanged,
+	}
+}
+
+func initAppOrgResolver(logger lager.Logger, conf *config.InternalConfig) *handlers.AppOrgResolver {
+	var tlsConfig *tls.Config
+	if conf.SkipSSLValidation {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: conf.SkipSSLValidation,
+		}
+	} else {
+		var err error
+		tlsConfig, err = nonmutualtls.NewClientTLSConfig(conf.UAACA, conf.CCCA)
+		if err != nil {
+			log.Fatalf("%s.%s error creating tls config: %s", logPrefix, jobPrefix, err) // not tested
+		}
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	cacheTTL := time.Duration(conf.AppOrgCacheSeconds) * time.Second
+	if cacheTTL == 0 {
+		cacheTTL = 5 * time.Minute
+	}
+
+	return &handlers.AppOrgResolver{
+		UAAClient: &uaa_client.Client{
+			BaseURL:    fmt.Sprintf("%s:%d", conf.UAAURL, conf.UAAPort),
+			Name:       conf.UAAClient,
+			Secret:     conf.UAAClientSecret,
+			HTTPClient: httpClient,
+			Logger:     logger,
+		},
+		CCClient: &cc_client.Client{
+			JSONClient: json_client.New(logger.Session("cc-json-client"), httpClient, conf.CCURL),
+			Logger:     logger,
+		},
+		Clock:     clock.NewClock(),
+		CacheTTL:  cacheTTL,
+		ChunkSize: conf.CCAppRequestChunkSize,
+	}
+}
+
+func initReplicaPoller(logger lager.Logger, conf *config.InternalConfig, router *db.ReplicaRouter) ifrit.Runner {
+	pollInterval := time.Duration(conf.DatabaseReplicaCheckIntervalSeconds) * time.Second
+	if pollInterval == 0 {
+		pollInterval = 10 * time.Second
+	}
+
+	return &poller.Poller{
+		Logger:          logger.Session("replica-lag-poller"),
+		PollInterval:    pollInterval,
+		SingleCycleFunc: router.CheckReplicas,
+	}
+}
@@ -8,20 +8,27 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"lib/common"
+	"lib/httpserver"
 	"lib/nonmutualtls"
 	"lib/poller"
 
 	"policy-server/adapter"
 	"policy-server/api"
 	"policy-server/api/api_v0"
+	"policy-server/asg"
 	"policy-server/cc_client"
 	"policy-server/cleaner"
 	"policy-server/config"
 	"policy-server/handlers"
+	"policy-server/health"
 	psmiddleware "policy-server/middleware"
+	"policy-server/openapi"
+	"policy-server/server_metrics"
 	"policy-server/store"
 	"policy-server/uaa_client"
 
@@ -33,6 +40,7 @@ import (
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
 	"code.cloudfoundry.org/cf-networking-helpers/middleware"
 	middlewareAdapter "code.cloudfoundry.org/cf-networking-helpers/middleware/adapter"
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/debugserver"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerflags"
@@ -67,6 +75,12 @@ func main() {
 
 	logger, reconfigurableSink := lagerflags.NewFromConfig(fmt.Sprintf("%s.%s", logPrefix, jobPrefix), common.GetLagerConfig())
 
+	logLevel, err := conf.LagerLogLevel()
+	if err != nil {
+		log.Fatalf("%s.%s: %s", logPrefix, jobPrefix, err)
+	}
+	reconfigurableSink.SetMinLevel(logLevel)
+
 	var tlsConfig *tls.Config
 	if conf.SkipSSLValidation {
 		tlsConfig = &tls.Config{
@@ -116,20 +130,39 @@ func main() {
 	)
 	logger.Info("db connection retrieved", lager.Data{})
 
+	metricsSender := &metrics.MetricsSender{
+		Logger: logger.Session("time-metric-emitter"),
+	}
+
+	readConnection, replicaPools := common.InitReplicaRouter(
+		logger,
+		connectionPool,
+		conf.DatabaseReplicas,
+		conf.MaxOpenConnections,
+		conf.MaxIdleConnections,
+		5*time.Minute,
+		time.Duration(conf.DatabaseReplicaMaxLagSeconds)*time.Second,
+		metricsSender,
+		logPrefix,
+		jobPrefix,
+	)
+
 	terminalsTable := &store.TerminalsTable{
 		Guids: &store.GuidGenerator{},
 	}
+	egressPolicyTable := &store.EgressPolicyTable{
+		Conn:  connectionPool,
+		Guids: &store.GuidGenerator{},
+	}
 	egressPolicyStore := &store.EgressPolicyStore{
-		EgressPolicyRepo: &store.EgressPolicyTable{
-			Conn:  connectionPool,
-			Guids: &store.GuidGenerator{},
-		},
-		TerminalsRepo: terminalsTable,
-		Conn:          connectionPool,
+		EgressPolicyRepo: egressPolicyTable,
+		TerminalsRepo:    terminalsTable,
+		Conn:             connectionPool,
 	}
 
-	c2cPolicyStore := store.New(
+	c2cPolicyStore := store.NewWithReader(
 		connectionPool,
+		readConnection,
 		storeGroup,
 		destination,
 		policy,
@@ -140,11 +173,7 @@ func main() {
 		log.Fatalf("%s.%s: failed to construct datastore: %s", logPrefix, jobPrefix, err) // not tested
 	}
 
-	tagDataStore := store.NewTagStore(connectionPool, &store.GroupTable{}, conf.TagLength)
-
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
-	}
+	tagDataStore := store.NewTagStoreWithReader(connectionPool, readConnection, &store.GroupTable{}, conf.TagLength)
 
 	wrappedStore := &store.MetricsWrapper{
 		Store:         c2cPolicyStore,
@@ -188,9 +217,16 @@ func main() {
 
 	egressDestinationStore := &store.EgressDestinationStore{
 		Conn:                    connectionPool,
+		ReadConn:                readConnection,
 		EgressDestinationRepo:   &store.EgressDestinationTable{},
 		TerminalsRepo:           terminalsTable,
 		DestinationMetadataRepo: &store.DestinationMetadataTable{},
+		TerminalUsageRepo:       egressPolicyTable,
+	}
+
+	egressDestinationValidator, err := api.NewEgressDestinationValidator(conf.ForbiddenEgressCIDRs)
+	if err != nil {
+		log.Fatalf("%s.%s: %s", logPrefix, jobPrefix, err) // not tested
 	}
 
 	destinationsIndexHandlerV1 := &handlers.DestinationsIndex{
@@ -201,34 +237,153 @@ func main() {
 	}
 
 	createDestinationsHandlerV1 := &handlers.DestinationsCreate{
+		ErrorResponse:              errorResponse,
+		EgressDestinationStore:     egressDestinationStore,
+		EgressDestinationLister:    egressDestinationStore,
+		EgressDestinationMapper:    egressDestinationMapper,
+		EgressDestinationValidator: egressDestinationValidator,
+		PolicyGuard:                policyGuard,
+		Logger:                     logger,
+	}
+
+	destinationsOverlapsHandlerV1 := &handlers.DestinationsOverlaps{
+		ErrorResponse:              errorResponse,
+		EgressDestinationStore:     egressDestinationStore,
+		EgressDestinationValidator: egressDestinationValidator,
+		PolicyGuard:                policyGuard,
+		Logger:                     logger,
+	}
+
+	destinationsShowHandlerV1 := &handlers.DestinationsShow{
+		ErrorResponse:           errorResponse,
+		EgressDestinationStore:  egressDestinationStore,
+		EgressDestinationMapper: egressDestinationMapper,
+		RataAdapter:             adapter.RataAdapter{},
+		Logger:                  logger,
+	}
+
+	deleteDestinationsHandlerV1 := &handlers.DestinationsDelete{
 		ErrorResponse:           errorResponse,
 		EgressDestinationStore:  egressDestinationStore,
 		EgressDestinationMapper: egressDestinationMapper,
 		PolicyGuard:             policyGuard,
+		RataAdapter:             adapter.RataAdapter{},
 		Logger:                  logger,
 	}
 
 	egressPolicyMapper := &api.EgressPolicyMapper{
 		Unmarshaler: marshal.UnmarshalFunc(json.Unmarshal),
-		Marshaler: marshal.MarshalFunc(json.Marshal),
+		Marshaler:   marshal.MarshalFunc(json.Marshal),
 	}
 
 	createEgressPolicyHandlerV1 := &handlers.EgressPolicyCreate{
-		Store:         egressPolicyStore,
-		Mapper:        egressPolicyMapper,
+		Store:             egressPolicyStore,
+		Mapper:            egressPolicyMapper,
+		DestinationLister: egressDestinationStore,
+		PolicyGuard:       policyGuard,
+		ErrorResponse:     errorResponse,
+		Logger:            logger,
+	}
+
+	batchMapper := &api.BatchMapper{
+		Marshaler: marshal.MarshalFunc(json.Marshal),
+	}
+
+	destinationsBatchCreateHandler := &handlers.DestinationsBatchCreate{
+		ErrorResponse:              errorResponse,
+		EgressDestinationStore:     egressDestinationStore,
+		EgressDestinationLister:    egressDestinationStore,
+		EgressDestinationValidator: egressDestinationValidator,
+		Mapper:                     batchMapper,
+		PolicyGuard:                policyGuard,
+		MaxBatchSize:               conf.MaxBatchSize,
+	}
+
+	destinationsBatchDeleteHandler := &handlers.DestinationsBatchDelete{
+		ErrorResponse:          errorResponse,
+		EgressDestinationStore: egressDestinationStore,
+		Mapper:                 batchMapper,
+		PolicyGuard:            policyGuard,
+		MaxBatchSize:           conf.MaxBatchSize,
+	}
+
+	egressPoliciesBatchCreateHandler := &handlers.EgressPoliciesBatchCreate{
+		ErrorResponse:     errorResponse,
+		Store:             egressPolicyStore,
+		DestinationLister: egressDestinationStore,
+		Mapper:            batchMapper,
+		PolicyGuard:       policyGuard,
+		MaxBatchSize:      conf.MaxBatchSize,
+	}
+
+	egressPoliciesBatchDeleteHandler := &handlers.EgressPoliciesBatchDelete{
 		ErrorResponse: errorResponse,
-		Logger:        logger,
+		Store:         egressPolicyStore,
+		Mapper:        batchMapper,
+		PolicyGuard:   policyGuard,
+		MaxBatchSize:  conf.MaxBatchSize,
 	}
 
 	policyCleaner := cleaner.NewPolicyCleaner(logger.Session("policy-cleaner"), wrappedStore, egressPolicyStore, uaaClient,
 		ccClient, 100, time.Duration(5)*time.Second)
 
+	asgImportHandler := &handlers.ASGImport{
+		Importer: &asg.Importer{
+			Logger:                     logger.Session("asg-importer"),
+			UAAClient:                  uaaClient,
+			CCClient:                   ccClient,
+			EgressDestinationStore:     egressDestinationStore,
+			EgressPolicyStore:          egressPolicyStore,
+			EgressDestinationValidator: egressDestinationValidator,
+		},
+		PolicyGuard:   policyGuard,
+		ErrorResponse: errorResponse,
+	}
+
+	effectiveEgressHandler := &handlers.EffectiveEgress{
+		ErrorResponse: errorResponse,
+		EgressStore:   egressPolicyStore,
+		Mapper:        &api.EffectiveEgressMapper{},
+		CCClient:      ccClient,
+		UAAClient:     uaaClient,
+		PolicyGuard:   policyGuard,
+		RataAdapter:   adapter.RataAdapter{},
+	}
+
 	policyCollectionWriter := api.NewPolicyCollectionWriter(marshal.MarshalFunc(json.Marshal))
 	policiesCleanupHandler := handlers.NewPoliciesCleanup(policyCollectionWriter, policyCleaner, errorResponse)
 
 	tagsIndexHandler := handlers.NewTagsIndex(wrappedStore, marshal.MarshalFunc(json.Marshal), errorResponse)
 
-	healthHandler := handlers.NewHealth(wrappedStore, errorResponse)
+	tagReclaimer := &cleaner.TagReclaimer{
+		Logger:                 logger.Session("tag-reclaimer"),
+		Store:                  tagDataStore,
+		UAAClient:              uaaClient,
+		CCClient:               ccClient,
+		MetricsSender:          metricsSender,
+		CCAppRequestChunkSize:  100,
+		Quarantine:             time.Duration(conf.TagReclaimQuarantineSeconds) * time.Second,
+		ReclaimEnabled:         conf.EnableTagReclamation,
+		UtilisationWarnPercent: conf.TagUtilisationWarningPercent,
+		Clock:                  clock.NewClock(),
+	}
+
+	requestTimeout := time.Duration(conf.RequestTimeout) * time.Second
+	healthChecker := &health.Checker{
+		Checks: []health.Check{
+			health.NewDatabaseCheck(wrappedStore, health.DefaultCheckTimeout),
+			health.NewMigrationsCheck(store.NewMigrator(connectionPool), connectionPool.DriverName(), connectionPool, health.DefaultCheckTimeout),
+			health.NewUAACheck(uaaClient, requestTimeout),
+			health.NewCCCheck(ccClient, requestTimeout),
+			health.NewCleanerCheck(policyCleaner, health.DefaultCheckTimeout),
+			health.NewTagUtilisationCheck(tagDataStore, health.DefaultCheckTimeout),
+		},
+	}
+	drainState := &httpserver.DrainState{}
+	healthHandler := handlers.NewHealth(wrappedStore, tagDataStore, healthChecker, errorResponse)
+	healthHandler.DrainState = drainState
+	readinessHandler := handlers.NewReadiness(healthChecker, errorResponse)
+	readinessHandler.DrainState = drainState
 
 	checkVersionWrapper := &handlers.CheckVersionWrapper{
 		ErrorResponse: errorResponse,
@@ -268,74 +423,165 @@ func main() {
 		return networkAdminAuthenticator.Wrap(handler)
 	}
 
+	networkWriteAuthenticator := &handlers.Authenticator{
+		Client:        uaaClient,
+		Scopes:        []string{"network.admin", "network.write"},
+		ErrorResponse: errorResponse,
+		ScopeChecking: !conf.EnableSpaceDeveloperSelfService,
+	}
 	authWriteWrap := func(handler http.Handler) http.Handler {
-		networkWriteAuthenticator := handlers.Authenticator{
-			Client:        uaaClient,
-			Scopes:        []string{"network.admin", "network.write"},
-			ErrorResponse: errorResponse,
-			ScopeChecking: !conf.EnableSpaceDeveloperSelfService,
-		}
 		return networkWriteAuthenticator.Wrap(handler)
 	}
 
+	// With egress self-service on, anyone may list destinations and create
+	// egress policies, and the handler checks that non-admins only attach
+	// existing destinations to apps in their spaces.
+	egressSelfServiceAuthenticator := &handlers.Authenticator{
+		Client:        uaaClient,
+		Scopes:        []string{"network.admin"},
+		ErrorResponse: errorResponse,
+		ScopeChecking: !conf.EnableEgressSelfService,
+	}
+	authEgressSelfServiceWrap := func(handler http.Handler) http.Handler {
+		return egressSelfServiceAuthenticator.Wrap(handler)
+	}
+
+	rateLimiter := &handlers.RateLimiter{
+		Clock:                          clock.NewClock(),
+		PerUser:                        handlers.RateLimit(conf.RateLimits.PerUser),
+		PerClient:                      handlers.RateLimit(conf.RateLimits.PerClient),
+		PerRoute:                       map[string]handlers.RateLimit{},
+		MaxConcurrentRequestsPerClient: conf.RateLimits.MaxConcurrentRequestsPerClient,
+		MetricsSender:                  metricsSender,
+	}
+	for routeName, limit := range conf.RateLimits.PerRoute {
+		rateLimiter.PerRoute[routeName] = handlers.RateLimit(limit)
+	}
 	externalRoutes := rata.Routes{
 		{Name: "uptime", Method: "GET", Path: "/"},
 		{Name: "uptime", Method: "GET", Path: "/networking"},
 		{Name: "health", Method: "GET", Path: "/health"},
+		{Name: "liveness", Method: "GET", Path: "/health/liveness"},
+		{Name: "readiness", Method: "GET", Path: "/health/readiness"},
+		{Name: "openapi", Method: "GET", Path: "/networking/:version/openapi.json"},
 		{Name: "whoami", Method: "GET", Path: "/networking/:version/external/whoami"},
 		{Name: "create_policies", Method: "POST", Path: "/networking/:version/external/policies"},
 		{Name: "delete_policies", Method: "POST", Path: "/networking/:version/external/policies/delete"},
 		{Name: "policies_index", Method: "GET", Path: "/networking/:version/external/policies"},
 		{Name: "destinations_index", Method: "GET", Path: "/networking/:version/external/destinations"},
 		{Name: "destinations_create", Method: "POST", Path: "/networking/:version/external/destinations"},
+		{Name: "destinations_overlaps", Method: "GET", Path: "/networking/:version/external/destinations/overlaps"},
+		{Name: "destinations_show", Method: "GET", Path: "/networking/:version/external/destinations/:id"},
+		{Name: "destinations_delete", Method: "DELETE", Path: "/networking/:version/external/destinations/:id"},
 		{Name: "create_egress_policies", Method: "POST", Path: "/networking/:version/external/egress_policies"},
+		{Name: "destinations_batch_create", Method: "POST", Path: "/networking/:version/external/destinations/batch"},
+		{Name: "destinations_batch_delete", Method: "POST", Path: "/networking/:version/external/destinations/batch/delete"},
+		{Name: "egress_policies_batch_create", Method: "POST", Path: "/networking/:version/external/egress_policies/batch"},
+		{Name: "egress_policies_batch_delete", Method: "POST", Path: "/networking/:version/external/egress_policies/batch/delete"},
 		{Name: "cleanup", Method: "POST", Path: "/networking/:version/external/policies/cleanup"},
+		{Name: "asg_import", Method: "POST", Path: "/networking/:version/external/asg_import"},
+		{Name: "effective_egress", Method: "GET", Path: "/networking/:version/external/apps/:guid/effective_egress"},
 		{Name: "tags_index", Method: "GET", Path: "/networking/:version/external/tags"},
 	}
 
+	openAPIDocuments, err := openapi.NewDocuments(externalRoutes, "v0", "v1")
+	if err != nil {
+		log.Fatalf("%s.%s: building openapi documents: %s", logPrefix, jobPrefix, err) // not tested
+	}
+	openAPIDocumentBytes, err := openAPIDocuments.Marshal()
+	if err != nil {
+		log.Fatalf("%s.%s: marshalling openapi documents: %s", logPrefix, jobPrefix, err) // not tested
+	}
+	requestValidator := &handlers.RequestValidator{
+		Schemas:       openAPIDocuments,
+		RataAdapter:   adapter.RataAdapter{},
+		ErrorResponse: errorResponse,
+	}
+	limitWrap := func(routeName string, handler http.Handler) http.Handler {
+		return rateLimiter.Wrap(routeName, handler)
+	}
+	routeWrap := func(routeName string, handler http.Handler) http.Handler {
+		return rateLimiter.WrapAuthenticated(routeName, requestValidator.Wrap(routeName, handler))
+	}
+
 	corsMiddleware := psmiddleware.CORS{}
 	externalRoutesWithOptions := corsMiddleware.AddOptionsRoutes("options", externalRoutes)
 
+	corsWrapper := &handlers.CORSOptionsWrapper{
+		RataRoutes:         externalRoutesWithOptions,
+		AllowedCORSDomains: conf.AllowedCORSDomains,
+	}
 	corsOptionsWrapper := func(handler http.Handler) http.Handler {
-		wrapper := handlers.CORSOptionsWrapper{
-			RataRoutes:         externalRoutesWithOptions,
-			AllowedCORSDomains: conf.AllowedCORSDomains,
-		}
-		return wrapper.Wrap(handler)
+		return corsWrapper.Wrap(handler)
 	}
 
 	externalHandlers := rata.Handlers{
 		"options": corsOptionsWrapper(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		})),
-		"uptime": corsOptionsWrapper(metricsWrap("Uptime", logWrap(uptimeHandler))),
-		"health": corsOptionsWrapper(metricsWrap("Health", logWrap(healthHandler))),
+		"uptime":    corsOptionsWrapper(metricsWrap("Uptime", logWrap(uptimeHandler))),
+		"health":    corsOptionsWrapper(metricsWrap("Health", logWrap(healthHandler))),
+		"liveness":  corsOptionsWrapper(metricsWrap("Liveness", logWrap(&handlers.Liveness{}))),
+		"readiness": corsOptionsWrapper(metricsWrap("Readiness", logWrap(readinessHandler))),
 
 		"create_policies": corsOptionsWrapper(metricsWrap("CreatePolicies",
-			logWrap(versionWrap(authWriteWrap(createPolicyHandlerV1), authWriteWrap(createPolicyHandlerV0))))),
+			logWrap(limitWrap("create_policies", versionWrap(authWriteWrap(routeWrap("create_policies", createPolicyHandlerV1)), authWriteWrap(routeWrap("create_policies", createPolicyHandlerV0))))))),
 
 		"delete_policies": corsOptionsWrapper(metricsWrap("DeletePolicies",
-			logWrap(versionWrap(authWriteWrap(deletePolicyHandlerV1), authWriteWrap(deletePolicyHandlerV0))))),
+			logWrap(limitWrap("delete_policies", versionWrap(authWriteWrap(routeWrap("delete_policies", deletePolicyHandlerV1)), authWriteWrap(routeWrap("delete_policies", deletePolicyHandlerV0))))))),
 
 		"policies_index": corsOptionsWrapper(metricsWrap("PoliciesIndex",
-			logWrap(versionWrap(authWriteWrap(policiesIndexHandlerV1), authWriteWrap(policiesIndexHandlerV0))))),
+			logWrap(limitWrap("policies_index", versionWrap(authWriteWrap(routeWrap("policies_index", policiesIndexHandlerV1)), authWriteWrap(routeWrap("policies_index", policiesIndexHandlerV0))))))),
 
 		"destinations_index": corsOptionsWrapper(metricsWrap("DestinationsIndex",
-			logWrap(versionWrap(authAdminWrap(destinationsIndexHandlerV1), authAdminWrap(destinationsIndexHandlerV1))))),
+			logWrap(limitWrap("destinations_index", versionWrap(authEgressSelfServiceWrap(routeWrap("destinations_index", destinationsIndexHandlerV1)), authEgressSelfServiceWrap(routeWrap("destinations_index", destinationsIndexHandlerV1))))))),
 
 		"destinations_create": corsOptionsWrapper(metricsWrap("DestinationsCreate",
-			logWrap(authAdminWrap(createDestinationsHandlerV1)))),
+			logWrap(limitWrap("destinations_create", authAdminWrap(routeWrap("destinations_create", createDestinationsHandlerV1)))))),
+
+		"destinations_overlaps": corsOptionsWrapper(metricsWrap("DestinationsOverlaps",
+			logWrap(limitWrap("destinations_overlaps", authAdminWrap(routeWrap("destinations_overlaps", destinationsOverlapsHandlerV1)))))),
+
+		"destinations_show": corsOptionsWrapper(metricsWrap("DestinationsShow",
+			logWrap(limitWrap("destinations_show", authEgressSelfServiceWrap(routeWrap("destinations_show", destinationsShowHandlerV1)))))),
+
+		"destinations_delete": corsOptionsWrapper(metricsWrap("DestinationsDelete",
+			logWrap(limitWrap("destinations_delete", authAdminWrap(routeWrap("destinations_delete", deleteDestinationsHandlerV1)))))),
 
 		"create_egress_policies": corsOptionsWrapper(metricsWrap("EgressPoliciesCreate",
-			logWrap(authAdminWrap(createEgressPolicyHandlerV1)))),
+			logWrap(limitWrap("create_egress_policies", authEgressSelfServiceWrap(routeWrap("create_egress_policies", createEgressPolicyHandlerV1)))))),
+
+		"destinations_batch_create": corsOptionsWrapper(metricsWrap("DestinationsBatchCreate",
+			logWrap(limitWrap("destinations_batch_create", authAdminWrap(routeWrap("destinations_batch_create", destinationsBatchCreateHandler)))))),
+
+		"destinations_batch_delete": corsOptionsWrapper(metricsWrap("DestinationsBatchDelete",
+			logWrap(limitWrap("destinations_batch_delete", authAdminWrap(routeWrap("destinations_batch_delete", destinationsBatchDeleteHandler)))))),
+
+		"egress_policies_batch_create": corsOptionsWrapper(metricsWrap("EgressPoliciesBatchCreate",
+			logWrap(limitWrap("egress_policies_batch_create", authAdminWrap(routeWrap("egress_policies_batch_create", egressPoliciesBatchCreateHandler)))))),
+
+		"egress_policies_batch_delete": corsOptionsWrapper(metricsWrap("EgressPoliciesBatchDelete",
+			logWrap(limitWrap("egress_policies_batch_delete", authAdminWrap(routeWrap("egress_policies_batch_delete", egressPoliciesBatchDeleteHandler)))))),
 
 		"cleanup": corsOptionsWrapper(metricsWrap("Cleanup",
-			logWrap(versionWrap(authAdminWrap(policiesCleanupHandler), authAdminWrap(policiesCleanupHandler))))),
+			logWrap(limitWrap("cleanup", versionWrap(authAdminWrap(routeWrap("cleanup", policiesCleanupHandler)), authAdminWrap(routeWrap("cleanup", policiesCleanupHandler))))))),
+
+		"asg_import": corsOptionsWrapper(metricsWrap("ASGImport",
+			logWrap(limitWrap("asg_import", authAdminWrap(routeWrap("asg_import", asgImportHandler)))))),
+
+		"effective_egress": corsOptionsWrapper(metricsWrap("EffectiveEgress",
+			logWrap(limitWrap("effective_egress", authAdminWrap(routeWrap("effective_egress", effectiveEgressHandler)))))),
 
 		"tags_index": corsOptionsWrapper(metricsWrap("TagsIndex",
-			logWrap(versionWrap(authAdminWrap(tagsIndexHandler), authAdminWrap(tagsIndexHandler))))),
+			logWrap(limitWrap("tags_index", versionWrap(authAdminWrap(routeWrap("tags_index", tagsIndexHandler)), authAdminWrap(routeWrap("tags_index", tagsIndexHandler))))))),
+
+		"openapi": corsOptionsWrapper(metricsWrap("OpenAPI", logWrap(&handlers.OpenAPIHandler{
+			Documents:     openAPIDocumentBytes,
+			RataAdapter:   adapter.RataAdapter{},
+			ErrorResponse: errorResponse,
+		}))),
 
 		"whoami": corsOptionsWrapper(metricsWrap("WhoAmI",
-			logWrap(versionWrap(authAdminWrap(whoamiHandler), authAdminWrap(whoamiHandler))))),
+			logWrap(limitWrap("whoami", versionWrap(authAdminWrap(routeWrap("whoami", whoamiHandler)), authAdminWrap(routeWrap("whoami", whoamiHandler))))))),
 	}
 
 	err = dropsonde.Initialize(conf.MetronAddress, dropsondeOrigin)
@@ -343,16 +589,51 @@ func main() {
 		log.Fatalf("%s.%s: initializing dropsonde: %s", logPrefix, jobPrefix, err)
 	}
 
-	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore)
-	externalServer := common.InitServer(logger, nil, conf.ListenHost, conf.ListenPort, externalHandlers, externalRoutesWithOptions)
+	metricSources := append(readConnection.LagSources(), server_metrics.NewTagUtilisationSources(tagDataStore)...)
+	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore, metricSources...)
+	serverOptions := httpserver.Options{
+		ReadTimeout:  time.Duration(conf.ServerReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(conf.ServerWriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(conf.ServerIdleTimeoutSeconds) * time.Second,
+		DrainTimeout: time.Duration(conf.DrainTimeoutSeconds) * time.Second,
+		DrainDelay:   time.Duration(conf.DrainDelaySeconds) * time.Second,
+		DrainState:   drainState,
+	}
+	externalServer := common.InitServer(logger, nil, conf.ListenHost, conf.ListenPort, externalHandlers, externalRoutesWithOptions, serverOptions)
 	poller := initPoller(logger, conf, policyCleaner)
+	tagReclaimerPoller := initTagReclaimerPoller(logger, conf, tagReclaimer)
 	debugServer := debugserver.Runner(fmt.Sprintf("%s:%d", conf.DebugServerHost, conf.DebugServerPort), reconfigurableSink)
 
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+	configReloader := &config.Reloader{
+		Logger: logger.Session("config-reloader"),
+		Path:   *configFilePath,
+		Config: conf,
+		Reload: reloadSignals,
+		Apply: func(reloaded *config.Config) {
+			quotaGuard.SetMaxPolicies(reloaded.MaxPolicies)
+			corsWrapper.SetAllowedCORSDomains(reloaded.AllowedCORSDomains)
+			networkWriteAuthenticator.SetScopeChecking(!reloaded.EnableSpaceDeveloperSelfService)
+			egressSelfServiceAuthenticator.SetScopeChecking(!reloaded.EnableEgressSelfService)
+
+			cleanupInterval := time.Duration(reloaded.CleanupInterval) * time.Second
+			poller.SetPollInterval(cleanupInterval)
+			tagReclaimerPoller.SetPollInterval(cleanupInterval)
+
+			logLevel, _ := reloaded.LagerLogLevel()
+			reconfigurableSink.SetMinLevel(logLevel)
+		},
+	}
+
 	members := grouper.Members{
 		{"metrics_emitter", metricsEmitter},
 		{"http_server", externalServer},
 		{"policy-cleaner-poller", poller},
+		{"tag-reclaimer-poller", tagReclaimerPoller},
 		{"debug-server", debugServer},
+		{"replica-lag-poller", initReplicaPoller(logger, conf, readConnection)},
+		{"config-reloader", configReloader},
 	}
 
 	logger.Info("starting external server", lager.Data{"listen-address": conf.ListenHost, "port": conf.ListenPort})
@@ -364,6 +645,9 @@ func main() {
 	if connectionPool != nil {
 		connectionPool.Close()
 	}
+	for _, replicaPool := range replicaPools {
+		replicaPool.Close()
+	}
 	if err != nil {
 		logger.Error("exited-with-failure", err)
 		os.Exit(1)
@@ -372,7 +656,7 @@ func main() {
 	logger.Info("exited")
 }
 
-func initPoller(logger lager.Logger, conf *config.Config, policyCleaner *cleaner.PolicyCleaner) ifrit.Runner {
+func initPoller(logger lager.Logger, conf *config.Config, policyCleaner *cleaner.PolicyCleaner) *poller.Poller {
 	pollInterval := time.Duration(conf.CleanupInterval) * time.Second
 
 	return &poller.Poller{
@@ -381,3 +665,26 @@ func initPoller(logger lager.Logger, conf *config.Config, policyCleaner *cleaner
 		SingleCycleFunc: policyCleaner.DeleteStalePoliciesWrapper,
 	}
 }
+
+func initTagReclaimerPoller(logger lager.Logger, conf *config.Config, tagReclaimer *cleaner.TagReclaimer) *poller.Poller {
+	pollInterval := time.Duration(conf.CleanupInterval) * time.Second
+
+	return &poller.Poller{
+		Logger:          logger.Session("tag-reclaimer-poller"),
+		PollInterval:    pollInterval,
+		SingleCycleFunc: tagReclaimer.ReclaimTagsWrapper,
+	}
+}
+
+func initReplicaPoller(logger lager.Logger, conf *config.Config, router *db.ReplicaRouter) ifrit.Runner {
+	pollInterval := time.Duration(conf.DatabaseReplicaCheckIntervalSeconds) * time.Second
+	if pollInterval == 0 {
+		pollInterval = 10 * time.Second
+	}
+
+	return &poller.Poller{
+		Logger:          logger.Session("replica-lag-poller"),
+		PollInterval:    pollInterval,
+		SingleCycleFunc: router.CheckReplicas,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 
 	validator "gopkg.in/validator.v2"
 
@@ -11,37 +12,74 @@ import (
 )
 
 type Config struct {
-	ListenHost                      string    `json:"listen_host" validate:"nonzero"`
-	ListenPort                      int       `json:"listen_port" validate:"nonzero"`
-	LogPrefix                       string    `json:"log_prefix" validate:"nonzero"`
-	DebugServerHost                 string    `json:"debug_server_host" validate:"nonzero"`
-	DebugServerPort                 int       `json:"debug_server_port" validate:"nonzero"`
-	UAAClient                       string    `json:"uaa_client" validate:"nonzero"`
-	UAAClientSecret                 string    `json:"uaa_client_secret" validate:"nonzero"`
-	UAACA                           string    `json:"uaa_ca"`
-	UAAURL                          string    `json:"uaa_url" validate:"nonzero"`
-	UAAPort                         int       `json:"uaa_port" validate:"nonzero"`
-	CCURL                           string    `json:"cc_url" validate:"nonzero"`
-	CCCA                            string    `json:"cc_ca_cert" validate:"nonzero"`
-	SkipSSLValidation               bool      `json:"skip_ssl_validation"`
-	Database                        db.Config `json:"database" validate:"nonzero"`
-	DatabaseMigrationTimeout        int       `json:"database_migration_timeout" validate:"min=1"`
-	TagLength                       int       `json:"tag_length" validate:"nonzero"`
-	MetronAddress                   string    `json:"metron_address" validate:"nonzero"`
-	LogLevel                        string    `json:"log_level"`
-	CleanupInterval                 int       `json:"cleanup_interval" validate:"min=1"`
-	CCAppRequestChunkSize           int       `json:"cc_app_request_chunk_size"`
-	RequestTimeout                  int       `json:"request_timeout" validate:"min=1"`
-	MaxPolicies                     int       `json:"max_policies" validate:"min=1"`
-	EnableSpaceDeveloperSelfService bool      `json:"enable_space_developer_self_service"`
-	AllowedCORSDomains              []string  `json:"allowed_cors_domains"`
-	MaxIdleConnections              int       `json:"max_idle_connections" validate:"min=0"`
-	MaxOpenConnections              int       `json:"max_open_connections" validate:"min=0"`
-	MaxConnectionsLifetimeSeconds   int       `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	ListenHost                          string      `json:"listen_host" validate:"nonzero"`
+	ListenPort                          int         `json:"listen_port" validate:"nonzero"`
+	LogPrefix                           string      `json:"log_prefix" validate:"nonzero"`
+	DebugServerHost                     string      `json:"debug_server_host" validate:"nonzero"`
+	DebugServerPort                     int         `json:"debug_server_port" validate:"nonzero"`
+	UAAClient                           string      `json:"uaa_client" validate:"nonzero"`
+	UAAClientSecret                     string      `json:"uaa_client_secret" validate:"nonzero"`
+	UAACA                               string      `json:"uaa_ca"`
+	UAAURL                              string      `json:"uaa_url" validate:"nonzero"`
+	UAAPort                             int         `json:"uaa_port" validate:"nonzero"`
+	CCURL                               string      `json:"cc_url" validate:"nonzero"`
+	CCCA                                string      `json:"cc_ca_cert" validate:"nonzero"`
+	SkipSSLValidation                   bool        `json:"skip_ssl_validation"`
+	Database                            db.Config   `json:"database" validate:"nonzero"`
+	DatabaseReplicas                    []db.Config `json:"database_replicas"`
+	DatabaseReplicaMaxLagSeconds        int         `json:"database_replica_max_lag_seconds" validate:"min=0"`
+	DatabaseReplicaCheckIntervalSeconds int         `json:"database_replica_check_interval_seconds" validate:"min=0"`
+	DatabaseMigrationTimeout            int         `json:"database_migration_timeout" validate:"min=1"`
+	TagLength                           int         `json:"tag_length" validate:"nonzero"`
+	MetronAddress                       string      `json:"metron_address" validate:"nonzero"`
+	LogLevel                            string      `json:"log_level"`
+	CleanupInterval                     int         `json:"cleanup_interval" validate:"min=1"`
+	EnableTagReclamation                bool        `json:"enable_tag_reclamation"`
+	TagReclaimQuarantineSeconds         int         `json:"tag_reclaim_quarantine_seconds" validate:"min=0"`
+	TagUtilisationWarningPercent        int         `json:"tag_utilisation_warning_percent" validate:"min=0,max=100"`
+	CCAppRequestChunkSize               int         `json:"cc_app_request_chunk_size"`
+	RequestTimeout                      int         `json:"request_timeout" validate:"min=1"`
+	MaxPolicies                         int         `json:"max_policies" validate:"min=1"`
+	EnableSpaceDeveloperSelfService     bool        `json:"enable_space_developer_self_service"`
+	EnableEgressSelfService             bool        `json:"enable_egress_self_service"`
+	AllowedCORSDomains                  []string    `json:"allowed_cors_domains"`
+	MaxIdleConnections                  int         `json:"max_idle_connections" validate:"min=0"`
+	MaxOpenConnections                  int         `json:"max_open_connections" validate:"min=0"`
+	MaxConnectionsLifetimeSeconds       int         `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	RateLimits                          RateLimits  `json:"rate_limits"`
+	ServerReadTimeoutSeconds            int         `json:"server_read_timeout_seconds" validate:"min=0"`
+	ServerWriteTimeoutSeconds           int         `json:"server_write_timeout_seconds" validate:"min=0"`
+	ServerIdleTimeoutSeconds            int         `json:"server_idle_timeout_seconds" validate:"min=0"`
+	DrainTimeoutSeconds                 int         `json:"drain_timeout_seconds" validate:"min=0"`
+	DrainDelaySeconds                   int         `json:"drain_delay_seconds" validate:"min=0"`
+	ForbiddenEgressCIDRs                []string    `json:"forbidden_egress_cidrs"`
+	MaxBatchSize                        int         `json:"max_batch_size" validate:"min=0"`
+}
+
+// RateLimits throttle the external API. A limit with no requests_per_second
+// does not apply.
+type RateLimits struct {
+	PerUser                        RateLimit            `json:"per_user"`
+	PerClient                      RateLimit            `json:"per_client"`
+	PerRoute                       map[string]RateLimit `json:"per_route"`
+	MaxConcurrentRequestsPerClient int                  `json:"max_concurrent_requests_per_client" validate:"min=0"`
+}
+
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second" validate:"min=0"`
+	Burst             int     `json:"burst" validate:"min=0"`
 }
 
 func (c *Config) Validate() error {
-	return validator.Validate(c)
+	if err := validator.Validate(c); err != nil {
+		return err
+	}
+	for _, cidr := range c.ForbiddenEgressCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("ForbiddenEgressCIDRs: %s", err)
+		}
+	}
+	return nil
 }
 
 func New(path string) (*Config, error) {
@@ -52,6 +52,17 @@ var _ = Describe("Config", func() {
 						"require_ssl": true,
 						"ca_cert": "/some/ca/cert/path"
 					},
+					"database_replicas": [{
+						"type": "mysql",
+						"user": "root",
+						"password": "password",
+						"host": "127.0.0.2",
+						"port": 3306,
+						"timeout": 5,
+						"database_name": "network_policy"
+					}],
+					"database_replica_max_lag_seconds": 7,
+					"database_replica_check_interval_seconds": 11,
 					"database_migration_timeout": 88,
 					"max_idle_connections": 4,
 					"max_open_connections": 5,
@@ -60,10 +71,22 @@ var _ = Describe("Config", func() {
 					"metron_address": "http://1.2.3.4:9999",
 					"log_level": "debug",
 					"cleanup_interval": 2,
+					"enable_tag_reclamation": true,
+					"tag_reclaim_quarantine_seconds": 3600,
+					"tag_utilisation_warning_percent": 75,
 					"request_timeout": 5,
 					"max_policies": 3,
 					"enable_space_developer_self_service": true,
-					"allowed_cors_domains": ["https://foo.bar", "https://bar.foo"]
+					"enable_egress_self_service": true,
+					"allowed_cors_domains": ["https://foo.bar", "https://bar.foo"],
+					"rate_limits": {
+						"per_user": {"requests_per_second": 0.5, "burst": 10},
+						"per_client": {"requests_per_second": 20, "burst": 40},
+						"per_route": {"policies_index": {"requests_per_second": 50, "burst": 100}},
+						"max_concurrent_requests_per_client": 8
+					},
+					"forbidden_egress_cidrs": ["10.255.0.0/16", "169.254.0.0/16"],
+					"max_batch_size": 50
 				}`)
 				c, err := config.New(file.Name())
 				Expect(err).NotTo(HaveOccurred())
@@ -89,6 +112,10 @@ var _ = Describe("Config", func() {
 				Expect(c.Database.DatabaseName).To(Equal("network_policy"))
 				Expect(c.Database.RequireSSL).To(Equal(true))
 				Expect(c.Database.CACert).To(Equal("/some/ca/cert/path"))
+				Expect(c.DatabaseReplicas).To(HaveLen(1))
+				Expect(c.DatabaseReplicas[0].Host).To(Equal("127.0.0.2"))
+				Expect(c.DatabaseReplicaMaxLagSeconds).To(Equal(7))
+				Expect(c.DatabaseReplicaCheckIntervalSeconds).To(Equal(11))
 				Expect(c.DatabaseMigrationTimeout).To(Equal(88))
 				Expect(c.MaxIdleConnections).To(Equal(4))
 				Expect(c.MaxOpenConnections).To(Equal(5))
@@ -97,13 +124,27 @@ var _ = Describe("Config", func() {
 				Expect(c.MetronAddress).To(Equal("http://1.2.3.4:9999"))
 				Expect(c.LogLevel).To(Equal("debug"))
 				Expect(c.CleanupInterval).To(Equal(2))
+				Expect(c.EnableTagReclamation).To(BeTrue())
+				Expect(c.TagReclaimQuarantineSeconds).To(Equal(3600))
+				Expect(c.TagUtilisationWarningPercent).To(Equal(75))
 				Expect(c.RequestTimeout).To(Equal(5))
 				Expect(c.MaxPolicies).To(Equal(3))
 				Expect(c.EnableSpaceDeveloperSelfService).To(BeTrue())
+				Expect(c.EnableEgressSelfService).To(BeTrue())
 				Expect(c.AllowedCORSDomains).To(Equal([]string{
 					"https://foo.bar",
 					"https://bar.foo",
 				}))
+				Expect(c.RateLimits).To(Equal(config.RateLimits{
+					PerUser:   config.RateLimit{RequestsPerSecond: 0.5, Burst: 10},
+					PerClient: config.RateLimit{RequestsPerSecond: 20, Burst: 40},
+					PerRoute: map[string]config.RateLimit{
+						"policies_index": {RequestsPerSecond: 50, Burst: 100},
+					},
+					MaxConcurrentRequestsPerClient: 8,
+				}))
+				Expect(c.ForbiddenEgressCIDRs).To(Equal([]string{"10.255.0.0/16", "169.254.0.0/16"}))
+				Expect(c.MaxBatchSize).To(Equal(50))
 			})
 		})
 
@@ -333,6 +374,44 @@ var _ = Describe("Config", func() {
 				})
 			})
 
+			Context("when a rate limit is negative", func() {
+				BeforeEach(func() {
+					allData["rate_limits"] = map[string]interface{}{
+						"per_user": map[string]interface{}{"requests_per_second": -1},
+					}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+
+				It("returns an error", func() {
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: RateLimits.PerUser.RequestsPerSecond: less than min"))
+				})
+			})
+
+			Context("when the max batch size is negative", func() {
+				BeforeEach(func() {
+					allData["max_batch_size"] = -1
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+
+				It("returns an error", func() {
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: MaxBatchSize: less than min"))
+				})
+			})
+
+			Context("when a forbidden egress cidr is invalid", func() {
+				BeforeEach(func() {
+					allData["forbidden_egress_cidrs"] = []string{"10.255.0.0/99"}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+
+				It("returns an error", func() {
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: ForbiddenEgressCIDRs: invalid CIDR address: 10.255.0.0/99"))
+				})
+			})
+
 			Context("when the config file is missing a database_name", func() {
 				BeforeEach(func() {
 					delete(allData["database"].(map[string]interface{}), "database_name")
@@ -11,27 +11,75 @@ import (
 )
 
 type InternalConfig struct {
-	LogPrefix                     string    `json:"log_prefix" validate:"nonzero"`
-	ListenHost                    string    `json:"listen_host" validate:"nonzero"`
-	InternalListenPort            int       `json:"internal_listen_port" validate:"nonzero"`
-	DebugServerHost               string    `json:"debug_server_host" validate:"nonzero"`
-	DebugServerPort               int       `json:"debug_server_port" validate:"nonzero"`
-	HealthCheckPort               int       `json:"health_check_port" validate:"nonzero"`
-	CACertFile                    string    `json:"ca_cert_file" validate:"nonzero"`
-	ServerCertFile                string    `json:"server_cert_file" validate:"nonzero"`
-	ServerKeyFile                 string    `json:"server_key_file" validate:"nonzero"`
-	Database                      db.Config `json:"database" validate:"nonzero"`
-	TagLength                     int       `json:"tag_length" validate:"nonzero"`
-	MetronAddress                 string    `json:"metron_address" validate:"nonzero"`
-	LogLevel                      string    `json:"log_level"`
-	RequestTimeout                int       `json:"request_timeout" validate:"min=1"`
-	MaxIdleConnections            int       `json:"max_idle_connections" validate:"min=0"`
-	MaxOpenConnections            int       `json:"max_open_connections" validate:"min=0"`
-	MaxConnectionsLifetimeSeconds int       `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	LogPrefix                           string           `json:"log_prefix" validate:"nonzero"`
+	ListenHost                          string           `json:"listen_host" validate:"nonzero"`
+	InternalListenPort                  int              `json:"internal_listen_port" validate:"nonzero"`
+	DebugServerHost                     string           `json:"debug_server_host" validate:"nonzero"`
+	DebugServerPort                     int              `json:"debug_server_port" validate:"nonzero"`
+	HealthCheckPort                     int              `json:"health_check_port" validate:"nonzero"`
+	CACertFile                          string           `json:"ca_cert_file" validate:"nonzero"`
+	ServerCertFile                      string           `json:"server_cert_file" validate:"nonzero"`
+	ServerKeyFile                       string           `json:"server_key_file" validate:"nonzero"`
+	TLSReloadIntervalSeconds            int              `json:"tls_reload_interval_seconds" validate:"min=0"`
+	CARotationOverlapSeconds            int              `json:"ca_rotation_overlap_seconds" validate:"min=0"`
+	ClientIdentities                    []ClientIdentity `json:"client_identities"`
+	Database                            db.Config        `json:"database" validate:"nonzero"`
+	DatabaseReplicas                    []db.Config      `json:"database_replicas"`
+	DatabaseReplicaMaxLagSeconds        int              `json:"database_replica_max_lag_seconds" validate:"min=0"`
+	DatabaseReplicaCheckIntervalSeconds int              `json:"database_replica_check_interval_seconds" validate:"min=0"`
+	TagLength                           int              `json:"tag_length" validate:"nonzero"`
+	MetronAddress                       string           `json:"metron_address" validate:"nonzero"`
+	LogLevel                            string           `json:"log_level"`
+	RequestTimeout                      int              `json:"request_timeout" validate:"min=1"`
+	MaxIdleConnections                  int              `json:"max_idle_connections" validate:"min=0"`
+	MaxOpenConnections                  int              `json:"max_open_connections" validate:"min=0"`
+	MaxConnectionsLifetimeSeconds       int              `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	ServerReadTimeoutSeconds            int              `json:"server_read_timeout_seconds" validate:"min=0"`
+	ServerWriteTimeoutSeconds           int              `json:"server_write_timeout_seconds" validate:"min=0"`
+	ServerIdleTimeoutSeconds            int              `json:"server_idle_timeout_seconds" validate:"min=0"`
+	DrainTimeoutSeconds                 int              `json:"drain_timeout_seconds" validate:"min=0"`
+	UAAClient                           string           `json:"uaa_client"`
+	UAAClientSecret                     string           `json:"uaa_client_secret"`
+	UAACA                               string           `json:"uaa_ca"`
+	UAAURL                              string           `json:"uaa_url"`
+	UAAPort                             int              `json:"uaa_port"`
+	CCURL                               string           `json:"cc_url"`
+	CCCA                                string           `json:"cc_ca_cert"`
+	SkipSSLValidation                   bool             `json:"skip_ssl_validation"`
+	AppOrgCacheSeconds                  int              `json:"app_org_cache_seconds" validate:"min=0"`
+	CCAppRequestChunkSize               int              `json:"cc_app_request_chunk_size" validate:"min=0"`
+}
+
+// ClientIdentity gives a role to the clients whose certificate has Identity
+// as its common name or as a subject alternative name.
+type ClientIdentity struct {
+	Identity string `json:"identity" validate:"nonzero"`
+	Role     string `json:"role" validate:"regexp=^(cell|operator|tagger)$"`
 }
 
 func (c *InternalConfig) Validate() error {
-	return validator.Validate(c)
+	if err := validator.Validate(c); err != nil {
+		return err
+	}
+	if c.CCURL == "" {
+		return nil
+	}
+	// Resolving the orgs of apps in Cloud Controller needs a UAA client.
+	required := []struct {
+		name string
+		set  bool
+	}{
+		{"UAAClient", c.UAAClient != ""},
+		{"UAAClientSecret", c.UAAClientSecret != ""},
+		{"UAAURL", c.UAAURL != ""},
+		{"UAAPort", c.UAAPort != 0},
+	}
+	for _, field := range required {
+		if !field.set {
+			return fmt.Errorf("%s: required with cc_url", field.name)
+		}
+	}
+	return nil
 }
 
 func NewInternal(path string) (*InternalConfig, error) {
@@ -36,6 +36,9 @@ var _ = Describe("InternalConfig", func() {
 					"ca_cert_file": "some/ca/cert/file",
 					"server_cert_file": "some/server/cert/file",
 					"server_key_file": "some/server/key/file",
+					"tls_reload_interval_seconds": 30,
+					"ca_rotation_overlap_seconds": 600,
+					"client_identities": [{"identity": "cell.service.cf.internal", "role": "cell"}, {"identity": "tagger.service.cf.internal", "role": "tagger"}],
 					"database": {
 						"type": "mysql",
 						"user": "root",
@@ -51,7 +54,16 @@ var _ = Describe("InternalConfig", func() {
 					"tag_length": 2,
 					"metron_address": "http://1.2.3.4:9999",
 					"log_level": "debug",
-					"request_timeout": 5
+					"request_timeout": 5,
+					"uaa_client": "network-policy",
+					"uaa_client_secret": "some-secret",
+					"uaa_ca": "some/uaa/ca",
+					"uaa_url": "https://uaa.some-domain",
+					"uaa_port": 8443,
+					"cc_url": "https://api.some-domain",
+					"cc_ca_cert": "some/cc/ca",
+					"app_org_cache_seconds": 300,
+					"cc_app_request_chunk_size": 100
 				}`)
 				c, err := config.NewInternal(file.Name())
 				Expect(err).NotTo(HaveOccurred())
@@ -64,6 +76,12 @@ var _ = Describe("InternalConfig", func() {
 				Expect(c.CACertFile).To(Equal("some/ca/cert/file"))
 				Expect(c.ServerCertFile).To(Equal("some/server/cert/file"))
 				Expect(c.ServerKeyFile).To(Equal("some/server/key/file"))
+				Expect(c.TLSReloadIntervalSeconds).To(Equal(30))
+				Expect(c.CARotationOverlapSeconds).To(Equal(600))
+				Expect(c.ClientIdentities).To(Equal([]config.ClientIdentity{
+					{Identity: "cell.service.cf.internal", Role: "cell"},
+					{Identity: "tagger.service.cf.internal", Role: "tagger"},
+				}))
 				Expect(c.Database.Type).To(Equal("mysql"))
 				Expect(c.Database.User).To(Equal("root"))
 				Expect(c.Database.Password).To(Equal("password"))
@@ -78,6 +96,15 @@ var _ = Describe("InternalConfig", func() {
 				Expect(c.MaxIdleConnections).To(Equal(4))
 				Expect(c.MaxOpenConnections).To(Equal(5))
 				Expect(c.MaxConnectionsLifetimeSeconds).To(Equal(45))
+				Expect(c.UAAClient).To(Equal("network-policy"))
+				Expect(c.UAAClientSecret).To(Equal("some-secret"))
+				Expect(c.UAACA).To(Equal("some/uaa/ca"))
+				Expect(c.UAAURL).To(Equal("https://uaa.some-domain"))
+				Expect(c.UAAPort).To(Equal(8443))
+				Expect(c.CCURL).To(Equal("https://api.some-domain"))
+				Expect(c.CCCA).To(Equal("some/cc/ca"))
+				Expect(c.AppOrgCacheSeconds).To(Equal(300))
+				Expect(c.CCAppRequestChunkSize).To(Equal(100))
 			})
 		})
 
@@ -155,6 +182,71 @@ var _ = Describe("InternalConfig", func() {
 			Entry("missing request timeout", "request_timeout", "RequestTimeout: less than min"),
 		)
 
+		DescribeTable("when cc_url is set without a uaa member",
+			func(missingFlag, errorMsg string) {
+				allData := map[string]interface{}{
+					"log_prefix":           "cfnetworking",
+					"listen_host":          "http://1.2.3.4",
+					"internal_listen_port": 2222,
+					"debug_server_host":    "http://4.4.4.4",
+					"debug_server_port":    3333,
+					"health_check_port":    4444,
+					"ca_cert_file":         "some/ca/cert/file",
+					"server_cert_file":     "some/server/cert/file",
+					"server_key_file":      "some/server/key/file",
+					"database": map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.1",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					},
+					"tag_length":        2,
+					"metron_address":    "http://1.2.3.4:9999",
+					"request_timeout":   5,
+					"cc_url":            "https://api.some-domain",
+					"uaa_client":        "network-policy",
+					"uaa_client_secret": "some-secret",
+					"uaa_url":           "https://uaa.some-domain",
+					"uaa_port":          8443,
+				}
+				delete(allData, missingFlag)
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				_, err = config.NewInternal(file.Name())
+				Expect(err).To(MatchError(fmt.Sprintf("invalid config: %s", errorMsg)))
+			},
+			Entry("missing uaa client", "uaa_client", "UAAClient: required with cc_url"),
+			Entry("missing uaa client secret", "uaa_client_secret", "UAAClientSecret: required with cc_url"),
+			Entry("missing uaa url", "uaa_url", "UAAURL: required with cc_url"),
+			Entry("missing uaa port", "uaa_port", "UAAPort: required with cc_url"),
+		)
+
+		Context("when a client identity has an unknown role", func() {
+			It("returns a meaningful error", func() {
+				file.WriteString(`{
+					"log_prefix": "cfnetworking",
+					"listen_host": "http://1.2.3.4",
+					"internal_listen_port": 2222,
+					"debug_server_host": "http://6.5.4.3",
+					"debug_server_port": 9999,
+					"health_check_port": 9443,
+					"ca_cert_file": "some/ca/cert/file",
+					"server_cert_file": "some/server/cert/file",
+					"server_key_file": "some/server/key/file",
+					"client_identities": [{"identity": "some-identity", "role": "admin"}],
+					"database": {"type": "mysql"},
+					"tag_length": 2,
+					"metron_address": "http://1.2.3.4:9999",
+					"request_timeout": 5
+				}`)
+				_, err := config.NewInternal(file.Name())
+				Expect(err).To(MatchError(ContainSubstring("ClientIdentities[0].Role: regular expression mismatch")))
+			})
+		})
+
 		Describe("database config", func() {
 			var allData map[string]interface{}
 			BeforeEach(func() {
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// reloadableFields are the fields, by json name, that a running policy server
+// applies when its config is reloaded. Any other field needs a restart.
+var reloadableFields = map[string]bool{
+	"max_policies":                        true,
+	"allowed_cors_domains":                true,
+	"cleanup_interval":                    true,
+	"enable_space_developer_self_service": true,
+	"enable_egress_self_service":          true,
+	"log_level":                           true,
+}
+
+// Reload returns c with the reloadable fields taken from newConfig, along with
+// the json names of the other fields that differ and so are ignored.
+func (c *Config) Reload(newConfig *Config) (*Config, []string) {
+	reloaded := *c
+	var ignored []string
+
+	current := reflect.ValueOf(c).Elem()
+	next := reflect.ValueOf(newConfig).Elem()
+	result := reflect.ValueOf(&reloaded).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		name := strings.Split(current.Type().Field(i).Tag.Get("json"), ",")[0]
+		if reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()) {
+			continue
+		}
+		if reloadableFields[name] {
+			result.Field(i).Set(next.Field(i))
+		} else {
+			ignored = append(ignored, name)
+		}
+	}
+	return &reloaded, ignored
+}
+
+// LagerLogLevel parses LogLevel. An empty level is info, and warn, which the
+// job specs have always offered, is error since lager has no warn level.
+func (c *Config) LagerLogLevel() (lager.LogLevel, error) {
+	switch c.LogLevel {
+	case "debug":
+		return lager.DEBUG, nil
+	case "", "info":
+		return lager.INFO, nil
+	case "warn", "error":
+		return lager.ERROR, nil
+	case "fatal":
+		return lager.FATAL, nil
+	default:
+		return lager.INFO, fmt.Errorf("unknown log level: %s", c.LogLevel)
+	}
+}
+
+// Reloader reads the config file again each time a signal arrives on Reload,
+// and passes the reloaded config to Apply. A config that does not parse or
+// validate is logged and not applied.
+type Reloader struct {
+	Logger lager.Logger
+	Path   string
+	Config *Config
+	Reload <-chan os.Signal
+	Apply  func(*Config)
+}
+
+func (r *Reloader) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-r.Reload:
+			r.reload()
+		}
+	}
+}
+
+func (r *Reloader) reload() {
+	newConfig, err := New(r.Path)
+	if err != nil {
+		r.Logger.Error("reload-config-failed", err)
+		return
+	}
+
+	_, err = newConfig.LagerLogLevel()
+	if err != nil {
+		r.Logger.Error("reload-config-failed", err)
+		return
+	}
+
+	reloaded, ignored := r.Config.Reload(newConfig)
+	if len(ignored) > 0 {
+		r.Logger.Info("reload-config-ignored-fields", lager.Data{"fields": ignored})
+	}
+
+	r.Apply(reloaded)
+	r.Config = reloaded
+	r.Logger.Info("reloaded-config", lager.Data{
+		"max_policies":                        reloaded.MaxPolicies,
+		"allowed_cors_domains":                reloaded.AllowedCORSDomains,
+		"cleanup_interval":                    reloaded.CleanupInterval,
+		"enable_space_developer_self_service": reloaded.EnableSpaceDeveloperSelfService,
+		"enable_egress_self_service":          reloaded.EnableEgressSelfService,
+		"log_level":                           reloaded.LogLevel,
+	})
+}
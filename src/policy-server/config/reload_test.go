@@ -0,0 +1,207 @@
+package config_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"policy-server/config"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Reload", func() {
+	var current *config.Config
+
+	BeforeEach(func() {
+		current = &config.Config{
+			ListenPort:         1234,
+			MaxPolicies:        3,
+			CleanupInterval:    60,
+			AllowedCORSDomains: []string{"https://foo.bar"},
+			LogLevel:           "info",
+		}
+	})
+
+	It("takes the reloadable fields from the new config", func() {
+		newConfig := *current
+		newConfig.MaxPolicies = 10
+		newConfig.CleanupInterval = 5
+		newConfig.AllowedCORSDomains = []string{"*"}
+		newConfig.EnableSpaceDeveloperSelfService = true
+		newConfig.EnableEgressSelfService = true
+		newConfig.LogLevel = "debug"
+
+		reloaded, ignored := current.Reload(&newConfig)
+		Expect(ignored).To(BeEmpty())
+		Expect(*reloaded).To(Equal(newConfig))
+		Expect(current.MaxPolicies).To(Equal(3))
+	})
+
+	It("keeps and reports the fields that need a restart", func() {
+		newConfig := *current
+		newConfig.ListenPort = 4321
+		newConfig.MaxPolicies = 10
+
+		reloaded, ignored := current.Reload(&newConfig)
+		Expect(ignored).To(Equal([]string{"listen_port"}))
+		Expect(reloaded.ListenPort).To(Equal(1234))
+		Expect(reloaded.MaxPolicies).To(Equal(10))
+	})
+})
+
+var _ = Describe("LagerLogLevel", func() {
+	It("parses the log level", func() {
+		for level, expected := range map[string]lager.LogLevel{
+			"":      lager.INFO,
+			"debug": lager.DEBUG,
+			"info":  lager.INFO,
+			"warn":  lager.ERROR,
+			"error": lager.ERROR,
+			"fatal": lager.FATAL,
+		} {
+			logLevel, err := (&config.Config{LogLevel: level}).LagerLogLevel()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logLevel).To(Equal(expected))
+		}
+	})
+
+	It("rejects an unknown log level", func() {
+		_, err := (&config.Config{LogLevel: "loud"}).LagerLogLevel()
+		Expect(err).To(MatchError("unknown log level: loud"))
+	})
+})
+
+var _ = Describe("Reloader", func() {
+	var (
+		reloader  *config.Reloader
+		logger    *lagertest.TestLogger
+		file      *os.File
+		configMap map[string]interface{}
+		applied   chan *config.Config
+		reload    chan os.Signal
+		signals   chan os.Signal
+		ready     chan struct{}
+		retChan   chan error
+	)
+
+	writeConfig := func() {
+		Expect(file.Truncate(0)).To(Succeed())
+		_, err := file.Seek(0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.NewEncoder(file).Encode(configMap)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		file, err = ioutil.TempFile(os.TempDir(), "config-")
+		Expect(err).NotTo(HaveOccurred())
+
+		configMap = map[string]interface{}{
+			"listen_host":       "http://1.2.3.4",
+			"listen_port":       1234,
+			"log_prefix":        "cfnetworking",
+			"debug_server_host": "http://4.4.4.4",
+			"debug_server_port": 3333,
+			"uaa_client":        "some-uaa-client",
+			"uaa_client_secret": "some-uaa-client-secret",
+			"uaa_url":           "http://uaa.example.com",
+			"uaa_port":          5555,
+			"cc_url":            "http://ccapi.example.com",
+			"cc_ca_cert":        "some/cc/ca/cert",
+			"database": map[string]interface{}{
+				"type":          "mysql",
+				"user":          "root",
+				"password":      "password",
+				"host":          "127.0.0.1",
+				"port":          3306,
+				"timeout":       5,
+				"database_name": "network_policy",
+			},
+			"database_migration_timeout": 88,
+			"tag_length":                 2,
+			"metron_address":             "http://1.2.3.4:9999",
+			"cleanup_interval":           2,
+			"request_timeout":            5,
+			"max_policies":               3,
+		}
+		writeConfig()
+
+		current, err := config.New(file.Name())
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+		applied = make(chan *config.Config, 1)
+		reload = make(chan os.Signal)
+		signals = make(chan os.Signal)
+		ready = make(chan struct{})
+		retChan = make(chan error)
+
+		reloader = &config.Reloader{
+			Logger: logger,
+			Path:   file.Name(),
+			Config: current,
+			Reload: reload,
+			Apply: func(c *config.Config) {
+				applied <- c
+			},
+		}
+
+		go func() {
+			retChan <- reloader.Run(signals, ready)
+		}()
+		Eventually(ready).Should(BeClosed())
+	})
+
+	AfterEach(func() {
+		signals <- os.Interrupt
+		Eventually(retChan).Should(Receive(nil))
+		os.Remove(file.Name())
+	})
+
+	It("applies the reloaded config", func() {
+		configMap["max_policies"] = 10
+		configMap["listen_port"] = 4321
+		writeConfig()
+
+		reload <- os.Interrupt
+
+		var reloaded *config.Config
+		Eventually(applied).Should(Receive(&reloaded))
+		Expect(reloaded.MaxPolicies).To(Equal(10))
+		Expect(reloaded.ListenPort).To(Equal(1234))
+		Expect(logger).To(gbytes.Say("reload-config-ignored-fields.*listen_port"))
+		Expect(logger).To(gbytes.Say("reloaded-config.*\"max_policies\":10"))
+	})
+
+	Context("when the new config is invalid", func() {
+		BeforeEach(func() {
+			delete(configMap, "listen_host")
+			writeConfig()
+		})
+
+		It("does not apply it", func() {
+			reload <- os.Interrupt
+
+			Eventually(logger).Should(gbytes.Say("reload-config-failed.*ListenHost: zero value"))
+			Consistently(applied).ShouldNot(Receive())
+		})
+	})
+
+	Context("when the new log level is unknown", func() {
+		BeforeEach(func() {
+			configMap["log_level"] = "loud"
+			writeConfig()
+		})
+
+		It("does not apply it", func() {
+			reload <- os.Interrupt
+
+			Eventually(logger).Should(gbytes.Say("reload-config-failed.*unknown log level: loud"))
+			Consistently(applied).ShouldNot(Receive())
+		})
+	})
+})
@@ -72,7 +72,7 @@ func (c *ConnWrapper) Close() error {
 
 func NewErroringConnectionPool(conf db.Config, maxOpenConnections int, maxIdleConnections int, connMaxLifetime time.Duration, logPrefix string, jobPrefix string, logger lager.Logger) (*ConnWrapper, error) {
 	retriableConnector := db.RetriableConnector{
-		Connector:     db.GetConnectionPool,
+		Connector:     getConnectionPool,
 		Sleeper:       db.SleeperFunc(time.Sleep),
 		RetryInterval: time.Duration(3) * time.Second,
 		MaxRetries:    10,
@@ -109,10 +109,17 @@ func NewErroringConnectionPool(conf db.Config, maxOpenConnections int, maxIdleCo
 	return &ConnWrapper{sqlxDB: connectionPool}, nil
 }
 
+func getConnectionPool(conf db.Config) (*sqlx.DB, error) {
+	if conf.Type == SQLite {
+		return getSQLiteConnectionPool(conf)
+	}
+	return db.GetConnectionPool(conf)
+}
+
 func NewConnectionPool(conf db.Config, maxOpenConnections int, maxIdleConnections int, connMaxLifetime time.Duration, logPrefix string, jobPrefix string, logger lager.Logger) *ConnWrapper {
 	conn, err := NewErroringConnectionPool(conf, maxOpenConnections, maxIdleConnections, connMaxLifetime, logPrefix, jobPrefix, logger)
 	if err != nil {
-		log.Fatalf(err.Error())
+		log.Fatal(err.Error())
 	}
 	return conn
 }
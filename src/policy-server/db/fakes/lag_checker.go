@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/db"
+	"sync"
+	"time"
+)
+
+type LagChecker struct {
+	LagStub        func(db.Conn) (time.Duration, error)
+	lagMutex       sync.RWMutex
+	lagArgsForCall []struct {
+		arg1 db.Conn
+	}
+	lagReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	lagReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *LagChecker) Lag(arg1 db.Conn) (time.Duration, error) {
+	fake.lagMutex.Lock()
+	ret, specificReturn := fake.lagReturnsOnCall[len(fake.lagArgsForCall)]
+	fake.lagArgsForCall = append(fake.lagArgsForCall, struct {
+		arg1 db.Conn
+	}{arg1})
+	stub := fake.LagStub
+	fakeReturns := fake.lagReturns
+	fake.recordInvocation("Lag", []interface{}{arg1})
+	fake.lagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LagChecker) LagCallCount() int {
+	fake.lagMutex.RLock()
+	defer fake.lagMutex.RUnlock()
+	return len(fake.lagArgsForCall)
+}
+
+func (fake *LagChecker) LagCalls(stub func(db.Conn) (time.Duration, error)) {
+	fake.lagMutex.Lock()
+	defer fake.lagMutex.Unlock()
+	fake.LagStub = stub
+}
+
+func (fake *LagChecker) LagArgsForCall(i int) db.Conn {
+	fake.lagMutex.RLock()
+	defer fake.lagMutex.RUnlock()
+	argsForCall := fake.lagArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *LagChecker) LagReturns(result1 time.Duration, result2 error) {
+	fake.lagMutex.Lock()
+	defer fake.lagMutex.Unlock()
+	fake.LagStub = nil
+	fake.lagReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LagChecker) LagReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.lagMutex.Lock()
+	defer fake.lagMutex.Unlock()
+	fake.LagStub = nil
+	if fake.lagReturnsOnCall == nil {
+		fake.lagReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.lagReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LagChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.lagMutex.RLock()
+	defer fake.lagMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *LagChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
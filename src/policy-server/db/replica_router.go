@@ -0,0 +1,227 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/metrics"
+	"code.cloudfoundry.org/lager"
+	"github.com/jmoiron/sqlx"
+)
+
+const primaryPoolName = "DBPrimary"
+
+//go:generate counterfeiter -o fakes/conn.go --fake-name Conn . Conn
+type Conn interface {
+	Beginx() (Transaction, error)
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	NamedExec(query string, arg interface{}) (sql.Result, error)
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	DriverName() string
+	RawConnection() *sqlx.DB
+	Rebind(string) string
+}
+
+//go:generate counterfeiter -o fakes/lag_checker.go --fake-name LagChecker . lagChecker
+type lagChecker interface {
+	Lag(Conn) (time.Duration, error)
+}
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+}
+
+type Replica struct {
+	Name string
+	Conn Conn
+
+	healthy bool
+	lag     time.Duration
+}
+
+// ReplicaRouter sends reads to a replica whose replication lag is within
+// MaxLag, falling back to the primary when no replica qualifies. Writes always
+// go to the primary. Replica health is only updated by CheckReplicas.
+type ReplicaRouter struct {
+	Primary       Conn
+	Replicas      []*Replica
+	MaxLag        time.Duration
+	LagChecker    lagChecker
+	MetricsSender metricsSender
+	Logger        lager.Logger
+
+	mutex sync.RWMutex
+	next  int
+}
+
+func NewReplicaRouter(primary Conn, replicas []Conn, maxLag time.Duration, metricsSender metricsSender, logger lager.Logger) *ReplicaRouter {
+	router := &ReplicaRouter{
+		Primary:       primary,
+		MaxLag:        maxLag,
+		LagChecker:    &ReplicationLagChecker{},
+		MetricsSender: metricsSender,
+		Logger:        logger,
+	}
+	for i, replica := range replicas {
+		router.Replicas = append(router.Replicas, &Replica{
+			Name: fmt.Sprintf("DBReplica%d", i),
+			Conn: replica,
+		})
+	}
+	return router
+}
+
+func (r *ReplicaRouter) CheckReplicas() error {
+	for _, replica := range r.Replicas {
+		lag, err := r.LagChecker.Lag(replica.Conn)
+		healthy := err == nil && lag <= r.MaxLag
+		if err != nil {
+			r.Logger.Error("check-replica-lag", err, lager.Data{"replica": replica.Name})
+		} else if !healthy {
+			r.Logger.Info("replica-lagging", lager.Data{"replica": replica.Name, "lag": lag.String()})
+		}
+
+		r.mutex.Lock()
+		replica.healthy = healthy
+		replica.lag = lag
+		r.mutex.Unlock()
+	}
+	return nil
+}
+
+func (r *ReplicaRouter) LagSources() []metrics.MetricSource {
+	var sources []metrics.MetricSource
+	for _, replica := range r.Replicas {
+		replica := replica
+		sources = append(sources, metrics.MetricSource{
+			Name: replica.Name + "LagSeconds",
+			Unit: "seconds",
+			Getter: func() (float64, error) {
+				r.mutex.RLock()
+				defer r.mutex.RUnlock()
+				return replica.lag.Seconds(), nil
+			},
+		})
+	}
+	return sources
+}
+
+func (r *ReplicaRouter) reader() Conn {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i := 0; i < len(r.Replicas); i++ {
+		replica := r.Replicas[(r.next+i)%len(r.Replicas)]
+		if replica.healthy {
+			r.next = (r.next + i + 1) % len(r.Replicas)
+			r.MetricsSender.IncrementCounter(replica.Name + "Reads")
+			return replica.Conn
+		}
+	}
+
+	if len(r.Replicas) > 0 {
+		r.MetricsSender.IncrementCounter("DBReplicaFallback")
+	}
+	r.MetricsSender.IncrementCounter(primaryPoolName + "Reads")
+	return r.Primary
+}
+
+func (r *ReplicaRouter) Beginx() (Transaction, error) {
+	return r.reader().Beginx()
+}
+
+func (r *ReplicaRouter) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.Primary.Exec(query, args...)
+}
+
+func (r *ReplicaRouter) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return r.Primary.NamedExec(query, arg)
+}
+
+func (r *ReplicaRouter) Get(dest interface{}, query string, args ...interface{}) error {
+	return r.reader().Get(dest, query, args...)
+}
+
+func (r *ReplicaRouter) Select(dest interface{}, query string, args ...interface{}) error {
+	return r.reader().Select(dest, query, args...)
+}
+
+func (r *ReplicaRouter) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.reader().QueryRow(query, args...)
+}
+
+func (r *ReplicaRouter) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.reader().Query(query, args...)
+}
+
+func (r *ReplicaRouter) DriverName() string {
+	return r.Primary.DriverName()
+}
+
+func (r *ReplicaRouter) RawConnection() *sqlx.DB {
+	return r.reader().RawConnection()
+}
+
+func (r *ReplicaRouter) Rebind(query string) string {
+	return r.Primary.Rebind(query)
+}
+
+// ReplicationLagChecker returns an error for a database that is not
+// replicating, rather than a lag of 0, so that it is never read from.
+type ReplicationLagChecker struct{}
+
+func (c *ReplicationLagChecker) Lag(conn Conn) (time.Duration, error) {
+	if conn.DriverName() == "mysql" {
+		return c.mysqlLag(conn)
+	}
+
+	var seconds sql.NullFloat64
+	err := conn.QueryRow(`
+		SELECT EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	`).Scan(&seconds)
+	if err != nil {
+		return 0, fmt.Errorf("querying replication lag: %s", err)
+	}
+	if !seconds.Valid {
+		return 0, fmt.Errorf("not a replica")
+	}
+	return time.Duration(seconds.Float64 * float64(time.Second)), nil
+}
+
+func (c *ReplicationLagChecker) mysqlLag(conn Conn) (time.Duration, error) {
+	rows, err := conn.RawConnection().Queryx("SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, fmt.Errorf("querying replication lag: %s", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("querying replication lag: %s", err)
+		}
+		return 0, fmt.Errorf("not a replica")
+	}
+
+	status := map[string]interface{}{}
+	err = rows.MapScan(status)
+	if err != nil {
+		return 0, fmt.Errorf("querying replication lag: %s", err)
+	}
+
+	raw, ok := status["Seconds_Behind_Master"].([]byte)
+	if !ok {
+		return 0, fmt.Errorf("replication is not running")
+	}
+	seconds, err := strconv.Atoi(string(raw))
+	if err != nil {
+		return 0, fmt.Errorf("parsing replication lag: %s", err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
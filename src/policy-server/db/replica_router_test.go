@@ -0,0 +1,130 @@
+package db_test
+
+import (
+	"errors"
+	"policy-server/db"
+	"policy-server/db/fakes"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ReplicaRouter", func() {
+	var (
+		primary           *fakes.Conn
+		replicaA          *fakes.Conn
+		replicaB          *fakes.Conn
+		fakeLagChecker    *fakes.LagChecker
+		fakeMetricsSender *fakes.MetricsSender
+		logger            *lagertest.TestLogger
+		router            *db.ReplicaRouter
+	)
+
+	BeforeEach(func() {
+		primary = &fakes.Conn{}
+		replicaA = &fakes.Conn{}
+		replicaB = &fakes.Conn{}
+		fakeLagChecker = &fakes.LagChecker{}
+		fakeMetricsSender = &fakes.MetricsSender{}
+		logger = lagertest.NewTestLogger("test")
+
+		router = db.NewReplicaRouter(primary, []db.Conn{replicaA, replicaB}, 5*time.Second, fakeMetricsSender, logger)
+		router.LagChecker = fakeLagChecker
+	})
+
+	Context("when the replicas have not been checked", func() {
+		It("reads from the primary", func() {
+			router.Query("SELECT 1")
+
+			Expect(primary.QueryCallCount()).To(Equal(1))
+			Expect(replicaA.QueryCallCount()).To(Equal(0))
+			Expect(replicaB.QueryCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the replicas are within the allowed lag", func() {
+		BeforeEach(func() {
+			fakeLagChecker.LagReturns(time.Second, nil)
+			Expect(router.CheckReplicas()).To(Succeed())
+		})
+
+		It("spreads reads across the replicas", func() {
+			router.Query("SELECT 1")
+			router.Query("SELECT 1")
+			router.Query("SELECT 1")
+
+			Expect(primary.QueryCallCount()).To(Equal(0))
+			Expect(replicaA.QueryCallCount()).To(Equal(2))
+			Expect(replicaB.QueryCallCount()).To(Equal(1))
+		})
+
+		It("sends writes to the primary", func() {
+			router.Exec("DELETE FROM groups")
+
+			Expect(primary.ExecCallCount()).To(Equal(1))
+			Expect(replicaA.ExecCallCount()).To(Equal(0))
+			Expect(replicaB.ExecCallCount()).To(Equal(0))
+		})
+
+		It("counts reads per pool", func() {
+			router.Query("SELECT 1")
+			router.Query("SELECT 1")
+
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(2))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("DBReplica0Reads"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(1)).To(Equal("DBReplica1Reads"))
+		})
+
+		It("reports the lag of each replica", func() {
+			sources := router.LagSources()
+			Expect(sources).To(HaveLen(2))
+			Expect(sources[0].Name).To(Equal("DBReplica0LagSeconds"))
+
+			lag, err := sources[0].Getter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lag).To(Equal(1.0))
+		})
+	})
+
+	Context("when a replica is lagging", func() {
+		BeforeEach(func() {
+			fakeLagChecker.LagStub = func(conn db.Conn) (time.Duration, error) {
+				if conn == replicaA {
+					return time.Minute, nil
+				}
+				return 0, nil
+			}
+			Expect(router.CheckReplicas()).To(Succeed())
+		})
+
+		It("skips it", func() {
+			router.Query("SELECT 1")
+			router.Query("SELECT 1")
+
+			Expect(replicaA.QueryCallCount()).To(Equal(0))
+			Expect(replicaB.QueryCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when no replica is healthy", func() {
+		BeforeEach(func() {
+			fakeLagChecker.LagReturns(0, errors.New("potato"))
+			Expect(router.CheckReplicas()).To(Succeed())
+		})
+
+		It("falls back to the primary", func() {
+			router.Query("SELECT 1")
+
+			Expect(primary.QueryCallCount()).To(Equal(1))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("DBReplicaFallback"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(1)).To(Equal("DBPrimaryReads"))
+		})
+
+		It("logs the lag check error", func() {
+			Expect(logger).To(gbytes.Say("check-replica-lag.*potato"))
+		})
+	})
+})
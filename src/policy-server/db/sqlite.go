@@ -0,0 +1,34 @@
+package db
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// SQLite is the driver name of the SQLite backend. It is only meant for
+// developer laptops and hermetic tests; the database_name of the config is
+// the path of the database file and the remaining connection fields are
+// ignored. Foreign keys are enforced, as they are by MySQL and Postgres. The
+// driver is compiled in with the sqlite build tag.
+const SQLite = "sqlite3"
+
+func getSQLiteConnectionPool(conf db.Config) (*sqlx.DB, error) {
+	if !sqliteDriverRegistered {
+		return nil, fmt.Errorf("sqlite support not compiled in, build with -tags sqlite")
+	}
+
+	conn, err := sqlx.Open(SQLite, fmt.Sprintf("file:%s?_busy_timeout=%d&_txlock=immediate&_foreign_keys=on", conf.DatabaseName, conf.Timeout*1000))
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Ping()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
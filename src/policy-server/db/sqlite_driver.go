@@ -0,0 +1,8 @@
+//go:build sqlite
+// +build sqlite
+
+package db
+
+import _ "github.com/mattn/go-sqlite3"
+
+const sqliteDriverRegistered = true
@@ -0,0 +1,6 @@
+//go:build !sqlite
+// +build !sqlite
+
+package db
+
+const sqliteDriverRegistered = false
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// AppOrgResolver looks up the orgs of apps in Cloud Controller, so that the
+// internal API returns the org egress policies of the apps it is asked for
+// without the agents having to know their orgs. Apps never change space, so
+// their orgs are cached for CacheTTL, as are guids that are not apps, such
+// as the space guids the agents also pass. Expired entries are kept for
+// another CacheTTL, to be returned when Cloud Controller can not be reached.
+type AppOrgResolver struct {
+	UAAClient uaaClient
+	CCClient  ccClient
+	Clock     clock.Clock
+	CacheTTL  time.Duration
+	ChunkSize int
+
+	lock       sync.Mutex
+	appOrgs    map[string]cachedOrg
+	spaceOrgs  map[string]cachedOrg
+	lastPruned time.Time
+}
+
+// cachedOrg is the org of an app or space, or an empty guid for an app that
+// does not exist.
+type cachedOrg struct {
+	orgGUID string
+	expires time.Time
+}
+
+// OrgGUIDs returns the orgs of the apps among guids, each once. When they
+// can not all be looked up, it returns the error with the orgs it has,
+// including those of expired entries.
+func (r *AppOrgResolver) OrgGUIDs(guids []string) ([]string, error) {
+	now := r.Clock.Now()
+	orgs, missing, stale := r.cached(guids, now)
+	if len(missing) == 0 {
+		return uniqueOrgs(orgs), nil
+	}
+
+	resolved, err := r.resolve(missing, now)
+	if err != nil {
+		return uniqueOrgs(append(orgs, stale...)), err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for guid, orgGUID := range resolved {
+		r.appOrgs[guid] = cachedOrg{orgGUID: orgGUID, expires: now.Add(r.CacheTTL)}
+		orgs = append(orgs, orgGUID)
+	}
+	return uniqueOrgs(orgs), nil
+}
+
+// resolve looks up the orgs of guids, a chunk of them at a time, with an
+// empty org for the guids that are not apps.
+func (r *AppOrgResolver) resolve(guids []string, now time.Time) (map[string]string, error) {
+	token, err := r.UAAClient.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("get uaa token: %s", err)
+	}
+
+	resolved := map[string]string{}
+	for _, chunk := range getChunks(guids, r.ChunkSize) {
+		appSpaces, err := r.CCClient.GetAppSpaces(token, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("get app spaces: %s", err)
+		}
+
+		for _, guid := range chunk {
+			spaceGUID, ok := appSpaces[guid]
+			if !ok {
+				resolved[guid] = ""
+				continue
+			}
+			orgGUID, err := r.spaceOrg(token, spaceGUID, now)
+			if err != nil {
+				return nil, err
+			}
+			resolved[guid] = orgGUID
+		}
+	}
+	return resolved, nil
+}
+
+// cached returns the orgs of the guids in the cache, the guids that are not,
+// and the orgs of those among them that have expired.
+func (r *AppOrgResolver) cached(guids []string, now time.Time) ([]string, []string, []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.prune(now)
+
+	var orgs, missing, stale []string
+	for _, guid := range guids {
+		cached, ok := r.appOrgs[guid]
+		if ok && now.Before(cached.expires) {
+			orgs = append(orgs, cached.orgGUID)
+			continue
+		}
+		if ok {
+			stale = append(stale, cached.orgGUID)
+		}
+		missing = append(missing, guid)
+	}
+	return orgs, missing, stale
+}
+
+func (r *AppOrgResolver) spaceOrg(token, spaceGUID string, now time.Time) (string, error) {
+	r.lock.Lock()
+	cached, ok := r.spaceOrgs[spaceGUID]
+	r.lock.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.orgGUID, nil
+	}
+
+	space, err := r.CCClient.GetSpace(token, spaceGUID)
+	if err != nil {
+		return "", fmt.Errorf("get space: %s", err)
+	}
+	var orgGUID string
+	if space != nil {
+		orgGUID = space.OrgGUID
+	}
+
+	r.lock.Lock()
+	r.spaceOrgs[spaceGUID] = cachedOrg{orgGUID: orgGUID, expires: now.Add(r.CacheTTL)}
+	r.lock.Unlock()
+	return orgGUID, nil
+}
+
+// prune drops the entries that expired over a CacheTTL ago, at most once per
+// CacheTTL.
+func (r *AppOrgResolver) prune(now time.Time) {
+	if r.appOrgs == nil {
+		r.appOrgs = map[string]cachedOrg{}
+		r.spaceOrgs = map[string]cachedOrg{}
+	}
+	if now.Sub(r.lastPruned) < r.CacheTTL {
+		return
+	}
+	r.lastPruned = now
+
+	for _, entries := range []map[string]cachedOrg{r.appOrgs, r.spaceOrgs} {
+		for guid, entry := range entries {
+			if !now.Before(entry.expires.Add(r.CacheTTL)) {
+				delete(entries, guid)
+			}
+		}
+	}
+}
+
+func uniqueOrgs(orgs []string) []string {
+	seen := map[string]struct{}{}
+	unique := []string{}
+	for _, orgGUID := range orgs {
+		if _, ok := seen[orgGUID]; ok || orgGUID == "" {
+			continue
+		}
+		seen[orgGUID] = struct{}{}
+		unique = append(unique, orgGUID)
+	}
+	return unique
+}
@@ -0,0 +1,176 @@
+package handlers_test
+
+import (
+	"errors"
+	"policy-server/api"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppOrgResolver", func() {
+	var (
+		resolver      *handlers.AppOrgResolver
+		fakeUAAClient *fakes.UAAClient
+		fakeCCClient  *fakes.CCClient
+		fakeClock     *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+
+		fakeCCClient = &fakes.CCClient{}
+		fakeCCClient.GetAppSpacesReturns(map[string]string{
+			"app-1": "space-1",
+			"app-2": "space-1",
+		}, nil)
+		fakeCCClient.GetSpaceReturns(&api.Space{Name: "some-space", OrgGUID: "org-1"}, nil)
+
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		resolver = &handlers.AppOrgResolver{
+			UAAClient: fakeUAAClient,
+			CCClient:  fakeCCClient,
+			Clock:     fakeClock,
+			CacheTTL:  time.Minute,
+		}
+	})
+
+	It("returns the orgs of the apps, each once", func() {
+		orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1", "app-2", "space-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orgGUIDs).To(Equal([]string{"org-1"}))
+
+		token, guids := fakeCCClient.GetAppSpacesArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+		Expect(guids).To(ConsistOf("app-1", "app-2", "space-1"))
+
+		Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(1))
+		_, spaceGUID := fakeCCClient.GetSpaceArgsForCall(0)
+		Expect(spaceGUID).To(Equal("space-1"))
+	})
+
+	It("caches the orgs, and the guids that are not apps, until they expire", func() {
+		_, err := resolver.OrgGUIDs([]string{"app-1", "space-1"})
+		Expect(err).NotTo(HaveOccurred())
+
+		orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1", "space-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orgGUIDs).To(Equal([]string{"org-1"}))
+		Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(1))
+
+		fakeClock.Increment(time.Minute)
+
+		_, err = resolver.OrgGUIDs([]string{"app-1", "space-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+		Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(2))
+	})
+
+	It("only looks up the guids that are not cached", func() {
+		_, err := resolver.OrgGUIDs([]string{"app-1"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = resolver.OrgGUIDs([]string{"app-1", "app-2"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, guids := fakeCCClient.GetAppSpacesArgsForCall(1)
+		Expect(guids).To(Equal([]string{"app-2"}))
+	})
+
+	Context("when there are more guids than fit in a chunk", func() {
+		BeforeEach(func() {
+			resolver.ChunkSize = 2
+		})
+
+		It("looks up their spaces a chunk at a time", func() {
+			orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1", "app-2", "space-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orgGUIDs).To(Equal([]string{"org-1"}))
+
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+			_, guids := fakeCCClient.GetAppSpacesArgsForCall(0)
+			Expect(guids).To(Equal([]string{"app-1", "app-2"}))
+			_, guids = fakeCCClient.GetAppSpacesArgsForCall(1)
+			Expect(guids).To(Equal([]string{"space-1"}))
+			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the space does not exist", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSpaceReturns(nil, nil)
+		})
+
+		It("returns no org for its apps", func() {
+			orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orgGUIDs).To(BeEmpty())
+		})
+	})
+
+	Context("when getting the uaa token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1"})
+			Expect(err).To(MatchError("get uaa token: banana"))
+			Expect(orgGUIDs).To(BeEmpty())
+		})
+	})
+
+	Context("when getting the app spaces fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAppSpacesReturns(nil, errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			_, err := resolver.OrgGUIDs([]string{"app-1"})
+			Expect(err).To(MatchError("get app spaces: banana"))
+		})
+	})
+
+	Context("when Cloud Controller fails after the orgs expired", func() {
+		BeforeEach(func() {
+			_, err := resolver.OrgGUIDs([]string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeCCClient.GetAppSpacesReturns(nil, errors.New("banana"))
+		})
+
+		It("returns the error with the expired orgs, until they are pruned", func() {
+			fakeClock.Increment(time.Minute)
+			orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1", "app-2"})
+			Expect(err).To(MatchError("get app spaces: banana"))
+			Expect(orgGUIDs).To(Equal([]string{"org-1"}))
+
+			fakeClock.Increment(time.Minute)
+			orgGUIDs, err = resolver.OrgGUIDs([]string{"app-1", "app-2"})
+			Expect(err).To(MatchError("get app spaces: banana"))
+			Expect(orgGUIDs).To(BeEmpty())
+		})
+	})
+
+	Context("when getting a space fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSpaceReturns(nil, errors.New("banana"))
+		})
+
+		It("returns the error and caches nothing", func() {
+			_, err := resolver.OrgGUIDs([]string{"app-1"})
+			Expect(err).To(MatchError("get space: banana"))
+
+			fakeCCClient.GetSpaceReturns(&api.Space{OrgGUID: "org-1"}, nil)
+			orgGUIDs, err := resolver.OrgGUIDs([]string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orgGUIDs).To(Equal([]string{"org-1"}))
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"policy-server/asg"
+)
+
+//go:generate counterfeiter -o fakes/asg_importer.go --fake-name ASGImporter . asgImporter
+type asgImporter interface {
+	Import(dryRun bool) (asg.Report, error)
+}
+
+type ASGImport struct {
+	Importer      asgImporter
+	PolicyGuard   policyGuard
+	ErrorResponse errorResponse
+}
+
+func (h *ASGImport) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("import-asgs")
+
+	if !policyGuard.IsNetworkAdmin(h.PolicyGuard, getTokenData(req)) {
+		h.ErrorResponse.Forbidden(logger, w, nil, "not authorized: importing security groups failed")
+		return
+	}
+
+	report, err := h.Importer.Import(req.URL.Query().Get("dry_run") == "true")
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "asg import failed")
+		return
+	}
+
+	bytes, err := json.Marshal(report)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshal report failed") // not tested
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/asg"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ASG import handler", func() {
+	var (
+		request         *http.Request
+		handler         *handlers.ASGImport
+		resp            *httptest.ResponseRecorder
+		fakeImporter    *fakes.ASGImporter
+		fakePolicyGuard *fakes.PolicyGuard
+		logger          *lagertest.TestLogger
+		token           uaa_client.CheckTokenResponse
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "/networking/v1/external/asg_import", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeImporter = &fakes.ASGImporter{}
+		fakeImporter.ImportReturns(asg.Report{
+			Destinations: []asg.DestinationChange{{
+				Action:        asg.ActionCreate,
+				GUID:          "some-guid",
+				Name:          "asg-dns-1",
+				SecurityGroup: "dns",
+				Protocol:      "udp",
+				StartIP:       "10.0.0.2",
+				EndIP:         "10.0.0.2",
+				StartPort:     53,
+				EndPort:       53,
+			}},
+			EgressPolicies: []asg.PolicyChange{},
+			Skipped:        []asg.Skipped{},
+		}, nil)
+
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
+
+		logger = lagertest.NewTestLogger("test")
+
+		handler = &handlers.ASGImport{
+			Importer:      fakeImporter,
+			PolicyGuard:   fakePolicyGuard,
+			ErrorResponse: &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}},
+		}
+		resp = httptest.NewRecorder()
+
+		token = uaa_client.CheckTokenResponse{
+			Scope:    []string{"network.admin"},
+			UserID:   "some-user-id",
+			UserName: "some-user",
+		}
+	})
+
+	It("imports the security groups and returns the report", func() {
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakePolicyGuard.IsNetworkAdminArgsForCall(0)).To(Equal(token))
+		Expect(fakeImporter.ImportCallCount()).To(Equal(1))
+		Expect(fakeImporter.ImportArgsForCall(0)).To(BeFalse())
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{
+			"dry_run": false,
+			"destinations": [{
+				"action": "create",
+				"id": "some-guid",
+				"name": "asg-dns-1",
+				"security_group": "dns",
+				"protocol": "udp",
+				"start_ip": "10.0.0.2",
+				"end_ip": "10.0.0.2",
+				"start_port": 53,
+				"end_port": 53
+			}],
+			"egress_policies": [],
+			"skipped": []
+		}`))
+	})
+
+	Context("when dry_run is set", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "dry_run=true"
+		})
+
+		It("only reports what would be imported", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+			Expect(fakeImporter.ImportArgsForCall(0)).To(BeTrue())
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	It("returns an error when the import fails", func() {
+		fakeImporter.ImportReturns(asg.Report{}, errors.New("banana"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "asg import failed"}`))
+	})
+
+	Context("when the user is not network admin", func() {
+		BeforeEach(func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+		})
+
+		It("returns an error", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "not authorized: importing security groups failed"}`))
+			Expect(fakeImporter.ImportCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"policy-server/uaa_client"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cf-networking-helpers/middleware"
 	"code.cloudfoundry.org/lager"
@@ -34,6 +35,8 @@ type Authenticator struct {
 	Scopes        []string
 	ErrorResponse errorResponse
 	ScopeChecking bool
+
+	lock sync.RWMutex
 }
 
 func getLogger(req *http.Request) lager.Logger {
@@ -55,6 +58,18 @@ func getTokenData(req *http.Request) uaa_client.CheckTokenResponse {
 	return uaa_client.CheckTokenResponse{}
 }
 
+func (a *Authenticator) SetScopeChecking(scopeChecking bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.ScopeChecking = scopeChecking
+}
+
+func (a *Authenticator) scopeChecking() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.ScopeChecking
+}
+
 func (a *Authenticator) Wrap(handle http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		logger := getLogger(req)
@@ -76,7 +91,7 @@ func (a *Authenticator) Wrap(handle http.Handler) http.Handler {
 			return
 		}
 
-		if a.ScopeChecking && !isAuthorized(tokenData.Scope, a.Scopes) {
+		if a.scopeChecking() && !isAuthorized(tokenData.Scope, a.Scopes) {
 			err := errors.New(fmt.Sprintf("provided scopes %s do not include allowed scopes %s", tokenData.Scope, a.Scopes))
 			a.ErrorResponse.Forbidden(logger, w, err, err.Error())
 			return
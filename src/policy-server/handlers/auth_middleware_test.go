@@ -132,6 +132,18 @@ var _ = Describe("Authentication middleware", func() {
 			makeRequest()
 			Expect(unprotectedCallCount).To(Equal(1))
 		})
+
+		Context("when scope checking is turned back on", func() {
+			BeforeEach(func() {
+				authenticator.SetScopeChecking(true)
+			})
+
+			It("rejects the token without scopes", func() {
+				makeRequest()
+				Expect(unprotectedCallCount).To(Equal(0))
+				Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(1))
+			})
+		})
 	})
 
 	Context("when the header has a lowercase bearer token", func() {
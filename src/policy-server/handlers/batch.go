@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/batch_mapper.go --fake-name BatchMapper . batchMapper
+type batchMapper interface {
+	AsBatchRequest(bytes []byte) (api.BatchRequest, error)
+	AsEgressDestination(item []byte) (store.EgressDestination, error)
+	AsEgressPolicy(item []byte) (store.EgressPolicy, error)
+	AsBytes(payload *api.BatchPayload) ([]byte, error)
+}
+
+// readBatchRequest reads a batch request, which must have between one item
+// and maxBatchSize items. A maxBatchSize of 0 means no limit.
+func readBatchRequest(req *http.Request, mapper batchMapper, maxBatchSize int, items func(api.BatchRequest) int) (api.BatchRequest, error) {
+	requestBytes, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return api.BatchRequest{}, fmt.Errorf("error reading request: %s", err)
+	}
+	request, err := mapper.AsBatchRequest(requestBytes)
+	if err != nil {
+		return api.BatchRequest{}, fmt.Errorf("error parsing batch: %s", err)
+	}
+	count := items(request)
+	if count == 0 {
+		return api.BatchRequest{}, fmt.Errorf("batch has no items")
+	}
+	if maxBatchSize > 0 && count > maxBatchSize {
+		return api.BatchRequest{}, fmt.Errorf("batch of %d items exceeds the limit of %d", count, maxBatchSize)
+	}
+	return request, nil
+}
+
+// uniqueBatchIDs fails each empty or repeated id of a delete batch, and
+// returns the indexes of the others.
+func uniqueBatchIDs(payload *api.BatchPayload, ids []string) []int {
+	var indexes []int
+	seen := map[string]bool{}
+	for i, id := range ids {
+		switch {
+		case id == "":
+			payload.Fail(i, id, fmt.Errorf("missing id"))
+		case seen[id]:
+			payload.Fail(i, id, fmt.Errorf("id %s is given twice in the same batch", id))
+		default:
+			seen[id] = true
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// writeBatch writes the result of each item. It responds with successStatus
+// when every item succeeded, with 400 when an all_or_nothing batch was not
+// applied, and with 207 when a best_effort batch was only partly applied.
+func writeBatch(logger lager.Logger, w http.ResponseWriter, errorResponse errorResponse, mapper batchMapper, payload *api.BatchPayload, successStatus int) {
+	responseBytes, err := mapper.AsBytes(payload)
+	if err != nil {
+		errorResponse.InternalServerError(logger, w, err, "error serializing batch results")
+		return
+	}
+
+	status := successStatus
+	if payload.Failed > 0 {
+		if payload.Mode == api.BatchModeAllOrNothing {
+			status = http.StatusBadRequest
+		} else {
+			status = http.StatusMultiStatus
+		}
+	}
+	w.WriteHeader(status)
+	w.Write(responseBytes)
+}
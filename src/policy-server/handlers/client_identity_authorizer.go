@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	ClientRoleCell     = "cell"
+	ClientRoleOperator = "operator"
+	ClientRoleTagger   = "tagger"
+)
+
+const ClientRoleKey = Key("clientRole")
+
+// ClientIdentityAuthorizer maps the identity of a client certificate, its
+// common name or one of its subject alternative names, to a role. Requests
+// from identities without a role, or with a role the route does not allow,
+// are rejected and audited. When no roles are configured every client is
+// allowed, as before roles existed.
+type ClientIdentityAuthorizer struct {
+	Roles         map[string]string
+	ErrorResponse errorResponse
+}
+
+func (a *ClientIdentityAuthorizer) Wrap(handler http.Handler, allowedRoles ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if len(a.Roles) == 0 {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		logger := getLogger(req)
+		logger = logger.Session("client-identity")
+
+		identities := clientIdentities(req)
+		identity, role := a.roleFor(identities)
+		if role == "" {
+			err := errors.New("unknown client identity")
+			auditDenial(logger, req, identities, err)
+			a.ErrorResponse.Forbidden(logger, w, err, err.Error())
+			return
+		}
+
+		if !containsString(allowedRoles, role) {
+			err := fmt.Errorf("client role %s is not allowed", role)
+			auditDenial(logger, req, []string{identity}, err)
+			a.ErrorResponse.Forbidden(logger, w, err, err.Error())
+			return
+		}
+
+		req = req.WithContext(context.WithValue(req.Context(), ClientRoleKey, role))
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func (a *ClientIdentityAuthorizer) roleFor(identities []string) (string, string) {
+	for _, identity := range identities {
+		if role, ok := a.Roles[identity]; ok {
+			return identity, role
+		}
+	}
+	return "", ""
+}
+
+func auditDenial(logger lager.Logger, req *http.Request, identities []string, err error) {
+	logger.Info("audit-request-denied", lager.Data{
+		"identities": identities,
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"reason":     err.Error(),
+	})
+}
+
+func getClientRole(req *http.Request) string {
+	if role, ok := req.Context().Value(ClientRoleKey).(string); ok {
+		return role
+	}
+	return ""
+}
+
+// clientIdentities lists the common name and subject alternative names of the
+// verified client certificate.
+func clientIdentities(req *http.Request) []string {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+
+	cert := req.TLS.PeerCertificates[0]
+	var identities []string
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		identities = append(identities, ip.String())
+	}
+	return identities
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
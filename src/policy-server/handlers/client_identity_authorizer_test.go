@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ClientIdentityAuthorizer", func() {
+	var (
+		authorizer        *handlers.ClientIdentityAuthorizer
+		fakeErrorResponse *fakes.ErrorResponse
+		logger            *lagertest.TestLogger
+		request           *http.Request
+		resp              *httptest.ResponseRecorder
+		wrapped           http.Handler
+		servedRole        string
+		served            bool
+	)
+
+	withClientCert := func(commonName string, dnsNames ...string) {
+		request.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{
+				Subject:  pkix.Name{CommonName: commonName},
+				DNSNames: dnsNames,
+			}},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp = httptest.NewRecorder()
+		logger = lagertest.NewTestLogger("test")
+
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		authorizer = &handlers.ClientIdentityAuthorizer{
+			Roles: map[string]string{
+				"cell.service.cf.internal":     handlers.ClientRoleCell,
+				"operator.service.cf.internal": handlers.ClientRoleOperator,
+			},
+			ErrorResponse: fakeErrorResponse,
+		}
+
+		served = false
+		servedRole = ""
+		inner := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			served = true
+			servedRole, _ = req.Context().Value(handlers.ClientRoleKey).(string)
+		})
+		wrapped = authorizer.Wrap(inner, handlers.ClientRoleCell, handlers.ClientRoleOperator)
+	})
+
+	It("passes the role of the common name to the handler", func() {
+		withClientCert("operator.service.cf.internal")
+		MakeRequestWithLogger(wrapped.ServeHTTP, resp, request, logger)
+
+		Expect(served).To(BeTrue())
+		Expect(servedRole).To(Equal(handlers.ClientRoleOperator))
+	})
+
+	It("matches subject alternative names", func() {
+		withClientCert("some-cn", "cell.service.cf.internal")
+		MakeRequestWithLogger(wrapped.ServeHTTP, resp, request, logger)
+
+		Expect(served).To(BeTrue())
+		Expect(servedRole).To(Equal(handlers.ClientRoleCell))
+	})
+
+	Context("when the identity has no role", func() {
+		BeforeEach(func() {
+			withClientCert("stranger", "stranger.example.com")
+		})
+
+		It("rejects and audits the request", func() {
+			MakeRequestWithLogger(wrapped.ServeHTTP, resp, request, logger)
+
+			Expect(served).To(BeFalse())
+			Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(1))
+			_, _, err, _ := fakeErrorResponse.ForbiddenArgsForCall(0)
+			Expect(err).To(MatchError("unknown client identity"))
+			Expect(logger).To(gbytes.Say(`audit-request-denied.*"identities":\["stranger","stranger.example.com"\].*"path":"/networking/v1/internal/policies"`))
+		})
+	})
+
+	Context("when the role is not allowed on the route", func() {
+		BeforeEach(func() {
+			wrapped = authorizer.Wrap(http.NotFoundHandler(), handlers.ClientRoleOperator)
+			withClientCert("cell.service.cf.internal")
+		})
+
+		It("rejects the request", func() {
+			MakeRequestWithLogger(wrapped.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(1))
+			_, _, err, _ := fakeErrorResponse.ForbiddenArgsForCall(0)
+			Expect(err).To(MatchError("client role cell is not allowed"))
+		})
+	})
+
+	Context("when there is no client cert", func() {
+		It("rejects the request", func() {
+			MakeRequestWithLogger(wrapped.ServeHTTP, resp, request, logger)
+
+			Expect(served).To(BeFalse())
+			Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when no roles are configured", func() {
+		BeforeEach(func() {
+			authorizer.Roles = nil
+		})
+
+		It("allows every client", func() {
+			MakeRequestWithLogger(wrapped.ServeHTTP, resp, request, logger)
+
+			Expect(served).To(BeTrue())
+			Expect(servedRole).To(BeEmpty())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/tedsuo/rata"
 )
@@ -12,9 +13,17 @@ import (
 type CORSOptionsWrapper struct {
 	RataRoutes         rata.Routes
 	AllowedCORSDomains []string
+
+	lock sync.RWMutex
+}
+
+func (c *CORSOptionsWrapper) SetAllowedCORSDomains(allowedCORSDomains []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.AllowedCORSDomains = allowedCORSDomains
 }
 
-func (c CORSOptionsWrapper) Wrap(handler http.Handler) http.Handler {
+func (c *CORSOptionsWrapper) Wrap(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		if req.Method == "OPTIONS" {
 			methods := []string{}
@@ -40,17 +49,20 @@ func (c CORSOptionsWrapper) Wrap(handler http.Handler) http.Handler {
 	})
 }
 
-func (c CORSOptionsWrapper) matchRoute(rataPath, requestPath string) (bool, error) {
+func (c *CORSOptionsWrapper) matchRoute(rataPath, requestPath string) (bool, error) {
 	pathReplacer := regexp.MustCompile("\\:\\w+")
 	pathPattern := pathReplacer.ReplaceAll([]byte(rataPath), []byte("\\w+"))
 	return regexp.Match(fmt.Sprintf("^%s$", pathPattern), []byte(requestPath))
 }
 
-func (c CORSOptionsWrapper) allowedOrigin(requestOrigins []string) (bool, string) {
+func (c *CORSOptionsWrapper) allowedOrigin(requestOrigins []string) (bool, string) {
 	if len(requestOrigins) < 1 {
 		return false, ""
 	}
 	requestOrigin := requestOrigins[0]
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
 	for _, allowedOrigin := range c.AllowedCORSDomains {
 		if allowedOrigin == requestOrigin || allowedOrigin == "*" {
 			return true, allowedOrigin
@@ -83,6 +83,21 @@ var _ = Describe("CORS Option Handler", func() {
 			})
 		})
 
+		Context("when the allowed domains are changed", func() {
+			BeforeEach(func() {
+				corsOptionsWrapper.SetAllowedCORSDomains([]string{"https://bing.com"})
+			})
+
+			It("uses the new domains", func() {
+				resp := httptest.NewRecorder()
+				request, _ := http.NewRequest("GET", "/", nil)
+				request.Header.Add("origin", "https://bing.com")
+
+				corsOptionsHandler.ServeHTTP(resp, request)
+				Expect(resp.Header()["Access-Control-Allow-Origin"]).To(Equal([]string{"https://bing.com"}))
+			})
+		})
+
 		It("calls the wrapped handler", func() {
 			resp := httptest.NewRecorder()
 			request, _ := http.NewRequest("GET", "/networking/v1/external/policies", nil)
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// DestinationsBatchCreate creates many egress destinations at once, and
+// reports which of them were created and why the others were not.
+type DestinationsBatchCreate struct {
+	ErrorResponse              errorResponse
+	EgressDestinationStore     EgressDestinationStoreCreator
+	EgressDestinationLister    EgressDestinationStoreLister
+	EgressDestinationValidator egressDestinationValidator
+	Mapper                     batchMapper
+	PolicyGuard                policyGuard
+	MaxBatchSize               int
+}
+
+func (h *DestinationsBatchCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("destinations-batch-create")
+
+	if !policyGuard.IsNetworkAdmin(h.PolicyGuard, getTokenData(req)) {
+		h.ErrorResponse.Forbidden(logger, w, nil, "not authorized: creating egress destinations failed")
+		return
+	}
+
+	request, err := readBatchRequest(req, h.Mapper, h.MaxBatchSize, func(r api.BatchRequest) int { return len(r.Destinations) })
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, err.Error())
+		return
+	}
+
+	existingDestinations, err := h.EgressDestinationLister.All()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "error getting egress destinations")
+		return
+	}
+
+	payload := api.NewBatchPayload(request.Mode, len(request.Destinations))
+	var destinations []store.EgressDestination
+	var indexes []int
+	var warnings [][]string
+	for i, item := range request.Destinations {
+		destination, err := h.Mapper.AsEgressDestination(item)
+		if err != nil {
+			payload.Fail(i, "", err)
+			continue
+		}
+		// Earlier items of the batch count as existing, so that duplicates
+		// within the batch are caught too.
+		destinationWarnings, err := h.EgressDestinationValidator.ValidateEgressDestinations([]store.EgressDestination{destination}, existingDestinations)
+		if err != nil {
+			payload.Fail(i, "", fmt.Errorf("invalid egress destination: %s", err))
+			continue
+		}
+		existingDestinations = append(existingDestinations, destination)
+		destinations = append(destinations, destination)
+		indexes = append(indexes, i)
+		warnings = append(warnings, destinationWarnings)
+	}
+
+	if request.Mode == api.BatchModeAllOrNothing {
+		if payload.Failed == 0 {
+			created, err := h.EgressDestinationStore.Create(destinations)
+			if err != nil {
+				// A destination created since the names were validated can
+				// still take one of them.
+				taken, ok := err.(store.DestinationNameTakenError)
+				if !ok {
+					h.ErrorResponse.InternalServerError(logger, w, err, "error creating egress destinations")
+					return
+				}
+				for j, destination := range destinations {
+					if strings.EqualFold(destination.Name, taken.Name) {
+						payload.Fail(indexes[j], "", fmt.Errorf("invalid egress destination: %s", taken))
+					}
+				}
+				if payload.Failed == 0 {
+					h.ErrorResponse.InternalServerError(logger, w, err, "error creating egress destinations")
+					return
+				}
+			} else {
+				for j, destination := range created {
+					payload.Succeed(indexes[j], destination.GUID, api.BatchStatusCreated, warnings[j])
+				}
+			}
+		}
+	} else {
+		for j, destination := range destinations {
+			created, err := h.EgressDestinationStore.Create([]store.EgressDestination{destination})
+			if err != nil {
+				if _, ok := err.(store.DestinationNameTakenError); ok {
+					payload.Fail(indexes[j], "", fmt.Errorf("invalid egress destination: %s", err))
+					continue
+				}
+				logger.Error("create-egress-destination", err, lager.Data{"index": indexes[j]})
+				payload.Fail(indexes[j], "", errors.New("error creating egress destination"))
+				continue
+			}
+			payload.Succeed(indexes[j], created[0].GUID, api.BatchStatusCreated, warnings[j])
+		}
+	}
+
+	writeBatch(logger, w, h.ErrorResponse, h.Mapper, payload, http.StatusCreated)
+}
+
+// DestinationsBatchDelete deletes many egress destinations at once, and
+// reports which of them were deleted and why the others were not.
+type DestinationsBatchDelete struct {
+	ErrorResponse          errorResponse
+	EgressDestinationStore EgressDestinationStoreDeleter
+	Mapper                 batchMapper
+	PolicyGuard            policyGuard
+	MaxBatchSize           int
+}
+
+func (h *DestinationsBatchDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("destinations-batch-delete")
+
+	if !policyGuard.IsNetworkAdmin(h.PolicyGuard, getTokenData(req)) {
+		h.ErrorResponse.Forbidden(logger, w, nil, "not authorized: deleting egress destinations failed")
+		return
+	}
+
+	request, err := readBatchRequest(req, h.Mapper, h.MaxBatchSize, func(r api.BatchRequest) int { return len(r.IDs) })
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, err.Error())
+		return
+	}
+
+	payload := api.NewBatchPayload(request.Mode, len(request.IDs))
+	indexes := uniqueBatchIDs(payload, request.IDs)
+
+	if request.Mode == api.BatchModeAllOrNothing {
+		if payload.Failed == 0 {
+			_, err := h.EgressDestinationStore.Delete(request.IDs...)
+			if err != nil {
+				guid, ok := destinationErrorGUID(err)
+				if !ok {
+					h.ErrorResponse.InternalServerError(logger, w, err, "error deleting egress destinations")
+					return
+				}
+				for _, i := range indexes {
+					if request.IDs[i] == guid {
+						payload.Fail(i, guid, err)
+					}
+				}
+			} else {
+				for _, i := range indexes {
+					payload.Succeed(i, request.IDs[i], api.BatchStatusDeleted, nil)
+				}
+			}
+		}
+	} else {
+		for _, i := range indexes {
+			guid := request.IDs[i]
+			_, err := h.EgressDestinationStore.Delete(guid)
+			if err != nil {
+				if _, ok := destinationErrorGUID(err); !ok {
+					logger.Error("delete-egress-destination", err, lager.Data{"index": i})
+					err = errors.New("error deleting egress destination")
+				}
+				payload.Fail(i, guid, err)
+				continue
+			}
+			payload.Succeed(i, guid, api.BatchStatusDeleted, nil)
+		}
+	}
+
+	writeBatch(logger, w, h.ErrorResponse, h.Mapper, payload, http.StatusOK)
+}
+
+// destinationErrorGUID returns the destination that a delete failed on, for
+// the errors that are the caller's to fix.
+func destinationErrorGUID(err error) (string, bool) {
+	switch e := err.(type) {
+	case store.DestinationNotFoundError:
+		return e.GUID, true
+	case store.DestinationInUseError:
+		return e.GUID, true
+	}
+	return "", false
+}
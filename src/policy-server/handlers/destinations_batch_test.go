@@ -0,0 +1,381 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/api"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Destinations batch handlers", func() {
+	var (
+		request         *http.Request
+		resp            *httptest.ResponseRecorder
+		fakeMapper      *fakes.BatchMapper
+		fakePolicyGuard *fakes.PolicyGuard
+		logger          *lagertest.TestLogger
+		token           uaa_client.CheckTokenResponse
+		errorResponse   *httperror.ErrorResponse
+	)
+
+	writtenPayload := func() *api.BatchPayload {
+		Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
+		return fakeMapper.AsBytesArgsForCall(0)
+	}
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "/networking/v1/external/destinations/batch", bytes.NewBuffer([]byte("some-request")))
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeMapper = &fakes.BatchMapper{}
+		fakeMapper.AsBytesReturns([]byte(`{"some": "results"}`), nil)
+		fakeMapper.AsEgressDestinationStub = func(item []byte) (store.EgressDestination, error) {
+			if string(item) == `"bad"` {
+				return store.EgressDestination{}, errors.New("validate destinations: bad destination")
+			}
+			var name string
+			Expect(json.Unmarshal(item, &name)).To(Succeed())
+			return store.EgressDestination{Name: name}, nil
+		}
+
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
+
+		logger = lagertest.NewTestLogger("test")
+		errorResponse = &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}}
+		resp = httptest.NewRecorder()
+		token = uaa_client.CheckTokenResponse{
+			Scope:  []string{"network.admin"},
+			UserID: "some-user-id",
+		}
+	})
+
+	Describe("DestinationsBatchCreate", func() {
+		var (
+			handler       *handlers.DestinationsBatchCreate
+			fakeStore     *fakes.EgressDestinationStoreCreator
+			fakeLister    *fakes.EgressDestinationStoreLister
+			fakeValidator *fakes.EgressDestinationValidator
+		)
+
+		batchOf := func(mode string, items ...string) {
+			batch := api.BatchRequest{Mode: mode}
+			for _, item := range items {
+				batch.Destinations = append(batch.Destinations, json.RawMessage(item))
+			}
+			fakeMapper.AsBatchRequestReturns(batch, nil)
+		}
+
+		BeforeEach(func() {
+			fakeStore = &fakes.EgressDestinationStoreCreator{}
+			fakeStore.CreateStub = func(destinations []store.EgressDestination) ([]store.EgressDestination, error) {
+				var created []store.EgressDestination
+				for _, destination := range destinations {
+					destination.GUID = destination.Name + "-guid"
+					created = append(created, destination)
+				}
+				return created, nil
+			}
+			fakeLister = &fakes.EgressDestinationStoreLister{}
+			fakeLister.AllReturns([]store.EgressDestination{{Name: "existing"}}, nil)
+			fakeValidator = &fakes.EgressDestinationValidator{}
+			fakeValidator.ValidateEgressDestinationsStub = func(destinations, existing []store.EgressDestination) ([]string, error) {
+				for _, destination := range existing {
+					if destination.Name == destinations[0].Name {
+						return nil, errors.New("destination name " + destination.Name + " is already taken")
+					}
+				}
+				return []string{destinations[0].Name + " warning"}, nil
+			}
+
+			handler = &handlers.DestinationsBatchCreate{
+				ErrorResponse:              errorResponse,
+				EgressDestinationStore:     fakeStore,
+				EgressDestinationLister:    fakeLister,
+				EgressDestinationValidator: fakeValidator,
+				Mapper:                     fakeMapper,
+				PolicyGuard:                fakePolicyGuard,
+				MaxBatchSize:               3,
+			}
+			batchOf(api.BatchModeAllOrNothing, `"a"`, `"b"`)
+		})
+
+		It("creates every destination in one go", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(string(fakeMapper.AsBatchRequestArgsForCall(0))).To(Equal("some-request"))
+			Expect(fakeStore.CreateCallCount()).To(Equal(1))
+			Expect(fakeStore.CreateArgsForCall(0)).To(Equal([]store.EgressDestination{{Name: "a"}, {Name: "b"}}))
+
+			payload := writtenPayload()
+			Expect(payload.Succeeded).To(Equal(2))
+			Expect(payload.Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, ID: "a-guid", Status: api.BatchStatusCreated, Warnings: []string{"a warning"}},
+				{Index: 1, ID: "b-guid", Status: api.BatchStatusCreated, Warnings: []string{"b warning"}},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusCreated))
+			Expect(resp.Body.String()).To(MatchJSON(`{"some": "results"}`))
+		})
+
+		It("validates each destination against those before it", func() {
+			batchOf(api.BatchModeAllOrNothing, `"a"`, `"a"`)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			_, existing := fakeValidator.ValidateEgressDestinationsArgsForCall(1)
+			Expect(existing).To(Equal([]store.EgressDestination{{Name: "existing"}, {Name: "a"}}))
+			Expect(writtenPayload().Results[1]).To(Equal(api.BatchItemResult{
+				Index:  1,
+				Status: api.BatchStatusFailed,
+				Error:  "invalid egress destination: destination name a is already taken",
+			}))
+		})
+
+		Context("when all or nothing and a destination is invalid", func() {
+			BeforeEach(func() {
+				batchOf(api.BatchModeAllOrNothing, `"a"`, `"bad"`, `"existing"`)
+			})
+
+			It("creates none of them and says which failed", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(fakeStore.CreateCallCount()).To(Equal(0))
+				payload := writtenPayload()
+				Expect(payload.Failed).To(Equal(2))
+				Expect(payload.Results).To(Equal([]api.BatchItemResult{
+					{Index: 0, Status: api.BatchStatusSkipped},
+					{Index: 1, Status: api.BatchStatusFailed, Error: "validate destinations: bad destination"},
+					{Index: 2, Status: api.BatchStatusFailed, Error: "invalid egress destination: destination name existing is already taken"},
+				}))
+				Expect(resp.Code).To(Equal(http.StatusBadRequest))
+				Expect(resp.Body.String()).To(MatchJSON(`{"some": "results"}`))
+			})
+		})
+
+		Context("when best effort", func() {
+			BeforeEach(func() {
+				batchOf(api.BatchModeBestEffort, `"a"`, `"bad"`, `"c"`)
+			})
+
+			It("creates the valid destinations one by one", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(fakeStore.CreateCallCount()).To(Equal(2))
+				Expect(fakeStore.CreateArgsForCall(1)).To(Equal([]store.EgressDestination{{Name: "c"}}))
+				payload := writtenPayload()
+				Expect(payload.Succeeded).To(Equal(2))
+				Expect(payload.Failed).To(Equal(1))
+				Expect(payload.Results[2].ID).To(Equal("c-guid"))
+				Expect(resp.Code).To(Equal(http.StatusMultiStatus))
+			})
+
+			It("reports a destination that the store fails to create", func() {
+				fakeStore.CreateStub = nil
+				fakeStore.CreateReturns(nil, errors.New("db down"))
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(writtenPayload().Results[0]).To(Equal(api.BatchItemResult{
+					Index:  0,
+					Status: api.BatchStatusFailed,
+					Error:  "error creating egress destination",
+				}))
+				Expect(logger).To(gbytes.Say("create-egress-destination.*db down"))
+			})
+
+			It("reports a destination whose name was taken since it was validated", func() {
+				fakeStore.CreateStub = nil
+				fakeStore.CreateReturnsOnCall(0, nil, store.DestinationNameTakenError{Name: "a"})
+				fakeStore.CreateReturnsOnCall(1, []store.EgressDestination{{GUID: "c-guid", Name: "c"}}, nil)
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				payload := writtenPayload()
+				Expect(payload.Results[0]).To(Equal(api.BatchItemResult{
+					Index:  0,
+					Status: api.BatchStatusFailed,
+					Error:  "invalid egress destination: destination name a is already taken",
+				}))
+				Expect(payload.Results[2].ID).To(Equal("c-guid"))
+				Expect(resp.Code).To(Equal(http.StatusMultiStatus))
+			})
+		})
+
+		It("rejects a batch over the size limit", func() {
+			batchOf(api.BatchModeAllOrNothing, `"a"`, `"b"`, `"c"`, `"d"`)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "batch of 4 items exceeds the limit of 3"}`))
+			Expect(fakeStore.CreateCallCount()).To(Equal(0))
+		})
+
+		It("allows any batch size when the limit is 0", func() {
+			handler.MaxBatchSize = 0
+			batchOf(api.BatchModeAllOrNothing, `"a"`, `"b"`, `"c"`, `"d"`)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusCreated))
+		})
+
+		It("rejects an empty batch", func() {
+			batchOf(api.BatchModeAllOrNothing)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "batch has no items"}`))
+		})
+
+		It("rejects a batch that cannot be parsed", func() {
+			fakeMapper.AsBatchRequestReturns(api.BatchRequest{}, errors.New("mode must be all_or_nothing or best_effort"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error parsing batch: mode must be all_or_nothing or best_effort"}`))
+		})
+
+		It("returns an error when the store fails an all or nothing batch", func() {
+			fakeStore.CreateStub = nil
+			fakeStore.CreateReturns(nil, errors.New("db down"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error creating egress destinations"}`))
+		})
+
+		It("says which destination's name was taken since an all or nothing batch was validated", func() {
+			batchOf(api.BatchModeAllOrNothing, `"a"`, `"B"`)
+			fakeStore.CreateStub = nil
+			fakeStore.CreateReturns(nil, store.DestinationNameTakenError{Name: "b"})
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			payload := writtenPayload()
+			Expect(payload.Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, Status: api.BatchStatusSkipped},
+				{Index: 1, Status: api.BatchStatusFailed, Error: "invalid egress destination: destination name b is already taken"},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("returns an error when listing destinations fails", func() {
+			fakeLister.AllReturns(nil, errors.New("db down"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error getting egress destinations"}`))
+		})
+
+		It("forbids users who are not network admins", func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "not authorized: creating egress destinations failed"}`))
+			Expect(fakeMapper.AsBatchRequestCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("DestinationsBatchDelete", func() {
+		var (
+			handler   *handlers.DestinationsBatchDelete
+			fakeStore *fakes.EgressDestinationStoreDeleter
+		)
+
+		BeforeEach(func() {
+			fakeStore = &fakes.EgressDestinationStoreDeleter{}
+			handler = &handlers.DestinationsBatchDelete{
+				ErrorResponse:          errorResponse,
+				EgressDestinationStore: fakeStore,
+				Mapper:                 fakeMapper,
+				PolicyGuard:            fakePolicyGuard,
+				MaxBatchSize:           3,
+			}
+			fakeMapper.AsBatchRequestReturns(api.BatchRequest{Mode: api.BatchModeAllOrNothing, IDs: []string{"guid-1", "guid-2"}}, nil)
+		})
+
+		It("deletes every destination in one go", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteArgsForCall(0)).To(Equal([]string{"guid-1", "guid-2"}))
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, ID: "guid-1", Status: api.BatchStatusDeleted},
+				{Index: 1, ID: "guid-2", Status: api.BatchStatusDeleted},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		It("says which destination stopped an all or nothing batch", func() {
+			fakeStore.DeleteReturns(nil, store.DestinationInUseError{GUID: "guid-2"})
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, Status: api.BatchStatusSkipped},
+				{Index: 1, ID: "guid-2", Status: api.BatchStatusFailed, Error: "destination guid-2 is in use by egress policies"},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("fails repeated ids", func() {
+			fakeMapper.AsBatchRequestReturns(api.BatchRequest{Mode: api.BatchModeAllOrNothing, IDs: []string{"guid-1", "guid-1"}}, nil)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			Expect(writtenPayload().Results[1]).To(Equal(api.BatchItemResult{
+				Index:  1,
+				ID:     "guid-1",
+				Status: api.BatchStatusFailed,
+				Error:  "id guid-1 is given twice in the same batch",
+			}))
+		})
+
+		It("deletes each destination on its own when best effort", func() {
+			fakeMapper.AsBatchRequestReturns(api.BatchRequest{Mode: api.BatchModeBestEffort, IDs: []string{"guid-1", "guid-2", "guid-3"}}, nil)
+			fakeStore.DeleteStub = func(guids ...string) ([]store.EgressDestination, error) {
+				switch guids[0] {
+				case "guid-1":
+					return nil, store.DestinationNotFoundError{GUID: "guid-1"}
+				case "guid-2":
+					return nil, errors.New("db down")
+				}
+				return []store.EgressDestination{{GUID: guids[0]}}, nil
+			}
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(3))
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, ID: "guid-1", Status: api.BatchStatusFailed, Error: "destination guid-1 not found"},
+				{Index: 1, ID: "guid-2", Status: api.BatchStatusFailed, Error: "error deleting egress destination"},
+				{Index: 2, ID: "guid-3", Status: api.BatchStatusDeleted},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusMultiStatus))
+		})
+
+		It("returns an error when the store fails an all or nothing batch", func() {
+			fakeStore.DeleteReturns(nil, errors.New("db down"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error deleting egress destinations"}`))
+		})
+
+		It("forbids users who are not network admins", func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "not authorized: deleting egress destinations failed"}`))
+		})
+	})
+})
@@ -1,19 +1,23 @@
 package handlers
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"policy-server/api"
 	"policy-server/store"
 
 	"code.cloudfoundry.org/lager"
 )
 
 type DestinationsCreate struct {
-	ErrorResponse           errorResponse
-	EgressDestinationStore  EgressDestinationStoreCreator
-	EgressDestinationMapper EgressDestinationMarshaller
-	PolicyGuard             policyGuard
-	Logger                  lager.Logger
+	ErrorResponse              errorResponse
+	EgressDestinationStore     EgressDestinationStoreCreator
+	EgressDestinationLister    EgressDestinationStoreLister
+	EgressDestinationMapper    EgressDestinationMarshaller
+	EgressDestinationValidator egressDestinationValidator
+	PolicyGuard                policyGuard
+	Logger                     lager.Logger
 }
 
 //go:generate counterfeiter -o fakes/egress_destination_store_creator.go --fake-name EgressDestinationStoreCreator . EgressDestinationStoreCreator
@@ -21,6 +25,12 @@ type EgressDestinationStoreCreator interface {
 	Create([]store.EgressDestination) ([]store.EgressDestination, error)
 }
 
+//go:generate counterfeiter -o fakes/egress_destination_validator.go --fake-name EgressDestinationValidator . egressDestinationValidator
+type egressDestinationValidator interface {
+	ValidateEgressDestinations(destinations, existing []store.EgressDestination) ([]string, error)
+	Overlaps(destinations []store.EgressDestination) []api.DestinationOverlap
+}
+
 func (d *DestinationsCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	var destinations, createdDestinations []store.EgressDestination
 	var requestBytes, responseBytes []byte
@@ -39,15 +49,29 @@ func (d *DestinationsCreate) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	}
 	destinations, err = d.EgressDestinationMapper.AsEgressDestinations(requestBytes)
 	if err != nil {
-		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error parsing egress destinations")
+		d.ErrorResponse.BadRequest(d.Logger, w, err, fmt.Sprintf("error parsing egress destinations: %s", err))
+		return
+	}
+	existingDestinations, err := d.EgressDestinationLister.All()
+	if err != nil {
+		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error getting egress destinations")
+		return
+	}
+	warnings, err := d.EgressDestinationValidator.ValidateEgressDestinations(destinations, existingDestinations)
+	if err != nil {
+		d.ErrorResponse.BadRequest(d.Logger, w, err, fmt.Sprintf("invalid egress destinations: %s", err))
 		return
 	}
 	createdDestinations, err = d.EgressDestinationStore.Create(destinations)
 	if err != nil {
+		if _, ok := err.(store.DestinationNameTakenError); ok {
+			d.ErrorResponse.BadRequest(d.Logger, w, err, fmt.Sprintf("invalid egress destinations: %s", err))
+			return
+		}
 		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error creating egress destinations")
 		return
 	}
-	responseBytes, err = d.EgressDestinationMapper.AsBytes(createdDestinations)
+	responseBytes, err = d.EgressDestinationMapper.AsBytesWithWarnings(createdDestinations, warnings)
 	if err != nil {
 		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error serializing egress destinations")
 		return
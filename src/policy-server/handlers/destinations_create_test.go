@@ -38,6 +38,9 @@ var _ = Describe("Destinations create handler", func() {
 		resp                  *httptest.ResponseRecorder
 		fakeMetricsSender     *storeFakes.MetricsSender
 		fakeStore             *fakes.EgressDestinationStoreCreator
+		fakeLister            *fakes.EgressDestinationStoreLister
+		fakeValidator         *fakes.EgressDestinationValidator
+		existingDestinations  []store.EgressDestination
 		fakeMarshaller        *fakes.EgressDestinationMarshaller
 		fakePolicyGuard       *fakes.PolicyGuard
 		logger                *lagertest.TestLogger
@@ -78,8 +81,15 @@ var _ = Describe("Destinations create handler", func() {
 		fakeStore = &fakes.EgressDestinationStoreCreator{}
 		fakeStore.CreateReturns(createdDestinations, nil)
 
+		existingDestinations = []store.EgressDestination{{GUID: "existing-one"}}
+		fakeLister = &fakes.EgressDestinationStoreLister{}
+		fakeLister.AllReturns(existingDestinations, nil)
+
+		fakeValidator = &fakes.EgressDestinationValidator{}
+		fakeValidator.ValidateEgressDestinationsReturns([]string{}, nil)
+
 		fakeMarshaller = &fakes.EgressDestinationMarshaller{}
-		fakeMarshaller.AsBytesReturns(expectedResponseBody, nil)
+		fakeMarshaller.AsBytesWithWarningsReturns(expectedResponseBody, nil)
 
 		fakePolicyGuard = &fakes.PolicyGuard{}
 		fakePolicyGuard.IsNetworkAdminReturns(true)
@@ -99,11 +109,13 @@ var _ = Describe("Destinations create handler", func() {
 		}
 
 		handler = &handlers.DestinationsCreate{
-			ErrorResponse:           errorResponse,
-			EgressDestinationStore:  fakeStore,
-			EgressDestinationMapper: fakeMarshaller,
-			PolicyGuard:             fakePolicyGuard,
-			Logger:                  logger,
+			ErrorResponse:              errorResponse,
+			EgressDestinationStore:     fakeStore,
+			EgressDestinationLister:    fakeLister,
+			EgressDestinationMapper:    fakeMarshaller,
+			EgressDestinationValidator: fakeValidator,
+			PolicyGuard:                fakePolicyGuard,
+			Logger:                     logger,
 		}
 		resp = httptest.NewRecorder()
 
@@ -123,12 +135,48 @@ var _ = Describe("Destinations create handler", func() {
 
 		Expect(fakeStore.CreateCallCount()).To(Equal(1))
 		Expect(fakeStore.CreateArgsForCall(0)).To(Equal(requestedDestinations))
-		Expect(fakeMarshaller.AsBytesCallCount()).To(Equal(1))
-		Expect(fakeMarshaller.AsBytesArgsForCall(0)).To(Equal(createdDestinations))
+		Expect(fakeMarshaller.AsBytesWithWarningsCallCount()).To(Equal(1))
+		marshalledDestinations, _ := fakeMarshaller.AsBytesWithWarningsArgsForCall(0)
+		Expect(marshalledDestinations).To(Equal(createdDestinations))
 		Expect(resp.Code).To(Equal(http.StatusCreated))
 		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
 	})
 
+	It("validates the destinations against the existing ones", func() {
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakeValidator.ValidateEgressDestinationsCallCount()).To(Equal(1))
+		destinations, existing := fakeValidator.ValidateEgressDestinationsArgsForCall(0)
+		Expect(destinations).To(Equal(requestedDestinations))
+		Expect(existing).To(Equal(existingDestinations))
+	})
+
+	It("returns the warnings of the validator", func() {
+		fakeValidator.ValidateEgressDestinationsReturns([]string{"some-warning"}, nil)
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		_, warnings := fakeMarshaller.AsBytesWithWarningsArgsForCall(0)
+		Expect(warnings).To(Equal([]string{"some-warning"}))
+	})
+
+	It("returns a bad request when the destinations are invalid", func() {
+		fakeValidator.ValidateEgressDestinationsReturns(nil, errors.New("destination a overlaps forbidden range 10.255.0.0/16"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "invalid egress destinations: destination a overlaps forbidden range 10.255.0.0/16"}`))
+		Expect(fakeStore.CreateCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the existing destinations cannot be listed", func() {
+		fakeLister.AllReturns(nil, errors.New("can't list"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error getting egress destinations"}`))
+		Expect(fakeStore.CreateCallCount()).To(Equal(0))
+	})
+
 	It("returns an error request body can't be read", func() {
 		request.Body = &failingReader{}
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
@@ -144,15 +192,22 @@ var _ = Describe("Destinations create handler", func() {
 		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error creating egress destinations"}`))
 	})
 
+	It("returns a bad request when the store finds the name taken", func() {
+		fakeStore.CreateReturns(nil, store.DestinationNameTakenError{Name: "dest-1"})
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "invalid egress destinations: destination name dest-1 is already taken"}`))
+	})
+
 	It("returns an error when the mapper returns an error", func() {
 		fakeMarshaller.AsEgressDestinationsReturns(nil, errors.New("whoa"))
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
-		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
-		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error parsing egress destinations"}`))
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error parsing egress destinations: whoa"}`))
 	})
 
 	It("returns an error when the marshalling created destinations", func() {
-		fakeMarshaller.AsBytesReturns(nil, errors.New("can't serialize"))
+		fakeMarshaller.AsBytesWithWarningsReturns(nil, errors.New("can't serialize"))
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
 		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error serializing egress destinations"}`))
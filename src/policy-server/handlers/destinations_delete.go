@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type DestinationsDelete struct {
+	ErrorResponse           errorResponse
+	EgressDestinationStore  EgressDestinationStoreDeleter
+	EgressDestinationMapper EgressDestinationMarshaller
+	PolicyGuard             policyGuard
+	RataAdapter             rataAdapter
+	Logger                  lager.Logger
+}
+
+//go:generate counterfeiter -o fakes/egress_destination_store_deleter.go --fake-name EgressDestinationStoreDeleter . EgressDestinationStoreDeleter
+type EgressDestinationStoreDeleter interface {
+	Delete(guids ...string) ([]store.EgressDestination, error)
+}
+
+func (d *DestinationsDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	userToken := getTokenData(req)
+	if policyGuard.IsNetworkAdmin(d.PolicyGuard, userToken) == false {
+		d.ErrorResponse.Forbidden(d.Logger, w, nil, "not authorized: deleting egress destinations failed")
+		return
+	}
+
+	guid := d.RataAdapter.Param(req, "id")
+	deletedDestinations, err := d.EgressDestinationStore.Delete(guid)
+	if err != nil {
+		switch err.(type) {
+		case store.DestinationNotFoundError:
+			d.ErrorResponse.NotFound(d.Logger, w, err, err.Error())
+		case store.DestinationInUseError:
+			d.ErrorResponse.Conflict(d.Logger, w, err, err.Error())
+		default:
+			d.ErrorResponse.InternalServerError(d.Logger, w, err, "error deleting egress destination")
+		}
+		return
+	}
+
+	responseBytes, err := d.EgressDestinationMapper.AsBytes(deletedDestinations)
+	if err != nil {
+		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error serializing egress destinations")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
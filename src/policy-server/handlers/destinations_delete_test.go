@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Destinations delete handler", func() {
+	var (
+		expectedResponseBody []byte
+		request              *http.Request
+		handler              *handlers.DestinationsDelete
+		resp                 *httptest.ResponseRecorder
+		fakeStore            *fakes.EgressDestinationStoreDeleter
+		fakeMarshaller       *fakes.EgressDestinationMarshaller
+		fakePolicyGuard      *fakes.PolicyGuard
+		fakeRataAdapter      *fakes.RataAdapter
+		logger               *lagertest.TestLogger
+		deletedDestinations  []store.EgressDestination
+		token                uaa_client.CheckTokenResponse
+	)
+
+	BeforeEach(func() {
+		expectedResponseBody = []byte("some-response")
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/networking/v1/external/destinations/some-guid", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		deletedDestinations = []store.EgressDestination{{GUID: "some-guid"}}
+
+		fakeStore = &fakes.EgressDestinationStoreDeleter{}
+		fakeStore.DeleteReturns(deletedDestinations, nil)
+
+		fakeMarshaller = &fakes.EgressDestinationMarshaller{}
+		fakeMarshaller.AsBytesReturns(expectedResponseBody, nil)
+
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
+
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("some-guid")
+
+		logger = lagertest.NewTestLogger("test")
+
+		handler = &handlers.DestinationsDelete{
+			ErrorResponse:           &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}},
+			EgressDestinationStore:  fakeStore,
+			EgressDestinationMapper: fakeMarshaller,
+			PolicyGuard:             fakePolicyGuard,
+			RataAdapter:             fakeRataAdapter,
+			Logger:                  logger,
+		}
+		resp = httptest.NewRecorder()
+
+		token = uaa_client.CheckTokenResponse{
+			Scope:    []string{"network.admin"},
+			UserID:   "some-user-id",
+			UserName: "some-user",
+		}
+	})
+
+	It("deletes the destination and returns it", func() {
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakePolicyGuard.IsNetworkAdminArgsForCall(0)).To(Equal(token))
+
+		_, param := fakeRataAdapter.ParamArgsForCall(0)
+		Expect(param).To(Equal("id"))
+
+		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+		Expect(fakeStore.DeleteArgsForCall(0)).To(Equal([]string{"some-guid"}))
+		Expect(fakeMarshaller.AsBytesArgsForCall(0)).To(Equal(deletedDestinations))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+	})
+
+	It("returns not found when the destination does not exist", func() {
+		fakeStore.DeleteReturns(nil, store.DestinationNotFoundError{GUID: "some-guid"})
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+		Expect(resp.Code).To(Equal(http.StatusNotFound))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "destination some-guid not found"}`))
+	})
+
+	It("returns a conflict when egress policies use the destination", func() {
+		fakeStore.DeleteReturns(nil, store.DestinationInUseError{GUID: "some-guid"})
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+		Expect(resp.Code).To(Equal(http.StatusConflict))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "destination some-guid is in use by egress policies"}`))
+	})
+
+	It("returns an error when the store returns an error", func() {
+		fakeStore.DeleteReturns(nil, errors.New("can't delete"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error deleting egress destination"}`))
+	})
+
+	It("returns an error when the deleted destinations cannot be marshalled", func() {
+		fakeMarshaller.AsBytesReturns(nil, errors.New("can't serialize"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error serializing egress destinations"}`))
+	})
+
+	Context("when the user is not network admin", func() {
+		BeforeEach(func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+		})
+
+		It("returns an error", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "not authorized: deleting egress destinations failed"}`))
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -16,6 +16,7 @@ type DestinationsIndex struct {
 //go:generate counterfeiter -o fakes/egress_destination_marshaller.go --fake-name EgressDestinationMarshaller . EgressDestinationMarshaller
 type EgressDestinationMarshaller interface {
 	AsBytes(egressDestinations []store.EgressDestination) ([]byte, error)
+	AsBytesWithWarnings(egressDestinations []store.EgressDestination, warnings []string) ([]byte, error)
 	AsEgressDestinations([]byte) ([]store.EgressDestination, error)
 }
 
@@ -30,6 +31,9 @@ func (d *DestinationsIndex) ServeHTTP(w http.ResponseWriter, req *http.Request)
 		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error getting egress destinations")
 		return
 	}
+	if name := req.URL.Query().Get("name"); name != "" {
+		egressDestinations = filterByName(egressDestinations, name)
+	}
 	responseBytes, err := d.EgressDestinationMapper.AsBytes(egressDestinations)
 	if err != nil {
 		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error mapping egress destinations")
@@ -38,3 +42,13 @@ func (d *DestinationsIndex) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	w.WriteHeader(http.StatusOK)
 	w.Write(responseBytes)
 }
+
+func filterByName(egressDestinations []store.EgressDestination, name string) []store.EgressDestination {
+	filtered := []store.EgressDestination{}
+	for _, egressDestination := range egressDestinations {
+		if egressDestination.Name == name {
+			filtered = append(filtered, egressDestination)
+		}
+	}
+	return filtered
+}
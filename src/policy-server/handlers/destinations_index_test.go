@@ -78,6 +78,23 @@ var _ = Describe("Destinations index handler", func() {
 		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
 	})
 
+	Context("when a name is given", func() {
+		BeforeEach(func() {
+			fakeStore.AllReturns([]store.EgressDestination{{GUID: "one", Name: "db"}, {GUID: "two", Name: "dns"}}, nil)
+
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v1/external/destinations?name=dns", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the destination with that name", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeMapper.AsBytesArgsForCall(0)).To(Equal([]store.EgressDestination{{GUID: "two", Name: "dns"}}))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+	})
+
 	It("returns an error when the store returns an error", func() {
 		fakeStore.AllReturns(nil, errors.New("things went askew"))
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
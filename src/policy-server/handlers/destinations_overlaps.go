@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"policy-server/api"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type DestinationsOverlaps struct {
+	ErrorResponse              errorResponse
+	EgressDestinationStore     EgressDestinationStoreLister
+	EgressDestinationValidator egressDestinationValidator
+	PolicyGuard                policyGuard
+	Logger                     lager.Logger
+}
+
+func (d *DestinationsOverlaps) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !policyGuard.IsNetworkAdmin(d.PolicyGuard, getTokenData(req)) {
+		d.ErrorResponse.Forbidden(d.Logger, w, nil, "not authorized: listing egress destination overlaps failed")
+		return
+	}
+
+	destinations, err := d.EgressDestinationStore.All()
+	if err != nil {
+		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error getting egress destinations")
+		return
+	}
+
+	overlaps := d.EgressDestinationValidator.Overlaps(destinations)
+	bytes, err := json.Marshal(api.DestinationOverlapsPayload{
+		TotalOverlaps: len(overlaps),
+		Overlaps:      overlaps,
+	})
+	if err != nil {
+		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error serializing egress destination overlaps") // not tested
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/api"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Destinations overlaps handler", func() {
+	var (
+		allDestinations []store.EgressDestination
+		request         *http.Request
+		handler         *handlers.DestinationsOverlaps
+		resp            *httptest.ResponseRecorder
+		fakeStore       *fakes.EgressDestinationStoreLister
+		fakeValidator   *fakes.EgressDestinationValidator
+		fakePolicyGuard *fakes.PolicyGuard
+		logger          *lagertest.TestLogger
+		token           uaa_client.CheckTokenResponse
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/destinations/overlaps", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		allDestinations = []store.EgressDestination{{GUID: "one"}, {GUID: "two"}}
+		fakeStore = &fakes.EgressDestinationStoreLister{}
+		fakeStore.AllReturns(allDestinations, nil)
+
+		fakeValidator = &fakes.EgressDestinationValidator{}
+		fakeValidator.OverlapsReturns([]api.DestinationOverlap{{
+			Duplicate:    true,
+			Destinations: []api.EgressDestination{{GUID: "one"}, {GUID: "two"}},
+		}})
+
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
+
+		logger = lagertest.NewTestLogger("test")
+
+		handler = &handlers.DestinationsOverlaps{
+			ErrorResponse:              &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}},
+			EgressDestinationStore:     fakeStore,
+			EgressDestinationValidator: fakeValidator,
+			PolicyGuard:                fakePolicyGuard,
+			Logger:                     logger,
+		}
+		resp = httptest.NewRecorder()
+
+		token = uaa_client.CheckTokenResponse{
+			Scope:  []string{"network.admin"},
+			UserID: "some-user-id",
+		}
+	})
+
+	It("returns the overlaps between all destinations", func() {
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakeValidator.OverlapsArgsForCall(0)).To(Equal(allDestinations))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.String()).To(MatchJSON(`{
+			"total_overlaps": 1,
+			"overlaps": [{
+				"duplicate": true,
+				"destinations": [
+					{"id": "one", "protocol": "", "ips": null},
+					{"id": "two", "protocol": "", "ips": null}
+				]
+			}]
+		}`))
+	})
+
+	It("returns an error when the store returns an error", func() {
+		fakeStore.AllReturns(nil, errors.New("can't list"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "error getting egress destinations"}`))
+	})
+
+	Context("when the user is not network admin", func() {
+		BeforeEach(func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+		})
+
+		It("returns an error", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "not authorized: listing egress destination overlaps failed"}`))
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type DestinationsShow struct {
+	ErrorResponse           errorResponse
+	EgressDestinationStore  EgressDestinationStoreLister
+	EgressDestinationMapper EgressDestinationMarshaller
+	RataAdapter             rataAdapter
+	Logger                  lager.Logger
+}
+
+func (d *DestinationsShow) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	guid := d.RataAdapter.Param(req, "id")
+	egressDestinations, err := d.EgressDestinationStore.All()
+	if err != nil {
+		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error getting egress destinations")
+		return
+	}
+
+	for _, egressDestination := range egressDestinations {
+		if egressDestination.GUID != guid {
+			continue
+		}
+		responseBytes, err := d.EgressDestinationMapper.AsBytes([]store.EgressDestination{egressDestination})
+		if err != nil {
+			d.ErrorResponse.InternalServerError(d.Logger, w, err, "error mapping egress destinations")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBytes)
+		return
+	}
+
+	err = store.DestinationNotFoundError{GUID: guid}
+	d.ErrorResponse.NotFound(d.Logger, w, err, err.Error())
+}
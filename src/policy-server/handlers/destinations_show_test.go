@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Destinations show handler", func() {
+	var (
+		expectedResponseBody []byte
+		request              *http.Request
+		handler              *handlers.DestinationsShow
+		resp                 *httptest.ResponseRecorder
+		fakeStore            *fakes.EgressDestinationStoreLister
+		fakeMarshaller       *fakes.EgressDestinationMarshaller
+		fakeRataAdapter      *fakes.RataAdapter
+		logger               *lagertest.TestLogger
+		token                uaa_client.CheckTokenResponse
+	)
+
+	BeforeEach(func() {
+		expectedResponseBody = []byte("some-response")
+
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/destinations/some-guid", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeStore = &fakes.EgressDestinationStoreLister{}
+		fakeStore.AllReturns([]store.EgressDestination{{GUID: "other-guid"}, {GUID: "some-guid", Name: "db"}}, nil)
+
+		fakeMarshaller = &fakes.EgressDestinationMarshaller{}
+		fakeMarshaller.AsBytesReturns(expectedResponseBody, nil)
+
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("some-guid")
+
+		logger = lagertest.NewTestLogger("test")
+
+		handler = &handlers.DestinationsShow{
+			ErrorResponse:           &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}},
+			EgressDestinationStore:  fakeStore,
+			EgressDestinationMapper: fakeMarshaller,
+			RataAdapter:             fakeRataAdapter,
+			Logger:                  logger,
+		}
+		resp = httptest.NewRecorder()
+
+		token = uaa_client.CheckTokenResponse{
+			Scope:  []string{"network.admin"},
+			UserID: "some-user-id",
+		}
+	})
+
+	It("returns the destination", func() {
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		_, param := fakeRataAdapter.ParamArgsForCall(0)
+		Expect(param).To(Equal("id"))
+		Expect(fakeMarshaller.AsBytesArgsForCall(0)).To(Equal([]store.EgressDestination{{GUID: "some-guid", Name: "db"}}))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+	})
+
+	It("returns a 404 when the destination does not exist", func() {
+		fakeRataAdapter.ParamReturns("missing-guid")
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusNotFound))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "destination missing-guid not found"}`))
+	})
+
+	It("returns an error when the store returns an error", func() {
+		fakeStore.AllReturns(nil, errors.New("can't list"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "error getting egress destinations"}`))
+	})
+
+	It("returns an error when the mapper returns an error", func() {
+		fakeMarshaller.AsBytesReturns(nil, errors.New("can't map"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "error mapping egress destinations"}`))
+	})
+})
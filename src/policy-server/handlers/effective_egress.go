@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"policy-server/store"
+)
+
+//go:generate counterfeiter -o fakes/effective_egress_mapper.go --fake-name EffectiveEgressMapper . effectiveEgressMapper
+type effectiveEgressMapper interface {
+	AsBytes(appGUID string, policies []store.EgressPolicy) ([]byte, error)
+	AsCSV(appGUID string, policies []store.EgressPolicy) ([]byte, error)
+}
+
+// EffectiveEgress reports every egress policy that applies to an app, through
+// the app itself, its space, its org or the default source, merged into
+// rules.
+type EffectiveEgress struct {
+	ErrorResponse errorResponse
+	EgressStore   egressPolicyStore
+	Mapper        effectiveEgressMapper
+	CCClient      ccClient
+	UAAClient     uaaClient
+	PolicyGuard   policyGuard
+	RataAdapter   rataAdapter
+}
+
+func (h *EffectiveEgress) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("effective-egress")
+
+	if !policyGuard.IsNetworkAdmin(h.PolicyGuard, getTokenData(req)) {
+		h.ErrorResponse.Forbidden(logger, w, nil, "not authorized: reporting effective egress failed")
+		return
+	}
+
+	appGUID := h.RataAdapter.Param(req, "guid")
+	format := req.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		h.ErrorResponse.BadRequest(logger, w, fmt.Errorf("invalid format: %s", format), "format must be json or csv")
+		return
+	}
+
+	sourceGUIDs, err := h.sourceGUIDs(appGUID)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "error getting app")
+		return
+	}
+	if sourceGUIDs == nil {
+		err = fmt.Errorf("app %s not found", appGUID)
+		h.ErrorResponse.NotFound(logger, w, err, err.Error())
+		return
+	}
+
+	egressPolicies, err := h.EgressStore.GetBySourceGuids(sourceGUIDs)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "error getting egress policies")
+		return
+	}
+
+	var responseBytes []byte
+	if format == "csv" {
+		responseBytes, err = h.Mapper.AsCSV(appGUID, egressPolicies)
+		w.Header().Set("Content-Type", "text/csv")
+	} else {
+		responseBytes, err = h.Mapper.AsBytes(appGUID, egressPolicies)
+	}
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "error mapping egress policies")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+// sourceGUIDs returns the app, space and org guids of an app, or nil when the
+// app does not exist.
+func (h *EffectiveEgress) sourceGUIDs(appGUID string) ([]string, error) {
+	token, err := h.UAAClient.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("get uaa token: %s", err)
+	}
+
+	appSpaces, err := h.CCClient.GetAppSpaces(token, []string{appGUID})
+	if err != nil {
+		return nil, fmt.Errorf("get app spaces: %s", err)
+	}
+	spaceGUID, ok := appSpaces[appGUID]
+	if !ok {
+		return nil, nil
+	}
+
+	space, err := h.CCClient.GetSpace(token, spaceGUID)
+	if err != nil {
+		return nil, fmt.Errorf("get space: %s", err)
+	}
+	if space == nil {
+		return nil, errors.New("get space: space not found")
+	}
+	return []string{appGUID, spaceGUID, space.OrgGUID}, nil
+}
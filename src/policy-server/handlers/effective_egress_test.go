@@ -0,0 +1,157 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/api"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Effective egress handler", func() {
+	var (
+		request         *http.Request
+		handler         *handlers.EffectiveEgress
+		resp            *httptest.ResponseRecorder
+		fakeStore       *fakes.EgressPolicyStore
+		fakeMapper      *fakes.EffectiveEgressMapper
+		fakeCCClient    *fakes.CCClient
+		fakeUAAClient   *fakes.UAAClient
+		fakePolicyGuard *fakes.PolicyGuard
+		fakeRataAdapter *fakes.RataAdapter
+		logger          *lagertest.TestLogger
+		token           uaa_client.CheckTokenResponse
+		egressPolicies  []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/apps/some-app-guid/effective_egress", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		egressPolicies = []store.EgressPolicy{{ID: "some-policy-guid"}}
+		fakeStore = &fakes.EgressPolicyStore{}
+		fakeStore.GetBySourceGuidsReturns(egressPolicies, nil)
+
+		fakeMapper = &fakes.EffectiveEgressMapper{}
+		fakeMapper.AsBytesReturns([]byte(`{"some": "json"}`), nil)
+		fakeMapper.AsCSVReturns([]byte("some,csv\n"), nil)
+
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+
+		fakeCCClient = &fakes.CCClient{}
+		fakeCCClient.GetAppSpacesReturns(map[string]string{"some-app-guid": "some-space-guid"}, nil)
+		fakeCCClient.GetSpaceReturns(&api.Space{Name: "some-space", OrgGUID: "some-org-guid"}, nil)
+
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
+
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("some-app-guid")
+
+		logger = lagertest.NewTestLogger("test")
+
+		handler = &handlers.EffectiveEgress{
+			ErrorResponse: &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}},
+			EgressStore:   fakeStore,
+			Mapper:        fakeMapper,
+			CCClient:      fakeCCClient,
+			UAAClient:     fakeUAAClient,
+			PolicyGuard:   fakePolicyGuard,
+			RataAdapter:   fakeRataAdapter,
+		}
+		resp = httptest.NewRecorder()
+
+		token = uaa_client.CheckTokenResponse{
+			Scope:  []string{"network.admin"},
+			UserID: "some-user-id",
+		}
+	})
+
+	It("reports the egress policies of the app, its space and its org", func() {
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		_, param := fakeRataAdapter.ParamArgsForCall(0)
+		Expect(param).To(Equal("guid"))
+
+		ccToken, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
+		Expect(ccToken).To(Equal("some-token"))
+		Expect(appGUIDs).To(Equal([]string{"some-app-guid"}))
+		_, spaceGUID := fakeCCClient.GetSpaceArgsForCall(0)
+		Expect(spaceGUID).To(Equal("some-space-guid"))
+
+		Expect(fakeStore.GetBySourceGuidsArgsForCall(0)).To(Equal([]string{"some-app-guid", "some-space-guid", "some-org-guid"}))
+		appGUID, policies := fakeMapper.AsBytesArgsForCall(0)
+		Expect(appGUID).To(Equal("some-app-guid"))
+		Expect(policies).To(Equal(egressPolicies))
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.String()).To(MatchJSON(`{"some": "json"}`))
+	})
+
+	It("reports as csv when asked to", func() {
+		request.URL.RawQuery = "format=csv"
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakeMapper.AsCSVCallCount()).To(Equal(1))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("text/csv"))
+		Expect(resp.Body.String()).To(Equal("some,csv\n"))
+	})
+
+	It("rejects an unknown format", func() {
+		request.URL.RawQuery = "format=xml"
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "format must be json or csv"}`))
+	})
+
+	It("returns a 404 when the app does not exist", func() {
+		fakeCCClient.GetAppSpacesReturns(map[string]string{}, nil)
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusNotFound))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "app some-app-guid not found"}`))
+		Expect(fakeStore.GetBySourceGuidsCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when cloud controller returns an error", func() {
+		fakeCCClient.GetSpaceReturns(nil, errors.New("cc down"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "error getting app"}`))
+	})
+
+	It("returns an error when the store returns an error", func() {
+		fakeStore.GetBySourceGuidsReturns(nil, errors.New("db down"))
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Body.String()).To(MatchJSON(`{"error": "error getting egress policies"}`))
+	})
+
+	Context("when the user is not network admin", func() {
+		BeforeEach(func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+		})
+
+		It("returns an error", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "not authorized: reporting effective egress failed"}`))
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/egress_policy_batch_store.go --fake-name EgressPolicyBatchStore . egressPolicyBatchStore
+type egressPolicyBatchStore interface {
+	Create(egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error)
+	DeleteByGUIDs(guids ...string) error
+}
+
+// EgressPoliciesBatchCreate creates many egress policies at once, and reports
+// which of them were created and why the others were not.
+type EgressPoliciesBatchCreate struct {
+	ErrorResponse     errorResponse
+	Store             egressPolicyBatchStore
+	DestinationLister EgressDestinationStoreLister
+	Mapper            batchMapper
+	PolicyGuard       policyGuard
+	MaxBatchSize      int
+}
+
+func (h *EgressPoliciesBatchCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("egress-policies-batch-create")
+
+	if !policyGuard.IsNetworkAdmin(h.PolicyGuard, getTokenData(req)) {
+		h.ErrorResponse.Forbidden(logger, w, nil, "not authorized: creating egress policies failed")
+		return
+	}
+
+	request, err := readBatchRequest(req, h.Mapper, h.MaxBatchSize, func(r api.BatchRequest) int { return len(r.EgressPolicies) })
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, err.Error())
+		return
+	}
+
+	destinations, err := h.DestinationLister.All()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "error getting egress destinations")
+		return
+	}
+
+	payload := api.NewBatchPayload(request.Mode, len(request.EgressPolicies))
+	var egressPolicies []store.EgressPolicy
+	var indexes []int
+	for i, item := range request.EgressPolicies {
+		egressPolicy, err := h.Mapper.AsEgressPolicy(item)
+		if err != nil {
+			payload.Fail(i, "", err)
+			continue
+		}
+		resolved := []store.EgressPolicy{egressPolicy}
+		err = resolveDestinations(resolved, destinations, true)
+		if err != nil {
+			payload.Fail(i, "", err)
+			continue
+		}
+		egressPolicies = append(egressPolicies, resolved[0])
+		indexes = append(indexes, i)
+	}
+
+	if request.Mode == api.BatchModeAllOrNothing {
+		if payload.Failed == 0 {
+			created, err := h.Store.Create(egressPolicies)
+			if err != nil {
+				h.ErrorResponse.InternalServerError(logger, w, err, "error creating egress policies")
+				return
+			}
+			for j, egressPolicy := range created {
+				payload.Succeed(indexes[j], egressPolicy.ID, api.BatchStatusCreated, nil)
+			}
+		}
+	} else {
+		for j, egressPolicy := range egressPolicies {
+			created, err := h.Store.Create([]store.EgressPolicy{egressPolicy})
+			if err != nil {
+				logger.Error("create-egress-policy", err, lager.Data{"index": indexes[j]})
+				payload.Fail(indexes[j], "", errors.New("error creating egress policy"))
+				continue
+			}
+			payload.Succeed(indexes[j], created[0].ID, api.BatchStatusCreated, nil)
+		}
+	}
+
+	writeBatch(logger, w, h.ErrorResponse, h.Mapper, payload, http.StatusCreated)
+}
+
+// EgressPoliciesBatchDelete deletes many egress policies at once, by id, and
+// reports which of them were deleted and why the others were not. Their
+// destinations are left in place.
+type EgressPoliciesBatchDelete struct {
+	ErrorResponse errorResponse
+	Store         egressPolicyBatchStore
+	Mapper        batchMapper
+	PolicyGuard   policyGuard
+	MaxBatchSize  int
+}
+
+func (h *EgressPoliciesBatchDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("egress-policies-batch-delete")
+
+	if !policyGuard.IsNetworkAdmin(h.PolicyGuard, getTokenData(req)) {
+		h.ErrorResponse.Forbidden(logger, w, nil, "not authorized: deleting egress policies failed")
+		return
+	}
+
+	request, err := readBatchRequest(req, h.Mapper, h.MaxBatchSize, func(r api.BatchRequest) int { return len(r.IDs) })
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, err.Error())
+		return
+	}
+
+	payload := api.NewBatchPayload(request.Mode, len(request.IDs))
+	indexes := uniqueBatchIDs(payload, request.IDs)
+
+	if request.Mode == api.BatchModeAllOrNothing {
+		if payload.Failed == 0 {
+			err := h.Store.DeleteByGUIDs(request.IDs...)
+			if notFound, ok := err.(store.EgressPolicyNotFoundError); ok {
+				for _, i := range indexes {
+					if request.IDs[i] == notFound.GUID {
+						payload.Fail(i, notFound.GUID, err)
+					}
+				}
+			} else if err != nil {
+				h.ErrorResponse.InternalServerError(logger, w, err, "error deleting egress policies")
+				return
+			} else {
+				for _, i := range indexes {
+					payload.Succeed(i, request.IDs[i], api.BatchStatusDeleted, nil)
+				}
+			}
+		}
+	} else {
+		for _, i := range indexes {
+			guid := request.IDs[i]
+			err := h.Store.DeleteByGUIDs(guid)
+			if err != nil {
+				if _, ok := err.(store.EgressPolicyNotFoundError); !ok {
+					logger.Error("delete-egress-policy", err, lager.Data{"index": i})
+					err = errors.New("error deleting egress policy")
+				}
+				payload.Fail(i, guid, err)
+				continue
+			}
+			payload.Succeed(i, guid, api.BatchStatusDeleted, nil)
+		}
+	}
+
+	writeBatch(logger, w, h.ErrorResponse, h.Mapper, payload, http.StatusOK)
+}
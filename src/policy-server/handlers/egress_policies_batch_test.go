@@ -0,0 +1,247 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/api"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Egress policies batch handlers", func() {
+	var (
+		request         *http.Request
+		resp            *httptest.ResponseRecorder
+		fakeStore       *fakes.EgressPolicyBatchStore
+		fakeMapper      *fakes.BatchMapper
+		fakePolicyGuard *fakes.PolicyGuard
+		logger          *lagertest.TestLogger
+		token           uaa_client.CheckTokenResponse
+		errorResponse   *httperror.ErrorResponse
+	)
+
+	writtenPayload := func() *api.BatchPayload {
+		Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
+		return fakeMapper.AsBytesArgsForCall(0)
+	}
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "/networking/v1/external/egress_policies/batch", bytes.NewBuffer([]byte("some-request")))
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeStore = &fakes.EgressPolicyBatchStore{}
+		fakeMapper = &fakes.BatchMapper{}
+		fakeMapper.AsBytesReturns([]byte(`{"some": "results"}`), nil)
+
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
+
+		logger = lagertest.NewTestLogger("test")
+		errorResponse = &httperror.ErrorResponse{MetricsSender: &storeFakes.MetricsSender{}}
+		resp = httptest.NewRecorder()
+		token = uaa_client.CheckTokenResponse{
+			Scope:  []string{"network.admin"},
+			UserID: "some-user-id",
+		}
+	})
+
+	Describe("EgressPoliciesBatchCreate", func() {
+		var (
+			handler    *handlers.EgressPoliciesBatchCreate
+			fakeLister *fakes.EgressDestinationStoreLister
+		)
+
+		batchOf := func(mode string, items ...string) {
+			batch := api.BatchRequest{Mode: mode}
+			for _, item := range items {
+				batch.EgressPolicies = append(batch.EgressPolicies, json.RawMessage(item))
+			}
+			fakeMapper.AsBatchRequestReturns(batch, nil)
+		}
+
+		BeforeEach(func() {
+			fakeMapper.AsEgressPolicyStub = func(item []byte) (store.EgressPolicy, error) {
+				if string(item) == `"bad"` {
+					return store.EgressPolicy{}, errors.New("validate egress policies: missing egress source")
+				}
+				var name string
+				Expect(json.Unmarshal(item, &name)).To(Succeed())
+				return store.EgressPolicy{
+					Source:      store.EgressSource{ID: "some-app-guid"},
+					Destination: store.EgressDestination{Name: name},
+				}, nil
+			}
+			fakeStore.CreateStub = func(policies []store.EgressPolicy) ([]store.EgressPolicy, error) {
+				var created []store.EgressPolicy
+				for _, policy := range policies {
+					policy.ID = policy.Destination.GUID + "-policy"
+					created = append(created, policy)
+				}
+				return created, nil
+			}
+			fakeLister = &fakes.EgressDestinationStoreLister{}
+			fakeLister.AllReturns([]store.EgressDestination{
+				{GUID: "db-guid", Name: "db"},
+				{GUID: "queue-guid", Name: "queue"},
+			}, nil)
+
+			handler = &handlers.EgressPoliciesBatchCreate{
+				ErrorResponse:     errorResponse,
+				Store:             fakeStore,
+				DestinationLister: fakeLister,
+				Mapper:            fakeMapper,
+				PolicyGuard:       fakePolicyGuard,
+				MaxBatchSize:      3,
+			}
+			batchOf(api.BatchModeAllOrNothing, `"db"`, `"queue"`)
+		})
+
+		It("creates every egress policy in one go, resolving destination names", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.CreateCallCount()).To(Equal(1))
+			policies := fakeStore.CreateArgsForCall(0)
+			Expect(policies).To(HaveLen(2))
+			Expect(policies[1].Destination.GUID).To(Equal("queue-guid"))
+
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, ID: "db-guid-policy", Status: api.BatchStatusCreated},
+				{Index: 1, ID: "queue-guid-policy", Status: api.BatchStatusCreated},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusCreated))
+			Expect(resp.Body.String()).To(MatchJSON(`{"some": "results"}`))
+		})
+
+		It("creates none of them when all or nothing and one is invalid", func() {
+			batchOf(api.BatchModeAllOrNothing, `"db"`, `"bad"`, `"missing"`)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.CreateCallCount()).To(Equal(0))
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, Status: api.BatchStatusSkipped},
+				{Index: 1, Status: api.BatchStatusFailed, Error: "validate egress policies: missing egress source"},
+				{Index: 2, Status: api.BatchStatusFailed, Error: "destination named missing not found"},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("creates the valid egress policies one by one when best effort", func() {
+			batchOf(api.BatchModeBestEffort, `"db"`, `"missing"`, `"queue"`)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.CreateCallCount()).To(Equal(2))
+			payload := writtenPayload()
+			Expect(payload.Succeeded).To(Equal(2))
+			Expect(payload.Failed).To(Equal(1))
+			Expect(payload.Results[2].ID).To(Equal("queue-guid-policy"))
+			Expect(resp.Code).To(Equal(http.StatusMultiStatus))
+		})
+
+		It("rejects a batch over the size limit", func() {
+			batchOf(api.BatchModeAllOrNothing, `"db"`, `"db"`, `"db"`, `"db"`)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "batch of 4 items exceeds the limit of 3"}`))
+		})
+
+		It("returns an error when the store fails an all or nothing batch", func() {
+			fakeStore.CreateStub = nil
+			fakeStore.CreateReturns(nil, errors.New("db down"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error creating egress policies"}`))
+		})
+
+		It("forbids users who are not network admins", func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "not authorized: creating egress policies failed"}`))
+		})
+	})
+
+	Describe("EgressPoliciesBatchDelete", func() {
+		var handler *handlers.EgressPoliciesBatchDelete
+
+		BeforeEach(func() {
+			handler = &handlers.EgressPoliciesBatchDelete{
+				ErrorResponse: errorResponse,
+				Store:         fakeStore,
+				Mapper:        fakeMapper,
+				PolicyGuard:   fakePolicyGuard,
+				MaxBatchSize:  3,
+			}
+			fakeMapper.AsBatchRequestReturns(api.BatchRequest{Mode: api.BatchModeAllOrNothing, IDs: []string{"policy-1", "policy-2"}}, nil)
+		})
+
+		It("deletes every egress policy in one go", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.DeleteByGUIDsCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteByGUIDsArgsForCall(0)).To(Equal([]string{"policy-1", "policy-2"}))
+			Expect(writtenPayload().Succeeded).To(Equal(2))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		It("says which egress policy stopped an all or nothing batch", func() {
+			fakeStore.DeleteByGUIDsReturns(store.EgressPolicyNotFoundError{GUID: "policy-2"})
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, Status: api.BatchStatusSkipped},
+				{Index: 1, ID: "policy-2", Status: api.BatchStatusFailed, Error: "egress policy policy-2 not found"},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("deletes each egress policy on its own when best effort", func() {
+			fakeMapper.AsBatchRequestReturns(api.BatchRequest{Mode: api.BatchModeBestEffort, IDs: []string{"policy-1", "", "policy-3"}}, nil)
+			fakeStore.DeleteByGUIDsStub = func(guids ...string) error {
+				if guids[0] == "policy-3" {
+					return errors.New("db down")
+				}
+				return nil
+			}
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.DeleteByGUIDsCallCount()).To(Equal(2))
+			Expect(writtenPayload().Results).To(Equal([]api.BatchItemResult{
+				{Index: 0, ID: "policy-1", Status: api.BatchStatusDeleted},
+				{Index: 1, Status: api.BatchStatusFailed, Error: "missing id"},
+				{Index: 2, ID: "policy-3", Status: api.BatchStatusFailed, Error: "error deleting egress policy"},
+			}))
+			Expect(resp.Code).To(Equal(http.StatusMultiStatus))
+		})
+
+		It("returns an error when the store fails an all or nothing batch", func() {
+			fakeStore.DeleteByGUIDsReturns(errors.New("db down"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error deleting egress policies"}`))
+		})
+
+		It("forbids users who are not network admins", func() {
+			fakePolicyGuard.IsNetworkAdminReturns(false)
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "not authorized: deleting egress policies failed"}`))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"code.cloudfoundry.org/lager"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"policy-server/store"
@@ -14,10 +15,12 @@ type egressPolicyMapper interface {
 }
 
 type EgressPolicyCreate struct {
-	Store         egressPolicyStore
-	Mapper        egressPolicyMapper
-	ErrorResponse errorResponse
-	Logger        lager.Logger
+	Store             egressPolicyStore
+	Mapper            egressPolicyMapper
+	DestinationLister EgressDestinationStoreLister
+	PolicyGuard       policyGuard
+	ErrorResponse     errorResponse
+	Logger            lager.Logger
 }
 
 func (e *EgressPolicyCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -29,10 +32,40 @@ func (e *EgressPolicyCreate) ServeHTTP(w http.ResponseWriter, req *http.Request)
 
 	storeEgressPolicies, err := e.Mapper.AsStoreEgressPolicy(requestBytes)
 	if err != nil {
-		e.ErrorResponse.BadRequest(e.Logger, w, err, "error parsing egress policies")
+		e.ErrorResponse.BadRequest(e.Logger, w, err, fmt.Sprintf("error parsing egress policies: %s", err))
 		return
 	}
 
+	userToken := getTokenData(req)
+	isNetworkAdmin := policyGuard.IsNetworkAdmin(e.PolicyGuard, userToken)
+
+	var destinations []store.EgressDestination
+	if !isNetworkAdmin || hasDestinationNames(storeEgressPolicies) {
+		destinations, err = e.DestinationLister.All()
+		if err != nil {
+			e.ErrorResponse.InternalServerError(e.Logger, w, err, "error getting egress destinations")
+			return
+		}
+	}
+
+	err = resolveDestinations(storeEgressPolicies, destinations, !isNetworkAdmin)
+	if err != nil {
+		e.ErrorResponse.BadRequest(e.Logger, w, err, err.Error())
+		return
+	}
+
+	if !isNetworkAdmin {
+		authorized, err := e.PolicyGuard.CheckEgressAccess(storeEgressPolicies, userToken)
+		if err != nil {
+			e.ErrorResponse.InternalServerError(e.Logger, w, err, "error checking egress policy access")
+			return
+		}
+		if !authorized {
+			e.ErrorResponse.Forbidden(e.Logger, w, nil, "not authorized: creating egress policies failed")
+			return
+		}
+	}
+
 	createdPolicies, err := e.Store.Create(storeEgressPolicies)
 	if err != nil {
 		e.ErrorResponse.InternalServerError(e.Logger, w, err, "error creating egress policy")
@@ -48,3 +81,38 @@ func (e *EgressPolicyCreate) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	w.WriteHeader(http.StatusCreated)
 	w.Write(bytes)
 }
+
+func hasDestinationNames(policies []store.EgressPolicy) bool {
+	for _, policy := range policies {
+		if policy.Destination.GUID == "" && policy.Destination.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDestinations sets the GUID of each destination that is given only by
+// name, so that manifests can refer to destinations by name. When mustExist
+// is set, as it is for space developers who may only use the destinations an
+// admin created, every destination GUID must be among destinations too.
+func resolveDestinations(policies []store.EgressPolicy, destinations []store.EgressDestination, mustExist bool) error {
+	guidsByName := map[string]string{}
+	knownGUIDs := map[string]bool{}
+	for _, destination := range destinations {
+		guidsByName[destination.Name] = destination.GUID
+		knownGUIDs[destination.GUID] = true
+	}
+
+	for i, policy := range policies {
+		if policy.Destination.GUID == "" {
+			guid, ok := guidsByName[policy.Destination.Name]
+			if !ok {
+				return store.DestinationNotFoundError{Name: policy.Destination.Name}
+			}
+			policies[i].Destination.GUID = guid
+		} else if mustExist && !knownGUIDs[policy.Destination.GUID] {
+			return store.DestinationNotFoundError{GUID: policy.Destination.GUID}
+		}
+	}
+	return nil
+}
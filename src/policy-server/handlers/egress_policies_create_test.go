@@ -26,6 +26,8 @@ var _ = Describe("EgressPoliciesCreate", func() {
 		expectedStoreEgressPolicies []store.EgressPolicy
 		fakeMapper                  *fakes.EgressPolicyMapper
 		fakeStore                   *fakes.EgressPolicyStore
+		fakeDestinationLister       *fakes.EgressDestinationStoreLister
+		fakePolicyGuard             *fakes.PolicyGuard
 		logger                      *lagertest.TestLogger
 		fakeMetricsSender           *storeFakes.MetricsSender
 		handler                     *handlers.EgressPolicyCreate
@@ -40,6 +42,10 @@ var _ = Describe("EgressPoliciesCreate", func() {
 	BeforeEach(func() {
 		fakeStore = &fakes.EgressPolicyStore{}
 		fakeMapper = &fakes.EgressPolicyMapper{}
+		fakeDestinationLister = &fakes.EgressDestinationStoreLister{}
+		fakeDestinationLister.AllReturns([]store.EgressDestination{{GUID: "THE-DB-GUID", Name: "db"}, {GUID: "A-DEST-GUID"}}, nil)
+		fakePolicyGuard = &fakes.PolicyGuard{}
+		fakePolicyGuard.IsNetworkAdminReturns(true)
 
 		fakeMetricsSender = &storeFakes.MetricsSender{}
 		errorResponse := &httperror.ErrorResponse{
@@ -49,10 +55,12 @@ var _ = Describe("EgressPoliciesCreate", func() {
 		logger = lagertest.NewTestLogger("test")
 
 		handler = &handlers.EgressPolicyCreate{
-			Store:         fakeStore,
-			Mapper:        fakeMapper,
-			ErrorResponse: errorResponse,
-			Logger:        logger,
+			Store:             fakeStore,
+			Mapper:            fakeMapper,
+			DestinationLister: fakeDestinationLister,
+			PolicyGuard:       fakePolicyGuard,
+			ErrorResponse:     errorResponse,
+			Logger:            logger,
 		}
 
 		var err error
@@ -124,6 +132,90 @@ var _ = Describe("EgressPoliciesCreate", func() {
 			Expect(string(body)).To(Equal(responseBody))
 		})
 
+		It("does not list destinations when every destination has an id", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeDestinationLister.AllCallCount()).To(Equal(0))
+		})
+
+		Context("when a destination is given by name", func() {
+			BeforeEach(func() {
+				fakeMapper.AsStoreEgressPolicyReturns([]store.EgressPolicy{{
+					Source:      store.EgressSource{ID: "AN-APP-GUID"},
+					Destination: store.EgressDestination{Name: "db"},
+				}}, nil)
+			})
+
+			It("creates the policy for the destination with that name", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(fakeStore.CreateArgsForCall(0)).To(Equal([]store.EgressPolicy{{
+					Source:      store.EgressSource{ID: "AN-APP-GUID"},
+					Destination: store.EgressDestination{GUID: "THE-DB-GUID", Name: "db"},
+				}}))
+			})
+
+			It("returns a 400 when no destination has that name", func() {
+				fakeDestinationLister.AllReturns([]store.EgressDestination{}, nil)
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(resp.Code).To(Equal(http.StatusBadRequest))
+				Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "destination named db not found"}`))
+				Expect(fakeStore.CreateCallCount()).To(Equal(0))
+			})
+
+			It("returns an error when listing destinations fails", func() {
+				fakeDestinationLister.AllReturns(nil, errors.New("can't list"))
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+				Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error getting egress destinations"}`))
+			})
+		})
+
+		Context("when the user is not network admin", func() {
+			BeforeEach(func() {
+				fakePolicyGuard.IsNetworkAdminReturns(false)
+				fakePolicyGuard.CheckEgressAccessReturns(true, nil)
+			})
+
+			It("creates egress policies for apps the user can access", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				policies, userToken := fakePolicyGuard.CheckEgressAccessArgsForCall(0)
+				Expect(policies).To(Equal(expectedStoreEgressPolicies))
+				Expect(userToken).To(Equal(token))
+				Expect(fakeStore.CreateArgsForCall(0)).To(Equal(expectedStoreEgressPolicies))
+				Expect(resp.Code).To(Equal(http.StatusCreated))
+			})
+
+			It("returns a 403 when the user cannot access an app", func() {
+				fakePolicyGuard.CheckEgressAccessReturns(false, nil)
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(resp.Code).To(Equal(http.StatusForbidden))
+				Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "not authorized: creating egress policies failed"}`))
+				Expect(fakeStore.CreateCallCount()).To(Equal(0))
+			})
+
+			It("returns an error when checking access fails", func() {
+				fakePolicyGuard.CheckEgressAccessReturns(false, errors.New("cc down"))
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+				Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error checking egress policy access"}`))
+			})
+
+			It("returns a 400 for a destination that does not exist", func() {
+				fakeDestinationLister.AllReturns([]store.EgressDestination{{GUID: "THE-DB-GUID", Name: "db"}}, nil)
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+				Expect(resp.Code).To(Equal(http.StatusBadRequest))
+				Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "destination A-DEST-GUID not found"}`))
+				Expect(fakePolicyGuard.CheckEgressAccessCallCount()).To(Equal(0))
+			})
+		})
+
 		It("returns a 400 when the request body can not be read", func() {
 			request.Body = &failingReader{}
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
@@ -144,7 +236,7 @@ var _ = Describe("EgressPoliciesCreate", func() {
 
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 			Expect(resp.Code).To(Equal(http.StatusBadRequest))
-			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error parsing egress policies"}`))
+			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error parsing egress policies: didn't go well"}`))
 		})
 
 		It("returns an error response when marshalling the response returns an error", func() {
@@ -0,0 +1,113 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/asg"
+	"sync"
+)
+
+type ASGImporter struct {
+	ImportStub        func(bool) (asg.Report, error)
+	importMutex       sync.RWMutex
+	importArgsForCall []struct {
+		arg1 bool
+	}
+	importReturns struct {
+		result1 asg.Report
+		result2 error
+	}
+	importReturnsOnCall map[int]struct {
+		result1 asg.Report
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ASGImporter) Import(arg1 bool) (asg.Report, error) {
+	fake.importMutex.Lock()
+	ret, specificReturn := fake.importReturnsOnCall[len(fake.importArgsForCall)]
+	fake.importArgsForCall = append(fake.importArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.ImportStub
+	fakeReturns := fake.importReturns
+	fake.recordInvocation("Import", []interface{}{arg1})
+	fake.importMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ASGImporter) ImportCallCount() int {
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	return len(fake.importArgsForCall)
+}
+
+func (fake *ASGImporter) ImportCalls(stub func(bool) (asg.Report, error)) {
+	fake.importMutex.Lock()
+	defer fake.importMutex.Unlock()
+	fake.ImportStub = stub
+}
+
+func (fake *ASGImporter) ImportArgsForCall(i int) bool {
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	argsForCall := fake.importArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ASGImporter) ImportReturns(result1 asg.Report, result2 error) {
+	fake.importMutex.Lock()
+	defer fake.importMutex.Unlock()
+	fake.ImportStub = nil
+	fake.importReturns = struct {
+		result1 asg.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ASGImporter) ImportReturnsOnCall(i int, result1 asg.Report, result2 error) {
+	fake.importMutex.Lock()
+	defer fake.importMutex.Unlock()
+	fake.ImportStub = nil
+	if fake.importReturnsOnCall == nil {
+		fake.importReturnsOnCall = make(map[int]struct {
+			result1 asg.Report
+			result2 error
+		})
+	}
+	fake.importReturnsOnCall[i] = struct {
+		result1 asg.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ASGImporter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ASGImporter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
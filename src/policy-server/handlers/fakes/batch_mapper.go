@@ -0,0 +1,366 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/api"
+	"policy-server/store"
+	"sync"
+)
+
+type BatchMapper struct {
+	AsBatchRequestStub        func([]byte) (api.BatchRequest, error)
+	asBatchRequestMutex       sync.RWMutex
+	asBatchRequestArgsForCall []struct {
+		arg1 []byte
+	}
+	asBatchRequestReturns struct {
+		result1 api.BatchRequest
+		result2 error
+	}
+	asBatchRequestReturnsOnCall map[int]struct {
+		result1 api.BatchRequest
+		result2 error
+	}
+	AsBytesStub        func(*api.BatchPayload) ([]byte, error)
+	asBytesMutex       sync.RWMutex
+	asBytesArgsForCall []struct {
+		arg1 *api.BatchPayload
+	}
+	asBytesReturns struct {
+		result1 []byte
+		result2 error
+	}
+	asBytesReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	AsEgressDestinationStub        func([]byte) (store.EgressDestination, error)
+	asEgressDestinationMutex       sync.RWMutex
+	asEgressDestinationArgsForCall []struct {
+		arg1 []byte
+	}
+	asEgressDestinationReturns struct {
+		result1 store.EgressDestination
+		result2 error
+	}
+	asEgressDestinationReturnsOnCall map[int]struct {
+		result1 store.EgressDestination
+		result2 error
+	}
+	AsEgressPolicyStub        func([]byte) (store.EgressPolicy, error)
+	asEgressPolicyMutex       sync.RWMutex
+	asEgressPolicyArgsForCall []struct {
+		arg1 []byte
+	}
+	asEgressPolicyReturns struct {
+		result1 store.EgressPolicy
+		result2 error
+	}
+	asEgressPolicyReturnsOnCall map[int]struct {
+		result1 store.EgressPolicy
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BatchMapper) AsBatchRequest(arg1 []byte) (api.BatchRequest, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.asBatchRequestMutex.Lock()
+	ret, specificReturn := fake.asBatchRequestReturnsOnCall[len(fake.asBatchRequestArgsForCall)]
+	fake.asBatchRequestArgsForCall = append(fake.asBatchRequestArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	stub := fake.AsBatchRequestStub
+	fakeReturns := fake.asBatchRequestReturns
+	fake.recordInvocation("AsBatchRequest", []interface{}{arg1Copy})
+	fake.asBatchRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BatchMapper) AsBatchRequestCallCount() int {
+	fake.asBatchRequestMutex.RLock()
+	defer fake.asBatchRequestMutex.RUnlock()
+	return len(fake.asBatchRequestArgsForCall)
+}
+
+func (fake *BatchMapper) AsBatchRequestCalls(stub func([]byte) (api.BatchRequest, error)) {
+	fake.asBatchRequestMutex.Lock()
+	defer fake.asBatchRequestMutex.Unlock()
+	fake.AsBatchRequestStub = stub
+}
+
+func (fake *BatchMapper) AsBatchRequestArgsForCall(i int) []byte {
+	fake.asBatchRequestMutex.RLock()
+	defer fake.asBatchRequestMutex.RUnlock()
+	argsForCall := fake.asBatchRequestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BatchMapper) AsBatchRequestReturns(result1 api.BatchRequest, result2 error) {
+	fake.asBatchRequestMutex.Lock()
+	defer fake.asBatchRequestMutex.Unlock()
+	fake.AsBatchRequestStub = nil
+	fake.asBatchRequestReturns = struct {
+		result1 api.BatchRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsBatchRequestReturnsOnCall(i int, result1 api.BatchRequest, result2 error) {
+	fake.asBatchRequestMutex.Lock()
+	defer fake.asBatchRequestMutex.Unlock()
+	fake.AsBatchRequestStub = nil
+	if fake.asBatchRequestReturnsOnCall == nil {
+		fake.asBatchRequestReturnsOnCall = make(map[int]struct {
+			result1 api.BatchRequest
+			result2 error
+		})
+	}
+	fake.asBatchRequestReturnsOnCall[i] = struct {
+		result1 api.BatchRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsBytes(arg1 *api.BatchPayload) ([]byte, error) {
+	fake.asBytesMutex.Lock()
+	ret, specificReturn := fake.asBytesReturnsOnCall[len(fake.asBytesArgsForCall)]
+	fake.asBytesArgsForCall = append(fake.asBytesArgsForCall, struct {
+		arg1 *api.BatchPayload
+	}{arg1})
+	stub := fake.AsBytesStub
+	fakeReturns := fake.asBytesReturns
+	fake.recordInvocation("AsBytes", []interface{}{arg1})
+	fake.asBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BatchMapper) AsBytesCallCount() int {
+	fake.asBytesMutex.RLock()
+	defer fake.asBytesMutex.RUnlock()
+	return len(fake.asBytesArgsForCall)
+}
+
+func (fake *BatchMapper) AsBytesCalls(stub func(*api.BatchPayload) ([]byte, error)) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = stub
+}
+
+func (fake *BatchMapper) AsBytesArgsForCall(i int) *api.BatchPayload {
+	fake.asBytesMutex.RLock()
+	defer fake.asBytesMutex.RUnlock()
+	argsForCall := fake.asBytesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BatchMapper) AsBytesReturns(result1 []byte, result2 error) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = nil
+	fake.asBytesReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsBytesReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = nil
+	if fake.asBytesReturnsOnCall == nil {
+		fake.asBytesReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.asBytesReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsEgressDestination(arg1 []byte) (store.EgressDestination, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.asEgressDestinationMutex.Lock()
+	ret, specificReturn := fake.asEgressDestinationReturnsOnCall[len(fake.asEgressDestinationArgsForCall)]
+	fake.asEgressDestinationArgsForCall = append(fake.asEgressDestinationArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	stub := fake.AsEgressDestinationStub
+	fakeReturns := fake.asEgressDestinationReturns
+	fake.recordInvocation("AsEgressDestination", []interface{}{arg1Copy})
+	fake.asEgressDestinationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BatchMapper) AsEgressDestinationCallCount() int {
+	fake.asEgressDestinationMutex.RLock()
+	defer fake.asEgressDestinationMutex.RUnlock()
+	return len(fake.asEgressDestinationArgsForCall)
+}
+
+func (fake *BatchMapper) AsEgressDestinationCalls(stub func([]byte) (store.EgressDestination, error)) {
+	fake.asEgressDestinationMutex.Lock()
+	defer fake.asEgressDestinationMutex.Unlock()
+	fake.AsEgressDestinationStub = stub
+}
+
+func (fake *BatchMapper) AsEgressDestinationArgsForCall(i int) []byte {
+	fake.asEgressDestinationMutex.RLock()
+	defer fake.asEgressDestinationMutex.RUnlock()
+	argsForCall := fake.asEgressDestinationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BatchMapper) AsEgressDestinationReturns(result1 store.EgressDestination, result2 error) {
+	fake.asEgressDestinationMutex.Lock()
+	defer fake.asEgressDestinationMutex.Unlock()
+	fake.AsEgressDestinationStub = nil
+	fake.asEgressDestinationReturns = struct {
+		result1 store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsEgressDestinationReturnsOnCall(i int, result1 store.EgressDestination, result2 error) {
+	fake.asEgressDestinationMutex.Lock()
+	defer fake.asEgressDestinationMutex.Unlock()
+	fake.AsEgressDestinationStub = nil
+	if fake.asEgressDestinationReturnsOnCall == nil {
+		fake.asEgressDestinationReturnsOnCall = make(map[int]struct {
+			result1 store.EgressDestination
+			result2 error
+		})
+	}
+	fake.asEgressDestinationReturnsOnCall[i] = struct {
+		result1 store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsEgressPolicy(arg1 []byte) (store.EgressPolicy, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.asEgressPolicyMutex.Lock()
+	ret, specificReturn := fake.asEgressPolicyReturnsOnCall[len(fake.asEgressPolicyArgsForCall)]
+	fake.asEgressPolicyArgsForCall = append(fake.asEgressPolicyArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	stub := fake.AsEgressPolicyStub
+	fakeReturns := fake.asEgressPolicyReturns
+	fake.recordInvocation("AsEgressPolicy", []interface{}{arg1Copy})
+	fake.asEgressPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BatchMapper) AsEgressPolicyCallCount() int {
+	fake.asEgressPolicyMutex.RLock()
+	defer fake.asEgressPolicyMutex.RUnlock()
+	return len(fake.asEgressPolicyArgsForCall)
+}
+
+func (fake *BatchMapper) AsEgressPolicyCalls(stub func([]byte) (store.EgressPolicy, error)) {
+	fake.asEgressPolicyMutex.Lock()
+	defer fake.asEgressPolicyMutex.Unlock()
+	fake.AsEgressPolicyStub = stub
+}
+
+func (fake *BatchMapper) AsEgressPolicyArgsForCall(i int) []byte {
+	fake.asEgressPolicyMutex.RLock()
+	defer fake.asEgressPolicyMutex.RUnlock()
+	argsForCall := fake.asEgressPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BatchMapper) AsEgressPolicyReturns(result1 store.EgressPolicy, result2 error) {
+	fake.asEgressPolicyMutex.Lock()
+	defer fake.asEgressPolicyMutex.Unlock()
+	fake.AsEgressPolicyStub = nil
+	fake.asEgressPolicyReturns = struct {
+		result1 store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) AsEgressPolicyReturnsOnCall(i int, result1 store.EgressPolicy, result2 error) {
+	fake.asEgressPolicyMutex.Lock()
+	defer fake.asEgressPolicyMutex.Unlock()
+	fake.AsEgressPolicyStub = nil
+	if fake.asEgressPolicyReturnsOnCall == nil {
+		fake.asEgressPolicyReturnsOnCall = make(map[int]struct {
+			result1 store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.asEgressPolicyReturnsOnCall[i] = struct {
+		result1 store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BatchMapper) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.asBatchRequestMutex.RLock()
+	defer fake.asBatchRequestMutex.RUnlock()
+	fake.asBytesMutex.RLock()
+	defer fake.asBytesMutex.RUnlock()
+	fake.asEgressDestinationMutex.RLock()
+	defer fake.asEgressDestinationMutex.RUnlock()
+	fake.asEgressPolicyMutex.RLock()
+	defer fake.asEgressPolicyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BatchMapper) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
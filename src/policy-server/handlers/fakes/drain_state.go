@@ -0,0 +1,98 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+)
+
+type DrainState struct {
+	DrainingStub        func() bool
+	drainingMutex       sync.RWMutex
+	drainingArgsForCall []struct {
+	}
+	drainingReturns struct {
+		result1 bool
+	}
+	drainingReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DrainState) Draining() bool {
+	fake.drainingMutex.Lock()
+	ret, specificReturn := fake.drainingReturnsOnCall[len(fake.drainingArgsForCall)]
+	fake.drainingArgsForCall = append(fake.drainingArgsForCall, struct {
+	}{})
+	stub := fake.DrainingStub
+	fakeReturns := fake.drainingReturns
+	fake.recordInvocation("Draining", []interface{}{})
+	fake.drainingMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *DrainState) DrainingCallCount() int {
+	fake.drainingMutex.RLock()
+	defer fake.drainingMutex.RUnlock()
+	return len(fake.drainingArgsForCall)
+}
+
+func (fake *DrainState) DrainingCalls(stub func() bool) {
+	fake.drainingMutex.Lock()
+	defer fake.drainingMutex.Unlock()
+	fake.DrainingStub = stub
+}
+
+func (fake *DrainState) DrainingReturns(result1 bool) {
+	fake.drainingMutex.Lock()
+	defer fake.drainingMutex.Unlock()
+	fake.DrainingStub = nil
+	fake.drainingReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *DrainState) DrainingReturnsOnCall(i int, result1 bool) {
+	fake.drainingMutex.Lock()
+	defer fake.drainingMutex.Unlock()
+	fake.DrainingStub = nil
+	if fake.drainingReturnsOnCall == nil {
+		fake.drainingReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.drainingReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *DrainState) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.drainingMutex.RLock()
+	defer fake.drainingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DrainState) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
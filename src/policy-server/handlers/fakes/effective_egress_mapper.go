@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store"
+	"sync"
+)
+
+type EffectiveEgressMapper struct {
+	AsBytesStub        func(string, []store.EgressPolicy) ([]byte, error)
+	asBytesMutex       sync.RWMutex
+	asBytesArgsForCall []struct {
+		arg1 string
+		arg2 []store.EgressPolicy
+	}
+	asBytesReturns struct {
+		result1 []byte
+		result2 error
+	}
+	asBytesReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	AsCSVStub        func(string, []store.EgressPolicy) ([]byte, error)
+	asCSVMutex       sync.RWMutex
+	asCSVArgsForCall []struct {
+		arg1 string
+		arg2 []store.EgressPolicy
+	}
+	asCSVReturns struct {
+		result1 []byte
+		result2 error
+	}
+	asCSVReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EffectiveEgressMapper) AsBytes(arg1 string, arg2 []store.EgressPolicy) ([]byte, error) {
+	var arg2Copy []store.EgressPolicy
+	if arg2 != nil {
+		arg2Copy = make([]store.EgressPolicy, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.asBytesMutex.Lock()
+	ret, specificReturn := fake.asBytesReturnsOnCall[len(fake.asBytesArgsForCall)]
+	fake.asBytesArgsForCall = append(fake.asBytesArgsForCall, struct {
+		arg1 string
+		arg2 []store.EgressPolicy
+	}{arg1, arg2Copy})
+	stub := fake.AsBytesStub
+	fakeReturns := fake.asBytesReturns
+	fake.recordInvocation("AsBytes", []interface{}{arg1, arg2Copy})
+	fake.asBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EffectiveEgressMapper) AsBytesCallCount() int {
+	fake.asBytesMutex.RLock()
+	defer fake.asBytesMutex.RUnlock()
+	return len(fake.asBytesArgsForCall)
+}
+
+func (fake *EffectiveEgressMapper) AsBytesCalls(stub func(string, []store.EgressPolicy) ([]byte, error)) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = stub
+}
+
+func (fake *EffectiveEgressMapper) AsBytesArgsForCall(i int) (string, []store.EgressPolicy) {
+	fake.asBytesMutex.RLock()
+	defer fake.asBytesMutex.RUnlock()
+	argsForCall := fake.asBytesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EffectiveEgressMapper) AsBytesReturns(result1 []byte, result2 error) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = nil
+	fake.asBytesReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EffectiveEgressMapper) AsBytesReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = nil
+	if fake.asBytesReturnsOnCall == nil {
+		fake.asBytesReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.asBytesReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EffectiveEgressMapper) AsCSV(arg1 string, arg2 []store.EgressPolicy) ([]byte, error) {
+	var arg2Copy []store.EgressPolicy
+	if arg2 != nil {
+		arg2Copy = make([]store.EgressPolicy, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.asCSVMutex.Lock()
+	ret, specificReturn := fake.asCSVReturnsOnCall[len(fake.asCSVArgsForCall)]
+	fake.asCSVArgsForCall = append(fake.asCSVArgsForCall, struct {
+		arg1 string
+		arg2 []store.EgressPolicy
+	}{arg1, arg2Copy})
+	stub := fake.AsCSVStub
+	fakeReturns := fake.asCSVReturns
+	fake.recordInvocation("AsCSV", []interface{}{arg1, arg2Copy})
+	fake.asCSVMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EffectiveEgressMapper) AsCSVCallCount() int {
+	fake.asCSVMutex.RLock()
+	defer fake.asCSVMutex.RUnlock()
+	return len(fake.asCSVArgsForCall)
+}
+
+func (fake *EffectiveEgressMapper) AsCSVCalls(stub func(string, []store.EgressPolicy) ([]byte, error)) {
+	fake.asCSVMutex.Lock()
+	defer fake.asCSVMutex.Unlock()
+	fake.AsCSVStub = stub
+}
+
+func (fake *EffectiveEgressMapper) AsCSVArgsForCall(i int) (string, []store.EgressPolicy) {
+	fake.asCSVMutex.RLock()
+	defer fake.asCSVMutex.RUnlock()
+	argsForCall := fake.asCSVArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EffectiveEgressMapper) AsCSVReturns(result1 []byte, result2 error) {
+	fake.asCSVMutex.Lock()
+	defer fake.asCSVMutex.Unlock()
+	fake.AsCSVStub = nil
+	fake.asCSVReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EffectiveEgressMapper) AsCSVReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.asCSVMutex.Lock()
+	defer fake.asCSVMutex.Unlock()
+	fake.AsCSVStub = nil
+	if fake.asCSVReturnsOnCall == nil {
+		fake.asCSVReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.asCSVReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EffectiveEgressMapper) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.asBytesMutex.RLock()
+	defer fake.asBytesMutex.RUnlock()
+	fake.asCSVMutex.RLock()
+	defer fake.asCSVMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *EffectiveEgressMapper) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
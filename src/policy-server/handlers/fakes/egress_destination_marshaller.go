@@ -8,10 +8,10 @@ import (
 )
 
 type EgressDestinationMarshaller struct {
-	AsBytesStub        func(egressDestinations []store.EgressDestination) ([]byte, error)
+	AsBytesStub        func([]store.EgressDestination) ([]byte, error)
 	asBytesMutex       sync.RWMutex
 	asBytesArgsForCall []struct {
-		egressDestinations []store.EgressDestination
+		arg1 []store.EgressDestination
 	}
 	asBytesReturns struct {
 		result1 []byte
@@ -21,6 +21,20 @@ type EgressDestinationMarshaller struct {
 		result1 []byte
 		result2 error
 	}
+	AsBytesWithWarningsStub        func([]store.EgressDestination, []string) ([]byte, error)
+	asBytesWithWarningsMutex       sync.RWMutex
+	asBytesWithWarningsArgsForCall []struct {
+		arg1 []store.EgressDestination
+		arg2 []string
+	}
+	asBytesWithWarningsReturns struct {
+		result1 []byte
+		result2 error
+	}
+	asBytesWithWarningsReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	AsEgressDestinationsStub        func([]byte) ([]store.EgressDestination, error)
 	asEgressDestinationsMutex       sync.RWMutex
 	asEgressDestinationsArgsForCall []struct {
@@ -38,26 +52,28 @@ type EgressDestinationMarshaller struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressDestinationMarshaller) AsBytes(egressDestinations []store.EgressDestination) ([]byte, error) {
-	var egressDestinationsCopy []store.EgressDestination
-	if egressDestinations != nil {
-		egressDestinationsCopy = make([]store.EgressDestination, len(egressDestinations))
-		copy(egressDestinationsCopy, egressDestinations)
+func (fake *EgressDestinationMarshaller) AsBytes(arg1 []store.EgressDestination) ([]byte, error) {
+	var arg1Copy []store.EgressDestination
+	if arg1 != nil {
+		arg1Copy = make([]store.EgressDestination, len(arg1))
+		copy(arg1Copy, arg1)
 	}
 	fake.asBytesMutex.Lock()
 	ret, specificReturn := fake.asBytesReturnsOnCall[len(fake.asBytesArgsForCall)]
 	fake.asBytesArgsForCall = append(fake.asBytesArgsForCall, struct {
-		egressDestinations []store.EgressDestination
-	}{egressDestinationsCopy})
-	fake.recordInvocation("AsBytes", []interface{}{egressDestinationsCopy})
+		arg1 []store.EgressDestination
+	}{arg1Copy})
+	stub := fake.AsBytesStub
+	fakeReturns := fake.asBytesReturns
+	fake.recordInvocation("AsBytes", []interface{}{arg1Copy})
 	fake.asBytesMutex.Unlock()
-	if fake.AsBytesStub != nil {
-		return fake.AsBytesStub(egressDestinations)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.asBytesReturns.result1, fake.asBytesReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *EgressDestinationMarshaller) AsBytesCallCount() int {
@@ -66,13 +82,22 @@ func (fake *EgressDestinationMarshaller) AsBytesCallCount() int {
 	return len(fake.asBytesArgsForCall)
 }
 
+func (fake *EgressDestinationMarshaller) AsBytesCalls(stub func([]store.EgressDestination) ([]byte, error)) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
+	fake.AsBytesStub = stub
+}
+
 func (fake *EgressDestinationMarshaller) AsBytesArgsForCall(i int) []store.EgressDestination {
 	fake.asBytesMutex.RLock()
 	defer fake.asBytesMutex.RUnlock()
-	return fake.asBytesArgsForCall[i].egressDestinations
+	argsForCall := fake.asBytesArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *EgressDestinationMarshaller) AsBytesReturns(result1 []byte, result2 error) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
 	fake.AsBytesStub = nil
 	fake.asBytesReturns = struct {
 		result1 []byte
@@ -81,6 +106,8 @@ func (fake *EgressDestinationMarshaller) AsBytesReturns(result1 []byte, result2
 }
 
 func (fake *EgressDestinationMarshaller) AsBytesReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.asBytesMutex.Lock()
+	defer fake.asBytesMutex.Unlock()
 	fake.AsBytesStub = nil
 	if fake.asBytesReturnsOnCall == nil {
 		fake.asBytesReturnsOnCall = make(map[int]struct {
@@ -94,6 +121,81 @@ func (fake *EgressDestinationMarshaller) AsBytesReturnsOnCall(i int, result1 []b
 	}{result1, result2}
 }
 
+func (fake *EgressDestinationMarshaller) AsBytesWithWarnings(arg1 []store.EgressDestination, arg2 []string) ([]byte, error) {
+	var arg1Copy []store.EgressDestination
+	if arg1 != nil {
+		arg1Copy = make([]store.EgressDestination, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.asBytesWithWarningsMutex.Lock()
+	ret, specificReturn := fake.asBytesWithWarningsReturnsOnCall[len(fake.asBytesWithWarningsArgsForCall)]
+	fake.asBytesWithWarningsArgsForCall = append(fake.asBytesWithWarningsArgsForCall, struct {
+		arg1 []store.EgressDestination
+		arg2 []string
+	}{arg1Copy, arg2Copy})
+	stub := fake.AsBytesWithWarningsStub
+	fakeReturns := fake.asBytesWithWarningsReturns
+	fake.recordInvocation("AsBytesWithWarnings", []interface{}{arg1Copy, arg2Copy})
+	fake.asBytesWithWarningsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressDestinationMarshaller) AsBytesWithWarningsCallCount() int {
+	fake.asBytesWithWarningsMutex.RLock()
+	defer fake.asBytesWithWarningsMutex.RUnlock()
+	return len(fake.asBytesWithWarningsArgsForCall)
+}
+
+func (fake *EgressDestinationMarshaller) AsBytesWithWarningsCalls(stub func([]store.EgressDestination, []string) ([]byte, error)) {
+	fake.asBytesWithWarningsMutex.Lock()
+	defer fake.asBytesWithWarningsMutex.Unlock()
+	fake.AsBytesWithWarningsStub = stub
+}
+
+func (fake *EgressDestinationMarshaller) AsBytesWithWarningsArgsForCall(i int) ([]store.EgressDestination, []string) {
+	fake.asBytesWithWarningsMutex.RLock()
+	defer fake.asBytesWithWarningsMutex.RUnlock()
+	argsForCall := fake.asBytesWithWarningsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressDestinationMarshaller) AsBytesWithWarningsReturns(result1 []byte, result2 error) {
+	fake.asBytesWithWarningsMutex.Lock()
+	defer fake.asBytesWithWarningsMutex.Unlock()
+	fake.AsBytesWithWarningsStub = nil
+	fake.asBytesWithWarningsReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationMarshaller) AsBytesWithWarningsReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.asBytesWithWarningsMutex.Lock()
+	defer fake.asBytesWithWarningsMutex.Unlock()
+	fake.AsBytesWithWarningsStub = nil
+	if fake.asBytesWithWarningsReturnsOnCall == nil {
+		fake.asBytesWithWarningsReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.asBytesWithWarningsReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressDestinationMarshaller) AsEgressDestinations(arg1 []byte) ([]store.EgressDestination, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -105,15 +207,17 @@ func (fake *EgressDestinationMarshaller) AsEgressDestinations(arg1 []byte) ([]st
 	fake.asEgressDestinationsArgsForCall = append(fake.asEgressDestinationsArgsForCall, struct {
 		arg1 []byte
 	}{arg1Copy})
+	stub := fake.AsEgressDestinationsStub
+	fakeReturns := fake.asEgressDestinationsReturns
 	fake.recordInvocation("AsEgressDestinations", []interface{}{arg1Copy})
 	fake.asEgressDestinationsMutex.Unlock()
-	if fake.AsEgressDestinationsStub != nil {
-		return fake.AsEgressDestinationsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.asEgressDestinationsReturns.result1, fake.asEgressDestinationsReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *EgressDestinationMarshaller) AsEgressDestinationsCallCount() int {
@@ -122,13 +226,22 @@ func (fake *EgressDestinationMarshaller) AsEgressDestinationsCallCount() int {
 	return len(fake.asEgressDestinationsArgsForCall)
 }
 
+func (fake *EgressDestinationMarshaller) AsEgressDestinationsCalls(stub func([]byte) ([]store.EgressDestination, error)) {
+	fake.asEgressDestinationsMutex.Lock()
+	defer fake.asEgressDestinationsMutex.Unlock()
+	fake.AsEgressDestinationsStub = stub
+}
+
 func (fake *EgressDestinationMarshaller) AsEgressDestinationsArgsForCall(i int) []byte {
 	fake.asEgressDestinationsMutex.RLock()
 	defer fake.asEgressDestinationsMutex.RUnlock()
-	return fake.asEgressDestinationsArgsForCall[i].arg1
+	argsForCall := fake.asEgressDestinationsArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *EgressDestinationMarshaller) AsEgressDestinationsReturns(result1 []store.EgressDestination, result2 error) {
+	fake.asEgressDestinationsMutex.Lock()
+	defer fake.asEgressDestinationsMutex.Unlock()
 	fake.AsEgressDestinationsStub = nil
 	fake.asEgressDestinationsReturns = struct {
 		result1 []store.EgressDestination
@@ -137,6 +250,8 @@ func (fake *EgressDestinationMarshaller) AsEgressDestinationsReturns(result1 []s
 }
 
 func (fake *EgressDestinationMarshaller) AsEgressDestinationsReturnsOnCall(i int, result1 []store.EgressDestination, result2 error) {
+	fake.asEgressDestinationsMutex.Lock()
+	defer fake.asEgressDestinationsMutex.Unlock()
 	fake.AsEgressDestinationsStub = nil
 	if fake.asEgressDestinationsReturnsOnCall == nil {
 		fake.asEgressDestinationsReturnsOnCall = make(map[int]struct {
@@ -155,6 +270,8 @@ func (fake *EgressDestinationMarshaller) Invocations() map[string][][]interface{
 	defer fake.invocationsMutex.RUnlock()
 	fake.asBytesMutex.RLock()
 	defer fake.asBytesMutex.RUnlock()
+	fake.asBytesWithWarningsMutex.RLock()
+	defer fake.asBytesWithWarningsMutex.RUnlock()
 	fake.asEgressDestinationsMutex.RLock()
 	defer fake.asEgressDestinationsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,192 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store"
+	"sync"
+)
+
+type EgressPolicyBatchStore struct {
+	CreateStub        func([]store.EgressPolicy) ([]store.EgressPolicy, error)
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		arg1 []store.EgressPolicy
+	}
+	createReturns struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	createReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	DeleteByGUIDsStub        func(...string) error
+	deleteByGUIDsMutex       sync.RWMutex
+	deleteByGUIDsArgsForCall []struct {
+		arg1 []string
+	}
+	deleteByGUIDsReturns struct {
+		result1 error
+	}
+	deleteByGUIDsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EgressPolicyBatchStore) Create(arg1 []store.EgressPolicy) ([]store.EgressPolicy, error) {
+	var arg1Copy []store.EgressPolicy
+	if arg1 != nil {
+		arg1Copy = make([]store.EgressPolicy, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		arg1 []store.EgressPolicy
+	}{arg1Copy})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
+	fake.recordInvocation("Create", []interface{}{arg1Copy})
+	fake.createMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyBatchStore) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *EgressPolicyBatchStore) CreateCalls(stub func([]store.EgressPolicy) ([]store.EgressPolicy, error)) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
+func (fake *EgressPolicyBatchStore) CreateArgsForCall(i int) []store.EgressPolicy {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EgressPolicyBatchStore) CreateReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	fake.createReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyBatchStore) CreateReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyBatchStore) DeleteByGUIDs(arg1 ...string) error {
+	fake.deleteByGUIDsMutex.Lock()
+	ret, specificReturn := fake.deleteByGUIDsReturnsOnCall[len(fake.deleteByGUIDsArgsForCall)]
+	fake.deleteByGUIDsArgsForCall = append(fake.deleteByGUIDsArgsForCall, struct {
+		arg1 []string
+	}{arg1})
+	stub := fake.DeleteByGUIDsStub
+	fakeReturns := fake.deleteByGUIDsReturns
+	fake.recordInvocation("DeleteByGUIDs", []interface{}{arg1})
+	fake.deleteByGUIDsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *EgressPolicyBatchStore) DeleteByGUIDsCallCount() int {
+	fake.deleteByGUIDsMutex.RLock()
+	defer fake.deleteByGUIDsMutex.RUnlock()
+	return len(fake.deleteByGUIDsArgsForCall)
+}
+
+func (fake *EgressPolicyBatchStore) DeleteByGUIDsCalls(stub func(...string) error) {
+	fake.deleteByGUIDsMutex.Lock()
+	defer fake.deleteByGUIDsMutex.Unlock()
+	fake.DeleteByGUIDsStub = stub
+}
+
+func (fake *EgressPolicyBatchStore) DeleteByGUIDsArgsForCall(i int) []string {
+	fake.deleteByGUIDsMutex.RLock()
+	defer fake.deleteByGUIDsMutex.RUnlock()
+	argsForCall := fake.deleteByGUIDsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EgressPolicyBatchStore) DeleteByGUIDsReturns(result1 error) {
+	fake.deleteByGUIDsMutex.Lock()
+	defer fake.deleteByGUIDsMutex.Unlock()
+	fake.DeleteByGUIDsStub = nil
+	fake.deleteByGUIDsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyBatchStore) DeleteByGUIDsReturnsOnCall(i int, result1 error) {
+	fake.deleteByGUIDsMutex.Lock()
+	defer fake.deleteByGUIDsMutex.Unlock()
+	fake.DeleteByGUIDsStub = nil
+	if fake.deleteByGUIDsReturnsOnCall == nil {
+		fake.deleteByGUIDsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteByGUIDsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyBatchStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	fake.deleteByGUIDsMutex.RLock()
+	defer fake.deleteByGUIDsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *EgressPolicyBatchStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -9,17 +9,33 @@ import (
 )
 
 type ErrorResponse struct {
-	InternalServerErrorStub        func(lager.Logger, http.ResponseWriter, error, string)
-	internalServerErrorMutex       sync.RWMutex
-	internalServerErrorArgsForCall []struct {
+	BadRequestStub        func(lager.Logger, http.ResponseWriter, error, string)
+	badRequestMutex       sync.RWMutex
+	badRequestArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 http.ResponseWriter
 		arg3 error
 		arg4 string
 	}
-	BadRequestStub        func(lager.Logger, http.ResponseWriter, error, string)
-	badRequestMutex       sync.RWMutex
-	badRequestArgsForCall []struct {
+	ConflictStub        func(lager.Logger, http.ResponseWriter, error, string)
+	conflictMutex       sync.RWMutex
+	conflictArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	ForbiddenStub        func(lager.Logger, http.ResponseWriter, error, string)
+	forbiddenMutex       sync.RWMutex
+	forbiddenArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	InternalServerErrorStub        func(lager.Logger, http.ResponseWriter, error, string)
+	internalServerErrorMutex       sync.RWMutex
+	internalServerErrorArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 http.ResponseWriter
 		arg3 error
@@ -33,9 +49,9 @@ type ErrorResponse struct {
 		arg3 error
 		arg4 string
 	}
-	ForbiddenStub        func(lager.Logger, http.ResponseWriter, error, string)
-	forbiddenMutex       sync.RWMutex
-	forbiddenArgsForCall []struct {
+	NotFoundStub        func(lager.Logger, http.ResponseWriter, error, string)
+	notFoundMutex       sync.RWMutex
+	notFoundArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 http.ResponseWriter
 		arg3 error
@@ -53,33 +69,6 @@ type ErrorResponse struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ErrorResponse) InternalServerError(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
-	fake.internalServerErrorMutex.Lock()
-	fake.internalServerErrorArgsForCall = append(fake.internalServerErrorArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 http.ResponseWriter
-		arg3 error
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("InternalServerError", []interface{}{arg1, arg2, arg3, arg4})
-	fake.internalServerErrorMutex.Unlock()
-	if fake.InternalServerErrorStub != nil {
-		fake.InternalServerErrorStub(arg1, arg2, arg3, arg4)
-	}
-}
-
-func (fake *ErrorResponse) InternalServerErrorCallCount() int {
-	fake.internalServerErrorMutex.RLock()
-	defer fake.internalServerErrorMutex.RUnlock()
-	return len(fake.internalServerErrorArgsForCall)
-}
-
-func (fake *ErrorResponse) InternalServerErrorArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
-	fake.internalServerErrorMutex.RLock()
-	defer fake.internalServerErrorMutex.RUnlock()
-	return fake.internalServerErrorArgsForCall[i].arg1, fake.internalServerErrorArgsForCall[i].arg2, fake.internalServerErrorArgsForCall[i].arg3, fake.internalServerErrorArgsForCall[i].arg4
-}
-
 func (fake *ErrorResponse) BadRequest(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
 	fake.badRequestMutex.Lock()
 	fake.badRequestArgsForCall = append(fake.badRequestArgsForCall, struct {
@@ -88,9 +77,10 @@ func (fake *ErrorResponse) BadRequest(arg1 lager.Logger, arg2 http.ResponseWrite
 		arg3 error
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.BadRequestStub
 	fake.recordInvocation("BadRequest", []interface{}{arg1, arg2, arg3, arg4})
 	fake.badRequestMutex.Unlock()
-	if fake.BadRequestStub != nil {
+	if stub != nil {
 		fake.BadRequestStub(arg1, arg2, arg3, arg4)
 	}
 }
@@ -101,37 +91,52 @@ func (fake *ErrorResponse) BadRequestCallCount() int {
 	return len(fake.badRequestArgsForCall)
 }
 
+func (fake *ErrorResponse) BadRequestCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.badRequestMutex.Lock()
+	defer fake.badRequestMutex.Unlock()
+	fake.BadRequestStub = stub
+}
+
 func (fake *ErrorResponse) BadRequestArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
 	fake.badRequestMutex.RLock()
 	defer fake.badRequestMutex.RUnlock()
-	return fake.badRequestArgsForCall[i].arg1, fake.badRequestArgsForCall[i].arg2, fake.badRequestArgsForCall[i].arg3, fake.badRequestArgsForCall[i].arg4
+	argsForCall := fake.badRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *ErrorResponse) NotAcceptable(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
-	fake.notAcceptableMutex.Lock()
-	fake.notAcceptableArgsForCall = append(fake.notAcceptableArgsForCall, struct {
+func (fake *ErrorResponse) Conflict(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.conflictMutex.Lock()
+	fake.conflictArgsForCall = append(fake.conflictArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 http.ResponseWriter
 		arg3 error
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("NotAcceptable", []interface{}{arg1, arg2, arg3, arg4})
-	fake.notAcceptableMutex.Unlock()
-	if fake.NotAcceptableStub != nil {
-		fake.NotAcceptableStub(arg1, arg2, arg3, arg4)
+	stub := fake.ConflictStub
+	fake.recordInvocation("Conflict", []interface{}{arg1, arg2, arg3, arg4})
+	fake.conflictMutex.Unlock()
+	if stub != nil {
+		fake.ConflictStub(arg1, arg2, arg3, arg4)
 	}
 }
 
-func (fake *ErrorResponse) NotAcceptableCallCount() int {
-	fake.notAcceptableMutex.RLock()
-	defer fake.notAcceptableMutex.RUnlock()
-	return len(fake.notAcceptableArgsForCall)
+func (fake *ErrorResponse) ConflictCallCount() int {
+	fake.conflictMutex.RLock()
+	defer fake.conflictMutex.RUnlock()
+	return len(fake.conflictArgsForCall)
 }
 
-func (fake *ErrorResponse) NotAcceptableArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
-	fake.notAcceptableMutex.RLock()
-	defer fake.notAcceptableMutex.RUnlock()
-	return fake.notAcceptableArgsForCall[i].arg1, fake.notAcceptableArgsForCall[i].arg2, fake.notAcceptableArgsForCall[i].arg3, fake.notAcceptableArgsForCall[i].arg4
+func (fake *ErrorResponse) ConflictCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.conflictMutex.Lock()
+	defer fake.conflictMutex.Unlock()
+	fake.ConflictStub = stub
+}
+
+func (fake *ErrorResponse) ConflictArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.conflictMutex.RLock()
+	defer fake.conflictMutex.RUnlock()
+	argsForCall := fake.conflictArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *ErrorResponse) Forbidden(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
@@ -142,9 +147,10 @@ func (fake *ErrorResponse) Forbidden(arg1 lager.Logger, arg2 http.ResponseWriter
 		arg3 error
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.ForbiddenStub
 	fake.recordInvocation("Forbidden", []interface{}{arg1, arg2, arg3, arg4})
 	fake.forbiddenMutex.Unlock()
-	if fake.ForbiddenStub != nil {
+	if stub != nil {
 		fake.ForbiddenStub(arg1, arg2, arg3, arg4)
 	}
 }
@@ -155,10 +161,122 @@ func (fake *ErrorResponse) ForbiddenCallCount() int {
 	return len(fake.forbiddenArgsForCall)
 }
 
+func (fake *ErrorResponse) ForbiddenCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.forbiddenMutex.Lock()
+	defer fake.forbiddenMutex.Unlock()
+	fake.ForbiddenStub = stub
+}
+
 func (fake *ErrorResponse) ForbiddenArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
 	fake.forbiddenMutex.RLock()
 	defer fake.forbiddenMutex.RUnlock()
-	return fake.forbiddenArgsForCall[i].arg1, fake.forbiddenArgsForCall[i].arg2, fake.forbiddenArgsForCall[i].arg3, fake.forbiddenArgsForCall[i].arg4
+	argsForCall := fake.forbiddenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ErrorResponse) InternalServerError(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.internalServerErrorMutex.Lock()
+	fake.internalServerErrorArgsForCall = append(fake.internalServerErrorArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.InternalServerErrorStub
+	fake.recordInvocation("InternalServerError", []interface{}{arg1, arg2, arg3, arg4})
+	fake.internalServerErrorMutex.Unlock()
+	if stub != nil {
+		fake.InternalServerErrorStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) InternalServerErrorCallCount() int {
+	fake.internalServerErrorMutex.RLock()
+	defer fake.internalServerErrorMutex.RUnlock()
+	return len(fake.internalServerErrorArgsForCall)
+}
+
+func (fake *ErrorResponse) InternalServerErrorCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.internalServerErrorMutex.Lock()
+	defer fake.internalServerErrorMutex.Unlock()
+	fake.InternalServerErrorStub = stub
+}
+
+func (fake *ErrorResponse) InternalServerErrorArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.internalServerErrorMutex.RLock()
+	defer fake.internalServerErrorMutex.RUnlock()
+	argsForCall := fake.internalServerErrorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ErrorResponse) NotAcceptable(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.notAcceptableMutex.Lock()
+	fake.notAcceptableArgsForCall = append(fake.notAcceptableArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.NotAcceptableStub
+	fake.recordInvocation("NotAcceptable", []interface{}{arg1, arg2, arg3, arg4})
+	fake.notAcceptableMutex.Unlock()
+	if stub != nil {
+		fake.NotAcceptableStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) NotAcceptableCallCount() int {
+	fake.notAcceptableMutex.RLock()
+	defer fake.notAcceptableMutex.RUnlock()
+	return len(fake.notAcceptableArgsForCall)
+}
+
+func (fake *ErrorResponse) NotAcceptableCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.notAcceptableMutex.Lock()
+	defer fake.notAcceptableMutex.Unlock()
+	fake.NotAcceptableStub = stub
+}
+
+func (fake *ErrorResponse) NotAcceptableArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.notAcceptableMutex.RLock()
+	defer fake.notAcceptableMutex.RUnlock()
+	argsForCall := fake.notAcceptableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ErrorResponse) NotFound(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.notFoundMutex.Lock()
+	fake.notFoundArgsForCall = append(fake.notFoundArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.NotFoundStub
+	fake.recordInvocation("NotFound", []interface{}{arg1, arg2, arg3, arg4})
+	fake.notFoundMutex.Unlock()
+	if stub != nil {
+		fake.NotFoundStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) NotFoundCallCount() int {
+	fake.notFoundMutex.RLock()
+	defer fake.notFoundMutex.RUnlock()
+	return len(fake.notFoundArgsForCall)
+}
+
+func (fake *ErrorResponse) NotFoundCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.notFoundMutex.Lock()
+	defer fake.notFoundMutex.Unlock()
+	fake.NotFoundStub = stub
+}
+
+func (fake *ErrorResponse) NotFoundArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.notFoundMutex.RLock()
+	defer fake.notFoundMutex.RUnlock()
+	argsForCall := fake.notFoundArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *ErrorResponse) Unauthorized(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
@@ -169,9 +287,10 @@ func (fake *ErrorResponse) Unauthorized(arg1 lager.Logger, arg2 http.ResponseWri
 		arg3 error
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.UnauthorizedStub
 	fake.recordInvocation("Unauthorized", []interface{}{arg1, arg2, arg3, arg4})
 	fake.unauthorizedMutex.Unlock()
-	if fake.UnauthorizedStub != nil {
+	if stub != nil {
 		fake.UnauthorizedStub(arg1, arg2, arg3, arg4)
 	}
 }
@@ -182,23 +301,34 @@ func (fake *ErrorResponse) UnauthorizedCallCount() int {
 	return len(fake.unauthorizedArgsForCall)
 }
 
+func (fake *ErrorResponse) UnauthorizedCalls(stub func(lager.Logger, http.ResponseWriter, error, string)) {
+	fake.unauthorizedMutex.Lock()
+	defer fake.unauthorizedMutex.Unlock()
+	fake.UnauthorizedStub = stub
+}
+
 func (fake *ErrorResponse) UnauthorizedArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
 	fake.unauthorizedMutex.RLock()
 	defer fake.unauthorizedMutex.RUnlock()
-	return fake.unauthorizedArgsForCall[i].arg1, fake.unauthorizedArgsForCall[i].arg2, fake.unauthorizedArgsForCall[i].arg3, fake.unauthorizedArgsForCall[i].arg4
+	argsForCall := fake.unauthorizedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *ErrorResponse) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.internalServerErrorMutex.RLock()
-	defer fake.internalServerErrorMutex.RUnlock()
 	fake.badRequestMutex.RLock()
 	defer fake.badRequestMutex.RUnlock()
-	fake.notAcceptableMutex.RLock()
-	defer fake.notAcceptableMutex.RUnlock()
+	fake.conflictMutex.RLock()
+	defer fake.conflictMutex.RUnlock()
 	fake.forbiddenMutex.RLock()
 	defer fake.forbiddenMutex.RUnlock()
+	fake.internalServerErrorMutex.RLock()
+	defer fake.internalServerErrorMutex.RUnlock()
+	fake.notAcceptableMutex.RLock()
+	defer fake.notAcceptableMutex.RUnlock()
+	fake.notFoundMutex.RLock()
+	defer fake.notFoundMutex.RUnlock()
 	fake.unauthorizedMutex.RLock()
 	defer fake.unauthorizedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
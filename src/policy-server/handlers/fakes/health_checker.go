@@ -0,0 +1,164 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/health"
+	"sync"
+)
+
+type HealthChecker struct {
+	DetailedStub        func() health.Report
+	detailedMutex       sync.RWMutex
+	detailedArgsForCall []struct {
+	}
+	detailedReturns struct {
+		result1 health.Report
+	}
+	detailedReturnsOnCall map[int]struct {
+		result1 health.Report
+	}
+	ReadinessStub        func() health.Report
+	readinessMutex       sync.RWMutex
+	readinessArgsForCall []struct {
+	}
+	readinessReturns struct {
+		result1 health.Report
+	}
+	readinessReturnsOnCall map[int]struct {
+		result1 health.Report
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *HealthChecker) Detailed() health.Report {
+	fake.detailedMutex.Lock()
+	ret, specificReturn := fake.detailedReturnsOnCall[len(fake.detailedArgsForCall)]
+	fake.detailedArgsForCall = append(fake.detailedArgsForCall, struct {
+	}{})
+	stub := fake.DetailedStub
+	fakeReturns := fake.detailedReturns
+	fake.recordInvocation("Detailed", []interface{}{})
+	fake.detailedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *HealthChecker) DetailedCallCount() int {
+	fake.detailedMutex.RLock()
+	defer fake.detailedMutex.RUnlock()
+	return len(fake.detailedArgsForCall)
+}
+
+func (fake *HealthChecker) DetailedCalls(stub func() health.Report) {
+	fake.detailedMutex.Lock()
+	defer fake.detailedMutex.Unlock()
+	fake.DetailedStub = stub
+}
+
+func (fake *HealthChecker) DetailedReturns(result1 health.Report) {
+	fake.detailedMutex.Lock()
+	defer fake.detailedMutex.Unlock()
+	fake.DetailedStub = nil
+	fake.detailedReturns = struct {
+		result1 health.Report
+	}{result1}
+}
+
+func (fake *HealthChecker) DetailedReturnsOnCall(i int, result1 health.Report) {
+	fake.detailedMutex.Lock()
+	defer fake.detailedMutex.Unlock()
+	fake.DetailedStub = nil
+	if fake.detailedReturnsOnCall == nil {
+		fake.detailedReturnsOnCall = make(map[int]struct {
+			result1 health.Report
+		})
+	}
+	fake.detailedReturnsOnCall[i] = struct {
+		result1 health.Report
+	}{result1}
+}
+
+func (fake *HealthChecker) Readiness() health.Report {
+	fake.readinessMutex.Lock()
+	ret, specificReturn := fake.readinessReturnsOnCall[len(fake.readinessArgsForCall)]
+	fake.readinessArgsForCall = append(fake.readinessArgsForCall, struct {
+	}{})
+	stub := fake.ReadinessStub
+	fakeReturns := fake.readinessReturns
+	fake.recordInvocation("Readiness", []interface{}{})
+	fake.readinessMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *HealthChecker) ReadinessCallCount() int {
+	fake.readinessMutex.RLock()
+	defer fake.readinessMutex.RUnlock()
+	return len(fake.readinessArgsForCall)
+}
+
+func (fake *HealthChecker) ReadinessCalls(stub func() health.Report) {
+	fake.readinessMutex.Lock()
+	defer fake.readinessMutex.Unlock()
+	fake.ReadinessStub = stub
+}
+
+func (fake *HealthChecker) ReadinessReturns(result1 health.Report) {
+	fake.readinessMutex.Lock()
+	defer fake.readinessMutex.Unlock()
+	fake.ReadinessStub = nil
+	fake.readinessReturns = struct {
+		result1 health.Report
+	}{result1}
+}
+
+func (fake *HealthChecker) ReadinessReturnsOnCall(i int, result1 health.Report) {
+	fake.readinessMutex.Lock()
+	defer fake.readinessMutex.Unlock()
+	fake.ReadinessStub = nil
+	if fake.readinessReturnsOnCall == nil {
+		fake.readinessReturnsOnCall = make(map[int]struct {
+			result1 health.Report
+		})
+	}
+	fake.readinessReturnsOnCall[i] = struct {
+		result1 health.Report
+	}{result1}
+}
+
+func (fake *HealthChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.detailedMutex.RLock()
+	defer fake.detailedMutex.RUnlock()
+	fake.readinessMutex.RLock()
+	defer fake.readinessMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *HealthChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+)
+
+type OrgResolver struct {
+	OrgGUIDsStub        func([]string) ([]string, error)
+	orgGUIDsMutex       sync.RWMutex
+	orgGUIDsArgsForCall []struct {
+		arg1 []string
+	}
+	orgGUIDsReturns struct {
+		result1 []string
+		result2 error
+	}
+	orgGUIDsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *OrgResolver) OrgGUIDs(arg1 []string) ([]string, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.orgGUIDsMutex.Lock()
+	ret, specificReturn := fake.orgGUIDsReturnsOnCall[len(fake.orgGUIDsArgsForCall)]
+	fake.orgGUIDsArgsForCall = append(fake.orgGUIDsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.OrgGUIDsStub
+	fakeReturns := fake.orgGUIDsReturns
+	fake.recordInvocation("OrgGUIDs", []interface{}{arg1Copy})
+	fake.orgGUIDsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrgResolver) OrgGUIDsCallCount() int {
+	fake.orgGUIDsMutex.RLock()
+	defer fake.orgGUIDsMutex.RUnlock()
+	return len(fake.orgGUIDsArgsForCall)
+}
+
+func (fake *OrgResolver) OrgGUIDsCalls(stub func([]string) ([]string, error)) {
+	fake.orgGUIDsMutex.Lock()
+	defer fake.orgGUIDsMutex.Unlock()
+	fake.OrgGUIDsStub = stub
+}
+
+func (fake *OrgResolver) OrgGUIDsArgsForCall(i int) []string {
+	fake.orgGUIDsMutex.RLock()
+	defer fake.orgGUIDsMutex.RUnlock()
+	argsForCall := fake.orgGUIDsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *OrgResolver) OrgGUIDsReturns(result1 []string, result2 error) {
+	fake.orgGUIDsMutex.Lock()
+	defer fake.orgGUIDsMutex.Unlock()
+	fake.OrgGUIDsStub = nil
+	fake.orgGUIDsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgResolver) OrgGUIDsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.orgGUIDsMutex.Lock()
+	defer fake.orgGUIDsMutex.Unlock()
+	fake.OrgGUIDsStub = nil
+	if fake.orgGUIDsReturnsOnCall == nil {
+		fake.orgGUIDsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.orgGUIDsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgResolver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.orgGUIDsMutex.RLock()
+	defer fake.orgGUIDsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *OrgResolver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -9,8 +9,9 @@ import (
 type PolicyCleaner struct {
 	DeleteStalePoliciesStub        func() ([]store.Policy, []store.EgressPolicy, error)
 	deleteStalePoliciesMutex       sync.RWMutex
-	deleteStalePoliciesArgsForCall []struct{}
-	deleteStalePoliciesReturns     struct {
+	deleteStalePoliciesArgsForCall []struct {
+	}
+	deleteStalePoliciesReturns struct {
 		result1 []store.Policy
 		result2 []store.EgressPolicy
 		result3 error
@@ -20,6 +21,20 @@ type PolicyCleaner struct {
 		result2 []store.EgressPolicy
 		result3 error
 	}
+	FindStalePoliciesStub        func() ([]store.Policy, []store.EgressPolicy, error)
+	findStalePoliciesMutex       sync.RWMutex
+	findStalePoliciesArgsForCall []struct {
+	}
+	findStalePoliciesReturns struct {
+		result1 []store.Policy
+		result2 []store.EgressPolicy
+		result3 error
+	}
+	findStalePoliciesReturnsOnCall map[int]struct {
+		result1 []store.Policy
+		result2 []store.EgressPolicy
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -27,16 +42,19 @@ type PolicyCleaner struct {
 func (fake *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
 	fake.deleteStalePoliciesMutex.Lock()
 	ret, specificReturn := fake.deleteStalePoliciesReturnsOnCall[len(fake.deleteStalePoliciesArgsForCall)]
-	fake.deleteStalePoliciesArgsForCall = append(fake.deleteStalePoliciesArgsForCall, struct{}{})
+	fake.deleteStalePoliciesArgsForCall = append(fake.deleteStalePoliciesArgsForCall, struct {
+	}{})
+	stub := fake.DeleteStalePoliciesStub
+	fakeReturns := fake.deleteStalePoliciesReturns
 	fake.recordInvocation("DeleteStalePolicies", []interface{}{})
 	fake.deleteStalePoliciesMutex.Unlock()
-	if fake.DeleteStalePoliciesStub != nil {
-		return fake.DeleteStalePoliciesStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.deleteStalePoliciesReturns.result1, fake.deleteStalePoliciesReturns.result2, fake.deleteStalePoliciesReturns.result3
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *PolicyCleaner) DeleteStalePoliciesCallCount() int {
@@ -45,7 +63,15 @@ func (fake *PolicyCleaner) DeleteStalePoliciesCallCount() int {
 	return len(fake.deleteStalePoliciesArgsForCall)
 }
 
+func (fake *PolicyCleaner) DeleteStalePoliciesCalls(stub func() ([]store.Policy, []store.EgressPolicy, error)) {
+	fake.deleteStalePoliciesMutex.Lock()
+	defer fake.deleteStalePoliciesMutex.Unlock()
+	fake.DeleteStalePoliciesStub = stub
+}
+
 func (fake *PolicyCleaner) DeleteStalePoliciesReturns(result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
+	fake.deleteStalePoliciesMutex.Lock()
+	defer fake.deleteStalePoliciesMutex.Unlock()
 	fake.DeleteStalePoliciesStub = nil
 	fake.deleteStalePoliciesReturns = struct {
 		result1 []store.Policy
@@ -55,6 +81,8 @@ func (fake *PolicyCleaner) DeleteStalePoliciesReturns(result1 []store.Policy, re
 }
 
 func (fake *PolicyCleaner) DeleteStalePoliciesReturnsOnCall(i int, result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
+	fake.deleteStalePoliciesMutex.Lock()
+	defer fake.deleteStalePoliciesMutex.Unlock()
 	fake.DeleteStalePoliciesStub = nil
 	if fake.deleteStalePoliciesReturnsOnCall == nil {
 		fake.deleteStalePoliciesReturnsOnCall = make(map[int]struct {
@@ -70,11 +98,72 @@ func (fake *PolicyCleaner) DeleteStalePoliciesReturnsOnCall(i int, result1 []sto
 	}{result1, result2, result3}
 }
 
+func (fake *PolicyCleaner) FindStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
+	fake.findStalePoliciesMutex.Lock()
+	ret, specificReturn := fake.findStalePoliciesReturnsOnCall[len(fake.findStalePoliciesArgsForCall)]
+	fake.findStalePoliciesArgsForCall = append(fake.findStalePoliciesArgsForCall, struct {
+	}{})
+	stub := fake.FindStalePoliciesStub
+	fakeReturns := fake.findStalePoliciesReturns
+	fake.recordInvocation("FindStalePolicies", []interface{}{})
+	fake.findStalePoliciesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesCallCount() int {
+	fake.findStalePoliciesMutex.RLock()
+	defer fake.findStalePoliciesMutex.RUnlock()
+	return len(fake.findStalePoliciesArgsForCall)
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesCalls(stub func() ([]store.Policy, []store.EgressPolicy, error)) {
+	fake.findStalePoliciesMutex.Lock()
+	defer fake.findStalePoliciesMutex.Unlock()
+	fake.FindStalePoliciesStub = stub
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesReturns(result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
+	fake.findStalePoliciesMutex.Lock()
+	defer fake.findStalePoliciesMutex.Unlock()
+	fake.FindStalePoliciesStub = nil
+	fake.findStalePoliciesReturns = struct {
+		result1 []store.Policy
+		result2 []store.EgressPolicy
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesReturnsOnCall(i int, result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
+	fake.findStalePoliciesMutex.Lock()
+	defer fake.findStalePoliciesMutex.Unlock()
+	fake.FindStalePoliciesStub = nil
+	if fake.findStalePoliciesReturnsOnCall == nil {
+		fake.findStalePoliciesReturnsOnCall = make(map[int]struct {
+			result1 []store.Policy
+			result2 []store.EgressPolicy
+			result3 error
+		})
+	}
+	fake.findStalePoliciesReturnsOnCall[i] = struct {
+		result1 []store.Policy
+		result2 []store.EgressPolicy
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *PolicyCleaner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.deleteStalePoliciesMutex.RLock()
 	defer fake.deleteStalePoliciesMutex.RUnlock()
+	fake.findStalePoliciesMutex.RLock()
+	defer fake.findStalePoliciesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
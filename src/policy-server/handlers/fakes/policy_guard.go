@@ -8,11 +8,11 @@ import (
 )
 
 type PolicyGuard struct {
-	CheckAccessStub        func(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccessStub        func([]store.Policy, uaa_client.CheckTokenResponse) (bool, error)
 	checkAccessMutex       sync.RWMutex
 	checkAccessArgsForCall []struct {
-		policies  []store.Policy
-		tokenData uaa_client.CheckTokenResponse
+		arg1 []store.Policy
+		arg2 uaa_client.CheckTokenResponse
 	}
 	checkAccessReturns struct {
 		result1 bool
@@ -22,10 +22,24 @@ type PolicyGuard struct {
 		result1 bool
 		result2 error
 	}
-	IsNetworkAdminStub        func(userToken uaa_client.CheckTokenResponse) bool
+	CheckEgressAccessStub        func([]store.EgressPolicy, uaa_client.CheckTokenResponse) (bool, error)
+	checkEgressAccessMutex       sync.RWMutex
+	checkEgressAccessArgsForCall []struct {
+		arg1 []store.EgressPolicy
+		arg2 uaa_client.CheckTokenResponse
+	}
+	checkEgressAccessReturns struct {
+		result1 bool
+		result2 error
+	}
+	checkEgressAccessReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	IsNetworkAdminStub        func(uaa_client.CheckTokenResponse) bool
 	isNetworkAdminMutex       sync.RWMutex
 	isNetworkAdminArgsForCall []struct {
-		userToken uaa_client.CheckTokenResponse
+		arg1 uaa_client.CheckTokenResponse
 	}
 	isNetworkAdminReturns struct {
 		result1 bool
@@ -37,27 +51,29 @@ type PolicyGuard struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyGuard) CheckAccess(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error) {
-	var policiesCopy []store.Policy
-	if policies != nil {
-		policiesCopy = make([]store.Policy, len(policies))
-		copy(policiesCopy, policies)
+func (fake *PolicyGuard) CheckAccess(arg1 []store.Policy, arg2 uaa_client.CheckTokenResponse) (bool, error) {
+	var arg1Copy []store.Policy
+	if arg1 != nil {
+		arg1Copy = make([]store.Policy, len(arg1))
+		copy(arg1Copy, arg1)
 	}
 	fake.checkAccessMutex.Lock()
 	ret, specificReturn := fake.checkAccessReturnsOnCall[len(fake.checkAccessArgsForCall)]
 	fake.checkAccessArgsForCall = append(fake.checkAccessArgsForCall, struct {
-		policies  []store.Policy
-		tokenData uaa_client.CheckTokenResponse
-	}{policiesCopy, tokenData})
-	fake.recordInvocation("CheckAccess", []interface{}{policiesCopy, tokenData})
+		arg1 []store.Policy
+		arg2 uaa_client.CheckTokenResponse
+	}{arg1Copy, arg2})
+	stub := fake.CheckAccessStub
+	fakeReturns := fake.checkAccessReturns
+	fake.recordInvocation("CheckAccess", []interface{}{arg1Copy, arg2})
 	fake.checkAccessMutex.Unlock()
-	if fake.CheckAccessStub != nil {
-		return fake.CheckAccessStub(policies, tokenData)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.checkAccessReturns.result1, fake.checkAccessReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *PolicyGuard) CheckAccessCallCount() int {
@@ -66,13 +82,22 @@ func (fake *PolicyGuard) CheckAccessCallCount() int {
 	return len(fake.checkAccessArgsForCall)
 }
 
+func (fake *PolicyGuard) CheckAccessCalls(stub func([]store.Policy, uaa_client.CheckTokenResponse) (bool, error)) {
+	fake.checkAccessMutex.Lock()
+	defer fake.checkAccessMutex.Unlock()
+	fake.CheckAccessStub = stub
+}
+
 func (fake *PolicyGuard) CheckAccessArgsForCall(i int) ([]store.Policy, uaa_client.CheckTokenResponse) {
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
-	return fake.checkAccessArgsForCall[i].policies, fake.checkAccessArgsForCall[i].tokenData
+	argsForCall := fake.checkAccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *PolicyGuard) CheckAccessReturns(result1 bool, result2 error) {
+	fake.checkAccessMutex.Lock()
+	defer fake.checkAccessMutex.Unlock()
 	fake.CheckAccessStub = nil
 	fake.checkAccessReturns = struct {
 		result1 bool
@@ -81,6 +106,8 @@ func (fake *PolicyGuard) CheckAccessReturns(result1 bool, result2 error) {
 }
 
 func (fake *PolicyGuard) CheckAccessReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkAccessMutex.Lock()
+	defer fake.checkAccessMutex.Unlock()
 	fake.CheckAccessStub = nil
 	if fake.checkAccessReturnsOnCall == nil {
 		fake.checkAccessReturnsOnCall = make(map[int]struct {
@@ -94,21 +121,93 @@ func (fake *PolicyGuard) CheckAccessReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
-func (fake *PolicyGuard) IsNetworkAdmin(userToken uaa_client.CheckTokenResponse) bool {
+func (fake *PolicyGuard) CheckEgressAccess(arg1 []store.EgressPolicy, arg2 uaa_client.CheckTokenResponse) (bool, error) {
+	var arg1Copy []store.EgressPolicy
+	if arg1 != nil {
+		arg1Copy = make([]store.EgressPolicy, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.checkEgressAccessMutex.Lock()
+	ret, specificReturn := fake.checkEgressAccessReturnsOnCall[len(fake.checkEgressAccessArgsForCall)]
+	fake.checkEgressAccessArgsForCall = append(fake.checkEgressAccessArgsForCall, struct {
+		arg1 []store.EgressPolicy
+		arg2 uaa_client.CheckTokenResponse
+	}{arg1Copy, arg2})
+	stub := fake.CheckEgressAccessStub
+	fakeReturns := fake.checkEgressAccessReturns
+	fake.recordInvocation("CheckEgressAccess", []interface{}{arg1Copy, arg2})
+	fake.checkEgressAccessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyGuard) CheckEgressAccessCallCount() int {
+	fake.checkEgressAccessMutex.RLock()
+	defer fake.checkEgressAccessMutex.RUnlock()
+	return len(fake.checkEgressAccessArgsForCall)
+}
+
+func (fake *PolicyGuard) CheckEgressAccessCalls(stub func([]store.EgressPolicy, uaa_client.CheckTokenResponse) (bool, error)) {
+	fake.checkEgressAccessMutex.Lock()
+	defer fake.checkEgressAccessMutex.Unlock()
+	fake.CheckEgressAccessStub = stub
+}
+
+func (fake *PolicyGuard) CheckEgressAccessArgsForCall(i int) ([]store.EgressPolicy, uaa_client.CheckTokenResponse) {
+	fake.checkEgressAccessMutex.RLock()
+	defer fake.checkEgressAccessMutex.RUnlock()
+	argsForCall := fake.checkEgressAccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PolicyGuard) CheckEgressAccessReturns(result1 bool, result2 error) {
+	fake.checkEgressAccessMutex.Lock()
+	defer fake.checkEgressAccessMutex.Unlock()
+	fake.CheckEgressAccessStub = nil
+	fake.checkEgressAccessReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyGuard) CheckEgressAccessReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkEgressAccessMutex.Lock()
+	defer fake.checkEgressAccessMutex.Unlock()
+	fake.CheckEgressAccessStub = nil
+	if fake.checkEgressAccessReturnsOnCall == nil {
+		fake.checkEgressAccessReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.checkEgressAccessReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyGuard) IsNetworkAdmin(arg1 uaa_client.CheckTokenResponse) bool {
 	fake.isNetworkAdminMutex.Lock()
 	ret, specificReturn := fake.isNetworkAdminReturnsOnCall[len(fake.isNetworkAdminArgsForCall)]
 	fake.isNetworkAdminArgsForCall = append(fake.isNetworkAdminArgsForCall, struct {
-		userToken uaa_client.CheckTokenResponse
-	}{userToken})
-	fake.recordInvocation("IsNetworkAdmin", []interface{}{userToken})
+		arg1 uaa_client.CheckTokenResponse
+	}{arg1})
+	stub := fake.IsNetworkAdminStub
+	fakeReturns := fake.isNetworkAdminReturns
+	fake.recordInvocation("IsNetworkAdmin", []interface{}{arg1})
 	fake.isNetworkAdminMutex.Unlock()
-	if fake.IsNetworkAdminStub != nil {
-		return fake.IsNetworkAdminStub(userToken)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.isNetworkAdminReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *PolicyGuard) IsNetworkAdminCallCount() int {
@@ -117,13 +216,22 @@ func (fake *PolicyGuard) IsNetworkAdminCallCount() int {
 	return len(fake.isNetworkAdminArgsForCall)
 }
 
+func (fake *PolicyGuard) IsNetworkAdminCalls(stub func(uaa_client.CheckTokenResponse) bool) {
+	fake.isNetworkAdminMutex.Lock()
+	defer fake.isNetworkAdminMutex.Unlock()
+	fake.IsNetworkAdminStub = stub
+}
+
 func (fake *PolicyGuard) IsNetworkAdminArgsForCall(i int) uaa_client.CheckTokenResponse {
 	fake.isNetworkAdminMutex.RLock()
 	defer fake.isNetworkAdminMutex.RUnlock()
-	return fake.isNetworkAdminArgsForCall[i].userToken
+	argsForCall := fake.isNetworkAdminArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *PolicyGuard) IsNetworkAdminReturns(result1 bool) {
+	fake.isNetworkAdminMutex.Lock()
+	defer fake.isNetworkAdminMutex.Unlock()
 	fake.IsNetworkAdminStub = nil
 	fake.isNetworkAdminReturns = struct {
 		result1 bool
@@ -131,6 +239,8 @@ func (fake *PolicyGuard) IsNetworkAdminReturns(result1 bool) {
 }
 
 func (fake *PolicyGuard) IsNetworkAdminReturnsOnCall(i int, result1 bool) {
+	fake.isNetworkAdminMutex.Lock()
+	defer fake.isNetworkAdminMutex.Unlock()
 	fake.IsNetworkAdminStub = nil
 	if fake.isNetworkAdminReturnsOnCall == nil {
 		fake.isNetworkAdminReturnsOnCall = make(map[int]struct {
@@ -147,6 +257,8 @@ func (fake *PolicyGuard) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
+	fake.checkEgressAccessMutex.RLock()
+	defer fake.checkEgressAccessMutex.RUnlock()
 	fake.isNetworkAdminMutex.RLock()
 	defer fake.isNetworkAdminMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
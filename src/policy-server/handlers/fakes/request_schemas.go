@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+)
+
+type RequestSchemas struct {
+	ValidateRequestStub        func(string, string, []byte) error
+	validateRequestMutex       sync.RWMutex
+	validateRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}
+	validateRequestReturns struct {
+		result1 error
+	}
+	validateRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RequestSchemas) ValidateRequest(arg1 string, arg2 string, arg3 []byte) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.validateRequestMutex.Lock()
+	ret, specificReturn := fake.validateRequestReturnsOnCall[len(fake.validateRequestArgsForCall)]
+	fake.validateRequestArgsForCall = append(fake.validateRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ValidateRequestStub
+	fakeReturns := fake.validateRequestReturns
+	fake.recordInvocation("ValidateRequest", []interface{}{arg1, arg2, arg3Copy})
+	fake.validateRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RequestSchemas) ValidateRequestCallCount() int {
+	fake.validateRequestMutex.RLock()
+	defer fake.validateRequestMutex.RUnlock()
+	return len(fake.validateRequestArgsForCall)
+}
+
+func (fake *RequestSchemas) ValidateRequestCalls(stub func(string, string, []byte) error) {
+	fake.validateRequestMutex.Lock()
+	defer fake.validateRequestMutex.Unlock()
+	fake.ValidateRequestStub = stub
+}
+
+func (fake *RequestSchemas) ValidateRequestArgsForCall(i int) (string, string, []byte) {
+	fake.validateRequestMutex.RLock()
+	defer fake.validateRequestMutex.RUnlock()
+	argsForCall := fake.validateRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RequestSchemas) ValidateRequestReturns(result1 error) {
+	fake.validateRequestMutex.Lock()
+	defer fake.validateRequestMutex.Unlock()
+	fake.ValidateRequestStub = nil
+	fake.validateRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RequestSchemas) ValidateRequestReturnsOnCall(i int, result1 error) {
+	fake.validateRequestMutex.Lock()
+	defer fake.validateRequestMutex.Unlock()
+	fake.ValidateRequestStub = nil
+	if fake.validateRequestReturnsOnCall == nil {
+		fake.validateRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RequestSchemas) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateRequestMutex.RLock()
+	defer fake.validateRequestMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RequestSchemas) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,104 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store"
+	"sync"
+)
+
+type TagUtilisationStore struct {
+	TagUtilisationStub        func() (store.TagUtilisation, error)
+	tagUtilisationMutex       sync.RWMutex
+	tagUtilisationArgsForCall []struct {
+	}
+	tagUtilisationReturns struct {
+		result1 store.TagUtilisation
+		result2 error
+	}
+	tagUtilisationReturnsOnCall map[int]struct {
+		result1 store.TagUtilisation
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TagUtilisationStore) TagUtilisation() (store.TagUtilisation, error) {
+	fake.tagUtilisationMutex.Lock()
+	ret, specificReturn := fake.tagUtilisationReturnsOnCall[len(fake.tagUtilisationArgsForCall)]
+	fake.tagUtilisationArgsForCall = append(fake.tagUtilisationArgsForCall, struct {
+	}{})
+	stub := fake.TagUtilisationStub
+	fakeReturns := fake.tagUtilisationReturns
+	fake.recordInvocation("TagUtilisation", []interface{}{})
+	fake.tagUtilisationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TagUtilisationStore) TagUtilisationCallCount() int {
+	fake.tagUtilisationMutex.RLock()
+	defer fake.tagUtilisationMutex.RUnlock()
+	return len(fake.tagUtilisationArgsForCall)
+}
+
+func (fake *TagUtilisationStore) TagUtilisationCalls(stub func() (store.TagUtilisation, error)) {
+	fake.tagUtilisationMutex.Lock()
+	defer fake.tagUtilisationMutex.Unlock()
+	fake.TagUtilisationStub = stub
+}
+
+func (fake *TagUtilisationStore) TagUtilisationReturns(result1 store.TagUtilisation, result2 error) {
+	fake.tagUtilisationMutex.Lock()
+	defer fake.tagUtilisationMutex.Unlock()
+	fake.TagUtilisationStub = nil
+	fake.tagUtilisationReturns = struct {
+		result1 store.TagUtilisation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagUtilisationStore) TagUtilisationReturnsOnCall(i int, result1 store.TagUtilisation, result2 error) {
+	fake.tagUtilisationMutex.Lock()
+	defer fake.tagUtilisationMutex.Unlock()
+	fake.TagUtilisationStub = nil
+	if fake.tagUtilisationReturnsOnCall == nil {
+		fake.tagUtilisationReturnsOnCall = make(map[int]struct {
+			result1 store.TagUtilisation
+			result2 error
+		})
+	}
+	fake.tagUtilisationReturnsOnCall[i] = struct {
+		result1 store.TagUtilisation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagUtilisationStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.tagUtilisationMutex.RLock()
+	defer fake.tagUtilisationMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TagUtilisationStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
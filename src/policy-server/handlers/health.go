@@ -1,18 +1,54 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
+	"policy-server/health"
 	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
 )
 
+//go:generate counterfeiter -o fakes/tag_utilisation_store.go --fake-name TagUtilisationStore . tagUtilisationStore
+type tagUtilisationStore interface {
+	TagUtilisation() (store.TagUtilisation, error)
+}
+
+//go:generate counterfeiter -o fakes/health_checker.go --fake-name HealthChecker . healthChecker
+type healthChecker interface {
+	Detailed() health.Report
+	Readiness() health.Report
+}
+
+//go:generate counterfeiter -o fakes/drain_state.go --fake-name DrainState . drainState
+type drainState interface {
+	Draining() bool
+}
+
 type Health struct {
 	Store         store.Store
+	TagStore      tagUtilisationStore
+	Checker       healthChecker
+	DrainState    drainState
 	ErrorResponse errorResponse
 }
 
-func NewHealth(store store.Store, errorResponse errorResponse) *Health {
+type healthResponse struct {
+	Tags tagHeadroom `json:"tags"`
+}
+
+type tagHeadroom struct {
+	Total     int `json:"total"`
+	Allocated int `json:"allocated"`
+	Orphaned  int `json:"orphaned"`
+	Available int `json:"available"`
+}
+
+func NewHealth(store store.Store, tagStore tagUtilisationStore, checker healthChecker, errorResponse errorResponse) *Health {
 	return &Health{
 		Store:         store,
+		TagStore:      tagStore,
+		Checker:       checker,
 		ErrorResponse: errorResponse,
 	}
 }
@@ -20,9 +56,108 @@ func NewHealth(store store.Store, errorResponse errorResponse) *Health {
 func (h *Health) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger := getLogger(req)
 	logger = logger.Session("health")
+
+	if isDraining(h.DrainState) {
+		writeDraining(logger, w)
+		return
+	}
+
+	if req.URL.Query().Get("detailed") == "true" && h.Checker != nil {
+		writeHealthReport(logger, w, h.Checker.Detailed(), h.ErrorResponse)
+		return
+	}
+
 	err := h.Store.CheckDatabase()
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check database failed")
 		return
 	}
+
+	if h.TagStore == nil {
+		return
+	}
+
+	utilisation, err := h.TagStore.TagUtilisation()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "tag utilisation failed")
+		return
+	}
+
+	responseBytes, err := json.Marshal(healthResponse{
+		Tags: tagHeadroom{
+			Total:     utilisation.Total,
+			Allocated: utilisation.Allocated,
+			Orphaned:  utilisation.Orphaned,
+			Available: utilisation.Total - utilisation.Allocated,
+		},
+	})
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshal response failed") // untested
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+// Liveness only reports that the process is serving requests. It never
+// touches a dependency, so that an outage elsewhere does not get the server
+// restarted.
+type Liveness struct{}
+
+func (h *Liveness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// Readiness reports whether the server can handle requests, by running the
+// required health checks.
+type Readiness struct {
+	Checker       healthChecker
+	DrainState    drainState
+	ErrorResponse errorResponse
+}
+
+func NewReadiness(checker healthChecker, errorResponse errorResponse) *Readiness {
+	return &Readiness{
+		Checker:       checker,
+		ErrorResponse: errorResponse,
+	}
+}
+
+func (h *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("readiness")
+
+	if isDraining(h.DrainState) {
+		writeDraining(logger, w)
+		return
+	}
+
+	writeHealthReport(logger, w, h.Checker.Readiness(), h.ErrorResponse)
+}
+
+func writeHealthReport(logger lager.Logger, w http.ResponseWriter, report health.Report, errorResponse errorResponse) {
+	responseBytes, err := json.Marshal(report)
+	if err != nil {
+		errorResponse.InternalServerError(logger, w, err, "marshal response failed") // untested
+		return
+	}
+
+	if report.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(responseBytes)
+}
+
+func isDraining(state drainState) bool {
+	return state != nil && state.Draining()
+}
+
+// writeDraining reports a server that is shutting down as unavailable, so
+// that load balancers stop sending it requests while in-flight ones finish.
+func writeDraining(logger lager.Logger, w http.ResponseWriter) {
+	logger.Info("draining")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	w.Write([]byte(`{"error": "server is shutting down"}`))
 }
@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
+	"policy-server/health"
+	"policy-server/store"
 	storeFakes "policy-server/store/fakes"
 
 	"code.cloudfoundry.org/lager"
@@ -55,6 +57,22 @@ var _ = Describe("Health handler", func() {
 		Expect(resp.Code).To(Equal(http.StatusOK))
 	})
 
+	Context("when the server is draining", func() {
+		BeforeEach(func() {
+			fakeDrainState := &fakes.DrainState{}
+			fakeDrainState.DrainingReturns(true)
+			handler.DrainState = fakeDrainState
+		})
+
+		It("returns a 503 without checking the database", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.CheckDatabaseCallCount()).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "server is shutting down"}`))
+		})
+	})
+
 	Context("when the logger is not provided", func() {
 		It("still works", func() {
 			handler.ServeHTTP(resp, request)
@@ -82,4 +100,168 @@ var _ = Describe("Health handler", func() {
 			Expect(description).To(Equal("check database failed"))
 		})
 	})
+
+	Context("when a tag store is provided", func() {
+		var fakeTagStore *fakes.TagUtilisationStore
+
+		BeforeEach(func() {
+			fakeTagStore = &fakes.TagUtilisationStore{}
+			fakeTagStore.TagUtilisationReturns(store.TagUtilisation{
+				Total:     255,
+				Allocated: 200,
+				Orphaned:  12,
+			}, nil)
+			handler.TagStore = fakeTagStore
+		})
+
+		It("reports the tag headroom", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(MatchJSON(`{
+				"tags": {
+					"total": 255,
+					"allocated": 200,
+					"orphaned": 12,
+					"available": 55
+				}
+			}`))
+		})
+
+		Context("when getting the tag utilisation fails", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagUtilisationReturns(store.TagUtilisation{}, errors.New("banana"))
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(err).To(MatchError("banana"))
+				Expect(description).To(Equal("tag utilisation failed"))
+			})
+		})
+	})
+
+	Context("when the detailed report is requested", func() {
+		var fakeChecker *fakes.HealthChecker
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", "/health?detailed=true", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeChecker = &fakes.HealthChecker{}
+			fakeChecker.DetailedReturns(health.Report{
+				Healthy: true,
+				Checks: []health.Result{
+					{Name: "database", Healthy: true, Required: true, DurationMS: 3},
+					{Name: "uaa", Healthy: false, DurationMS: 1000, Error: "timed out after 1s"},
+				},
+			})
+			handler.Checker = fakeChecker
+		})
+
+		It("returns the report of every check", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeChecker.DetailedCallCount()).To(Equal(1))
+			Expect(fakeStore.CheckDatabaseCallCount()).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(MatchJSON(`{
+				"healthy": true,
+				"checks": [
+					{"name": "database", "healthy": true, "required": true, "duration_ms": 3},
+					{"name": "uaa", "healthy": false, "required": false, "duration_ms": 1000, "error": "timed out after 1s"}
+				]
+			}`))
+		})
+
+		Context("when the report is unhealthy", func() {
+			BeforeEach(func() {
+				fakeChecker.DetailedReturns(health.Report{Healthy: false})
+			})
+
+			It("returns a 503", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+				Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			})
+		})
+	})
+})
+
+var _ = Describe("Liveness handler", func() {
+	It("returns a 200 without checking any dependency", func() {
+		request, err := http.NewRequest("GET", "/health/liveness", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp := httptest.NewRecorder()
+
+		(&handlers.Liveness{}).ServeHTTP(resp, request)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+	})
+})
+
+var _ = Describe("Readiness handler", func() {
+	var (
+		handler     *handlers.Readiness
+		fakeChecker *fakes.HealthChecker
+		request     *http.Request
+		resp        *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/health/readiness", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp = httptest.NewRecorder()
+
+		fakeChecker = &fakes.HealthChecker{}
+		fakeChecker.ReadinessReturns(health.Report{
+			Healthy: true,
+			Checks:  []health.Result{{Name: "database", Healthy: true, Required: true}},
+		})
+		handler = handlers.NewReadiness(fakeChecker, &fakes.ErrorResponse{})
+	})
+
+	It("returns the report of the required checks", func() {
+		handler.ServeHTTP(resp, request)
+
+		Expect(fakeChecker.ReadinessCallCount()).To(Equal(1))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.String()).To(MatchJSON(`{
+			"healthy": true,
+			"checks": [{"name": "database", "healthy": true, "required": true, "duration_ms": 0}]
+		}`))
+	})
+
+	Context("when a required check fails", func() {
+		BeforeEach(func() {
+			fakeChecker.ReadinessReturns(health.Report{
+				Healthy: false,
+				Checks:  []health.Result{{Name: "database", Required: true, Error: "potato"}},
+			})
+		})
+
+		It("returns a 503", func() {
+			handler.ServeHTTP(resp, request)
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+
+	Context("when the server is draining", func() {
+		BeforeEach(func() {
+			fakeDrainState := &fakes.DrainState{}
+			fakeDrainState.DrainingReturns(true)
+			handler.DrainState = fakeDrainState
+		})
+
+		It("returns a 503 without running the checks", func() {
+			handler.ServeHTTP(resp, request)
+
+			Expect(fakeChecker.ReadinessCallCount()).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "server is shutting down"}`))
+		})
+	})
 })
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// OpenAPIHandler serves the OpenAPI document of the version in the path.
+type OpenAPIHandler struct {
+	Documents     map[string][]byte
+	RataAdapter   rataAdapter
+	ErrorResponse errorResponse
+}
+
+func (h *OpenAPIHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("openapi")
+
+	version := h.RataAdapter.Param(req, "version")
+	document, ok := h.Documents[version]
+	if !ok {
+		h.ErrorResponse.NotAcceptable(logger, w, nil, fmt.Sprintf("api version '%s' not supported", version))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
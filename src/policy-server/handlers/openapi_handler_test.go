@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenAPIHandler", func() {
+	var (
+		handler           *handlers.OpenAPIHandler
+		fakeRataAdapter   *fakes.RataAdapter
+		fakeErrorResponse *fakes.ErrorResponse
+		request           *http.Request
+		resp              *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/openapi.json", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp = httptest.NewRecorder()
+
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("v1")
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		handler = &handlers.OpenAPIHandler{
+			Documents:     map[string][]byte{"v1": []byte(`{"openapi": "3.0.3"}`)},
+			RataAdapter:   fakeRataAdapter,
+			ErrorResponse: fakeErrorResponse,
+		}
+	})
+
+	It("serves the document of the version", func() {
+		handler.ServeHTTP(resp, request)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body.String()).To(MatchJSON(`{"openapi": "3.0.3"}`))
+	})
+
+	Context("when the version has no document", func() {
+		BeforeEach(func() {
+			fakeRataAdapter.ParamReturns("v9")
+		})
+
+		It("returns not acceptable", func() {
+			handler.ServeHTTP(resp, request)
+
+			Expect(fakeErrorResponse.NotAcceptableCallCount()).To(Equal(1))
+			_, _, _, description := fakeErrorResponse.NotAcceptableArgsForCall(0)
+			Expect(description).To(Equal("api version 'v9' not supported"))
+		})
+	})
+})
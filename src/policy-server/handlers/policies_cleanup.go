@@ -11,12 +11,15 @@ import (
 //go:generate counterfeiter -o fakes/policy_cleaner.go --fake-name PolicyCleaner . policyCleaner
 type policyCleaner interface {
 	DeleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error)
+	FindStalePolicies() ([]store.Policy, []store.EgressPolicy, error)
 }
 
 //go:generate counterfeiter -o fakes/error_response.go --fake-name ErrorResponse . errorResponse
 type errorResponse interface {
 	InternalServerError(lager.Logger, http.ResponseWriter, error, string)
 	BadRequest(lager.Logger, http.ResponseWriter, error, string)
+	NotFound(lager.Logger, http.ResponseWriter, error, string)
+	Conflict(lager.Logger, http.ResponseWriter, error, string)
 	NotAcceptable(lager.Logger, http.ResponseWriter, error, string)
 	Forbidden(lager.Logger, http.ResponseWriter, error, string)
 	Unauthorized(lager.Logger, http.ResponseWriter, error, string)
@@ -40,7 +43,12 @@ func (h *PoliciesCleanup) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger := getLogger(req)
 	logger = logger.Session("cleanup-policies")
 
-	c2cPolicies, egressPolicies, err := h.PolicyCleaner.DeleteStalePolicies()
+	cleanup := h.PolicyCleaner.DeleteStalePolicies
+	if req.URL.Query().Get("dry_run") == "true" {
+		cleanup = h.PolicyCleaner.FindStalePolicies
+	}
+
+	c2cPolicies, egressPolicies, err := cleanup()
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "policies cleanup failed")
 		return
@@ -101,6 +101,25 @@ var _ = Describe("PoliciesCleanup", func() {
 		Expect(resp.Body.String()).To(Equal(`some-bytes`))
 	})
 
+	Context("when it is a dry run", func() {
+		BeforeEach(func() {
+			request, _ = http.NewRequest("POST", "/networking/v1/external/policies/cleanup?dry_run=true", nil)
+			fakePolicyCleaner.FindStalePoliciesReturns(policies, egressPolicies, nil)
+		})
+
+		It("returns the stale policies without deleting them", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakePolicyCleaner.FindStalePoliciesCallCount()).To(Equal(1))
+			Expect(fakePolicyCleaner.DeleteStalePoliciesCallCount()).To(Equal(0))
+
+			policiesArg, egressPoliciesArg := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(policiesArg).To(Equal(policies))
+			Expect(egressPoliciesArg).To(Equal(egressPolicies))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+	})
+
 	Context("when the logger isn't on the request context", func() {
 		It("returns all the policies, but does not include the tags", func() {
 			handler.ServeHTTP(resp, request)
@@ -15,6 +15,7 @@ import (
 //go:generate counterfeiter -o fakes/policy_guard.go --fake-name PolicyGuard . policyGuard
 type policyGuard interface {
 	CheckAccess(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckEgressAccess(egressPolicies []store.EgressPolicy, tokenData uaa_client.CheckTokenResponse) (bool, error)
 	IsNetworkAdmin(userToken uaa_client.CheckTokenResponse) bool
 }
 
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"policy-server/api"
@@ -17,12 +19,21 @@ type egressPolicyStore interface {
 	Create(egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error)
 }
 
+//go:generate counterfeiter -o fakes/org_resolver.go --fake-name OrgResolver . orgResolver
+type orgResolver interface {
+	OrgGUIDs(guids []string) ([]string, error)
+}
+
+// PoliciesIndexInternal returns the policies of the guids it is asked for.
+// When it has an OrgResolver, it also returns the egress policies of the
+// orgs of the app guids among them, as far as those can be looked up.
 type PoliciesIndexInternal struct {
 	Logger                 lager.Logger
 	Store                  store.Store
 	PolicyCollectionWriter api.PolicyCollectionWriter
 	ErrorResponse          errorResponse
 	EgressStore            egressPolicyStore
+	OrgResolver            orgResolver
 }
 
 func NewPoliciesIndexInternal(logger lager.Logger, store store.Store, egressStore egressPolicyStore,
@@ -43,6 +54,25 @@ func (h *PoliciesIndexInternal) ServeHTTP(w http.ResponseWriter, req *http.Reque
 	queryValues := req.URL.Query()
 	ids := parseIds(queryValues)
 
+	appLifecycle := queryValues.Get("app_lifecycle")
+	if appLifecycle == "" {
+		appLifecycle = store.AppLifecycleRunning
+	}
+	switch appLifecycle {
+	case store.AppLifecycleRunning, store.AppLifecycleStaging, store.AppLifecycleAll:
+	default:
+		err := fmt.Errorf("invalid app_lifecycle: %s", appLifecycle)
+		h.ErrorResponse.BadRequest(logger, w, err, "app_lifecycle must be running, staging or all")
+		return
+	}
+
+	if getClientRole(req) == ClientRoleCell && len(ids) == 0 {
+		err := errors.New("cell clients must request policies by app guid")
+		auditDenial(logger, req, clientIdentities(req), err)
+		h.ErrorResponse.Forbidden(logger, w, err, err.Error())
+		return
+	}
+
 	var policies []store.Policy
 	var err error
 	if len(ids) == 0 {
@@ -60,13 +90,24 @@ func (h *PoliciesIndexInternal) ServeHTTP(w http.ResponseWriter, req *http.Reque
 	if len(ids) == 0 {
 		egressPolicies, err = h.EgressStore.All()
 	} else {
-		egressPolicies, err = h.EgressStore.GetBySourceGuids(ids)
+		sourceGUIDs := ids
+		if h.OrgResolver != nil {
+			// The policies of the apps are still served when their orgs can
+			// not all be looked up, with those of the orgs that could be.
+			orgGUIDs, resolveErr := h.OrgResolver.OrgGUIDs(ids)
+			if resolveErr != nil {
+				logger.Error("resolving-app-orgs-failed", resolveErr)
+			}
+			sourceGUIDs = append(append([]string{}, ids...), orgGUIDs...)
+		}
+		egressPolicies, err = h.EgressStore.GetBySourceGuids(sourceGUIDs)
 	}
 
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "egress database read failed")
 		return
 	}
+	egressPolicies = filterByAppLifecycle(egressPolicies, appLifecycle)
 
 	bytes, err := h.PolicyCollectionWriter.AsBytes(policies, egressPolicies)
 	if err != nil {
@@ -86,3 +127,20 @@ func parseIds(queryValues url.Values) []string {
 	}
 	return ids
 }
+
+// filterByAppLifecycle keeps the egress policies that apply to apps in the
+// given lifecycle, which are those for that lifecycle and those for all of
+// them. Every policy is kept for the all lifecycle.
+func filterByAppLifecycle(egressPolicies []store.EgressPolicy, appLifecycle string) []store.EgressPolicy {
+	if appLifecycle == store.AppLifecycleAll {
+		return egressPolicies
+	}
+
+	filtered := []store.EgressPolicy{}
+	for _, egressPolicy := range egressPolicies {
+		if egressPolicy.AppLifecycle == appLifecycle || egressPolicy.AppLifecycle == store.AppLifecycleAll {
+			filtered = append(filtered, egressPolicy)
+		}
+	}
+	return filtered
+}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -17,6 +18,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("PoliciesIndexInternal", func() {
@@ -119,6 +121,52 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
 	})
 
+	Context("when there is an org resolver", func() {
+		var fakeOrgResolver *fakes.OrgResolver
+
+		BeforeEach(func() {
+			fakeOrgResolver = &fakes.OrgResolver{}
+			fakeOrgResolver.OrgGUIDsReturns([]string{"some-org-guid"}, nil)
+			handler.OrgResolver = fakeOrgResolver
+		})
+
+		It("also returns the egress policies of the orgs of the requested apps", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid,some-space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeOrgResolver.OrgGUIDsCallCount()).To(Equal(1))
+			Expect(fakeOrgResolver.OrgGUIDsArgsForCall(0)).To(Equal([]string{"some-app-guid", "some-space-guid"}))
+			Expect(fakeEgressStore.GetBySourceGuidsArgsForCall(0)).To(Equal([]string{"some-app-guid", "some-space-guid", "some-org-guid"}))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		It("does not resolve orgs when no ids are passed", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeOrgResolver.OrgGUIDsCallCount()).To(Equal(0))
+		})
+
+		Context("when resolving the orgs fails", func() {
+			BeforeEach(func() {
+				fakeOrgResolver.OrgGUIDsReturns([]string{"stale-org-guid"}, errors.New("banana"))
+			})
+
+			It("logs the error and returns the egress policies of the orgs it resolved", func() {
+				request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid", nil)
+				Expect(err).NotTo(HaveOccurred())
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeEgressStore.GetBySourceGuidsArgsForCall(0)).To(Equal([]string{"some-app-guid", "stale-org-guid"}))
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(0))
+				Expect(resp.Code).To(Equal(http.StatusOK))
+				Expect(logger).To(gbytes.Say("resolving-app-orgs-failed.*banana"))
+			})
+		})
+	})
+
 	Context("when the logger isn't on the request context", func() {
 		It("still works", func() {
 			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid", nil)
@@ -143,6 +191,106 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		})
 	})
 
+	Context("when the client has the cell role", func() {
+		var request *http.Request
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v0/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request = request.WithContext(context.WithValue(request.Context(), handlers.ClientRoleKey, handlers.ClientRoleCell))
+		})
+
+		It("does not return all of the policies", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.AllCallCount()).To(Equal(0))
+			Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(1))
+			_, _, err, _ := fakeErrorResponse.ForbiddenArgsForCall(0)
+			Expect(err).To(MatchError("cell clients must request policies by app guid"))
+			Expect(logger).To(gbytes.Say("audit-request-denied"))
+		})
+
+		It("returns the policies of the requested apps", func() {
+			request.URL.RawQuery = "id=some-app-guid"
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when an app lifecycle is passed", func() {
+		BeforeEach(func() {
+			fakeEgressStore.GetBySourceGuidsReturns([]store.EgressPolicy{
+				{ID: "running-policy", AppLifecycle: "running"},
+				{ID: "staging-policy", AppLifecycle: "staging"},
+				{ID: "all-policy", AppLifecycle: "all"},
+			}, nil)
+		})
+
+		It("returns only the egress policies that apply in that lifecycle", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid&app_lifecycle=staging", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			_, egressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(egressPolicies).To(Equal([]store.EgressPolicy{
+				{ID: "staging-policy", AppLifecycle: "staging"},
+				{ID: "all-policy", AppLifecycle: "all"},
+			}))
+		})
+
+		It("returns only the running egress policies when no lifecycle is passed", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			_, egressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(egressPolicies).To(Equal([]store.EgressPolicy{
+				{ID: "running-policy", AppLifecycle: "running"},
+				{ID: "all-policy", AppLifecycle: "all"},
+			}))
+		})
+
+		It("returns only the running egress policies when the lifecycle is empty", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid&app_lifecycle=", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			_, egressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(egressPolicies).To(Equal([]store.EgressPolicy{
+				{ID: "running-policy", AppLifecycle: "running"},
+				{ID: "all-policy", AppLifecycle: "all"},
+			}))
+		})
+
+		It("returns every egress policy for the all lifecycle", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid&app_lifecycle=all", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			_, egressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(egressPolicies).To(HaveLen(3))
+		})
+
+		It("returns a bad request for an unknown lifecycle", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid&app_lifecycle=sometimes", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(err).To(MatchError("invalid app_lifecycle: sometimes"))
+			Expect(description).To(Equal("app_lifecycle must be running, staging or all"))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(0))
+		})
+	})
+
 	Context("when rendering the policies as bytes fails", func() {
 		BeforeEach(func() {
 			fakePolicyCollectionWriter.AsBytesReturns(nil, errors.New("banana"))
@@ -19,10 +19,32 @@ func NewPolicyGuard(uaaClient uaaClient, ccClient ccClient) *PolicyGuard {
 }
 
 func (g *PolicyGuard) CheckAccess(policies []store.Policy, userToken uaa_client.CheckTokenResponse) (bool, error) {
-	for _, scope := range userToken.Scope {
-		if scope == "network.admin" {
-			return true, nil
+	if g.IsNetworkAdmin(userToken) {
+		return true, nil
+	}
+	return g.canAccessApps(uniqueAppGUIDs(policies), userToken)
+}
+
+// CheckEgressAccess allows a network admin any egress policy, and anyone else
+// only egress policies whose source is an app in a space they belong to. A
+// source that is not an app in Cloud Controller denies access, since a guid
+// that is not yet an app could become one in a space of someone else.
+func (g *PolicyGuard) CheckEgressAccess(egressPolicies []store.EgressPolicy, userToken uaa_client.CheckTokenResponse) (bool, error) {
+	if g.IsNetworkAdmin(userToken) {
+		return true, nil
+	}
+
+	set := map[string]struct{}{}
+	var appGUIDs []string
+	for _, egressPolicy := range egressPolicies {
+		if egressPolicy.Source.Type != "" && egressPolicy.Source.Type != "app" {
+			return false, nil
+		}
+		if _, ok := set[egressPolicy.Source.ID]; ok {
+			continue
 		}
+		set[egressPolicy.Source.ID] = struct{}{}
+		appGUIDs = append(appGUIDs, egressPolicy.Source.ID)
 	}
 
 	token, err := g.UAAClient.GetToken()
@@ -30,10 +52,41 @@ func (g *PolicyGuard) CheckAccess(policies []store.Policy, userToken uaa_client.
 		return false, fmt.Errorf("getting token: %s", err)
 	}
 
-	spaceGUIDs, err := g.CCClient.GetSpaceGUIDs(token, uniqueAppGUIDs(policies))
+	appSpaces, err := g.CCClient.GetAppSpaces(token, appGUIDs)
+	if err != nil {
+		return false, fmt.Errorf("getting app spaces: %s", err)
+	}
+
+	spaceSet := map[string]struct{}{}
+	var spaceGUIDs []string
+	for _, appGUID := range appGUIDs {
+		spaceGUID, ok := appSpaces[appGUID]
+		if !ok {
+			return false, nil
+		}
+		if _, ok := spaceSet[spaceGUID]; ok {
+			continue
+		}
+		spaceSet[spaceGUID] = struct{}{}
+		spaceGUIDs = append(spaceGUIDs, spaceGUID)
+	}
+	return g.canAccessSpaces(token, spaceGUIDs, userToken)
+}
+
+func (g *PolicyGuard) canAccessApps(appGUIDs []string, userToken uaa_client.CheckTokenResponse) (bool, error) {
+	token, err := g.UAAClient.GetToken()
+	if err != nil {
+		return false, fmt.Errorf("getting token: %s", err)
+	}
+
+	spaceGUIDs, err := g.CCClient.GetSpaceGUIDs(token, appGUIDs)
 	if err != nil {
 		return false, fmt.Errorf("getting space guids: %s", err)
 	}
+	return g.canAccessSpaces(token, spaceGUIDs, userToken)
+}
+
+func (g *PolicyGuard) canAccessSpaces(token string, spaceGUIDs []string, userToken uaa_client.CheckTokenResponse) (bool, error) {
 	for _, guid := range spaceGUIDs {
 		space, err := g.CCClient.GetSpace(token, guid)
 		if err != nil {
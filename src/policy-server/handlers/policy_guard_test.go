@@ -259,4 +259,79 @@ var _ = Describe("PolicyGuard", func() {
 			})
 		})
 	})
+
+	Describe("CheckEgressAccess", func() {
+		var egressPolicies []store.EgressPolicy
+
+		BeforeEach(func() {
+			fakeCCClient.GetAppSpacesReturns(map[string]string{
+				"some-app-guid":  "space-guid-1",
+				"other-app-guid": "space-guid-2",
+			}, nil)
+			egressPolicies = []store.EgressPolicy{
+				{Source: store.EgressSource{ID: "some-app-guid"}, Destination: store.EgressDestination{GUID: "some-destination-guid"}},
+				{Source: store.EgressSource{ID: "other-app-guid", Type: "app"}, Destination: store.EgressDestination{GUID: "some-destination-guid"}},
+			}
+		})
+
+		It("checks that the user can access the source app of every egress policy", func() {
+			authorized, err := policyGuard.CheckEgressAccess(egressPolicies, tokenData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorized).To(BeTrue())
+
+			token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
+			Expect(token).To(Equal("policy-server-token"))
+			Expect(appGUIDs).To(Equal([]string{"some-app-guid", "other-app-guid"}))
+			Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(2))
+			_, spaceGUID := fakeCCClient.GetSpaceArgsForCall(0)
+			Expect(spaceGUID).To(Equal("space-guid-1"))
+			_, spaceGUID = fakeCCClient.GetSpaceArgsForCall(1)
+			Expect(spaceGUID).To(Equal("space-guid-2"))
+		})
+
+		It("returns false when a source is not an app in Cloud Controller", func() {
+			fakeCCClient.GetAppSpacesReturns(map[string]string{"some-app-guid": "space-guid-1"}, nil)
+
+			authorized, err := policyGuard.CheckEgressAccess(egressPolicies, tokenData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorized).To(BeFalse())
+			Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(0))
+		})
+
+		It("returns a useful error when getting the app spaces fails", func() {
+			fakeCCClient.GetAppSpacesReturns(nil, errors.New("banana"))
+
+			authorized, err := policyGuard.CheckEgressAccess(egressPolicies, tokenData)
+			Expect(err).To(MatchError("getting app spaces: banana"))
+			Expect(authorized).To(BeFalse())
+		})
+
+		It("does not allow sources other than apps", func() {
+			egressPolicies[1].Source = store.EgressSource{ID: "some-space-guid", Type: "space"}
+
+			authorized, err := policyGuard.CheckEgressAccess(egressPolicies, tokenData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorized).To(BeFalse())
+			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+		})
+
+		It("returns false when the user is not in the space of an app", func() {
+			fakeCCClient.GetUserSpaceStub = nil
+			fakeCCClient.GetUserSpaceReturns(nil, nil)
+
+			authorized, err := policyGuard.CheckEgressAccess(egressPolicies, tokenData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorized).To(BeFalse())
+		})
+
+		It("allows a network admin any egress policy", func() {
+			egressPolicies[1].Source = store.EgressSource{Type: "default"}
+			tokenData = uaa_client.CheckTokenResponse{Scope: []string{"network.admin"}}
+
+			authorized, err := policyGuard.CheckEgressAccess(egressPolicies, tokenData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorized).To(BeTrue())
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(0))
+		})
+	})
 })
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"policy-server/store"
 	"policy-server/uaa_client"
+	"sync"
 )
 
 type QuotaGuard struct {
 	Store       policyStore
 	MaxPolicies int
+
+	lock sync.RWMutex
 }
 
 func NewQuotaGuard(store policyStore, maxPolicies int) *QuotaGuard {
@@ -18,6 +21,12 @@ func NewQuotaGuard(store policyStore, maxPolicies int) *QuotaGuard {
 	}
 }
 
+func (g *QuotaGuard) SetMaxPolicies(maxPolicies int) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.MaxPolicies = maxPolicies
+}
+
 func (g *QuotaGuard) CheckAccess(policies []store.Policy, userToken uaa_client.CheckTokenResponse) (bool, error) {
 	for _, scope := range userToken.Scope {
 		if scope == "network.admin" {
@@ -32,8 +41,13 @@ func (g *QuotaGuard) CheckAccess(policies []store.Policy, userToken uaa_client.C
 		return false, fmt.Errorf("getting policies: %s", err)
 	}
 	currentAppCounts := sourceCounts(sourcePolicies, appGuids)
+
+	g.lock.RLock()
+	maxPolicies := g.MaxPolicies
+	g.lock.RUnlock()
+
 	for _, appGuid := range appGuids {
-		if currentAppCounts[appGuid]+toAddSourceCounts[appGuid] > g.MaxPolicies {
+		if currentAppCounts[appGuid]+toAddSourceCounts[appGuid] > maxPolicies {
 			return false, nil
 		}
 	}
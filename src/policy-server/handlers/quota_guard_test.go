@@ -73,6 +73,19 @@ var _ = Describe("QuotaGuard", func() {
 
 				Expect(authorized).To(BeFalse())
 			})
+
+			Context("when the quota is raised", func() {
+				BeforeEach(func() {
+					quotaGuard.SetMaxPolicies(3)
+				})
+
+				It("allows policy creation", func() {
+					authorized, err := quotaGuard.CheckAccess(policies, tokenData)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(authorized).To(BeTrue())
+				})
+			})
 		})
 		Context("when getting the policies by guid fails", func() {
 			BeforeEach(func() {
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+}
+
+// RateLimit is a token bucket that holds up to Burst requests and refills at
+// RequestsPerSecond. A RequestsPerSecond of zero means no limit.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RateLimiter throttles requests by route and by the bearer token they carry
+// before the token is checked with UAA, so that a flood of requests does not
+// turn into a flood of check_token calls. Once a request is authenticated it
+// is also throttled by the user ID and client ID of its token.
+type RateLimiter struct {
+	Clock                          clock.Clock
+	PerUser                        RateLimit
+	PerClient                      RateLimit
+	PerRoute                       map[string]RateLimit
+	MaxConcurrentRequestsPerClient int
+	MetricsSender                  metricsSender
+
+	lock          sync.Mutex
+	userBuckets   map[string]*tokenBucket
+	clientBuckets map[string]*tokenBucket
+	routeBuckets  map[string]*tokenBucket
+	tokenBuckets  map[string]*tokenBucket
+	inFlight      map[string]int
+	lastPruned    time.Time
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+type namedBucket struct {
+	name   string
+	bucket *tokenBucket
+}
+
+// pruneInterval is how often buckets that have refilled completely, and so
+// behave as new ones, are dropped.
+const pruneInterval = time.Minute
+
+// Wrap throttles requests by route, and by their bearer token with the per
+// user limit, since a token belongs to a single user. It must wrap the
+// authenticator.
+func (l *RateLimiter) Wrap(routeName string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := getLogger(req)
+		logger = logger.Session("rate-limit")
+
+		retryAfter, err := l.take(func(now time.Time) []namedBucket {
+			var buckets []namedBucket
+			if limit, ok := l.PerRoute[routeName]; ok && limit.RequestsPerSecond > 0 {
+				l.routeBuckets = withBucket(l.routeBuckets, routeName, limit, now)
+				buckets = append(buckets, namedBucket{"route " + routeName, l.routeBuckets[routeName]})
+			}
+			if token := req.Header.Get("Authorization"); token != "" && l.PerUser.RequestsPerSecond > 0 {
+				key := tokenKey(token)
+				l.tokenBuckets = withBucket(l.tokenBuckets, key, l.PerUser, now)
+				buckets = append(buckets, namedBucket{"token", l.tokenBuckets[key]})
+			}
+			return buckets
+		})
+		if err != nil {
+			l.throttle(logger, w, routeName, retryAfter, err)
+			return
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// WrapAuthenticated throttles requests by the user ID and client ID of their
+// token, and caps the requests each client has in flight. It must be wrapped
+// by the authenticator, since it reads the token data from the request
+// context.
+func (l *RateLimiter) WrapAuthenticated(routeName string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := getLogger(req)
+		logger = logger.Session("rate-limit")
+
+		tokenData := getTokenData(req)
+		retryAfter, err := l.take(func(now time.Time) []namedBucket {
+			var buckets []namedBucket
+			if tokenData.UserID != "" && l.PerUser.RequestsPerSecond > 0 {
+				l.userBuckets = withBucket(l.userBuckets, tokenData.UserID, l.PerUser, now)
+				buckets = append(buckets, namedBucket{"user " + tokenData.UserID, l.userBuckets[tokenData.UserID]})
+			}
+			if tokenData.ClientID != "" && l.PerClient.RequestsPerSecond > 0 {
+				l.clientBuckets = withBucket(l.clientBuckets, tokenData.ClientID, l.PerClient, now)
+				buckets = append(buckets, namedBucket{"client " + tokenData.ClientID, l.clientBuckets[tokenData.ClientID]})
+			}
+			return buckets
+		})
+		if err != nil {
+			l.throttle(logger, w, routeName, retryAfter, err)
+			return
+		}
+
+		if !l.acquire(tokenData.ClientID) {
+			err := fmt.Errorf("client %s has %d requests in flight", tokenData.ClientID, l.MaxConcurrentRequestsPerClient)
+			l.throttle(logger, w, routeName, time.Second, err)
+			return
+		}
+		defer l.release(tokenData.ClientID)
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// take removes a token from each of the buckets, or from none of them if any
+// is empty, in which case it returns how long until it would succeed.
+func (l *RateLimiter) take(bucketsAt func(now time.Time) []namedBucket) (time.Duration, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.Clock.Now()
+	l.prune(now)
+
+	buckets := bucketsAt(now)
+
+	var wait time.Duration
+	var exceeded string
+	for _, b := range buckets {
+		b.bucket.refill(now)
+		if bucketWait := b.bucket.wait(); bucketWait > wait {
+			wait = bucketWait
+			exceeded = b.name
+		}
+	}
+	if wait > 0 {
+		return wait, fmt.Errorf("rate limit exceeded for %s", exceeded)
+	}
+
+	for _, b := range buckets {
+		b.bucket.tokens--
+	}
+	return 0, nil
+}
+
+func (l *RateLimiter) acquire(clientID string) bool {
+	if l.MaxConcurrentRequestsPerClient <= 0 || clientID == "" {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.inFlight == nil {
+		l.inFlight = map[string]int{}
+	}
+	if l.inFlight[clientID] >= l.MaxConcurrentRequestsPerClient {
+		return false
+	}
+	l.inFlight[clientID]++
+	return true
+}
+
+func (l *RateLimiter) release(clientID string) {
+	if l.MaxConcurrentRequestsPerClient <= 0 || clientID == "" {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.inFlight[clientID]--
+	if l.inFlight[clientID] <= 0 {
+		delete(l.inFlight, clientID)
+	}
+}
+
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPruned) < pruneInterval {
+		return
+	}
+	l.lastPruned = now
+
+	for _, buckets := range []map[string]*tokenBucket{l.routeBuckets, l.tokenBuckets, l.userBuckets, l.clientBuckets} {
+		for key, bucket := range buckets {
+			bucket.refill(now)
+			if bucket.tokens >= bucket.capacity() {
+				delete(buckets, key)
+			}
+		}
+	}
+}
+
+func (l *RateLimiter) throttle(logger lager.Logger, w http.ResponseWriter, routeName string, retryAfter time.Duration, err error) {
+	l.MetricsSender.IncrementCounter("ThrottledRequests")
+	l.MetricsSender.IncrementCounter(fmt.Sprintf("ThrottledRequests.%s", routeName))
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	tooManyRequests(logger, w, err, err.Error(), routeName)
+}
+
+// tooManyRequests responds like the shared error response, which has no
+// response for 429.
+func tooManyRequests(logger lager.Logger, w http.ResponseWriter, err error, description, routeName string) {
+	logger.Error("request-throttled", err, lager.Data{"route": routeName})
+	body, marshalErr := json.Marshal(map[string]string{"error": description})
+	if marshalErr != nil {
+		logger.Error("marshal-error-response", marshalErr) // not tested
+	}
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write(body)
+}
+
+// tokenKey keys the buckets of bearer tokens by their hash, so that the
+// tokens themselves are not kept.
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func withBucket(buckets map[string]*tokenBucket, key string, limit RateLimit, now time.Time) map[string]*tokenBucket {
+	if buckets == nil {
+		buckets = map[string]*tokenBucket{}
+	}
+	if _, ok := buckets[key]; !ok {
+		bucket := &tokenBucket{limit: limit, last: now}
+		bucket.tokens = bucket.capacity()
+		buckets[key] = bucket
+	}
+	return buckets
+}
+
+// capacity is the burst size, and at least one request so that a bucket can
+// ever be taken from.
+func (b *tokenBucket) capacity() float64 {
+	if b.limit.Burst < 1 {
+		return 1
+	}
+	return float64(b.limit.Burst)
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.capacity(), b.tokens+elapsed*b.limit.RequestsPerSecond)
+		b.last = now
+	}
+}
+
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.RequestsPerSecond * float64(time.Second))
+}
@@ -0,0 +1,215 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/uaa_client"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		rateLimiter       *handlers.RateLimiter
+		fakeClock         *fakeclock.FakeClock
+		fakeMetricsSender *fakes.MetricsSender
+		innerHandler      *fakes.HTTPHandler
+		logger            *lagertest.TestLogger
+		tokenData         uaa_client.CheckTokenResponse
+	)
+
+	makeRequest := func(routeName string, tokenData uaa_client.CheckTokenResponse) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "/networking/v1/external/policies", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp := httptest.NewRecorder()
+		MakeRequestWithLoggerAndAuth(rateLimiter.WrapAuthenticated(routeName, innerHandler).ServeHTTP, resp, request, logger, tokenData)
+		return resp
+	}
+
+	makeUnauthenticatedRequest := func(routeName, token string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "/networking/v1/external/policies", nil)
+		Expect(err).NotTo(HaveOccurred())
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp := httptest.NewRecorder()
+		MakeRequestWithLogger(rateLimiter.Wrap(routeName, innerHandler).ServeHTTP, resp, request, logger)
+		return resp
+	}
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		fakeMetricsSender = &fakes.MetricsSender{}
+		innerHandler = &fakes.HTTPHandler{}
+		logger = lagertest.NewTestLogger("test")
+		tokenData = uaa_client.CheckTokenResponse{
+			UserID:   "some-user-id",
+			ClientID: "some-client-id",
+		}
+
+		rateLimiter = &handlers.RateLimiter{
+			Clock:         fakeClock,
+			MetricsSender: fakeMetricsSender,
+		}
+	})
+
+	It("does not limit requests by default", func() {
+		for i := 0; i < 100; i++ {
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+		}
+		Expect(innerHandler.ServeHTTPCallCount()).To(Equal(100))
+	})
+
+	Context("when users are limited", func() {
+		BeforeEach(func() {
+			rateLimiter.PerUser = handlers.RateLimit{RequestsPerSecond: 0.5, Burst: 2}
+		})
+
+		It("throttles a user once their burst is spent", func() {
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+
+			resp := makeRequest("policies_index", tokenData)
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Header().Get("Retry-After")).To(Equal("2"))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "rate limit exceeded for user some-user-id"}`))
+			Expect(innerHandler.ServeHTTPCallCount()).To(Equal(2))
+		})
+
+		It("refills the bucket over time", func() {
+			makeRequest("policies_index", tokenData)
+			makeRequest("policies_index", tokenData)
+
+			fakeClock.Increment(2 * time.Second)
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("limits each user separately", func() {
+			makeRequest("policies_index", tokenData)
+			makeRequest("policies_index", tokenData)
+
+			otherUser := tokenData
+			otherUser.UserID = "some-other-user-id"
+			Expect(makeRequest("policies_index", otherUser).Code).To(Equal(http.StatusOK))
+		})
+
+		It("emits metrics and logs for throttled requests", func() {
+			makeRequest("policies_index", tokenData)
+			makeRequest("policies_index", tokenData)
+			makeRequest("policies_index", tokenData)
+
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(2))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("ThrottledRequests"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(1)).To(Equal("ThrottledRequests.policies_index"))
+			Expect(logger.Logs()).To(HaveLen(1))
+			Expect(logger.Logs()[0].Message).To(Equal("test.rate-limit.request-throttled"))
+			Expect(logger.Logs()[0].Data).To(HaveKeyWithValue("route", "policies_index"))
+		})
+	})
+
+	Context("when clients are limited", func() {
+		BeforeEach(func() {
+			rateLimiter.PerClient = handlers.RateLimit{RequestsPerSecond: 1, Burst: 1}
+		})
+
+		It("throttles all users of a client together", func() {
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+
+			otherUser := tokenData
+			otherUser.UserID = "some-other-user-id"
+			resp := makeRequest("policies_index", otherUser)
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "rate limit exceeded for client some-client-id"}`))
+		})
+	})
+
+	Context("when a route is limited", func() {
+		BeforeEach(func() {
+			rateLimiter.PerRoute = map[string]handlers.RateLimit{
+				"policies_index": {RequestsPerSecond: 1, Burst: 1},
+			}
+		})
+
+		It("throttles the route for everyone before the token is checked", func() {
+			Expect(makeUnauthenticatedRequest("policies_index", "some-token").Code).To(Equal(http.StatusOK))
+
+			resp := makeUnauthenticatedRequest("policies_index", "some-other-token")
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "rate limit exceeded for route policies_index"}`))
+			Expect(innerHandler.ServeHTTPCallCount()).To(Equal(1))
+
+			Expect(makeUnauthenticatedRequest("create_policies", "some-token").Code).To(Equal(http.StatusOK))
+		})
+
+		It("does not spend the user's tokens on throttled requests", func() {
+			rateLimiter.PerUser = handlers.RateLimit{RequestsPerSecond: 1, Burst: 5}
+
+			makeUnauthenticatedRequest("policies_index", "some-token")
+			for i := 0; i < 10; i++ {
+				makeUnauthenticatedRequest("policies_index", "some-token")
+			}
+			for i := 0; i < 4; i++ {
+				Expect(makeUnauthenticatedRequest("create_policies", "some-token").Code).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	Context("when users are limited and the token is not yet checked", func() {
+		BeforeEach(func() {
+			rateLimiter.PerUser = handlers.RateLimit{RequestsPerSecond: 1, Burst: 1}
+		})
+
+		It("throttles each bearer token before it is checked", func() {
+			Expect(makeUnauthenticatedRequest("policies_index", "some-token").Code).To(Equal(http.StatusOK))
+
+			resp := makeUnauthenticatedRequest("policies_index", "some-token")
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "rate limit exceeded for token"}`))
+			Expect(innerHandler.ServeHTTPCallCount()).To(Equal(1))
+
+			Expect(makeUnauthenticatedRequest("policies_index", "some-other-token").Code).To(Equal(http.StatusOK))
+		})
+
+		It("leaves requests without a token to the authenticator", func() {
+			Expect(makeUnauthenticatedRequest("policies_index", "").Code).To(Equal(http.StatusOK))
+			Expect(makeUnauthenticatedRequest("policies_index", "").Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when concurrent requests per client are capped", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			rateLimiter.MaxConcurrentRequestsPerClient = 1
+			release = make(chan struct{})
+			innerHandler.ServeHTTPStub = func(w http.ResponseWriter, req *http.Request) {
+				<-release
+			}
+		})
+
+		It("throttles requests beyond the cap until one finishes", func() {
+			done := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+				close(done)
+			}()
+			Eventually(innerHandler.ServeHTTPCallCount).Should(Equal(1))
+
+			resp := makeRequest("policies_index", tokenData)
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Header().Get("Retry-After")).To(Equal("1"))
+
+			close(release)
+			Eventually(done).Should(BeClosed())
+			Expect(makeRequest("policies_index", tokenData).Code).To(Equal(http.StatusOK))
+		})
+	})
+})
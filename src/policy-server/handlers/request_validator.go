@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+//go:generate counterfeiter -o fakes/request_schemas.go --fake-name RequestSchemas . requestSchemas
+type requestSchemas interface {
+	ValidateRequest(version, routeName string, body []byte) error
+}
+
+// RequestValidator rejects request bodies that do not match the schema of
+// their route in the OpenAPI document, before they reach the handler.
+type RequestValidator struct {
+	Schemas       requestSchemas
+	RataAdapter   rataAdapter
+	ErrorResponse errorResponse
+}
+
+func (v *RequestValidator) Wrap(routeName string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := getLogger(req)
+		logger = logger.Session("validate-request")
+
+		if req.Body == nil || req.Method == http.MethodGet {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			v.ErrorResponse.BadRequest(logger, w, err, "failed reading request body")
+			return
+		}
+
+		version := v.RataAdapter.Param(req, "version")
+		err = v.Schemas.ValidateRequest(version, routeName, body)
+		if err != nil {
+			v.ErrorResponse.BadRequest(logger, w, err, fmt.Sprintf("invalid request body: %s", err))
+			return
+		}
+
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		handler.ServeHTTP(w, req)
+	})
+}
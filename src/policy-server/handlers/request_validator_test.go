@@ -0,0 +1,115 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RequestValidator", func() {
+	var (
+		validator         *handlers.RequestValidator
+		fakeSchemas       *fakes.RequestSchemas
+		fakeRataAdapter   *fakes.RataAdapter
+		fakeErrorResponse *fakes.ErrorResponse
+		innerHandler      *fakes.HTTPHandler
+		logger            *lagertest.TestLogger
+		request           *http.Request
+		resp              *httptest.ResponseRecorder
+		receivedBody      []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "/networking/v1/external/policies", bytes.NewBufferString(`{"policies": []}`))
+		Expect(err).NotTo(HaveOccurred())
+		resp = httptest.NewRecorder()
+		logger = lagertest.NewTestLogger("test")
+
+		fakeSchemas = &fakes.RequestSchemas{}
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("v1")
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		innerHandler = &fakes.HTTPHandler{}
+		innerHandler.ServeHTTPStub = func(w http.ResponseWriter, req *http.Request) {
+			receivedBody, _ = ioutil.ReadAll(req.Body)
+		}
+
+		validator = &handlers.RequestValidator{
+			Schemas:       fakeSchemas,
+			RataAdapter:   fakeRataAdapter,
+			ErrorResponse: fakeErrorResponse,
+		}
+	})
+
+	It("validates the body against the schema of the route and version", func() {
+		MakeRequestWithLogger(validator.Wrap("create_policies", innerHandler).ServeHTTP, resp, request, logger)
+
+		Expect(fakeRataAdapter.ParamCallCount()).To(Equal(1))
+		_, param := fakeRataAdapter.ParamArgsForCall(0)
+		Expect(param).To(Equal("version"))
+
+		Expect(fakeSchemas.ValidateRequestCallCount()).To(Equal(1))
+		version, routeName, body := fakeSchemas.ValidateRequestArgsForCall(0)
+		Expect(version).To(Equal("v1"))
+		Expect(routeName).To(Equal("create_policies"))
+		Expect(body).To(MatchJSON(`{"policies": []}`))
+	})
+
+	It("passes the body on to the handler", func() {
+		MakeRequestWithLogger(validator.Wrap("create_policies", innerHandler).ServeHTTP, resp, request, logger)
+
+		Expect(innerHandler.ServeHTTPCallCount()).To(Equal(1))
+		Expect(receivedBody).To(MatchJSON(`{"policies": []}`))
+	})
+
+	It("does not validate GET requests", func() {
+		request.Method = "GET"
+		MakeRequestWithLogger(validator.Wrap("policies_index", innerHandler).ServeHTTP, resp, request, logger)
+
+		Expect(fakeSchemas.ValidateRequestCallCount()).To(Equal(0))
+		Expect(innerHandler.ServeHTTPCallCount()).To(Equal(1))
+	})
+
+	Context("when the body does not match the schema", func() {
+		BeforeEach(func() {
+			fakeSchemas.ValidateRequestReturns(errors.New("policies must have at least 1 items"))
+		})
+
+		It("returns a bad request without calling the handler", func() {
+			MakeRequestWithLogger(validator.Wrap("create_policies", innerHandler).ServeHTTP, resp, request, logger)
+
+			Expect(innerHandler.ServeHTTPCallCount()).To(Equal(0))
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+			_, w, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("policies must have at least 1 items"))
+			Expect(description).To(Equal("invalid request body: policies must have at least 1 items"))
+		})
+	})
+
+	Context("when the body cannot be read", func() {
+		BeforeEach(func() {
+			request.Body = &testsupport.BadReader{}
+		})
+
+		It("returns a bad request", func() {
+			MakeRequestWithLogger(validator.Wrap("create_policies", innerHandler).ServeHTTP, resp, request, logger)
+
+			Expect(innerHandler.ServeHTTPCallCount()).To(Equal(0))
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("failed reading request body"))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const DefaultCheckTimeout = 5 * time.Second
+
+// Check probes a single dependency. Run returns details to include in the
+// report, or an error when the dependency is unhealthy. Required checks decide
+// readiness; the others are only reported.
+type Check struct {
+	Name     string
+	Timeout  time.Duration
+	Required bool
+	Run      func() (interface{}, error)
+}
+
+type Result struct {
+	Name       string      `json:"name"`
+	Healthy    bool        `json:"healthy"`
+	Required   bool        `json:"required"`
+	DurationMS int64       `json:"duration_ms"`
+	Details    interface{} `json:"details,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+type Report struct {
+	Healthy bool     `json:"healthy"`
+	Checks  []Result `json:"checks"`
+}
+
+type Checker struct {
+	Checks []Check
+}
+
+// Detailed runs every check concurrently. The report is healthy when all of
+// the required checks pass.
+func (c *Checker) Detailed() Report {
+	return runChecks(c.Checks)
+}
+
+// Readiness runs only the required checks.
+func (c *Checker) Readiness() Report {
+	var required []Check
+	for _, check := range c.Checks {
+		if check.Required {
+			required = append(required, check)
+		}
+	}
+	return runChecks(required)
+}
+
+func runChecks(checks []Check) Report {
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = runCheck(check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Healthy: true, Checks: results}
+	for _, result := range results {
+		if result.Required && !result.Healthy {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+type outcome struct {
+	details interface{}
+	err     error
+}
+
+func runCheck(check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	// buffered so that a check which outlives its timeout does not leak
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		details, err := check.Run()
+		done <- outcome{details: details, err: err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-done:
+	case <-time.After(timeout):
+		o.err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	result := Result{
+		Name:       check.Name,
+		Healthy:    o.err == nil,
+		Required:   check.Required,
+		DurationMS: int64(time.Since(start) / time.Millisecond),
+		Details:    o.details,
+	}
+	if o.err != nil {
+		result.Error = o.err.Error()
+	}
+	return result
+}
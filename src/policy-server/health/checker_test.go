@@ -0,0 +1,97 @@
+package health_test
+
+import (
+	"errors"
+	"policy-server/health"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		checker *health.Checker
+		release chan struct{}
+	)
+
+	BeforeEach(func() {
+		release = make(chan struct{})
+		checker = &health.Checker{
+			Checks: []health.Check{
+				{
+					Name:     "required-check",
+					Required: true,
+					Run: func() (interface{}, error) {
+						return map[string]int{"some-detail": 1}, nil
+					},
+				},
+				{
+					Name: "optional-check",
+					Run: func() (interface{}, error) {
+						return nil, errors.New("potato")
+					},
+				},
+				{
+					Name:    "slow-check",
+					Timeout: 10 * time.Millisecond,
+					Run: func() (interface{}, error) {
+						<-release
+						return nil, nil
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		close(release)
+	})
+
+	Describe("Detailed", func() {
+		It("reports every check in order", func() {
+			report := checker.Detailed()
+			Expect(report.Checks).To(HaveLen(3))
+
+			Expect(report.Checks[0].Name).To(Equal("required-check"))
+			Expect(report.Checks[0].Healthy).To(BeTrue())
+			Expect(report.Checks[0].Required).To(BeTrue())
+			Expect(report.Checks[0].Details).To(Equal(map[string]int{"some-detail": 1}))
+
+			Expect(report.Checks[1].Name).To(Equal("optional-check"))
+			Expect(report.Checks[1].Healthy).To(BeFalse())
+			Expect(report.Checks[1].Error).To(Equal("potato"))
+		})
+
+		It("fails checks that exceed their timeout", func() {
+			report := checker.Detailed()
+			Expect(report.Checks[2].Healthy).To(BeFalse())
+			Expect(report.Checks[2].Error).To(Equal("timed out after 10ms"))
+		})
+
+		It("is healthy while only optional checks fail", func() {
+			Expect(checker.Detailed().Healthy).To(BeTrue())
+		})
+
+		Context("when a required check fails", func() {
+			BeforeEach(func() {
+				checker.Checks[0].Run = func() (interface{}, error) {
+					return nil, errors.New("potato")
+				}
+			})
+
+			It("is unhealthy", func() {
+				Expect(checker.Detailed().Healthy).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Readiness", func() {
+		It("runs only the required checks", func() {
+			report := checker.Readiness()
+			Expect(report.Healthy).To(BeTrue())
+			Expect(report.Checks).To(HaveLen(1))
+			Expect(report.Checks[0].Name).To(Equal("required-check"))
+		})
+	})
+})
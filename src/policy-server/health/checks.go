@@ -0,0 +1,159 @@
+package health
+
+import (
+	"fmt"
+	"policy-server/cleaner"
+	"policy-server/store"
+	"policy-server/store/migrations"
+	"time"
+)
+
+//go:generate counterfeiter -o fakes/database_checker.go --fake-name DatabaseChecker . databaseChecker
+type databaseChecker interface {
+	CheckDatabase() error
+}
+
+//go:generate counterfeiter -o fakes/migration_status.go --fake-name MigrationStatus . migrationStatus
+type migrationStatus interface {
+	Status(driverName string, migrationDb migrations.MigrationDb) ([]migrations.MigrationStatus, error)
+}
+
+//go:generate counterfeiter -o fakes/uaa_client.go --fake-name UAAClient . uaaClient
+type uaaClient interface {
+	GetToken() (string, error)
+}
+
+//go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
+type ccClient interface {
+	CheckReachable() error
+}
+
+//go:generate counterfeiter -o fakes/cleaner_status.go --fake-name CleanerStatus . cleanerStatus
+type cleanerStatus interface {
+	LastRun() cleaner.RunResult
+}
+
+//go:generate counterfeiter -o fakes/tag_utilisation_store.go --fake-name TagUtilisationStore . tagUtilisationStore
+type tagUtilisationStore interface {
+	TagUtilisation() (store.TagUtilisation, error)
+}
+
+type migrationDetails struct {
+	Version string `json:"version"`
+	Pending int    `json:"pending"`
+}
+
+type cleanerDetails struct {
+	LastRun *time.Time `json:"last_run"`
+}
+
+type tagDetails struct {
+	Total     int `json:"total"`
+	Allocated int `json:"allocated"`
+	Orphaned  int `json:"orphaned"`
+	Available int `json:"available"`
+}
+
+func NewDatabaseCheck(db databaseChecker, timeout time.Duration) Check {
+	return Check{
+		Name:     "database",
+		Timeout:  timeout,
+		Required: true,
+		Run: func() (interface{}, error) {
+			return nil, db.CheckDatabase()
+		},
+	}
+}
+
+// NewMigrationsCheck reports the last applied migration. The server is not
+// ready while migrations are pending, since its queries expect the newest
+// schema.
+func NewMigrationsCheck(migrator migrationStatus, driverName string, migrationDb migrations.MigrationDb, timeout time.Duration) Check {
+	return Check{
+		Name:     "migrations",
+		Timeout:  timeout,
+		Required: true,
+		Run: func() (interface{}, error) {
+			statuses, err := migrator.Status(driverName, migrationDb)
+			if err != nil {
+				return nil, err
+			}
+
+			details := migrationDetails{}
+			for _, status := range statuses {
+				if status.Applied {
+					details.Version = status.Id
+				} else {
+					details.Pending++
+				}
+			}
+			if details.Pending > 0 {
+				return details, fmt.Errorf("%d migrations pending", details.Pending)
+			}
+			return details, nil
+		},
+	}
+}
+
+func NewUAACheck(uaaClient uaaClient, timeout time.Duration) Check {
+	return Check{
+		Name:    "uaa",
+		Timeout: timeout,
+		Run: func() (interface{}, error) {
+			_, err := uaaClient.GetToken()
+			return nil, err
+		},
+	}
+}
+
+func NewCCCheck(ccClient ccClient, timeout time.Duration) Check {
+	return Check{
+		Name:    "cloud_controller",
+		Timeout: timeout,
+		Run: func() (interface{}, error) {
+			return nil, ccClient.CheckReachable()
+		},
+	}
+}
+
+// NewCleanerCheck reports the result of the last policy cleanup. A cleaner
+// that has not run yet is healthy.
+func NewCleanerCheck(policyCleaner cleanerStatus, timeout time.Duration) Check {
+	return Check{
+		Name:    "policy_cleaner",
+		Timeout: timeout,
+		Run: func() (interface{}, error) {
+			lastRun := policyCleaner.LastRun()
+			if lastRun.Time.IsZero() {
+				return cleanerDetails{}, nil
+			}
+			return cleanerDetails{LastRun: &lastRun.Time}, lastRun.Error
+		},
+	}
+}
+
+// NewTagUtilisationCheck reports the tag space and fails once it is
+// exhausted, since no new app or space can then be given a policy.
+func NewTagUtilisationCheck(tagStore tagUtilisationStore, timeout time.Duration) Check {
+	return Check{
+		Name:    "tags",
+		Timeout: timeout,
+		Run: func() (interface{}, error) {
+			utilisation, err := tagStore.TagUtilisation()
+			if err != nil {
+				return nil, err
+			}
+
+			details := tagDetails{
+				Total:     utilisation.Total,
+				Allocated: utilisation.Allocated,
+				Orphaned:  utilisation.Orphaned,
+				Available: utilisation.Total - utilisation.Allocated,
+			}
+			if details.Available <= 0 {
+				return details, fmt.Errorf("tag space exhausted")
+			}
+			return details, nil
+		},
+	}
+}
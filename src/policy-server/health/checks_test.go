@@ -0,0 +1,160 @@
+package health_test
+
+import (
+	"encoding/json"
+	"errors"
+	"policy-server/cleaner"
+	"policy-server/health"
+	"policy-server/health/fakes"
+	"policy-server/store"
+	"policy-server/store/migrations"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checks", func() {
+	Describe("NewDatabaseCheck", func() {
+		var fakeDatabase *fakes.DatabaseChecker
+
+		BeforeEach(func() {
+			fakeDatabase = &fakes.DatabaseChecker{}
+		})
+
+		It("is required and checks the database", func() {
+			check := health.NewDatabaseCheck(fakeDatabase, time.Second)
+			Expect(check.Required).To(BeTrue())
+			Expect(check.Timeout).To(Equal(time.Second))
+
+			_, err := check.Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDatabase.CheckDatabaseCallCount()).To(Equal(1))
+		})
+
+		It("returns the database error", func() {
+			fakeDatabase.CheckDatabaseReturns(errors.New("potato"))
+			_, err := health.NewDatabaseCheck(fakeDatabase, time.Second).Run()
+			Expect(err).To(MatchError("potato"))
+		})
+	})
+
+	Describe("NewMigrationsCheck", func() {
+		var fakeMigrator *fakes.MigrationStatus
+
+		BeforeEach(func() {
+			fakeMigrator = &fakes.MigrationStatus{}
+			fakeMigrator.StatusReturns([]migrations.MigrationStatus{
+				{Id: "1", Applied: true},
+				{Id: "2", Applied: true},
+			}, nil)
+		})
+
+		It("reports the last applied migration", func() {
+			check := health.NewMigrationsCheck(fakeMigrator, "some-driver", nil, time.Second)
+			Expect(check.Required).To(BeTrue())
+
+			details, err := check.Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Marshal(details)).To(MatchJSON(`{"version": "2", "pending": 0}`))
+
+			driverName, _ := fakeMigrator.StatusArgsForCall(0)
+			Expect(driverName).To(Equal("some-driver"))
+		})
+
+		Context("when migrations are pending", func() {
+			BeforeEach(func() {
+				fakeMigrator.StatusReturns([]migrations.MigrationStatus{
+					{Id: "1", Applied: true},
+					{Id: "2"},
+				}, nil)
+			})
+
+			It("fails", func() {
+				details, err := health.NewMigrationsCheck(fakeMigrator, "some-driver", nil, time.Second).Run()
+				Expect(err).To(MatchError("1 migrations pending"))
+				Expect(json.Marshal(details)).To(MatchJSON(`{"version": "1", "pending": 1}`))
+			})
+		})
+
+		Context("when the status cannot be read", func() {
+			BeforeEach(func() {
+				fakeMigrator.StatusReturns(nil, errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				_, err := health.NewMigrationsCheck(fakeMigrator, "some-driver", nil, time.Second).Run()
+				Expect(err).To(MatchError("potato"))
+			})
+		})
+	})
+
+	Describe("NewUAACheck", func() {
+		It("fails when a token cannot be fetched", func() {
+			fakeUAAClient := &fakes.UAAClient{}
+			fakeUAAClient.GetTokenReturns("", errors.New("potato"))
+
+			check := health.NewUAACheck(fakeUAAClient, time.Second)
+			Expect(check.Required).To(BeFalse())
+
+			_, err := check.Run()
+			Expect(err).To(MatchError("potato"))
+		})
+	})
+
+	Describe("NewCCCheck", func() {
+		It("fails when the cloud controller is unreachable", func() {
+			fakeCCClient := &fakes.CCClient{}
+			fakeCCClient.CheckReachableReturns(errors.New("potato"))
+
+			check := health.NewCCCheck(fakeCCClient, time.Second)
+			Expect(check.Required).To(BeFalse())
+
+			_, err := check.Run()
+			Expect(err).To(MatchError("potato"))
+		})
+	})
+
+	Describe("NewCleanerCheck", func() {
+		var fakeCleaner *fakes.CleanerStatus
+
+		BeforeEach(func() {
+			fakeCleaner = &fakes.CleanerStatus{}
+		})
+
+		It("is healthy before the first run", func() {
+			_, err := health.NewCleanerCheck(fakeCleaner, time.Second).Run()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the last run and its error", func() {
+			lastRun := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+			fakeCleaner.LastRunReturns(cleaner.RunResult{Time: lastRun, Error: errors.New("potato")})
+
+			details, err := health.NewCleanerCheck(fakeCleaner, time.Second).Run()
+			Expect(err).To(MatchError("potato"))
+			Expect(json.Marshal(details)).To(MatchJSON(`{"last_run": "2018-01-02T03:04:05Z"}`))
+		})
+	})
+
+	Describe("NewTagUtilisationCheck", func() {
+		var fakeTagStore *fakes.TagUtilisationStore
+
+		BeforeEach(func() {
+			fakeTagStore = &fakes.TagUtilisationStore{}
+			fakeTagStore.TagUtilisationReturns(store.TagUtilisation{Total: 10, Allocated: 4, Orphaned: 1}, nil)
+		})
+
+		It("reports the tag space", func() {
+			details, err := health.NewTagUtilisationCheck(fakeTagStore, time.Second).Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Marshal(details)).To(MatchJSON(`{"total": 10, "allocated": 4, "orphaned": 1, "available": 6}`))
+		})
+
+		It("fails when the tag space is exhausted", func() {
+			fakeTagStore.TagUtilisationReturns(store.TagUtilisation{Total: 10, Allocated: 10}, nil)
+			_, err := health.NewTagUtilisationCheck(fakeTagStore, time.Second).Run()
+			Expect(err).To(MatchError("tag space exhausted"))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+)
+
+type CCClient struct {
+	CheckReachableStub        func() error
+	checkReachableMutex       sync.RWMutex
+	checkReachableArgsForCall []struct {
+	}
+	checkReachableReturns struct {
+		result1 error
+	}
+	checkReachableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CCClient) CheckReachable() error {
+	fake.checkReachableMutex.Lock()
+	ret, specificReturn := fake.checkReachableReturnsOnCall[len(fake.checkReachableArgsForCall)]
+	fake.checkReachableArgsForCall = append(fake.checkReachableArgsForCall, struct {
+	}{})
+	stub := fake.CheckReachableStub
+	fakeReturns := fake.checkReachableReturns
+	fake.recordInvocation("CheckReachable", []interface{}{})
+	fake.checkReachableMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CCClient) CheckReachableCallCount() int {
+	fake.checkReachableMutex.RLock()
+	defer fake.checkReachableMutex.RUnlock()
+	return len(fake.checkReachableArgsForCall)
+}
+
+func (fake *CCClient) CheckReachableCalls(stub func() error) {
+	fake.checkReachableMutex.Lock()
+	defer fake.checkReachableMutex.Unlock()
+	fake.CheckReachableStub = stub
+}
+
+func (fake *CCClient) CheckReachableReturns(result1 error) {
+	fake.checkReachableMutex.Lock()
+	defer fake.checkReachableMutex.Unlock()
+	fake.CheckReachableStub = nil
+	fake.checkReachableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CCClient) CheckReachableReturnsOnCall(i int, result1 error) {
+	fake.checkReachableMutex.Lock()
+	defer fake.checkReachableMutex.Unlock()
+	fake.CheckReachableStub = nil
+	if fake.checkReachableReturnsOnCall == nil {
+		fake.checkReachableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkReachableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CCClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkReachableMutex.RLock()
+	defer fake.checkReachableMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CCClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
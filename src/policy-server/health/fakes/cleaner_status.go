@@ -0,0 +1,99 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/cleaner"
+	"sync"
+)
+
+type CleanerStatus struct {
+	LastRunStub        func() cleaner.RunResult
+	lastRunMutex       sync.RWMutex
+	lastRunArgsForCall []struct {
+	}
+	lastRunReturns struct {
+		result1 cleaner.RunResult
+	}
+	lastRunReturnsOnCall map[int]struct {
+		result1 cleaner.RunResult
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CleanerStatus) LastRun() cleaner.RunResult {
+	fake.lastRunMutex.Lock()
+	ret, specificReturn := fake.lastRunReturnsOnCall[len(fake.lastRunArgsForCall)]
+	fake.lastRunArgsForCall = append(fake.lastRunArgsForCall, struct {
+	}{})
+	stub := fake.LastRunStub
+	fakeReturns := fake.lastRunReturns
+	fake.recordInvocation("LastRun", []interface{}{})
+	fake.lastRunMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CleanerStatus) LastRunCallCount() int {
+	fake.lastRunMutex.RLock()
+	defer fake.lastRunMutex.RUnlock()
+	return len(fake.lastRunArgsForCall)
+}
+
+func (fake *CleanerStatus) LastRunCalls(stub func() cleaner.RunResult) {
+	fake.lastRunMutex.Lock()
+	defer fake.lastRunMutex.Unlock()
+	fake.LastRunStub = stub
+}
+
+func (fake *CleanerStatus) LastRunReturns(result1 cleaner.RunResult) {
+	fake.lastRunMutex.Lock()
+	defer fake.lastRunMutex.Unlock()
+	fake.LastRunStub = nil
+	fake.lastRunReturns = struct {
+		result1 cleaner.RunResult
+	}{result1}
+}
+
+func (fake *CleanerStatus) LastRunReturnsOnCall(i int, result1 cleaner.RunResult) {
+	fake.lastRunMutex.Lock()
+	defer fake.lastRunMutex.Unlock()
+	fake.LastRunStub = nil
+	if fake.lastRunReturnsOnCall == nil {
+		fake.lastRunReturnsOnCall = make(map[int]struct {
+			result1 cleaner.RunResult
+		})
+	}
+	fake.lastRunReturnsOnCall[i] = struct {
+		result1 cleaner.RunResult
+	}{result1}
+}
+
+func (fake *CleanerStatus) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.lastRunMutex.RLock()
+	defer fake.lastRunMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CleanerStatus) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
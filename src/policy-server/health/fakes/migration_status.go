@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/store/migrations"
+	"sync"
+)
+
+type MigrationStatus struct {
+	StatusStub        func(string, migrations.MigrationDb) ([]migrations.MigrationStatus, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+		arg1 string
+		arg2 migrations.MigrationDb
+	}
+	statusReturns struct {
+		result1 []migrations.MigrationStatus
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 []migrations.MigrationStatus
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *MigrationStatus) Status(arg1 string, arg2 migrations.MigrationDb) ([]migrations.MigrationStatus, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+		arg1 string
+		arg2 migrations.MigrationDb
+	}{arg1, arg2})
+	stub := fake.StatusStub
+	fakeReturns := fake.statusReturns
+	fake.recordInvocation("Status", []interface{}{arg1, arg2})
+	fake.statusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *MigrationStatus) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *MigrationStatus) StatusCalls(stub func(string, migrations.MigrationDb) ([]migrations.MigrationStatus, error)) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *MigrationStatus) StatusArgsForCall(i int) (string, migrations.MigrationDb) {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	argsForCall := fake.statusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *MigrationStatus) StatusReturns(result1 []migrations.MigrationStatus, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 []migrations.MigrationStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MigrationStatus) StatusReturnsOnCall(i int, result1 []migrations.MigrationStatus, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 []migrations.MigrationStatus
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 []migrations.MigrationStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MigrationStatus) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *MigrationStatus) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -107,9 +107,9 @@ var _ = Describe("External API Adding Policies", func() {
 		v0RequestMissingProtocol := `{ "policies": [ {"source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "port": 8080 } } ] }`
 		v0Response := `{ "total_policies": 1, "policies": [ { "source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "port": 8080 } } ]}`
 
-		missingStartPortResponse := `{ "error": "mapper: validate policies: missing start port" }`
-		missingPortResponse := `{ "error": "mapper: validate policies: missing port" }`
-		invalidProtocolResponse := `{ "error": "mapper: validate policies: invalid destination protocol, specify either udp or tcp" }`
+		missingPortsResponse := `{ "error": "invalid request body: policies[0].destination.ports is required" }`
+		missingPortResponse := `{ "error": "invalid request body: policies[0].destination.port is required" }`
+		missingProtocolResponse := `{ "error": "invalid request body: policies[0].destination.protocol is required" }`
 
 		DescribeTable("adding policies succeeds", addPoliciesSucceeds,
 			Entry("v1", "v1", v1Request, v1Response),
@@ -117,11 +117,11 @@ var _ = Describe("External API Adding Policies", func() {
 		)
 
 		DescribeTable("failure cases", addPoliciesFails,
-			Entry("v1: missing ports", "v1", v0Request, missingStartPortResponse),
-			Entry("v1: missing protocol", "v1", v1RequestMissingProtocol, invalidProtocolResponse),
+			Entry("v1: missing ports", "v1", v0Request, missingPortsResponse),
+			Entry("v1: missing protocol", "v1", v1RequestMissingProtocol, missingProtocolResponse),
 
 			Entry("v0: missing port", "v0", v1Request, missingPortResponse),
-			Entry("v0: missing protocol", "v0", v0RequestMissingProtocol, invalidProtocolResponse),
+			Entry("v0: missing protocol", "v0", v0RequestMissingProtocol, missingProtocolResponse),
 		)
 	})
 })
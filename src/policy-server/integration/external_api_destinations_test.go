@@ -1,12 +1,15 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"policy-server/api"
 	"policy-server/config"
 	"policy-server/integration/helpers"
 	"regexp"
+	"strings"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
@@ -155,6 +158,255 @@ var _ = Describe("External Destination API", func() {
 			))
 		})
 	})
+
+	Describe("overlapping destinations", func() {
+		BeforeEach(func() {
+			resp := helpers.MakeAndDoRequest("POST", destinationsURL, nil, bytes.NewBufferString(`{
+				"destinations": [{
+					"name": "wide",
+					"ips": [{"cidr": "23.96.32.0/24"}],
+					"ports": [{"start": 8080, "end": 8090}],
+					"protocol": "tcp"
+				}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+		})
+
+		It("rejects duplicates and warns about overlaps", func() {
+			resp := helpers.MakeAndDoRequest("POST", destinationsURL, nil, bytes.NewBufferString(`{
+				"destinations": [{
+					"name": "copy",
+					"ips": [{"start": "23.96.32.0", "end": "23.96.32.255"}],
+					"ports": [{"start": 8080, "end": 8090}],
+					"protocol": "tcp"
+				}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			responseBytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseBytes).To(MatchJSON(`{"error": "invalid egress destinations: destination copy duplicates existing destination wide"}`))
+
+			resp = helpers.MakeAndDoRequest("POST", destinationsURL, nil, bytes.NewBufferString(`{
+				"destinations": [{
+					"name": "narrow",
+					"ips": [{"start": "23.96.32.148", "end": "23.96.32.148"}],
+					"ports": [{"start": 8080, "end": 8080}],
+					"protocol": "tcp"
+				}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			responseBytes, err = ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBytes)).To(ContainSubstring(`"warnings":["destination narrow overlaps existing destination wide"]`))
+
+			resp = helpers.MakeAndDoRequest("GET", destinationsURL+"/overlaps", nil, nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			responseBytes, err = ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBytes)).To(WithTransform(replaceGUID, MatchJSON(`{
+				"total_overlaps": 1,
+				"overlaps": [{
+					"duplicate": false,
+					"destinations": [
+						{
+							"id": "<replaced>",
+							"name": "wide",
+							"ips": [{"start": "23.96.32.0", "end": "23.96.32.255"}],
+							"ports": [{"start": 8080, "end": 8090}],
+							"protocol": "tcp"
+						},
+						{
+							"id": "<replaced>",
+							"name": "narrow",
+							"ips": [{"start": "23.96.32.148", "end": "23.96.32.148"}],
+							"ports": [{"start": 8080, "end": 8080}],
+							"protocol": "tcp"
+						}
+					]
+				}]
+			}`)))
+		})
+	})
+
+	Describe("batches", func() {
+		var batchURL string
+
+		BeforeEach(func() {
+			batchURL = destinationsURL + "/batch"
+		})
+
+		It("creates none of an all or nothing batch when one item fails", func() {
+			resp := helpers.MakeAndDoRequest("POST", batchURL, nil, bytes.NewBufferString(`{
+				"destinations": [
+					{"name": "db", "ips": [{"start": "23.96.34.1", "end": "23.96.34.1"}], "protocol": "tcp"},
+					{"name": "broken", "ips": [], "protocol": "tcp"}
+				]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			responseBytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseBytes).To(MatchJSON(`{
+				"mode": "all_or_nothing",
+				"total": 2,
+				"succeeded": 0,
+				"failed": 1,
+				"results": [
+					{"index": 0, "status": "skipped"},
+					{"index": 1, "status": "failed", "error": "validate destinations: expected exactly one iprange"}
+				]
+			}`))
+
+			resp = helpers.MakeAndDoRequest("GET", destinationsURL+"?name=db", nil, nil)
+			responseBytes, err = ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseBytes).To(MatchJSON(`{"total_destinations": 0, "destinations": []}`))
+		})
+
+		It("creates and deletes what it can of a best effort batch", func() {
+			resp := helpers.MakeAndDoRequest("POST", batchURL, nil, bytes.NewBufferString(`{
+				"mode": "best_effort",
+				"destinations": [
+					{"name": "db", "ips": [{"start": "23.96.34.1", "end": "23.96.34.1"}], "protocol": "tcp"},
+					{"name": "db", "ips": [{"start": "23.96.34.2", "end": "23.96.34.2"}], "protocol": "tcp"}
+				]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusMultiStatus))
+			var created api.BatchPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+			Expect(created.Succeeded).To(Equal(1))
+			Expect(created.Results[1].Error).To(Equal("invalid egress destination: destination name db is already taken"))
+
+			resp = helpers.MakeAndDoRequest("POST", batchURL+"/delete", nil, bytes.NewBufferString(fmt.Sprintf(`{
+				"mode": "best_effort",
+				"ids": ["%s", "missing-guid"]
+			}`, created.Results[0].ID)))
+			Expect(resp.StatusCode).To(Equal(http.StatusMultiStatus))
+			var deleted api.BatchPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&deleted)).To(Succeed())
+			Expect(deleted.Results[0].Status).To(Equal(api.BatchStatusDeleted))
+			Expect(deleted.Results[1].Error).To(Equal("destination missing-guid not found"))
+		})
+
+		It("fails only the malformed items of a best effort batch", func() {
+			resp := helpers.MakeAndDoRequest("POST", batchURL, nil, bytes.NewBufferString(`{
+				"mode": "best_effort",
+				"destinations": [
+					{"name": "db", "ips": [{"start": "23.96.34.1", "end": "23.96.34.1"}], "protocol": "tcp"},
+					{"name": "broken", "ips": "23.96.34.2", "protocol": "tcp"}
+				]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusMultiStatus))
+			var created api.BatchPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+			Expect(created.Succeeded).To(Equal(1))
+			Expect(created.Results[0].Status).To(Equal(api.BatchStatusCreated))
+			Expect(created.Results[1].Status).To(Equal(api.BatchStatusFailed))
+			Expect(created.Results[1].Error).To(Equal("unmarshal json: json: cannot unmarshal string into Go struct field EgressDestination.ips of type []api.IPRange"))
+
+			egressPoliciesBatchURL := strings.Replace(batchURL, "/destinations", "/egress_policies", 1)
+			resp = helpers.MakeAndDoRequest("POST", egressPoliciesBatchURL, nil, bytes.NewBufferString(`{
+				"mode": "best_effort",
+				"egress_policies": [
+					{"source": {"id": 5}, "destination": {"name": "db"}},
+					{"source": {"id": "live-app-1-guid"}, "destination": {"name": "db"}}
+				]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusMultiStatus))
+			var createdPolicies api.BatchPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&createdPolicies)).To(Succeed())
+			Expect(createdPolicies.Succeeded).To(Equal(1))
+			Expect(createdPolicies.Results[0].Error).To(Equal("unmarshal json: json: cannot unmarshal number into Go struct field EgressPolicy.source.id of type string"))
+			Expect(createdPolicies.Results[1].Status).To(Equal(api.BatchStatusCreated))
+		})
+
+		It("deletes egress policies by id and keeps their destination", func() {
+			resp := helpers.MakeAndDoRequest("POST", batchURL, nil, bytes.NewBufferString(`{
+				"destinations": [{"name": "db", "ips": [{"start": "23.96.34.1", "end": "23.96.34.1"}], "protocol": "tcp"}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			egressPoliciesBatchURL := strings.Replace(batchURL, "/destinations", "/egress_policies", 1)
+			resp = helpers.MakeAndDoRequest("POST", egressPoliciesBatchURL, nil, bytes.NewBufferString(`{
+				"egress_policies": [{"source": {"id": "live-app-1-guid"}, "destination": {"name": "db"}}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			var created api.BatchPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+
+			resp = helpers.MakeAndDoRequest("POST", egressPoliciesBatchURL+"/delete", nil, bytes.NewBufferString(fmt.Sprintf(`{
+				"ids": ["%s"]
+			}`, created.Results[0].ID)))
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			resp = helpers.MakeAndDoRequest("GET", destinationsURL+"?name=db", nil, nil)
+			responseBytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBytes)).To(ContainSubstring(`"total_destinations":1`))
+		})
+	})
+
+	Describe("looking up destinations", func() {
+		var dbGUID string
+
+		BeforeEach(func() {
+			resp := helpers.MakeAndDoRequest("POST", destinationsURL, nil, bytes.NewBufferString(`{
+				"destinations": [
+					{"name": "db", "ips": [{"start": "23.96.33.1", "end": "23.96.33.1"}], "ports": [{"start": 5432, "end": 5432}], "protocol": "tcp"},
+					{"name": "dns", "ips": [{"start": "23.96.33.2", "end": "23.96.33.2"}], "ports": [{"start": 53, "end": 53}], "protocol": "udp"}
+				]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			var created api.DestinationsPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+			dbGUID = created.EgressDestinations[0].GUID
+		})
+
+		It("finds destinations by name and by id", func() {
+			resp := helpers.MakeAndDoRequest("GET", destinationsURL+"?name=dns", nil, nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			responseBytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBytes)).To(WithTransform(replaceGUID, MatchJSON(`{
+				"total_destinations": 1,
+				"destinations": [{"id": "<replaced>", "name": "dns", "ips": [{"start": "23.96.33.2", "end": "23.96.33.2"}], "ports": [{"start": 53, "end": 53}], "protocol": "udp"}]
+			}`)))
+
+			resp = helpers.MakeAndDoRequest("GET", destinationsURL+"/"+dbGUID, nil, nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			responseBytes, err = ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBytes)).To(ContainSubstring(`"name":"db"`))
+
+			resp = helpers.MakeAndDoRequest("GET", destinationsURL+"/missing-guid", nil, nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("rejects a name that is already taken", func() {
+			resp := helpers.MakeAndDoRequest("POST", destinationsURL, nil, bytes.NewBufferString(`{
+				"destinations": [{"name": "db", "ips": [{"start": "23.96.33.9", "end": "23.96.33.9"}], "protocol": "tcp"}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			responseBytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responseBytes).To(MatchJSON(`{"error": "invalid egress destinations: destination name db is already taken"}`))
+		})
+
+		It("creates egress policies for a destination given by name", func() {
+			egressPoliciesURL := strings.Replace(destinationsURL, "/destinations", "/egress_policies", 1)
+			resp := helpers.MakeAndDoRequest("POST", egressPoliciesURL, nil, bytes.NewBufferString(`{
+				"egress_policies": [{"source": {"id": "live-app-1-guid"}, "destination": {"name": "db"}}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			responseBytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBytes)).To(ContainSubstring(fmt.Sprintf(`"destination":{"id":"%s"}`, dbGUID)))
+
+			resp = helpers.MakeAndDoRequest("POST", egressPoliciesURL, nil, bytes.NewBufferString(`{
+				"egress_policies": [{"source": {"id": "live-app-1-guid"}, "destination": {"name": "missing"}}]
+			}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
 })
 
 var replaceGUIDRegex = regexp.MustCompile(`"id":"[^"]*"`)
@@ -28,7 +28,6 @@ var _ = Describe("External API Egress Policies", func() {
 		logger            lager.Logger
 
 		fakeMetron metrics.FakeMetron
-		token      string
 	)
 
 	BeforeEach(func() {
@@ -43,9 +42,7 @@ var _ = Describe("External API Egress Policies", func() {
 		conf = policyServerConfs[0]
 		logger = lager.NewLogger("psclient")
 
-		client = psclient.NewClient(logger, http.DefaultClient, fmt.Sprintf("http://%s:%d", conf.ListenHost, conf.ListenPort))
-
-		token = "valid-token"
+		client = psclient.NewClient(logger, http.DefaultClient, fmt.Sprintf("http://%s:%d", conf.ListenHost, conf.ListenPort), psclient.StaticToken("valid-token"))
 	})
 
 	AfterEach(func() {
@@ -69,7 +66,7 @@ var _ = Describe("External API Egress Policies", func() {
 				},
 			},
 		}
-		destGuid, err := client.CreateDestination(someDest, token)
+		destGuid, err := client.CreateDestination(someDest)
 		Expect(err).NotTo(HaveOccurred())
 
 		somePolicy := psclient.EgressPolicy{
@@ -81,11 +78,11 @@ var _ = Describe("External API Egress Policies", func() {
 				ID: destGuid,
 			},
 		}
-		_, err = client.CreateEgressPolicy(somePolicy, token)
+		_, err = client.CreateEgressPolicy(somePolicy)
 		Expect(err).NotTo(HaveOccurred())
 
 		//TODO: re-instate when index is an endpoint
-		//egressPolicies, err := client.ListEgressPolicies(token)
+		//egressPolicies, err := client.ListEgressPolicies()
 		//Expect(err).NotTo(HaveOccurred())
 		//Expect(egressPolicies).To(ConsistOf(somePolicy))
 	})
@@ -188,6 +188,7 @@ func DefaultTestConfigWithCCServer(dbConfig db.Config, metronAddress string, fix
 		RequestTimeout:                  10,
 		MaxPolicies:                     2,
 		EnableSpaceDeveloperSelfService: false,
+		EnableEgressSelfService:         false,
 		DatabaseMigrationTimeout:        600,
 	}
 
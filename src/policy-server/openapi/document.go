@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tedsuo/rata"
+)
+
+const openAPIVersion = "3.0.3"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	requestSchemas map[string]*Schema
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem holds the operations of a path by lower case HTTP method.
+type PathItem map[string]*OperationObject
+
+type OperationObject struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewDocument describes the routes of the given API version. Every route must
+// have an entry in Operations, so that the document cannot leave a route out.
+func NewDocument(version string, routes rata.Routes) (*Document, error) {
+	document := &Document{
+		OpenAPI: openAPIVersion,
+		Info: Info{
+			Title:   "Policy Server External API",
+			Version: version,
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"uaa": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "A UAA token. The scopes each operation needs are in its description.",
+				},
+			},
+		},
+		requestSchemas: map[string]*Schema{},
+	}
+
+	for _, route := range routes {
+		operation, ok := Operations[route.Name]
+		if !ok {
+			return nil, fmt.Errorf("no operation for route %s", route.Name)
+		}
+
+		object, err := operation.object(route.Name, version)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %s", route.Name, err)
+		}
+		if object.RequestBody != nil {
+			document.requestSchemas[route.Name] = object.RequestBody.Content["application/json"].Schema
+		}
+
+		path := openAPIPath(route.Path, version)
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = object
+	}
+	return document, nil
+}
+
+// ValidateRequest checks a request body against the schema of its route. A
+// route without a request body accepts any body.
+func (d *Document) ValidateRequest(routeName string, body []byte) error {
+	schema, ok := d.requestSchemas[routeName]
+	if !ok {
+		return nil
+	}
+
+	var value interface{}
+	err := json.Unmarshal(body, &value)
+	if err != nil {
+		return fmt.Errorf("body must be valid json: %s", err)
+	}
+	return schema.Validate(value)
+}
+
+// Documents holds a document by API version.
+type Documents map[string]*Document
+
+func NewDocuments(routes rata.Routes, versions ...string) (Documents, error) {
+	documents := Documents{}
+	for _, version := range versions {
+		document, err := NewDocument(version, routes)
+		if err != nil {
+			return nil, fmt.Errorf("api %s: %s", version, err)
+		}
+		documents[version] = document
+	}
+	return documents, nil
+}
+
+func (d Documents) ValidateRequest(version, routeName string, body []byte) error {
+	document, ok := d[version]
+	if !ok {
+		return nil
+	}
+	return document.ValidateRequest(routeName, body)
+}
+
+// Marshal returns the JSON of each document by API version.
+func (d Documents) Marshal() (map[string][]byte, error) {
+	marshalled := map[string][]byte{}
+	for version, document := range d {
+		documentBytes, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s document: %s", version, err)
+		}
+		marshalled[version] = documentBytes
+	}
+	return marshalled, nil
+}
+
+// openAPIPath turns a rata path into an OpenAPI one, with the version filled
+// in and any other parameter in braces.
+func openAPIPath(path, version string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == ":version" {
+			segments[i] = version
+		} else if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (o Operation) object(routeName, version string) (*OperationObject, error) {
+	object := &OperationObject{
+		OperationID: routeName,
+		Summary:     o.Summary,
+		Parameters:  o.Parameters,
+		Responses:   map[string]Response{},
+	}
+
+	if len(o.Scopes) > 0 {
+		object.Description = fmt.Sprintf("Requires one of the scopes: %s.", strings.Join(o.Scopes, ", "))
+		object.Security = []map[string][]string{{"uaa": {}}}
+	}
+
+	if request, ok := forVersion(o.Request, version); ok {
+		schema, err := SchemaFor(reflect.TypeOf(request))
+		if err != nil {
+			return nil, fmt.Errorf("request schema: %s", err)
+		}
+		object.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: schema}},
+		}
+	}
+
+	response := Response{Description: o.ResponseDescription}
+	if responseBody, ok := forVersion(o.Response, version); ok {
+		schema, err := SchemaFor(reflect.TypeOf(responseBody))
+		if err != nil {
+			return nil, fmt.Errorf("response schema: %s", err)
+		}
+		response.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	status := o.ResponseStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+	object.Responses[strconv.Itoa(status)] = response
+
+	if object.RequestBody != nil {
+		object.Responses["400"] = Response{Description: "The request body does not match the schema, or is otherwise invalid."}
+	}
+	if len(o.Scopes) > 0 {
+		object.Responses["401"] = Response{Description: "The authorization header is missing."}
+		object.Responses["403"] = Response{Description: "The token is invalid or lacks the scopes required."}
+		object.Responses["429"] = Response{Description: "Too many requests. Retry after the seconds in the Retry-After header."}
+	}
+	return object, nil
+}
+
+func forVersion(byVersion map[string]interface{}, version string) (interface{}, bool) {
+	if value, ok := byVersion[version]; ok {
+		return value, true
+	}
+	value, ok := byVersion[""]
+	return value, ok
+}
@@ -0,0 +1,130 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"policy-server/openapi"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Document", func() {
+	var routes rata.Routes
+
+	BeforeEach(func() {
+		routes = rata.Routes{
+			{Name: "uptime", Method: "GET", Path: "/"},
+			{Name: "create_policies", Method: "POST", Path: "/networking/:version/external/policies"},
+			{Name: "policies_index", Method: "GET", Path: "/networking/:version/external/policies"},
+			{Name: "destinations_create", Method: "POST", Path: "/networking/:version/external/destinations"},
+		}
+	})
+
+	It("describes every route of the version", func() {
+		document, err := openapi.NewDocument("v1", routes)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(document.OpenAPI).To(Equal("3.0.3"))
+		Expect(document.Info.Version).To(Equal("v1"))
+		Expect(document.Paths).To(HaveLen(3))
+		Expect(document.Paths["/"]).To(HaveKey("get"))
+		Expect(document.Paths["/networking/v1/external/policies"]).To(HaveKey("get"))
+		Expect(document.Paths["/networking/v1/external/policies"]).To(HaveKey("post"))
+
+		create := document.Paths["/networking/v1/external/policies"]["post"]
+		Expect(create.OperationID).To(Equal("create_policies"))
+		Expect(create.Description).To(Equal("Requires one of the scopes: network.admin, network.write."))
+		Expect(create.Security).To(Equal([]map[string][]string{{"uaa": {}}}))
+		Expect(create.RequestBody.Required).To(BeTrue())
+		Expect(create.Responses).To(HaveKey("200"))
+		Expect(create.Responses).To(HaveKey("400"))
+		Expect(create.Responses).To(HaveKey("429"))
+
+		Expect(document.Paths["/networking/v1/external/destinations"]["post"].Responses).To(HaveKey("201"))
+
+		index := document.Paths["/networking/v1/external/policies"]["get"]
+		Expect(index.RequestBody).To(BeNil())
+		Expect(index.Parameters).To(HaveLen(3))
+	})
+
+	It("marshals to json", func() {
+		documents, err := openapi.NewDocuments(routes, "v0", "v1")
+		Expect(err).NotTo(HaveOccurred())
+
+		marshalled, err := documents.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(marshalled).To(HaveLen(2))
+
+		var document map[string]interface{}
+		Expect(json.Unmarshal(marshalled["v0"], &document)).To(Succeed())
+		Expect(document).To(HaveKeyWithValue("openapi", "3.0.3"))
+		Expect(document["paths"]).To(HaveKey("/networking/v0/external/policies"))
+	})
+
+	It("describes every external route", func() {
+		for name := range openapi.Operations {
+			_, err := openapi.NewDocument("v1", rata.Routes{{Name: name, Method: "GET", Path: "/"}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	Context("when a route has no operation", func() {
+		It("returns an error", func() {
+			routes = append(routes, rata.Route{Name: "potato", Method: "GET", Path: "/potato"})
+			_, err := openapi.NewDocuments(routes, "v1")
+			Expect(err).To(MatchError("api v1: no operation for route potato"))
+		})
+	})
+
+	Describe("ValidateRequest", func() {
+		var documents openapi.Documents
+
+		BeforeEach(func() {
+			var err error
+			documents, err = openapi.NewDocuments(routes, "v0", "v1")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the body against the schema of the route and version", func() {
+			v1Request := `{"policies": [{"source": {"id": "some-app-guid"}, "destination": {"id": "some-other-app-guid", "protocol": "tcp", "ports": {"start": 8080, "end": 8080}}}]}`
+			v0Request := `{"policies": [{"source": {"id": "some-app-guid"}, "destination": {"id": "some-other-app-guid", "protocol": "tcp", "port": 8080}}]}`
+
+			Expect(documents.ValidateRequest("v1", "create_policies", []byte(v1Request))).To(Succeed())
+			Expect(documents.ValidateRequest("v0", "create_policies", []byte(v0Request))).To(Succeed())
+
+			Expect(documents.ValidateRequest("v1", "create_policies", []byte(v0Request))).To(MatchError("policies[0].destination.ports is required"))
+			Expect(documents.ValidateRequest("v0", "create_policies", []byte(v1Request))).To(MatchError("policies[0].destination.port is required"))
+		})
+
+		It("leaves the items of a batch for the handler to validate one by one", func() {
+			documents, err := openapi.NewDocuments(rata.Routes{
+				{Name: "destinations_batch_create", Method: "POST", Path: "/networking/:version/external/destinations/batch"},
+				{Name: "egress_policies_batch_create", Method: "POST", Path: "/networking/:version/external/egress_policies/batch"},
+			}, "v1")
+			Expect(err).NotTo(HaveOccurred())
+
+			destinations := `{"mode": "best_effort", "destinations": [{"name": "db", "ips": "23.96.34.2", "protocol": 6}]}`
+			egressPolicies := `{"mode": "best_effort", "egress_policies": [{"source": {"id": 5}}]}`
+
+			Expect(documents.ValidateRequest("v1", "destinations_batch_create", []byte(destinations))).To(Succeed())
+			Expect(documents.ValidateRequest("v1", "egress_policies_batch_create", []byte(egressPolicies))).To(Succeed())
+
+			Expect(documents.ValidateRequest("v1", "destinations_batch_create", []byte(`{"destinations": ["db"]}`))).To(MatchError("destinations[0] must be an object"))
+			Expect(documents.ValidateRequest("v1", "egress_policies_batch_create", []byte(`{"mode": "sometimes", "egress_policies": []}`))).To(MatchError("mode must be one of all_or_nothing, best_effort"))
+		})
+
+		It("rejects a body that is not json", func() {
+			err := documents.ValidateRequest("v1", "create_policies", []byte(`{`))
+			Expect(err).To(MatchError(ContainSubstring("body must be valid json")))
+		})
+
+		It("accepts any body for routes without a request body", func() {
+			Expect(documents.ValidateRequest("v1", "policies_index", []byte(""))).To(Succeed())
+		})
+
+		It("accepts any body for unknown versions", func() {
+			Expect(documents.ValidateRequest("v9", "create_policies", []byte(""))).To(Succeed())
+		})
+	})
+})
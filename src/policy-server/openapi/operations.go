@@ -0,0 +1,278 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"policy-server/api"
+	"policy-server/api/api_v0"
+	"policy-server/asg"
+	"policy-server/handlers"
+	"policy-server/health"
+)
+
+// Operation describes a route of the external API. Request and Response hold
+// a value of the type of the JSON body, by API version, where the "" version
+// applies to every version without its own entry.
+type Operation struct {
+	Summary             string
+	Scopes              []string
+	Parameters          []Parameter
+	Request             map[string]interface{}
+	Response            map[string]interface{}
+	ResponseStatus      int
+	ResponseDescription string
+}
+
+var (
+	adminScopes = []string{"network.admin"}
+	writeScopes = []string{"network.admin", "network.write"}
+)
+
+type tagsResponse struct {
+	Tags []api.Tag `json:"tags"`
+}
+
+type emptyResponse struct{}
+
+// The items of a batch are only required to be objects. Each is parsed and
+// validated on its own, so that a malformed item fails alone in a best_effort
+// batch instead of the whole request.
+type destinationsBatchRequest struct {
+	Mode         string                   `json:"mode" openapi:"enum=all_or_nothing|best_effort"`
+	Destinations []map[string]interface{} `json:"destinations" openapi:"required"`
+}
+
+type egressPoliciesBatchRequest struct {
+	Mode           string                   `json:"mode" openapi:"enum=all_or_nothing|best_effort"`
+	EgressPolicies []map[string]interface{} `json:"egress_policies" openapi:"required"`
+}
+
+type batchDeleteRequest struct {
+	Mode string   `json:"mode" openapi:"enum=all_or_nothing|best_effort"`
+	IDs  []string `json:"ids" openapi:"required"`
+}
+
+const batchResponseDescription = "The result of each item, by index. Every item succeeded when the status is %s. Otherwise, a 400 means an all_or_nothing batch changed nothing, with valid items skipped, and a 207 means a best_effort batch applied only the items that succeeded. A batch over max_batch_size items fails with a 400."
+
+func commaSeparatedQuery(name, description string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "string"},
+	}
+}
+
+// Operations holds the operation of each external route by route name.
+var Operations = map[string]Operation{
+	"uptime": {
+		Summary:             "Report the uptime of the server",
+		ResponseDescription: "The uptime, as plain text.",
+	},
+	"health": {
+		Summary: "Check the health of the server",
+		Parameters: []Parameter{{
+			Name:        "detailed",
+			In:          "query",
+			Description: "When true, run and report every health check.",
+			Schema:      &Schema{Type: "boolean"},
+		}},
+		Response:            map[string]interface{}{"": health.Report{}},
+		ResponseDescription: "The server is healthy. The detailed report is returned with a 503 when it is not.",
+	},
+	"liveness": {
+		Summary:             "Check the server is serving requests",
+		ResponseDescription: "The server is live.",
+	},
+	"readiness": {
+		Summary:             "Check the server can handle requests",
+		Response:            map[string]interface{}{"": health.Report{}},
+		ResponseDescription: "The server is ready. The report is returned with a 503 when it is not.",
+	},
+	"openapi": {
+		Summary:             "Get the OpenAPI document of an API version",
+		Response:            map[string]interface{}{"": map[string]interface{}{}},
+		ResponseDescription: "The OpenAPI document.",
+	},
+	"whoami": {
+		Summary:             "Get the user of the token",
+		Scopes:              adminScopes,
+		Response:            map[string]interface{}{"": handlers.WhoAmIResponse{}},
+		ResponseDescription: "The user name.",
+	},
+	"create_policies": {
+		Summary: "Create policies",
+		Scopes:  writeScopes,
+		Request: map[string]interface{}{
+			"":   api.PoliciesPayload{},
+			"v0": api_v0.Policies{},
+		},
+		Response:            map[string]interface{}{"": emptyResponse{}},
+		ResponseDescription: "The policies were created.",
+	},
+	"delete_policies": {
+		Summary: "Delete policies",
+		Scopes:  writeScopes,
+		Request: map[string]interface{}{
+			"":   api.PoliciesPayload{},
+			"v0": api_v0.Policies{},
+		},
+		Response:            map[string]interface{}{"": emptyResponse{}},
+		ResponseDescription: "The policies were deleted.",
+	},
+	"policies_index": {
+		Summary: "List policies",
+		Scopes:  writeScopes,
+		Parameters: []Parameter{
+			commaSeparatedQuery("id", "Comma separated policy group ids. Only policies with one of them as source or destination are listed."),
+			commaSeparatedQuery("source_id", "Comma separated policy group ids. Only policies with one of them as source are listed."),
+			commaSeparatedQuery("dest_id", "Comma separated policy group ids. Only policies with one of them as destination are listed."),
+		},
+		Response: map[string]interface{}{
+			"":   api.PolicyCollectionPayload{},
+			"v0": api_v0.Policies{},
+		},
+		ResponseDescription: "The policies.",
+	},
+	"destinations_index": {
+		Summary: "List egress destinations",
+		Scopes:  adminScopes,
+		Parameters: []Parameter{{
+			Name:        "name",
+			In:          "query",
+			Description: "Only the destination with this name is listed.",
+			Schema:      &Schema{Type: "string"},
+		}},
+		Response:            map[string]interface{}{"": api.DestinationsPayload{}},
+		ResponseDescription: "The egress destinations.",
+	},
+	"destinations_create": {
+		Summary:             "Create egress destinations",
+		Scopes:              adminScopes,
+		Request:             map[string]interface{}{"": api.DestinationsPayload{}},
+		Response:            map[string]interface{}{"": api.DestinationsPayload{}},
+		ResponseStatus:      http.StatusCreated,
+		ResponseDescription: "The egress destinations, with their ids, and warnings about the existing destinations they overlap. Destinations that reach a forbidden range, duplicate another destination or take a name that is in use are rejected with a 400.",
+	},
+	"destinations_overlaps": {
+		Summary:             "List overlapping egress destinations",
+		Scopes:              adminScopes,
+		Response:            map[string]interface{}{"": api.DestinationOverlapsPayload{}},
+		ResponseDescription: "The destinations that reach a forbidden range, and each pair of destinations that allow some of the same traffic.",
+	},
+	"destinations_show": {
+		Summary: "Get an egress destination",
+		Scopes:  adminScopes,
+		Parameters: []Parameter{{
+			Name:        "id",
+			In:          "path",
+			Description: "The id of the destination.",
+			Required:    true,
+			Schema:      &Schema{Type: "string"},
+		}},
+		Response:            map[string]interface{}{"": api.DestinationsPayload{}},
+		ResponseDescription: "The destination. A 404 is returned when it does not exist.",
+	},
+	"destinations_delete": {
+		Summary: "Delete an egress destination",
+		Scopes:  adminScopes,
+		Parameters: []Parameter{{
+			Name:        "id",
+			In:          "path",
+			Description: "The id of the destination.",
+			Required:    true,
+			Schema:      &Schema{Type: "string"},
+		}},
+		Response:            map[string]interface{}{"": api.DestinationsPayload{}},
+		ResponseDescription: "The deleted destination. A 404 is returned when it does not exist, and a 409 when egress policies use it.",
+	},
+	"create_egress_policies": {
+		Summary:             "Create egress policies",
+		Scopes:              adminScopes,
+		Request:             map[string]interface{}{"": api.EgressPoliciesPayload{}},
+		Response:            map[string]interface{}{"": api.EgressPoliciesPayload{}},
+		ResponseStatus:      http.StatusCreated,
+		ResponseDescription: "The egress policies, with their ids. A destination may be given by name instead of id; a 400 is returned when no destination has that name. With egress self-service enabled, a user without network.admin may attach existing destinations to apps in spaces they belong to, and gets a 403 for any other source.",
+	},
+	"destinations_batch_create": {
+		Summary:             "Create many egress destinations, reporting each",
+		Scopes:              adminScopes,
+		Request:             map[string]interface{}{"": destinationsBatchRequest{}},
+		Response:            map[string]interface{}{"": api.BatchPayload{}},
+		ResponseStatus:      http.StatusCreated,
+		ResponseDescription: fmt.Sprintf(batchResponseDescription, "201") + " Each created item has the id of its destination.",
+	},
+	"destinations_batch_delete": {
+		Summary:             "Delete many egress destinations, reporting each",
+		Scopes:              adminScopes,
+		Request:             map[string]interface{}{"": batchDeleteRequest{}},
+		Response:            map[string]interface{}{"": api.BatchPayload{}},
+		ResponseDescription: fmt.Sprintf(batchResponseDescription, "200"),
+	},
+	"egress_policies_batch_create": {
+		Summary:             "Create many egress policies, reporting each",
+		Scopes:              adminScopes,
+		Request:             map[string]interface{}{"": egressPoliciesBatchRequest{}},
+		Response:            map[string]interface{}{"": api.BatchPayload{}},
+		ResponseStatus:      http.StatusCreated,
+		ResponseDescription: fmt.Sprintf(batchResponseDescription, "201") + " Each created item has the id of its egress policy.",
+	},
+	"egress_policies_batch_delete": {
+		Summary:             "Delete many egress policies by id, reporting each",
+		Scopes:              adminScopes,
+		Request:             map[string]interface{}{"": batchDeleteRequest{}},
+		Response:            map[string]interface{}{"": api.BatchPayload{}},
+		ResponseDescription: fmt.Sprintf(batchResponseDescription, "200") + " Their destinations are left in place.",
+	},
+	"cleanup": {
+		Summary: "Delete the policies of apps that no longer exist",
+		Scopes:  adminScopes,
+		Parameters: []Parameter{{
+			Name:        "dry_run",
+			In:          "query",
+			Description: "When true, return the policies that would be deleted without deleting them.",
+			Schema:      &Schema{Type: "boolean"},
+		}},
+		Response:            map[string]interface{}{"": api.PolicyCollectionPayload{}},
+		ResponseDescription: "The policies that were deleted, or would be on a dry run.",
+	},
+	"asg_import": {
+		Summary: "Import application security groups as egress destinations and policies",
+		Scopes:  adminScopes,
+		Parameters: []Parameter{{
+			Name:        "dry_run",
+			In:          "query",
+			Description: "When true, report what would be imported without creating anything.",
+			Schema:      &Schema{Type: "boolean"},
+		}},
+		Response:            map[string]interface{}{"": asg.Report{}},
+		ResponseDescription: "What was imported, what already existed and what could not be converted.",
+	},
+	"effective_egress": {
+		Summary: "Report the egress an app is allowed",
+		Scopes:  adminScopes,
+		Parameters: []Parameter{
+			{
+				Name:        "guid",
+				In:          "path",
+				Description: "The guid of the app.",
+				Required:    true,
+				Schema:      &Schema{Type: "string"},
+			},
+			{
+				Name:        "format",
+				In:          "query",
+				Description: "json, the default, or csv.",
+				Schema:      &Schema{Type: "string", Enum: []string{"json", "csv"}},
+			},
+		},
+		Response:            map[string]interface{}{"": api.EffectiveEgressPayload{}},
+		ResponseDescription: "The traffic the app may send, merged from the egress policies of the app, its space, its org and the default source, with the policies that allow each rule. A 404 is returned when the app does not exist.",
+	},
+	"tags_index": {
+		Summary:             "List the tags of policy groups",
+		Scopes:              adminScopes,
+		Response:            map[string]interface{}{"": tagsResponse{}},
+		ResponseDescription: "The tags.",
+	},
+}
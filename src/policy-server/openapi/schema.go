@@ -0,0 +1,280 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema is the subset of the OpenAPI schema object that the policy server
+// API needs.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// SchemaFor builds the schema of the JSON encoding of a Go type. Struct fields
+// are named by their json tag, and constrained by their openapi tag, a comma
+// separated list of:
+//
+//	required, enum=a|b, format=f, minimum=n, maximum=n, minItems=n, maxItems=n
+func SchemaFor(t reflect.Type) (*Schema, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return SchemaFor(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := SchemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := SchemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func structSchema(t reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema, err := SchemaFor(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", t.Name(), field.Name, err)
+		}
+		required, err := applyTag(fieldSchema, field.Tag.Get("openapi"))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", t.Name(), field.Name, err)
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = fieldSchema
+	}
+	sort.Strings(schema.Required)
+	return schema, nil
+}
+
+func applyTag(schema *Schema, tag string) (bool, error) {
+	required := false
+	if tag == "" {
+		return required, nil
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		parts := strings.SplitN(option, "=", 2)
+		key := parts[0]
+		if key == "required" {
+			required = true
+			continue
+		}
+		if len(parts) != 2 {
+			return false, fmt.Errorf("openapi tag option %s needs a value", key)
+		}
+
+		value := parts[1]
+		switch key {
+		case "enum":
+			schema.Enum = strings.Split(value, "|")
+		case "format":
+			schema.Format = value
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, fmt.Errorf("openapi tag option %s: %s", key, err)
+			}
+			if key == "minimum" {
+				schema.Minimum = &n
+			} else {
+				schema.Maximum = &n
+			}
+		case "minItems", "maxItems":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return false, fmt.Errorf("openapi tag option %s: %s", key, err)
+			}
+			if key == "minItems" {
+				schema.MinItems = &n
+			} else {
+				schema.MaxItems = &n
+			}
+		default:
+			return false, fmt.Errorf("unknown openapi tag option %s", key)
+		}
+	}
+	return required, nil
+}
+
+// Validate checks a value decoded from JSON against the schema. The error
+// names the path of the first value that does not match, going through the
+// fields of an object in the order of their names. A required field that is
+// null counts as missing.
+func (s *Schema) Validate(value interface{}) error {
+	return s.validate("", value)
+}
+
+func (s *Schema) validate(path string, value interface{}) error {
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return validationError(path, "must be an object")
+		}
+		for _, name := range s.Required {
+			if object[name] == nil {
+				return validationError(joinPath(path, name), "is required")
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fieldValue := object[name]
+			fieldSchema, ok := s.Properties[name]
+			if !ok {
+				fieldSchema = s.AdditionalProperties
+			}
+			if fieldSchema == nil || fieldValue == nil {
+				continue
+			}
+			if err := fieldSchema.validate(joinPath(path, name), fieldValue); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return validationError(path, "must be an array")
+		}
+		if s.MinItems != nil && len(array) < *s.MinItems {
+			return validationError(path, fmt.Sprintf("must have at least %d items", *s.MinItems))
+		}
+		if s.MaxItems != nil && len(array) > *s.MaxItems {
+			return validationError(path, fmt.Sprintf("must have at most %d items", *s.MaxItems))
+		}
+		for i, item := range array {
+			if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			return validationError(path, "must be a number")
+		}
+		if s.Type == "integer" && n != math.Trunc(n) {
+			return validationError(path, "must be an integer")
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			return validationError(path, fmt.Sprintf("must be at least %v", *s.Minimum))
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return validationError(path, fmt.Sprintf("must be at most %v", *s.Maximum))
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return validationError(path, "must be a string")
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, str) {
+			return validationError(path, fmt.Sprintf("must be one of %s", strings.Join(s.Enum, ", ")))
+		}
+		if err := validateFormat(s.Format, str); err != nil {
+			return validationError(path, err.Error())
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return validationError(path, "must be a boolean")
+		}
+	}
+	return nil
+}
+
+// validateFormat checks the string formats the API uses. Other formats are
+// only documentation.
+func validateFormat(format, str string) error {
+	switch format {
+	case "ipv4":
+		ip := net.ParseIP(str)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("must be an ipv4 address")
+		}
+	case "ip":
+		if net.ParseIP(str) == nil {
+			return fmt.Errorf("must be an ip address")
+		}
+	case "cidr":
+		if _, _, err := net.ParseCIDR(str); err != nil {
+			return fmt.Errorf("must be a cidr")
+		}
+	}
+	return nil
+}
+
+func validationError(path, message string) error {
+	if path == "" {
+		path = "body"
+	}
+	return fmt.Errorf("%s %s", path, message)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"policy-server/openapi"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testPayload struct {
+	Items []testItem          `json:"items" openapi:"required,minItems=1"`
+	Extra map[string]string   `json:"extra,omitempty"`
+	Any   interface{}         `json:"any,omitempty"`
+	Skip  string              `json:"-"`
+	Ptr   *testItem           `json:"ptr,omitempty"`
+	Flags map[string]bool     `json:"flags,omitempty"`
+	Meta  map[string]*float64 `json:"meta,omitempty"`
+}
+
+type testItem struct {
+	Name     string  `json:"name" openapi:"required,enum=a|b"`
+	Port     int     `json:"port" openapi:"minimum=1,maximum=65535"`
+	IP       string  `json:"ip,omitempty" openapi:"format=ipv4"`
+	Weight   float64 `json:"weight,omitempty"`
+	Disabled bool    `json:"disabled,omitempty"`
+}
+
+var _ = Describe("Schema", func() {
+	var schema *openapi.Schema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = openapi.SchemaFor(reflect.TypeOf(testPayload{}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("SchemaFor", func() {
+		It("describes the json encoding of the type", func() {
+			Expect(json.Marshal(schema)).To(MatchJSON(`{
+				"type": "object",
+				"required": ["items"],
+				"properties": {
+					"items": {
+						"type": "array",
+						"minItems": 1,
+						"items": {
+							"type": "object",
+							"required": ["name"],
+							"properties": {
+								"name": {"type": "string", "enum": ["a", "b"]},
+								"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+								"ip": {"type": "string", "format": "ipv4"},
+								"weight": {"type": "number"},
+								"disabled": {"type": "boolean"}
+							}
+						}
+					},
+					"extra": {"type": "object", "additionalProperties": {"type": "string"}},
+					"any": {},
+					"ptr": {
+						"type": "object",
+						"required": ["name"],
+						"properties": {
+							"name": {"type": "string", "enum": ["a", "b"]},
+							"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+							"ip": {"type": "string", "format": "ipv4"},
+							"weight": {"type": "number"},
+							"disabled": {"type": "boolean"}
+						}
+					},
+					"flags": {"type": "object", "additionalProperties": {"type": "boolean"}},
+					"meta": {"type": "object", "additionalProperties": {"type": "number"}}
+				}
+			}`))
+		})
+
+		Context("when an openapi tag is invalid", func() {
+			It("returns an error", func() {
+				type badTag struct {
+					Name string `json:"name" openapi:"pattern=.*"`
+				}
+				_, err := openapi.SchemaFor(reflect.TypeOf(badTag{}))
+				Expect(err).To(MatchError("badTag.Name: unknown openapi tag option pattern"))
+			})
+		})
+
+		Context("when a type has no json encoding", func() {
+			It("returns an error", func() {
+				type badType struct {
+					Callback func() `json:"callback"`
+				}
+				_, err := openapi.SchemaFor(reflect.TypeOf(badType{}))
+				Expect(err).To(MatchError("badType.Callback: unsupported type func()"))
+			})
+		})
+	})
+
+	Describe("Validate", func() {
+		validate := func(body string) error {
+			var value interface{}
+			Expect(json.Unmarshal([]byte(body), &value)).To(Succeed())
+			return schema.Validate(value)
+		}
+
+		It("accepts a matching value", func() {
+			Expect(validate(`{"items": [{"name": "a", "port": 80, "ip": "10.0.0.1", "weight": 0.5}], "unknown": 1}`)).To(Succeed())
+		})
+
+		It("accepts null for optional values", func() {
+			Expect(validate(`{"items": [{"name": "a"}], "ptr": null}`)).To(Succeed())
+		})
+
+		expectInvalid := func(body, message string) {
+			Expect(validate(body)).To(MatchError(message))
+		}
+
+		It("rejects values that do not match", func() {
+			expectInvalid(`[]`, "body must be an object")
+			expectInvalid(`{}`, "items is required")
+			expectInvalid(`{"items": {}}`, "items must be an array")
+			expectInvalid(`{"items": []}`, "items must have at least 1 items")
+			expectInvalid(`{"items": [{}]}`, "items[0].name is required")
+			expectInvalid(`{"items": [{"name": "c"}]}`, "items[0].name must be one of a, b")
+			expectInvalid(`{"items": [{"name": 1}]}`, "items[0].name must be a string")
+			expectInvalid(`{"items": [{"name": "a", "port": "80"}]}`, "items[0].port must be a number")
+			expectInvalid(`{"items": [{"name": "a", "port": 1.5}]}`, "items[0].port must be an integer")
+			expectInvalid(`{"items": [{"name": "a", "port": 0}]}`, "items[0].port must be at least 1")
+			expectInvalid(`{"items": [{"name": "a", "port": 65536}]}`, "items[0].port must be at most 65535")
+			expectInvalid(`{"items": [{"name": "a", "ip": "::1"}]}`, "items[0].ip must be an ipv4 address")
+			expectInvalid(`{"items": [{"name": "a", "disabled": "yes"}]}`, "items[0].disabled must be a boolean")
+			expectInvalid(`{"items": [{"name": "a"}], "extra": {"key": 1}}`, "extra.key must be a string")
+		})
+
+		It("rejects null for required values", func() {
+			expectInvalid(`{"items": null}`, "items is required")
+			expectInvalid(`{"items": [{"name": null}]}`, "items[0].name is required")
+		})
+
+		It("reports the first invalid field in the order of their names", func() {
+			for i := 0; i < 20; i++ {
+				expectInvalid(`{"items": [{"name": "a", "port": 0, "ip": "::1", "disabled": "yes"}]}`, "items[0].disabled must be a boolean")
+			}
+		})
+
+		It("validates ip and cidr formats of either family", func() {
+			ip := &openapi.Schema{Type: "string", Format: "ip"}
+			Expect(ip.Validate("10.0.0.1")).To(Succeed())
+			Expect(ip.Validate("2001:db8::1")).To(Succeed())
+			Expect(ip.Validate("10.0.0")).To(MatchError("body must be an ip address"))
+
+			cidr := &openapi.Schema{Type: "string", Format: "cidr"}
+			Expect(cidr.Validate("10.0.0.0/8")).To(Succeed())
+			Expect(cidr.Validate("2001:db8::/32")).To(Succeed())
+			Expect(cidr.Validate("2001:db8::/129")).To(MatchError("body must be a cidr"))
+		})
+	})
+})
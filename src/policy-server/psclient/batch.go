@@ -0,0 +1,73 @@
+package psclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"policy-server/api"
+)
+
+// CreateDestinationsBatch creates destinations in one request, in the
+// all_or_nothing or best_effort mode, and returns the result of each.
+func (c *Client) CreateDestinationsBatch(mode string, destinations []Destination) (api.BatchPayload, error) {
+	items, err := batchItems(len(destinations), func(i int) interface{} { return destinations[i] })
+	if err != nil {
+		return api.BatchPayload{}, err
+	}
+	return c.batch("/networking/v1/external/destinations/batch", api.BatchRequest{Mode: mode, Destinations: items})
+}
+
+// DeleteDestinationsBatch deletes destinations in one request, in the
+// all_or_nothing or best_effort mode, and returns the result of each.
+func (c *Client) DeleteDestinationsBatch(mode string, guids []string) (api.BatchPayload, error) {
+	return c.batch("/networking/v1/external/destinations/batch/delete", api.BatchRequest{Mode: mode, IDs: guids})
+}
+
+// CreateEgressPoliciesBatch creates egress policies in one request, in the
+// all_or_nothing or best_effort mode, and returns the result of each.
+func (c *Client) CreateEgressPoliciesBatch(mode string, egressPolicies []EgressPolicy) (api.BatchPayload, error) {
+	items, err := batchItems(len(egressPolicies), func(i int) interface{} { return egressPolicies[i] })
+	if err != nil {
+		return api.BatchPayload{}, err
+	}
+	return c.batch("/networking/v1/external/egress_policies/batch", api.BatchRequest{Mode: mode, EgressPolicies: items})
+}
+
+// DeleteEgressPoliciesBatch deletes egress policies in one request, in the
+// all_or_nothing or best_effort mode, and returns the result of each.
+func (c *Client) DeleteEgressPoliciesBatch(mode string, guids []string) (api.BatchPayload, error) {
+	return c.batch("/networking/v1/external/egress_policies/batch/delete", api.BatchRequest{Mode: mode, IDs: guids})
+}
+
+// batch returns the results of a batch whether or not every item was
+// applied: the server responds to a failed all_or_nothing batch with 400 and
+// its results, and Failed counts the items that were not applied. Only
+// requests it rejected as a whole return an error.
+func (c *Client) batch(route string, request api.BatchRequest) (api.BatchPayload, error) {
+	var response api.BatchPayload
+	err := c.do("POST", route, request, &response)
+	if err == nil {
+		return response, nil
+	}
+
+	apiErr, ok := err.(*Error)
+	if !ok || apiErr.StatusCode != http.StatusBadRequest {
+		return api.BatchPayload{}, err
+	}
+	if jsonErr := json.Unmarshal([]byte(apiErr.Description), &response); jsonErr != nil || len(response.Results) == 0 {
+		return api.BatchPayload{}, err
+	}
+	return response, nil
+}
+
+func batchItems(count int, item func(int) interface{}) ([]json.RawMessage, error) {
+	items := make([]json.RawMessage, count)
+	for i := range items {
+		itemBytes, err := json.Marshal(item(i))
+		if err != nil {
+			return nil, fmt.Errorf("marshal batch item %d: %s", i, err)
+		}
+		items[i] = itemBytes
+	}
+	return items, nil
+}
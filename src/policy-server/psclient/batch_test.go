@@ -0,0 +1,139 @@
+package psclient_test
+
+import (
+	"net/http"
+	"policy-server/api"
+	"policy-server/psclient"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Batch", func() {
+	var (
+		server *fakePolicyServer
+		client *psclient.Client
+	)
+
+	BeforeEach(func() {
+		server = newFakePolicyServer()
+		client = psclient.NewClient(lagertest.NewTestLogger("test"), http.DefaultClient, server.URL, psclient.StaticToken("some-token"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("CreateDestinationsBatch", func() {
+		It("sends each destination as an item and returns the results", func() {
+			server.Respond(http.StatusCreated, `{
+				"mode": "all_or_nothing",
+				"total": 1,
+				"succeeded": 1,
+				"failed": 0,
+				"results": [{"index": 0, "id": "some-dest-guid", "status": "created"}]
+			}`)
+
+			payload, err := client.CreateDestinationsBatch(api.BatchModeAllOrNothing, []psclient.Destination{{
+				Name:     "some-dest",
+				Protocol: "tcp",
+				IPs:      []psclient.IPRange{{CIDR: "10.0.0.0/8"}},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Succeeded).To(Equal(1))
+			Expect(payload.Results[0].ID).To(Equal("some-dest-guid"))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/destinations/batch"))
+			Expect(requests[0].Body).To(MatchJSON(`{
+				"mode": "all_or_nothing",
+				"destinations": [{"name": "some-dest", "protocol": "tcp", "ips": [{"cidr": "10.0.0.0/8"}]}]
+			}`))
+		})
+
+		It("returns the results of an all_or_nothing batch that was not applied", func() {
+			server.Respond(http.StatusBadRequest, `{
+				"mode": "all_or_nothing",
+				"total": 2,
+				"succeeded": 0,
+				"failed": 1,
+				"results": [
+					{"index": 0, "status": "skipped"},
+					{"index": 1, "status": "failed", "error": "invalid egress destination: name taken"}
+				]
+			}`)
+
+			payload, err := client.CreateDestinationsBatch(api.BatchModeAllOrNothing, []psclient.Destination{{Name: "a"}, {Name: "b"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Failed).To(Equal(1))
+			Expect(payload.Results[1].Error).To(Equal("invalid egress destination: name taken"))
+		})
+
+		It("returns the error when the server rejects the whole batch", func() {
+			server.Respond(http.StatusBadRequest, `{"error": "batch of 2 items exceeds the limit of 1"}`)
+
+			_, err := client.CreateDestinationsBatch(api.BatchModeBestEffort, []psclient.Destination{{Name: "a"}, {Name: "b"}})
+			Expect(err).To(MatchError("400 Bad Request: batch of 2 items exceeds the limit of 1"))
+		})
+	})
+
+	Describe("DeleteDestinationsBatch", func() {
+		It("sends the guids and returns the results", func() {
+			server.Respond(http.StatusMultiStatus, `{
+				"mode": "best_effort",
+				"total": 2,
+				"succeeded": 1,
+				"failed": 1,
+				"results": [
+					{"index": 0, "id": "dest-1", "status": "deleted"},
+					{"index": 1, "id": "dest-2", "status": "failed", "error": "destination dest-2 is in use"}
+				]
+			}`)
+
+			payload, err := client.DeleteDestinationsBatch(api.BatchModeBestEffort, []string{"dest-1", "dest-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Succeeded).To(Equal(1))
+			Expect(payload.Results[1].Status).To(Equal(api.BatchStatusFailed))
+
+			requests := server.Requests()
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/destinations/batch/delete"))
+			Expect(requests[0].Body).To(MatchJSON(`{"mode": "best_effort", "ids": ["dest-1", "dest-2"]}`))
+		})
+	})
+
+	Describe("CreateEgressPoliciesBatch", func() {
+		It("sends each egress policy as an item", func() {
+			server.Respond(http.StatusCreated, `{"mode": "all_or_nothing", "total": 1, "succeeded": 1, "failed": 0, "results": [{"index": 0, "id": "some-egress-policy-guid", "status": "created"}]}`)
+
+			payload, err := client.CreateEgressPoliciesBatch(api.BatchModeAllOrNothing, []psclient.EgressPolicy{{
+				Source:      psclient.EgressPolicySource{Type: "space", ID: "some-space-guid"},
+				Destination: psclient.EgressPolicyDestination{ID: "some-dest-guid"},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Results[0].ID).To(Equal("some-egress-policy-guid"))
+
+			requests := server.Requests()
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/egress_policies/batch"))
+			Expect(requests[0].Body).To(MatchJSON(`{
+				"mode": "all_or_nothing",
+				"egress_policies": [{"source": {"type": "space", "id": "some-space-guid"}, "destination": {"id": "some-dest-guid"}}]
+			}`))
+		})
+	})
+
+	Describe("DeleteEgressPoliciesBatch", func() {
+		It("sends the guids", func() {
+			server.Respond(http.StatusOK, `{"mode": "all_or_nothing", "total": 1, "succeeded": 1, "failed": 0, "results": [{"index": 0, "id": "some-egress-policy-guid", "status": "deleted"}]}`)
+
+			payload, err := client.DeleteEgressPoliciesBatch(api.BatchModeAllOrNothing, []string{"some-egress-policy-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Succeeded).To(Equal(1))
+
+			requests := server.Requests()
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/egress_policies/batch/delete"))
+			Expect(requests[0].Body).To(MatchJSON(`{"mode": "all_or_nothing", "ids": ["some-egress-policy-guid"]}`))
+		})
+	})
+})
@@ -1,97 +1,227 @@
 package psclient
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/json_client"
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 )
 
+// Client calls the policy server external API. It fetches a token from its
+// TokenFetcher on first use, and fetches a new one when the server rejects
+// it. Requests that are throttled with a 429 or turned away with a 503 are
+// retried with backoff, as the server has not handled them. Other 5xx are
+// only retried for idempotent methods, since the server may have handled the
+// request before failing.
 type Client struct {
-	JsonClient json_client.JsonClient
+	JsonClient   json_client.JsonClient
+	TokenFetcher TokenFetcher
+	Logger       lager.Logger
+	Clock        clock.Clock
+	Retry        RetryPolicy
+	PageSize     int
+
+	lock  sync.Mutex
+	token string
 }
 
-type IPRange struct {
-	Start string
-	End   string
+//go:generate counterfeiter -o fakes/token_fetcher.go --fake-name TokenFetcher . TokenFetcher
+type TokenFetcher interface {
+	GetToken() (string, error)
 }
 
-type Port struct {
-	Start int
-	End   int
+// StaticToken is a TokenFetcher for a token obtained elsewhere, such as from
+// `cf oauth-token`. It cannot be refreshed.
+type StaticToken string
+
+func (t StaticToken) GetToken() (string, error) {
+	return string(t), nil
 }
 
-type Destination struct {
-	GUID     string `json:"id,omitempty"`
-	Protocol string
-	IPs      []IPRange
-	Ports    []Port
+// RetryPolicy retries a request up to MaxRetries times, waiting
+// InitialBackoff before the first retry and twice as long before each
+// following one, up to MaxBackoff.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
 }
 
-type DestinationList struct {
-	Destinations []Destination
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
 }
 
-type EgressPolicy struct {
-	GUID        string                  `json:"id,omitempty"`
-	Source      EgressPolicySource      `json:"source"`
-	Destination EgressPolicyDestination `json:"destination"`
+const DefaultPageSize = 100
+
+func NewClient(logger lager.Logger, httpClient json_client.HttpClient, baseURL string, tokenFetcher TokenFetcher) *Client {
+	return &Client{
+		JsonClient:   json_client.New(logger, httpClient, baseURL),
+		TokenFetcher: tokenFetcher,
+		Logger:       logger,
+		Clock:        clock.NewClock(),
+		Retry:        DefaultRetryPolicy,
+		PageSize:     DefaultPageSize,
+	}
 }
 
-type EgressPolicySource struct {
-	Type string `json:"type,omitempty"`
-	ID   string `json:"id"`
+func (c *Client) do(method, route string, reqData, respData interface{}) error {
+	refreshed := false
+	backoff := c.Retry.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		token, err := c.getToken()
+		if err != nil {
+			return fmt.Errorf("get token: %s", err)
+		}
+
+		err = c.JsonClient.Do(method, route, reqData, respData, "Bearer "+token)
+		if err == nil {
+			return nil
+		}
+
+		httpErr, ok := err.(*json_client.HttpResponseCodeError)
+		if !ok {
+			return fmt.Errorf("json client do: %s", err)
+		}
+		apiErr := newError(httpErr)
+
+		if apiErr.tokenRejected() && !refreshed {
+			refreshed = true
+			c.clearToken(token)
+			continue
+		}
+
+		if !apiErr.retryable(method) || attempt >= c.Retry.MaxRetries {
+			return apiErr
+		}
+
+		c.logger().Info("retrying", lager.Data{
+			"method":  method,
+			"route":   route,
+			"status":  apiErr.StatusCode,
+			"attempt": attempt + 1,
+			"backoff": backoff.String(),
+		})
+		c.clock().Sleep(backoff)
+		backoff *= 2
+		if c.Retry.MaxBackoff > 0 && backoff > c.Retry.MaxBackoff {
+			backoff = c.Retry.MaxBackoff
+		}
+	}
 }
 
-type EgressPolicyDestination struct {
-	ID string `json:"id"`
+func (c *Client) getToken() (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token != "" {
+		return c.token, nil
+	}
+	token, err := c.TokenFetcher.GetToken()
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+	return token, nil
 }
 
-type EgressPolicyList struct {
-	TotalEgressPolicies int            `json:"total_egress_policies,omitempty"`
-	EgressPolicies      []EgressPolicy `json:"egress_policies"`
+// clearToken forgets a rejected token, unless another request has already
+// replaced it.
+func (c *Client) clearToken(token string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token == token {
+		c.token = ""
+	}
 }
 
-func NewClient(logger lager.Logger, httpClient json_client.HttpClient, baseURL string) *Client {
-	return &Client{
-		JsonClient: json_client.New(logger, httpClient, baseURL),
+func (c *Client) logger() lager.Logger {
+	if c.Logger == nil {
+		return lager.NewLogger("psclient")
 	}
+	return c.Logger
 }
 
-func (c *Client) CreateDestination(destination Destination, token string) (string, error) {
-	var response DestinationList
-	err := c.JsonClient.Do("POST", "/networking/v1/external/destinations", DestinationList{
-		Destinations: []Destination{
-			destination,
-		},
-	}, &response, "Bearer " + token)
-	if err != nil {
-		return "", fmt.Errorf("json client do: %s", err)
+func (c *Client) clock() clock.Clock {
+	if c.Clock == nil {
+		return clock.NewClock()
 	}
+	return c.Clock
+}
 
-	return response.Destinations[0].GUID, nil
+func (c *Client) pageSize() int {
+	if c.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return c.PageSize
 }
 
-func (c *Client) CreateEgressPolicy(egressPolicy EgressPolicy, token string) (string, error) {
-	var response EgressPolicyList
-	err := c.JsonClient.Do("POST", "/networking/v1/external/egress_policies", EgressPolicyList{
-		EgressPolicies: []EgressPolicy{
-			egressPolicy,
-		},
-	}, &response, "Bearer " + token)
-	if err != nil {
-		return "", fmt.Errorf("json client do: %s", err)
+// Error is a response from the policy server with a status other than 2xx.
+// Description is the error the server gave, or the raw body if it gave none.
+type Error struct {
+	StatusCode  int
+	Description string
+}
+
+func newError(httpErr *json_client.HttpResponseCodeError) *Error {
+	var body struct {
+		Error string `json:"error"`
+	}
+	description := httpErr.Message
+	if err := json.Unmarshal([]byte(httpErr.Message), &body); err == nil && body.Error != "" {
+		description = body.Error
+	}
+	return &Error{
+		StatusCode:  httpErr.StatusCode,
+		Description: description,
 	}
+}
 
-	return response.EgressPolicies[0].GUID, nil
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Description)
 }
 
-func (c *Client) ListEgressPolicies(token string) (EgressPolicyList, error) {
-	var response EgressPolicyList
-	err := c.JsonClient.Do("GET", "/networking/v1/external/egress_policies", "", &response, "Bearer " + token)
-	if err != nil {
-		return EgressPolicyList{}, fmt.Errorf("list egress policies api call: %s", err)
+// Retryable is true for throttled requests and for requests the server was
+// unavailable for, which it has not handled and so can be retried whatever
+// their method.
+func (e *Error) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusServiceUnavailable
+}
+
+// retryable is also true for the other server errors of idempotent methods.
+func (e *Error) retryable(method string) bool {
+	if e.Retryable() {
+		return true
 	}
+	return e.StatusCode >= 500 && idempotent(method)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+func (e *Error) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *Error) Forbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
 
-	return response, nil
+// tokenRejected is true when the server could not verify the token, which
+// it reports as a 403, as opposed to a token that lacks the scopes needed.
+func (e *Error) tokenRejected() bool {
+	return e.StatusCode == http.StatusUnauthorized ||
+		(e.StatusCode == http.StatusForbidden && e.Description == "failed to verify token with uaa")
 }
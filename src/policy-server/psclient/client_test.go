@@ -1,185 +1,202 @@
 package psclient_test
 
 import (
-	"encoding/json"
 	"errors"
+	"net/http"
 	"policy-server/psclient"
+	"policy-server/psclient/fakes"
+	"time"
 
-	"code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("Client", func() {
 	var (
-		jsonClient *fakes.JSONClient
-		client     *psclient.Client
-		token      string
+		server           *fakePolicyServer
+		fakeTokenFetcher *fakes.TokenFetcher
+		logger           *lagertest.TestLogger
+		client           *psclient.Client
 	)
 
 	BeforeEach(func() {
-		jsonClient = &fakes.JSONClient{}
-		client = &psclient.Client{
-			JsonClient: jsonClient,
+		server = newFakePolicyServer()
+		fakeTokenFetcher = &fakes.TokenFetcher{}
+		fakeTokenFetcher.GetTokenReturnsOnCall(0, "some-token", nil)
+		fakeTokenFetcher.GetTokenReturnsOnCall(1, "some-new-token", nil)
+		logger = lagertest.NewTestLogger("test")
+
+		client = psclient.NewClient(logger, http.DefaultClient, server.URL, fakeTokenFetcher)
+		client.Retry = psclient.RetryPolicy{
+			MaxRetries:     2,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
 		}
-		token = "some-token"
 	})
 
-	Describe("CreateDestination", func() {
-		var (
-			destination psclient.Destination
-		)
-
-		BeforeEach(func() {
-			jsonClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-				respBytes := []byte(`{
-					"destinations": [ { "id": "some-dest-guid" } ]
-				}`)
-				json.Unmarshal(respBytes, respData)
-				return nil
-			}
-
-			destination = psclient.Destination{
-				Protocol: "tcp",
-				IPs: []psclient.IPRange{
-					{
-						Start: "1.2.3.4",
-						End:   "1.2.3.5",
-					},
-				},
-				Ports: []psclient.Port{
-					{
-						Start: 8080,
-						End:   9090,
-					},
-				},
-			}
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("tokens", func() {
+		It("fetches a token once and reuses it", func() {
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
+
+			_, err := client.WhoAmI()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.WhoAmI()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTokenFetcher.GetTokenCallCount()).To(Equal(1))
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[0].Authorization).To(Equal("Bearer some-token"))
+			Expect(requests[1].Authorization).To(Equal("Bearer some-token"))
 		})
 
-		It("creates a destination and returns a guid", func() {
-			guid, err := client.CreateDestination(destination, token)
+		It("fetches a new token when the server cannot verify the token", func() {
+			server.Respond(http.StatusForbidden, `{"error": "failed to verify token with uaa"}`)
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
+
+			userName, err := client.WhoAmI()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(guid).To(Equal("some-dest-guid"))
+			Expect(userName).To(Equal("some-user"))
+			Expect(fakeTokenFetcher.GetTokenCallCount()).To(Equal(2))
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[0].Authorization).To(Equal("Bearer some-token"))
+			Expect(requests[1].Authorization).To(Equal("Bearer some-new-token"))
+		})
 
-			Expect(jsonClient.DoCallCount()).To(Equal(1))
-			passedMethod, passedRoute, passedReqData, _, passedToken := jsonClient.DoArgsForCall(0)
-			Expect(passedMethod).To(Equal("POST"))
-			Expect(passedRoute).To(Equal("/networking/v1/external/destinations"))
+		It("fetches a new token when the server is missing the token", func() {
+			server.Respond(http.StatusUnauthorized, `{"error": "missing authorization header"}`)
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
 
-			Expect(passedReqData).To(Equal(psclient.DestinationList{
-				Destinations:[]psclient.Destination{destination},
-			}))
-			Expect(passedToken).To(Equal("Bearer some-token"))
+			_, err := client.WhoAmI()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTokenFetcher.GetTokenCallCount()).To(Equal(2))
 		})
 
-		It("returns an error when the json client do fails", func() {
-			jsonClient.DoStub = nil
-			jsonClient.DoReturns(errors.New("failed to do"))
-			_, err := client.CreateDestination(destination, token)
-			Expect(err).To(MatchError("json client do: failed to do"))
+		It("only fetches a new token once per request", func() {
+			server.Respond(http.StatusUnauthorized, `{"error": "missing authorization header"}`)
+			server.Respond(http.StatusUnauthorized, `{"error": "missing authorization header"}`)
+
+			_, err := client.WhoAmI()
+			Expect(err).To(MatchError("401 Unauthorized: missing authorization header"))
+			Expect(server.Requests()).To(HaveLen(2))
 		})
-	})
 
-	Describe("CreateEgressPolicy", func() {
-		var (
-			egressPolicy psclient.EgressPolicy
-		)
-
-		BeforeEach(func() {
-			jsonClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-				respBytes := []byte(`{
-					"egress_policies": [ { "id": "some-egress-policy-guid" } ]
-				}`)
-				json.Unmarshal(respBytes, respData)
-				return nil
-			}
-
-			egressPolicy = psclient.EgressPolicy{
-				Source: psclient.EgressPolicySource{
-					Type: "app",
-					ID:   "some-app-guid",
-				},
-				Destination: psclient.EgressPolicyDestination{
-					ID: "some-dest-guid",
-				},
-			}
+		It("does not fetch a new token when the token lacks scopes", func() {
+			server.Respond(http.StatusForbidden, `{"error": "provided scopes [] do not include allowed scopes [network.admin]"}`)
+
+			_, err := client.WhoAmI()
+			Expect(err).To(MatchError("403 Forbidden: provided scopes [] do not include allowed scopes [network.admin]"))
+			Expect(fakeTokenFetcher.GetTokenCallCount()).To(Equal(1))
+		})
+
+		It("returns an error when the token cannot be fetched", func() {
+			fakeTokenFetcher.GetTokenReturnsOnCall(0, "", errors.New("banana"))
+
+			_, err := client.WhoAmI()
+			Expect(err).To(MatchError("get token: banana"))
+			Expect(server.Requests()).To(BeEmpty())
 		})
+	})
+
+	Describe("retries", func() {
+		It("retries server errors with backoff", func() {
+			server.Respond(http.StatusBadGateway, `bad gateway`)
+			server.Respond(http.StatusInternalServerError, `{"error": "database unavailable"}`)
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
 
-		It("creates an egress policy and returns a guid", func() {
-			guid, err := client.CreateEgressPolicy(egressPolicy, token)
+			userName, err := client.WhoAmI()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(guid).To(Equal("some-egress-policy-guid"))
+			Expect(userName).To(Equal("some-user"))
+			Expect(server.Requests()).To(HaveLen(3))
 
-			Expect(jsonClient.DoCallCount()).To(Equal(1))
-			passedMethod, passedRoute, passedReqData, _, passedToken := jsonClient.DoArgsForCall(0)
-			Expect(passedMethod).To(Equal("POST"))
-			Expect(passedRoute).To(Equal("/networking/v1/external/egress_policies"))
+			Expect(logger.Logs()).To(HaveLen(2))
+			Expect(logger.Logs()[0].Message).To(Equal("test.retrying"))
+			Expect(logger.Logs()[0].Data).To(HaveKeyWithValue("backoff", "1ms"))
+			Expect(logger.Logs()[1].Data).To(HaveKeyWithValue("backoff", "2ms"))
+		})
+
+		It("retries throttled requests", func() {
+			server.Respond(http.StatusTooManyRequests, `{"error": "rate limit exceeded for user some-user"}`)
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
 
-			Expect(passedReqData).To(Equal(psclient.EgressPolicyList{
-				EgressPolicies: []psclient.EgressPolicy{egressPolicy}},
-			))
-			Expect(passedToken).To(Equal("Bearer some-token"))
+			_, err := client.WhoAmI()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Requests()).To(HaveLen(2))
 		})
 
-		It("returns an error when the json client do fails", func() {
-			jsonClient.DoStub = nil
-			jsonClient.DoReturns(errors.New("failed to do"))
-			_, err := client.CreateEgressPolicy(egressPolicy, token)
-			Expect(err).To(MatchError("json client do: failed to do"))
+		It("gives up after the max retries", func() {
+			server.Respond(http.StatusInternalServerError, `{"error": "database unavailable"}`)
+			server.Respond(http.StatusInternalServerError, `{"error": "database unavailable"}`)
+			server.Respond(http.StatusInternalServerError, `{"error": "database unavailable"}`)
+
+			_, err := client.WhoAmI()
+			Expect(err).To(MatchError("500 Internal Server Error: database unavailable"))
+			Expect(server.Requests()).To(HaveLen(3))
 		})
-	})
 
-	Describe("ListEgressPolicy", func() {
-		BeforeEach(func() {
-			jsonClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-				respBytes := []byte(`{
-					"total_egress_policies": 1,
-					"egress_policies": [
-						{
-							"id": "some-egress-policy-guid",
-							"source": {
-								"type": "app",
-								"id": "some-app-guid"
-							},
-							"destination": {
-								"id": "some-dest-guid"
-							}
-						}
-					]
-				}`)
-				json.Unmarshal(respBytes, respData)
-				return nil
-			}
+		It("does not retry server errors of requests that are not idempotent", func() {
+			server.Respond(http.StatusInternalServerError, `{"error": "database unavailable"}`)
+			server.Respond(http.StatusBadGateway, `bad gateway`)
+
+			err := client.CreatePolicies(nil)
+			Expect(err).To(MatchError("500 Internal Server Error: database unavailable"))
+			Expect(server.Requests()).To(HaveLen(1))
 		})
 
-		It("lists all egress policies", func() {
-			policyList, err := client.ListEgressPolicies(token)
+		It("retries requests that are not idempotent when the server is unavailable", func() {
+			server.Respond(http.StatusServiceUnavailable, `{"error": "server is shutting down"}`)
+			server.Respond(http.StatusOK, `{}`)
+
+			err := client.CreatePolicies(nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(policyList.TotalEgressPolicies).To(Equal(1))
-			Expect(policyList.EgressPolicies).To(ConsistOf(psclient.EgressPolicy{
-				GUID: "some-egress-policy-guid",
-				Source: psclient.EgressPolicySource{
-					Type: "app",
-					ID:   "some-app-guid",
-				},
-				Destination: psclient.EgressPolicyDestination{
-					ID: "some-dest-guid",
-				},
-			}))
-
-			Expect(jsonClient.DoCallCount()).To(Equal(1))
-			passedMethod, passedRoute, passedReqData, _, passedToken := jsonClient.DoArgsForCall(0)
-			Expect(passedMethod).To(Equal("GET"))
-			Expect(passedRoute).To(Equal("/networking/v1/external/egress_policies"))
-			Expect(passedReqData).To(BeEmpty())
-			Expect(passedToken).To(Equal("Bearer some-token"))
+			Expect(server.Requests()).To(HaveLen(2))
 		})
 
-		It("returns an error when the json client do fails", func() {
-			jsonClient.DoStub = nil
-			jsonClient.DoReturns(errors.New("failed to do"))
-			_, err := client.ListEgressPolicies(token)
-			Expect(err).To(MatchError("list egress policies api call: failed to do"))
+		It("does not retry client errors", func() {
+			server.Respond(http.StatusBadRequest, `{"error": "invalid request body: policies is required"}`)
+
+			err := client.CreatePolicies(nil)
+			Expect(err).To(HaveOccurred())
+			Expect(server.Requests()).To(HaveLen(1))
 		})
 	})
+
+	Describe("errors", func() {
+		It("returns a typed error for responses other than 2xx", func() {
+			server.Respond(http.StatusNotFound, `404 page not found`)
+
+			_, err := client.WhoAmI()
+			apiErr, ok := err.(*psclient.Error)
+			Expect(ok).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(apiErr.Description).To(Equal("404 page not found"))
+			Expect(apiErr.NotFound()).To(BeTrue())
+			Expect(apiErr.Retryable()).To(BeFalse())
+		})
+
+		It("returns an error when the server cannot be reached", func() {
+			server.Close()
+
+			_, err := client.WhoAmI()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("json client do: "))
+			_, ok := err.(*psclient.Error)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("StaticToken", func() {
+	It("returns the token", func() {
+		token, err := psclient.StaticToken("some-token").GetToken()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("some-token"))
+	})
 })
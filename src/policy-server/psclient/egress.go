@@ -0,0 +1,215 @@
+package psclient
+
+import (
+	"fmt"
+	"net/url"
+	"policy-server/api"
+	"policy-server/asg"
+)
+
+// IPRange is either a start and end address or a CIDR, of either family.
+type IPRange struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	CIDR  string `json:"cidr,omitempty"`
+}
+
+type Port struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type Destination struct {
+	GUID        string    `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Protocol    string    `json:"protocol"`
+	IPs         []IPRange `json:"ips"`
+	Ports       []Port    `json:"ports,omitempty"`
+	ICMPType    *int      `json:"icmp_type,omitempty"`
+	ICMPCode    *int      `json:"icmp_code,omitempty"`
+}
+
+type DestinationList struct {
+	TotalDestinations int           `json:"total_destinations,omitempty"`
+	Destinations      []Destination `json:"destinations"`
+}
+
+// DestinationOverlap is two destinations that allow some of the same
+// traffic, or one destination that reaches a forbidden CIDR.
+type DestinationOverlap struct {
+	Duplicate     bool          `json:"duplicate"`
+	ForbiddenCIDR string        `json:"forbidden_cidr,omitempty"`
+	Destinations  []Destination `json:"destinations"`
+}
+
+type DestinationOverlapList struct {
+	TotalOverlaps int                  `json:"total_overlaps"`
+	Overlaps      []DestinationOverlap `json:"overlaps"`
+}
+
+type EgressPolicy struct {
+	GUID         string                  `json:"id,omitempty"`
+	Source       EgressPolicySource      `json:"source"`
+	Destination  EgressPolicyDestination `json:"destination"`
+	Log          bool                    `json:"log,omitempty"`
+	AppLifecycle string                  `json:"app_lifecycle,omitempty"`
+}
+
+type EgressPolicySource struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+type EgressPolicyDestination struct {
+	ID string `json:"id"`
+}
+
+type EgressPolicyList struct {
+	TotalEgressPolicies int            `json:"total_egress_policies,omitempty"`
+	EgressPolicies      []EgressPolicy `json:"egress_policies"`
+}
+
+func (c *Client) ListDestinations() ([]Destination, error) {
+	var response DestinationList
+	err := c.do("GET", "/networking/v1/external/destinations", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Destinations, nil
+}
+
+// GetDestination returns the destination with a GUID, or an *Error whose
+// NotFound is true when there is none.
+func (c *Client) GetDestination(guid string) (Destination, error) {
+	var response DestinationList
+	err := c.do("GET", "/networking/v1/external/destinations/"+url.PathEscape(guid), nil, &response)
+	if err != nil {
+		return Destination{}, err
+	}
+	if len(response.Destinations) == 0 {
+		return Destination{}, fmt.Errorf("get destination: no destination in response")
+	}
+	return response.Destinations[0], nil
+}
+
+// FindDestination returns the destination with a name, and false when there
+// is none.
+func (c *Client) FindDestination(name string) (Destination, bool, error) {
+	var response DestinationList
+	err := c.do("GET", "/networking/v1/external/destinations?"+url.Values{"name": {name}}.Encode(), nil, &response)
+	if err != nil {
+		return Destination{}, false, err
+	}
+	if len(response.Destinations) == 0 {
+		return Destination{}, false, nil
+	}
+	return response.Destinations[0], true, nil
+}
+
+// CreateDestinations returns the destinations created, with their GUIDs.
+func (c *Client) CreateDestinations(destinations []Destination) ([]Destination, error) {
+	var response DestinationList
+	err := c.do("POST", "/networking/v1/external/destinations", DestinationList{
+		Destinations: destinations,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Destinations, nil
+}
+
+func (c *Client) CreateDestination(destination Destination) (string, error) {
+	destinations, err := c.CreateDestinations([]Destination{destination})
+	if err != nil {
+		return "", err
+	}
+	return destinations[0].GUID, nil
+}
+
+// DeleteDestination deletes a destination that no egress policy uses, and
+// returns it.
+func (c *Client) DeleteDestination(guid string) (Destination, error) {
+	var response DestinationList
+	err := c.do("DELETE", "/networking/v1/external/destinations/"+url.PathEscape(guid), nil, &response)
+	if err != nil {
+		return Destination{}, err
+	}
+	if len(response.Destinations) == 0 {
+		return Destination{}, fmt.Errorf("delete destination: no destination in response")
+	}
+	return response.Destinations[0], nil
+}
+
+// ListDestinationOverlaps lists the destinations that reach a forbidden range
+// and the pairs of destinations that overlap.
+func (c *Client) ListDestinationOverlaps() ([]DestinationOverlap, error) {
+	var response DestinationOverlapList
+	err := c.do("GET", "/networking/v1/external/destinations/overlaps", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Overlaps, nil
+}
+
+// CreateEgressPolicies returns the egress policies created, with their GUIDs.
+func (c *Client) CreateEgressPolicies(egressPolicies []EgressPolicy) ([]EgressPolicy, error) {
+	var response EgressPolicyList
+	err := c.do("POST", "/networking/v1/external/egress_policies", EgressPolicyList{
+		EgressPolicies: egressPolicies,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.EgressPolicies, nil
+}
+
+func (c *Client) CreateEgressPolicy(egressPolicy EgressPolicy) (string, error) {
+	egressPolicies, err := c.CreateEgressPolicies([]EgressPolicy{egressPolicy})
+	if err != nil {
+		return "", err
+	}
+	return egressPolicies[0].GUID, nil
+}
+
+// ListEgressPolicies lists egress policies, which the server returns with the
+// c2c policies.
+func (c *Client) ListEgressPolicies() (EgressPolicyList, error) {
+	var response policiesResponse
+	err := c.do("GET", "/networking/v1/external/policies", nil, &response)
+	if err != nil {
+		return EgressPolicyList{}, err
+	}
+	return EgressPolicyList{
+		TotalEgressPolicies: response.TotalEgressPolicies,
+		EgressPolicies:      response.EgressPolicies,
+	}, nil
+}
+
+// EffectiveEgress returns the rules of every egress policy that applies to an
+// app, through the app itself, its space, its org or the default source.
+func (c *Client) EffectiveEgress(appGUID string) (api.EffectiveEgressPayload, error) {
+	var response api.EffectiveEgressPayload
+	err := c.do("GET", "/networking/v1/external/apps/"+url.PathEscape(appGUID)+"/effective_egress", nil, &response)
+	if err != nil {
+		return api.EffectiveEgressPayload{}, err
+	}
+	return response, nil
+}
+
+// ImportASGs converts the application security groups in Cloud Controller to
+// egress destinations and policies, and returns the report. A dry run only
+// returns the report.
+func (c *Client) ImportASGs(dryRun bool) (asg.Report, error) {
+	route := "/networking/v1/external/asg_import"
+	if dryRun {
+		route += "?dry_run=true"
+	}
+
+	var response asg.Report
+	err := c.do("POST", route, nil, &response)
+	if err != nil {
+		return asg.Report{}, err
+	}
+	return response, nil
+}
@@ -0,0 +1,271 @@
+package psclient_test
+
+import (
+	"net/http"
+	"policy-server/psclient"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Egress", func() {
+	var (
+		server      *fakePolicyServer
+		client      *psclient.Client
+		destination psclient.Destination
+	)
+
+	BeforeEach(func() {
+		server = newFakePolicyServer()
+		client = psclient.NewClient(lagertest.NewTestLogger("test"), http.DefaultClient, server.URL, psclient.StaticToken("some-token"))
+
+		destination = psclient.Destination{
+			Name:     "some-dest",
+			Protocol: "tcp",
+			IPs:      []psclient.IPRange{{Start: "1.2.3.4", End: "1.2.3.5"}},
+			Ports:    []psclient.Port{{Start: 8080, End: 9090}},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("CreateDestination", func() {
+		It("creates a destination and returns its guid", func() {
+			server.Respond(http.StatusCreated, `{"total_destinations": 1, "destinations": [{"id": "some-dest-guid"}]}`)
+
+			guid, err := client.CreateDestination(destination)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(guid).To(Equal("some-dest-guid"))
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/destinations"))
+			Expect(requests[0].Authorization).To(Equal("Bearer some-token"))
+			Expect(requests[0].Body).To(MatchJSON(`{
+				"destinations": [{
+					"name": "some-dest",
+					"protocol": "tcp",
+					"ips": [{"start": "1.2.3.4", "end": "1.2.3.5"}],
+					"ports": [{"start": 8080, "end": 9090}]
+				}]
+			}`))
+		})
+
+		It("returns the error from the server", func() {
+			server.Respond(http.StatusBadRequest, `{"error": "invalid request body: destinations[0].protocol must be one of tcp, udp, icmp"}`)
+
+			_, err := client.CreateDestination(destination)
+			Expect(err).To(MatchError("400 Bad Request: invalid request body: destinations[0].protocol must be one of tcp, udp, icmp"))
+		})
+	})
+
+	Describe("ListDestinations", func() {
+		It("lists the destinations", func() {
+			server.Respond(http.StatusOK, `{
+				"total_destinations": 1,
+				"destinations": [{
+					"id": "some-dest-guid",
+					"name": "some-dest",
+					"protocol": "icmp",
+					"icmp_type": 8,
+					"icmp_code": 0,
+					"ips": [{"start": "1.2.3.4", "end": "1.2.3.5"}]
+				}]
+			}`)
+
+			destinations, err := client.ListDestinations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destinations).To(HaveLen(1))
+			Expect(destinations[0].GUID).To(Equal("some-dest-guid"))
+			Expect(*destinations[0].ICMPType).To(Equal(8))
+			Expect(*destinations[0].ICMPCode).To(Equal(0))
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/destinations"))
+		})
+	})
+
+	Describe("GetDestination", func() {
+		It("gets the destination", func() {
+			server.Respond(http.StatusOK, `{"total_destinations": 1, "destinations": [{"id": "some-dest-guid", "name": "some-dest"}]}`)
+
+			found, err := client.GetDestination("some-dest-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Name).To(Equal("some-dest"))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("GET"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/destinations/some-dest-guid"))
+		})
+
+		It("returns a not found error when there is no such destination", func() {
+			server.Respond(http.StatusNotFound, `{"error": "destination some-dest-guid not found"}`)
+
+			_, err := client.GetDestination("some-dest-guid")
+			Expect(err).To(MatchError("404 Not Found: destination some-dest-guid not found"))
+			Expect(err.(*psclient.Error).NotFound()).To(BeTrue())
+		})
+	})
+
+	Describe("FindDestination", func() {
+		It("finds the destination by name", func() {
+			server.Respond(http.StatusOK, `{"total_destinations": 1, "destinations": [{"id": "some-dest-guid", "name": "some dest"}]}`)
+
+			found, ok, err := client.FindDestination("some dest")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(found.GUID).To(Equal("some-dest-guid"))
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/destinations?name=some+dest"))
+		})
+
+		It("returns false when there is no destination with the name", func() {
+			server.Respond(http.StatusOK, `{"total_destinations": 0, "destinations": []}`)
+
+			_, ok, err := client.FindDestination("some-dest")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("DeleteDestination", func() {
+		It("deletes the destination and returns it", func() {
+			server.Respond(http.StatusOK, `{"total_destinations": 1, "destinations": [{"id": "some-dest-guid", "name": "some-dest"}]}`)
+
+			deleted, err := client.DeleteDestination("some-dest-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted.Name).To(Equal("some-dest"))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("DELETE"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/destinations/some-dest-guid"))
+		})
+
+		It("returns a conflict when egress policies use the destination", func() {
+			server.Respond(http.StatusConflict, `{"error": "destination some-dest-guid is in use by egress policies"}`)
+
+			_, err := client.DeleteDestination("some-dest-guid")
+			Expect(err).To(MatchError("409 Conflict: destination some-dest-guid is in use by egress policies"))
+		})
+	})
+
+	Describe("ListDestinationOverlaps", func() {
+		It("lists the overlaps", func() {
+			server.Respond(http.StatusOK, `{
+				"total_overlaps": 1,
+				"overlaps": [{"duplicate": true, "destinations": [{"id": "dest-a"}, {"id": "dest-b"}]}]
+			}`)
+
+			overlaps, err := client.ListDestinationOverlaps()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overlaps).To(Equal([]psclient.DestinationOverlap{{
+				Duplicate:    true,
+				Destinations: []psclient.Destination{{GUID: "dest-a"}, {GUID: "dest-b"}},
+			}}))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("GET"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/destinations/overlaps"))
+		})
+	})
+
+	Describe("CreateEgressPolicy", func() {
+		It("creates an egress policy and returns its guid", func() {
+			server.Respond(http.StatusCreated, `{"egress_policies": [{"id": "some-egress-policy-guid"}]}`)
+
+			guid, err := client.CreateEgressPolicy(psclient.EgressPolicy{
+				Source:      psclient.EgressPolicySource{Type: "app", ID: "some-app-guid"},
+				Destination: psclient.EgressPolicyDestination{ID: "some-dest-guid"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(guid).To(Equal("some-egress-policy-guid"))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/egress_policies"))
+			Expect(requests[0].Body).To(MatchJSON(`{
+				"egress_policies": [{
+					"source": {"type": "app", "id": "some-app-guid"},
+					"destination": {"id": "some-dest-guid"}
+				}]
+			}`))
+		})
+	})
+
+	Describe("ListEgressPolicies", func() {
+		It("lists the egress policies returned with the policies", func() {
+			server.Respond(http.StatusOK, `{
+				"total_policies": 0,
+				"policies": [],
+				"total_egress_policies": 1,
+				"egress_policies": [{
+					"source": {"type": "app", "id": "some-app-guid"},
+					"destination": {"id": "some-dest-guid", "protocol": "tcp", "ips": [{"start": "1.2.3.4", "end": "1.2.3.5"}]}
+				}]
+			}`)
+
+			policyList, err := client.ListEgressPolicies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policyList).To(Equal(psclient.EgressPolicyList{
+				TotalEgressPolicies: 1,
+				EgressPolicies: []psclient.EgressPolicy{{
+					Source:      psclient.EgressPolicySource{Type: "app", ID: "some-app-guid"},
+					Destination: psclient.EgressPolicyDestination{ID: "some-dest-guid"},
+				}},
+			}))
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/policies"))
+		})
+	})
+
+	Describe("EffectiveEgress", func() {
+		It("returns the effective egress rules of the app", func() {
+			server.Respond(http.StatusOK, `{
+				"app_id": "some-app-guid",
+				"total_rules": 1,
+				"rules": [{
+					"protocol": "tcp",
+					"ips": {"start": "10.0.0.0", "end": "10.0.0.4", "family": "ipv4"},
+					"ports": [{"start": 443, "end": 443}],
+					"app_lifecycle": "all",
+					"policy_ids": ["some-egress-policy-guid"],
+					"destinations": ["some-dest"],
+					"sources": [{"id": "some-space-guid", "type": "space"}]
+				}]
+			}`)
+
+			payload, err := client.EffectiveEgress("some-app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.AppGUID).To(Equal("some-app-guid"))
+			Expect(payload.Rules).To(HaveLen(1))
+			Expect(payload.Rules[0].IPRange.Family).To(Equal("ipv4"))
+			Expect(payload.Rules[0].PolicyGUIDs).To(Equal([]string{"some-egress-policy-guid"}))
+			Expect(payload.Rules[0].Sources[0].Type).To(Equal("space"))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("GET"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/apps/some-app-guid/effective_egress"))
+		})
+	})
+
+	Describe("ImportASGs", func() {
+		It("imports the security groups and returns the report", func() {
+			server.Respond(http.StatusOK, `{
+				"dry_run": true,
+				"destinations": [{"action": "create", "name": "asg-dns-1", "security_group": "dns", "protocol": "udp", "start_ip": "10.0.0.2", "end_ip": "10.0.0.2"}],
+				"egress_policies": [{"action": "create", "space_id": "some-space-guid", "destination_name": "asg-dns-1"}],
+				"skipped": []
+			}`)
+
+			report, err := client.ImportASGs(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.Destinations[0].Name).To(Equal("asg-dns-1"))
+			Expect(report.EgressPolicies[0].SpaceGUID).To(Equal("some-space-guid"))
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/asg_import?dry_run=true"))
+		})
+	})
+})
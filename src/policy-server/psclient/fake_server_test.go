@@ -0,0 +1,71 @@
+package psclient_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+type receivedRequest struct {
+	Method        string
+	RequestURI    string
+	Authorization string
+	Body          string
+}
+
+// fakePolicyServer answers requests with the responses queued on it, in
+// order, and records the requests it receives.
+type fakePolicyServer struct {
+	*httptest.Server
+
+	lock      sync.Mutex
+	responses []fakeResponse
+	requests  []receivedRequest
+}
+
+func newFakePolicyServer() *fakePolicyServer {
+	s := &fakePolicyServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *fakePolicyServer) Respond(status int, body string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.responses = append(s.responses, fakeResponse{status: status, body: body})
+}
+
+func (s *fakePolicyServer) Requests() []receivedRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]receivedRequest{}, s.requests...)
+}
+
+func (s *fakePolicyServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, receivedRequest{
+		Method:        req.Method,
+		RequestURI:    req.RequestURI,
+		Authorization: req.Header.Get("Authorization"),
+		Body:          string(body),
+	})
+
+	if len(s.responses) == 0 {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte(`{"error": "no response queued"}`))
+		return
+	}
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	w.WriteHeader(response.status)
+	w.Write([]byte(response.body))
+}
@@ -0,0 +1,186 @@
+package psclient
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"policy-server/api"
+)
+
+// PolicyFilter narrows a policy listing to policies with one of IDs as their
+// source or destination, one of SourceIDs as their source, or one of DestIDs
+// as their destination. An empty filter matches every policy.
+type PolicyFilter struct {
+	IDs       []string
+	SourceIDs []string
+	DestIDs   []string
+}
+
+type policiesResponse struct {
+	TotalPolicies       int            `json:"total_policies"`
+	Policies            []api.Policy   `json:"policies"`
+	TotalEgressPolicies int            `json:"total_egress_policies"`
+	EgressPolicies      []EgressPolicy `json:"egress_policies"`
+}
+
+// PolicyIterator lists policies a page at a time. The server returns every
+// matching policy in one response, so a page is a request for at most
+// PageSize of the filter IDs, which keeps the URL short for long filters.
+// Policies that match more than one page are returned once.
+type PolicyIterator struct {
+	client   *Client
+	filter   PolicyFilter
+	pages    [][]string
+	policies []api.Policy
+	current  api.Policy
+	seen     map[string]bool
+	err      error
+}
+
+func (c *Client) Policies(filter PolicyFilter) *PolicyIterator {
+	pages := [][]string{nil}
+	if len(filter.IDs) > 0 {
+		pages = chunk(filter.IDs, c.pageSize())
+	}
+	return &PolicyIterator{
+		client: c,
+		filter: filter,
+		pages:  pages,
+		seen:   map[string]bool{},
+	}
+}
+
+// Next advances to the next policy, fetching the next page when needed. It
+// returns false when there are no more policies or a request failed.
+func (i *PolicyIterator) Next() bool {
+	for {
+		if i.err != nil {
+			return false
+		}
+		for len(i.policies) > 0 {
+			policy := i.policies[0]
+			i.policies = i.policies[1:]
+			key := fmt.Sprintf("%+v", policy)
+			if i.seen[key] {
+				continue
+			}
+			i.seen[key] = true
+			i.current = policy
+			return true
+		}
+		if len(i.pages) == 0 {
+			return false
+		}
+
+		filter := i.filter
+		filter.IDs = i.pages[0]
+		i.pages = i.pages[1:]
+
+		var response policiesResponse
+		i.err = i.client.do("GET", policiesRoute(filter), nil, &response)
+		i.policies = response.Policies
+	}
+}
+
+func (i *PolicyIterator) Policy() api.Policy {
+	return i.current
+}
+
+// Err is the error that stopped the iteration, if any.
+func (i *PolicyIterator) Err() error {
+	return i.err
+}
+
+func (c *Client) ListPolicies(filter PolicyFilter) ([]api.Policy, error) {
+	policies := []api.Policy{}
+	iterator := c.Policies(filter)
+	for iterator.Next() {
+		policies = append(policies, iterator.Policy())
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (c *Client) CreatePolicies(policies []api.Policy) error {
+	return c.do("POST", "/networking/v1/external/policies", api.PoliciesPayload{
+		Policies: policies,
+	}, nil)
+}
+
+func (c *Client) DeletePolicies(policies []api.Policy) error {
+	return c.do("POST", "/networking/v1/external/policies/delete", api.PoliciesPayload{
+		Policies: policies,
+	}, nil)
+}
+
+// Cleanup deletes the c2c and egress policies of apps and spaces that no
+// longer exist, and returns them. A dry run only returns them.
+func (c *Client) Cleanup(dryRun bool) (api.PolicyCollectionPayload, error) {
+	route := "/networking/v1/external/policies/cleanup"
+	if dryRun {
+		route += "?dry_run=true"
+	}
+
+	var response api.PolicyCollectionPayload
+	err := c.do("POST", route, nil, &response)
+	if err != nil {
+		return api.PolicyCollectionPayload{}, err
+	}
+	return response, nil
+}
+
+func (c *Client) ListTags() ([]api.Tag, error) {
+	var response struct {
+		Tags []api.Tag `json:"tags"`
+	}
+	err := c.do("GET", "/networking/v1/external/tags", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Tags, nil
+}
+
+// WhoAmI returns the user name of the token.
+func (c *Client) WhoAmI() (string, error) {
+	var response struct {
+		UserName string `json:"user_name"`
+	}
+	err := c.do("GET", "/networking/v1/external/whoami", nil, &response)
+	if err != nil {
+		return "", err
+	}
+	return response.UserName, nil
+}
+
+func policiesRoute(filter PolicyFilter) string {
+	route := "/networking/v1/external/policies"
+	query := url.Values{}
+	if len(filter.IDs) > 0 {
+		query.Set("id", strings.Join(filter.IDs, ","))
+	}
+	if len(filter.SourceIDs) > 0 {
+		query.Set("source_id", strings.Join(filter.SourceIDs, ","))
+	}
+	if len(filter.DestIDs) > 0 {
+		query.Set("dest_id", strings.Join(filter.DestIDs, ","))
+	}
+	if len(query) > 0 {
+		route += "?" + query.Encode()
+	}
+	return route
+}
+
+func chunk(list []string, size int) [][]string {
+	chunks := [][]string{}
+	for i := 0; i < len(list); i += size {
+		end := i + size
+		if end > len(list) {
+			end = len(list)
+		}
+		chunks = append(chunks, list[i:end])
+	}
+	return chunks
+}
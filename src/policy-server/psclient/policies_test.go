@@ -0,0 +1,204 @@
+package psclient_test
+
+import (
+	"net/http"
+	"policy-server/api"
+	"policy-server/psclient"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policies", func() {
+	var (
+		server *fakePolicyServer
+		client *psclient.Client
+	)
+
+	policy := func(sourceID, destinationID string) api.Policy {
+		return api.Policy{
+			Source: api.Source{ID: sourceID},
+			Destination: api.Destination{
+				ID:       destinationID,
+				Protocol: "tcp",
+				Ports:    api.Ports{Start: 8080, End: 8080},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		server = newFakePolicyServer()
+		client = psclient.NewClient(lagertest.NewTestLogger("test"), http.DefaultClient, server.URL, psclient.StaticToken("some-token"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ListPolicies", func() {
+		It("lists the policies that match the filter", func() {
+			server.Respond(http.StatusOK, `{
+				"total_policies": 1,
+				"policies": [
+					{ "source": { "id": "app-1" }, "destination": { "id": "app-2", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				]
+			}`)
+
+			policies, err := client.ListPolicies(psclient.PolicyFilter{
+				SourceIDs: []string{"app-1"},
+				DestIDs:   []string{"app-2", "app-3"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(Equal([]api.Policy{policy("app-1", "app-2")}))
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal("GET"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/policies?dest_id=app-2%2Capp-3&source_id=app-1"))
+			Expect(requests[0].Authorization).To(Equal("Bearer some-token"))
+		})
+
+		It("lists every policy without a filter", func() {
+			server.Respond(http.StatusOK, `{"total_policies": 0, "policies": []}`)
+
+			policies, err := client.ListPolicies(psclient.PolicyFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(BeEmpty())
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/policies"))
+		})
+
+		It("returns the error of a failed page", func() {
+			server.Respond(http.StatusBadRequest, `{"error": "bad request"}`)
+
+			_, err := client.ListPolicies(psclient.PolicyFilter{})
+			Expect(err).To(MatchError("400 Bad Request: bad request"))
+		})
+	})
+
+	Describe("Policies", func() {
+		BeforeEach(func() {
+			client.PageSize = 2
+		})
+
+		It("requests a page of ids at a time and returns each policy once", func() {
+			server.Respond(http.StatusOK, `{
+				"total_policies": 2,
+				"policies": [
+					{ "source": { "id": "app-1" }, "destination": { "id": "app-2", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } },
+					{ "source": { "id": "app-2" }, "destination": { "id": "app-3", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				]
+			}`)
+			server.Respond(http.StatusOK, `{
+				"total_policies": 1,
+				"policies": [
+					{ "source": { "id": "app-2" }, "destination": { "id": "app-3", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				]
+			}`)
+
+			iterator := client.Policies(psclient.PolicyFilter{IDs: []string{"app-1", "app-2", "app-3"}})
+			Expect(server.Requests()).To(BeEmpty())
+
+			var policies []api.Policy
+			for iterator.Next() {
+				policies = append(policies, iterator.Policy())
+			}
+			Expect(iterator.Err()).NotTo(HaveOccurred())
+			Expect(policies).To(Equal([]api.Policy{policy("app-1", "app-2"), policy("app-2", "app-3")}))
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/policies?id=app-1%2Capp-2"))
+			Expect(requests[1].RequestURI).To(Equal("/networking/v1/external/policies?id=app-3"))
+		})
+
+		It("stops at the first failed page", func() {
+			server.Respond(http.StatusBadRequest, `{"error": "bad request"}`)
+
+			iterator := client.Policies(psclient.PolicyFilter{IDs: []string{"app-1", "app-2", "app-3"}})
+			Expect(iterator.Next()).To(BeFalse())
+			Expect(iterator.Err()).To(MatchError("400 Bad Request: bad request"))
+			Expect(server.Requests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("CreatePolicies", func() {
+		It("creates the policies", func() {
+			server.Respond(http.StatusOK, `{}`)
+
+			err := client.CreatePolicies([]api.Policy{policy("app-1", "app-2")})
+			Expect(err).NotTo(HaveOccurred())
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/policies"))
+			Expect(requests[0].Body).To(MatchJSON(`{
+				"total_policies": 0,
+				"policies": [
+					{ "source": { "id": "app-1" }, "destination": { "id": "app-2", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				]
+			}`))
+		})
+	})
+
+	Describe("DeletePolicies", func() {
+		It("deletes the policies", func() {
+			server.Respond(http.StatusOK, `{}`)
+
+			err := client.DeletePolicies([]api.Policy{policy("app-1", "app-2")})
+			Expect(err).NotTo(HaveOccurred())
+
+			requests := server.Requests()
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].RequestURI).To(Equal("/networking/v1/external/policies/delete"))
+			Expect(requests[0].Body).To(ContainSubstring(`"source":{"id":"app-1"}`))
+		})
+	})
+
+	Describe("Cleanup", func() {
+		It("returns the policies that were deleted", func() {
+			server.Respond(http.StatusOK, `{
+				"total_policies": 1,
+				"policies": [
+					{ "source": { "id": "app-1" }, "destination": { "id": "app-2", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				]
+			}`)
+
+			result, err := client.Cleanup(false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(Equal([]api.Policy{policy("app-1", "app-2")}))
+			Expect(server.Requests()[0].Method).To(Equal("POST"))
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/policies/cleanup"))
+		})
+
+		It("only finds the stale policies on a dry run", func() {
+			server.Respond(http.StatusOK, `{"total_policies": 0, "policies": []}`)
+
+			_, err := client.Cleanup(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/policies/cleanup?dry_run=true"))
+		})
+	})
+
+	Describe("ListTags", func() {
+		It("lists the tags", func() {
+			server.Respond(http.StatusOK, `{"tags": [{"id": "app-1", "tag": "0001", "type": "app"}]}`)
+
+			tags, err := client.ListTags()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(Equal([]api.Tag{{ID: "app-1", Tag: "0001", Type: "app"}}))
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/tags"))
+		})
+	})
+
+	Describe("WhoAmI", func() {
+		It("returns the user name of the token", func() {
+			server.Respond(http.StatusOK, `{"user_name": "some-user"}`)
+
+			userName, err := client.WhoAmI()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userName).To(Equal("some-user"))
+			Expect(server.Requests()[0].RequestURI).To(Equal("/networking/v1/external/whoami"))
+		})
+	})
+})
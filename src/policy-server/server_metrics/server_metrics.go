@@ -20,3 +20,35 @@ func NewTotalPoliciesSource(lister listStore) metrics.MetricSource {
 		},
 	}
 }
+
+//go:generate counterfeiter -o fakes/tag_utilisation_store.go --fake-name TagUtilisationStore . tagUtilisationStore
+type tagUtilisationStore interface {
+	TagUtilisation() (store.TagUtilisation, error)
+}
+
+func NewTagUtilisationSources(tagStore tagUtilisationStore) []metrics.MetricSource {
+	getter := func(count func(store.TagUtilisation) int) func() (float64, error) {
+		return func() (float64, error) {
+			utilisation, err := tagStore.TagUtilisation()
+			return float64(count(utilisation)), err
+		}
+	}
+
+	return []metrics.MetricSource{
+		{
+			Name:   "tagsAllocated",
+			Unit:   "",
+			Getter: getter(func(u store.TagUtilisation) int { return u.Allocated }),
+		},
+		{
+			Name:   "tagsOrphaned",
+			Unit:   "",
+			Getter: getter(func(u store.TagUtilisation) int { return u.Orphaned }),
+		},
+		{
+			Name:   "tagsAvailable",
+			Unit:   "",
+			Getter: getter(func(u store.TagUtilisation) int { return u.Total - u.Allocated }),
+		},
+	}
+}
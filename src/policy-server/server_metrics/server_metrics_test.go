@@ -1,6 +1,7 @@
 package server_metrics_test
 
 import (
+	"errors"
 	"policy-server/server_metrics"
 	"policy-server/server_metrics/fakes"
 
@@ -56,3 +57,43 @@ var _ = Describe("NewTotalPoliciesSource", func() {
 		})
 	})
 })
+
+var _ = Describe("NewTagUtilisationSources", func() {
+	var fakeTagStore *fakes.TagUtilisationStore
+
+	BeforeEach(func() {
+		fakeTagStore = &fakes.TagUtilisationStore{}
+		fakeTagStore.TagUtilisationReturns(store.TagUtilisation{
+			Total:     255,
+			Allocated: 200,
+			Orphaned:  12,
+		}, nil)
+	})
+
+	It("returns the allocated, orphaned and available tag counts", func() {
+		sources := server_metrics.NewTagUtilisationSources(fakeTagStore)
+		Expect(sources).To(HaveLen(3))
+
+		values := map[string]float64{}
+		for _, source := range sources {
+			value, err := source.Getter()
+			Expect(err).NotTo(HaveOccurred())
+			values[source.Name] = value
+		}
+
+		Expect(values).To(Equal(map[string]float64{
+			"tagsAllocated": 200,
+			"tagsOrphaned":  12,
+			"tagsAvailable": 55,
+		}))
+	})
+
+	Context("when the store fails", func() {
+		It("returns the error", func() {
+			fakeTagStore.TagUtilisationReturns(store.TagUtilisation{}, errors.New("banana"))
+			sources := server_metrics.NewTagUtilisationSources(fakeTagStore)
+			_, err := sources[0].Getter()
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+})
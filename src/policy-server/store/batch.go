@@ -0,0 +1,46 @@
+package store
+
+// maxBatchSize bounds the number of rows referenced by a single batched
+// statement so that large payloads stay under driver placeholder limits.
+const maxBatchSize = 1000
+
+type batch struct {
+	start int
+	end   int
+}
+
+func batches(total int) []batch {
+	var result []batch
+	for start := 0; start < total; start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > total {
+			end = total
+		}
+		result = append(result, batch{start: start, end: end})
+	}
+	return result
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func uniqueInts(values []int) []int {
+	seen := map[int]bool{}
+	var result []int
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -1,10 +1,15 @@
 package store
 
-import "policy-server/db"
+import (
+	"fmt"
+	"policy-server/db"
+	"policy-server/store/helpers"
+)
 
 //go:generate counterfeiter -o fakes/destination_repo.go --fake-name DestinationRepo . DestinationRepo
 type DestinationRepo interface {
 	Create(db.Transaction, int, int, int, int, string) (int, error)
+	CreateMany(db.Transaction, []DestinationRow) (map[DestinationRow]int, error)
 	Delete(db.Transaction, int) error
 	GetID(db.Transaction, int, int, int, int, string) (int, error)
 	CountWhereGroupID(db.Transaction, int) (int, error)
@@ -13,6 +18,14 @@ type DestinationRepo interface {
 type DestinationTable struct {
 }
 
+type DestinationRow struct {
+	GroupID   int
+	Port      int
+	StartPort int
+	EndPort   int
+	Protocol  string
+}
+
 func (d *DestinationTable) Create(tx db.Transaction, destinationGroupId, port, startPort, endPort int, protocol string) (int, error) {
 	dualStatement := ""
 	if tx.DriverName() == "mysql" {
@@ -46,6 +59,93 @@ func (d *DestinationTable) Create(tx db.Transaction, destinationGroupId, port, s
 	return id, err
 }
 
+// CreateMany returns the destination id for every row, inserting the rows
+// that do not exist yet with multi-row inserts.
+func (d *DestinationTable) CreateMany(tx db.Transaction, rows []DestinationRow) (map[DestinationRow]int, error) {
+	var groupIDs []int
+	for _, row := range rows {
+		groupIDs = append(groupIDs, row.GroupID)
+	}
+	groupIDs = uniqueInts(groupIDs)
+
+	ids, err := d.findRows(tx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []DestinationRow
+	pending := map[DestinationRow]bool{}
+	for _, row := range rows {
+		if _, ok := ids[row]; !ok && !pending[row] {
+			pending[row] = true
+			missing = append(missing, row)
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	for _, batch := range batches(len(missing)) {
+		chunk := missing[batch.start:batch.end]
+
+		var args []interface{}
+		for _, row := range chunk {
+			args = append(args, row.GroupID, row.Port, row.StartPort, row.EndPort, row.Protocol)
+		}
+
+		_, err = tx.Exec(tx.Rebind(fmt.Sprintf(`
+			INSERT INTO destinations (group_id, port, start_port, end_port, protocol)
+			VALUES %s
+		`, helpers.QuestionMarkTuples(len(chunk), 5))), args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.findRows(tx, groupIDs)
+}
+
+func (d *DestinationTable) findRows(tx db.Transaction, groupIDs []int) (map[DestinationRow]int, error) {
+	lockStatement := helpers.ForUpdate(tx.DriverName())
+	if tx.DriverName() == "mysql" {
+		lockStatement = " LOCK IN SHARE MODE "
+	}
+
+	ids := map[DestinationRow]int{}
+	for _, batch := range batches(len(groupIDs)) {
+		chunk := groupIDs[batch.start:batch.end]
+
+		var args []interface{}
+		for _, id := range chunk {
+			args = append(args, id)
+		}
+
+		rows, err := tx.Queryx(tx.Rebind(fmt.Sprintf(`
+			SELECT id, group_id, port, start_port, end_port, protocol FROM destinations
+			WHERE group_id IN (%s) `+lockStatement, helpers.QuestionMarks(len(chunk)))), args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var id int
+			var row DestinationRow
+			err = rows.Scan(&id, &row.GroupID, &row.Port, &row.StartPort, &row.EndPort, &row.Protocol)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			ids[row] = id
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
 func (d *DestinationTable) Delete(tx db.Transaction, id int) error {
 	_, err := tx.Exec(
 		tx.Rebind(`DELETE FROM destinations WHERE id = ?`),
@@ -56,7 +156,7 @@ func (d *DestinationTable) Delete(tx db.Transaction, id int) error {
 
 func (d *DestinationTable) GetID(tx db.Transaction, destinationGroupId, port, startPort, endPort int, protocol string) (int, error) {
 	var id int
-	lockStatement := " FOR UPDATE "
+	lockStatement := helpers.ForUpdate(tx.DriverName())
 	if tx.DriverName() == "mysql" {
 		lockStatement = " LOCK IN SHARE MODE "
 	}
@@ -3,13 +3,14 @@ package store
 import (
 	"fmt"
 	"policy-server/db"
+	"strings"
 )
 
 type DestinationMetadataTable struct{}
 
 func (d *DestinationMetadataTable) Create(tx db.Transaction, terminalGUID, name, description string) (int64, error) {
 	driver := tx.DriverName()
-	if driver == "mysql" {
+	if driver == "mysql" || driver == "sqlite3" {
 		result, err := tx.Exec(tx.Rebind(`
 			INSERT INTO destination_metadatas (terminal_guid, name, description)
 			VALUES (?, ?, ?)
@@ -19,7 +20,7 @@ func (d *DestinationMetadataTable) Create(tx db.Transaction, terminalGUID, name,
 			description,
 		)
 		if err != nil {
-			return -1, fmt.Errorf("failed to create destination metadata: %s", err)
+			return -1, createMetadataError(err, name)
 		}
 		return result.LastInsertId()
 	} else if driver == "postgres" {
@@ -36,7 +37,7 @@ func (d *DestinationMetadataTable) Create(tx db.Transaction, terminalGUID, name,
 		).Scan(&id)
 
 		if err != nil {
-			return -1, fmt.Errorf("failed to create destination metadata: %s", err)
+			return -1, createMetadataError(err, name)
 		}
 
 		return id, nil
@@ -44,3 +45,26 @@ func (d *DestinationMetadataTable) Create(tx db.Transaction, terminalGUID, name,
 	}
 	return -1, fmt.Errorf("unknown driver: %s", driver)
 }
+
+func (d *DestinationMetadataTable) Delete(tx db.Transaction, terminalGUID string) error {
+	_, err := tx.Exec(tx.Rebind(`DELETE FROM destination_metadatas WHERE terminal_guid = ?`), terminalGUID)
+	return err
+}
+
+// createMetadataError is a DestinationNameTakenError for a unique violation,
+// since the generated terminal guid of a new destination is never taken.
+func createMetadataError(err error, name string) error {
+	if isUniqueViolation(err) {
+		return DestinationNameTakenError{Name: name}
+	}
+	return fmt.Errorf("failed to create destination metadata: %s", err)
+}
+
+// isUniqueViolation matches the unique violation messages of the mysql,
+// postgres and sqlite drivers.
+func isUniqueViolation(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "Error 1062") ||
+		strings.Contains(message, "duplicate key value violates unique constraint") ||
+		strings.Contains(message, "UNIQUE constraint failed")
+}
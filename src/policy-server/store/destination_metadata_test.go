@@ -33,5 +33,19 @@ var _ = Describe("DestinationMetadata", func() {
 				Expect(err).To(MatchError("failed to create destination metadata: failed to insert"))
 			})
 		})
+
+		Context("because the name is taken", func() {
+			It("returns a name taken error", func() {
+				tx.DriverNameReturns("mysql")
+				tx.ExecReturns(nil, errors.New("Error 1062: Duplicate entry 'some-name' for key 'name'"))
+				_, err := destinationMetadataTable.Create(tx, "term-guid", "some-name", "some-desc")
+				Expect(err).To(Equal(store.DestinationNameTakenError{Name: "some-name"}))
+
+				tx.DriverNameReturns("sqlite3")
+				tx.ExecReturns(nil, errors.New("UNIQUE constraint failed: destination_metadatas.name"))
+				_, err = destinationMetadataTable.Create(tx, "term-guid", "some-name", "some-desc")
+				Expect(err).To(Equal(store.DestinationNameTakenError{Name: "some-name"}))
+			})
+		})
 	})
 })
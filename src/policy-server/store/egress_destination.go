@@ -8,15 +8,22 @@ import (
 type EgressDestinationTable struct{}
 
 func (e *EgressDestinationTable) CreateIPRange(tx db.Transaction, destinationTerminalGUID, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error) {
+	startIPKey, endIPKey, err := ipRangeKeys(startIP, endIP)
+	if err != nil {
+		return -1, fmt.Errorf("error inserting ip ranges: %s", err)
+	}
+
 	driverName := tx.DriverName()
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == "sqlite3" {
 		result, err := tx.Exec(tx.Rebind(`
-			INSERT INTO ip_ranges (protocol, start_ip, end_ip, terminal_guid, start_port, end_port, icmp_type, icmp_code)
-			VALUES (?,?,?,?,?,?,?,?)
+			INSERT INTO ip_ranges (protocol, start_ip, end_ip, start_ip_key, end_ip_key, terminal_guid, start_port, end_port, icmp_type, icmp_code)
+			VALUES (?,?,?,?,?,?,?,?,?,?)
 		`),
 			protocol,
 			startIP,
 			endIP,
+			startIPKey,
+			endIPKey,
 			destinationTerminalGUID,
 			startPort,
 			endPort,
@@ -32,14 +39,16 @@ func (e *EgressDestinationTable) CreateIPRange(tx db.Transaction, destinationTer
 	} else if driverName == "postgres" {
 		var id int64
 
-		err := tx.QueryRow(tx.Rebind(`
-			INSERT INTO ip_ranges (protocol, start_ip, end_ip, terminal_guid, start_port, end_port, icmp_type, icmp_code)
-			VALUES (?,?,?,?,?,?,?,?)
+		err = tx.QueryRow(tx.Rebind(`
+			INSERT INTO ip_ranges (protocol, start_ip, end_ip, start_ip_key, end_ip_key, terminal_guid, start_port, end_port, icmp_type, icmp_code)
+			VALUES (?,?,?,?,?,?,?,?,?,?)
 			RETURNING id
 		`),
 			protocol,
 			startIP,
 			endIP,
+			startIPKey,
+			endIPKey,
 			destinationTerminalGUID,
 			startPort,
 			endPort,
@@ -57,6 +66,11 @@ func (e *EgressDestinationTable) CreateIPRange(tx db.Transaction, destinationTer
 	return -1, fmt.Errorf("unknown driver: %s", driverName)
 }
 
+func (e *EgressDestinationTable) Delete(tx db.Transaction, destinationTerminalGUID string) error {
+	_, err := tx.Exec(tx.Rebind(`DELETE FROM ip_ranges WHERE terminal_guid = ?`), destinationTerminalGUID)
+	return err
+}
+
 func (e *EgressDestinationTable) All(tx db.Transaction) ([]EgressDestination, error) {
 	rows, err := tx.Queryx(`
     SELECT
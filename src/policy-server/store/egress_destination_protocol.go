@@ -0,0 +1,54 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ProtocolAll is the protocol of a destination that allows every protocol,
+// on every port and for every icmp type and code.
+const ProtocolAll = "all"
+
+// ValidateProtocolShape checks that a destination only has the fields its
+// protocol uses: tcp and udp have at most one port range and no icmp type or
+// code, icmp has an icmp type and code and no ports, and all has neither.
+func (d EgressDestination) ValidateProtocolShape() error {
+	switch d.Protocol {
+	case "tcp", "udp":
+		if len(d.Ports) > 1 {
+			return errors.New("only one port range can be defined")
+		}
+		for _, ports := range d.Ports {
+			if ports.Start < 1 || ports.End > 65535 || ports.Start > ports.End {
+				return fmt.Errorf("invalid port range %d-%d", ports.Start, ports.End)
+			}
+		}
+		if d.ICMPType != 0 || d.ICMPCode != 0 {
+			return fmt.Errorf("icmp type and code can not be defined with %s", d.Protocol)
+		}
+	case "icmp":
+		if len(d.Ports) > 0 {
+			return errors.New("ports can not be defined with icmp")
+		}
+		if !validICMP(d.ICMPType) || !validICMP(d.ICMPCode) {
+			return errors.New("icmp type and code must be between -1 and 255")
+		}
+		if d.ICMPType == AllICMP && d.ICMPCode != AllICMP {
+			return errors.New("icmp code must be -1 when icmp type is -1")
+		}
+	case ProtocolAll:
+		if len(d.Ports) > 0 {
+			return errors.New("ports can not be defined with all")
+		}
+		if d.ICMPType != 0 || d.ICMPCode != 0 {
+			return errors.New("icmp type and code can not be defined with all")
+		}
+	default:
+		return errors.New("protocol must be tcp, udp, icmp or all")
+	}
+	return nil
+}
+
+func validICMP(value int) bool {
+	return value >= AllICMP && value <= 255
+}
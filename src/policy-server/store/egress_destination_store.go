@@ -9,22 +9,71 @@ import (
 type egressDestinationRepo interface {
 	All(tx db.Transaction) ([]EgressDestination, error)
 	CreateIPRange(tx db.Transaction, destinationTerminalGUID, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error)
+	Delete(tx db.Transaction, destinationTerminalGUID string) error
 }
 
 //go:generate counterfeiter -o fakes/destination_metadata_repo.go --fake-name DestinationMetadataRepo . destinationMetadataRepo
 type destinationMetadataRepo interface {
 	Create(tx db.Transaction, terminalGUID, name, description string) (int64, error)
+	Delete(tx db.Transaction, terminalGUID string) error
+}
+
+//go:generate counterfeiter -o fakes/terminal_usage_repo.go --fake-name TerminalUsageRepo . terminalUsageRepo
+type terminalUsageRepo interface {
+	IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error)
+}
+
+// DestinationNotFoundError is returned when looking up or deleting a
+// destination, by GUID or by name, that does not exist.
+type DestinationNotFoundError struct {
+	GUID string
+	Name string
+}
+
+func (e DestinationNotFoundError) Error() string {
+	if e.GUID == "" && e.Name != "" {
+		return fmt.Sprintf("destination named %s not found", e.Name)
+	}
+	return fmt.Sprintf("destination %s not found", e.GUID)
+}
+
+// DestinationInUseError is returned when deleting a destination that egress
+// policies still refer to.
+type DestinationInUseError struct {
+	GUID string
+}
+
+func (e DestinationInUseError) Error() string {
+	return fmt.Sprintf("destination %s is in use by egress policies", e.GUID)
+}
+
+// DestinationNameTakenError is returned when creating a destination with a
+// name that another destination already has, which the database rejects even
+// when the other one was created after the name was checked.
+type DestinationNameTakenError struct {
+	Name string
+}
+
+func (e DestinationNameTakenError) Error() string {
+	return fmt.Sprintf("destination name %s is already taken", e.Name)
 }
 
 type EgressDestinationStore struct {
 	Conn                    Database
+	ReadConn                Database
 	EgressDestinationRepo   egressDestinationRepo
 	TerminalsRepo           terminalsRepo
 	DestinationMetadataRepo destinationMetadataRepo
+	TerminalUsageRepo       terminalUsageRepo
 }
 
 func (e *EgressDestinationStore) All() ([]EgressDestination, error) {
-	tx, err := e.Conn.Beginx()
+	conn := e.Conn
+	if e.ReadConn != nil {
+		conn = e.ReadConn
+	}
+
+	tx, err := conn.Beginx()
 	if err != nil {
 		return []EgressDestination{}, fmt.Errorf("egress destination store create transaction: %s", err)
 	}
@@ -33,6 +82,12 @@ func (e *EgressDestinationStore) All() ([]EgressDestination, error) {
 }
 
 func (e *EgressDestinationStore) Create(egressDestinations []EgressDestination) ([]EgressDestination, error) {
+	for _, egressDestination := range egressDestinations {
+		if err := egressDestination.ValidateProtocolShape(); err != nil {
+			return []EgressDestination{}, fmt.Errorf("egress destination store create: %s", err)
+		}
+	}
+
 	tx, err := e.Conn.Beginx()
 	if err != nil {
 		return []EgressDestination{}, fmt.Errorf("egress destination store create transaction: %s", err)
@@ -49,6 +104,9 @@ func (e *EgressDestinationStore) Create(egressDestinations []EgressDestination)
 		_, err = e.DestinationMetadataRepo.Create(tx, destinationTerminalGUID, egressDestination.Name, egressDestination.Description)
 		if err != nil {
 			tx.Rollback()
+			if _, ok := err.(DestinationNameTakenError); ok {
+				return []EgressDestination{}, err
+			}
 			return []EgressDestination{}, fmt.Errorf("egress destination store create destination metadata: %s", err)
 		}
 
@@ -86,3 +144,69 @@ func (e *EgressDestinationStore) Create(egressDestinations []EgressDestination)
 
 	return results, nil
 }
+
+// Delete removes destinations by GUID and returns them. Nothing is removed if
+// any of them does not exist or is in use.
+func (e *EgressDestinationStore) Delete(guids ...string) ([]EgressDestination, error) {
+	tx, err := e.Conn.Beginx()
+	if err != nil {
+		return []EgressDestination{}, fmt.Errorf("egress destination store delete transaction: %s", err)
+	}
+
+	allDestinations, err := e.EgressDestinationRepo.All(tx)
+	if err != nil {
+		tx.Rollback()
+		return []EgressDestination{}, fmt.Errorf("egress destination store list destinations: %s", err)
+	}
+	destinationsByGUID := map[string]EgressDestination{}
+	for _, destination := range allDestinations {
+		destinationsByGUID[destination.GUID] = destination
+	}
+
+	deleted := []EgressDestination{}
+	for _, guid := range guids {
+		destination, ok := destinationsByGUID[guid]
+		if !ok {
+			tx.Rollback()
+			return []EgressDestination{}, DestinationNotFoundError{GUID: guid}
+		}
+
+		inUse, err := e.TerminalUsageRepo.IsTerminalInUse(tx, guid)
+		if err != nil {
+			tx.Rollback()
+			return []EgressDestination{}, fmt.Errorf("egress destination store check terminal usage: %s", err)
+		}
+		if inUse {
+			tx.Rollback()
+			return []EgressDestination{}, DestinationInUseError{GUID: guid}
+		}
+
+		err = e.EgressDestinationRepo.Delete(tx, guid)
+		if err != nil {
+			tx.Rollback()
+			return []EgressDestination{}, fmt.Errorf("egress destination store delete ip range: %s", err)
+		}
+
+		err = e.DestinationMetadataRepo.Delete(tx, guid)
+		if err != nil {
+			tx.Rollback()
+			return []EgressDestination{}, fmt.Errorf("egress destination store delete destination metadata: %s", err)
+		}
+
+		err = e.TerminalsRepo.Delete(tx, guid)
+		if err != nil {
+			tx.Rollback()
+			return []EgressDestination{}, fmt.Errorf("egress destination store delete terminal: %s", err)
+		}
+
+		deleted = append(deleted, destination)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return []EgressDestination{}, fmt.Errorf("egress destination store commit transaction: %s", err)
+	}
+
+	return deleted, nil
+}
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 	uuid "github.com/nu7hatch/gouuid"
 	. "github.com/onsi/ginkgo"
@@ -33,7 +32,7 @@ var _ = Describe("EgressDestinationStore", func() {
 		)
 
 		BeforeEach(func() {
-			dbConf = testsupport.GetDBConfig()
+			dbConf = testhelpers.GetDBConfig()
 			dbConf.DatabaseName = fmt.Sprintf("egress_destination_store_test_node_%d", time.Now().UnixNano())
 			dbConf.Timeout = 30
 			testhelpers.CreateDatabase(dbConf)
@@ -54,6 +53,7 @@ var _ = Describe("EgressDestinationStore", func() {
 				DestinationMetadataRepo: destinationMetadataRepo,
 				Conn:                    realDb,
 				EgressDestinationRepo:   egressDestinationTable,
+				TerminalUsageRepo:       &store.EgressPolicyTable{Conn: realDb},
 			}
 		})
 
@@ -129,6 +129,33 @@ var _ = Describe("EgressDestinationStore", func() {
 				Expect(destinations[1].ICMPType).To(Equal(12))
 				Expect(destinations[1].ICMPCode).To(Equal(13))
 			})
+
+			It("deletes destinations from the database", func() {
+				createdDestinations, err := egressDestinationsStore.Create(toBeCreatedDestinations)
+				Expect(err).NotTo(HaveOccurred())
+
+				deletedDestinations, err := egressDestinationsStore.Delete(createdDestinations[0].GUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deletedDestinations).To(HaveLen(1))
+				Expect(deletedDestinations[0].Name).To(Equal("dest-1"))
+
+				destinations, err := egressDestinationsStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(HaveLen(1))
+				Expect(destinations[0].GUID).To(Equal(createdDestinations[1].GUID))
+			})
+
+			It("does not delete destinations that do not exist", func() {
+				createdDestinations, err := egressDestinationsStore.Create(toBeCreatedDestinations)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = egressDestinationsStore.Delete(createdDestinations[0].GUID, "some-missing-guid")
+				Expect(err).To(Equal(store.DestinationNotFoundError{GUID: "some-missing-guid"}))
+
+				destinations, err := egressDestinationsStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(HaveLen(2))
+			})
 		})
 	})
 
@@ -139,6 +166,7 @@ var _ = Describe("EgressDestinationStore", func() {
 			terminalsRepo           *fakes.TerminalsRepo
 			egressDestinationRepo   *fakes.EgressDestinationRepo
 			destinationMetadataRepo *fakes.DestinationMetadataRepo
+			terminalUsageRepo       *fakes.TerminalUsageRepo
 		)
 
 		BeforeEach(func() {
@@ -150,16 +178,29 @@ var _ = Describe("EgressDestinationStore", func() {
 			terminalsRepo = &fakes.TerminalsRepo{}
 			egressDestinationRepo = &fakes.EgressDestinationRepo{}
 			destinationMetadataRepo = &fakes.DestinationMetadataRepo{}
+			terminalUsageRepo = &fakes.TerminalUsageRepo{}
 
 			egressDestinationsStore = &store.EgressDestinationStore{
 				Conn:                    mockDB,
 				EgressDestinationRepo:   egressDestinationRepo,
 				DestinationMetadataRepo: destinationMetadataRepo,
 				TerminalsRepo:           terminalsRepo,
+				TerminalUsageRepo:       terminalUsageRepo,
 			}
 		})
 
 		Context("Create", func() {
+			Context("when a destination does not fit its protocol", func() {
+				It("returns an error without creating a transaction", func() {
+					_, err := egressDestinationsStore.Create([]store.EgressDestination{
+						{Protocol: "tcp"},
+						{Protocol: "icmp", Ports: []store.Ports{{Start: 80, End: 80}}},
+					})
+					Expect(err).To(MatchError("egress destination store create: ports can not be defined with icmp"))
+					Expect(mockDB.BeginxCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the transaction cannot be created", func() {
 				BeforeEach(func() {
 					mockDB.BeginxReturns(nil, errors.New("can't create a transaction"))
@@ -177,12 +218,12 @@ var _ = Describe("EgressDestinationStore", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := egressDestinationsStore.Create([]store.EgressDestination{{}})
+					_, err := egressDestinationsStore.Create([]store.EgressDestination{{Protocol: "tcp"}})
 					Expect(err).To(MatchError("egress destination store create terminal: can't create a terminal"))
 				})
 
 				It("rolls back the transaction", func() {
-					egressDestinationsStore.Create([]store.EgressDestination{{}})
+					egressDestinationsStore.Create([]store.EgressDestination{{Protocol: "tcp"}})
 					Expect(tx.RollbackCallCount()).To(Equal(1))
 				})
 			})
@@ -213,6 +254,20 @@ var _ = Describe("EgressDestinationStore", func() {
 				})
 			})
 
+			Context("when the name of the destination is taken", func() {
+				BeforeEach(func() {
+					destinationMetadataRepo.CreateReturns(-1, store.DestinationNameTakenError{Name: "some-name"})
+				})
+
+				It("returns the name taken error and rolls back the transaction", func() {
+					_, err := egressDestinationsStore.Create([]store.EgressDestination{
+						{Name: "some-name", Protocol: "tcp", IPRanges: []store.IPRange{{Start: "2.2.2.4", End: "2.2.2.5"}}},
+					})
+					Expect(err).To(Equal(store.DestinationNameTakenError{Name: "some-name"}))
+					Expect(tx.RollbackCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("when creating the ip range returns an error", func() {
 				var err error
 				BeforeEach(func() {
@@ -255,6 +310,138 @@ var _ = Describe("EgressDestinationStore", func() {
 			})
 		})
 
+		Context("Delete", func() {
+			BeforeEach(func() {
+				egressDestinationRepo.AllReturns([]store.EgressDestination{{GUID: "some-guid", Name: "some-name"}}, nil)
+			})
+
+			Context("when the transaction cannot be created", func() {
+				BeforeEach(func() {
+					mockDB.BeginxReturns(nil, errors.New("can't create a transaction"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store delete transaction: can't create a transaction"))
+				})
+			})
+
+			It("deletes the ip range, metadata and terminal of the destination", func() {
+				deleted, err := egressDestinationsStore.Delete("some-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(Equal([]store.EgressDestination{{GUID: "some-guid", Name: "some-name"}}))
+
+				Expect(terminalUsageRepo.IsTerminalInUseCallCount()).To(Equal(1))
+				_, guid := terminalUsageRepo.IsTerminalInUseArgsForCall(0)
+				Expect(guid).To(Equal("some-guid"))
+
+				Expect(egressDestinationRepo.DeleteCallCount()).To(Equal(1))
+				_, guid = egressDestinationRepo.DeleteArgsForCall(0)
+				Expect(guid).To(Equal("some-guid"))
+
+				Expect(destinationMetadataRepo.DeleteCallCount()).To(Equal(1))
+				_, guid = destinationMetadataRepo.DeleteArgsForCall(0)
+				Expect(guid).To(Equal("some-guid"))
+
+				Expect(terminalsRepo.DeleteCallCount()).To(Equal(1))
+				_, guid = terminalsRepo.DeleteArgsForCall(0)
+				Expect(guid).To(Equal("some-guid"))
+
+				Expect(tx.CommitCallCount()).To(Equal(1))
+			})
+
+			Context("when the destination does not exist", func() {
+				It("returns a not found error and rolls back", func() {
+					_, err := egressDestinationsStore.Delete("some-other-guid")
+					Expect(err).To(Equal(store.DestinationNotFoundError{GUID: "some-other-guid"}))
+					Expect(err).To(MatchError("destination some-other-guid not found"))
+					Expect(egressDestinationRepo.DeleteCallCount()).To(Equal(0))
+					Expect(tx.RollbackCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the destination is in use", func() {
+				BeforeEach(func() {
+					terminalUsageRepo.IsTerminalInUseReturns(true, nil)
+				})
+
+				It("returns an in use error and rolls back", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(Equal(store.DestinationInUseError{GUID: "some-guid"}))
+					Expect(err).To(MatchError("destination some-guid is in use by egress policies"))
+					Expect(egressDestinationRepo.DeleteCallCount()).To(Equal(0))
+					Expect(tx.RollbackCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when listing the destinations fails", func() {
+				BeforeEach(func() {
+					egressDestinationRepo.AllReturns(nil, errors.New("can't list"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store list destinations: can't list"))
+				})
+			})
+
+			Context("when checking the terminal usage fails", func() {
+				BeforeEach(func() {
+					terminalUsageRepo.IsTerminalInUseReturns(false, errors.New("can't check"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store check terminal usage: can't check"))
+				})
+			})
+
+			Context("when deleting the ip range fails", func() {
+				BeforeEach(func() {
+					egressDestinationRepo.DeleteReturns(errors.New("can't delete"))
+				})
+
+				It("returns an error and rolls back", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store delete ip range: can't delete"))
+					Expect(tx.RollbackCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when deleting the destination metadata fails", func() {
+				BeforeEach(func() {
+					destinationMetadataRepo.DeleteReturns(errors.New("can't delete"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store delete destination metadata: can't delete"))
+				})
+			})
+
+			Context("when deleting the terminal fails", func() {
+				BeforeEach(func() {
+					terminalsRepo.DeleteReturns(errors.New("can't delete"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store delete terminal: can't delete"))
+				})
+			})
+
+			Context("when the transaction cannot be committed", func() {
+				BeforeEach(func() {
+					tx.CommitReturns(errors.New("can't commit transaction"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressDestinationsStore.Delete("some-guid")
+					Expect(err).To(MatchError("egress destination store commit transaction: can't commit transaction"))
+				})
+			})
+		})
+
 		Context("All", func() {
 			Context("when the transaction cannot be created", func() {
 				BeforeEach(func() {
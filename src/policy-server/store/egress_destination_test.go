@@ -8,7 +8,6 @@ import (
 	"time"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 
 	uuid "github.com/nu7hatch/gouuid"
@@ -28,7 +27,7 @@ var _ = Describe("EgressDestination", func() {
 	)
 
 	BeforeEach(func() {
-		dbConf = testsupport.GetDBConfig()
+		dbConf = testhelpers.GetDBConfig()
 		dbConf.DatabaseName = fmt.Sprintf("egress_destination_test_node_%d", time.Now().UnixNano())
 		dbConf.Timeout = 30
 		testhelpers.CreateDatabase(dbConf)
@@ -0,0 +1,81 @@
+package store
+
+// AllICMP is the icmp type or code of a destination that allows every type
+// or code.
+const AllICMP = -1
+
+// SameTraffic reports whether two destinations allow the same traffic,
+// ignoring their GUID, name and description.
+func (d EgressDestination) SameTraffic(other EgressDestination) bool {
+	if d.Protocol != other.Protocol || len(d.IPRanges) != len(other.IPRanges) || len(d.Ports) != len(other.Ports) {
+		return false
+	}
+	for i := range d.IPRanges {
+		if d.IPRanges[i] != other.IPRanges[i] {
+			return false
+		}
+	}
+	for i := range d.Ports {
+		if d.Ports[i] != other.Ports[i] {
+			return false
+		}
+	}
+	if d.Protocol == "icmp" {
+		return d.ICMPType == other.ICMPType && d.ICMPCode == other.ICMPCode
+	}
+	return true
+}
+
+// OverlapsTraffic reports whether some traffic is allowed by both
+// destinations. A destination without ports allows every port, and one for
+// all protocols overlaps every destination it shares an ip with.
+func (d EgressDestination) OverlapsTraffic(other EgressDestination) bool {
+	if d.Protocol != other.Protocol && d.Protocol != ProtocolAll && other.Protocol != ProtocolAll {
+		return false
+	}
+	if !ipRangesOverlap(d.IPRanges, other.IPRanges) {
+		return false
+	}
+	if d.Protocol != other.Protocol {
+		return true
+	}
+	if d.Protocol == "icmp" {
+		return icmpOverlaps(d.ICMPType, other.ICMPType) && icmpOverlaps(d.ICMPCode, other.ICMPCode)
+	}
+	if len(d.Ports) == 0 || len(other.Ports) == 0 {
+		return true
+	}
+	for _, ports := range d.Ports {
+		for _, otherPorts := range other.Ports {
+			if ports.Start <= otherPorts.End && otherPorts.Start <= ports.End {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ipRangesOverlap compares ranges by their keys, so that ranges of
+// different address families never overlap.
+func ipRangesOverlap(ipRanges, otherIPRanges []IPRange) bool {
+	for _, ipRange := range ipRanges {
+		start, end, err := ipRangeKeys(ipRange.Start, ipRange.End)
+		if err != nil {
+			continue
+		}
+		for _, otherIPRange := range otherIPRanges {
+			otherStart, otherEnd, err := ipRangeKeys(otherIPRange.Start, otherIPRange.End)
+			if err != nil {
+				continue
+			}
+			if start <= otherEnd && otherStart <= end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func icmpOverlaps(a, b int) bool {
+	return a == b || a == AllICMP || b == AllICMP
+}
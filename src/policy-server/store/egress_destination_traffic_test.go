@@ -0,0 +1,190 @@
+package store_test
+
+import (
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EgressDestination traffic", func() {
+	var destination store.EgressDestination
+
+	BeforeEach(func() {
+		destination = store.EgressDestination{
+			GUID:     "some-guid",
+			Name:     "some-name",
+			Protocol: "tcp",
+			IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.9"}},
+			Ports:    []store.Ports{{Start: 80, End: 90}},
+		}
+	})
+
+	Describe("SameTraffic", func() {
+		It("ignores the guid, name and description", func() {
+			other := destination
+			other.GUID, other.Name, other.Description = "other-guid", "other-name", "other-description"
+			Expect(destination.SameTraffic(other)).To(BeTrue())
+		})
+
+		It("compares the protocol, ip ranges and ports", func() {
+			other := destination
+			other.Protocol = "udp"
+			Expect(destination.SameTraffic(other)).To(BeFalse())
+
+			other = destination
+			other.IPRanges = []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.8"}}
+			Expect(destination.SameTraffic(other)).To(BeFalse())
+
+			other = destination
+			other.Ports = nil
+			Expect(destination.SameTraffic(other)).To(BeFalse())
+		})
+	})
+
+	Describe("OverlapsTraffic", func() {
+		It("overlaps when the ip ranges and ports intersect", func() {
+			other := destination
+			other.IPRanges = []store.IPRange{{Start: "10.0.0.9", End: "10.0.0.20"}}
+			other.Ports = []store.Ports{{Start: 90, End: 100}}
+			Expect(destination.OverlapsTraffic(other)).To(BeTrue())
+		})
+
+		It("does not overlap when the ip ranges or ports are apart", func() {
+			other := destination
+			other.IPRanges = []store.IPRange{{Start: "10.0.0.10", End: "10.0.0.20"}}
+			Expect(destination.OverlapsTraffic(other)).To(BeFalse())
+
+			other = destination
+			other.Ports = []store.Ports{{Start: 91, End: 100}}
+			Expect(destination.OverlapsTraffic(other)).To(BeFalse())
+		})
+
+		It("treats a destination without ports as every port", func() {
+			other := destination
+			other.Ports = nil
+			Expect(destination.OverlapsTraffic(other)).To(BeTrue())
+		})
+
+		It("does not overlap across protocols or address families", func() {
+			other := destination
+			other.Protocol = "udp"
+			Expect(destination.OverlapsTraffic(other)).To(BeFalse())
+
+			other = destination
+			other.IPRanges = []store.IPRange{{Start: "::", End: "::ffff"}}
+			Expect(destination.OverlapsTraffic(other)).To(BeFalse())
+		})
+
+		It("treats an icmp type or code of -1 as every type or code", func() {
+			destination = store.EgressDestination{
+				Protocol: "icmp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.9"}},
+				ICMPType: 8,
+				ICMPCode: 0,
+			}
+			other := destination
+			other.ICMPType = store.AllICMP
+			Expect(destination.OverlapsTraffic(other)).To(BeTrue())
+
+			other.ICMPType = 0
+			Expect(destination.OverlapsTraffic(other)).To(BeFalse())
+		})
+
+		It("overlaps every protocol when one destination is for all protocols", func() {
+			other := destination
+			other.Protocol = store.ProtocolAll
+			other.Ports = nil
+			Expect(destination.OverlapsTraffic(other)).To(BeTrue())
+			Expect(other.OverlapsTraffic(destination)).To(BeTrue())
+
+			other.IPRanges = []store.IPRange{{Start: "10.0.0.10", End: "10.0.0.20"}}
+			Expect(destination.OverlapsTraffic(other)).To(BeFalse())
+		})
+	})
+
+	Describe("ValidateProtocolShape", func() {
+		It("accepts tcp and udp with one port range or none", func() {
+			Expect(destination.ValidateProtocolShape()).To(Succeed())
+
+			destination.Protocol = "udp"
+			destination.Ports = nil
+			Expect(destination.ValidateProtocolShape()).To(Succeed())
+		})
+
+		It("rejects tcp and udp with several or invalid port ranges", func() {
+			destination.Ports = []store.Ports{{Start: 80, End: 80}, {Start: 443, End: 443}}
+			Expect(destination.ValidateProtocolShape()).To(MatchError("only one port range can be defined"))
+
+			destination.Ports = []store.Ports{{Start: 90, End: 80}}
+			Expect(destination.ValidateProtocolShape()).To(MatchError("invalid port range 90-80"))
+
+			destination.Ports = []store.Ports{{Start: 0, End: 80}}
+			Expect(destination.ValidateProtocolShape()).To(MatchError("invalid port range 0-80"))
+
+			destination.Ports = []store.Ports{{Start: 80, End: 65536}}
+			Expect(destination.ValidateProtocolShape()).To(MatchError("invalid port range 80-65536"))
+		})
+
+		It("rejects tcp and udp with an icmp type or code", func() {
+			destination.ICMPCode = 3
+			Expect(destination.ValidateProtocolShape()).To(MatchError("icmp type and code can not be defined with tcp"))
+		})
+
+		Context("when the protocol is icmp", func() {
+			BeforeEach(func() {
+				destination.Protocol = "icmp"
+				destination.Ports = nil
+				destination.ICMPType = 8
+			})
+
+			It("accepts an icmp type and code", func() {
+				Expect(destination.ValidateProtocolShape()).To(Succeed())
+
+				destination.ICMPType, destination.ICMPCode = store.AllICMP, store.AllICMP
+				Expect(destination.ValidateProtocolShape()).To(Succeed())
+			})
+
+			It("rejects ports", func() {
+				destination.Ports = []store.Ports{{Start: 80, End: 80}}
+				Expect(destination.ValidateProtocolShape()).To(MatchError("ports can not be defined with icmp"))
+			})
+
+			It("rejects an icmp type or code out of range", func() {
+				destination.ICMPType = 256
+				Expect(destination.ValidateProtocolShape()).To(MatchError("icmp type and code must be between -1 and 255"))
+
+				destination.ICMPType, destination.ICMPCode = 8, -2
+				Expect(destination.ValidateProtocolShape()).To(MatchError("icmp type and code must be between -1 and 255"))
+			})
+
+			It("rejects a code for every icmp type", func() {
+				destination.ICMPType, destination.ICMPCode = store.AllICMP, 0
+				Expect(destination.ValidateProtocolShape()).To(MatchError("icmp code must be -1 when icmp type is -1"))
+			})
+		})
+
+		Context("when the protocol is all", func() {
+			BeforeEach(func() {
+				destination.Protocol = store.ProtocolAll
+				destination.Ports = nil
+			})
+
+			It("accepts neither ports nor an icmp type or code", func() {
+				Expect(destination.ValidateProtocolShape()).To(Succeed())
+
+				destination.Ports = []store.Ports{{Start: 80, End: 80}}
+				Expect(destination.ValidateProtocolShape()).To(MatchError("ports can not be defined with all"))
+
+				destination.Ports = nil
+				destination.ICMPType = 8
+				Expect(destination.ValidateProtocolShape()).To(MatchError("icmp type and code can not be defined with all"))
+			})
+		})
+
+		It("rejects other protocols", func() {
+			destination.Protocol = "sctp"
+			Expect(destination.ValidateProtocolShape()).To(MatchError("protocol must be tcp, udp, icmp or all"))
+		})
+	})
+})
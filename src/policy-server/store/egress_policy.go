@@ -15,7 +15,7 @@ type EgressPolicyTable struct {
 func (e *EgressPolicyTable) CreateApp(tx db.Transaction, sourceTerminalGUID, appGUID string) (int64, error) {
 	driverName := tx.DriverName()
 
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == "sqlite3" {
 		result, err := tx.Exec(tx.Rebind(`
 			INSERT INTO apps (terminal_guid, app_guid)
 			VALUES (?,?)
@@ -50,15 +50,22 @@ func (e *EgressPolicyTable) CreateApp(tx db.Transaction, sourceTerminalGUID, app
 }
 
 func (e *EgressPolicyTable) CreateIPRange(tx db.Transaction, destinationTerminalGUID, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error) {
+	startIPKey, endIPKey, err := ipRangeKeys(startIP, endIP)
+	if err != nil {
+		return -1, fmt.Errorf("error inserting ip ranges: %s", err)
+	}
+
 	driverName := tx.DriverName()
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == "sqlite3" {
 		result, err := tx.Exec(tx.Rebind(`
-			INSERT INTO ip_ranges (protocol, start_ip, end_ip, terminal_guid, start_port, end_port, icmp_type, icmp_code)
-			VALUES (?,?,?,?,?,?,?,?)
+			INSERT INTO ip_ranges (protocol, start_ip, end_ip, start_ip_key, end_ip_key, terminal_guid, start_port, end_port, icmp_type, icmp_code)
+			VALUES (?,?,?,?,?,?,?,?,?,?)
 		`),
 			protocol,
 			startIP,
 			endIP,
+			startIPKey,
+			endIPKey,
 			destinationTerminalGUID,
 			startPort,
 			endPort,
@@ -74,14 +81,16 @@ func (e *EgressPolicyTable) CreateIPRange(tx db.Transaction, destinationTerminal
 	} else if driverName == "postgres" {
 		var id int64
 
-		err := tx.QueryRow(tx.Rebind(`
-			INSERT INTO ip_ranges (protocol, start_ip, end_ip, terminal_guid, start_port, end_port, icmp_type, icmp_code)
-			VALUES (?,?,?,?,?,?,?,?)
+		err = tx.QueryRow(tx.Rebind(`
+			INSERT INTO ip_ranges (protocol, start_ip, end_ip, start_ip_key, end_ip_key, terminal_guid, start_port, end_port, icmp_type, icmp_code)
+			VALUES (?,?,?,?,?,?,?,?,?,?)
 			RETURNING id
 		`),
 			protocol,
 			startIP,
 			endIP,
+			startIPKey,
+			endIPKey,
 			destinationTerminalGUID,
 			startPort,
 			endPort,
@@ -99,16 +108,18 @@ func (e *EgressPolicyTable) CreateIPRange(tx db.Transaction, destinationTerminal
 	return -1, fmt.Errorf("unknown driver: %s", driverName)
 }
 
-func (e *EgressPolicyTable) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string) (string, error) {
+func (e *EgressPolicyTable) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string, log bool, appLifecycle string) (string, error) {
 	guid := e.Guids.New()
 
 	_, err := tx.Exec(tx.Rebind(`
-			INSERT INTO egress_policies (guid, source_guid, destination_guid)
-			VALUES (?, ?,?)
+			INSERT INTO egress_policies (guid, source_guid, destination_guid, log, app_lifecycle)
+			VALUES (?, ?, ?, ?, ?)
 		`),
 		guid,
 		sourceTerminalGUID,
 		destinationTerminalGUID,
+		log,
+		appLifecycle,
 	)
 
 	if err != nil {
@@ -121,7 +132,7 @@ func (e *EgressPolicyTable) CreateEgressPolicy(tx db.Transaction, sourceTerminal
 func (e *EgressPolicyTable) CreateSpace(tx db.Transaction, sourceTerminalGUID, spaceGUID string) (int64, error) {
 	driverName := tx.DriverName()
 
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == "sqlite3" {
 		result, err := tx.Exec(tx.Rebind(`
 			INSERT INTO spaces (terminal_guid, space_guid)
 			VALUES (?,?)
@@ -155,6 +166,78 @@ func (e *EgressPolicyTable) CreateSpace(tx db.Transaction, sourceTerminalGUID, s
 	return -1, fmt.Errorf("unknown driver: %s", driverName)
 }
 
+func (e *EgressPolicyTable) CreateOrg(tx db.Transaction, sourceTerminalGUID, orgGUID string) (int64, error) {
+	driverName := tx.DriverName()
+
+	if driverName == "mysql" || driverName == "sqlite3" {
+		result, err := tx.Exec(tx.Rebind(`
+			INSERT INTO orgs (terminal_guid, org_guid)
+			VALUES (?,?)
+		`),
+			sourceTerminalGUID,
+			orgGUID,
+		)
+		if err != nil {
+			return -1, err
+		}
+
+		return result.LastInsertId()
+	} else if driverName == "postgres" {
+		var id int64
+
+		err := tx.QueryRow(tx.Rebind(`
+			INSERT INTO orgs (terminal_guid, org_guid)
+			VALUES (?,?)
+			RETURNING id
+		`),
+			sourceTerminalGUID,
+			orgGUID,
+		).Scan(&id)
+
+		if err != nil {
+			return -1, fmt.Errorf("error inserting org: %s", err)
+		}
+
+		return id, nil
+	}
+	return -1, fmt.Errorf("unknown driver: %s", driverName)
+}
+
+func (e *EgressPolicyTable) CreateDefault(tx db.Transaction, sourceTerminalGUID string) (int64, error) {
+	driverName := tx.DriverName()
+
+	if driverName == "mysql" || driverName == "sqlite3" {
+		result, err := tx.Exec(tx.Rebind(`
+			INSERT INTO default_sources (terminal_guid)
+			VALUES (?)
+		`),
+			sourceTerminalGUID,
+		)
+		if err != nil {
+			return -1, err
+		}
+
+		return result.LastInsertId()
+	} else if driverName == "postgres" {
+		var id int64
+
+		err := tx.QueryRow(tx.Rebind(`
+			INSERT INTO default_sources (terminal_guid)
+			VALUES (?)
+			RETURNING id
+		`),
+			sourceTerminalGUID,
+		).Scan(&id)
+
+		if err != nil {
+			return -1, fmt.Errorf("error inserting default source: %s", err)
+		}
+
+		return id, nil
+	}
+	return -1, fmt.Errorf("unknown driver: %s", driverName)
+}
+
 func (e *EgressPolicyTable) DeleteEgressPolicy(tx db.Transaction, egressPolicyGUID string) error {
 	_, err := tx.Exec(tx.Rebind(`DELETE FROM egress_policies WHERE guid = ?`), egressPolicyGUID)
 	return err
@@ -175,6 +258,16 @@ func (e *EgressPolicyTable) DeleteSpace(tx db.Transaction, spaceID int64) error
 	return err
 }
 
+func (e *EgressPolicyTable) DeleteOrg(tx db.Transaction, orgID int64) error {
+	_, err := tx.Exec(tx.Rebind(`DELETE FROM orgs WHERE id = ?`), orgID)
+	return err
+}
+
+func (e *EgressPolicyTable) DeleteDefault(tx db.Transaction, defaultID int64) error {
+	_, err := tx.Exec(tx.Rebind(`DELETE FROM default_sources WHERE id = ?`), defaultID)
+	return err
+}
+
 func (e *EgressPolicyTable) IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error) {
 	var count int64
 	err := tx.QueryRow(tx.Rebind(`SELECT COUNT(guid) FROM egress_policies WHERE source_guid = ? OR destination_guid = ?`), terminalGUID, terminalGUID).Scan(&count)
@@ -185,7 +278,7 @@ func (e *EgressPolicyTable) IsTerminalInUse(tx db.Transaction, terminalGUID stri
 }
 
 func (e *EgressPolicyTable) GetIDCollectionsByEgressPolicy(tx db.Transaction, egressPolicy EgressPolicy) ([]EgressPolicyIDCollection, error) {
-	var sourceID, appID, spaceID, ipRangeID int64
+	var sourceID, ipRangeID int64
 	var egressPolicyGUID, sourceTerminalGUID, destinationTerminalGUID string
 	var startPort, endPort int64
 
@@ -194,16 +287,36 @@ func (e *EgressPolicyTable) GetIDCollectionsByEgressPolicy(tx db.Transaction, eg
 		endPort = int64(egressPolicy.Destination.Ports[0].End)
 	}
 
-	var sourceTable, sourceGUIDColumn string
+	// There is only one default source, which has no guid to match.
+	var sourceTable, sourceFilter string
+	var args []interface{}
 	switch egressPolicy.Source.Type {
 	case "space":
 		sourceTable = "spaces"
-		sourceGUIDColumn = "space_guid"
+		sourceFilter = "spaces.space_guid = ? AND"
+		args = append(args, egressPolicy.Source.ID)
+	case "org":
+		sourceTable = "orgs"
+		sourceFilter = "orgs.org_guid = ? AND"
+		args = append(args, egressPolicy.Source.ID)
+	case "default":
+		sourceTable = "default_sources"
 	default:
 		sourceTable = "apps"
-		sourceGUIDColumn = "app_guid"
+		sourceFilter = "apps.app_guid = ? AND"
+		args = append(args, egressPolicy.Source.ID)
 	}
 
+	args = append(args,
+		egressPolicy.Destination.Protocol,
+		egressPolicy.Destination.IPRanges[0].Start,
+		egressPolicy.Destination.IPRanges[0].End,
+		startPort,
+		endPort,
+		egressPolicy.Destination.ICMPType,
+		egressPolicy.Destination.ICMPCode,
+	)
+
 	rows, err := tx.Queryx(tx.Rebind(fmt.Sprintf(`
 		SELECT
 			egress_policies.guid,
@@ -214,7 +327,7 @@ func (e *EgressPolicyTable) GetIDCollectionsByEgressPolicy(tx db.Transaction, eg
 		FROM egress_policies
 		JOIN %[1]s on (egress_policies.source_guid = %[1]s.terminal_guid)
 		JOIN ip_ranges on (egress_policies.destination_guid = ip_ranges.terminal_guid)
-		WHERE %[1]s.%[2]s = ? AND
+		WHERE %[2]s
 			ip_ranges.protocol = ? AND
 			ip_ranges.start_ip = ? AND
 			ip_ranges.end_ip = ? AND
@@ -222,15 +335,8 @@ func (e *EgressPolicyTable) GetIDCollectionsByEgressPolicy(tx db.Transaction, eg
 			ip_ranges.end_port = ? AND
 			ip_ranges.icmp_type = ? AND
 			ip_ranges.icmp_code = ?
-		;`, sourceTable, sourceGUIDColumn)),
-		egressPolicy.Source.ID,
-		egressPolicy.Destination.Protocol,
-		egressPolicy.Destination.IPRanges[0].Start,
-		egressPolicy.Destination.IPRanges[0].End,
-		startPort,
-		endPort,
-		egressPolicy.Destination.ICMPType,
-		egressPolicy.Destination.ICMPCode,
+		;`, sourceTable, sourceFilter)),
+		args...,
 	)
 
 	if err != nil {
@@ -244,28 +350,74 @@ func (e *EgressPolicyTable) GetIDCollectionsByEgressPolicy(tx db.Transaction, eg
 	for rows.Next() {
 		rows.Scan(&egressPolicyGUID, &sourceTerminalGUID, &destinationTerminalGUID, &sourceID, &ipRangeID)
 
+		idCollection := EgressPolicyIDCollection{
+			EgressPolicyGUID:        egressPolicyGUID,
+			DestinationTerminalGUID: destinationTerminalGUID,
+			DestinationIPRangeID:    ipRangeID,
+			SourceTerminalGUID:      sourceTerminalGUID,
+			SourceAppID:             -1,
+			SourceSpaceID:           -1,
+			SourceOrgID:             -1,
+			SourceDefaultID:         -1,
+		}
 		switch egressPolicy.Source.Type {
 		case "space":
-			appID = -1
-			spaceID = sourceID
+			idCollection.SourceSpaceID = sourceID
+		case "org":
+			idCollection.SourceOrgID = sourceID
+		case "default":
+			idCollection.SourceDefaultID = sourceID
 		default:
-			spaceID = -1
-			appID = sourceID
+			idCollection.SourceAppID = sourceID
 		}
 
-		policyIDCollections = append(policyIDCollections, EgressPolicyIDCollection{
-			EgressPolicyGUID:        egressPolicyGUID,
-			DestinationTerminalGUID: destinationTerminalGUID,
-			DestinationIPRangeID:    ipRangeID,
-			SourceTerminalGUID:      sourceTerminalGUID,
-			SourceAppID:             appID,
-			SourceSpaceID:           spaceID,
-		})
+		policyIDCollections = append(policyIDCollections, idCollection)
 	}
 
 	return policyIDCollections, nil
 }
 
+// GetIDCollectionByGUID finds an egress policy by its guid. The collection
+// has no EgressPolicyGUID when there is no such policy. Destinations are
+// shared between policies, so only the source ids are set.
+func (e *EgressPolicyTable) GetIDCollectionByGUID(tx db.Transaction, egressPolicyGUID string) (EgressPolicyIDCollection, error) {
+	idCollection := EgressPolicyIDCollection{
+		DestinationIPRangeID: -1,
+	}
+
+	err := tx.QueryRow(tx.Rebind(`
+		SELECT
+			egress_policies.guid,
+			egress_policies.source_guid,
+			egress_policies.destination_guid,
+			COALESCE(apps.id, -1),
+			COALESCE(spaces.id, -1),
+			COALESCE(orgs.id, -1),
+			COALESCE(default_sources.id, -1)
+		FROM egress_policies
+		LEFT OUTER JOIN apps on (egress_policies.source_guid = apps.terminal_guid)
+		LEFT OUTER JOIN spaces on (egress_policies.source_guid = spaces.terminal_guid)
+		LEFT OUTER JOIN orgs on (egress_policies.source_guid = orgs.terminal_guid)
+		LEFT OUTER JOIN default_sources on (egress_policies.source_guid = default_sources.terminal_guid)
+		WHERE egress_policies.guid = ?
+	`),
+		egressPolicyGUID,
+	).Scan(
+		&idCollection.EgressPolicyGUID,
+		&idCollection.SourceTerminalGUID,
+		&idCollection.DestinationTerminalGUID,
+		&idCollection.SourceAppID,
+		&idCollection.SourceSpaceID,
+		&idCollection.SourceOrgID,
+		&idCollection.SourceDefaultID,
+	)
+
+	if err != nil && err == sql.ErrNoRows {
+		return EgressPolicyIDCollection{}, nil
+	}
+	return idCollection, err
+}
+
 func (e *EgressPolicyTable) GetTerminalByAppGUID(tx db.Transaction, appGUID string) (string, error) {
 	var guid string
 
@@ -298,6 +450,36 @@ func (e *EgressPolicyTable) GetTerminalBySpaceGUID(tx db.Transaction, spaceGUID
 	}
 }
 
+func (e *EgressPolicyTable) GetTerminalByOrgGUID(tx db.Transaction, orgGUID string) (string, error) {
+	var guid string
+
+	err := tx.QueryRow(tx.Rebind(`
+		SELECT terminal_guid FROM orgs WHERE org_guid = ?
+	`),
+		orgGUID,
+	).Scan(&guid)
+
+	if err != nil && err == sql.ErrNoRows {
+		return "", nil
+	} else {
+		return guid, err
+	}
+}
+
+// GetDefaultTerminal returns the terminal of the default source, of which
+// there is at most one, or an empty guid when there is none.
+func (e *EgressPolicyTable) GetDefaultTerminal(tx db.Transaction) (string, error) {
+	var guid string
+
+	err := tx.QueryRow(`SELECT terminal_guid FROM default_sources ORDER BY id LIMIT 1`).Scan(&guid)
+
+	if err != nil && err == sql.ErrNoRows {
+		return "", nil
+	} else {
+		return guid, err
+	}
+}
+
 func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 	rows, err := e.Conn.Query(`
 	SELECT
@@ -306,6 +488,8 @@ func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 		destination_metadatas.description,
 		apps.app_guid,
 		spaces.space_guid,
+		orgs.org_guid,
+		default_sources.terminal_guid,
 		ip_ranges.terminal_guid,
 		ip_ranges.protocol,
 		ip_ranges.start_ip,
@@ -313,10 +497,14 @@ func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 		ip_ranges.start_port,
 		ip_ranges.end_port,
 		ip_ranges.icmp_type,
-		ip_ranges.icmp_code
+		ip_ranges.icmp_code,
+		egress_policies.log,
+		egress_policies.app_lifecycle
 	FROM egress_policies
 	LEFT OUTER JOIN apps ON (egress_policies.source_guid = apps.terminal_guid)
 	LEFT OUTER JOIN spaces ON (egress_policies.source_guid = spaces.terminal_guid)
+	LEFT OUTER JOIN orgs ON (egress_policies.source_guid = orgs.terminal_guid)
+	LEFT OUTER JOIN default_sources ON (egress_policies.source_guid = default_sources.terminal_guid)
 	LEFT OUTER JOIN ip_ranges ON (egress_policies.destination_guid = ip_ranges.terminal_guid)
 	LEFT OUTER JOIN destination_metadatas ON (egress_policies.destination_guid = destination_metadatas.terminal_guid);`)
 
@@ -328,10 +516,12 @@ func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 	defer rows.Close()
 	for rows.Next() {
 
-		var egressPolicyGUID, name, description, destinationGUID, sourceAppGUID, sourceSpaceGUID, protocol, startIP, endIP *string
+		var egressPolicyGUID, name, description, destinationGUID, sourceAppGUID, sourceSpaceGUID, sourceOrgGUID, defaultTerminalGUID, protocol, startIP, endIP *string
 		var startPort, endPort, icmpType, icmpCode int
+		var log bool
+		var appLifecycle string
 
-		err = rows.Scan(&egressPolicyGUID, &name, &description, &sourceAppGUID, &sourceSpaceGUID, &destinationGUID, &protocol, &startIP, &endIP, &startPort, &endPort, &icmpType, &icmpCode)
+		err = rows.Scan(&egressPolicyGUID, &name, &description, &sourceAppGUID, &sourceSpaceGUID, &sourceOrgGUID, &defaultTerminalGUID, &destinationGUID, &protocol, &startIP, &endIP, &startPort, &endPort, &icmpType, &icmpCode, &log, &appLifecycle)
 		if err != nil {
 			return []EgressPolicy{}, err
 		}
@@ -346,20 +536,7 @@ func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 			}
 		}
 
-		var source EgressSource
-
-		switch {
-		case sourceSpaceGUID != nil:
-			source = EgressSource{
-				ID:   *sourceSpaceGUID,
-				Type: "space",
-			}
-		default:
-			source = EgressSource{
-				ID:   *sourceAppGUID,
-				Type: "app",
-			}
-		}
+		source := egressSource(sourceAppGUID, sourceSpaceGUID, sourceOrgGUID, defaultTerminalGUID)
 
 		foundPolicies = append(foundPolicies, EgressPolicy{
 			ID:     *egressPolicyGUID,
@@ -379,6 +556,8 @@ func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 				ICMPType: icmpType,
 				ICMPCode: icmpCode,
 			},
+			Log:          log,
+			AppLifecycle: appLifecycle,
 		})
 	}
 
@@ -394,20 +573,30 @@ func (e *EgressPolicyTable) GetBySourceGuids(ids []string) ([]EgressPolicy, erro
 
 	query := fmt.Sprintf(`
 	SELECT
+		egress_policies.guid,
+		destination_metadatas.name,
+		ip_ranges.terminal_guid,
 		apps.app_guid,
 		spaces.space_guid,
+		orgs.org_guid,
+		default_sources.terminal_guid,
 		ip_ranges.protocol,
 		ip_ranges.start_ip,
 		ip_ranges.end_ip,
 		ip_ranges.start_port,
 		ip_ranges.end_port,
 		ip_ranges.icmp_type,
-		ip_ranges.icmp_code
+		ip_ranges.icmp_code,
+		egress_policies.log,
+		egress_policies.app_lifecycle
 	FROM egress_policies
 	LEFT OUTER JOIN apps on (egress_policies.source_guid = apps.terminal_guid)
 	LEFT OUTER JOIN spaces on (egress_policies.source_guid = spaces.terminal_guid)
+	LEFT OUTER JOIN orgs on (egress_policies.source_guid = orgs.terminal_guid)
+	LEFT OUTER JOIN default_sources on (egress_policies.source_guid = default_sources.terminal_guid)
 	LEFT OUTER JOIN ip_ranges on (egress_policies.destination_guid = ip_ranges.terminal_guid)
-	WHERE apps.app_guid IN (%s) OR spaces.space_guid IN (%s);`, strings.Join(ids, ","), strings.Join(ids, ","))
+	LEFT OUTER JOIN destination_metadatas on (egress_policies.destination_guid = destination_metadatas.terminal_guid)
+	WHERE apps.app_guid IN (%[1]s) OR spaces.space_guid IN (%[1]s) OR orgs.org_guid IN (%[1]s) OR default_sources.terminal_guid IS NOT NULL;`, strings.Join(ids, ","))
 	rows, err := e.Conn.Query(query)
 	if err != nil {
 		return foundPolicies, err
@@ -416,10 +605,12 @@ func (e *EgressPolicyTable) GetBySourceGuids(ids []string) ([]EgressPolicy, erro
 	defer rows.Close()
 	for rows.Next() {
 
-		var sourceAppGUID, sourceSpaceGUID, protocol, startIP, endIP *string
+		var egressPolicyGUID, name, destinationGUID, sourceAppGUID, sourceSpaceGUID, sourceOrgGUID, defaultTerminalGUID, protocol, startIP, endIP *string
 		var startPort, endPort, icmpType, icmpCode int
+		var log bool
+		var appLifecycle string
 
-		err = rows.Scan(&sourceAppGUID, &sourceSpaceGUID, &protocol, &startIP, &endIP, &startPort, &endPort, &icmpType, &icmpCode)
+		err = rows.Scan(&egressPolicyGUID, &name, &destinationGUID, &sourceAppGUID, &sourceSpaceGUID, &sourceOrgGUID, &defaultTerminalGUID, &protocol, &startIP, &endIP, &startPort, &endPort, &icmpType, &icmpCode, &log, &appLifecycle)
 		if err != nil {
 			return foundPolicies, err
 		}
@@ -434,24 +625,14 @@ func (e *EgressPolicyTable) GetBySourceGuids(ids []string) ([]EgressPolicy, erro
 			}
 		}
 
-		var source EgressSource
-
-		switch {
-		case sourceSpaceGUID != nil:
-			source = EgressSource{
-				ID:   *sourceSpaceGUID,
-				Type: "space",
-			}
-		default:
-			source = EgressSource{
-				ID:   *sourceAppGUID,
-				Type: "app",
-			}
-		}
+		source := egressSource(sourceAppGUID, sourceSpaceGUID, sourceOrgGUID, defaultTerminalGUID)
 
 		foundPolicies = append(foundPolicies, EgressPolicy{
+			ID:     *egressPolicyGUID,
 			Source: source,
 			Destination: EgressDestination{
+				GUID:     *destinationGUID,
+				Name:     *name,
 				Protocol: *protocol,
 				Ports:    ports,
 				IPRanges: []IPRange{
@@ -463,8 +644,25 @@ func (e *EgressPolicyTable) GetBySourceGuids(ids []string) ([]EgressPolicy, erro
 				ICMPType: icmpType,
 				ICMPCode: icmpCode,
 			},
+			Log:          log,
+			AppLifecycle: appLifecycle,
 		})
 	}
 
 	return foundPolicies, nil
 }
+
+// egressSource returns the source of a row joined with every source table,
+// only one of which has a match.
+func egressSource(appGUID, spaceGUID, orgGUID, defaultTerminalGUID *string) EgressSource {
+	switch {
+	case spaceGUID != nil:
+		return EgressSource{ID: *spaceGUID, Type: "space"}
+	case orgGUID != nil:
+		return EgressSource{ID: *orgGUID, Type: "org"}
+	case defaultTerminalGUID != nil:
+		return EgressSource{Type: "default"}
+	default:
+		return EgressSource{ID: *appGUID, Type: "app"}
+	}
+}
@@ -9,17 +9,24 @@ import (
 type egressPolicyRepo interface {
 	CreateApp(tx db.Transaction, sourceTerminalGUID string, appGUID string) (int64, error)
 	CreateIPRange(tx db.Transaction, destinationTerminalGUID string, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error)
-	CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string) (string, error)
+	CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string, log bool, appLifecycle string) (string, error)
 	CreateSpace(tx db.Transaction, sourceTerminalGUID string, spaceGUID string) (int64, error)
+	CreateOrg(tx db.Transaction, sourceTerminalGUID string, orgGUID string) (int64, error)
+	CreateDefault(tx db.Transaction, sourceTerminalGUID string) (int64, error)
 	GetTerminalByAppGUID(tx db.Transaction, appGUID string) (string, error)
 	GetTerminalBySpaceGUID(tx db.Transaction, appGUID string) (string, error)
+	GetTerminalByOrgGUID(tx db.Transaction, orgGUID string) (string, error)
+	GetDefaultTerminal(tx db.Transaction) (string, error)
 	GetAllPolicies() ([]EgressPolicy, error)
 	GetBySourceGuids(ids []string) ([]EgressPolicy, error)
 	GetIDCollectionsByEgressPolicy(tx db.Transaction, egressPolicy EgressPolicy) ([]EgressPolicyIDCollection, error)
+	GetIDCollectionByGUID(tx db.Transaction, egressPolicyGUID string) (EgressPolicyIDCollection, error)
 	DeleteEgressPolicy(tx db.Transaction, egressPolicyGUID string) error
 	DeleteIPRange(tx db.Transaction, ipRangeID int64) error
 	DeleteApp(tx db.Transaction, appID int64) error
 	DeleteSpace(tx db.Transaction, spaceID int64) error
+	DeleteOrg(tx db.Transaction, orgID int64) error
+	DeleteDefault(tx db.Transaction, defaultID int64) error
 	IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error)
 }
 
@@ -29,6 +36,16 @@ type terminalsRepo interface {
 	Delete(tx db.Transaction, terminalGUID string) error
 }
 
+// EgressPolicyNotFoundError is returned when deleting an egress policy, by
+// guid, that does not exist.
+type EgressPolicyNotFoundError struct {
+	GUID string
+}
+
+func (e EgressPolicyNotFoundError) Error() string {
+	return fmt.Sprintf("egress policy %s not found", e.GUID)
+}
+
 type EgressPolicyStore struct {
 	TerminalsRepo    terminalsRepo
 	EgressPolicyRepo egressPolicyRepo
@@ -73,6 +90,40 @@ func (e *EgressPolicyStore) createWithTx(tx db.Transaction, policies []EgressPol
 					return nil, fmt.Errorf("failed to create space: %s", err)
 				}
 			}
+		case "org":
+			sourceTerminalGUID, err = e.EgressPolicyRepo.GetTerminalByOrgGUID(tx, policy.Source.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get terminal by org guid: %s", err)
+			}
+
+			if sourceTerminalGUID == "" {
+				sourceTerminalGUID, err = e.TerminalsRepo.Create(tx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create source terminal: %s", err)
+				}
+
+				_, err = e.EgressPolicyRepo.CreateOrg(tx, sourceTerminalGUID, policy.Source.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create org: %s", err)
+				}
+			}
+		case "default":
+			sourceTerminalGUID, err = e.EgressPolicyRepo.GetDefaultTerminal(tx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get default terminal: %s", err)
+			}
+
+			if sourceTerminalGUID == "" {
+				sourceTerminalGUID, err = e.TerminalsRepo.Create(tx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create source terminal: %s", err)
+				}
+
+				_, err = e.EgressPolicyRepo.CreateDefault(tx, sourceTerminalGUID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create default source: %s", err)
+				}
+			}
 		default:
 			sourceTerminalGUID, err = e.EgressPolicyRepo.GetTerminalByAppGUID(tx, policy.Source.ID)
 			if err != nil {
@@ -92,7 +143,11 @@ func (e *EgressPolicyStore) createWithTx(tx db.Transaction, policies []EgressPol
 			}
 		}
 
-		createdPolicyGUID, err := e.EgressPolicyRepo.CreateEgressPolicy(tx, sourceTerminalGUID, policy.Destination.GUID)
+		if policy.AppLifecycle == "" {
+			policy.AppLifecycle = AppLifecycleAll
+		}
+
+		createdPolicyGUID, err := e.EgressPolicyRepo.CreateEgressPolicy(tx, sourceTerminalGUID, policy.Destination.GUID, policy.Log, policy.AppLifecycle)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create egress policy: %s", err)
 		}
@@ -141,32 +196,88 @@ func (e *EgressPolicyStore) deleteWithTx(tx db.Transaction, egressPolicies []Egr
 				return fmt.Errorf("failed to delete destination terminal: %s", err)
 			}
 
-			terminalInUse, err := e.EgressPolicyRepo.IsTerminalInUse(tx, egressPolicyIDCollection.SourceTerminalGUID)
+			err = e.deleteSourceIfUnused(tx, egressPolicyIDCollection)
 			if err != nil {
-				return fmt.Errorf("failed to check if source terminal is in use: %s", err)
+				return err
 			}
+		}
+	}
 
-			if !terminalInUse {
-				if egressPolicyIDCollection.SourceAppID != -1 {
-					err = e.EgressPolicyRepo.DeleteApp(tx, egressPolicyIDCollection.SourceAppID)
-					if err != nil {
-						return fmt.Errorf("failed to delete source app: %s", err)
-					}
-				}
+	return nil
+}
 
-				if egressPolicyIDCollection.SourceSpaceID != -1 {
-					err = e.EgressPolicyRepo.DeleteSpace(tx, egressPolicyIDCollection.SourceSpaceID)
-					if err != nil {
-						return fmt.Errorf("failed to delete source space: %s", err)
-					}
-				}
+// DeleteByGUIDs deletes egress policies by guid, along with their sources once
+// no other policy uses them. Unlike Delete, it leaves their destinations in
+// place, since destinations are shared between policies. Nothing is deleted
+// if any of the policies does not exist.
+func (e *EgressPolicyStore) DeleteByGUIDs(guids ...string) error {
+	tx, err := e.Conn.Beginx()
+	if err != nil {
+		return fmt.Errorf("create transaction: %s", err)
+	}
 
-				err = e.TerminalsRepo.Delete(tx, egressPolicyIDCollection.SourceTerminalGUID)
-				if err != nil {
-					return fmt.Errorf("failed to delete source terminal: %s", err)
-				}
+	for _, guid := range guids {
+		egressPolicyIDCollection, err := e.EgressPolicyRepo.GetIDCollectionByGUID(tx, guid)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("failed to find egress policy: %s", err))
+		}
+		if egressPolicyIDCollection.EgressPolicyGUID == "" {
+			return rollback(tx, EgressPolicyNotFoundError{GUID: guid})
+		}
+
+		err = e.EgressPolicyRepo.DeleteEgressPolicy(tx, guid)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("failed to delete egress policy: %s", err))
+		}
+
+		err = e.deleteSourceIfUnused(tx, egressPolicyIDCollection)
+		if err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	return commit(tx)
+}
+
+func (e *EgressPolicyStore) deleteSourceIfUnused(tx db.Transaction, egressPolicyIDCollection EgressPolicyIDCollection) error {
+	terminalInUse, err := e.EgressPolicyRepo.IsTerminalInUse(tx, egressPolicyIDCollection.SourceTerminalGUID)
+	if err != nil {
+		return fmt.Errorf("failed to check if source terminal is in use: %s", err)
+	}
+
+	if !terminalInUse {
+		if egressPolicyIDCollection.SourceAppID != -1 {
+			err = e.EgressPolicyRepo.DeleteApp(tx, egressPolicyIDCollection.SourceAppID)
+			if err != nil {
+				return fmt.Errorf("failed to delete source app: %s", err)
+			}
+		}
+
+		if egressPolicyIDCollection.SourceSpaceID != -1 {
+			err = e.EgressPolicyRepo.DeleteSpace(tx, egressPolicyIDCollection.SourceSpaceID)
+			if err != nil {
+				return fmt.Errorf("failed to delete source space: %s", err)
+			}
+		}
+
+		if egressPolicyIDCollection.SourceOrgID != -1 {
+			err = e.EgressPolicyRepo.DeleteOrg(tx, egressPolicyIDCollection.SourceOrgID)
+			if err != nil {
+				return fmt.Errorf("failed to delete source org: %s", err)
 			}
 		}
+
+		if egressPolicyIDCollection.SourceDefaultID != -1 {
+			err = e.EgressPolicyRepo.DeleteDefault(tx, egressPolicyIDCollection.SourceDefaultID)
+			if err != nil {
+				return fmt.Errorf("failed to delete default source: %s", err)
+			}
+		}
+
+		err = e.TerminalsRepo.Delete(tx, egressPolicyIDCollection.SourceTerminalGUID)
+		if err != nil {
+			return fmt.Errorf("failed to delete source terminal: %s", err)
+		}
 	}
 
 	return nil
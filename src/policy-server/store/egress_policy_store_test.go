@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"errors"
+	"policy-server/db"
 	dbfakes "policy-server/db/fakes"
 	"policy-server/store"
 	"policy-server/store/fakes"
@@ -88,6 +89,7 @@ var _ = Describe("EgressPolicyStore", func() {
 					Destination: store.EgressDestination{
 						GUID: "some-destination-guid",
 					},
+					AppLifecycle: "all",
 				},
 				{
 					ID: "some-egress-policy-guid-2",
@@ -97,20 +99,48 @@ var _ = Describe("EgressPolicyStore", func() {
 					Destination: store.EgressDestination{
 						GUID: "some-destination-guid-2",
 					},
+					AppLifecycle: "all",
 				},
 			}))
 
-			argTx, sourceID, destinationID := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			argTx, sourceID, destinationID, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-app-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid"))
 
-			argTx, sourceID, destinationID = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			argTx, sourceID, destinationID, _, _ = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-space-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid-2"))
 		})
 
+		It("creates the egress policy with its log attribute", func() {
+			egressPolicies[1].Log = true
+
+			_, err := egressPolicyStore.Create(egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, log, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			Expect(log).To(BeFalse())
+			_, _, _, log, _ = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			Expect(log).To(BeTrue())
+		})
+
+		It("creates the egress policy for all app lifecycles unless one is given", func() {
+			egressPolicies[1].AppLifecycle = "staging"
+
+			createdPolicies, err := egressPolicyStore.Create(egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, _, appLifecycle := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			Expect(appLifecycle).To(Equal("all"))
+			_, _, _, _, appLifecycle = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			Expect(appLifecycle).To(Equal("staging"))
+
+			Expect(createdPolicies[0].AppLifecycle).To(Equal("all"))
+			Expect(createdPolicies[1].AppLifecycle).To(Equal("staging"))
+		})
+
 		It("returns an error when the database connection can't begin a transaction", func() {
 			mockDb.BeginxReturns(nil, errors.New("potato"))
 			_, err := egressPolicyStore.Create(egressPolicies)
@@ -200,12 +230,12 @@ var _ = Describe("EgressPolicyStore", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateEgressPolicyCallCount()).To(Equal(2))
 
-			argTx, sourceID, destinationID := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			argTx, sourceID, destinationID, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-app-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid"))
 
-			argTx, sourceID, destinationID = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			argTx, sourceID, destinationID, _, _ = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-space-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid-2"))
@@ -224,7 +254,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			_, err := egressPolicyStore.Create(egressPolicies)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateAppCallCount()).To(Equal(0))
-			_, sourceID, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(sourceID).To(Equal("66"))
 		})
 
@@ -234,7 +264,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			_, err := egressPolicyStore.Create([]store.EgressPolicy{spacePolicy})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateSpaceCallCount()).To(Equal(0))
-			_, sourceID, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(sourceID).To(Equal("55"))
 		})
 
@@ -261,6 +291,94 @@ var _ = Describe("EgressPolicyStore", func() {
 			Expect(err).To(MatchError("failed to get terminal by space guid: OMG WHY DID THIS FAIL"))
 		})
 
+		Context("when the source is an org", func() {
+			var orgPolicy store.EgressPolicy
+
+			BeforeEach(func() {
+				orgPolicy = store.EgressPolicy{
+					Source:      store.EgressSource{Type: "org", ID: "org-guid"},
+					Destination: store.EgressDestination{GUID: "some-destination-guid"},
+				}
+			})
+
+			It("creates an org with a sourceTerminalGUID", func() {
+				terminalsRepo.CreateReturns("some-term-guid", nil)
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{orgPolicy})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyRepo.GetTerminalByOrgGUIDCallCount()).To(Equal(1))
+				Expect(egressPolicyRepo.CreateOrgCallCount()).To(Equal(1))
+				argTx, argSourceTerminalGUID, argOrgGUID := egressPolicyRepo.CreateOrgArgsForCall(0)
+				Expect(argTx).To(Equal(tx))
+				Expect(argSourceTerminalGUID).To(Equal("some-term-guid"))
+				Expect(argOrgGUID).To(Equal("org-guid"))
+				_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+				Expect(sourceID).To(Equal("some-term-guid"))
+			})
+
+			It("uses the existing org terminal id when it exists", func() {
+				egressPolicyRepo.GetTerminalByOrgGUIDReturns("44", nil)
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{orgPolicy})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyRepo.CreateOrgCallCount()).To(Equal(0))
+				_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+				Expect(sourceID).To(Equal("44"))
+			})
+
+			It("returns an error when the GetTerminalByOrgGUID fails", func() {
+				egressPolicyRepo.GetTerminalByOrgGUIDReturns("", errors.New("OMG WHY DID THIS FAIL"))
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{orgPolicy})
+				Expect(err).To(MatchError("failed to get terminal by org guid: OMG WHY DID THIS FAIL"))
+			})
+
+			It("returns an error when the CreateOrg fails", func() {
+				egressPolicyRepo.CreateOrgReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{orgPolicy})
+				Expect(err).To(MatchError("failed to create org: OMG WHY DID THIS FAIL"))
+			})
+		})
+
+		Context("when the source is the default", func() {
+			var defaultPolicy store.EgressPolicy
+
+			BeforeEach(func() {
+				defaultPolicy = store.EgressPolicy{
+					Source:      store.EgressSource{Type: "default"},
+					Destination: store.EgressDestination{GUID: "some-destination-guid"},
+				}
+			})
+
+			It("creates the default source the first time", func() {
+				terminalsRepo.CreateReturns("some-term-guid", nil)
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{defaultPolicy})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyRepo.CreateDefaultCallCount()).To(Equal(1))
+				argTx, argSourceTerminalGUID := egressPolicyRepo.CreateDefaultArgsForCall(0)
+				Expect(argTx).To(Equal(tx))
+				Expect(argSourceTerminalGUID).To(Equal("some-term-guid"))
+			})
+
+			It("uses the existing default terminal when it exists", func() {
+				egressPolicyRepo.GetDefaultTerminalReturns("33", nil)
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{defaultPolicy})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyRepo.CreateDefaultCallCount()).To(Equal(0))
+				_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+				Expect(sourceID).To(Equal("33"))
+			})
+
+			It("returns an error when the GetDefaultTerminal fails", func() {
+				egressPolicyRepo.GetDefaultTerminalReturns("", errors.New("OMG WHY DID THIS FAIL"))
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{defaultPolicy})
+				Expect(err).To(MatchError("failed to get default terminal: OMG WHY DID THIS FAIL"))
+			})
+
+			It("returns an error when the CreateDefault fails", func() {
+				egressPolicyRepo.CreateDefaultReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
+				_, err := egressPolicyStore.Create([]store.EgressPolicy{defaultPolicy})
+				Expect(err).To(MatchError("failed to create default source: OMG WHY DID THIS FAIL"))
+			})
+		})
+
 		It("returns an error when the GetTerminalByAppGUID fails", func() {
 			egressPolicyRepo.GetTerminalByAppGUIDReturns("", errors.New("OMG WHY DID THIS FAIL"))
 
@@ -329,6 +447,8 @@ var _ = Describe("EgressPolicyStore", func() {
 				DestinationTerminalGUID: destTerminalGUID,
 				SourceAppID:             appID,
 				SourceSpaceID:           -1,
+				SourceOrgID:             -1,
+				SourceDefaultID:         -1,
 				SourceTerminalGUID:      srcTerminalGUID,
 			}
 
@@ -338,6 +458,8 @@ var _ = Describe("EgressPolicyStore", func() {
 				DestinationTerminalGUID: destTerminalGUID2,
 				SourceAppID:             appID2,
 				SourceSpaceID:           -1,
+				SourceOrgID:             -1,
+				SourceDefaultID:         -1,
 				SourceTerminalGUID:      srcTerminalGUID2,
 			}
 
@@ -445,6 +567,56 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 		})
 
+		Context("when the source terminal is attached to an org", func() {
+			BeforeEach(func() {
+				egressPolicyIDCollection.SourceAppID = -1
+				egressPolicyIDCollection.SourceOrgID = 31
+				egressPolicyRepo.GetIDCollectionsByEgressPolicyReturns([]store.EgressPolicyIDCollection{egressPolicyIDCollection}, nil)
+			})
+
+			It("deletes the org", func() {
+				err := egressPolicyStore.Delete(egressPoliciesToDelete)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(egressPolicyRepo.DeleteAppCallCount()).To(Equal(0))
+				Expect(egressPolicyRepo.DeleteOrgCallCount()).To(Equal(1))
+				passedTx, passedOrgID := egressPolicyRepo.DeleteOrgArgsForCall(0)
+				Expect(passedTx).To(Equal(tx))
+				Expect(passedOrgID).To(Equal(int64(31)))
+			})
+
+			It("returns an error when the EgressPolicyRepo.DeleteOrg fails", func() {
+				egressPolicyRepo.DeleteOrgReturns(errors.New("ther's a bug"))
+				err := egressPolicyStore.Delete(egressPoliciesToDelete)
+				Expect(err).To(MatchError("failed to delete source org: ther's a bug"))
+			})
+		})
+
+		Context("when the source terminal is the default source", func() {
+			BeforeEach(func() {
+				egressPolicyIDCollection.SourceAppID = -1
+				egressPolicyIDCollection.SourceDefaultID = 1
+				egressPolicyRepo.GetIDCollectionsByEgressPolicyReturns([]store.EgressPolicyIDCollection{egressPolicyIDCollection}, nil)
+			})
+
+			It("deletes the default source", func() {
+				err := egressPolicyStore.Delete(egressPoliciesToDelete)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(egressPolicyRepo.DeleteAppCallCount()).To(Equal(0))
+				Expect(egressPolicyRepo.DeleteDefaultCallCount()).To(Equal(1))
+				passedTx, passedDefaultID := egressPolicyRepo.DeleteDefaultArgsForCall(0)
+				Expect(passedTx).To(Equal(tx))
+				Expect(passedDefaultID).To(Equal(int64(1)))
+			})
+
+			It("returns an error when the EgressPolicyRepo.DeleteDefault fails", func() {
+				egressPolicyRepo.DeleteDefaultReturns(errors.New("ther's a bug"))
+				err := egressPolicyStore.Delete(egressPoliciesToDelete)
+				Expect(err).To(MatchError("failed to delete default source: ther's a bug"))
+			})
+		})
+
 		Context("when there are multiple egress policies", func() {
 			BeforeEach(func() {
 				egressPoliciesToDelete = append(egressPoliciesToDelete, store.EgressPolicy{
@@ -598,6 +770,82 @@ var _ = Describe("EgressPolicyStore", func() {
 		})
 	})
 
+	Describe("DeleteByGUIDs", func() {
+		BeforeEach(func() {
+			egressPolicyRepo.GetIDCollectionByGUIDStub = func(_ db.Transaction, guid string) (store.EgressPolicyIDCollection, error) {
+				return store.EgressPolicyIDCollection{
+					EgressPolicyGUID:        guid,
+					DestinationTerminalGUID: "some-destination-guid",
+					DestinationIPRangeID:    -1,
+					SourceTerminalGUID:      "src-terminal-" + guid,
+					SourceAppID:             42,
+					SourceSpaceID:           -1,
+					SourceOrgID:             -1,
+					SourceDefaultID:         -1,
+				}, nil
+			}
+		})
+
+		It("deletes the policies and their unused sources but not their destinations", func() {
+			err := egressPolicyStore.DeleteByGUIDs("policy-1", "policy-2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(egressPolicyRepo.DeleteEgressPolicyCallCount()).To(Equal(2))
+			passedTx, passedGUID := egressPolicyRepo.DeleteEgressPolicyArgsForCall(0)
+			Expect(passedTx).To(Equal(tx))
+			Expect(passedGUID).To(Equal("policy-1"))
+			_, passedGUID = egressPolicyRepo.DeleteEgressPolicyArgsForCall(1)
+			Expect(passedGUID).To(Equal("policy-2"))
+
+			Expect(egressPolicyRepo.DeleteAppCallCount()).To(Equal(2))
+			Expect(terminalsRepo.DeleteCallCount()).To(Equal(2))
+			_, passedTerminalGUID := terminalsRepo.DeleteArgsForCall(0)
+			Expect(passedTerminalGUID).To(Equal("src-terminal-policy-1"))
+			Expect(egressPolicyRepo.DeleteIPRangeCallCount()).To(Equal(0))
+
+			Expect(tx.CommitCallCount()).To(Equal(1))
+		})
+
+		It("keeps a source that another policy uses", func() {
+			egressPolicyRepo.IsTerminalInUseReturns(true, nil)
+
+			err := egressPolicyStore.DeleteByGUIDs("policy-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(egressPolicyRepo.DeleteAppCallCount()).To(Equal(0))
+			Expect(terminalsRepo.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("deletes nothing when a policy does not exist", func() {
+			egressPolicyRepo.GetIDCollectionByGUIDStub = nil
+			egressPolicyRepo.GetIDCollectionByGUIDReturns(store.EgressPolicyIDCollection{}, nil)
+
+			err := egressPolicyStore.DeleteByGUIDs("missing-policy")
+			Expect(err).To(Equal(store.EgressPolicyNotFoundError{GUID: "missing-policy"}))
+			Expect(err).To(MatchError("egress policy missing-policy not found"))
+
+			Expect(egressPolicyRepo.DeleteEgressPolicyCallCount()).To(Equal(0))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+		})
+
+		It("returns an error when finding a policy fails", func() {
+			egressPolicyRepo.GetIDCollectionByGUIDStub = nil
+			egressPolicyRepo.GetIDCollectionByGUIDReturns(store.EgressPolicyIDCollection{}, errors.New("ther's a bug"))
+
+			err := egressPolicyStore.DeleteByGUIDs("policy-1")
+			Expect(err).To(MatchError("failed to find egress policy: ther's a bug"))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+		})
+
+		It("returns an error when deleting a policy fails", func() {
+			egressPolicyRepo.DeleteEgressPolicyReturns(errors.New("ther's a bug"))
+
+			err := egressPolicyStore.DeleteByGUIDs("policy-1")
+			Expect(err).To(MatchError("failed to delete egress policy: ther's a bug"))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+		})
+	})
+
 	Describe("All", func() {
 		Context("when there are policies created", func() {
 			BeforeEach(func() {
@@ -16,7 +16,6 @@ import (
 	dbfakes "policy-server/db/fakes"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 )
 
@@ -37,7 +36,7 @@ var _ = Describe("Egress Policy Table", func() {
 		var err error
 		mockDb = &fakes.Db{}
 
-		dbConf = testsupport.GetDBConfig()
+		dbConf = testhelpers.GetDBConfig()
 		dbConf.DatabaseName = fmt.Sprintf("store_test_node_%d", time.Now().UnixNano())
 		dbConf.Timeout = 30
 		testhelpers.CreateDatabase(dbConf)
@@ -142,6 +141,59 @@ var _ = Describe("Egress Policy Table", func() {
 		})
 	})
 
+	Context("CreateOrg", func() {
+		It("should create an org and return the ID", func() {
+			orgTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			id, err := egressPolicyTable.CreateOrg(tx, orgTerminalGUID, "some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(id).To(Equal(int64(1)))
+
+			foundID, err := egressPolicyTable.GetTerminalByOrgGUID(tx, "some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundID).To(Equal(orgTerminalGUID))
+		})
+
+		It("should return an error if the driver is not supported", func() {
+			fakeTx := &dbfakes.Transaction{}
+
+			fakeTx.DriverNameReturns("db2")
+
+			_, err := egressPolicyTable.CreateOrg(fakeTx, "some-term-guid", "some-org-guid")
+			Expect(err).To(MatchError("unknown driver: db2"))
+		})
+	})
+
+	Context("CreateDefault", func() {
+		It("should create the default source and return the ID", func() {
+			foundID, err := egressPolicyTable.GetDefaultTerminal(tx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundID).To(Equal(""))
+
+			defaultTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			id, err := egressPolicyTable.CreateDefault(tx, defaultTerminalGUID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(id).To(Equal(int64(1)))
+
+			foundID, err = egressPolicyTable.GetDefaultTerminal(tx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundID).To(Equal(defaultTerminalGUID))
+		})
+
+		It("should return an error if the driver is not supported", func() {
+			fakeTx := &dbfakes.Transaction{}
+
+			fakeTx.DriverNameReturns("db2")
+
+			_, err := egressPolicyTable.CreateDefault(fakeTx, "some-term-guid")
+			Expect(err).To(MatchError("unknown driver: db2"))
+		})
+	})
+
 	Context("CreateIPRange", func() {
 		It("should create an iprange and return the ID", func() {
 			ipRangeTerminalGUID, err := terminalsTable.Create(tx)
@@ -166,6 +218,14 @@ var _ = Describe("Egress Policy Table", func() {
 			Expect(icmpCode).To(Equal(int64(0)))
 		})
 
+		It("rejects an invalid ip", func() {
+			ipRangeTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = egressPolicyTable.CreateIPRange(tx, ipRangeTerminalGUID, "1.1.1", "2.2.2.2", "tcp", 8080, 8081, 0, 0)
+			Expect(err).To(MatchError("error inserting ip ranges: ip range start: invalid ip address: 1.1.1"))
+		})
+
 		It("should create an iprange with icmp and return the ID", func() {
 			ipRangeTerminalGUID, err := terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
@@ -206,7 +266,7 @@ var _ = Describe("Egress Policy Table", func() {
 			destinationTerminalId, err := terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			guid, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId)
+			guid, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId, false, "all")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(guid).To(Equal("guid-1"))
 
@@ -220,7 +280,7 @@ var _ = Describe("Egress Policy Table", func() {
 		})
 
 		It("should return the sql error", func() {
-			_, err := egressPolicyTable.CreateEgressPolicy(tx, "some-term-guid", "some-term-guid")
+			_, err := egressPolicyTable.CreateEgressPolicy(tx, "some-term-guid", "some-term-guid", false, "all")
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -236,7 +296,7 @@ var _ = Describe("Egress Policy Table", func() {
 			destinationTerminalId, err := terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId)
+			egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId, false, "all")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -406,7 +466,7 @@ var _ = Describe("Egress Policy Table", func() {
 			sourceTerminalGUID, err = terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			_, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID)
+			_, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID, false, "all")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -462,7 +522,7 @@ var _ = Describe("Egress Policy Table", func() {
 			destinationTerminalGUID, err = terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID)
+			egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID, false, "all")
 			Expect(err).ToNot(HaveOccurred())
 
 			appID, err = egressPolicyTable.CreateApp(tx, sourceTerminalGUID, "some-app-guid")
@@ -482,6 +542,8 @@ var _ = Describe("Egress Policy Table", func() {
 				SourceTerminalGUID:      sourceTerminalGUID,
 				SourceAppID:             appID,
 				SourceSpaceID:           -1,
+				SourceOrgID:             -1,
+				SourceDefaultID:         -1,
 			}}))
 		})
 
@@ -498,7 +560,7 @@ var _ = Describe("Egress Policy Table", func() {
 				destinationTerminalGUIDDuplicate, err = terminalsTable.Create(tx)
 				Expect(err).ToNot(HaveOccurred())
 
-				egressPolicyIDDuplicate, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUIDDuplicate)
+				egressPolicyIDDuplicate, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUIDDuplicate, false, "all")
 				Expect(err).ToNot(HaveOccurred())
 
 				ipRangeIDDuplicate, err = egressPolicyTable.CreateIPRange(tx, destinationTerminalGUIDDuplicate, "1.1.1.1", "2.2.2.2", "tcp", 8080, 8081, 0, 0)
@@ -516,6 +578,8 @@ var _ = Describe("Egress Policy Table", func() {
 						SourceTerminalGUID:      sourceTerminalGUID,
 						SourceAppID:             appID,
 						SourceSpaceID:           -1,
+						SourceOrgID:             -1,
+						SourceDefaultID:         -1,
 					},
 					store.EgressPolicyIDCollection{
 						EgressPolicyGUID:        egressPolicyIDDuplicate,
@@ -524,6 +588,8 @@ var _ = Describe("Egress Policy Table", func() {
 						SourceTerminalGUID:      sourceTerminalGUID,
 						SourceAppID:             appID,
 						SourceSpaceID:           -1,
+						SourceOrgID:             -1,
+						SourceDefaultID:         -1,
 					},
 				))
 			})
@@ -567,7 +633,7 @@ var _ = Describe("Egress Policy Table", func() {
 				spaceID, err = egressPolicyTable.CreateSpace(tx, spaceSourceTerminalGUID, "some-space-guid")
 				Expect(err).ToNot(HaveOccurred())
 
-				spaceEgressPolicyID, err = egressPolicyTable.CreateEgressPolicy(tx, spaceSourceTerminalGUID, destinationTerminalGUID)
+				spaceEgressPolicyID, err = egressPolicyTable.CreateEgressPolicy(tx, spaceSourceTerminalGUID, destinationTerminalGUID, false, "all")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -581,6 +647,8 @@ var _ = Describe("Egress Policy Table", func() {
 					SourceTerminalGUID:      spaceSourceTerminalGUID,
 					SourceSpaceID:           spaceID,
 					SourceAppID:             -1,
+					SourceOrgID:             -1,
+					SourceDefaultID:         -1,
 				}}))
 			})
 		})
@@ -609,7 +677,7 @@ var _ = Describe("Egress Policy Table", func() {
 				destinationTerminalGUID, err = terminalsTable.Create(tx)
 				Expect(err).ToNot(HaveOccurred())
 
-				egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID)
+				egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID, false, "all")
 				Expect(err).ToNot(HaveOccurred())
 
 				appID, err = egressPolicyTable.CreateApp(tx, sourceTerminalGUID, "some-app-guid-2")
@@ -631,6 +699,8 @@ var _ = Describe("Egress Policy Table", func() {
 					SourceTerminalGUID:      sourceTerminalGUID,
 					SourceAppID:             appID,
 					SourceSpaceID:           -1,
+					SourceOrgID:             -1,
+					SourceDefaultID:         -1,
 				}}))
 			})
 		})
@@ -662,7 +732,7 @@ var _ = Describe("Egress Policy Table", func() {
 				destinationTerminalGUID, err = terminalsTable.Create(tx)
 				Expect(err).ToNot(HaveOccurred())
 
-				egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID)
+				egressPolicyGUID, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID, false, "all")
 				Expect(err).ToNot(HaveOccurred())
 
 				appID, err = egressPolicyTable.CreateApp(tx, sourceTerminalGUID, "some-app-guid-2")
@@ -677,7 +747,7 @@ var _ = Describe("Egress Policy Table", func() {
 				otherDestTermID, err := terminalsTable.Create(tx)
 				Expect(err).ToNot(HaveOccurred())
 
-				_, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, otherDestTermID)
+				_, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, otherDestTermID, false, "all")
 				Expect(err).ToNot(HaveOccurred())
 
 				otherIpRangeID, err := egressPolicyTable.CreateIPRange(tx, otherDestTermID, "1.1.1.1", "2.2.2.2", "icmp", 0, 0, 3, 4)
@@ -695,6 +765,8 @@ var _ = Describe("Egress Policy Table", func() {
 					SourceTerminalGUID:      sourceTerminalGUID,
 					SourceAppID:             appID,
 					SourceSpaceID:           -1,
+					SourceOrgID:             -1,
+					SourceDefaultID:         -1,
 				}}))
 			})
 		})
@@ -722,6 +794,38 @@ var _ = Describe("Egress Policy Table", func() {
 		})
 	})
 
+	Context("GetIDCollectionByGUID", func() {
+		It("returns the source ids of an egress policy", func() {
+			sourceTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+			destinationTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+			spaceID, err := egressPolicyTable.CreateSpace(tx, sourceTerminalGUID, "some-space-guid")
+			Expect(err).ToNot(HaveOccurred())
+			egressPolicyGUID, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID, false, "all")
+			Expect(err).ToNot(HaveOccurred())
+
+			ids, err := egressPolicyTable.GetIDCollectionByGUID(tx, egressPolicyGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal(store.EgressPolicyIDCollection{
+				EgressPolicyGUID:        egressPolicyGUID,
+				DestinationTerminalGUID: destinationTerminalGUID,
+				DestinationIPRangeID:    -1,
+				SourceTerminalGUID:      sourceTerminalGUID,
+				SourceAppID:             -1,
+				SourceSpaceID:           spaceID,
+				SourceOrgID:             -1,
+				SourceDefaultID:         -1,
+			}))
+		})
+
+		It("returns an empty collection when there is no such policy", func() {
+			ids, err := egressPolicyTable.GetIDCollectionByGUID(tx, "missing-policy-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal(store.EgressPolicyIDCollection{}))
+		})
+	})
+
 	Context("GetTerminalByAppGUID", func() {
 		It("should return the terminal id for an app if it exists", func() {
 			terminalId, err := terminalsTable.Create(tx)
@@ -844,6 +948,7 @@ var _ = Describe("Egress Policy Table", func() {
 					Destination: store.EgressDestination{
 						GUID: createdEgessDestinations[1].GUID,
 					},
+					AppLifecycle: "staging",
 				},
 				{
 					Source: store.EgressSource{
@@ -897,6 +1002,7 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 					},
+					AppLifecycle: "all",
 				},
 				{
 					ID: "guid-2",
@@ -916,6 +1022,7 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 					},
+					AppLifecycle: "staging",
 				},
 				{
 					ID: "guid-3",
@@ -937,6 +1044,7 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 					},
+					AppLifecycle: "all",
 				},
 				{
 					ID: "guid-4",
@@ -958,6 +1066,7 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 					},
+					AppLifecycle: "all",
 				},
 			}))
 		})
@@ -1100,6 +1209,41 @@ var _ = Describe("Egress Policy Table", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		Context("when there are org and default policies", func() {
+			BeforeEach(func() {
+				_, err := egressStore.Create([]store.EgressPolicy{
+					{
+						Source:      store.EgressSource{ID: "some-org-guid", Type: "org"},
+						Destination: store.EgressDestination{GUID: egressPolicies[0].Destination.GUID},
+					},
+					{
+						Source:      store.EgressSource{ID: "other-org-guid", Type: "org"},
+						Destination: store.EgressDestination{GUID: egressPolicies[1].Destination.GUID},
+					},
+					{
+						Source:      store.EgressSource{Type: "default"},
+						Destination: store.EgressDestination{GUID: egressPolicies[2].Destination.GUID},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the policies of the given orgs and every default policy", func() {
+				policies, err := egressPolicyTable.GetBySourceGuids([]string{"some-app-guid", "some-org-guid"})
+				Expect(err).ToNot(HaveOccurred())
+
+				var sources []store.EgressSource
+				for _, policy := range policies {
+					sources = append(sources, policy.Source)
+				}
+				Expect(sources).To(ConsistOf(
+					store.EgressSource{ID: "some-app-guid", Type: "app"},
+					store.EgressSource{ID: "some-org-guid", Type: "org"},
+					store.EgressSource{Type: "default"},
+				))
+			})
+		})
+
 		Context("when there are policies with the given id", func() {
 			It("returns egress policies with those ids", func() {
 				policies, err := egressPolicyTable.GetBySourceGuids([]string{"some-app-guid", "different-app-guid", "some-space-guid"})
@@ -1112,6 +1256,24 @@ var _ = Describe("Egress Policy Table", func() {
 			})
 		})
 
+		It("returns the policy and destination ids", func() {
+			allPolicies, err := egressPolicyTable.GetAllPolicies()
+			Expect(err).ToNot(HaveOccurred())
+			var expectedPolicyGUID string
+			for _, policy := range allPolicies {
+				if policy.Source.ID == "some-app-guid" {
+					expectedPolicyGUID = policy.ID
+				}
+			}
+
+			policies, err := egressPolicyTable.GetBySourceGuids([]string{"some-app-guid"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].ID).To(Equal(expectedPolicyGUID))
+			Expect(policies[0].Destination.GUID).To(Equal(egressPolicies[0].Destination.GUID))
+			Expect(policies[0].Destination.Name).To(Equal("a"))
+		})
+
 		Context("when there are no policies with the given id", func() {
 			It("returns no egress policies", func() {
 				policies, err := egressPolicyTable.GetBySourceGuids([]string{"meow-this-is-a-bogus-app-guid"})
@@ -1142,7 +1304,7 @@ func egressDestinationStore(realDb store.Database) *store.EgressDestinationStore
 
 	destinationMetadataTable := &store.DestinationMetadataTable{}
 	egressDestinationStore := &store.EgressDestinationStore{
-		Conn: realDb,
+		Conn:                    realDb,
 		EgressDestinationRepo:   &store.EgressDestinationTable{},
 		TerminalsRepo:           terminalsRepo,
 		DestinationMetadataRepo: destinationMetadataTable,
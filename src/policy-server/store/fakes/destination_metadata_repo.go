@@ -7,13 +7,13 @@ import (
 )
 
 type DestinationMetadataRepo struct {
-	CreateStub        func(tx db.Transaction, terminalGUID, name, description string) (int64, error)
+	CreateStub        func(db.Transaction, string, string, string) (int64, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		tx           db.Transaction
-		terminalGUID string
-		name         string
-		description  string
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 string
 	}
 	createReturns struct {
 		result1 int64
@@ -23,28 +23,42 @@ type DestinationMetadataRepo struct {
 		result1 int64
 		result2 error
 	}
+	DeleteStub        func(db.Transaction, string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *DestinationMetadataRepo) Create(tx db.Transaction, terminalGUID string, name string, description string) (int64, error) {
+func (fake *DestinationMetadataRepo) Create(arg1 db.Transaction, arg2 string, arg3 string, arg4 string) (int64, error) {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		tx           db.Transaction
-		terminalGUID string
-		name         string
-		description  string
-	}{tx, terminalGUID, name, description})
-	fake.recordInvocation("Create", []interface{}{tx, terminalGUID, name, description})
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
+	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3, arg4})
 	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(tx, terminalGUID, name, description)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createReturns.result1, fake.createReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *DestinationMetadataRepo) CreateCallCount() int {
@@ -53,13 +67,22 @@ func (fake *DestinationMetadataRepo) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
+func (fake *DestinationMetadataRepo) CreateCalls(stub func(db.Transaction, string, string, string) (int64, error)) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
 func (fake *DestinationMetadataRepo) CreateArgsForCall(i int) (db.Transaction, string, string, string) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].tx, fake.createArgsForCall[i].terminalGUID, fake.createArgsForCall[i].name, fake.createArgsForCall[i].description
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *DestinationMetadataRepo) CreateReturns(result1 int64, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	fake.createReturns = struct {
 		result1 int64
@@ -68,6 +91,8 @@ func (fake *DestinationMetadataRepo) CreateReturns(result1 int64, result2 error)
 }
 
 func (fake *DestinationMetadataRepo) CreateReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	if fake.createReturnsOnCall == nil {
 		fake.createReturnsOnCall = make(map[int]struct {
@@ -81,11 +106,75 @@ func (fake *DestinationMetadataRepo) CreateReturnsOnCall(i int, result1 int64, r
 	}{result1, result2}
 }
 
+func (fake *DestinationMetadataRepo) Delete(arg1 db.Transaction, arg2 string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *DestinationMetadataRepo) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *DestinationMetadataRepo) DeleteCalls(stub func(db.Transaction, string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *DestinationMetadataRepo) DeleteArgsForCall(i int) (db.Transaction, string) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *DestinationMetadataRepo) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DestinationMetadataRepo) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *DestinationMetadataRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -8,6 +8,20 @@ import (
 )
 
 type DestinationRepo struct {
+	CountWhereGroupIDStub        func(db.Transaction, int) (int, error)
+	countWhereGroupIDMutex       sync.RWMutex
+	countWhereGroupIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int
+	}
+	countWhereGroupIDReturns struct {
+		result1 int
+		result2 error
+	}
+	countWhereGroupIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	CreateStub        func(db.Transaction, int, int, int, int, string) (int, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
@@ -26,6 +40,20 @@ type DestinationRepo struct {
 		result1 int
 		result2 error
 	}
+	CreateManyStub        func(db.Transaction, []store.DestinationRow) (map[store.DestinationRow]int, error)
+	createManyMutex       sync.RWMutex
+	createManyArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 []store.DestinationRow
+	}
+	createManyReturns struct {
+		result1 map[store.DestinationRow]int
+		result2 error
+	}
+	createManyReturnsOnCall map[int]struct {
+		result1 map[store.DestinationRow]int
+		result2 error
+	}
 	DeleteStub        func(db.Transaction, int) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -56,22 +84,73 @@ type DestinationRepo struct {
 		result1 int
 		result2 error
 	}
-	CountWhereGroupIDStub        func(db.Transaction, int) (int, error)
-	countWhereGroupIDMutex       sync.RWMutex
-	countWhereGroupIDArgsForCall []struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DestinationRepo) CountWhereGroupID(arg1 db.Transaction, arg2 int) (int, error) {
+	fake.countWhereGroupIDMutex.Lock()
+	ret, specificReturn := fake.countWhereGroupIDReturnsOnCall[len(fake.countWhereGroupIDArgsForCall)]
+	fake.countWhereGroupIDArgsForCall = append(fake.countWhereGroupIDArgsForCall, struct {
 		arg1 db.Transaction
 		arg2 int
+	}{arg1, arg2})
+	stub := fake.CountWhereGroupIDStub
+	fakeReturns := fake.countWhereGroupIDReturns
+	fake.recordInvocation("CountWhereGroupID", []interface{}{arg1, arg2})
+	fake.countWhereGroupIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
-	countWhereGroupIDReturns struct {
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DestinationRepo) CountWhereGroupIDCallCount() int {
+	fake.countWhereGroupIDMutex.RLock()
+	defer fake.countWhereGroupIDMutex.RUnlock()
+	return len(fake.countWhereGroupIDArgsForCall)
+}
+
+func (fake *DestinationRepo) CountWhereGroupIDCalls(stub func(db.Transaction, int) (int, error)) {
+	fake.countWhereGroupIDMutex.Lock()
+	defer fake.countWhereGroupIDMutex.Unlock()
+	fake.CountWhereGroupIDStub = stub
+}
+
+func (fake *DestinationRepo) CountWhereGroupIDArgsForCall(i int) (db.Transaction, int) {
+	fake.countWhereGroupIDMutex.RLock()
+	defer fake.countWhereGroupIDMutex.RUnlock()
+	argsForCall := fake.countWhereGroupIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *DestinationRepo) CountWhereGroupIDReturns(result1 int, result2 error) {
+	fake.countWhereGroupIDMutex.Lock()
+	defer fake.countWhereGroupIDMutex.Unlock()
+	fake.CountWhereGroupIDStub = nil
+	fake.countWhereGroupIDReturns = struct {
 		result1 int
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *DestinationRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.countWhereGroupIDMutex.Lock()
+	defer fake.countWhereGroupIDMutex.Unlock()
+	fake.CountWhereGroupIDStub = nil
+	if fake.countWhereGroupIDReturnsOnCall == nil {
+		fake.countWhereGroupIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
 	}
-	countWhereGroupIDReturnsOnCall map[int]struct {
+	fake.countWhereGroupIDReturnsOnCall[i] = struct {
 		result1 int
 		result2 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	}{result1, result2}
 }
 
 func (fake *DestinationRepo) Create(arg1 db.Transaction, arg2 int, arg3 int, arg4 int, arg5 int, arg6 string) (int, error) {
@@ -85,15 +164,17 @@ func (fake *DestinationRepo) Create(arg1 db.Transaction, arg2 int, arg3 int, arg
 		arg5 int
 		arg6 string
 	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
 	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createReturns.result1, fake.createReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *DestinationRepo) CreateCallCount() int {
@@ -102,13 +183,22 @@ func (fake *DestinationRepo) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
+func (fake *DestinationRepo) CreateCalls(stub func(db.Transaction, int, int, int, int, string) (int, error)) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
 func (fake *DestinationRepo) CreateArgsForCall(i int) (db.Transaction, int, int, int, int, string) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2, fake.createArgsForCall[i].arg3, fake.createArgsForCall[i].arg4, fake.createArgsForCall[i].arg5, fake.createArgsForCall[i].arg6
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *DestinationRepo) CreateReturns(result1 int, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	fake.createReturns = struct {
 		result1 int
@@ -117,6 +207,8 @@ func (fake *DestinationRepo) CreateReturns(result1 int, result2 error) {
 }
 
 func (fake *DestinationRepo) CreateReturnsOnCall(i int, result1 int, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	if fake.createReturnsOnCall == nil {
 		fake.createReturnsOnCall = make(map[int]struct {
@@ -130,6 +222,76 @@ func (fake *DestinationRepo) CreateReturnsOnCall(i int, result1 int, result2 err
 	}{result1, result2}
 }
 
+func (fake *DestinationRepo) CreateMany(arg1 db.Transaction, arg2 []store.DestinationRow) (map[store.DestinationRow]int, error) {
+	var arg2Copy []store.DestinationRow
+	if arg2 != nil {
+		arg2Copy = make([]store.DestinationRow, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createManyMutex.Lock()
+	ret, specificReturn := fake.createManyReturnsOnCall[len(fake.createManyArgsForCall)]
+	fake.createManyArgsForCall = append(fake.createManyArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 []store.DestinationRow
+	}{arg1, arg2Copy})
+	stub := fake.CreateManyStub
+	fakeReturns := fake.createManyReturns
+	fake.recordInvocation("CreateMany", []interface{}{arg1, arg2Copy})
+	fake.createManyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DestinationRepo) CreateManyCallCount() int {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return len(fake.createManyArgsForCall)
+}
+
+func (fake *DestinationRepo) CreateManyCalls(stub func(db.Transaction, []store.DestinationRow) (map[store.DestinationRow]int, error)) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = stub
+}
+
+func (fake *DestinationRepo) CreateManyArgsForCall(i int) (db.Transaction, []store.DestinationRow) {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	argsForCall := fake.createManyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *DestinationRepo) CreateManyReturns(result1 map[store.DestinationRow]int, result2 error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = nil
+	fake.createManyReturns = struct {
+		result1 map[store.DestinationRow]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DestinationRepo) CreateManyReturnsOnCall(i int, result1 map[store.DestinationRow]int, result2 error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = nil
+	if fake.createManyReturnsOnCall == nil {
+		fake.createManyReturnsOnCall = make(map[int]struct {
+			result1 map[store.DestinationRow]int
+			result2 error
+		})
+	}
+	fake.createManyReturnsOnCall[i] = struct {
+		result1 map[store.DestinationRow]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *DestinationRepo) Delete(arg1 db.Transaction, arg2 int) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -137,15 +299,17 @@ func (fake *DestinationRepo) Delete(arg1 db.Transaction, arg2 int) error {
 		arg1 db.Transaction
 		arg2 int
 	}{arg1, arg2})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
 	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
 	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *DestinationRepo) DeleteCallCount() int {
@@ -154,13 +318,22 @@ func (fake *DestinationRepo) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
+func (fake *DestinationRepo) DeleteCalls(stub func(db.Transaction, int) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
 func (fake *DestinationRepo) DeleteArgsForCall(i int) (db.Transaction, int) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *DestinationRepo) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	fake.deleteReturns = struct {
 		result1 error
@@ -168,6 +341,8 @@ func (fake *DestinationRepo) DeleteReturns(result1 error) {
 }
 
 func (fake *DestinationRepo) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	if fake.deleteReturnsOnCall == nil {
 		fake.deleteReturnsOnCall = make(map[int]struct {
@@ -190,15 +365,17 @@ func (fake *DestinationRepo) GetID(arg1 db.Transaction, arg2 int, arg3 int, arg4
 		arg5 int
 		arg6 string
 	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.GetIDStub
+	fakeReturns := fake.getIDReturns
 	fake.recordInvocation("GetID", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.getIDMutex.Unlock()
-	if fake.GetIDStub != nil {
-		return fake.GetIDStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getIDReturns.result1, fake.getIDReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *DestinationRepo) GetIDCallCount() int {
@@ -207,13 +384,22 @@ func (fake *DestinationRepo) GetIDCallCount() int {
 	return len(fake.getIDArgsForCall)
 }
 
+func (fake *DestinationRepo) GetIDCalls(stub func(db.Transaction, int, int, int, int, string) (int, error)) {
+	fake.getIDMutex.Lock()
+	defer fake.getIDMutex.Unlock()
+	fake.GetIDStub = stub
+}
+
 func (fake *DestinationRepo) GetIDArgsForCall(i int) (db.Transaction, int, int, int, int, string) {
 	fake.getIDMutex.RLock()
 	defer fake.getIDMutex.RUnlock()
-	return fake.getIDArgsForCall[i].arg1, fake.getIDArgsForCall[i].arg2, fake.getIDArgsForCall[i].arg3, fake.getIDArgsForCall[i].arg4, fake.getIDArgsForCall[i].arg5, fake.getIDArgsForCall[i].arg6
+	argsForCall := fake.getIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *DestinationRepo) GetIDReturns(result1 int, result2 error) {
+	fake.getIDMutex.Lock()
+	defer fake.getIDMutex.Unlock()
 	fake.GetIDStub = nil
 	fake.getIDReturns = struct {
 		result1 int
@@ -222,6 +408,8 @@ func (fake *DestinationRepo) GetIDReturns(result1 int, result2 error) {
 }
 
 func (fake *DestinationRepo) GetIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.getIDMutex.Lock()
+	defer fake.getIDMutex.Unlock()
 	fake.GetIDStub = nil
 	if fake.getIDReturnsOnCall == nil {
 		fake.getIDReturnsOnCall = make(map[int]struct {
@@ -235,69 +423,19 @@ func (fake *DestinationRepo) GetIDReturnsOnCall(i int, result1 int, result2 erro
 	}{result1, result2}
 }
 
-func (fake *DestinationRepo) CountWhereGroupID(arg1 db.Transaction, arg2 int) (int, error) {
-	fake.countWhereGroupIDMutex.Lock()
-	ret, specificReturn := fake.countWhereGroupIDReturnsOnCall[len(fake.countWhereGroupIDArgsForCall)]
-	fake.countWhereGroupIDArgsForCall = append(fake.countWhereGroupIDArgsForCall, struct {
-		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("CountWhereGroupID", []interface{}{arg1, arg2})
-	fake.countWhereGroupIDMutex.Unlock()
-	if fake.CountWhereGroupIDStub != nil {
-		return fake.CountWhereGroupIDStub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.countWhereGroupIDReturns.result1, fake.countWhereGroupIDReturns.result2
-}
-
-func (fake *DestinationRepo) CountWhereGroupIDCallCount() int {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return len(fake.countWhereGroupIDArgsForCall)
-}
-
-func (fake *DestinationRepo) CountWhereGroupIDArgsForCall(i int) (db.Transaction, int) {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return fake.countWhereGroupIDArgsForCall[i].arg1, fake.countWhereGroupIDArgsForCall[i].arg2
-}
-
-func (fake *DestinationRepo) CountWhereGroupIDReturns(result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	fake.countWhereGroupIDReturns = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *DestinationRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	if fake.countWhereGroupIDReturnsOnCall == nil {
-		fake.countWhereGroupIDReturnsOnCall = make(map[int]struct {
-			result1 int
-			result2 error
-		})
-	}
-	fake.countWhereGroupIDReturnsOnCall[i] = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *DestinationRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.countWhereGroupIDMutex.RLock()
+	defer fake.countWhereGroupIDMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.getIDMutex.RLock()
 	defer fake.getIDMutex.RUnlock()
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
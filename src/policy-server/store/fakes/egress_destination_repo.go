@@ -8,10 +8,10 @@ import (
 )
 
 type EgressDestinationRepo struct {
-	AllStub        func(tx db.Transaction) ([]store.EgressDestination, error)
+	AllStub        func(db.Transaction) ([]store.EgressDestination, error)
 	allMutex       sync.RWMutex
 	allArgsForCall []struct {
-		tx db.Transaction
+		arg1 db.Transaction
 	}
 	allReturns struct {
 		result1 []store.EgressDestination
@@ -21,18 +21,18 @@ type EgressDestinationRepo struct {
 		result1 []store.EgressDestination
 		result2 error
 	}
-	CreateIPRangeStub        func(tx db.Transaction, destinationTerminalGUID, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error)
+	CreateIPRangeStub        func(db.Transaction, string, string, string, string, int64, int64, int64, int64) (int64, error)
 	createIPRangeMutex       sync.RWMutex
 	createIPRangeArgsForCall []struct {
-		tx                      db.Transaction
-		destinationTerminalGUID string
-		startIP                 string
-		endIP                   string
-		protocol                string
-		startPort               int64
-		endPort                 int64
-		icmpType                int64
-		icmpCode                int64
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int64
+		arg7 int64
+		arg8 int64
+		arg9 int64
 	}
 	createIPRangeReturns struct {
 		result1 int64
@@ -42,25 +42,39 @@ type EgressDestinationRepo struct {
 		result1 int64
 		result2 error
 	}
+	DeleteStub        func(db.Transaction, string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressDestinationRepo) All(tx db.Transaction) ([]store.EgressDestination, error) {
+func (fake *EgressDestinationRepo) All(arg1 db.Transaction) ([]store.EgressDestination, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
 	fake.allArgsForCall = append(fake.allArgsForCall, struct {
-		tx db.Transaction
-	}{tx})
-	fake.recordInvocation("All", []interface{}{tx})
+		arg1 db.Transaction
+	}{arg1})
+	stub := fake.AllStub
+	fakeReturns := fake.allReturns
+	fake.recordInvocation("All", []interface{}{arg1})
 	fake.allMutex.Unlock()
-	if fake.AllStub != nil {
-		return fake.AllStub(tx)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.allReturns.result1, fake.allReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *EgressDestinationRepo) AllCallCount() int {
@@ -69,13 +83,22 @@ func (fake *EgressDestinationRepo) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *EgressDestinationRepo) AllCalls(stub func(db.Transaction) ([]store.EgressDestination, error)) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = stub
+}
+
 func (fake *EgressDestinationRepo) AllArgsForCall(i int) db.Transaction {
 	fake.allMutex.RLock()
 	defer fake.allMutex.RUnlock()
-	return fake.allArgsForCall[i].tx
+	argsForCall := fake.allArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *EgressDestinationRepo) AllReturns(result1 []store.EgressDestination, result2 error) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
 	fake.AllStub = nil
 	fake.allReturns = struct {
 		result1 []store.EgressDestination
@@ -84,6 +107,8 @@ func (fake *EgressDestinationRepo) AllReturns(result1 []store.EgressDestination,
 }
 
 func (fake *EgressDestinationRepo) AllReturnsOnCall(i int, result1 []store.EgressDestination, result2 error) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
 	fake.AllStub = nil
 	if fake.allReturnsOnCall == nil {
 		fake.allReturnsOnCall = make(map[int]struct {
@@ -97,29 +122,31 @@ func (fake *EgressDestinationRepo) AllReturnsOnCall(i int, result1 []store.Egres
 	}{result1, result2}
 }
 
-func (fake *EgressDestinationRepo) CreateIPRange(tx db.Transaction, destinationTerminalGUID string, startIP string, endIP string, protocol string, startPort int64, endPort int64, icmpType int64, icmpCode int64) (int64, error) {
+func (fake *EgressDestinationRepo) CreateIPRange(arg1 db.Transaction, arg2 string, arg3 string, arg4 string, arg5 string, arg6 int64, arg7 int64, arg8 int64, arg9 int64) (int64, error) {
 	fake.createIPRangeMutex.Lock()
 	ret, specificReturn := fake.createIPRangeReturnsOnCall[len(fake.createIPRangeArgsForCall)]
 	fake.createIPRangeArgsForCall = append(fake.createIPRangeArgsForCall, struct {
-		tx                      db.Transaction
-		destinationTerminalGUID string
-		startIP                 string
-		endIP                   string
-		protocol                string
-		startPort               int64
-		endPort                 int64
-		icmpType                int64
-		icmpCode                int64
-	}{tx, destinationTerminalGUID, startIP, endIP, protocol, startPort, endPort, icmpType, icmpCode})
-	fake.recordInvocation("CreateIPRange", []interface{}{tx, destinationTerminalGUID, startIP, endIP, protocol, startPort, endPort, icmpType, icmpCode})
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int64
+		arg7 int64
+		arg8 int64
+		arg9 int64
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	stub := fake.CreateIPRangeStub
+	fakeReturns := fake.createIPRangeReturns
+	fake.recordInvocation("CreateIPRange", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
 	fake.createIPRangeMutex.Unlock()
-	if fake.CreateIPRangeStub != nil {
-		return fake.CreateIPRangeStub(tx, destinationTerminalGUID, startIP, endIP, protocol, startPort, endPort, icmpType, icmpCode)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createIPRangeReturns.result1, fake.createIPRangeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *EgressDestinationRepo) CreateIPRangeCallCount() int {
@@ -128,13 +155,22 @@ func (fake *EgressDestinationRepo) CreateIPRangeCallCount() int {
 	return len(fake.createIPRangeArgsForCall)
 }
 
+func (fake *EgressDestinationRepo) CreateIPRangeCalls(stub func(db.Transaction, string, string, string, string, int64, int64, int64, int64) (int64, error)) {
+	fake.createIPRangeMutex.Lock()
+	defer fake.createIPRangeMutex.Unlock()
+	fake.CreateIPRangeStub = stub
+}
+
 func (fake *EgressDestinationRepo) CreateIPRangeArgsForCall(i int) (db.Transaction, string, string, string, string, int64, int64, int64, int64) {
 	fake.createIPRangeMutex.RLock()
 	defer fake.createIPRangeMutex.RUnlock()
-	return fake.createIPRangeArgsForCall[i].tx, fake.createIPRangeArgsForCall[i].destinationTerminalGUID, fake.createIPRangeArgsForCall[i].startIP, fake.createIPRangeArgsForCall[i].endIP, fake.createIPRangeArgsForCall[i].protocol, fake.createIPRangeArgsForCall[i].startPort, fake.createIPRangeArgsForCall[i].endPort, fake.createIPRangeArgsForCall[i].icmpType, fake.createIPRangeArgsForCall[i].icmpCode
+	argsForCall := fake.createIPRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9
 }
 
 func (fake *EgressDestinationRepo) CreateIPRangeReturns(result1 int64, result2 error) {
+	fake.createIPRangeMutex.Lock()
+	defer fake.createIPRangeMutex.Unlock()
 	fake.CreateIPRangeStub = nil
 	fake.createIPRangeReturns = struct {
 		result1 int64
@@ -143,6 +179,8 @@ func (fake *EgressDestinationRepo) CreateIPRangeReturns(result1 int64, result2 e
 }
 
 func (fake *EgressDestinationRepo) CreateIPRangeReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createIPRangeMutex.Lock()
+	defer fake.createIPRangeMutex.Unlock()
 	fake.CreateIPRangeStub = nil
 	if fake.createIPRangeReturnsOnCall == nil {
 		fake.createIPRangeReturnsOnCall = make(map[int]struct {
@@ -156,6 +194,68 @@ func (fake *EgressDestinationRepo) CreateIPRangeReturnsOnCall(i int, result1 int
 	}{result1, result2}
 }
 
+func (fake *EgressDestinationRepo) Delete(arg1 db.Transaction, arg2 string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *EgressDestinationRepo) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *EgressDestinationRepo) DeleteCalls(stub func(db.Transaction, string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *EgressDestinationRepo) DeleteArgsForCall(i int) (db.Transaction, string) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressDestinationRepo) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressDestinationRepo) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *EgressDestinationRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -163,6 +263,8 @@ func (fake *EgressDestinationRepo) Invocations() map[string][][]interface{} {
 	defer fake.allMutex.RUnlock()
 	fake.createIPRangeMutex.RLock()
 	defer fake.createIPRangeMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -8,12 +8,12 @@ import (
 )
 
 type EgressPolicyRepo struct {
-	CreateAppStub        func(tx db.Transaction, sourceTerminalGUID string, appGUID string) (int64, error)
+	CreateAppStub        func(db.Transaction, string, string) (int64, error)
 	createAppMutex       sync.RWMutex
 	createAppArgsForCall []struct {
-		tx                 db.Transaction
-		sourceTerminalGUID string
-		appGUID            string
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
 	}
 	createAppReturns struct {
 		result1 int64
@@ -23,33 +23,28 @@ type EgressPolicyRepo struct {
 		result1 int64
 		result2 error
 	}
-	CreateIPRangeStub        func(tx db.Transaction, destinationTerminalGUID string, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error)
-	createIPRangeMutex       sync.RWMutex
-	createIPRangeArgsForCall []struct {
-		tx                      db.Transaction
-		destinationTerminalGUID string
-		startIP                 string
-		endIP                   string
-		protocol                string
-		startPort               int64
-		endPort                 int64
-		icmpType                int64
-		icmpCode                int64
+	CreateDefaultStub        func(db.Transaction, string) (int64, error)
+	createDefaultMutex       sync.RWMutex
+	createDefaultArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
 	}
-	createIPRangeReturns struct {
+	createDefaultReturns struct {
 		result1 int64
 		result2 error
 	}
-	createIPRangeReturnsOnCall map[int]struct {
+	createDefaultReturnsOnCall map[int]struct {
 		result1 int64
 		result2 error
 	}
-	CreateEgressPolicyStub        func(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string) (string, error)
+	CreateEgressPolicyStub        func(db.Transaction, string, string, bool, string) (string, error)
 	createEgressPolicyMutex       sync.RWMutex
 	createEgressPolicyArgsForCall []struct {
-		tx                      db.Transaction
-		sourceTerminalGUID      string
-		destinationTerminalGUID string
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 bool
+		arg5 string
 	}
 	createEgressPolicyReturns struct {
 		result1 string
@@ -59,53 +54,134 @@ type EgressPolicyRepo struct {
 		result1 string
 		result2 error
 	}
-	CreateSpaceStub        func(tx db.Transaction, sourceTerminalGUID string, spaceGUID string) (int64, error)
-	createSpaceMutex       sync.RWMutex
-	createSpaceArgsForCall []struct {
-		tx                 db.Transaction
-		sourceTerminalGUID string
-		spaceGUID          string
+	CreateIPRangeStub        func(db.Transaction, string, string, string, string, int64, int64, int64, int64) (int64, error)
+	createIPRangeMutex       sync.RWMutex
+	createIPRangeArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int64
+		arg7 int64
+		arg8 int64
+		arg9 int64
 	}
-	createSpaceReturns struct {
+	createIPRangeReturns struct {
 		result1 int64
 		result2 error
 	}
-	createSpaceReturnsOnCall map[int]struct {
+	createIPRangeReturnsOnCall map[int]struct {
 		result1 int64
 		result2 error
 	}
-	GetTerminalByAppGUIDStub        func(tx db.Transaction, appGUID string) (string, error)
-	getTerminalByAppGUIDMutex       sync.RWMutex
-	getTerminalByAppGUIDArgsForCall []struct {
-		tx      db.Transaction
-		appGUID string
+	CreateOrgStub        func(db.Transaction, string, string) (int64, error)
+	createOrgMutex       sync.RWMutex
+	createOrgArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
 	}
-	getTerminalByAppGUIDReturns struct {
-		result1 string
+	createOrgReturns struct {
+		result1 int64
 		result2 error
 	}
-	getTerminalByAppGUIDReturnsOnCall map[int]struct {
-		result1 string
+	createOrgReturnsOnCall map[int]struct {
+		result1 int64
 		result2 error
 	}
-	GetTerminalBySpaceGUIDStub        func(tx db.Transaction, appGUID string) (string, error)
-	getTerminalBySpaceGUIDMutex       sync.RWMutex
-	getTerminalBySpaceGUIDArgsForCall []struct {
-		tx      db.Transaction
-		appGUID string
+	CreateSpaceStub        func(db.Transaction, string, string) (int64, error)
+	createSpaceMutex       sync.RWMutex
+	createSpaceArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
 	}
-	getTerminalBySpaceGUIDReturns struct {
-		result1 string
+	createSpaceReturns struct {
+		result1 int64
 		result2 error
 	}
-	getTerminalBySpaceGUIDReturnsOnCall map[int]struct {
-		result1 string
+	createSpaceReturnsOnCall map[int]struct {
+		result1 int64
 		result2 error
 	}
+	DeleteAppStub        func(db.Transaction, int64) error
+	deleteAppMutex       sync.RWMutex
+	deleteAppArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int64
+	}
+	deleteAppReturns struct {
+		result1 error
+	}
+	deleteAppReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteDefaultStub        func(db.Transaction, int64) error
+	deleteDefaultMutex       sync.RWMutex
+	deleteDefaultArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int64
+	}
+	deleteDefaultReturns struct {
+		result1 error
+	}
+	deleteDefaultReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteEgressPolicyStub        func(db.Transaction, string) error
+	deleteEgressPolicyMutex       sync.RWMutex
+	deleteEgressPolicyArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+	}
+	deleteEgressPolicyReturns struct {
+		result1 error
+	}
+	deleteEgressPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteIPRangeStub        func(db.Transaction, int64) error
+	deleteIPRangeMutex       sync.RWMutex
+	deleteIPRangeArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int64
+	}
+	deleteIPRangeReturns struct {
+		result1 error
+	}
+	deleteIPRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteOrgStub        func(db.Transaction, int64) error
+	deleteOrgMutex       sync.RWMutex
+	deleteOrgArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int64
+	}
+	deleteOrgReturns struct {
+		result1 error
+	}
+	deleteOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteSpaceStub        func(db.Transaction, int64) error
+	deleteSpaceMutex       sync.RWMutex
+	deleteSpaceArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int64
+	}
+	deleteSpaceReturns struct {
+		result1 error
+	}
+	deleteSpaceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAllPoliciesStub        func() ([]store.EgressPolicy, error)
 	getAllPoliciesMutex       sync.RWMutex
-	getAllPoliciesArgsForCall []struct{}
-	getAllPoliciesReturns     struct {
+	getAllPoliciesArgsForCall []struct {
+	}
+	getAllPoliciesReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
@@ -113,10 +189,10 @@ type EgressPolicyRepo struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	GetBySourceGuidsStub        func(ids []string) ([]store.EgressPolicy, error)
+	GetBySourceGuidsStub        func([]string) ([]store.EgressPolicy, error)
 	getBySourceGuidsMutex       sync.RWMutex
 	getBySourceGuidsArgsForCall []struct {
-		ids []string
+		arg1 []string
 	}
 	getBySourceGuidsReturns struct {
 		result1 []store.EgressPolicy
@@ -126,11 +202,38 @@ type EgressPolicyRepo struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	GetIDCollectionsByEgressPolicyStub        func(tx db.Transaction, egressPolicy store.EgressPolicy) ([]store.EgressPolicyIDCollection, error)
+	GetDefaultTerminalStub        func(db.Transaction) (string, error)
+	getDefaultTerminalMutex       sync.RWMutex
+	getDefaultTerminalArgsForCall []struct {
+		arg1 db.Transaction
+	}
+	getDefaultTerminalReturns struct {
+		result1 string
+		result2 error
+	}
+	getDefaultTerminalReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetIDCollectionByGUIDStub        func(db.Transaction, string) (store.EgressPolicyIDCollection, error)
+	getIDCollectionByGUIDMutex       sync.RWMutex
+	getIDCollectionByGUIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+	}
+	getIDCollectionByGUIDReturns struct {
+		result1 store.EgressPolicyIDCollection
+		result2 error
+	}
+	getIDCollectionByGUIDReturnsOnCall map[int]struct {
+		result1 store.EgressPolicyIDCollection
+		result2 error
+	}
+	GetIDCollectionsByEgressPolicyStub        func(db.Transaction, store.EgressPolicy) ([]store.EgressPolicyIDCollection, error)
 	getIDCollectionsByEgressPolicyMutex       sync.RWMutex
 	getIDCollectionsByEgressPolicyArgsForCall []struct {
-		tx           db.Transaction
-		egressPolicy store.EgressPolicy
+		arg1 db.Transaction
+		arg2 store.EgressPolicy
 	}
 	getIDCollectionsByEgressPolicyReturns struct {
 		result1 []store.EgressPolicyIDCollection
@@ -140,59 +243,53 @@ type EgressPolicyRepo struct {
 		result1 []store.EgressPolicyIDCollection
 		result2 error
 	}
-	DeleteEgressPolicyStub        func(tx db.Transaction, egressPolicyGUID string) error
-	deleteEgressPolicyMutex       sync.RWMutex
-	deleteEgressPolicyArgsForCall []struct {
-		tx               db.Transaction
-		egressPolicyGUID string
-	}
-	deleteEgressPolicyReturns struct {
-		result1 error
-	}
-	deleteEgressPolicyReturnsOnCall map[int]struct {
-		result1 error
-	}
-	DeleteIPRangeStub        func(tx db.Transaction, ipRangeID int64) error
-	deleteIPRangeMutex       sync.RWMutex
-	deleteIPRangeArgsForCall []struct {
-		tx        db.Transaction
-		ipRangeID int64
+	GetTerminalByAppGUIDStub        func(db.Transaction, string) (string, error)
+	getTerminalByAppGUIDMutex       sync.RWMutex
+	getTerminalByAppGUIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
 	}
-	deleteIPRangeReturns struct {
-		result1 error
+	getTerminalByAppGUIDReturns struct {
+		result1 string
+		result2 error
 	}
-	deleteIPRangeReturnsOnCall map[int]struct {
-		result1 error
+	getTerminalByAppGUIDReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
 	}
-	DeleteAppStub        func(tx db.Transaction, appID int64) error
-	deleteAppMutex       sync.RWMutex
-	deleteAppArgsForCall []struct {
-		tx    db.Transaction
-		appID int64
+	GetTerminalByOrgGUIDStub        func(db.Transaction, string) (string, error)
+	getTerminalByOrgGUIDMutex       sync.RWMutex
+	getTerminalByOrgGUIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
 	}
-	deleteAppReturns struct {
-		result1 error
+	getTerminalByOrgGUIDReturns struct {
+		result1 string
+		result2 error
 	}
-	deleteAppReturnsOnCall map[int]struct {
-		result1 error
+	getTerminalByOrgGUIDReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
 	}
-	DeleteSpaceStub        func(tx db.Transaction, spaceID int64) error
-	deleteSpaceMutex       sync.RWMutex
-	deleteSpaceArgsForCall []struct {
-		tx      db.Transaction
-		spaceID int64
+	GetTerminalBySpaceGUIDStub        func(db.Transaction, string) (string, error)
+	getTerminalBySpaceGUIDMutex       sync.RWMutex
+	getTerminalBySpaceGUIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
 	}
-	deleteSpaceReturns struct {
-		result1 error
+	getTerminalBySpaceGUIDReturns struct {
+		result1 string
+		result2 error
 	}
-	deleteSpaceReturnsOnCall map[int]struct {
-		result1 error
+	getTerminalBySpaceGUIDReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
 	}
-	IsTerminalInUseStub        func(tx db.Transaction, terminalGUID string) (bool, error)
+	IsTerminalInUseStub        func(db.Transaction, string) (bool, error)
 	isTerminalInUseMutex       sync.RWMutex
 	isTerminalInUseArgsForCall []struct {
-		tx           db.Transaction
-		terminalGUID string
+		arg1 db.Transaction
+		arg2 string
 	}
 	isTerminalInUseReturns struct {
 		result1 bool
@@ -206,23 +303,25 @@ type EgressPolicyRepo struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressPolicyRepo) CreateApp(tx db.Transaction, sourceTerminalGUID string, appGUID string) (int64, error) {
+func (fake *EgressPolicyRepo) CreateApp(arg1 db.Transaction, arg2 string, arg3 string) (int64, error) {
 	fake.createAppMutex.Lock()
 	ret, specificReturn := fake.createAppReturnsOnCall[len(fake.createAppArgsForCall)]
 	fake.createAppArgsForCall = append(fake.createAppArgsForCall, struct {
-		tx                 db.Transaction
-		sourceTerminalGUID string
-		appGUID            string
-	}{tx, sourceTerminalGUID, appGUID})
-	fake.recordInvocation("CreateApp", []interface{}{tx, sourceTerminalGUID, appGUID})
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateAppStub
+	fakeReturns := fake.createAppReturns
+	fake.recordInvocation("CreateApp", []interface{}{arg1, arg2, arg3})
 	fake.createAppMutex.Unlock()
-	if fake.CreateAppStub != nil {
-		return fake.CreateAppStub(tx, sourceTerminalGUID, appGUID)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createAppReturns.result1, fake.createAppReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *EgressPolicyRepo) CreateAppCallCount() int {
@@ -231,13 +330,22 @@ func (fake *EgressPolicyRepo) CreateAppCallCount() int {
 	return len(fake.createAppArgsForCall)
 }
 
+func (fake *EgressPolicyRepo) CreateAppCalls(stub func(db.Transaction, string, string) (int64, error)) {
+	fake.createAppMutex.Lock()
+	defer fake.createAppMutex.Unlock()
+	fake.CreateAppStub = stub
+}
+
 func (fake *EgressPolicyRepo) CreateAppArgsForCall(i int) (db.Transaction, string, string) {
 	fake.createAppMutex.RLock()
 	defer fake.createAppMutex.RUnlock()
-	return fake.createAppArgsForCall[i].tx, fake.createAppArgsForCall[i].sourceTerminalGUID, fake.createAppArgsForCall[i].appGUID
+	argsForCall := fake.createAppArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *EgressPolicyRepo) CreateAppReturns(result1 int64, result2 error) {
+	fake.createAppMutex.Lock()
+	defer fake.createAppMutex.Unlock()
 	fake.CreateAppStub = nil
 	fake.createAppReturns = struct {
 		result1 int64
@@ -246,6 +354,8 @@ func (fake *EgressPolicyRepo) CreateAppReturns(result1 int64, result2 error) {
 }
 
 func (fake *EgressPolicyRepo) CreateAppReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createAppMutex.Lock()
+	defer fake.createAppMutex.Unlock()
 	fake.CreateAppStub = nil
 	if fake.createAppReturnsOnCall == nil {
 		fake.createAppReturnsOnCall = make(map[int]struct {
@@ -259,82 +369,92 @@ func (fake *EgressPolicyRepo) CreateAppReturnsOnCall(i int, result1 int64, resul
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) CreateIPRange(tx db.Transaction, destinationTerminalGUID string, startIP string, endIP string, protocol string, startPort int64, endPort int64, icmpType int64, icmpCode int64) (int64, error) {
-	fake.createIPRangeMutex.Lock()
-	ret, specificReturn := fake.createIPRangeReturnsOnCall[len(fake.createIPRangeArgsForCall)]
-	fake.createIPRangeArgsForCall = append(fake.createIPRangeArgsForCall, struct {
-		tx                      db.Transaction
-		destinationTerminalGUID string
-		startIP                 string
-		endIP                   string
-		protocol                string
-		startPort               int64
-		endPort                 int64
-		icmpType                int64
-		icmpCode                int64
-	}{tx, destinationTerminalGUID, startIP, endIP, protocol, startPort, endPort, icmpType, icmpCode})
-	fake.recordInvocation("CreateIPRange", []interface{}{tx, destinationTerminalGUID, startIP, endIP, protocol, startPort, endPort, icmpType, icmpCode})
-	fake.createIPRangeMutex.Unlock()
-	if fake.CreateIPRangeStub != nil {
-		return fake.CreateIPRangeStub(tx, destinationTerminalGUID, startIP, endIP, protocol, startPort, endPort, icmpType, icmpCode)
+func (fake *EgressPolicyRepo) CreateDefault(arg1 db.Transaction, arg2 string) (int64, error) {
+	fake.createDefaultMutex.Lock()
+	ret, specificReturn := fake.createDefaultReturnsOnCall[len(fake.createDefaultArgsForCall)]
+	fake.createDefaultArgsForCall = append(fake.createDefaultArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CreateDefaultStub
+	fakeReturns := fake.createDefaultReturns
+	fake.recordInvocation("CreateDefault", []interface{}{arg1, arg2})
+	fake.createDefaultMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createIPRangeReturns.result1, fake.createIPRangeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EgressPolicyRepo) CreateIPRangeCallCount() int {
-	fake.createIPRangeMutex.RLock()
-	defer fake.createIPRangeMutex.RUnlock()
-	return len(fake.createIPRangeArgsForCall)
+func (fake *EgressPolicyRepo) CreateDefaultCallCount() int {
+	fake.createDefaultMutex.RLock()
+	defer fake.createDefaultMutex.RUnlock()
+	return len(fake.createDefaultArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) CreateIPRangeArgsForCall(i int) (db.Transaction, string, string, string, string, int64, int64, int64, int64) {
-	fake.createIPRangeMutex.RLock()
-	defer fake.createIPRangeMutex.RUnlock()
-	return fake.createIPRangeArgsForCall[i].tx, fake.createIPRangeArgsForCall[i].destinationTerminalGUID, fake.createIPRangeArgsForCall[i].startIP, fake.createIPRangeArgsForCall[i].endIP, fake.createIPRangeArgsForCall[i].protocol, fake.createIPRangeArgsForCall[i].startPort, fake.createIPRangeArgsForCall[i].endPort, fake.createIPRangeArgsForCall[i].icmpType, fake.createIPRangeArgsForCall[i].icmpCode
+func (fake *EgressPolicyRepo) CreateDefaultCalls(stub func(db.Transaction, string) (int64, error)) {
+	fake.createDefaultMutex.Lock()
+	defer fake.createDefaultMutex.Unlock()
+	fake.CreateDefaultStub = stub
 }
 
-func (fake *EgressPolicyRepo) CreateIPRangeReturns(result1 int64, result2 error) {
-	fake.CreateIPRangeStub = nil
-	fake.createIPRangeReturns = struct {
+func (fake *EgressPolicyRepo) CreateDefaultArgsForCall(i int) (db.Transaction, string) {
+	fake.createDefaultMutex.RLock()
+	defer fake.createDefaultMutex.RUnlock()
+	argsForCall := fake.createDefaultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) CreateDefaultReturns(result1 int64, result2 error) {
+	fake.createDefaultMutex.Lock()
+	defer fake.createDefaultMutex.Unlock()
+	fake.CreateDefaultStub = nil
+	fake.createDefaultReturns = struct {
 		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) CreateIPRangeReturnsOnCall(i int, result1 int64, result2 error) {
-	fake.CreateIPRangeStub = nil
-	if fake.createIPRangeReturnsOnCall == nil {
-		fake.createIPRangeReturnsOnCall = make(map[int]struct {
+func (fake *EgressPolicyRepo) CreateDefaultReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createDefaultMutex.Lock()
+	defer fake.createDefaultMutex.Unlock()
+	fake.CreateDefaultStub = nil
+	if fake.createDefaultReturnsOnCall == nil {
+		fake.createDefaultReturnsOnCall = make(map[int]struct {
 			result1 int64
 			result2 error
 		})
 	}
-	fake.createIPRangeReturnsOnCall[i] = struct {
+	fake.createDefaultReturnsOnCall[i] = struct {
 		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID string, destinationTerminalGUID string) (string, error) {
+func (fake *EgressPolicyRepo) CreateEgressPolicy(arg1 db.Transaction, arg2 string, arg3 string, arg4 bool, arg5 string) (string, error) {
 	fake.createEgressPolicyMutex.Lock()
 	ret, specificReturn := fake.createEgressPolicyReturnsOnCall[len(fake.createEgressPolicyArgsForCall)]
 	fake.createEgressPolicyArgsForCall = append(fake.createEgressPolicyArgsForCall, struct {
-		tx                      db.Transaction
-		sourceTerminalGUID      string
-		destinationTerminalGUID string
-	}{tx, sourceTerminalGUID, destinationTerminalGUID})
-	fake.recordInvocation("CreateEgressPolicy", []interface{}{tx, sourceTerminalGUID, destinationTerminalGUID})
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 bool
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.CreateEgressPolicyStub
+	fakeReturns := fake.createEgressPolicyReturns
+	fake.recordInvocation("CreateEgressPolicy", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.createEgressPolicyMutex.Unlock()
-	if fake.CreateEgressPolicyStub != nil {
-		return fake.CreateEgressPolicyStub(tx, sourceTerminalGUID, destinationTerminalGUID)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createEgressPolicyReturns.result1, fake.createEgressPolicyReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *EgressPolicyRepo) CreateEgressPolicyCallCount() int {
@@ -343,13 +463,22 @@ func (fake *EgressPolicyRepo) CreateEgressPolicyCallCount() int {
 	return len(fake.createEgressPolicyArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) CreateEgressPolicyArgsForCall(i int) (db.Transaction, string, string) {
+func (fake *EgressPolicyRepo) CreateEgressPolicyCalls(stub func(db.Transaction, string, string, bool, string) (string, error)) {
+	fake.createEgressPolicyMutex.Lock()
+	defer fake.createEgressPolicyMutex.Unlock()
+	fake.CreateEgressPolicyStub = stub
+}
+
+func (fake *EgressPolicyRepo) CreateEgressPolicyArgsForCall(i int) (db.Transaction, string, string, bool, string) {
 	fake.createEgressPolicyMutex.RLock()
 	defer fake.createEgressPolicyMutex.RUnlock()
-	return fake.createEgressPolicyArgsForCall[i].tx, fake.createEgressPolicyArgsForCall[i].sourceTerminalGUID, fake.createEgressPolicyArgsForCall[i].destinationTerminalGUID
+	argsForCall := fake.createEgressPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *EgressPolicyRepo) CreateEgressPolicyReturns(result1 string, result2 error) {
+	fake.createEgressPolicyMutex.Lock()
+	defer fake.createEgressPolicyMutex.Unlock()
 	fake.CreateEgressPolicyStub = nil
 	fake.createEgressPolicyReturns = struct {
 		result1 string
@@ -358,6 +487,8 @@ func (fake *EgressPolicyRepo) CreateEgressPolicyReturns(result1 string, result2
 }
 
 func (fake *EgressPolicyRepo) CreateEgressPolicyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createEgressPolicyMutex.Lock()
+	defer fake.createEgressPolicyMutex.Unlock()
 	fake.CreateEgressPolicyStub = nil
 	if fake.createEgressPolicyReturnsOnCall == nil {
 		fake.createEgressPolicyReturnsOnCall = make(map[int]struct {
@@ -371,330 +502,352 @@ func (fake *EgressPolicyRepo) CreateEgressPolicyReturnsOnCall(i int, result1 str
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) CreateSpace(tx db.Transaction, sourceTerminalGUID string, spaceGUID string) (int64, error) {
-	fake.createSpaceMutex.Lock()
-	ret, specificReturn := fake.createSpaceReturnsOnCall[len(fake.createSpaceArgsForCall)]
-	fake.createSpaceArgsForCall = append(fake.createSpaceArgsForCall, struct {
-		tx                 db.Transaction
-		sourceTerminalGUID string
-		spaceGUID          string
-	}{tx, sourceTerminalGUID, spaceGUID})
-	fake.recordInvocation("CreateSpace", []interface{}{tx, sourceTerminalGUID, spaceGUID})
-	fake.createSpaceMutex.Unlock()
-	if fake.CreateSpaceStub != nil {
-		return fake.CreateSpaceStub(tx, sourceTerminalGUID, spaceGUID)
+func (fake *EgressPolicyRepo) CreateIPRange(arg1 db.Transaction, arg2 string, arg3 string, arg4 string, arg5 string, arg6 int64, arg7 int64, arg8 int64, arg9 int64) (int64, error) {
+	fake.createIPRangeMutex.Lock()
+	ret, specificReturn := fake.createIPRangeReturnsOnCall[len(fake.createIPRangeArgsForCall)]
+	fake.createIPRangeArgsForCall = append(fake.createIPRangeArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int64
+		arg7 int64
+		arg8 int64
+		arg9 int64
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	stub := fake.CreateIPRangeStub
+	fakeReturns := fake.createIPRangeReturns
+	fake.recordInvocation("CreateIPRange", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	fake.createIPRangeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createSpaceReturns.result1, fake.createSpaceReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EgressPolicyRepo) CreateSpaceCallCount() int {
-	fake.createSpaceMutex.RLock()
-	defer fake.createSpaceMutex.RUnlock()
-	return len(fake.createSpaceArgsForCall)
+func (fake *EgressPolicyRepo) CreateIPRangeCallCount() int {
+	fake.createIPRangeMutex.RLock()
+	defer fake.createIPRangeMutex.RUnlock()
+	return len(fake.createIPRangeArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) CreateSpaceArgsForCall(i int) (db.Transaction, string, string) {
-	fake.createSpaceMutex.RLock()
-	defer fake.createSpaceMutex.RUnlock()
-	return fake.createSpaceArgsForCall[i].tx, fake.createSpaceArgsForCall[i].sourceTerminalGUID, fake.createSpaceArgsForCall[i].spaceGUID
+func (fake *EgressPolicyRepo) CreateIPRangeCalls(stub func(db.Transaction, string, string, string, string, int64, int64, int64, int64) (int64, error)) {
+	fake.createIPRangeMutex.Lock()
+	defer fake.createIPRangeMutex.Unlock()
+	fake.CreateIPRangeStub = stub
 }
 
-func (fake *EgressPolicyRepo) CreateSpaceReturns(result1 int64, result2 error) {
-	fake.CreateSpaceStub = nil
-	fake.createSpaceReturns = struct {
+func (fake *EgressPolicyRepo) CreateIPRangeArgsForCall(i int) (db.Transaction, string, string, string, string, int64, int64, int64, int64) {
+	fake.createIPRangeMutex.RLock()
+	defer fake.createIPRangeMutex.RUnlock()
+	argsForCall := fake.createIPRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9
+}
+
+func (fake *EgressPolicyRepo) CreateIPRangeReturns(result1 int64, result2 error) {
+	fake.createIPRangeMutex.Lock()
+	defer fake.createIPRangeMutex.Unlock()
+	fake.CreateIPRangeStub = nil
+	fake.createIPRangeReturns = struct {
 		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) CreateSpaceReturnsOnCall(i int, result1 int64, result2 error) {
-	fake.CreateSpaceStub = nil
-	if fake.createSpaceReturnsOnCall == nil {
-		fake.createSpaceReturnsOnCall = make(map[int]struct {
+func (fake *EgressPolicyRepo) CreateIPRangeReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createIPRangeMutex.Lock()
+	defer fake.createIPRangeMutex.Unlock()
+	fake.CreateIPRangeStub = nil
+	if fake.createIPRangeReturnsOnCall == nil {
+		fake.createIPRangeReturnsOnCall = make(map[int]struct {
 			result1 int64
 			result2 error
 		})
 	}
-	fake.createSpaceReturnsOnCall[i] = struct {
+	fake.createIPRangeReturnsOnCall[i] = struct {
 		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetTerminalByAppGUID(tx db.Transaction, appGUID string) (string, error) {
-	fake.getTerminalByAppGUIDMutex.Lock()
-	ret, specificReturn := fake.getTerminalByAppGUIDReturnsOnCall[len(fake.getTerminalByAppGUIDArgsForCall)]
-	fake.getTerminalByAppGUIDArgsForCall = append(fake.getTerminalByAppGUIDArgsForCall, struct {
-		tx      db.Transaction
-		appGUID string
-	}{tx, appGUID})
-	fake.recordInvocation("GetTerminalByAppGUID", []interface{}{tx, appGUID})
-	fake.getTerminalByAppGUIDMutex.Unlock()
-	if fake.GetTerminalByAppGUIDStub != nil {
-		return fake.GetTerminalByAppGUIDStub(tx, appGUID)
+func (fake *EgressPolicyRepo) CreateOrg(arg1 db.Transaction, arg2 string, arg3 string) (int64, error) {
+	fake.createOrgMutex.Lock()
+	ret, specificReturn := fake.createOrgReturnsOnCall[len(fake.createOrgArgsForCall)]
+	fake.createOrgArgsForCall = append(fake.createOrgArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateOrgStub
+	fakeReturns := fake.createOrgReturns
+	fake.recordInvocation("CreateOrg", []interface{}{arg1, arg2, arg3})
+	fake.createOrgMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getTerminalByAppGUIDReturns.result1, fake.getTerminalByAppGUIDReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EgressPolicyRepo) GetTerminalByAppGUIDCallCount() int {
-	fake.getTerminalByAppGUIDMutex.RLock()
-	defer fake.getTerminalByAppGUIDMutex.RUnlock()
-	return len(fake.getTerminalByAppGUIDArgsForCall)
+func (fake *EgressPolicyRepo) CreateOrgCallCount() int {
+	fake.createOrgMutex.RLock()
+	defer fake.createOrgMutex.RUnlock()
+	return len(fake.createOrgArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) GetTerminalByAppGUIDArgsForCall(i int) (db.Transaction, string) {
-	fake.getTerminalByAppGUIDMutex.RLock()
-	defer fake.getTerminalByAppGUIDMutex.RUnlock()
-	return fake.getTerminalByAppGUIDArgsForCall[i].tx, fake.getTerminalByAppGUIDArgsForCall[i].appGUID
+func (fake *EgressPolicyRepo) CreateOrgCalls(stub func(db.Transaction, string, string) (int64, error)) {
+	fake.createOrgMutex.Lock()
+	defer fake.createOrgMutex.Unlock()
+	fake.CreateOrgStub = stub
 }
 
-func (fake *EgressPolicyRepo) GetTerminalByAppGUIDReturns(result1 string, result2 error) {
-	fake.GetTerminalByAppGUIDStub = nil
-	fake.getTerminalByAppGUIDReturns = struct {
-		result1 string
+func (fake *EgressPolicyRepo) CreateOrgArgsForCall(i int) (db.Transaction, string, string) {
+	fake.createOrgMutex.RLock()
+	defer fake.createOrgMutex.RUnlock()
+	argsForCall := fake.createOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *EgressPolicyRepo) CreateOrgReturns(result1 int64, result2 error) {
+	fake.createOrgMutex.Lock()
+	defer fake.createOrgMutex.Unlock()
+	fake.CreateOrgStub = nil
+	fake.createOrgReturns = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetTerminalByAppGUIDReturnsOnCall(i int, result1 string, result2 error) {
-	fake.GetTerminalByAppGUIDStub = nil
-	if fake.getTerminalByAppGUIDReturnsOnCall == nil {
-		fake.getTerminalByAppGUIDReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *EgressPolicyRepo) CreateOrgReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createOrgMutex.Lock()
+	defer fake.createOrgMutex.Unlock()
+	fake.CreateOrgStub = nil
+	if fake.createOrgReturnsOnCall == nil {
+		fake.createOrgReturnsOnCall = make(map[int]struct {
+			result1 int64
 			result2 error
 		})
 	}
-	fake.getTerminalByAppGUIDReturnsOnCall[i] = struct {
-		result1 string
+	fake.createOrgReturnsOnCall[i] = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetTerminalBySpaceGUID(tx db.Transaction, appGUID string) (string, error) {
-	fake.getTerminalBySpaceGUIDMutex.Lock()
-	ret, specificReturn := fake.getTerminalBySpaceGUIDReturnsOnCall[len(fake.getTerminalBySpaceGUIDArgsForCall)]
-	fake.getTerminalBySpaceGUIDArgsForCall = append(fake.getTerminalBySpaceGUIDArgsForCall, struct {
-		tx      db.Transaction
-		appGUID string
-	}{tx, appGUID})
-	fake.recordInvocation("GetTerminalBySpaceGUID", []interface{}{tx, appGUID})
-	fake.getTerminalBySpaceGUIDMutex.Unlock()
-	if fake.GetTerminalBySpaceGUIDStub != nil {
-		return fake.GetTerminalBySpaceGUIDStub(tx, appGUID)
+func (fake *EgressPolicyRepo) CreateSpace(arg1 db.Transaction, arg2 string, arg3 string) (int64, error) {
+	fake.createSpaceMutex.Lock()
+	ret, specificReturn := fake.createSpaceReturnsOnCall[len(fake.createSpaceArgsForCall)]
+	fake.createSpaceArgsForCall = append(fake.createSpaceArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateSpaceStub
+	fakeReturns := fake.createSpaceReturns
+	fake.recordInvocation("CreateSpace", []interface{}{arg1, arg2, arg3})
+	fake.createSpaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getTerminalBySpaceGUIDReturns.result1, fake.getTerminalBySpaceGUIDReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDCallCount() int {
-	fake.getTerminalBySpaceGUIDMutex.RLock()
-	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
-	return len(fake.getTerminalBySpaceGUIDArgsForCall)
+func (fake *EgressPolicyRepo) CreateSpaceCallCount() int {
+	fake.createSpaceMutex.RLock()
+	defer fake.createSpaceMutex.RUnlock()
+	return len(fake.createSpaceArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDArgsForCall(i int) (db.Transaction, string) {
-	fake.getTerminalBySpaceGUIDMutex.RLock()
-	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
-	return fake.getTerminalBySpaceGUIDArgsForCall[i].tx, fake.getTerminalBySpaceGUIDArgsForCall[i].appGUID
-}
-
-func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDReturns(result1 string, result2 error) {
-	fake.GetTerminalBySpaceGUIDStub = nil
-	fake.getTerminalBySpaceGUIDReturns = struct {
-		result1 string
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDReturnsOnCall(i int, result1 string, result2 error) {
-	fake.GetTerminalBySpaceGUIDStub = nil
-	if fake.getTerminalBySpaceGUIDReturnsOnCall == nil {
-		fake.getTerminalBySpaceGUIDReturnsOnCall = make(map[int]struct {
-			result1 string
-			result2 error
-		})
-	}
-	fake.getTerminalBySpaceGUIDReturnsOnCall[i] = struct {
-		result1 string
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *EgressPolicyRepo) GetAllPolicies() ([]store.EgressPolicy, error) {
-	fake.getAllPoliciesMutex.Lock()
-	ret, specificReturn := fake.getAllPoliciesReturnsOnCall[len(fake.getAllPoliciesArgsForCall)]
-	fake.getAllPoliciesArgsForCall = append(fake.getAllPoliciesArgsForCall, struct{}{})
-	fake.recordInvocation("GetAllPolicies", []interface{}{})
-	fake.getAllPoliciesMutex.Unlock()
-	if fake.GetAllPoliciesStub != nil {
-		return fake.GetAllPoliciesStub()
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.getAllPoliciesReturns.result1, fake.getAllPoliciesReturns.result2
+func (fake *EgressPolicyRepo) CreateSpaceCalls(stub func(db.Transaction, string, string) (int64, error)) {
+	fake.createSpaceMutex.Lock()
+	defer fake.createSpaceMutex.Unlock()
+	fake.CreateSpaceStub = stub
 }
 
-func (fake *EgressPolicyRepo) GetAllPoliciesCallCount() int {
-	fake.getAllPoliciesMutex.RLock()
-	defer fake.getAllPoliciesMutex.RUnlock()
-	return len(fake.getAllPoliciesArgsForCall)
+func (fake *EgressPolicyRepo) CreateSpaceArgsForCall(i int) (db.Transaction, string, string) {
+	fake.createSpaceMutex.RLock()
+	defer fake.createSpaceMutex.RUnlock()
+	argsForCall := fake.createSpaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *EgressPolicyRepo) GetAllPoliciesReturns(result1 []store.EgressPolicy, result2 error) {
-	fake.GetAllPoliciesStub = nil
-	fake.getAllPoliciesReturns = struct {
-		result1 []store.EgressPolicy
+func (fake *EgressPolicyRepo) CreateSpaceReturns(result1 int64, result2 error) {
+	fake.createSpaceMutex.Lock()
+	defer fake.createSpaceMutex.Unlock()
+	fake.CreateSpaceStub = nil
+	fake.createSpaceReturns = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetAllPoliciesReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
-	fake.GetAllPoliciesStub = nil
-	if fake.getAllPoliciesReturnsOnCall == nil {
-		fake.getAllPoliciesReturnsOnCall = make(map[int]struct {
-			result1 []store.EgressPolicy
+func (fake *EgressPolicyRepo) CreateSpaceReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createSpaceMutex.Lock()
+	defer fake.createSpaceMutex.Unlock()
+	fake.CreateSpaceStub = nil
+	if fake.createSpaceReturnsOnCall == nil {
+		fake.createSpaceReturnsOnCall = make(map[int]struct {
+			result1 int64
 			result2 error
 		})
 	}
-	fake.getAllPoliciesReturnsOnCall[i] = struct {
-		result1 []store.EgressPolicy
+	fake.createSpaceReturnsOnCall[i] = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuids(ids []string) ([]store.EgressPolicy, error) {
-	var idsCopy []string
-	if ids != nil {
-		idsCopy = make([]string, len(ids))
-		copy(idsCopy, ids)
-	}
-	fake.getBySourceGuidsMutex.Lock()
-	ret, specificReturn := fake.getBySourceGuidsReturnsOnCall[len(fake.getBySourceGuidsArgsForCall)]
-	fake.getBySourceGuidsArgsForCall = append(fake.getBySourceGuidsArgsForCall, struct {
-		ids []string
-	}{idsCopy})
-	fake.recordInvocation("GetBySourceGuids", []interface{}{idsCopy})
-	fake.getBySourceGuidsMutex.Unlock()
-	if fake.GetBySourceGuidsStub != nil {
-		return fake.GetBySourceGuidsStub(ids)
+func (fake *EgressPolicyRepo) DeleteApp(arg1 db.Transaction, arg2 int64) error {
+	fake.deleteAppMutex.Lock()
+	ret, specificReturn := fake.deleteAppReturnsOnCall[len(fake.deleteAppArgsForCall)]
+	fake.deleteAppArgsForCall = append(fake.deleteAppArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteAppStub
+	fakeReturns := fake.deleteAppReturns
+	fake.recordInvocation("DeleteApp", []interface{}{arg1, arg2})
+	fake.deleteAppMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.getBySourceGuidsReturns.result1, fake.getBySourceGuidsReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuidsCallCount() int {
-	fake.getBySourceGuidsMutex.RLock()
-	defer fake.getBySourceGuidsMutex.RUnlock()
-	return len(fake.getBySourceGuidsArgsForCall)
+func (fake *EgressPolicyRepo) DeleteAppCallCount() int {
+	fake.deleteAppMutex.RLock()
+	defer fake.deleteAppMutex.RUnlock()
+	return len(fake.deleteAppArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuidsArgsForCall(i int) []string {
-	fake.getBySourceGuidsMutex.RLock()
-	defer fake.getBySourceGuidsMutex.RUnlock()
-	return fake.getBySourceGuidsArgsForCall[i].ids
+func (fake *EgressPolicyRepo) DeleteAppCalls(stub func(db.Transaction, int64) error) {
+	fake.deleteAppMutex.Lock()
+	defer fake.deleteAppMutex.Unlock()
+	fake.DeleteAppStub = stub
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuidsReturns(result1 []store.EgressPolicy, result2 error) {
-	fake.GetBySourceGuidsStub = nil
-	fake.getBySourceGuidsReturns = struct {
-		result1 []store.EgressPolicy
-		result2 error
-	}{result1, result2}
+func (fake *EgressPolicyRepo) DeleteAppArgsForCall(i int) (db.Transaction, int64) {
+	fake.deleteAppMutex.RLock()
+	defer fake.deleteAppMutex.RUnlock()
+	argsForCall := fake.deleteAppArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuidsReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
-	fake.GetBySourceGuidsStub = nil
-	if fake.getBySourceGuidsReturnsOnCall == nil {
-		fake.getBySourceGuidsReturnsOnCall = make(map[int]struct {
-			result1 []store.EgressPolicy
-			result2 error
+func (fake *EgressPolicyRepo) DeleteAppReturns(result1 error) {
+	fake.deleteAppMutex.Lock()
+	defer fake.deleteAppMutex.Unlock()
+	fake.DeleteAppStub = nil
+	fake.deleteAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyRepo) DeleteAppReturnsOnCall(i int, result1 error) {
+	fake.deleteAppMutex.Lock()
+	defer fake.deleteAppMutex.Unlock()
+	fake.DeleteAppStub = nil
+	if fake.deleteAppReturnsOnCall == nil {
+		fake.deleteAppReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getBySourceGuidsReturnsOnCall[i] = struct {
-		result1 []store.EgressPolicy
-		result2 error
-	}{result1, result2}
+	fake.deleteAppReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicy(tx db.Transaction, egressPolicy store.EgressPolicy) ([]store.EgressPolicyIDCollection, error) {
-	fake.getIDCollectionsByEgressPolicyMutex.Lock()
-	ret, specificReturn := fake.getIDCollectionsByEgressPolicyReturnsOnCall[len(fake.getIDCollectionsByEgressPolicyArgsForCall)]
-	fake.getIDCollectionsByEgressPolicyArgsForCall = append(fake.getIDCollectionsByEgressPolicyArgsForCall, struct {
-		tx           db.Transaction
-		egressPolicy store.EgressPolicy
-	}{tx, egressPolicy})
-	fake.recordInvocation("GetIDCollectionsByEgressPolicy", []interface{}{tx, egressPolicy})
-	fake.getIDCollectionsByEgressPolicyMutex.Unlock()
-	if fake.GetIDCollectionsByEgressPolicyStub != nil {
-		return fake.GetIDCollectionsByEgressPolicyStub(tx, egressPolicy)
+func (fake *EgressPolicyRepo) DeleteDefault(arg1 db.Transaction, arg2 int64) error {
+	fake.deleteDefaultMutex.Lock()
+	ret, specificReturn := fake.deleteDefaultReturnsOnCall[len(fake.deleteDefaultArgsForCall)]
+	fake.deleteDefaultArgsForCall = append(fake.deleteDefaultArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteDefaultStub
+	fakeReturns := fake.deleteDefaultReturns
+	fake.recordInvocation("DeleteDefault", []interface{}{arg1, arg2})
+	fake.deleteDefaultMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.getIDCollectionsByEgressPolicyReturns.result1, fake.getIDCollectionsByEgressPolicyReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyCallCount() int {
-	fake.getIDCollectionsByEgressPolicyMutex.RLock()
-	defer fake.getIDCollectionsByEgressPolicyMutex.RUnlock()
-	return len(fake.getIDCollectionsByEgressPolicyArgsForCall)
+func (fake *EgressPolicyRepo) DeleteDefaultCallCount() int {
+	fake.deleteDefaultMutex.RLock()
+	defer fake.deleteDefaultMutex.RUnlock()
+	return len(fake.deleteDefaultArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyArgsForCall(i int) (db.Transaction, store.EgressPolicy) {
-	fake.getIDCollectionsByEgressPolicyMutex.RLock()
-	defer fake.getIDCollectionsByEgressPolicyMutex.RUnlock()
-	return fake.getIDCollectionsByEgressPolicyArgsForCall[i].tx, fake.getIDCollectionsByEgressPolicyArgsForCall[i].egressPolicy
+func (fake *EgressPolicyRepo) DeleteDefaultCalls(stub func(db.Transaction, int64) error) {
+	fake.deleteDefaultMutex.Lock()
+	defer fake.deleteDefaultMutex.Unlock()
+	fake.DeleteDefaultStub = stub
 }
 
-func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyReturns(result1 []store.EgressPolicyIDCollection, result2 error) {
-	fake.GetIDCollectionsByEgressPolicyStub = nil
-	fake.getIDCollectionsByEgressPolicyReturns = struct {
-		result1 []store.EgressPolicyIDCollection
-		result2 error
-	}{result1, result2}
+func (fake *EgressPolicyRepo) DeleteDefaultArgsForCall(i int) (db.Transaction, int64) {
+	fake.deleteDefaultMutex.RLock()
+	defer fake.deleteDefaultMutex.RUnlock()
+	argsForCall := fake.deleteDefaultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyReturnsOnCall(i int, result1 []store.EgressPolicyIDCollection, result2 error) {
-	fake.GetIDCollectionsByEgressPolicyStub = nil
-	if fake.getIDCollectionsByEgressPolicyReturnsOnCall == nil {
-		fake.getIDCollectionsByEgressPolicyReturnsOnCall = make(map[int]struct {
-			result1 []store.EgressPolicyIDCollection
-			result2 error
+func (fake *EgressPolicyRepo) DeleteDefaultReturns(result1 error) {
+	fake.deleteDefaultMutex.Lock()
+	defer fake.deleteDefaultMutex.Unlock()
+	fake.DeleteDefaultStub = nil
+	fake.deleteDefaultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyRepo) DeleteDefaultReturnsOnCall(i int, result1 error) {
+	fake.deleteDefaultMutex.Lock()
+	defer fake.deleteDefaultMutex.Unlock()
+	fake.DeleteDefaultStub = nil
+	if fake.deleteDefaultReturnsOnCall == nil {
+		fake.deleteDefaultReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getIDCollectionsByEgressPolicyReturnsOnCall[i] = struct {
-		result1 []store.EgressPolicyIDCollection
-		result2 error
-	}{result1, result2}
+	fake.deleteDefaultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *EgressPolicyRepo) DeleteEgressPolicy(tx db.Transaction, egressPolicyGUID string) error {
+func (fake *EgressPolicyRepo) DeleteEgressPolicy(arg1 db.Transaction, arg2 string) error {
 	fake.deleteEgressPolicyMutex.Lock()
 	ret, specificReturn := fake.deleteEgressPolicyReturnsOnCall[len(fake.deleteEgressPolicyArgsForCall)]
 	fake.deleteEgressPolicyArgsForCall = append(fake.deleteEgressPolicyArgsForCall, struct {
-		tx               db.Transaction
-		egressPolicyGUID string
-	}{tx, egressPolicyGUID})
-	fake.recordInvocation("DeleteEgressPolicy", []interface{}{tx, egressPolicyGUID})
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteEgressPolicyStub
+	fakeReturns := fake.deleteEgressPolicyReturns
+	fake.recordInvocation("DeleteEgressPolicy", []interface{}{arg1, arg2})
 	fake.deleteEgressPolicyMutex.Unlock()
-	if fake.DeleteEgressPolicyStub != nil {
-		return fake.DeleteEgressPolicyStub(tx, egressPolicyGUID)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteEgressPolicyReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *EgressPolicyRepo) DeleteEgressPolicyCallCount() int {
@@ -703,13 +856,22 @@ func (fake *EgressPolicyRepo) DeleteEgressPolicyCallCount() int {
 	return len(fake.deleteEgressPolicyArgsForCall)
 }
 
+func (fake *EgressPolicyRepo) DeleteEgressPolicyCalls(stub func(db.Transaction, string) error) {
+	fake.deleteEgressPolicyMutex.Lock()
+	defer fake.deleteEgressPolicyMutex.Unlock()
+	fake.DeleteEgressPolicyStub = stub
+}
+
 func (fake *EgressPolicyRepo) DeleteEgressPolicyArgsForCall(i int) (db.Transaction, string) {
 	fake.deleteEgressPolicyMutex.RLock()
 	defer fake.deleteEgressPolicyMutex.RUnlock()
-	return fake.deleteEgressPolicyArgsForCall[i].tx, fake.deleteEgressPolicyArgsForCall[i].egressPolicyGUID
+	argsForCall := fake.deleteEgressPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *EgressPolicyRepo) DeleteEgressPolicyReturns(result1 error) {
+	fake.deleteEgressPolicyMutex.Lock()
+	defer fake.deleteEgressPolicyMutex.Unlock()
 	fake.DeleteEgressPolicyStub = nil
 	fake.deleteEgressPolicyReturns = struct {
 		result1 error
@@ -717,6 +879,8 @@ func (fake *EgressPolicyRepo) DeleteEgressPolicyReturns(result1 error) {
 }
 
 func (fake *EgressPolicyRepo) DeleteEgressPolicyReturnsOnCall(i int, result1 error) {
+	fake.deleteEgressPolicyMutex.Lock()
+	defer fake.deleteEgressPolicyMutex.Unlock()
 	fake.DeleteEgressPolicyStub = nil
 	if fake.deleteEgressPolicyReturnsOnCall == nil {
 		fake.deleteEgressPolicyReturnsOnCall = make(map[int]struct {
@@ -728,22 +892,24 @@ func (fake *EgressPolicyRepo) DeleteEgressPolicyReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *EgressPolicyRepo) DeleteIPRange(tx db.Transaction, ipRangeID int64) error {
+func (fake *EgressPolicyRepo) DeleteIPRange(arg1 db.Transaction, arg2 int64) error {
 	fake.deleteIPRangeMutex.Lock()
 	ret, specificReturn := fake.deleteIPRangeReturnsOnCall[len(fake.deleteIPRangeArgsForCall)]
 	fake.deleteIPRangeArgsForCall = append(fake.deleteIPRangeArgsForCall, struct {
-		tx        db.Transaction
-		ipRangeID int64
-	}{tx, ipRangeID})
-	fake.recordInvocation("DeleteIPRange", []interface{}{tx, ipRangeID})
+		arg1 db.Transaction
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteIPRangeStub
+	fakeReturns := fake.deleteIPRangeReturns
+	fake.recordInvocation("DeleteIPRange", []interface{}{arg1, arg2})
 	fake.deleteIPRangeMutex.Unlock()
-	if fake.DeleteIPRangeStub != nil {
-		return fake.DeleteIPRangeStub(tx, ipRangeID)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteIPRangeReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *EgressPolicyRepo) DeleteIPRangeCallCount() int {
@@ -752,13 +918,22 @@ func (fake *EgressPolicyRepo) DeleteIPRangeCallCount() int {
 	return len(fake.deleteIPRangeArgsForCall)
 }
 
+func (fake *EgressPolicyRepo) DeleteIPRangeCalls(stub func(db.Transaction, int64) error) {
+	fake.deleteIPRangeMutex.Lock()
+	defer fake.deleteIPRangeMutex.Unlock()
+	fake.DeleteIPRangeStub = stub
+}
+
 func (fake *EgressPolicyRepo) DeleteIPRangeArgsForCall(i int) (db.Transaction, int64) {
 	fake.deleteIPRangeMutex.RLock()
 	defer fake.deleteIPRangeMutex.RUnlock()
-	return fake.deleteIPRangeArgsForCall[i].tx, fake.deleteIPRangeArgsForCall[i].ipRangeID
+	argsForCall := fake.deleteIPRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *EgressPolicyRepo) DeleteIPRangeReturns(result1 error) {
+	fake.deleteIPRangeMutex.Lock()
+	defer fake.deleteIPRangeMutex.Unlock()
 	fake.DeleteIPRangeStub = nil
 	fake.deleteIPRangeReturns = struct {
 		result1 error
@@ -766,6 +941,8 @@ func (fake *EgressPolicyRepo) DeleteIPRangeReturns(result1 error) {
 }
 
 func (fake *EgressPolicyRepo) DeleteIPRangeReturnsOnCall(i int, result1 error) {
+	fake.deleteIPRangeMutex.Lock()
+	defer fake.deleteIPRangeMutex.Unlock()
 	fake.DeleteIPRangeStub = nil
 	if fake.deleteIPRangeReturnsOnCall == nil {
 		fake.deleteIPRangeReturnsOnCall = make(map[int]struct {
@@ -777,71 +954,86 @@ func (fake *EgressPolicyRepo) DeleteIPRangeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *EgressPolicyRepo) DeleteApp(tx db.Transaction, appID int64) error {
-	fake.deleteAppMutex.Lock()
-	ret, specificReturn := fake.deleteAppReturnsOnCall[len(fake.deleteAppArgsForCall)]
-	fake.deleteAppArgsForCall = append(fake.deleteAppArgsForCall, struct {
-		tx    db.Transaction
-		appID int64
-	}{tx, appID})
-	fake.recordInvocation("DeleteApp", []interface{}{tx, appID})
-	fake.deleteAppMutex.Unlock()
-	if fake.DeleteAppStub != nil {
-		return fake.DeleteAppStub(tx, appID)
+func (fake *EgressPolicyRepo) DeleteOrg(arg1 db.Transaction, arg2 int64) error {
+	fake.deleteOrgMutex.Lock()
+	ret, specificReturn := fake.deleteOrgReturnsOnCall[len(fake.deleteOrgArgsForCall)]
+	fake.deleteOrgArgsForCall = append(fake.deleteOrgArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteOrgStub
+	fakeReturns := fake.deleteOrgReturns
+	fake.recordInvocation("DeleteOrg", []interface{}{arg1, arg2})
+	fake.deleteOrgMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteAppReturns.result1
+	return fakeReturns.result1
 }
 
-func (fake *EgressPolicyRepo) DeleteAppCallCount() int {
-	fake.deleteAppMutex.RLock()
-	defer fake.deleteAppMutex.RUnlock()
-	return len(fake.deleteAppArgsForCall)
+func (fake *EgressPolicyRepo) DeleteOrgCallCount() int {
+	fake.deleteOrgMutex.RLock()
+	defer fake.deleteOrgMutex.RUnlock()
+	return len(fake.deleteOrgArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) DeleteAppArgsForCall(i int) (db.Transaction, int64) {
-	fake.deleteAppMutex.RLock()
-	defer fake.deleteAppMutex.RUnlock()
-	return fake.deleteAppArgsForCall[i].tx, fake.deleteAppArgsForCall[i].appID
+func (fake *EgressPolicyRepo) DeleteOrgCalls(stub func(db.Transaction, int64) error) {
+	fake.deleteOrgMutex.Lock()
+	defer fake.deleteOrgMutex.Unlock()
+	fake.DeleteOrgStub = stub
 }
 
-func (fake *EgressPolicyRepo) DeleteAppReturns(result1 error) {
-	fake.DeleteAppStub = nil
-	fake.deleteAppReturns = struct {
+func (fake *EgressPolicyRepo) DeleteOrgArgsForCall(i int) (db.Transaction, int64) {
+	fake.deleteOrgMutex.RLock()
+	defer fake.deleteOrgMutex.RUnlock()
+	argsForCall := fake.deleteOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) DeleteOrgReturns(result1 error) {
+	fake.deleteOrgMutex.Lock()
+	defer fake.deleteOrgMutex.Unlock()
+	fake.DeleteOrgStub = nil
+	fake.deleteOrgReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *EgressPolicyRepo) DeleteAppReturnsOnCall(i int, result1 error) {
-	fake.DeleteAppStub = nil
-	if fake.deleteAppReturnsOnCall == nil {
-		fake.deleteAppReturnsOnCall = make(map[int]struct {
+func (fake *EgressPolicyRepo) DeleteOrgReturnsOnCall(i int, result1 error) {
+	fake.deleteOrgMutex.Lock()
+	defer fake.deleteOrgMutex.Unlock()
+	fake.DeleteOrgStub = nil
+	if fake.deleteOrgReturnsOnCall == nil {
+		fake.deleteOrgReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteAppReturnsOnCall[i] = struct {
+	fake.deleteOrgReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *EgressPolicyRepo) DeleteSpace(tx db.Transaction, spaceID int64) error {
+func (fake *EgressPolicyRepo) DeleteSpace(arg1 db.Transaction, arg2 int64) error {
 	fake.deleteSpaceMutex.Lock()
 	ret, specificReturn := fake.deleteSpaceReturnsOnCall[len(fake.deleteSpaceArgsForCall)]
 	fake.deleteSpaceArgsForCall = append(fake.deleteSpaceArgsForCall, struct {
-		tx      db.Transaction
-		spaceID int64
-	}{tx, spaceID})
-	fake.recordInvocation("DeleteSpace", []interface{}{tx, spaceID})
+		arg1 db.Transaction
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteSpaceStub
+	fakeReturns := fake.deleteSpaceReturns
+	fake.recordInvocation("DeleteSpace", []interface{}{arg1, arg2})
 	fake.deleteSpaceMutex.Unlock()
-	if fake.DeleteSpaceStub != nil {
-		return fake.DeleteSpaceStub(tx, spaceID)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteSpaceReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *EgressPolicyRepo) DeleteSpaceCallCount() int {
@@ -850,13 +1042,22 @@ func (fake *EgressPolicyRepo) DeleteSpaceCallCount() int {
 	return len(fake.deleteSpaceArgsForCall)
 }
 
+func (fake *EgressPolicyRepo) DeleteSpaceCalls(stub func(db.Transaction, int64) error) {
+	fake.deleteSpaceMutex.Lock()
+	defer fake.deleteSpaceMutex.Unlock()
+	fake.DeleteSpaceStub = stub
+}
+
 func (fake *EgressPolicyRepo) DeleteSpaceArgsForCall(i int) (db.Transaction, int64) {
 	fake.deleteSpaceMutex.RLock()
 	defer fake.deleteSpaceMutex.RUnlock()
-	return fake.deleteSpaceArgsForCall[i].tx, fake.deleteSpaceArgsForCall[i].spaceID
+	argsForCall := fake.deleteSpaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *EgressPolicyRepo) DeleteSpaceReturns(result1 error) {
+	fake.deleteSpaceMutex.Lock()
+	defer fake.deleteSpaceMutex.Unlock()
 	fake.DeleteSpaceStub = nil
 	fake.deleteSpaceReturns = struct {
 		result1 error
@@ -864,6 +1065,8 @@ func (fake *EgressPolicyRepo) DeleteSpaceReturns(result1 error) {
 }
 
 func (fake *EgressPolicyRepo) DeleteSpaceReturnsOnCall(i int, result1 error) {
+	fake.deleteSpaceMutex.Lock()
+	defer fake.deleteSpaceMutex.Unlock()
 	fake.DeleteSpaceStub = nil
 	if fake.deleteSpaceReturnsOnCall == nil {
 		fake.deleteSpaceReturnsOnCall = make(map[int]struct {
@@ -875,87 +1078,628 @@ func (fake *EgressPolicyRepo) DeleteSpaceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *EgressPolicyRepo) IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error) {
-	fake.isTerminalInUseMutex.Lock()
-	ret, specificReturn := fake.isTerminalInUseReturnsOnCall[len(fake.isTerminalInUseArgsForCall)]
-	fake.isTerminalInUseArgsForCall = append(fake.isTerminalInUseArgsForCall, struct {
-		tx           db.Transaction
-		terminalGUID string
-	}{tx, terminalGUID})
-	fake.recordInvocation("IsTerminalInUse", []interface{}{tx, terminalGUID})
-	fake.isTerminalInUseMutex.Unlock()
-	if fake.IsTerminalInUseStub != nil {
-		return fake.IsTerminalInUseStub(tx, terminalGUID)
+func (fake *EgressPolicyRepo) GetAllPolicies() ([]store.EgressPolicy, error) {
+	fake.getAllPoliciesMutex.Lock()
+	ret, specificReturn := fake.getAllPoliciesReturnsOnCall[len(fake.getAllPoliciesArgsForCall)]
+	fake.getAllPoliciesArgsForCall = append(fake.getAllPoliciesArgsForCall, struct {
+	}{})
+	stub := fake.GetAllPoliciesStub
+	fakeReturns := fake.getAllPoliciesReturns
+	fake.recordInvocation("GetAllPolicies", []interface{}{})
+	fake.getAllPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.isTerminalInUseReturns.result1, fake.isTerminalInUseReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *EgressPolicyRepo) IsTerminalInUseCallCount() int {
-	fake.isTerminalInUseMutex.RLock()
-	defer fake.isTerminalInUseMutex.RUnlock()
-	return len(fake.isTerminalInUseArgsForCall)
+func (fake *EgressPolicyRepo) GetAllPoliciesCallCount() int {
+	fake.getAllPoliciesMutex.RLock()
+	defer fake.getAllPoliciesMutex.RUnlock()
+	return len(fake.getAllPoliciesArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) IsTerminalInUseArgsForCall(i int) (db.Transaction, string) {
-	fake.isTerminalInUseMutex.RLock()
-	defer fake.isTerminalInUseMutex.RUnlock()
-	return fake.isTerminalInUseArgsForCall[i].tx, fake.isTerminalInUseArgsForCall[i].terminalGUID
+func (fake *EgressPolicyRepo) GetAllPoliciesCalls(stub func() ([]store.EgressPolicy, error)) {
+	fake.getAllPoliciesMutex.Lock()
+	defer fake.getAllPoliciesMutex.Unlock()
+	fake.GetAllPoliciesStub = stub
 }
 
-func (fake *EgressPolicyRepo) IsTerminalInUseReturns(result1 bool, result2 error) {
-	fake.IsTerminalInUseStub = nil
-	fake.isTerminalInUseReturns = struct {
-		result1 bool
+func (fake *EgressPolicyRepo) GetAllPoliciesReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.getAllPoliciesMutex.Lock()
+	defer fake.getAllPoliciesMutex.Unlock()
+	fake.GetAllPoliciesStub = nil
+	fake.getAllPoliciesReturns = struct {
+		result1 []store.EgressPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) IsTerminalInUseReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.IsTerminalInUseStub = nil
-	if fake.isTerminalInUseReturnsOnCall == nil {
-		fake.isTerminalInUseReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *EgressPolicyRepo) GetAllPoliciesReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.getAllPoliciesMutex.Lock()
+	defer fake.getAllPoliciesMutex.Unlock()
+	fake.GetAllPoliciesStub = nil
+	if fake.getAllPoliciesReturnsOnCall == nil {
+		fake.getAllPoliciesReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
 			result2 error
 		})
 	}
-	fake.isTerminalInUseReturnsOnCall[i] = struct {
-		result1 bool
+	fake.getAllPoliciesReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) Invocations() map[string][][]interface{} {
-	fake.invocationsMutex.RLock()
-	defer fake.invocationsMutex.RUnlock()
-	fake.createAppMutex.RLock()
-	defer fake.createAppMutex.RUnlock()
-	fake.createIPRangeMutex.RLock()
-	defer fake.createIPRangeMutex.RUnlock()
-	fake.createEgressPolicyMutex.RLock()
-	defer fake.createEgressPolicyMutex.RUnlock()
-	fake.createSpaceMutex.RLock()
-	defer fake.createSpaceMutex.RUnlock()
-	fake.getTerminalByAppGUIDMutex.RLock()
-	defer fake.getTerminalByAppGUIDMutex.RUnlock()
-	fake.getTerminalBySpaceGUIDMutex.RLock()
-	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
-	fake.getAllPoliciesMutex.RLock()
-	defer fake.getAllPoliciesMutex.RUnlock()
+func (fake *EgressPolicyRepo) GetBySourceGuids(arg1 []string) ([]store.EgressPolicy, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.getBySourceGuidsMutex.Lock()
+	ret, specificReturn := fake.getBySourceGuidsReturnsOnCall[len(fake.getBySourceGuidsArgsForCall)]
+	fake.getBySourceGuidsArgsForCall = append(fake.getBySourceGuidsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.GetBySourceGuidsStub
+	fakeReturns := fake.getBySourceGuidsReturns
+	fake.recordInvocation("GetBySourceGuids", []interface{}{arg1Copy})
+	fake.getBySourceGuidsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsCallCount() int {
 	fake.getBySourceGuidsMutex.RLock()
 	defer fake.getBySourceGuidsMutex.RUnlock()
+	return len(fake.getBySourceGuidsArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsCalls(stub func([]string) ([]store.EgressPolicy, error)) {
+	fake.getBySourceGuidsMutex.Lock()
+	defer fake.getBySourceGuidsMutex.Unlock()
+	fake.GetBySourceGuidsStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsArgsForCall(i int) []string {
+	fake.getBySourceGuidsMutex.RLock()
+	defer fake.getBySourceGuidsMutex.RUnlock()
+	argsForCall := fake.getBySourceGuidsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.getBySourceGuidsMutex.Lock()
+	defer fake.getBySourceGuidsMutex.Unlock()
+	fake.GetBySourceGuidsStub = nil
+	fake.getBySourceGuidsReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.getBySourceGuidsMutex.Lock()
+	defer fake.getBySourceGuidsMutex.Unlock()
+	fake.GetBySourceGuidsStub = nil
+	if fake.getBySourceGuidsReturnsOnCall == nil {
+		fake.getBySourceGuidsReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getBySourceGuidsReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminal(arg1 db.Transaction) (string, error) {
+	fake.getDefaultTerminalMutex.Lock()
+	ret, specificReturn := fake.getDefaultTerminalReturnsOnCall[len(fake.getDefaultTerminalArgsForCall)]
+	fake.getDefaultTerminalArgsForCall = append(fake.getDefaultTerminalArgsForCall, struct {
+		arg1 db.Transaction
+	}{arg1})
+	stub := fake.GetDefaultTerminalStub
+	fakeReturns := fake.getDefaultTerminalReturns
+	fake.recordInvocation("GetDefaultTerminal", []interface{}{arg1})
+	fake.getDefaultTerminalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalCallCount() int {
+	fake.getDefaultTerminalMutex.RLock()
+	defer fake.getDefaultTerminalMutex.RUnlock()
+	return len(fake.getDefaultTerminalArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalCalls(stub func(db.Transaction) (string, error)) {
+	fake.getDefaultTerminalMutex.Lock()
+	defer fake.getDefaultTerminalMutex.Unlock()
+	fake.GetDefaultTerminalStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalArgsForCall(i int) db.Transaction {
+	fake.getDefaultTerminalMutex.RLock()
+	defer fake.getDefaultTerminalMutex.RUnlock()
+	argsForCall := fake.getDefaultTerminalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalReturns(result1 string, result2 error) {
+	fake.getDefaultTerminalMutex.Lock()
+	defer fake.getDefaultTerminalMutex.Unlock()
+	fake.GetDefaultTerminalStub = nil
+	fake.getDefaultTerminalReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDefaultTerminalMutex.Lock()
+	defer fake.getDefaultTerminalMutex.Unlock()
+	fake.GetDefaultTerminalStub = nil
+	if fake.getDefaultTerminalReturnsOnCall == nil {
+		fake.getDefaultTerminalReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDefaultTerminalReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionByGUID(arg1 db.Transaction, arg2 string) (store.EgressPolicyIDCollection, error) {
+	fake.getIDCollectionByGUIDMutex.Lock()
+	ret, specificReturn := fake.getIDCollectionByGUIDReturnsOnCall[len(fake.getIDCollectionByGUIDArgsForCall)]
+	fake.getIDCollectionByGUIDArgsForCall = append(fake.getIDCollectionByGUIDArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetIDCollectionByGUIDStub
+	fakeReturns := fake.getIDCollectionByGUIDReturns
+	fake.recordInvocation("GetIDCollectionByGUID", []interface{}{arg1, arg2})
+	fake.getIDCollectionByGUIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionByGUIDCallCount() int {
+	fake.getIDCollectionByGUIDMutex.RLock()
+	defer fake.getIDCollectionByGUIDMutex.RUnlock()
+	return len(fake.getIDCollectionByGUIDArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionByGUIDCalls(stub func(db.Transaction, string) (store.EgressPolicyIDCollection, error)) {
+	fake.getIDCollectionByGUIDMutex.Lock()
+	defer fake.getIDCollectionByGUIDMutex.Unlock()
+	fake.GetIDCollectionByGUIDStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionByGUIDArgsForCall(i int) (db.Transaction, string) {
+	fake.getIDCollectionByGUIDMutex.RLock()
+	defer fake.getIDCollectionByGUIDMutex.RUnlock()
+	argsForCall := fake.getIDCollectionByGUIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionByGUIDReturns(result1 store.EgressPolicyIDCollection, result2 error) {
+	fake.getIDCollectionByGUIDMutex.Lock()
+	defer fake.getIDCollectionByGUIDMutex.Unlock()
+	fake.GetIDCollectionByGUIDStub = nil
+	fake.getIDCollectionByGUIDReturns = struct {
+		result1 store.EgressPolicyIDCollection
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionByGUIDReturnsOnCall(i int, result1 store.EgressPolicyIDCollection, result2 error) {
+	fake.getIDCollectionByGUIDMutex.Lock()
+	defer fake.getIDCollectionByGUIDMutex.Unlock()
+	fake.GetIDCollectionByGUIDStub = nil
+	if fake.getIDCollectionByGUIDReturnsOnCall == nil {
+		fake.getIDCollectionByGUIDReturnsOnCall = make(map[int]struct {
+			result1 store.EgressPolicyIDCollection
+			result2 error
+		})
+	}
+	fake.getIDCollectionByGUIDReturnsOnCall[i] = struct {
+		result1 store.EgressPolicyIDCollection
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicy(arg1 db.Transaction, arg2 store.EgressPolicy) ([]store.EgressPolicyIDCollection, error) {
+	fake.getIDCollectionsByEgressPolicyMutex.Lock()
+	ret, specificReturn := fake.getIDCollectionsByEgressPolicyReturnsOnCall[len(fake.getIDCollectionsByEgressPolicyArgsForCall)]
+	fake.getIDCollectionsByEgressPolicyArgsForCall = append(fake.getIDCollectionsByEgressPolicyArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 store.EgressPolicy
+	}{arg1, arg2})
+	stub := fake.GetIDCollectionsByEgressPolicyStub
+	fakeReturns := fake.getIDCollectionsByEgressPolicyReturns
+	fake.recordInvocation("GetIDCollectionsByEgressPolicy", []interface{}{arg1, arg2})
+	fake.getIDCollectionsByEgressPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyCallCount() int {
 	fake.getIDCollectionsByEgressPolicyMutex.RLock()
 	defer fake.getIDCollectionsByEgressPolicyMutex.RUnlock()
+	return len(fake.getIDCollectionsByEgressPolicyArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyCalls(stub func(db.Transaction, store.EgressPolicy) ([]store.EgressPolicyIDCollection, error)) {
+	fake.getIDCollectionsByEgressPolicyMutex.Lock()
+	defer fake.getIDCollectionsByEgressPolicyMutex.Unlock()
+	fake.GetIDCollectionsByEgressPolicyStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyArgsForCall(i int) (db.Transaction, store.EgressPolicy) {
+	fake.getIDCollectionsByEgressPolicyMutex.RLock()
+	defer fake.getIDCollectionsByEgressPolicyMutex.RUnlock()
+	argsForCall := fake.getIDCollectionsByEgressPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyReturns(result1 []store.EgressPolicyIDCollection, result2 error) {
+	fake.getIDCollectionsByEgressPolicyMutex.Lock()
+	defer fake.getIDCollectionsByEgressPolicyMutex.Unlock()
+	fake.GetIDCollectionsByEgressPolicyStub = nil
+	fake.getIDCollectionsByEgressPolicyReturns = struct {
+		result1 []store.EgressPolicyIDCollection
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetIDCollectionsByEgressPolicyReturnsOnCall(i int, result1 []store.EgressPolicyIDCollection, result2 error) {
+	fake.getIDCollectionsByEgressPolicyMutex.Lock()
+	defer fake.getIDCollectionsByEgressPolicyMutex.Unlock()
+	fake.GetIDCollectionsByEgressPolicyStub = nil
+	if fake.getIDCollectionsByEgressPolicyReturnsOnCall == nil {
+		fake.getIDCollectionsByEgressPolicyReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicyIDCollection
+			result2 error
+		})
+	}
+	fake.getIDCollectionsByEgressPolicyReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicyIDCollection
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByAppGUID(arg1 db.Transaction, arg2 string) (string, error) {
+	fake.getTerminalByAppGUIDMutex.Lock()
+	ret, specificReturn := fake.getTerminalByAppGUIDReturnsOnCall[len(fake.getTerminalByAppGUIDArgsForCall)]
+	fake.getTerminalByAppGUIDArgsForCall = append(fake.getTerminalByAppGUIDArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetTerminalByAppGUIDStub
+	fakeReturns := fake.getTerminalByAppGUIDReturns
+	fake.recordInvocation("GetTerminalByAppGUID", []interface{}{arg1, arg2})
+	fake.getTerminalByAppGUIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByAppGUIDCallCount() int {
+	fake.getTerminalByAppGUIDMutex.RLock()
+	defer fake.getTerminalByAppGUIDMutex.RUnlock()
+	return len(fake.getTerminalByAppGUIDArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByAppGUIDCalls(stub func(db.Transaction, string) (string, error)) {
+	fake.getTerminalByAppGUIDMutex.Lock()
+	defer fake.getTerminalByAppGUIDMutex.Unlock()
+	fake.GetTerminalByAppGUIDStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByAppGUIDArgsForCall(i int) (db.Transaction, string) {
+	fake.getTerminalByAppGUIDMutex.RLock()
+	defer fake.getTerminalByAppGUIDMutex.RUnlock()
+	argsForCall := fake.getTerminalByAppGUIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByAppGUIDReturns(result1 string, result2 error) {
+	fake.getTerminalByAppGUIDMutex.Lock()
+	defer fake.getTerminalByAppGUIDMutex.Unlock()
+	fake.GetTerminalByAppGUIDStub = nil
+	fake.getTerminalByAppGUIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByAppGUIDReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getTerminalByAppGUIDMutex.Lock()
+	defer fake.getTerminalByAppGUIDMutex.Unlock()
+	fake.GetTerminalByAppGUIDStub = nil
+	if fake.getTerminalByAppGUIDReturnsOnCall == nil {
+		fake.getTerminalByAppGUIDReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getTerminalByAppGUIDReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUID(arg1 db.Transaction, arg2 string) (string, error) {
+	fake.getTerminalByOrgGUIDMutex.Lock()
+	ret, specificReturn := fake.getTerminalByOrgGUIDReturnsOnCall[len(fake.getTerminalByOrgGUIDArgsForCall)]
+	fake.getTerminalByOrgGUIDArgsForCall = append(fake.getTerminalByOrgGUIDArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetTerminalByOrgGUIDStub
+	fakeReturns := fake.getTerminalByOrgGUIDReturns
+	fake.recordInvocation("GetTerminalByOrgGUID", []interface{}{arg1, arg2})
+	fake.getTerminalByOrgGUIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDCallCount() int {
+	fake.getTerminalByOrgGUIDMutex.RLock()
+	defer fake.getTerminalByOrgGUIDMutex.RUnlock()
+	return len(fake.getTerminalByOrgGUIDArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDCalls(stub func(db.Transaction, string) (string, error)) {
+	fake.getTerminalByOrgGUIDMutex.Lock()
+	defer fake.getTerminalByOrgGUIDMutex.Unlock()
+	fake.GetTerminalByOrgGUIDStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDArgsForCall(i int) (db.Transaction, string) {
+	fake.getTerminalByOrgGUIDMutex.RLock()
+	defer fake.getTerminalByOrgGUIDMutex.RUnlock()
+	argsForCall := fake.getTerminalByOrgGUIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDReturns(result1 string, result2 error) {
+	fake.getTerminalByOrgGUIDMutex.Lock()
+	defer fake.getTerminalByOrgGUIDMutex.Unlock()
+	fake.GetTerminalByOrgGUIDStub = nil
+	fake.getTerminalByOrgGUIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getTerminalByOrgGUIDMutex.Lock()
+	defer fake.getTerminalByOrgGUIDMutex.Unlock()
+	fake.GetTerminalByOrgGUIDStub = nil
+	if fake.getTerminalByOrgGUIDReturnsOnCall == nil {
+		fake.getTerminalByOrgGUIDReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getTerminalByOrgGUIDReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalBySpaceGUID(arg1 db.Transaction, arg2 string) (string, error) {
+	fake.getTerminalBySpaceGUIDMutex.Lock()
+	ret, specificReturn := fake.getTerminalBySpaceGUIDReturnsOnCall[len(fake.getTerminalBySpaceGUIDArgsForCall)]
+	fake.getTerminalBySpaceGUIDArgsForCall = append(fake.getTerminalBySpaceGUIDArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetTerminalBySpaceGUIDStub
+	fakeReturns := fake.getTerminalBySpaceGUIDReturns
+	fake.recordInvocation("GetTerminalBySpaceGUID", []interface{}{arg1, arg2})
+	fake.getTerminalBySpaceGUIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDCallCount() int {
+	fake.getTerminalBySpaceGUIDMutex.RLock()
+	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
+	return len(fake.getTerminalBySpaceGUIDArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDCalls(stub func(db.Transaction, string) (string, error)) {
+	fake.getTerminalBySpaceGUIDMutex.Lock()
+	defer fake.getTerminalBySpaceGUIDMutex.Unlock()
+	fake.GetTerminalBySpaceGUIDStub = stub
+}
+
+func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDArgsForCall(i int) (db.Transaction, string) {
+	fake.getTerminalBySpaceGUIDMutex.RLock()
+	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
+	argsForCall := fake.getTerminalBySpaceGUIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDReturns(result1 string, result2 error) {
+	fake.getTerminalBySpaceGUIDMutex.Lock()
+	defer fake.getTerminalBySpaceGUIDMutex.Unlock()
+	fake.GetTerminalBySpaceGUIDStub = nil
+	fake.getTerminalBySpaceGUIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getTerminalBySpaceGUIDMutex.Lock()
+	defer fake.getTerminalBySpaceGUIDMutex.Unlock()
+	fake.GetTerminalBySpaceGUIDStub = nil
+	if fake.getTerminalBySpaceGUIDReturnsOnCall == nil {
+		fake.getTerminalBySpaceGUIDReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getTerminalBySpaceGUIDReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) IsTerminalInUse(arg1 db.Transaction, arg2 string) (bool, error) {
+	fake.isTerminalInUseMutex.Lock()
+	ret, specificReturn := fake.isTerminalInUseReturnsOnCall[len(fake.isTerminalInUseArgsForCall)]
+	fake.isTerminalInUseArgsForCall = append(fake.isTerminalInUseArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.IsTerminalInUseStub
+	fakeReturns := fake.isTerminalInUseReturns
+	fake.recordInvocation("IsTerminalInUse", []interface{}{arg1, arg2})
+	fake.isTerminalInUseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EgressPolicyRepo) IsTerminalInUseCallCount() int {
+	fake.isTerminalInUseMutex.RLock()
+	defer fake.isTerminalInUseMutex.RUnlock()
+	return len(fake.isTerminalInUseArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) IsTerminalInUseCalls(stub func(db.Transaction, string) (bool, error)) {
+	fake.isTerminalInUseMutex.Lock()
+	defer fake.isTerminalInUseMutex.Unlock()
+	fake.IsTerminalInUseStub = stub
+}
+
+func (fake *EgressPolicyRepo) IsTerminalInUseArgsForCall(i int) (db.Transaction, string) {
+	fake.isTerminalInUseMutex.RLock()
+	defer fake.isTerminalInUseMutex.RUnlock()
+	argsForCall := fake.isTerminalInUseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EgressPolicyRepo) IsTerminalInUseReturns(result1 bool, result2 error) {
+	fake.isTerminalInUseMutex.Lock()
+	defer fake.isTerminalInUseMutex.Unlock()
+	fake.IsTerminalInUseStub = nil
+	fake.isTerminalInUseReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) IsTerminalInUseReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isTerminalInUseMutex.Lock()
+	defer fake.isTerminalInUseMutex.Unlock()
+	fake.IsTerminalInUseStub = nil
+	if fake.isTerminalInUseReturnsOnCall == nil {
+		fake.isTerminalInUseReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isTerminalInUseReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAppMutex.RLock()
+	defer fake.createAppMutex.RUnlock()
+	fake.createDefaultMutex.RLock()
+	defer fake.createDefaultMutex.RUnlock()
+	fake.createEgressPolicyMutex.RLock()
+	defer fake.createEgressPolicyMutex.RUnlock()
+	fake.createIPRangeMutex.RLock()
+	defer fake.createIPRangeMutex.RUnlock()
+	fake.createOrgMutex.RLock()
+	defer fake.createOrgMutex.RUnlock()
+	fake.createSpaceMutex.RLock()
+	defer fake.createSpaceMutex.RUnlock()
+	fake.deleteAppMutex.RLock()
+	defer fake.deleteAppMutex.RUnlock()
+	fake.deleteDefaultMutex.RLock()
+	defer fake.deleteDefaultMutex.RUnlock()
 	fake.deleteEgressPolicyMutex.RLock()
 	defer fake.deleteEgressPolicyMutex.RUnlock()
 	fake.deleteIPRangeMutex.RLock()
 	defer fake.deleteIPRangeMutex.RUnlock()
-	fake.deleteAppMutex.RLock()
-	defer fake.deleteAppMutex.RUnlock()
+	fake.deleteOrgMutex.RLock()
+	defer fake.deleteOrgMutex.RUnlock()
 	fake.deleteSpaceMutex.RLock()
 	defer fake.deleteSpaceMutex.RUnlock()
+	fake.getAllPoliciesMutex.RLock()
+	defer fake.getAllPoliciesMutex.RUnlock()
+	fake.getBySourceGuidsMutex.RLock()
+	defer fake.getBySourceGuidsMutex.RUnlock()
+	fake.getDefaultTerminalMutex.RLock()
+	defer fake.getDefaultTerminalMutex.RUnlock()
+	fake.getIDCollectionByGUIDMutex.RLock()
+	defer fake.getIDCollectionByGUIDMutex.RUnlock()
+	fake.getIDCollectionsByEgressPolicyMutex.RLock()
+	defer fake.getIDCollectionsByEgressPolicyMutex.RUnlock()
+	fake.getTerminalByAppGUIDMutex.RLock()
+	defer fake.getTerminalByAppGUIDMutex.RUnlock()
+	fake.getTerminalByOrgGUIDMutex.RLock()
+	defer fake.getTerminalByOrgGUIDMutex.RUnlock()
+	fake.getTerminalBySpaceGUIDMutex.RLock()
+	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
 	fake.isTerminalInUseMutex.RLock()
 	defer fake.isTerminalInUseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -23,6 +23,21 @@ type GroupRepo struct {
 		result1 int
 		result2 error
 	}
+	CreateManyStub        func(db.Transaction, []string, string) (map[string]int, error)
+	createManyMutex       sync.RWMutex
+	createManyArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 []string
+		arg3 string
+	}
+	createManyReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	createManyReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	DeleteStub        func(db.Transaction, int) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -61,15 +76,17 @@ func (fake *GroupRepo) Create(arg1 db.Transaction, arg2 string, arg3 string) (in
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
 	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3})
 	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createReturns.result1, fake.createReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *GroupRepo) CreateCallCount() int {
@@ -78,13 +95,22 @@ func (fake *GroupRepo) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
+func (fake *GroupRepo) CreateCalls(stub func(db.Transaction, string, string) (int, error)) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
 func (fake *GroupRepo) CreateArgsForCall(i int) (db.Transaction, string, string) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2, fake.createArgsForCall[i].arg3
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *GroupRepo) CreateReturns(result1 int, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	fake.createReturns = struct {
 		result1 int
@@ -93,6 +119,8 @@ func (fake *GroupRepo) CreateReturns(result1 int, result2 error) {
 }
 
 func (fake *GroupRepo) CreateReturnsOnCall(i int, result1 int, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	if fake.createReturnsOnCall == nil {
 		fake.createReturnsOnCall = make(map[int]struct {
@@ -106,6 +134,77 @@ func (fake *GroupRepo) CreateReturnsOnCall(i int, result1 int, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *GroupRepo) CreateMany(arg1 db.Transaction, arg2 []string, arg3 string) (map[string]int, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createManyMutex.Lock()
+	ret, specificReturn := fake.createManyReturnsOnCall[len(fake.createManyArgsForCall)]
+	fake.createManyArgsForCall = append(fake.createManyArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 []string
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	stub := fake.CreateManyStub
+	fakeReturns := fake.createManyReturns
+	fake.recordInvocation("CreateMany", []interface{}{arg1, arg2Copy, arg3})
+	fake.createManyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *GroupRepo) CreateManyCallCount() int {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return len(fake.createManyArgsForCall)
+}
+
+func (fake *GroupRepo) CreateManyCalls(stub func(db.Transaction, []string, string) (map[string]int, error)) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = stub
+}
+
+func (fake *GroupRepo) CreateManyArgsForCall(i int) (db.Transaction, []string, string) {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	argsForCall := fake.createManyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *GroupRepo) CreateManyReturns(result1 map[string]int, result2 error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = nil
+	fake.createManyReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GroupRepo) CreateManyReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = nil
+	if fake.createManyReturnsOnCall == nil {
+		fake.createManyReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.createManyReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *GroupRepo) Delete(arg1 db.Transaction, arg2 int) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -113,15 +212,17 @@ func (fake *GroupRepo) Delete(arg1 db.Transaction, arg2 int) error {
 		arg1 db.Transaction
 		arg2 int
 	}{arg1, arg2})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
 	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
 	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *GroupRepo) DeleteCallCount() int {
@@ -130,13 +231,22 @@ func (fake *GroupRepo) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
+func (fake *GroupRepo) DeleteCalls(stub func(db.Transaction, int) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
 func (fake *GroupRepo) DeleteArgsForCall(i int) (db.Transaction, int) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *GroupRepo) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	fake.deleteReturns = struct {
 		result1 error
@@ -144,6 +254,8 @@ func (fake *GroupRepo) DeleteReturns(result1 error) {
 }
 
 func (fake *GroupRepo) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	if fake.deleteReturnsOnCall == nil {
 		fake.deleteReturnsOnCall = make(map[int]struct {
@@ -162,15 +274,17 @@ func (fake *GroupRepo) GetID(arg1 db.Transaction, arg2 string) (int, error) {
 		arg1 db.Transaction
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.GetIDStub
+	fakeReturns := fake.getIDReturns
 	fake.recordInvocation("GetID", []interface{}{arg1, arg2})
 	fake.getIDMutex.Unlock()
-	if fake.GetIDStub != nil {
-		return fake.GetIDStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getIDReturns.result1, fake.getIDReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *GroupRepo) GetIDCallCount() int {
@@ -179,13 +293,22 @@ func (fake *GroupRepo) GetIDCallCount() int {
 	return len(fake.getIDArgsForCall)
 }
 
+func (fake *GroupRepo) GetIDCalls(stub func(db.Transaction, string) (int, error)) {
+	fake.getIDMutex.Lock()
+	defer fake.getIDMutex.Unlock()
+	fake.GetIDStub = stub
+}
+
 func (fake *GroupRepo) GetIDArgsForCall(i int) (db.Transaction, string) {
 	fake.getIDMutex.RLock()
 	defer fake.getIDMutex.RUnlock()
-	return fake.getIDArgsForCall[i].arg1, fake.getIDArgsForCall[i].arg2
+	argsForCall := fake.getIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *GroupRepo) GetIDReturns(result1 int, result2 error) {
+	fake.getIDMutex.Lock()
+	defer fake.getIDMutex.Unlock()
 	fake.GetIDStub = nil
 	fake.getIDReturns = struct {
 		result1 int
@@ -194,6 +317,8 @@ func (fake *GroupRepo) GetIDReturns(result1 int, result2 error) {
 }
 
 func (fake *GroupRepo) GetIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.getIDMutex.Lock()
+	defer fake.getIDMutex.Unlock()
 	fake.GetIDStub = nil
 	if fake.getIDReturnsOnCall == nil {
 		fake.getIDReturnsOnCall = make(map[int]struct {
@@ -212,6 +337,8 @@ func (fake *GroupRepo) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.getIDMutex.RLock()
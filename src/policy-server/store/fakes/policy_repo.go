@@ -8,6 +8,34 @@ import (
 )
 
 type PolicyRepo struct {
+	CountWhereDestinationIDStub        func(db.Transaction, int) (int, error)
+	countWhereDestinationIDMutex       sync.RWMutex
+	countWhereDestinationIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int
+	}
+	countWhereDestinationIDReturns struct {
+		result1 int
+		result2 error
+	}
+	countWhereDestinationIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	CountWhereGroupIDStub        func(db.Transaction, int) (int, error)
+	countWhereGroupIDMutex       sync.RWMutex
+	countWhereGroupIDArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 int
+	}
+	countWhereGroupIDReturns struct {
+		result1 int
+		result2 error
+	}
+	countWhereGroupIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	CreateStub        func(db.Transaction, int, int) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
@@ -21,6 +49,18 @@ type PolicyRepo struct {
 	createReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreateManyStub        func(db.Transaction, []store.PolicyRow) error
+	createManyMutex       sync.RWMutex
+	createManyArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 []store.PolicyRow
+	}
+	createManyReturns struct {
+		result1 error
+	}
+	createManyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteStub        func(db.Transaction, int, int) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -34,36 +74,138 @@ type PolicyRepo struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CountWhereGroupIDStub        func(db.Transaction, int) (int, error)
-	countWhereGroupIDMutex       sync.RWMutex
-	countWhereGroupIDArgsForCall []struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PolicyRepo) CountWhereDestinationID(arg1 db.Transaction, arg2 int) (int, error) {
+	fake.countWhereDestinationIDMutex.Lock()
+	ret, specificReturn := fake.countWhereDestinationIDReturnsOnCall[len(fake.countWhereDestinationIDArgsForCall)]
+	fake.countWhereDestinationIDArgsForCall = append(fake.countWhereDestinationIDArgsForCall, struct {
 		arg1 db.Transaction
 		arg2 int
+	}{arg1, arg2})
+	stub := fake.CountWhereDestinationIDStub
+	fakeReturns := fake.countWhereDestinationIDReturns
+	fake.recordInvocation("CountWhereDestinationID", []interface{}{arg1, arg2})
+	fake.countWhereDestinationIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
-	countWhereGroupIDReturns struct {
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyRepo) CountWhereDestinationIDCallCount() int {
+	fake.countWhereDestinationIDMutex.RLock()
+	defer fake.countWhereDestinationIDMutex.RUnlock()
+	return len(fake.countWhereDestinationIDArgsForCall)
+}
+
+func (fake *PolicyRepo) CountWhereDestinationIDCalls(stub func(db.Transaction, int) (int, error)) {
+	fake.countWhereDestinationIDMutex.Lock()
+	defer fake.countWhereDestinationIDMutex.Unlock()
+	fake.CountWhereDestinationIDStub = stub
+}
+
+func (fake *PolicyRepo) CountWhereDestinationIDArgsForCall(i int) (db.Transaction, int) {
+	fake.countWhereDestinationIDMutex.RLock()
+	defer fake.countWhereDestinationIDMutex.RUnlock()
+	argsForCall := fake.countWhereDestinationIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PolicyRepo) CountWhereDestinationIDReturns(result1 int, result2 error) {
+	fake.countWhereDestinationIDMutex.Lock()
+	defer fake.countWhereDestinationIDMutex.Unlock()
+	fake.CountWhereDestinationIDStub = nil
+	fake.countWhereDestinationIDReturns = struct {
 		result1 int
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyRepo) CountWhereDestinationIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.countWhereDestinationIDMutex.Lock()
+	defer fake.countWhereDestinationIDMutex.Unlock()
+	fake.CountWhereDestinationIDStub = nil
+	if fake.countWhereDestinationIDReturnsOnCall == nil {
+		fake.countWhereDestinationIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
 	}
-	countWhereGroupIDReturnsOnCall map[int]struct {
+	fake.countWhereDestinationIDReturnsOnCall[i] = struct {
 		result1 int
 		result2 error
-	}
-	CountWhereDestinationIDStub        func(db.Transaction, int) (int, error)
-	countWhereDestinationIDMutex       sync.RWMutex
-	countWhereDestinationIDArgsForCall []struct {
+	}{result1, result2}
+}
+
+func (fake *PolicyRepo) CountWhereGroupID(arg1 db.Transaction, arg2 int) (int, error) {
+	fake.countWhereGroupIDMutex.Lock()
+	ret, specificReturn := fake.countWhereGroupIDReturnsOnCall[len(fake.countWhereGroupIDArgsForCall)]
+	fake.countWhereGroupIDArgsForCall = append(fake.countWhereGroupIDArgsForCall, struct {
 		arg1 db.Transaction
 		arg2 int
+	}{arg1, arg2})
+	stub := fake.CountWhereGroupIDStub
+	fakeReturns := fake.countWhereGroupIDReturns
+	fake.recordInvocation("CountWhereGroupID", []interface{}{arg1, arg2})
+	fake.countWhereGroupIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
-	countWhereDestinationIDReturns struct {
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PolicyRepo) CountWhereGroupIDCallCount() int {
+	fake.countWhereGroupIDMutex.RLock()
+	defer fake.countWhereGroupIDMutex.RUnlock()
+	return len(fake.countWhereGroupIDArgsForCall)
+}
+
+func (fake *PolicyRepo) CountWhereGroupIDCalls(stub func(db.Transaction, int) (int, error)) {
+	fake.countWhereGroupIDMutex.Lock()
+	defer fake.countWhereGroupIDMutex.Unlock()
+	fake.CountWhereGroupIDStub = stub
+}
+
+func (fake *PolicyRepo) CountWhereGroupIDArgsForCall(i int) (db.Transaction, int) {
+	fake.countWhereGroupIDMutex.RLock()
+	defer fake.countWhereGroupIDMutex.RUnlock()
+	argsForCall := fake.countWhereGroupIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PolicyRepo) CountWhereGroupIDReturns(result1 int, result2 error) {
+	fake.countWhereGroupIDMutex.Lock()
+	defer fake.countWhereGroupIDMutex.Unlock()
+	fake.CountWhereGroupIDStub = nil
+	fake.countWhereGroupIDReturns = struct {
 		result1 int
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.countWhereGroupIDMutex.Lock()
+	defer fake.countWhereGroupIDMutex.Unlock()
+	fake.CountWhereGroupIDStub = nil
+	if fake.countWhereGroupIDReturnsOnCall == nil {
+		fake.countWhereGroupIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
 	}
-	countWhereDestinationIDReturnsOnCall map[int]struct {
+	fake.countWhereGroupIDReturnsOnCall[i] = struct {
 		result1 int
 		result2 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	}{result1, result2}
 }
 
 func (fake *PolicyRepo) Create(arg1 db.Transaction, arg2 int, arg3 int) error {
@@ -74,15 +216,17 @@ func (fake *PolicyRepo) Create(arg1 db.Transaction, arg2 int, arg3 int) error {
 		arg2 int
 		arg3 int
 	}{arg1, arg2, arg3})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
 	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3})
 	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.createReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *PolicyRepo) CreateCallCount() int {
@@ -91,13 +235,22 @@ func (fake *PolicyRepo) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
+func (fake *PolicyRepo) CreateCalls(stub func(db.Transaction, int, int) error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
 func (fake *PolicyRepo) CreateArgsForCall(i int) (db.Transaction, int, int) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2, fake.createArgsForCall[i].arg3
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *PolicyRepo) CreateReturns(result1 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	fake.createReturns = struct {
 		result1 error
@@ -105,6 +258,8 @@ func (fake *PolicyRepo) CreateReturns(result1 error) {
 }
 
 func (fake *PolicyRepo) CreateReturnsOnCall(i int, result1 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
 	fake.CreateStub = nil
 	if fake.createReturnsOnCall == nil {
 		fake.createReturnsOnCall = make(map[int]struct {
@@ -116,6 +271,73 @@ func (fake *PolicyRepo) CreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PolicyRepo) CreateMany(arg1 db.Transaction, arg2 []store.PolicyRow) error {
+	var arg2Copy []store.PolicyRow
+	if arg2 != nil {
+		arg2Copy = make([]store.PolicyRow, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createManyMutex.Lock()
+	ret, specificReturn := fake.createManyReturnsOnCall[len(fake.createManyArgsForCall)]
+	fake.createManyArgsForCall = append(fake.createManyArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 []store.PolicyRow
+	}{arg1, arg2Copy})
+	stub := fake.CreateManyStub
+	fakeReturns := fake.createManyReturns
+	fake.recordInvocation("CreateMany", []interface{}{arg1, arg2Copy})
+	fake.createManyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PolicyRepo) CreateManyCallCount() int {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return len(fake.createManyArgsForCall)
+}
+
+func (fake *PolicyRepo) CreateManyCalls(stub func(db.Transaction, []store.PolicyRow) error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = stub
+}
+
+func (fake *PolicyRepo) CreateManyArgsForCall(i int) (db.Transaction, []store.PolicyRow) {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	argsForCall := fake.createManyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PolicyRepo) CreateManyReturns(result1 error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = nil
+	fake.createManyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyRepo) CreateManyReturnsOnCall(i int, result1 error) {
+	fake.createManyMutex.Lock()
+	defer fake.createManyMutex.Unlock()
+	fake.CreateManyStub = nil
+	if fake.createManyReturnsOnCall == nil {
+		fake.createManyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createManyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PolicyRepo) Delete(arg1 db.Transaction, arg2 int, arg3 int) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -124,15 +346,17 @@ func (fake *PolicyRepo) Delete(arg1 db.Transaction, arg2 int, arg3 int) error {
 		arg2 int
 		arg3 int
 	}{arg1, arg2, arg3})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
 	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteReturns.result1
+	return fakeReturns.result1
 }
 
 func (fake *PolicyRepo) DeleteCallCount() int {
@@ -141,13 +365,22 @@ func (fake *PolicyRepo) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
+func (fake *PolicyRepo) DeleteCalls(stub func(db.Transaction, int, int) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
 func (fake *PolicyRepo) DeleteArgsForCall(i int) (db.Transaction, int, int) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2, fake.deleteArgsForCall[i].arg3
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *PolicyRepo) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	fake.deleteReturns = struct {
 		result1 error
@@ -155,6 +388,8 @@ func (fake *PolicyRepo) DeleteReturns(result1 error) {
 }
 
 func (fake *PolicyRepo) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	if fake.deleteReturnsOnCall == nil {
 		fake.deleteReturnsOnCall = make(map[int]struct {
@@ -166,121 +401,19 @@ func (fake *PolicyRepo) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *PolicyRepo) CountWhereGroupID(arg1 db.Transaction, arg2 int) (int, error) {
-	fake.countWhereGroupIDMutex.Lock()
-	ret, specificReturn := fake.countWhereGroupIDReturnsOnCall[len(fake.countWhereGroupIDArgsForCall)]
-	fake.countWhereGroupIDArgsForCall = append(fake.countWhereGroupIDArgsForCall, struct {
-		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("CountWhereGroupID", []interface{}{arg1, arg2})
-	fake.countWhereGroupIDMutex.Unlock()
-	if fake.CountWhereGroupIDStub != nil {
-		return fake.CountWhereGroupIDStub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.countWhereGroupIDReturns.result1, fake.countWhereGroupIDReturns.result2
-}
-
-func (fake *PolicyRepo) CountWhereGroupIDCallCount() int {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return len(fake.countWhereGroupIDArgsForCall)
-}
-
-func (fake *PolicyRepo) CountWhereGroupIDArgsForCall(i int) (db.Transaction, int) {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return fake.countWhereGroupIDArgsForCall[i].arg1, fake.countWhereGroupIDArgsForCall[i].arg2
-}
-
-func (fake *PolicyRepo) CountWhereGroupIDReturns(result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	fake.countWhereGroupIDReturns = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *PolicyRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	if fake.countWhereGroupIDReturnsOnCall == nil {
-		fake.countWhereGroupIDReturnsOnCall = make(map[int]struct {
-			result1 int
-			result2 error
-		})
-	}
-	fake.countWhereGroupIDReturnsOnCall[i] = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *PolicyRepo) CountWhereDestinationID(arg1 db.Transaction, arg2 int) (int, error) {
-	fake.countWhereDestinationIDMutex.Lock()
-	ret, specificReturn := fake.countWhereDestinationIDReturnsOnCall[len(fake.countWhereDestinationIDArgsForCall)]
-	fake.countWhereDestinationIDArgsForCall = append(fake.countWhereDestinationIDArgsForCall, struct {
-		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("CountWhereDestinationID", []interface{}{arg1, arg2})
-	fake.countWhereDestinationIDMutex.Unlock()
-	if fake.CountWhereDestinationIDStub != nil {
-		return fake.CountWhereDestinationIDStub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.countWhereDestinationIDReturns.result1, fake.countWhereDestinationIDReturns.result2
-}
-
-func (fake *PolicyRepo) CountWhereDestinationIDCallCount() int {
-	fake.countWhereDestinationIDMutex.RLock()
-	defer fake.countWhereDestinationIDMutex.RUnlock()
-	return len(fake.countWhereDestinationIDArgsForCall)
-}
-
-func (fake *PolicyRepo) CountWhereDestinationIDArgsForCall(i int) (db.Transaction, int) {
-	fake.countWhereDestinationIDMutex.RLock()
-	defer fake.countWhereDestinationIDMutex.RUnlock()
-	return fake.countWhereDestinationIDArgsForCall[i].arg1, fake.countWhereDestinationIDArgsForCall[i].arg2
-}
-
-func (fake *PolicyRepo) CountWhereDestinationIDReturns(result1 int, result2 error) {
-	fake.CountWhereDestinationIDStub = nil
-	fake.countWhereDestinationIDReturns = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *PolicyRepo) CountWhereDestinationIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CountWhereDestinationIDStub = nil
-	if fake.countWhereDestinationIDReturnsOnCall == nil {
-		fake.countWhereDestinationIDReturnsOnCall = make(map[int]struct {
-			result1 int
-			result2 error
-		})
-	}
-	fake.countWhereDestinationIDReturnsOnCall[i] = struct {
-		result1 int
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *PolicyRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.countWhereDestinationIDMutex.RLock()
+	defer fake.countWhereDestinationIDMutex.RUnlock()
+	fake.countWhereGroupIDMutex.RLock()
+	defer fake.countWhereGroupIDMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	fake.countWhereDestinationIDMutex.RLock()
-	defer fake.countWhereDestinationIDMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
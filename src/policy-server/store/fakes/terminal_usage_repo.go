@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"policy-server/db"
+	"sync"
+)
+
+type TerminalUsageRepo struct {
+	IsTerminalInUseStub        func(db.Transaction, string) (bool, error)
+	isTerminalInUseMutex       sync.RWMutex
+	isTerminalInUseArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 string
+	}
+	isTerminalInUseReturns struct {
+		result1 bool
+		result2 error
+	}
+	isTerminalInUseReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TerminalUsageRepo) IsTerminalInUse(arg1 db.Transaction, arg2 string) (bool, error) {
+	fake.isTerminalInUseMutex.Lock()
+	ret, specificReturn := fake.isTerminalInUseReturnsOnCall[len(fake.isTerminalInUseArgsForCall)]
+	fake.isTerminalInUseArgsForCall = append(fake.isTerminalInUseArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.IsTerminalInUseStub
+	fakeReturns := fake.isTerminalInUseReturns
+	fake.recordInvocation("IsTerminalInUse", []interface{}{arg1, arg2})
+	fake.isTerminalInUseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TerminalUsageRepo) IsTerminalInUseCallCount() int {
+	fake.isTerminalInUseMutex.RLock()
+	defer fake.isTerminalInUseMutex.RUnlock()
+	return len(fake.isTerminalInUseArgsForCall)
+}
+
+func (fake *TerminalUsageRepo) IsTerminalInUseCalls(stub func(db.Transaction, string) (bool, error)) {
+	fake.isTerminalInUseMutex.Lock()
+	defer fake.isTerminalInUseMutex.Unlock()
+	fake.IsTerminalInUseStub = stub
+}
+
+func (fake *TerminalUsageRepo) IsTerminalInUseArgsForCall(i int) (db.Transaction, string) {
+	fake.isTerminalInUseMutex.RLock()
+	defer fake.isTerminalInUseMutex.RUnlock()
+	argsForCall := fake.isTerminalInUseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TerminalUsageRepo) IsTerminalInUseReturns(result1 bool, result2 error) {
+	fake.isTerminalInUseMutex.Lock()
+	defer fake.isTerminalInUseMutex.Unlock()
+	fake.IsTerminalInUseStub = nil
+	fake.isTerminalInUseReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TerminalUsageRepo) IsTerminalInUseReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isTerminalInUseMutex.Lock()
+	defer fake.isTerminalInUseMutex.Unlock()
+	fake.IsTerminalInUseStub = nil
+	if fake.isTerminalInUseReturnsOnCall == nil {
+		fake.isTerminalInUseReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isTerminalInUseReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TerminalUsageRepo) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.isTerminalInUseMutex.RLock()
+	defer fake.isTerminalInUseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TerminalUsageRepo) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
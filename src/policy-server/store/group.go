@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"fmt"
 	"policy-server/db"
+	"policy-server/store/helpers"
 )
 
 //go:generate counterfeiter -o fakes/group_repo.go --fake-name GroupRepo . GroupRepo
 type GroupRepo interface {
 	Create(db.Transaction, string, string) (int, error)
+	CreateMany(db.Transaction, []string, string) (map[string]int, error)
 	Delete(db.Transaction, int) error
 	GetID(db.Transaction, string) (int, error)
 }
@@ -36,6 +38,131 @@ func (g *GroupTable) Create(tx db.Transaction, guid, groupType string) (int, err
 	return id, nil
 }
 
+// CreateMany returns the group id for every guid, allocating blank tags for the
+// guids that do not have one yet. Lookups and updates are issued in batches
+// rather than once per guid.
+func (g *GroupTable) CreateMany(tx db.Transaction, guids []string, groupType string) (map[string]int, error) {
+	guids = uniqueStrings(guids)
+
+	ids, err := g.findRowsByGUID(tx, guids, groupType)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, guid := range guids {
+		if _, ok := ids[guid]; !ok {
+			missing = append(missing, guid)
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	blankIDs, err := g.blankRows(tx, len(missing))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available tag: %s", err.Error())
+	}
+	if len(blankIDs) < len(missing) {
+		return nil, fmt.Errorf("failed to find available tag: %d requested, %d available", len(missing), len(blankIDs))
+	}
+
+	err = g.updateRows(tx, blankIDs, missing, groupType)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, guid := range missing {
+		ids[guid] = blankIDs[i]
+	}
+	return ids, nil
+}
+
+func (g *GroupTable) findRowsByGUID(tx db.Transaction, guids []string, groupType string) (map[string]int, error) {
+	ids := map[string]int{}
+	for _, batch := range batches(len(guids)) {
+		chunk := guids[batch.start:batch.end]
+
+		args := []interface{}{groupType}
+		for _, guid := range chunk {
+			args = append(args, guid)
+		}
+
+		rows, err := tx.Queryx(tx.Rebind(fmt.Sprintf(`
+			SELECT id, guid FROM groups
+			WHERE type = ? AND guid IN (%s)
+		`, helpers.QuestionMarks(len(chunk)))), args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var id int
+			var guid string
+			err = rows.Scan(&id, &guid)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			ids[guid] = id
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+func (g *GroupTable) blankRows(tx db.Transaction, count int) ([]int, error) {
+	rows, err := tx.Queryx(fmt.Sprintf(`
+		SELECT id FROM groups
+		WHERE guid is NULL
+		ORDER BY id
+		LIMIT %d
+	`, count) + helpers.ForUpdate(tx.DriverName()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (g *GroupTable) updateRows(tx db.Transaction, ids []int, guids []string, groupType string) error {
+	for _, batch := range batches(len(guids)) {
+		var cases string
+		var caseArgs, idArgs []interface{}
+		for i := batch.start; i < batch.end; i++ {
+			cases += " WHEN ? THEN ?"
+			caseArgs = append(caseArgs, ids[i], guids[i])
+			idArgs = append(idArgs, ids[i])
+		}
+
+		args := append(caseArgs, groupType)
+		args = append(args, idArgs...)
+
+		_, err := tx.Exec(tx.Rebind(fmt.Sprintf(`
+			UPDATE groups SET guid = CASE id%s END, type = ?
+			WHERE id IN (%s)
+		`, cases, helpers.QuestionMarks(len(idArgs)))), args...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (g *GroupTable) findRowByGUID(tx db.Transaction, guid, groupType string) (int, error) {
 	var id int
 	err := tx.QueryRow(
@@ -56,8 +183,7 @@ func (g *GroupTable) firstBlankRow(tx db.Transaction) (int, error) {
 		WHERE guid is NULL
 		ORDER BY id
 		LIMIT 1
-		FOR UPDATE
-	`).Scan(&id)
+	` + helpers.ForUpdate(tx.DriverName())).Scan(&id)
 	return id, err
 }
 
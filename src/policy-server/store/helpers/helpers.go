@@ -8,6 +8,7 @@ import (
 const (
 	MySQL    = "mysql"
 	Postgres = "postgres"
+	SQLite   = "sqlite3"
 )
 
 func QuestionMarks(count int) string {
@@ -18,7 +19,7 @@ func QuestionMarks(count int) string {
 }
 
 func RebindForSQLDialect(query, dialect string) string {
-	if dialect == MySQL {
+	if dialect == MySQL || dialect == SQLite {
 		return query
 	}
 	if dialect != Postgres {
@@ -31,3 +32,20 @@ func RebindForSQLDialect(query, dialect string) string {
 	}
 	return strings.Join(strParts, "")
 }
+
+func QuestionMarkTuples(rowCount, columnCount int) string {
+	if rowCount == 0 || columnCount == 0 {
+		return ""
+	}
+	tuple := "(" + QuestionMarks(columnCount) + ")"
+	return strings.Repeat(tuple+", ", rowCount-1) + tuple
+}
+
+// ForUpdate returns the row locking clause for dialect. SQLite has no row
+// locks; a write transaction holds the database lock until it ends.
+func ForUpdate(dialect string) string {
+	if dialect == SQLite {
+		return ""
+	}
+	return " FOR UPDATE "
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+)
+
+// ipKey encodes an address as the 32 hex digits of its 16 byte form, so that
+// keys compare as strings in the same order as the addresses do, whatever
+// the database. IPv4 addresses are encoded as ipv4-mapped ipv6 addresses.
+func ipKey(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid ip address: %s", ip)
+	}
+	return hex.EncodeToString(parsed.To16()), nil
+}
+
+func ipRangeKeys(startIP, endIP string) (string, string, error) {
+	startKey, err := ipKey(startIP)
+	if err != nil {
+		return "", "", fmt.Errorf("ip range start: %s", err)
+	}
+	endKey, err := ipKey(endIP)
+	if err != nil {
+		return "", "", fmt.Errorf("ip range end: %s", err)
+	}
+	return startKey, endKey, nil
+}
+
+// IPRangeKeyPopulator sets the keys of ip ranges stored before the keys
+// were added.
+type IPRangeKeyPopulator struct {
+	DBConnection Database
+}
+
+func (p *IPRangeKeyPopulator) PopulateKeys() error {
+	var ipRanges []struct {
+		ID      int64  `db:"id"`
+		StartIP string `db:"start_ip"`
+		EndIP   string `db:"end_ip"`
+	}
+	err := p.DBConnection.Select(&ipRanges, `
+		SELECT id, start_ip, end_ip
+		FROM ip_ranges
+		WHERE start_ip_key IS NULL OR end_ip_key IS NULL`)
+	if err != nil {
+		return fmt.Errorf("selecting ip ranges: %s", err)
+	}
+
+	for _, ipRange := range ipRanges {
+		startKey, endKey, err := ipRangeKeys(ipRange.StartIP, ipRange.EndIP)
+		if err != nil {
+			return fmt.Errorf("ip range %d: %s", ipRange.ID, err)
+		}
+		_, err = p.DBConnection.Exec(p.DBConnection.Rebind(`
+			UPDATE ip_ranges SET start_ip_key = ?, end_ip_key = ? WHERE id = ?`),
+			startKey, endKey, ipRange.ID)
+		if err != nil {
+			return fmt.Errorf("updating ip range %d: %s", ipRange.ID, err)
+		}
+	}
+	return nil
+}
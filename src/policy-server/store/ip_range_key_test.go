@@ -0,0 +1,86 @@
+package store_test
+
+import (
+	"fmt"
+	"policy-server/db"
+	"policy-server/store"
+	"test-helpers"
+	"time"
+
+	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IP range keys", func() {
+	var (
+		dbConf dbHelper.Config
+		realDb *db.ConnWrapper
+	)
+
+	BeforeEach(func() {
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("ip_range_key_test_node_%d", time.Now().UnixNano())
+		testhelpers.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("IP Range Key Test")
+		realDb = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "IP Range Key Test", "IP Range Key Test", logger)
+		migrate(realDb)
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testhelpers.RemoveDatabase(dbConf)
+	})
+
+	createIPRange := func(startIP, endIP string) {
+		tx, err := realDb.Beginx()
+		Expect(err).NotTo(HaveOccurred())
+		terminalGUID, err := (&store.TerminalsTable{Guids: &store.GuidGenerator{}}).Create(tx)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = (&store.EgressDestinationTable{}).CreateIPRange(tx, terminalGUID, startIP, endIP, "tcp", 443, 443, 0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.Commit()).To(Succeed())
+	}
+
+	containing := func(ip string) []string {
+		key := map[string]string{
+			"10.0.0.5":    "00000000000000000000ffff0a000005",
+			"10.0.1.5":    "00000000000000000000ffff0a000105",
+			"2001:db8::5": "20010db8000000000000000000000005",
+		}[ip]
+		var startIPs []string
+		err := realDb.Select(&startIPs, realDb.Rebind(`
+			SELECT start_ip FROM ip_ranges
+			WHERE start_ip_key <= ? AND end_ip_key >= ?
+			ORDER BY id`), key, key)
+		Expect(err).NotTo(HaveOccurred())
+		return startIPs
+	}
+
+	It("stores keys that range-compare like the addresses of either family", func() {
+		createIPRange("10.0.0.1", "10.0.0.9")
+		createIPRange("2001:db8::", "2001:db8::ff")
+
+		Expect(containing("10.0.0.5")).To(Equal([]string{"10.0.0.1"}))
+		Expect(containing("10.0.1.5")).To(BeEmpty())
+		Expect(containing("2001:db8::5")).To(Equal([]string{"2001:db8::"}))
+	})
+
+	Describe("IPRangeKeyPopulator", func() {
+		It("sets the keys of ip ranges stored without them", func() {
+			createIPRange("10.0.0.1", "10.0.0.9")
+			_, err := realDb.Exec(`UPDATE ip_ranges SET start_ip_key = NULL, end_ip_key = NULL`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containing("10.0.0.5")).To(BeEmpty())
+
+			populator := &store.IPRangeKeyPopulator{DBConnection: realDb}
+			Expect(populator.PopulateKeys()).To(Succeed())
+
+			Expect(containing("10.0.0.5")).To(Equal([]string{"10.0.0.1"}))
+		})
+	})
+})
@@ -9,14 +9,30 @@ import (
 )
 
 type MigrateAdapter struct {
-	ExecMaxStub        func(db migrations.MigrationDb, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, maxNumMigrations int) (int, error)
+	ExecDownMaxStub        func(migrations.MigrationDb, string, migrate.MigrationSource, int) (int, error)
+	execDownMaxMutex       sync.RWMutex
+	execDownMaxArgsForCall []struct {
+		arg1 migrations.MigrationDb
+		arg2 string
+		arg3 migrate.MigrationSource
+		arg4 int
+	}
+	execDownMaxReturns struct {
+		result1 int
+		result2 error
+	}
+	execDownMaxReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	ExecMaxStub        func(migrations.MigrationDb, string, migrate.MigrationSource, migrate.MigrationDirection, int) (int, error)
 	execMaxMutex       sync.RWMutex
 	execMaxArgsForCall []struct {
-		db               migrations.MigrationDb
-		dialect          string
-		m                migrate.MigrationSource
-		dir              migrate.MigrationDirection
-		maxNumMigrations int
+		arg1 migrations.MigrationDb
+		arg2 string
+		arg3 migrate.MigrationSource
+		arg4 migrate.MigrationDirection
+		arg5 int
 	}
 	execMaxReturns struct {
 		result1 int
@@ -26,29 +42,112 @@ type MigrateAdapter struct {
 		result1 int
 		result2 error
 	}
+	GetMigrationRecordsStub        func(migrations.MigrationDb, string) ([]*migrate.MigrationRecord, error)
+	getMigrationRecordsMutex       sync.RWMutex
+	getMigrationRecordsArgsForCall []struct {
+		arg1 migrations.MigrationDb
+		arg2 string
+	}
+	getMigrationRecordsReturns struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}
+	getMigrationRecordsReturnsOnCall map[int]struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *MigrateAdapter) ExecMax(db migrations.MigrationDb, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, maxNumMigrations int) (int, error) {
+func (fake *MigrateAdapter) ExecDownMax(arg1 migrations.MigrationDb, arg2 string, arg3 migrate.MigrationSource, arg4 int) (int, error) {
+	fake.execDownMaxMutex.Lock()
+	ret, specificReturn := fake.execDownMaxReturnsOnCall[len(fake.execDownMaxArgsForCall)]
+	fake.execDownMaxArgsForCall = append(fake.execDownMaxArgsForCall, struct {
+		arg1 migrations.MigrationDb
+		arg2 string
+		arg3 migrate.MigrationSource
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ExecDownMaxStub
+	fakeReturns := fake.execDownMaxReturns
+	fake.recordInvocation("ExecDownMax", []interface{}{arg1, arg2, arg3, arg4})
+	fake.execDownMaxMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *MigrateAdapter) ExecDownMaxCallCount() int {
+	fake.execDownMaxMutex.RLock()
+	defer fake.execDownMaxMutex.RUnlock()
+	return len(fake.execDownMaxArgsForCall)
+}
+
+func (fake *MigrateAdapter) ExecDownMaxCalls(stub func(migrations.MigrationDb, string, migrate.MigrationSource, int) (int, error)) {
+	fake.execDownMaxMutex.Lock()
+	defer fake.execDownMaxMutex.Unlock()
+	fake.ExecDownMaxStub = stub
+}
+
+func (fake *MigrateAdapter) ExecDownMaxArgsForCall(i int) (migrations.MigrationDb, string, migrate.MigrationSource, int) {
+	fake.execDownMaxMutex.RLock()
+	defer fake.execDownMaxMutex.RUnlock()
+	argsForCall := fake.execDownMaxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *MigrateAdapter) ExecDownMaxReturns(result1 int, result2 error) {
+	fake.execDownMaxMutex.Lock()
+	defer fake.execDownMaxMutex.Unlock()
+	fake.ExecDownMaxStub = nil
+	fake.execDownMaxReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MigrateAdapter) ExecDownMaxReturnsOnCall(i int, result1 int, result2 error) {
+	fake.execDownMaxMutex.Lock()
+	defer fake.execDownMaxMutex.Unlock()
+	fake.ExecDownMaxStub = nil
+	if fake.execDownMaxReturnsOnCall == nil {
+		fake.execDownMaxReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.execDownMaxReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MigrateAdapter) ExecMax(arg1 migrations.MigrationDb, arg2 string, arg3 migrate.MigrationSource, arg4 migrate.MigrationDirection, arg5 int) (int, error) {
 	fake.execMaxMutex.Lock()
 	ret, specificReturn := fake.execMaxReturnsOnCall[len(fake.execMaxArgsForCall)]
 	fake.execMaxArgsForCall = append(fake.execMaxArgsForCall, struct {
-		db               migrations.MigrationDb
-		dialect          string
-		m                migrate.MigrationSource
-		dir              migrate.MigrationDirection
-		maxNumMigrations int
-	}{db, dialect, m, dir, maxNumMigrations})
-	fake.recordInvocation("ExecMax", []interface{}{db, dialect, m, dir, maxNumMigrations})
+		arg1 migrations.MigrationDb
+		arg2 string
+		arg3 migrate.MigrationSource
+		arg4 migrate.MigrationDirection
+		arg5 int
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ExecMaxStub
+	fakeReturns := fake.execMaxReturns
+	fake.recordInvocation("ExecMax", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.execMaxMutex.Unlock()
-	if fake.ExecMaxStub != nil {
-		return fake.ExecMaxStub(db, dialect, m, dir, maxNumMigrations)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.execMaxReturns.result1, fake.execMaxReturns.result2
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *MigrateAdapter) ExecMaxCallCount() int {
@@ -57,13 +156,22 @@ func (fake *MigrateAdapter) ExecMaxCallCount() int {
 	return len(fake.execMaxArgsForCall)
 }
 
+func (fake *MigrateAdapter) ExecMaxCalls(stub func(migrations.MigrationDb, string, migrate.MigrationSource, migrate.MigrationDirection, int) (int, error)) {
+	fake.execMaxMutex.Lock()
+	defer fake.execMaxMutex.Unlock()
+	fake.ExecMaxStub = stub
+}
+
 func (fake *MigrateAdapter) ExecMaxArgsForCall(i int) (migrations.MigrationDb, string, migrate.MigrationSource, migrate.MigrationDirection, int) {
 	fake.execMaxMutex.RLock()
 	defer fake.execMaxMutex.RUnlock()
-	return fake.execMaxArgsForCall[i].db, fake.execMaxArgsForCall[i].dialect, fake.execMaxArgsForCall[i].m, fake.execMaxArgsForCall[i].dir, fake.execMaxArgsForCall[i].maxNumMigrations
+	argsForCall := fake.execMaxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *MigrateAdapter) ExecMaxReturns(result1 int, result2 error) {
+	fake.execMaxMutex.Lock()
+	defer fake.execMaxMutex.Unlock()
 	fake.ExecMaxStub = nil
 	fake.execMaxReturns = struct {
 		result1 int
@@ -72,6 +180,8 @@ func (fake *MigrateAdapter) ExecMaxReturns(result1 int, result2 error) {
 }
 
 func (fake *MigrateAdapter) ExecMaxReturnsOnCall(i int, result1 int, result2 error) {
+	fake.execMaxMutex.Lock()
+	defer fake.execMaxMutex.Unlock()
 	fake.ExecMaxStub = nil
 	if fake.execMaxReturnsOnCall == nil {
 		fake.execMaxReturnsOnCall = make(map[int]struct {
@@ -85,11 +195,80 @@ func (fake *MigrateAdapter) ExecMaxReturnsOnCall(i int, result1 int, result2 err
 	}{result1, result2}
 }
 
+func (fake *MigrateAdapter) GetMigrationRecords(arg1 migrations.MigrationDb, arg2 string) ([]*migrate.MigrationRecord, error) {
+	fake.getMigrationRecordsMutex.Lock()
+	ret, specificReturn := fake.getMigrationRecordsReturnsOnCall[len(fake.getMigrationRecordsArgsForCall)]
+	fake.getMigrationRecordsArgsForCall = append(fake.getMigrationRecordsArgsForCall, struct {
+		arg1 migrations.MigrationDb
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetMigrationRecordsStub
+	fakeReturns := fake.getMigrationRecordsReturns
+	fake.recordInvocation("GetMigrationRecords", []interface{}{arg1, arg2})
+	fake.getMigrationRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsCallCount() int {
+	fake.getMigrationRecordsMutex.RLock()
+	defer fake.getMigrationRecordsMutex.RUnlock()
+	return len(fake.getMigrationRecordsArgsForCall)
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsCalls(stub func(migrations.MigrationDb, string) ([]*migrate.MigrationRecord, error)) {
+	fake.getMigrationRecordsMutex.Lock()
+	defer fake.getMigrationRecordsMutex.Unlock()
+	fake.GetMigrationRecordsStub = stub
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsArgsForCall(i int) (migrations.MigrationDb, string) {
+	fake.getMigrationRecordsMutex.RLock()
+	defer fake.getMigrationRecordsMutex.RUnlock()
+	argsForCall := fake.getMigrationRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsReturns(result1 []*migrate.MigrationRecord, result2 error) {
+	fake.getMigrationRecordsMutex.Lock()
+	defer fake.getMigrationRecordsMutex.Unlock()
+	fake.GetMigrationRecordsStub = nil
+	fake.getMigrationRecordsReturns = struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsReturnsOnCall(i int, result1 []*migrate.MigrationRecord, result2 error) {
+	fake.getMigrationRecordsMutex.Lock()
+	defer fake.getMigrationRecordsMutex.Unlock()
+	fake.GetMigrationRecordsStub = nil
+	if fake.getMigrationRecordsReturnsOnCall == nil {
+		fake.getMigrationRecordsReturnsOnCall = make(map[int]struct {
+			result1 []*migrate.MigrationRecord
+			result2 error
+		})
+	}
+	fake.getMigrationRecordsReturnsOnCall[i] = struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *MigrateAdapter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.execDownMaxMutex.RLock()
+	defer fake.execDownMaxMutex.RUnlock()
 	fake.execMaxMutex.RLock()
 	defer fake.execMaxMutex.RUnlock()
+	fake.getMigrationRecordsMutex.RLock()
+	defer fake.getMigrationRecordsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -15,5 +15,25 @@ func (ma *MigrateAdapter) ExecMax(db MigrationDb, dialect string, m migrate.Migr
 		return 0, errors.New("down migration not supported")
 	}
 
+	if dialect == "sqlite3" {
+		// SQLite databases are local to a single process, so there is no
+		// other policy server to coordinate with.
+		return migrate.ExecMax(db.RawConnection().DB, dialect, m, dir, max)
+	}
+
 	return migrate.ExecMaxWithLock(db.RawConnection().DB, dialect, m, dir, max, 1*time.Minute) // tested through integration
 }
+
+// ExecDownMax reverts the last max applied migrations. Callers are expected to
+// have checked that every one of them defines a reverse.
+func (ma *MigrateAdapter) ExecDownMax(db MigrationDb, dialect string, m migrate.MigrationSource, max int) (int, error) {
+	if dialect == "sqlite3" {
+		return migrate.ExecMax(db.RawConnection().DB, dialect, m, migrate.Down, max)
+	}
+
+	return migrate.ExecMaxWithLock(db.RawConnection().DB, dialect, m, migrate.Down, max, 1*time.Minute) // tested through integration
+}
+
+func (ma *MigrateAdapter) GetMigrationRecords(db MigrationDb, dialect string) ([]*migrate.MigrationRecord, error) {
+	return migrate.GetMigrationRecords(db.RawConnection().DB, dialect)
+}
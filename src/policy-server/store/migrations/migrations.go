@@ -329,7 +329,78 @@ var MigrationsToPerform = PolicyServerMigrations{
 		Up: migration_v0055,
 	},
 	PolicyServerMigration{
-		Id: "56",
-		Up: migration_v0056,
+		Id:   "56",
+		Up:   migration_v0056,
+		Down: migration_v0056_down,
+	},
+	PolicyServerMigration{
+		Id:   "57",
+		Up:   migration_v0057,
+		Down: migration_v0057_down,
+	},
+	PolicyServerMigration{
+		Id:   "58",
+		Up:   migration_v0058,
+		Down: migration_v0058_down,
+	},
+	PolicyServerMigration{
+		Id:   "59",
+		Up:   migration_v0059,
+		Down: migration_v0059_down,
+	},
+	PolicyServerMigration{
+		Id:   "60",
+		Up:   migration_v0060,
+		Down: migration_v0060_down,
+	},
+	PolicyServerMigration{
+		Id:   "61",
+		Up:   migration_v0061,
+		Down: migration_v0061_down,
+	},
+	PolicyServerMigration{
+		Id:   "62",
+		Up:   migration_v0062,
+		Down: migration_v0062_down,
+	},
+	PolicyServerMigration{
+		Id:   "63",
+		Up:   migration_v0063,
+		Down: migration_v0063_down,
+	},
+	PolicyServerMigration{
+		Id:   "64",
+		Up:   migration_v0064,
+		Down: migration_v0064_down,
+	},
+	PolicyServerMigration{
+		Id:   "65",
+		Up:   migration_v0065,
+		Down: migration_v0065_down,
+	},
+	PolicyServerMigration{
+		Id:   "66",
+		Up:   migration_v0066,
+		Down: migration_v0066_down,
+	},
+	PolicyServerMigration{
+		Id:   "67",
+		Up:   migration_v0067,
+		Down: migration_v0067_down,
+	},
+	PolicyServerMigration{
+		Id:   "68",
+		Up:   migration_v0068,
+		Down: migration_v0068_down,
+	},
+	PolicyServerMigration{
+		Id:   "69",
+		Up:   migration_v0069,
+		Down: migration_v0069_down,
+	},
+	PolicyServerMigration{
+		Id:   "70",
+		Up:   migration_v0070,
+		Down: migration_v0070_down,
 	},
 }
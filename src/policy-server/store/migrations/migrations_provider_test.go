@@ -44,6 +44,15 @@ var _ = Describe("Migrations Provider", func() {
 		Expect(migrationsToPerform).To(Equal(expectedMigrations))
 	})
 
+	It("provides a sqlite3 variant of every migration when no legacy migration occurred", func() {
+		migrationsToPerform, err := migrationsProvider.MigrationsToPerform()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, migration := range migrationsToPerform {
+			Expect(migration.Up).To(HaveKey("sqlite3"), "migration %s has no sqlite3 variant", migration.Id)
+		}
+	})
+
 	It("returns a helpful error message for V1MigrationOccurred errors", func() {
 		migrationStore.HasV1MigrationOccurredReturns(false, errors.New("I AM ERROR"))
 		_, err := migrationsProvider.MigrationsToPerform()
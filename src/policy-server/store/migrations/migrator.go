@@ -3,6 +3,7 @@ package migrations
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/cf-container-networking/sql-migrate"
 	"github.com/jmoiron/sqlx"
@@ -11,6 +12,8 @@ import (
 //go:generate counterfeiter -o fakes/migrate_adapter.go --fake-name MigrateAdapter . migrateAdapter
 type migrateAdapter interface {
 	ExecMax(db MigrationDb, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, maxNumMigrations int) (int, error)
+	ExecDownMax(db MigrationDb, dialect string, m migrate.MigrationSource, maxNumMigrations int) (int, error)
+	GetMigrationRecords(db MigrationDb, dialect string) ([]*migrate.MigrationRecord, error)
 }
 
 //go:generate counterfeiter -o fakes/migration_db.go --fake-name MigrationDb . MigrationDb
@@ -58,6 +61,163 @@ func (m *Migrator) PerformMigrations(driverName string, migrationDb MigrationDb,
 	return numMigrations, nil
 }
 
+type MigrationStatus struct {
+	Id         string
+	Applied    bool
+	AppliedAt  time.Time
+	Reversible bool
+}
+
+func (m *Migrator) Status(driverName string, migrationDb MigrationDb) ([]MigrationStatus, error) {
+	migrationsToPerform, err := m.MigrationsProvider.MigrationsToPerform()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving migrations to perform: %s", err)
+	}
+
+	records, err := m.MigrateAdapter.GetMigrationRecords(migrationDb, driverName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving migration records: %s", err)
+	}
+
+	appliedAt := map[string]time.Time{}
+	for _, record := range records {
+		appliedAt[record.Id] = record.AppliedAt
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range migrationsToPerform {
+		applied, ok := appliedAt[migration.Id]
+		statuses = append(statuses, MigrationStatus{
+			Id:         migration.Id,
+			Applied:    ok,
+			AppliedAt:  applied,
+			Reversible: migration.reversibleForDriver(driverName),
+		})
+	}
+	return statuses, nil
+}
+
+// PendingMigrations returns the migrations PerformMigrations would run, with
+// the statements for driverName, without running them.
+func (m *Migrator) PendingMigrations(driverName string, migrationDb MigrationDb) ([]*migrate.Migration, error) {
+	migrationsToPerform, statuses, err := m.migrationsWithStatus(driverName, migrationDb)
+	if err != nil {
+		return nil, err
+	}
+
+	if !migrationsToPerform.supportsDriver(driverName) {
+		return nil, fmt.Errorf("unsupported driver: %s", driverName)
+	}
+
+	pending := []*migrate.Migration{}
+	for i, migration := range migrationsToPerform {
+		if !statuses[i].Applied {
+			pending = append(pending, migration.forDriver(driverName))
+		}
+	}
+	return pending, nil
+}
+
+// DownMigrations returns the applied migrations after targetId, newest first,
+// that PerformDownMigrations would revert. Every one of them must define a
+// reverse for driverName.
+func (m *Migrator) DownMigrations(driverName string, migrationDb MigrationDb, targetId string) ([]*migrate.Migration, error) {
+	migrationsToPerform, statuses, err := m.migrationsWithStatus(driverName, migrationDb)
+	if err != nil {
+		return nil, err
+	}
+
+	targetIdx := -1
+	for i, migration := range migrationsToPerform {
+		if migration.Id == targetId {
+			targetIdx = i
+		}
+	}
+	if targetIdx == -1 {
+		return nil, fmt.Errorf("unknown target migration: %s", targetId)
+	}
+	if !statuses[targetIdx].Applied {
+		return nil, fmt.Errorf("target migration %s has not been applied", targetId)
+	}
+
+	down := []*migrate.Migration{}
+	for i := len(migrationsToPerform) - 1; i > targetIdx; i-- {
+		if !statuses[i].Applied {
+			continue
+		}
+		if !statuses[i].Reversible {
+			return nil, fmt.Errorf("migration %s cannot be reverted on %s", migrationsToPerform[i].Id, driverName)
+		}
+		down = append(down, migrationsToPerform[i].forDriver(driverName))
+	}
+	return down, nil
+}
+
+// ForDriver returns the given migrations with their statements for
+// driverName, such as to print the SQL another database would run.
+func (m *Migrator) ForDriver(ms []*migrate.Migration, driverName string) ([]*migrate.Migration, error) {
+	migrationsToPerform, err := m.MigrationsProvider.MigrationsToPerform()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving migrations to perform: %s", err)
+	}
+
+	byId := map[string]PolicyServerMigration{}
+	for _, migration := range migrationsToPerform {
+		byId[migration.Id] = migration
+	}
+
+	result := []*migrate.Migration{}
+	for _, migration := range ms {
+		policyServerMigration, ok := byId[migration.Id]
+		if !ok || !policyServerMigration.supportsDriver(driverName) {
+			return nil, fmt.Errorf("migration %s has no statements for %s", migration.Id, driverName)
+		}
+		result = append(result, policyServerMigration.forDriver(driverName))
+	}
+	return result, nil
+}
+
+func (m *Migrator) PerformDownMigrations(driverName string, migrationDb MigrationDb, targetId string) (int, error) {
+	down, err := m.DownMigrations(driverName, migrationDb, targetId)
+	if err != nil {
+		return 0, err
+	}
+	if len(down) == 0 {
+		return 0, nil
+	}
+
+	migrationsToPerform, err := m.MigrationsProvider.MigrationsToPerform()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving migrations to perform: %s", err)
+	}
+
+	numMigrations, err := m.MigrateAdapter.ExecDownMax(
+		migrationDb,
+		driverName,
+		migrate.MemoryMigrationSource{
+			Migrations: migrationsToPerform.ForDriver(driverName),
+		},
+		len(down),
+	)
+	if err != nil {
+		return numMigrations, fmt.Errorf("executing down migration: %s", err)
+	}
+	return numMigrations, nil
+}
+
+func (m *Migrator) migrationsWithStatus(driverName string, migrationDb MigrationDb) (PolicyServerMigrations, []MigrationStatus, error) {
+	migrationsToPerform, err := m.MigrationsProvider.MigrationsToPerform()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving migrations to perform: %s", err)
+	}
+
+	statuses, err := m.Status(driverName, migrationDb)
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrationsToPerform, statuses, nil
+}
+
 type PolicyServerMigrations []PolicyServerMigration
 
 func (s PolicyServerMigrations) ForDriver(driverName string) []*migrate.Migration {
@@ -78,18 +238,27 @@ func (s PolicyServerMigrations) supportsDriver(driverName string) bool {
 	return true
 }
 
+// PolicyServerMigration holds the statements of a migration per driver. Down
+// is optional; a migration can only be reverted on the drivers it lists.
 type PolicyServerMigration struct {
-	Id string
-	Up map[string][]string
+	Id   string
+	Up   map[string][]string
+	Down map[string][]string
 }
 
 func (psm *PolicyServerMigration) forDriver(driverName string) *migrate.Migration {
 	return &migrate.Migration{
-		Id: psm.Id,
-		Up: psm.Up[driverName],
+		Id:   psm.Id,
+		Up:   psm.Up[driverName],
+		Down: psm.Down[driverName],
 	}
 }
 
+func (psm *PolicyServerMigration) reversibleForDriver(driverName string) bool {
+	_, found := psm.Down[driverName]
+	return found
+}
+
 func (psm *PolicyServerMigration) supportsDriver(driverName string) bool {
 	_, foundUp := psm.Up[driverName]
 	return foundUp
@@ -1536,6 +1536,171 @@ var _ = Describe("migrations", func() {
 			})
 		})
 
+		Describe("V57 - Group orphaned_at column", func() {
+			It("should migrate", func() {
+				migrateTo("56")
+				Expect(queryTableColumnNames("groups", realDb)).NotTo(ContainElement("orphaned_at"))
+
+				migrateTo("57")
+				Expect(queryTableColumnNames("groups", realDb)).To(ContainElement("orphaned_at"))
+			})
+		})
+
+		Describe("V58/V59 - Policy log column", func() {
+			It("should migrate", func() {
+				migrateTo("57")
+				Expect(queryTableColumnNames("policies", realDb)).NotTo(ContainElement("log"))
+				Expect(queryTableColumnNames("egress_policies", realDb)).NotTo(ContainElement("log"))
+
+				migrateTo("59")
+				Expect(queryTableColumnNames("policies", realDb)).To(ContainElement("log"))
+				Expect(queryTableColumnNames("egress_policies", realDb)).To(ContainElement("log"))
+			})
+		})
+
+		Describe("V60 - IP range keys", func() {
+			It("should migrate", func() {
+				migrateTo("59")
+				Expect(queryTableColumnNames("ip_ranges", realDb)).NotTo(ContainElement("start_ip_key"))
+
+				migrateTo("60")
+				Expect(queryTableColumnNames("ip_ranges", realDb)).To(ContainElement("start_ip_key"))
+				Expect(queryTableColumnNames("ip_ranges", realDb)).To(ContainElement("end_ip_key"))
+			})
+		})
+
+		Describe("V61 - Org and default egress sources", func() {
+			It("should migrate", func() {
+				migrateTo("60")
+				Expect(queryTableColumnNames("orgs", realDb)).To(BeEmpty())
+
+				migrateTo("61")
+				Expect(queryTableColumnNames("orgs", realDb)).To(ConsistOf("id", "org_guid", "terminal_guid"))
+				Expect(queryTableColumnNames("default_sources", realDb)).To(ConsistOf("id", "terminal_guid"))
+			})
+		})
+
+		Describe("V62 - Egress policy app lifecycle", func() {
+			It("should migrate", func() {
+				migrateTo("61")
+				Expect(queryTableColumnNames("egress_policies", realDb)).NotTo(ContainElement("app_lifecycle"))
+
+				migrateTo("62")
+				Expect(queryTableColumnNames("egress_policies", realDb)).To(ContainElement("app_lifecycle"))
+			})
+		})
+
+		Describe("V63 to V65 - Normalize ip ranges by protocol", func() {
+			It("should migrate", func() {
+				migrateTo("62")
+
+				insertIPRange := func(terminalGUID, protocol string, startPort, endPort, icmpType, icmpCode int) {
+					_, err := realDb.Exec(realDb.RawConnection().Rebind(`INSERT INTO terminals (guid) VALUES (?)`), terminalGUID)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = realDb.Exec(realDb.RawConnection().Rebind(`
+						INSERT INTO ip_ranges (protocol, start_ip, end_ip, terminal_guid, start_port, end_port, icmp_type, icmp_code)
+						VALUES (?, '10.0.0.1', '10.0.0.1', ?, ?, ?, ?, ?)
+					`), protocol, terminalGUID, startPort, endPort, icmpType, icmpCode)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				insertIPRange("tcp-guid", "tcp", 8080, 8080, 0, 0)
+				insertIPRange("udp-with-icmp-guid", "udp", 53, 53, 3, 1)
+				insertIPRange("icmp-with-ports-guid", "icmp", 80, 90, 8, 0)
+				insertIPRange("all-with-ports-guid", "all", 80, 90, 0, 0)
+				insertIPRange("icmp-any-type-guid", "icmp", 0, 0, -1, 4)
+
+				migrateTo("65")
+				Expect(queryTableColumnNames("ip_range_normalizations", realDb)).To(ConsistOf(
+					"id", "ip_range_id", "terminal_guid", "protocol", "start_port", "end_port", "icmp_type", "icmp_code", "reason",
+				))
+
+				queryIPRange := func(terminalGUID string) []int {
+					var startPort, endPort, icmpType, icmpCode int
+					err := realDb.QueryRow(realDb.RawConnection().Rebind(`
+						SELECT start_port, end_port, icmp_type, icmp_code FROM ip_ranges WHERE terminal_guid = ?
+					`), terminalGUID).Scan(&startPort, &endPort, &icmpType, &icmpCode)
+					Expect(err).NotTo(HaveOccurred())
+					return []int{startPort, endPort, icmpType, icmpCode}
+				}
+				Expect(queryIPRange("tcp-guid")).To(Equal([]int{8080, 8080, 0, 0}))
+				Expect(queryIPRange("udp-with-icmp-guid")).To(Equal([]int{53, 53, 0, 0}))
+				Expect(queryIPRange("icmp-with-ports-guid")).To(Equal([]int{0, 0, 8, 0}))
+				Expect(queryIPRange("all-with-ports-guid")).To(Equal([]int{0, 0, 0, 0}))
+				Expect(queryIPRange("icmp-any-type-guid")).To(Equal([]int{0, 0, -1, -1}))
+
+				Expect(queryTableForColumnValues("ip_range_normalizations", "terminal_guid", realDb)).To(ConsistOf(
+					"udp-with-icmp-guid", "icmp-with-ports-guid", "all-with-ports-guid", "icmp-any-type-guid",
+				))
+
+				var icmpType, icmpCode int
+				err := realDb.QueryRow(`SELECT icmp_type, icmp_code FROM ip_range_normalizations WHERE terminal_guid = 'udp-with-icmp-guid'`).Scan(&icmpType, &icmpCode)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(icmpType).To(Equal(3))
+				Expect(icmpCode).To(Equal(1))
+
+				By("reverting the normalization")
+				numMigrations, err := migrator.PerformDownMigrations(realDb.DriverName(), realDb, "64")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(1))
+
+				Expect(queryIPRange("tcp-guid")).To(Equal([]int{8080, 8080, 0, 0}))
+				Expect(queryIPRange("udp-with-icmp-guid")).To(Equal([]int{53, 53, 3, 1}))
+				Expect(queryIPRange("icmp-with-ports-guid")).To(Equal([]int{80, 90, 8, 0}))
+				Expect(queryIPRange("all-with-ports-guid")).To(Equal([]int{80, 90, 0, 0}))
+				Expect(queryIPRange("icmp-any-type-guid")).To(Equal([]int{0, 0, -1, 4}))
+			})
+		})
+
+		Describe("V66 to V70 - Default source singleton", func() {
+			insertTerminal := func(guid string) {
+				_, err := realDb.Exec(realDb.RawConnection().Rebind(`INSERT INTO terminals (guid) VALUES (?)`), guid)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			insertDefaultSource := func(terminalGUID string) error {
+				_, err := realDb.Exec(realDb.RawConnection().Rebind(`INSERT INTO default_sources (terminal_guid) VALUES (?)`), terminalGUID)
+				return err
+			}
+
+			insertEgressPolicy := func(guid, sourceGUID, destinationGUID string) {
+				_, err := realDb.Exec(realDb.RawConnection().Rebind(`
+					INSERT INTO egress_policies (guid, source_guid, destination_guid) VALUES (?, ?, ?)
+				`), guid, sourceGUID, destinationGUID)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			It("merges the default sources into the first one", func() {
+				migrateTo("65")
+
+				for _, guid := range []string{"default-1", "default-2", "default-3", "destination-1", "destination-2", "destination-3"} {
+					insertTerminal(guid)
+				}
+				for _, guid := range []string{"default-1", "default-2", "default-3"} {
+					Expect(insertDefaultSource(guid)).To(Succeed())
+				}
+				insertEgressPolicy("policy-1", "default-1", "destination-1")
+				insertEgressPolicy("policy-2", "default-2", "destination-1")
+				insertEgressPolicy("policy-3", "default-2", "destination-2")
+				insertEgressPolicy("policy-4", "default-3", "destination-2")
+				insertEgressPolicy("policy-5", "default-3", "destination-3")
+
+				migrateTo("70")
+				Expect(queryTableColumnNames("default_sources", realDb)).To(ConsistOf("id", "terminal_guid", "singleton"))
+				Expect(queryTableForColumnValues("default_sources", "terminal_guid", realDb)).To(ConsistOf("default-1"))
+				Expect(queryTableForColumnValues("egress_policies", "guid", realDb)).To(ConsistOf("policy-1", "policy-3", "policy-5"))
+				Expect(queryTableForColumnValues("egress_policies", "source_guid", realDb)).To(ConsistOf("default-1", "default-1", "default-1"))
+			})
+
+			It("allows only one default source", func() {
+				migrateTo("70")
+
+				insertTerminal("default-1")
+				insertTerminal("default-2")
+				Expect(insertDefaultSource("default-1")).To(Succeed())
+				Expect(insertDefaultSource("default-2")).NotTo(Succeed())
+			})
+		})
+
 		Context("when migrating in parallel", func() {
 			Context("mysql", func() {
 				BeforeEach(func() {
@@ -1654,10 +1819,19 @@ var _ = Describe("migrations", func() {
 		})
 	})
 	Describe("Migrations should be atomic", func() {
+		// SQLite runs DDL in the transaction of the migration, and can only
+		// change most columns by rebuilding their table in several statements.
+		// Migrations 60 and 61 shipped with several statements, and an
+		// applied migration can not change.
+		shippedWithSeveralStatements := map[string]bool{"60": true, "61": true}
+
 		It("should contain a single statement per migration", func() {
 			for _, migration := range migrations.MigrationsToPerform {
+				if shippedWithSeveralStatements[migration.Id] {
+					continue
+				}
 				for dbType, statements := range migration.Up {
-					if len(statements) > 1 {
+					if dbType != "sqlite3" && len(statements) > 1 {
 						Fail(fmt.Sprintf("Migration %s for %s has %d statements. Expected a single statement per migration.",
 							migration.Id, dbType, len(statements)))
 					}
@@ -1665,6 +1839,241 @@ var _ = Describe("migrations", func() {
 
 			}
 		})
+
+		It("should contain a single statement per down migration", func() {
+			for _, migration := range migrations.MigrationsToPerform {
+				if shippedWithSeveralStatements[migration.Id] {
+					continue
+				}
+				for dbType, statements := range migration.Down {
+					if len(statements) > 1 {
+						Fail(fmt.Sprintf("Down migration %s for %s has %d statements. Expected a single statement per migration.",
+							migration.Id, dbType, len(statements)))
+					}
+				}
+			}
+		})
+	})
+
+	Describe("PerformDownMigrations", func() {
+		It("reverts the migrations after the target", func() {
+			migrateTo("56")
+
+			numMigrations, err := migrator.PerformDownMigrations(realDb.DriverName(), realDb, "55")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(1))
+
+			statuses, err := migrator.Status(realDb.DriverName(), realDb)
+			Expect(err).NotTo(HaveOccurred())
+			for _, status := range statuses {
+				if status.Id == "56" {
+					Expect(status.Applied).To(BeFalse())
+				}
+			}
+
+			By("re-applying the reverted migration")
+			migrateTo("56")
+		})
+	})
+})
+
+var _ = Describe("Migrator", func() {
+	var (
+		mockDb                 *fakes.Db
+		mockMigrateAdapter     *migrationsFakes.MigrateAdapter
+		mockMigrationsProvider *migrationsFakes.MigrationsProvider
+		migrator               *migrations.Migrator
+		appliedAt              time.Time
+	)
+
+	BeforeEach(func() {
+		mockDb = &fakes.Db{}
+		mockMigrateAdapter = &migrationsFakes.MigrateAdapter{}
+		mockMigrationsProvider = &migrationsFakes.MigrationsProvider{}
+		migrator = &migrations.Migrator{
+			MigrateAdapter:     mockMigrateAdapter,
+			MigrationsProvider: mockMigrationsProvider,
+		}
+
+		mockMigrationsProvider.MigrationsToPerformReturns(migrations.PolicyServerMigrations{
+			{
+				Id:   "1",
+				Up:   map[string][]string{"mysql": {"create a"}, "postgres": {"create a"}},
+				Down: map[string][]string{"mysql": {"drop a"}, "postgres": {"drop a"}},
+			},
+			{
+				Id:   "2",
+				Up:   map[string][]string{"mysql": {"create b"}, "postgres": {"create b"}},
+				Down: map[string][]string{"mysql": {"drop b"}},
+			},
+			{
+				Id:   "3",
+				Up:   map[string][]string{"mysql": {"create c"}, "postgres": {"create c"}},
+				Down: map[string][]string{"mysql": {"drop c"}, "postgres": {"drop c"}},
+			},
+		}, nil)
+
+		appliedAt = time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockMigrateAdapter.GetMigrationRecordsReturns([]*migrate.MigrationRecord{
+			{Id: "1", AppliedAt: appliedAt},
+			{Id: "2", AppliedAt: appliedAt},
+		}, nil)
+	})
+
+	Describe("Status", func() {
+		It("lists every migration with whether it has been applied", func() {
+			statuses, err := migrator.Status("postgres", mockDb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(Equal([]migrations.MigrationStatus{
+				{Id: "1", Applied: true, AppliedAt: appliedAt, Reversible: true},
+				{Id: "2", Applied: true, AppliedAt: appliedAt, Reversible: false},
+				{Id: "3", Applied: false, Reversible: true},
+			}))
+
+			db, driverName := mockMigrateAdapter.GetMigrationRecordsArgsForCall(0)
+			Expect(db).To(Equal(mockDb))
+			Expect(driverName).To(Equal("postgres"))
+		})
+
+		Context("when getting the migration records fails", func() {
+			It("returns a meaningful error message", func() {
+				mockMigrateAdapter.GetMigrationRecordsReturns(nil, errors.New("potato"))
+				_, err := migrator.Status("postgres", mockDb)
+				Expect(err).To(MatchError("error retrieving migration records: potato"))
+			})
+		})
+
+		Context("when getting migrations to perform fails", func() {
+			It("returns a meaningful error message", func() {
+				mockMigrationsProvider.MigrationsToPerformReturns(nil, errors.New("mark mark mark"))
+				_, err := migrator.Status("postgres", mockDb)
+				Expect(err).To(MatchError("error retrieving migrations to perform: mark mark mark"))
+			})
+		})
+	})
+
+	Describe("PendingMigrations", func() {
+		It("returns the statements of unapplied migrations for the driver", func() {
+			pending, err := migrator.PendingMigrations("postgres", mockDb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(Equal([]*migrate.Migration{
+				{Id: "3", Up: []string{"create c"}, Down: []string{"drop c"}},
+			}))
+			Expect(mockMigrateAdapter.ExecMaxCallCount()).To(Equal(0))
+		})
+
+		Context("when the driver is not supported", func() {
+			It("returns an error", func() {
+				_, err := migrator.PendingMigrations("etcd", mockDb)
+				Expect(err).To(MatchError("unsupported driver: etcd"))
+			})
+		})
+	})
+
+	Describe("DownMigrations", func() {
+		It("returns the applied migrations after the target, newest first", func() {
+			down, err := migrator.DownMigrations("mysql", mockDb, "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(down).To(Equal([]*migrate.Migration{
+				{Id: "2", Up: []string{"create b"}, Down: []string{"drop b"}},
+			}))
+		})
+
+		Context("when the target is the latest applied migration", func() {
+			It("returns nothing", func() {
+				down, err := migrator.DownMigrations("mysql", mockDb, "2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(down).To(BeEmpty())
+			})
+		})
+
+		Context("when a migration after the target has no reverse for the driver", func() {
+			It("returns an error", func() {
+				_, err := migrator.DownMigrations("postgres", mockDb, "1")
+				Expect(err).To(MatchError("migration 2 cannot be reverted on postgres"))
+			})
+		})
+
+		Context("when the target is unknown", func() {
+			It("returns an error", func() {
+				_, err := migrator.DownMigrations("mysql", mockDb, "42")
+				Expect(err).To(MatchError("unknown target migration: 42"))
+			})
+		})
+
+		Context("when the target has not been applied", func() {
+			It("returns an error", func() {
+				_, err := migrator.DownMigrations("mysql", mockDb, "3")
+				Expect(err).To(MatchError("target migration 3 has not been applied"))
+			})
+		})
+	})
+
+	Describe("ForDriver", func() {
+		It("returns the statements of the migrations for the driver", func() {
+			down, err := migrator.DownMigrations("mysql", mockDb, "1")
+			Expect(err).NotTo(HaveOccurred())
+
+			forPostgres, err := migrator.ForDriver(down, "postgres")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(forPostgres).To(Equal([]*migrate.Migration{
+				{Id: "2", Up: []string{"create b"}},
+			}))
+		})
+
+		Context("when a migration has no statements for the driver", func() {
+			It("returns an error", func() {
+				pending, err := migrator.PendingMigrations("mysql", mockDb)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = migrator.ForDriver(pending, "sqlite3")
+				Expect(err).To(MatchError("migration 3 has no statements for sqlite3"))
+			})
+		})
+	})
+
+	Describe("PerformDownMigrations", func() {
+		It("reverts the migrations after the target", func() {
+			mockMigrateAdapter.ExecDownMaxReturns(1, nil)
+
+			numMigrations, err := migrator.PerformDownMigrations("mysql", mockDb, "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(1))
+
+			Expect(mockMigrateAdapter.ExecDownMaxCallCount()).To(Equal(1))
+			db, driverName, source, max := mockMigrateAdapter.ExecDownMaxArgsForCall(0)
+			Expect(db).To(Equal(mockDb))
+			Expect(driverName).To(Equal("mysql"))
+			Expect(max).To(Equal(1))
+			ms, err := source.FindMigrations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ms).To(HaveLen(3))
+		})
+
+		Context("when there is nothing to revert", func() {
+			It("does not call the adapter", func() {
+				numMigrations, err := migrator.PerformDownMigrations("mysql", mockDb, "2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(0))
+				Expect(mockMigrateAdapter.ExecDownMaxCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a migration cannot be reverted", func() {
+			It("does not call the adapter", func() {
+				_, err := migrator.PerformDownMigrations("postgres", mockDb, "1")
+				Expect(err).To(MatchError("migration 2 cannot be reverted on postgres"))
+				Expect(mockMigrateAdapter.ExecDownMaxCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the down migration fails", func() {
+			It("returns an error", func() {
+				mockMigrateAdapter.ExecDownMaxReturns(0, errors.New("banana"))
+				_, err := migrator.PerformDownMigrations("mysql", mockDb, "1")
+				Expect(err).To(MatchError("executing down migration: banana"))
+			})
+		})
 	})
 })
 
@@ -62,6 +62,13 @@ var migration_modified_v0001 = map[string][]string{
 		UNIQUE (guid)
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS groups (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guid text,
+		UNIQUE (guid)
+	);`,
+	},
 }
 
 var migration_modified_v0001a = map[string][]string{
@@ -84,6 +91,15 @@ var migration_modified_v0001a = map[string][]string{
 		UNIQUE (group_id, port, protocol)
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS destinations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		group_id int REFERENCES groups(id),
+		port int,
+		protocol text
+	);`,
+		`CREATE UNIQUE INDEX destinations_group_id_port_protocol_unique ON destinations (group_id, port, protocol);`,
+	},
 }
 
 var migration_modified_v0001b = map[string][]string{
@@ -104,4 +120,12 @@ var migration_modified_v0001b = map[string][]string{
 		UNIQUE (group_id, destination_id)
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS policies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		group_id int REFERENCES groups(id),
+		destination_id int REFERENCES destinations(id),
+		UNIQUE (group_id, destination_id)
+	);`,
+	},
 }
@@ -52,6 +52,9 @@ var migration_modified_v0002 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE destinations ADD COLUMN start_port int;`,
 	},
+	"sqlite3": {
+		`ALTER TABLE destinations ADD COLUMN start_port int;`,
+	},
 }
 
 var migration_modified_v0002a = map[string][]string{
@@ -61,6 +64,9 @@ var migration_modified_v0002a = map[string][]string{
 	"postgres": {
 		`ALTER TABLE destinations ADD COLUMN end_port int;`,
 	},
+	"sqlite3": {
+		`ALTER TABLE destinations ADD COLUMN end_port int;`,
+	},
 }
 
 var migration_modified_v0002b = map[string][]string{
@@ -70,6 +76,9 @@ var migration_modified_v0002b = map[string][]string{
 	"postgres": {
 		`UPDATE destinations SET start_port = port;`,
 	},
+	"sqlite3": {
+		`UPDATE destinations SET start_port = port;`,
+	},
 }
 
 var migration_modified_v0002c = map[string][]string{
@@ -79,6 +88,9 @@ var migration_modified_v0002c = map[string][]string{
 	"postgres": {
 		`UPDATE destinations SET end_port = port;`,
 	},
+	"sqlite3": {
+		`UPDATE destinations SET end_port = port;`,
+	},
 }
 
 var migration_modified_v0002d = map[string][]string{
@@ -113,6 +125,9 @@ END;`,
 		 	END$$;
 	`,
 	},
+	"sqlite3": {
+		`DROP INDEX destinations_group_id_port_protocol_unique;`,
+	},
 }
 
 var migration_modified_v0002e = map[string][]string{
@@ -120,6 +135,7 @@ var migration_modified_v0002e = map[string][]string{
 		`CALL drop_destination_index();`,
 	},
 	"postgres": {},
+	"sqlite3":  {},
 }
 
 var migration_modified_v0002f = map[string][]string{
@@ -129,4 +145,7 @@ var migration_modified_v0002f = map[string][]string{
 	"postgres": {
 		`ALTER TABLE destinations ADD CONSTRAINT unique_destination UNIQUE (group_id, start_port, end_port, protocol);`,
 	},
+	"sqlite3": {
+		`CREATE UNIQUE INDEX unique_destination ON destinations (group_id, start_port, end_port, protocol);`,
+	},
 }
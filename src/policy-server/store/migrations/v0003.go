@@ -20,6 +20,9 @@ var migration_modified_v0003 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE groups ADD COLUMN type text DEFAULT 'app'`,
 	},
+	"sqlite3": {
+		`ALTER TABLE groups ADD COLUMN type text DEFAULT 'app'`,
+	},
 }
 
 var migration_modified_v0003a = map[string][]string{
@@ -30,4 +33,7 @@ var migration_modified_v0003a = map[string][]string{
 	"postgres": {
 		`CREATE INDEX idx_type ON groups (type)`,
 	},
+	"sqlite3": {
+		`CREATE INDEX idx_type ON groups (type)`,
+	},
 }
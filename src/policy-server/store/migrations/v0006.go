@@ -25,4 +25,14 @@ var migration_v0006 = map[string][]string{
         FOREIGN KEY (terminal_id) references terminals(id)
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS ip_ranges (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		protocol text,
+		start_ip text,
+		end_ip text,
+		terminal_id int,
+		FOREIGN KEY (terminal_id) references terminals(id)
+	);`,
+	},
 }
@@ -23,4 +23,12 @@ var migration_v0007 = map[string][]string{
 		app_guid text CONSTRAINT apps_app_guid_unique UNIQUE
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS apps (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		terminal_id int,
+		app_guid text CONSTRAINT apps_app_guid_unique UNIQUE,
+		FOREIGN KEY (terminal_id) references terminals(id)
+	);`,
+	},
 }
@@ -5,4 +5,7 @@ var migration_v0008 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX source_terminal_id_idx ON egress_policies (source_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX source_terminal_id_idx ON egress_policies (source_id);`,
+	},
 }
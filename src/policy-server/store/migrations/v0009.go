@@ -5,4 +5,7 @@ var migration_v0009 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX destination_terminal_id_idx ON egress_policies (destination_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX destination_terminal_id_idx ON egress_policies (destination_id);`,
+	},
 }
@@ -5,4 +5,7 @@ var migration_v0010 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX ip_range_terminal_id_idx ON ip_ranges (terminal_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX ip_range_terminal_id_idx ON ip_ranges (terminal_id);`,
+	},
 }
@@ -5,4 +5,7 @@ var migration_v0011 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX app_terminal_id_idx ON apps (terminal_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX app_terminal_id_idx ON apps (terminal_id);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0012 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE ip_ranges ADD COLUMN start_port int;`,
 	},
+	"sqlite3": {
+		`ALTER TABLE ip_ranges ADD COLUMN start_port int;`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0013 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE ip_ranges ADD COLUMN end_port int;`,
 	},
+	"sqlite3": {
+		`ALTER TABLE ip_ranges ADD COLUMN end_port int;`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0014 = map[string][]string{
 	"postgres": {
 		`UPDATE ip_ranges SET start_port = 0;`,
 	},
+	"sqlite3": {
+		`UPDATE ip_ranges SET start_port = 0;`,
+	},
 }
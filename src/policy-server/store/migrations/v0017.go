@@ -7,4 +7,7 @@ var migration_v0017 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE ip_ranges ADD COLUMN icmp_code INT DEFAULT 0;`,
 	},
+	"sqlite3": {
+		`ALTER TABLE ip_ranges ADD COLUMN icmp_code INT DEFAULT 0;`,
+	},
 }
@@ -23,4 +23,12 @@ var migration_v0018 = map[string][]string{
 		space_guid text CONSTRAINT spaces_space_guid_unique UNIQUE
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS spaces (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		terminal_id int,
+		space_guid text CONSTRAINT spaces_space_guid_unique UNIQUE,
+		FOREIGN KEY (terminal_id) references terminals(id)
+	);`,
+	},
 }
@@ -25,4 +25,13 @@ var migration_v0019 = map[string][]string{
 		description text
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS destination_metadatas (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		terminal_id int,
+		name text CONSTRAINT metadata_name_unique UNIQUE,
+		description text,
+		FOREIGN KEY (terminal_id) references terminals(id)
+	);`,
+	},
 }
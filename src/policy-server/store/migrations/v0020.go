@@ -5,4 +5,7 @@ var migration_v0020 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX metadata_terminal_id_idx ON destination_metadatas (terminal_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX metadata_terminal_id_idx ON destination_metadatas (terminal_id);`,
+	},
 }
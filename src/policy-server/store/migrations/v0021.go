@@ -5,4 +5,7 @@ var migration_v0021 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX metadata_name_idx ON destination_metadatas (name);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX metadata_name_idx ON destination_metadatas (name);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0022 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE terminals ADD COLUMN guid VARCHAR(36);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE terminals ADD COLUMN guid VARCHAR(36);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0023 = map[string][]string{
 	"postgres": {
 		`UPDATE terminals SET guid = id;`,
 	},
+	"sqlite3": {
+		`UPDATE terminals SET guid = id;`,
+	},
 }
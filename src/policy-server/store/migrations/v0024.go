@@ -8,4 +8,7 @@ var migration_v0024 = map[string][]string{
 		`ALTER TABLE terminals ADD CONSTRAINT terminals_guid_unique UNIQUE (guid),
 		 ALTER COLUMN guid SET NOT NULL;`,
 	},
+	"sqlite3": {
+		`CREATE UNIQUE INDEX terminals_guid_unique ON terminals (guid);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0025 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE apps ADD COLUMN terminal_guid VARCHAR(36);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE apps ADD COLUMN terminal_guid VARCHAR(36);`,
+	},
 }
@@ -9,4 +9,8 @@ var migration_v0026 = map[string][]string{
 		`UPDATE apps
 		 SET terminal_guid = terminal_id;`,
 	},
+	"sqlite3": {
+		`UPDATE apps
+		 SET terminal_guid = terminal_id;`,
+	},
 }
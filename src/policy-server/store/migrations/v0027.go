@@ -16,4 +16,16 @@ var migration_v0027 = map[string][]string{
 		 ALTER COLUMN terminal_guid SET NOT NULL,
 		 DROP terminal_id;`,
 	},
+	"sqlite3": {
+		`CREATE TABLE apps_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		app_guid text CONSTRAINT apps_app_guid_unique UNIQUE,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT apps_terminal_guid_unique UNIQUE,
+		CONSTRAINT apps_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+		`INSERT INTO apps_new (id, app_guid, terminal_guid)
+		 SELECT id, app_guid, terminal_guid FROM apps;`,
+		`DROP TABLE apps;`,
+		`ALTER TABLE apps_new RENAME TO apps;`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0028 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE spaces ADD COLUMN terminal_guid VARCHAR(36);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE spaces ADD COLUMN terminal_guid VARCHAR(36);`,
+	},
 }
@@ -9,4 +9,8 @@ var migration_v0029 = map[string][]string{
 		`UPDATE spaces
 		 SET terminal_guid = terminal_id;`,
 	},
+	"sqlite3": {
+		`UPDATE spaces
+		 SET terminal_guid = terminal_id;`,
+	},
 }
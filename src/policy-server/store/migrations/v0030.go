@@ -16,4 +16,16 @@ var migration_v0030 = map[string][]string{
 		 ALTER COLUMN terminal_guid SET NOT NULL,
 		 DROP terminal_id;`,
 	},
+	"sqlite3": {
+		`CREATE TABLE spaces_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		space_guid text CONSTRAINT spaces_space_guid_unique UNIQUE,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT spaces_terminal_guid_unique UNIQUE,
+		CONSTRAINT spaces_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+		`INSERT INTO spaces_new (id, space_guid, terminal_guid)
+		 SELECT id, space_guid, terminal_guid FROM spaces;`,
+		`DROP TABLE spaces;`,
+		`ALTER TABLE spaces_new RENAME TO spaces;`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0031 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE ip_ranges ADD COLUMN terminal_guid VARCHAR(36);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE ip_ranges ADD COLUMN terminal_guid VARCHAR(36);`,
+	},
 }
@@ -9,4 +9,8 @@ var migration_v0032 = map[string][]string{
 		`UPDATE ip_ranges
 		 SET terminal_guid = terminal_id;`,
 	},
+	"sqlite3": {
+		`UPDATE ip_ranges
+		 SET terminal_guid = terminal_id;`,
+	},
 }
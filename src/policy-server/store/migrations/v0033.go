@@ -16,4 +16,22 @@ var migration_v0033 = map[string][]string{
 		 ALTER COLUMN terminal_guid SET NOT NULL,
 		 DROP terminal_id;`,
 	},
+	"sqlite3": {
+		`CREATE TABLE ip_ranges_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		protocol text,
+		start_ip text,
+		end_ip text,
+		start_port int,
+		end_port int,
+		icmp_type INT DEFAULT 0,
+		icmp_code INT DEFAULT 0,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT ip_ranges_terminal_guid_unique UNIQUE,
+		CONSTRAINT ip_ranges_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+		`INSERT INTO ip_ranges_new (id, protocol, start_ip, end_ip, start_port, end_port, icmp_type, icmp_code, terminal_guid)
+		 SELECT id, protocol, start_ip, end_ip, start_port, end_port, icmp_type, icmp_code, terminal_guid FROM ip_ranges;`,
+		`DROP TABLE ip_ranges;`,
+		`ALTER TABLE ip_ranges_new RENAME TO ip_ranges;`,
+	},
 }
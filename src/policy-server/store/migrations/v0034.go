@@ -7,4 +7,7 @@ var migration_v0034 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE destination_metadatas ADD COLUMN terminal_guid VARCHAR(36);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE destination_metadatas ADD COLUMN terminal_guid VARCHAR(36);`,
+	},
 }
@@ -9,4 +9,8 @@ var migration_v0035 = map[string][]string{
 		`UPDATE destination_metadatas
 		 SET terminal_guid = terminal_id;`,
 	},
+	"sqlite3": {
+		`UPDATE destination_metadatas
+		 SET terminal_guid = terminal_id;`,
+	},
 }
@@ -16,4 +16,18 @@ var migration_v0036 = map[string][]string{
 		 ALTER COLUMN terminal_guid SET NOT NULL,
 		 DROP terminal_id;`,
 	},
+	"sqlite3": {
+		`CREATE TABLE destination_metadatas_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name text CONSTRAINT metadata_name_unique UNIQUE,
+		description text,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT destination_metadatas_terminal_guid_unique UNIQUE,
+		CONSTRAINT destination_metadatas_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+		`INSERT INTO destination_metadatas_new (id, name, description, terminal_guid)
+		 SELECT id, name, description, terminal_guid FROM destination_metadatas;`,
+		`DROP TABLE destination_metadatas;`,
+		`ALTER TABLE destination_metadatas_new RENAME TO destination_metadatas;`,
+		`CREATE INDEX metadata_name_idx ON destination_metadatas (name);`,
+	},
 }
@@ -9,4 +9,8 @@ var migration_v0038 = map[string][]string{
 		`UPDATE egress_policies
 		 SET source_guid = source_id;`,
 	},
+	"sqlite3": {
+		`UPDATE egress_policies
+		 SET source_guid = source_id;`,
+	},
 }
@@ -15,4 +15,18 @@ var migration_v0039 = map[string][]string{
 		 ALTER COLUMN source_guid SET NOT NULL,
 		 DROP source_id;`,
 	},
+	"sqlite3": {
+		`CREATE TABLE egress_policies_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source_guid VARCHAR(36) NOT NULL,
+		destination_id int,
+		CONSTRAINT egress_policies_source_guid_fk FOREIGN KEY (source_guid) REFERENCES terminals(guid),
+		FOREIGN KEY (destination_id) references terminals(id)
+	);`,
+		`INSERT INTO egress_policies_new (id, source_guid, destination_id)
+		 SELECT id, source_guid, destination_id FROM egress_policies;`,
+		`DROP TABLE egress_policies;`,
+		`ALTER TABLE egress_policies_new RENAME TO egress_policies;`,
+		`CREATE INDEX destination_terminal_id_idx ON egress_policies (destination_id);`,
+	},
 }
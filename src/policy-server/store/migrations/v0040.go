@@ -7,4 +7,7 @@ var migration_v0040 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE egress_policies ADD COLUMN destination_guid VARCHAR(36);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE egress_policies ADD COLUMN destination_guid VARCHAR(36);`,
+	},
 }
@@ -9,4 +9,8 @@ var migration_v0041 = map[string][]string{
 		`UPDATE egress_policies
 		 SET destination_guid = destination_id;`,
 	},
+	"sqlite3": {
+		`UPDATE egress_policies
+		 SET destination_guid = destination_id;`,
+	},
 }
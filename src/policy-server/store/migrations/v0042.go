@@ -15,4 +15,17 @@ var migration_v0042 = map[string][]string{
 		 ALTER COLUMN destination_guid SET NOT NULL,
 		 DROP destination_id;`,
 	},
+	"sqlite3": {
+		`CREATE TABLE egress_policies_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source_guid VARCHAR(36) NOT NULL,
+		destination_guid VARCHAR(36) NOT NULL,
+		CONSTRAINT egress_policies_source_guid_fk FOREIGN KEY (source_guid) REFERENCES terminals(guid),
+		CONSTRAINT egress_policies_destination_guid_fk FOREIGN KEY (destination_guid) REFERENCES terminals(guid)
+	);`,
+		`INSERT INTO egress_policies_new (id, source_guid, destination_guid)
+		 SELECT id, source_guid, destination_guid FROM egress_policies;`,
+		`DROP TABLE egress_policies;`,
+		`ALTER TABLE egress_policies_new RENAME TO egress_policies;`,
+	},
 }
@@ -12,4 +12,8 @@ var migration_v0043 = map[string][]string{
 		 DROP id,
 		 ADD PRIMARY KEY (guid);`,
 	},
+	// SQLite keeps the id of terminals: rebuilding the table would drop it
+	// from under the foreign keys that reference it, and guid is already
+	// unique since v0024.
+	"sqlite3": {},
 }
@@ -6,4 +6,5 @@ var migration_v0044 = map[string][]string{
 		 DROP id;`,
 	},
 	"postgres": {},
+	"sqlite3":  {},
 }
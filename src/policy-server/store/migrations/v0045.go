@@ -7,4 +7,7 @@ var migration_v0045 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX apps_terminal_guid_idx ON apps (terminal_guid);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX apps_terminal_guid_idx ON apps (terminal_guid);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0046 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX spaces_terminal_guid_idx ON spaces (terminal_guid);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX spaces_terminal_guid_idx ON spaces (terminal_guid);`,
+	},
 }
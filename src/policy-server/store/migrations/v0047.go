@@ -7,4 +7,7 @@ var migration_v0047 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX ip_ranges_terminal_guid_idx ON ip_ranges (terminal_guid);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX ip_ranges_terminal_guid_idx ON ip_ranges (terminal_guid);`,
+	},
 }
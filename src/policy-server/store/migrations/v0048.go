@@ -7,4 +7,7 @@ var migration_v0048 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX destination_metadatas_terminal_guid_idx ON destination_metadatas (terminal_guid);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX destination_metadatas_terminal_guid_idx ON destination_metadatas (terminal_guid);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0049 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX egress_policies_source_guid_idx ON egress_policies (source_guid);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX egress_policies_source_guid_idx ON egress_policies (source_guid);`,
+	},
 }
@@ -7,4 +7,7 @@ var migration_v0050 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX egress_policies_destination_guid_idx ON egress_policies (destination_guid);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX egress_policies_destination_guid_idx ON egress_policies (destination_guid);`,
+	},
 }
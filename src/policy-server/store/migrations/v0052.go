@@ -7,4 +7,7 @@ var migration_v0052 = map[string][]string{
 	"postgres": {
 		`UPDATE egress_policies SET guid = id;`,
 	},
+	"sqlite3": {
+		`UPDATE egress_policies SET guid = id;`,
+	},
 }
@@ -8,4 +8,7 @@ var migration_v0053 = map[string][]string{
 		`ALTER TABLE egress_policies ADD CONSTRAINT egress_policies_guid_unique UNIQUE (guid),
 		 ALTER COLUMN guid SET NOT NULL;`,
 	},
+	"sqlite3": {
+		`CREATE UNIQUE INDEX egress_policies_guid_unique ON egress_policies (guid);`,
+	},
 }
@@ -12,4 +12,19 @@ var migration_v0054 = map[string][]string{
 		 DROP id,
 		 ADD PRIMARY KEY (guid);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE egress_policies_new (
+		guid VARCHAR(36) NOT NULL PRIMARY KEY,
+		source_guid VARCHAR(36) NOT NULL,
+		destination_guid VARCHAR(36) NOT NULL,
+		CONSTRAINT egress_policies_source_guid_fk FOREIGN KEY (source_guid) REFERENCES terminals(guid),
+		CONSTRAINT egress_policies_destination_guid_fk FOREIGN KEY (destination_guid) REFERENCES terminals(guid)
+	);`,
+		`INSERT INTO egress_policies_new (guid, source_guid, destination_guid)
+		 SELECT guid, source_guid, destination_guid FROM egress_policies;`,
+		`DROP TABLE egress_policies;`,
+		`ALTER TABLE egress_policies_new RENAME TO egress_policies;`,
+		`CREATE INDEX egress_policies_source_guid_idx ON egress_policies (source_guid);`,
+		`CREATE INDEX egress_policies_destination_guid_idx ON egress_policies (destination_guid);`,
+	},
 }
@@ -7,4 +7,19 @@ var migration_v0056 = map[string][]string{
 	"postgres": {
 		`ALTER TABLE egress_policies ADD CONSTRAINT egress_policies_source_guid_destination_guid_unique UNIQUE (source_guid, destination_guid)`,
 	},
+	"sqlite3": {
+		`CREATE UNIQUE INDEX egress_policies_source_guid_destination_guid_unique ON egress_policies (source_guid, destination_guid)`,
+	},
+}
+
+var migration_v0056_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies DROP INDEX egress_policies_source_guid_destination_guid_unique`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies DROP CONSTRAINT egress_policies_source_guid_destination_guid_unique`,
+	},
+	"sqlite3": {
+		`DROP INDEX egress_policies_source_guid_destination_guid_unique`,
+	},
 }
@@ -0,0 +1,25 @@
+package migrations
+
+var migration_v0057 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE groups ADD COLUMN orphaned_at TIMESTAMP NULL DEFAULT NULL`,
+	},
+	"postgres": {
+		`ALTER TABLE groups ADD COLUMN orphaned_at TIMESTAMP`,
+	},
+	"sqlite3": {
+		`ALTER TABLE groups ADD COLUMN orphaned_at TIMESTAMP`,
+	},
+}
+
+var migration_v0057_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE groups DROP COLUMN orphaned_at`,
+	},
+	"postgres": {
+		`ALTER TABLE groups DROP COLUMN orphaned_at`,
+	},
+	"sqlite3": {
+		`ALTER TABLE groups DROP COLUMN orphaned_at`,
+	},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+var migration_v0058 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE policies ADD COLUMN log BOOLEAN NOT NULL DEFAULT false`,
+	},
+	"postgres": {
+		`ALTER TABLE policies ADD COLUMN log BOOLEAN NOT NULL DEFAULT false`,
+	},
+	"sqlite3": {
+		`ALTER TABLE policies ADD COLUMN log BOOLEAN NOT NULL DEFAULT false`,
+	},
+}
+
+var migration_v0058_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE policies DROP COLUMN log`,
+	},
+	"postgres": {
+		`ALTER TABLE policies DROP COLUMN log`,
+	},
+	"sqlite3": {
+		`ALTER TABLE policies DROP COLUMN log`,
+	},
+}
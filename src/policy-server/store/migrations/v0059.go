@@ -0,0 +1,25 @@
+package migrations
+
+var migration_v0059 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies ADD COLUMN log BOOLEAN NOT NULL DEFAULT false`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies ADD COLUMN log BOOLEAN NOT NULL DEFAULT false`,
+	},
+	"sqlite3": {
+		`ALTER TABLE egress_policies ADD COLUMN log BOOLEAN NOT NULL DEFAULT false`,
+	},
+}
+
+var migration_v0059_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies DROP COLUMN log`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies DROP COLUMN log`,
+	},
+	"sqlite3": {
+		`ALTER TABLE egress_policies DROP COLUMN log`,
+	},
+}
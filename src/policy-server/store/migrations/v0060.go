@@ -0,0 +1,39 @@
+package migrations
+
+// The keys of ip ranges that already exist are set by the migrate-db job,
+// since computing them needs more than SQL.
+var migration_v0060 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE ip_ranges ADD COLUMN start_ip_key varchar(32)`,
+		`ALTER TABLE ip_ranges ADD COLUMN end_ip_key varchar(32)`,
+		`CREATE INDEX ip_ranges_key_idx ON ip_ranges (start_ip_key, end_ip_key)`,
+	},
+	"postgres": {
+		`ALTER TABLE ip_ranges ADD COLUMN start_ip_key varchar(32)`,
+		`ALTER TABLE ip_ranges ADD COLUMN end_ip_key varchar(32)`,
+		`CREATE INDEX ip_ranges_key_idx ON ip_ranges (start_ip_key, end_ip_key)`,
+	},
+	"sqlite3": {
+		`ALTER TABLE ip_ranges ADD COLUMN start_ip_key varchar(32)`,
+		`ALTER TABLE ip_ranges ADD COLUMN end_ip_key varchar(32)`,
+		`CREATE INDEX ip_ranges_key_idx ON ip_ranges (start_ip_key, end_ip_key)`,
+	},
+}
+
+var migration_v0060_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE ip_ranges DROP INDEX ip_ranges_key_idx`,
+		`ALTER TABLE ip_ranges DROP COLUMN end_ip_key`,
+		`ALTER TABLE ip_ranges DROP COLUMN start_ip_key`,
+	},
+	"postgres": {
+		`DROP INDEX ip_ranges_key_idx`,
+		`ALTER TABLE ip_ranges DROP COLUMN end_ip_key`,
+		`ALTER TABLE ip_ranges DROP COLUMN start_ip_key`,
+	},
+	"sqlite3": {
+		`DROP INDEX ip_ranges_key_idx`,
+		`ALTER TABLE ip_ranges DROP COLUMN end_ip_key`,
+		`ALTER TABLE ip_ranges DROP COLUMN start_ip_key`,
+	},
+}
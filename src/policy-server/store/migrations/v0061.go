@@ -0,0 +1,65 @@
+package migrations
+
+// Egress policies can have an org, or every app (the default source), as
+// their source. There is at most one default source, which all default
+// egress policies share.
+var migration_v0061 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS orgs (
+		id int NOT NULL AUTO_INCREMENT,
+		PRIMARY KEY (id),
+		org_guid varchar(255),
+		UNIQUE(org_guid),
+		terminal_guid VARCHAR(36) NOT NULL UNIQUE,
+		CONSTRAINT orgs_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid),
+		INDEX orgs_terminal_guid_idx (terminal_guid)
+	);`,
+		`CREATE TABLE IF NOT EXISTS default_sources (
+		id int NOT NULL AUTO_INCREMENT,
+		PRIMARY KEY (id),
+		terminal_guid VARCHAR(36) NOT NULL UNIQUE,
+		CONSTRAINT default_sources_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS orgs (
+		id SERIAL PRIMARY KEY,
+		org_guid text CONSTRAINT orgs_org_guid_unique UNIQUE,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT orgs_terminal_guid_unique UNIQUE,
+		CONSTRAINT orgs_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+		`CREATE TABLE IF NOT EXISTS default_sources (
+		id SERIAL PRIMARY KEY,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT default_sources_terminal_guid_unique UNIQUE,
+		CONSTRAINT default_sources_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS orgs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		org_guid text CONSTRAINT orgs_org_guid_unique UNIQUE,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT orgs_terminal_guid_unique UNIQUE,
+		CONSTRAINT orgs_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+		`CREATE TABLE IF NOT EXISTS default_sources (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT default_sources_terminal_guid_unique UNIQUE,
+		CONSTRAINT default_sources_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+	},
+}
+
+var migration_v0061_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE default_sources`,
+		`DROP TABLE orgs`,
+	},
+	"postgres": {
+		`DROP TABLE default_sources`,
+		`DROP TABLE orgs`,
+	},
+	"sqlite3": {
+		`DROP TABLE default_sources`,
+		`DROP TABLE orgs`,
+	},
+}
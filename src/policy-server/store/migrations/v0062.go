@@ -0,0 +1,25 @@
+package migrations
+
+var migration_v0062 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies ADD COLUMN app_lifecycle VARCHAR(16) NOT NULL DEFAULT 'all'`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies ADD COLUMN app_lifecycle VARCHAR(16) NOT NULL DEFAULT 'all'`,
+	},
+	"sqlite3": {
+		`ALTER TABLE egress_policies ADD COLUMN app_lifecycle VARCHAR(16) NOT NULL DEFAULT 'all'`,
+	},
+}
+
+var migration_v0062_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies DROP COLUMN app_lifecycle`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies DROP COLUMN app_lifecycle`,
+	},
+	"sqlite3": {
+		`ALTER TABLE egress_policies DROP COLUMN app_lifecycle`,
+	},
+}
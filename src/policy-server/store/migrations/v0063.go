@@ -0,0 +1,58 @@
+package migrations
+
+// ip_range_normalizations reports the ip ranges that migration 65 changes to
+// fit the shape of their protocol, with the values they had before.
+var migration_v0063 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS ip_range_normalizations (
+		id int NOT NULL AUTO_INCREMENT,
+		PRIMARY KEY (id),
+		ip_range_id int NOT NULL,
+		terminal_guid VARCHAR(36),
+		protocol varchar(255),
+		start_port int,
+		end_port int,
+		icmp_type int,
+		icmp_code int,
+		reason varchar(255) NOT NULL
+	);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS ip_range_normalizations (
+		id SERIAL PRIMARY KEY,
+		ip_range_id int NOT NULL,
+		terminal_guid VARCHAR(36),
+		protocol text,
+		start_port int,
+		end_port int,
+		icmp_type int,
+		icmp_code int,
+		reason varchar(255) NOT NULL
+	);`,
+	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS ip_range_normalizations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		ip_range_id int NOT NULL,
+		terminal_guid VARCHAR(36),
+		protocol text,
+		start_port int,
+		end_port int,
+		icmp_type int,
+		icmp_code int,
+		reason varchar(255) NOT NULL
+	);`,
+	},
+}
+
+var migration_v0063_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE ip_range_normalizations`,
+	},
+	"postgres": {
+		`DROP TABLE ip_range_normalizations`,
+	},
+	"sqlite3": {
+		`DROP TABLE ip_range_normalizations`,
+	},
+}
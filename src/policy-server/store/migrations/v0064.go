@@ -0,0 +1,40 @@
+package migrations
+
+const reportIPRangeNormalizations = `INSERT INTO ip_range_normalizations (ip_range_id, terminal_guid, protocol, start_port, end_port, icmp_type, icmp_code, reason)
+		SELECT id, terminal_guid, protocol, start_port, end_port, icmp_type, icmp_code, 'icmp type and code removed from non-icmp protocol'
+		FROM ip_ranges
+		WHERE protocol <> 'icmp' AND (icmp_type <> 0 OR icmp_code <> 0)
+		UNION ALL
+		SELECT id, terminal_guid, protocol, start_port, end_port, icmp_type, icmp_code, 'ports removed from icmp or all protocol'
+		FROM ip_ranges
+		WHERE protocol IN ('icmp', 'all') AND (start_port <> 0 OR end_port <> 0)
+		UNION ALL
+		SELECT id, terminal_guid, protocol, start_port, end_port, icmp_type, icmp_code, 'icmp code widened to every code of every icmp type'
+		FROM ip_ranges
+		WHERE protocol = 'icmp' AND icmp_type = -1 AND icmp_code <> -1`
+
+// Each ip range that does not fit the shape of its protocol is reported once
+// per reason, before migration 65 normalizes it.
+var migration_v0064 = map[string][]string{
+	"mysql": {
+		reportIPRangeNormalizations,
+	},
+	"postgres": {
+		reportIPRangeNormalizations,
+	},
+	"sqlite3": {
+		reportIPRangeNormalizations,
+	},
+}
+
+var migration_v0064_down = map[string][]string{
+	"mysql": {
+		`DELETE FROM ip_range_normalizations`,
+	},
+	"postgres": {
+		`DELETE FROM ip_range_normalizations`,
+	},
+	"sqlite3": {
+		`DELETE FROM ip_range_normalizations`,
+	},
+}
@@ -0,0 +1,44 @@
+package migrations
+
+// Only icmp keeps an icmp type and code, icmp and all lose their ports, and
+// an icmp type of -1 takes an icmp code of -1. The icmp code is set before
+// the icmp type, since mysql assigns the columns in order.
+const normalizeIPRanges = `UPDATE ip_ranges SET
+		start_port = CASE WHEN protocol IN ('icmp', 'all') THEN 0 ELSE start_port END,
+		end_port = CASE WHEN protocol IN ('icmp', 'all') THEN 0 ELSE end_port END,
+		icmp_code = CASE WHEN protocol <> 'icmp' THEN 0 WHEN icmp_type = -1 THEN -1 ELSE icmp_code END,
+		icmp_type = CASE WHEN protocol <> 'icmp' THEN 0 ELSE icmp_type END
+		WHERE id IN (SELECT ip_range_id FROM ip_range_normalizations)`
+
+// Every report of an ip range holds the values it had before, so reverting
+// restores them from any of its reports.
+const restoreIPRanges = `UPDATE ip_ranges SET
+		start_port = (SELECT MAX(n.start_port) FROM ip_range_normalizations n WHERE n.ip_range_id = ip_ranges.id),
+		end_port = (SELECT MAX(n.end_port) FROM ip_range_normalizations n WHERE n.ip_range_id = ip_ranges.id),
+		icmp_type = (SELECT MAX(n.icmp_type) FROM ip_range_normalizations n WHERE n.ip_range_id = ip_ranges.id),
+		icmp_code = (SELECT MAX(n.icmp_code) FROM ip_range_normalizations n WHERE n.ip_range_id = ip_ranges.id)
+		WHERE id IN (SELECT ip_range_id FROM ip_range_normalizations)`
+
+var migration_v0065 = map[string][]string{
+	"mysql": {
+		normalizeIPRanges,
+	},
+	"postgres": {
+		normalizeIPRanges,
+	},
+	"sqlite3": {
+		normalizeIPRanges,
+	},
+}
+
+var migration_v0065_down = map[string][]string{
+	"mysql": {
+		restoreIPRanges,
+	},
+	"postgres": {
+		restoreIPRanges,
+	},
+	"sqlite3": {
+		restoreIPRanges,
+	},
+}
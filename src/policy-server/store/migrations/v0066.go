@@ -0,0 +1,34 @@
+package migrations
+
+// Concurrent creates could add more than one default source before
+// default_sources had a singleton. Migrations 66 to 68 merge them into the
+// first one: this one deletes each default egress policy whose destination
+// already has one from an earlier default source. DISTINCT keeps mysql from
+// merging the derived table, which it can not select from while deleting.
+const deleteDuplicateDefaultEgressPolicies = `DELETE FROM egress_policies WHERE guid IN (
+		SELECT guid FROM (
+			SELECT DISTINCT p.guid FROM egress_policies p
+			JOIN default_sources s ON (p.source_guid = s.terminal_guid)
+			JOIN egress_policies q ON (q.destination_guid = p.destination_guid)
+			JOIN default_sources t ON (q.source_guid = t.terminal_guid)
+			WHERE t.id < s.id
+		) AS duplicate_default_egress_policies
+	)`
+
+var migration_v0066 = map[string][]string{
+	"mysql": {
+		deleteDuplicateDefaultEgressPolicies,
+	},
+	"postgres": {
+		deleteDuplicateDefaultEgressPolicies,
+	},
+	"sqlite3": {
+		deleteDuplicateDefaultEgressPolicies,
+	},
+}
+
+var migration_v0066_down = map[string][]string{
+	"mysql":    {},
+	"postgres": {},
+	"sqlite3":  {},
+}
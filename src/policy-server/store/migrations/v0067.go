@@ -0,0 +1,24 @@
+package migrations
+
+// The egress policies of every other default source move to the first one.
+const moveEgressPoliciesToFirstDefaultSource = `UPDATE egress_policies SET
+		source_guid = (SELECT terminal_guid FROM default_sources WHERE id = (SELECT MIN(id) FROM default_sources))
+		WHERE source_guid IN (SELECT terminal_guid FROM default_sources WHERE id > (SELECT MIN(id) FROM default_sources))`
+
+var migration_v0067 = map[string][]string{
+	"mysql": {
+		moveEgressPoliciesToFirstDefaultSource,
+	},
+	"postgres": {
+		moveEgressPoliciesToFirstDefaultSource,
+	},
+	"sqlite3": {
+		moveEgressPoliciesToFirstDefaultSource,
+	},
+}
+
+var migration_v0067_down = map[string][]string{
+	"mysql":    {},
+	"postgres": {},
+	"sqlite3":  {},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+// Every default source but the first one no longer has egress policies. The
+// derived table lets mysql select from default_sources while deleting from it.
+const deleteExtraDefaultSources = `DELETE FROM default_sources WHERE id > (
+		SELECT first_id FROM (SELECT MIN(id) AS first_id FROM default_sources) AS first_default_source
+	)`
+
+var migration_v0068 = map[string][]string{
+	"mysql": {
+		deleteExtraDefaultSources,
+	},
+	"postgres": {
+		deleteExtraDefaultSources,
+	},
+	"sqlite3": {
+		deleteExtraDefaultSources,
+	},
+}
+
+var migration_v0068_down = map[string][]string{
+	"mysql":    {},
+	"postgres": {},
+	"sqlite3":  {},
+}
@@ -0,0 +1,27 @@
+package migrations
+
+// Default sources are never inserted with a singleton, so every one of them
+// has the same one, and its unique index from migration 70 allows only one.
+var migration_v0069 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE default_sources ADD COLUMN singleton int NOT NULL DEFAULT 1`,
+	},
+	"postgres": {
+		`ALTER TABLE default_sources ADD COLUMN singleton int NOT NULL DEFAULT 1`,
+	},
+	"sqlite3": {
+		`ALTER TABLE default_sources ADD COLUMN singleton int NOT NULL DEFAULT 1`,
+	},
+}
+
+var migration_v0069_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE default_sources DROP COLUMN singleton`,
+	},
+	"postgres": {
+		`ALTER TABLE default_sources DROP COLUMN singleton`,
+	},
+	"sqlite3": {
+		`ALTER TABLE default_sources DROP COLUMN singleton`,
+	},
+}
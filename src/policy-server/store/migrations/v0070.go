@@ -0,0 +1,25 @@
+package migrations
+
+var migration_v0070 = map[string][]string{
+	"mysql": {
+		`CREATE UNIQUE INDEX default_sources_singleton_unique ON default_sources (singleton)`,
+	},
+	"postgres": {
+		`CREATE UNIQUE INDEX default_sources_singleton_unique ON default_sources (singleton)`,
+	},
+	"sqlite3": {
+		`CREATE UNIQUE INDEX default_sources_singleton_unique ON default_sources (singleton)`,
+	},
+}
+
+var migration_v0070_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE default_sources DROP INDEX default_sources_singleton_unique`,
+	},
+	"postgres": {
+		`DROP INDEX default_sources_singleton_unique`,
+	},
+	"sqlite3": {
+		`DROP INDEX default_sources_singleton_unique`,
+	},
+}
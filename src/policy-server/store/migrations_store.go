@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"policy-server/db"
+	"policy-server/store/migrations"
 	"strings"
 )
 
@@ -11,8 +12,21 @@ type MigrationsStore struct {
 	DBConn *db.ConnWrapper
 }
 
+// NewMigrator returns a migrator for the database of dbConn, which also
+// checks which legacy migrations it has had.
+func NewMigrator(dbConn *db.ConnWrapper) *migrations.Migrator {
+	return &migrations.Migrator{
+		MigrateAdapter: &migrations.MigrateAdapter{},
+		MigrationsProvider: &migrations.MigrationsProvider{
+			Store: &MigrationsStore{
+				DBConn: dbConn,
+			},
+		},
+	}
+}
+
 func (m *MigrationsStore) HasV1MigrationOccurred() (bool, error) {
-	if !m.tableExists("gorp_migrations") {
+	if m.isSQLite() || !m.tableExists("gorp_migrations") {
 		return false, nil
 	}
 
@@ -32,7 +46,7 @@ func (m *MigrationsStore) HasV1MigrationOccurred() (bool, error) {
 }
 
 func (m *MigrationsStore) HasV2MigrationOccurred() (bool, error) {
-	if !m.tableExists("gorp_migrations") {
+	if m.isSQLite() || !m.tableExists("gorp_migrations") {
 		return false, nil
 	}
 
@@ -66,7 +80,7 @@ func (m *MigrationsStore) HasV2MigrationOccurred() (bool, error) {
 }
 
 func (m *MigrationsStore) HasV3MigrationOccurred() (bool, error) {
-	if !m.tableExists("gorp_migrations") {
+	if m.isSQLite() || !m.tableExists("gorp_migrations") {
 		return false, nil
 	}
 
@@ -97,6 +111,12 @@ func (m *MigrationsStore) HasV3MigrationOccurred() (bool, error) {
 	return true, nil
 }
 
+// SQLite support was added after the legacy migrations were replaced, so a
+// SQLite database has only ever run the modified ones.
+func (m *MigrationsStore) isSQLite() bool {
+	return m.DBConn.DriverName() == "sqlite3"
+}
+
 func (m *MigrationsStore) tableExists(tableName string) bool {
 	rows, err := m.DBConn.Query(fmt.Sprintf("SELECT 1 FROM %s LIMIT 1", tableName))
 	if err != nil {
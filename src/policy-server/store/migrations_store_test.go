@@ -11,7 +11,6 @@ import (
 	migrationsFakes "policy-server/store/migrations/fakes"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 
 	. "github.com/onsi/ginkgo"
@@ -31,7 +30,7 @@ var _ = Describe("MigrationsStore", func() {
 	)
 
 	BeforeEach(func() {
-		dbConf = testsupport.GetDBConfig()
+		dbConf = testhelpers.GetDBConfig()
 		dbConf.DatabaseName = fmt.Sprintf("migrator_store_test_node_%d", time.Now().UnixNano())
 		dbConf.Timeout = 30
 		testhelpers.CreateDatabase(dbConf)
@@ -8,6 +8,7 @@ type PolicyCollection struct {
 type Policy struct {
 	Source      Source
 	Destination Destination
+	Log         bool
 }
 
 type Source struct {
@@ -34,12 +35,29 @@ type Tag struct {
 	Type string
 }
 
+type TagUtilisation struct {
+	Total     int
+	Allocated int
+	Orphaned  int
+}
+
+// EgressPolicy applies to apps in the AppLifecycle given, one of
+// AppLifecycleRunning, AppLifecycleStaging or AppLifecycleAll. An empty
+// AppLifecycle is created as AppLifecycleAll.
 type EgressPolicy struct {
-	ID          string
-	Source      EgressSource
-	Destination EgressDestination
+	ID           string
+	Source       EgressSource
+	Destination  EgressDestination
+	Log          bool
+	AppLifecycle string
 }
 
+const (
+	AppLifecycleRunning = "running"
+	AppLifecycleStaging = "staging"
+	AppLifecycleAll     = "all"
+)
+
 type EgressSource struct {
 	ID   string
 	Type string
@@ -69,4 +87,6 @@ type EgressPolicyIDCollection struct {
 	SourceTerminalGUID      string
 	SourceAppID             int64
 	SourceSpaceID           int64
+	SourceOrgID             int64
+	SourceDefaultID         int64
 }
@@ -1,10 +1,15 @@
 package store
 
-import "policy-server/db"
+import (
+	"fmt"
+	"policy-server/db"
+	"policy-server/store/helpers"
+)
 
 //go:generate counterfeiter -o fakes/policy_repo.go --fake-name PolicyRepo . PolicyRepo
 type PolicyRepo interface {
 	Create(db.Transaction, int, int) error
+	CreateMany(db.Transaction, []PolicyRow) error
 	Delete(db.Transaction, int, int) error
 	CountWhereGroupID(db.Transaction, int) (int, error)
 	CountWhereDestinationID(db.Transaction, int) (int, error)
@@ -13,6 +18,12 @@ type PolicyRepo interface {
 type PolicyTable struct {
 }
 
+type PolicyRow struct {
+	GroupID       int
+	DestinationID int
+	Log           bool
+}
+
 func (p *PolicyTable) Create(tx db.Transaction, sourceGroupId int, destinationId int) error {
 	dualStatement := ""
 	if tx.DriverName() == "mysql" {
@@ -36,6 +47,98 @@ func (p *PolicyTable) Create(tx db.Transaction, sourceGroupId int, destinationId
 	return err
 }
 
+// CreateMany inserts the policy rows that do not exist yet with multi-row
+// inserts, and updates the log attribute of the rows that do.
+func (p *PolicyTable) CreateMany(tx db.Transaction, rows []PolicyRow) error {
+	var destinationIDs []int
+	for _, row := range rows {
+		destinationIDs = append(destinationIDs, row.DestinationID)
+	}
+	destinationIDs = uniqueInts(destinationIDs)
+
+	// existing maps each stored row, without its Log, to the Log it has
+	existing := map[PolicyRow]bool{}
+	for _, batch := range batches(len(destinationIDs)) {
+		chunk := destinationIDs[batch.start:batch.end]
+
+		var args []interface{}
+		for _, id := range chunk {
+			args = append(args, id)
+		}
+
+		result, err := tx.Queryx(tx.Rebind(fmt.Sprintf(`
+			SELECT group_id, destination_id, log FROM policies
+			WHERE destination_id IN (%s)
+		`, helpers.QuestionMarks(len(chunk)))), args...)
+		if err != nil {
+			return err
+		}
+
+		for result.Next() {
+			var row PolicyRow
+			var log bool
+			err = result.Scan(&row.GroupID, &row.DestinationID, &log)
+			if err != nil {
+				result.Close()
+				return err
+			}
+			existing[row] = log
+		}
+		err = result.Err()
+		result.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	// a policy given more than once takes the last Log it was given
+	var keys []PolicyRow
+	wanted := map[PolicyRow]bool{}
+	for _, row := range rows {
+		key := PolicyRow{GroupID: row.GroupID, DestinationID: row.DestinationID}
+		if _, ok := wanted[key]; !ok {
+			keys = append(keys, key)
+		}
+		wanted[key] = row.Log
+	}
+
+	var missing []PolicyRow
+	for _, key := range keys {
+		log, found := existing[key]
+		if !found {
+			missing = append(missing, PolicyRow{GroupID: key.GroupID, DestinationID: key.DestinationID, Log: wanted[key]})
+			continue
+		}
+		if log != wanted[key] {
+			_, err := tx.Exec(tx.Rebind(`
+				UPDATE policies SET log = ?
+				WHERE group_id = ? AND destination_id = ?
+			`), wanted[key], key.GroupID, key.DestinationID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, batch := range batches(len(missing)) {
+		chunk := missing[batch.start:batch.end]
+
+		var args []interface{}
+		for _, row := range chunk {
+			args = append(args, row.GroupID, row.DestinationID, row.Log)
+		}
+
+		_, err := tx.Exec(tx.Rebind(fmt.Sprintf(`
+			INSERT INTO policies (group_id, destination_id, log)
+			VALUES %s
+		`, helpers.QuestionMarkTuples(len(chunk), 3))), args...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *PolicyTable) Delete(tx db.Transaction, sourceGroupId int, destinationId int) error {
 	_, err := tx.Exec(tx.Rebind(`DELETE FROM policies WHERE group_id = ? AND destination_id = ?`),
 		sourceGroupId,
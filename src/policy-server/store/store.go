@@ -42,6 +42,7 @@ type Database interface {
 
 type store struct {
 	conn        Database
+	reader      Database
 	group       GroupRepo
 	destination DestinationRepo
 	policy      PolicyRepo
@@ -49,8 +50,15 @@ type store struct {
 }
 
 func New(dbConnectionPool Database, g GroupRepo, d DestinationRepo, p PolicyRepo, tl int) Store {
+	return NewWithReader(dbConnectionPool, dbConnectionPool, g, d, p, tl)
+}
+
+// NewWithReader returns a Store that serves All and ByGuids from reader,
+// typically a read replica router, and everything else from dbConnectionPool.
+func NewWithReader(dbConnectionPool, reader Database, g GroupRepo, d DestinationRepo, p PolicyRepo, tl int) Store {
 	return &store{
 		conn:        dbConnectionPool,
+		reader:      reader,
 		group:       g,
 		destination: d,
 		policy:      p,
@@ -92,37 +100,56 @@ func (s *store) CheckDatabase() error {
 }
 
 func (s *store) createWithTx(tx db.Transaction, policies []Policy) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	var guids []string
 	for _, policy := range policies {
-		sourceGroupId, err := s.group.Create(tx, policy.Source.ID, "app")
-		if err != nil {
-			return fmt.Errorf("creating group: %s", err)
-		}
+		guids = append(guids, policy.Source.ID, policy.Destination.ID)
+	}
 
-		destinationGroupId, err := s.group.Create(tx, policy.Destination.ID, "app")
-		if err != nil {
-			return fmt.Errorf("creating group: %s", err)
-		}
+	groupIds, err := s.group.CreateMany(tx, guids, "app")
+	if err != nil {
+		return fmt.Errorf("creating group: %s", err)
+	}
 
-		destinationId, err := s.destination.Create(
-			tx,
-			destinationGroupId,
-			policy.Destination.Port,
-			policy.Destination.Ports.Start,
-			policy.Destination.Ports.End,
-			policy.Destination.Protocol,
-		)
-		if err != nil {
-			return fmt.Errorf("creating destination: %s", err)
-		}
+	var destinationRows []DestinationRow
+	for _, policy := range policies {
+		destinationRows = append(destinationRows, destinationRowFor(groupIds, policy))
+	}
 
-		err = s.policy.Create(tx, sourceGroupId, destinationId)
-		if err != nil {
-			return fmt.Errorf("creating policy: %s", err)
-		}
+	destinationIds, err := s.destination.CreateMany(tx, destinationRows)
+	if err != nil {
+		return fmt.Errorf("creating destination: %s", err)
+	}
+
+	var policyRows []PolicyRow
+	for i, policy := range policies {
+		policyRows = append(policyRows, PolicyRow{
+			GroupID:       groupIds[policy.Source.ID],
+			DestinationID: destinationIds[destinationRows[i]],
+			Log:           policy.Log,
+		})
+	}
+
+	err = s.policy.CreateMany(tx, policyRows)
+	if err != nil {
+		return fmt.Errorf("creating policy: %s", err)
 	}
 	return nil
 }
 
+func destinationRowFor(groupIds map[string]int, policy Policy) DestinationRow {
+	return DestinationRow{
+		GroupID:   groupIds[policy.Destination.ID],
+		Port:      policy.Destination.Port,
+		StartPort: policy.Destination.Ports.Start,
+		EndPort:   policy.Destination.Ports.End,
+		Protocol:  policy.Destination.Protocol,
+	}
+}
+
 func (s *store) deleteWithTx(tx db.Transaction, policies []Policy) error {
 	for _, p := range policies {
 		sourceGroupID, err := s.group.GetID(tx, p.Source.ID)
@@ -215,9 +242,9 @@ func (s *store) deleteGroupRowIfLast(tx db.Transaction, groupId int) error {
 
 func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, error) {
 	var policies []Policy
-	rebindedQuery := helpers.RebindForSQLDialect(query, s.conn.DriverName())
+	rebindedQuery := helpers.RebindForSQLDialect(query, s.reader.DriverName())
 
-	rows, err := s.conn.Query(rebindedQuery, args...)
+	rows, err := s.reader.Query(rebindedQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing all: %s", err)
 	}
@@ -226,6 +253,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 	for rows.Next() {
 		var sourceId, destinationId, protocol string
 		var port, startPort, endPort, sourceTag, destinationTag int
+		var log bool
 		err = rows.Scan(
 			&sourceId,
 			&sourceTag,
@@ -235,6 +263,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 			&startPort,
 			&endPort,
 			&protocol,
+			&log,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("listing all: %s", err)
@@ -255,6 +284,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 					End:   endPort,
 				},
 			},
+			Log: log,
 		})
 	}
 	err = rows.Err()
@@ -289,7 +319,8 @@ func (s *store) ByGuids(srcGuids, destGuids []string, inSourceAndDest bool) ([]P
 			destinations.port,
 			destinations.start_port,
 			destinations.end_port,
-			destinations.protocol
+			destinations.protocol,
+			policies.log
 		from policies
 		left outer join groups as src_grp on (policies.group_id = src_grp.id)
 		left outer join destinations on (destinations.id = policies.destination_id)
@@ -326,7 +357,8 @@ func (s *store) All() ([]Policy, error) {
 			destinations.port,
 			destinations.start_port,
 			destinations.end_port,
-			destinations.protocol
+			destinations.protocol,
+			policies.log
 		from policies
 		left outer join groups as src_grp on (policies.group_id = src_grp.id)
 		left outer join destinations on (destinations.id = policies.destination_id)
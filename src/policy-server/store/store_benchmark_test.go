@@ -0,0 +1,92 @@
+package store_test
+
+import (
+	"fmt"
+	"policy-server/store"
+	"time"
+
+	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
+
+	"policy-server/db"
+	"test-helpers"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store performance", func() {
+	const policyCount = 500
+
+	var (
+		dbConf   dbHelper.Config
+		realDb   *db.ConnWrapper
+		policies []store.Policy
+	)
+
+	BeforeEach(func() {
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("store_benchmark_test_node_%d", time.Now().UnixNano())
+		testhelpers.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("Store Benchmark Test")
+		realDb = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "Store Benchmark Test", "Store Benchmark Test", logger)
+		migrateAndPopulateTags(realDb, 3)
+
+		policies = nil
+		for i := 0; i < policyCount; i++ {
+			policies = append(policies, store.Policy{
+				Source: store.Source{ID: fmt.Sprintf("source-app-%d", i)},
+				Destination: store.Destination{
+					ID:       fmt.Sprintf("destination-app-%d", i%50),
+					Protocol: "tcp",
+					Port:     8080 + i,
+					Ports:    store.Ports{Start: 8080 + i, End: 8080 + i},
+				},
+			})
+		}
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testhelpers.RemoveDatabase(dbConf)
+	})
+
+	Measure("creates policies row by row", func(b Benchmarker) {
+		group := &store.GroupTable{}
+		destination := &store.DestinationTable{}
+		policy := &store.PolicyTable{}
+
+		b.Time("runtime", func() {
+			tx, err := realDb.Beginx()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, p := range policies {
+				sourceGroupId, err := group.Create(tx, p.Source.ID, "app")
+				Expect(err).NotTo(HaveOccurred())
+
+				destinationGroupId, err := group.Create(tx, p.Destination.ID, "app")
+				Expect(err).NotTo(HaveOccurred())
+
+				destinationId, err := destination.Create(tx, destinationGroupId, p.Destination.Port, p.Destination.Ports.Start, p.Destination.Ports.End, p.Destination.Protocol)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(policy.Create(tx, sourceGroupId, destinationId)).To(Succeed())
+			}
+
+			Expect(tx.Commit()).To(Succeed())
+		})
+	}, 3)
+
+	Measure("creates policies in batches", func(b Benchmarker) {
+		dataStore := store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 3)
+
+		runtime := b.Time("runtime", func() {
+			Expect(dataStore.Create(policies)).To(Succeed())
+		})
+
+		Expect(runtime.Seconds()).To(BeNumerically("<", 5), "Creating policies in batches shouldn't take too long.")
+	}, 3)
+})
@@ -42,7 +42,7 @@ var _ = Describe("Store", func() {
 	BeforeEach(func() {
 		mockDb = &fakes.Db{}
 
-		dbConf = testsupport.GetDBConfig()
+		dbConf = testhelpers.GetDBConfig()
 		dbConf.DatabaseName = fmt.Sprintf("store_test_node_%d", time.Now().UnixNano())
 
 		testhelpers.CreateDatabase(dbConf)
@@ -171,6 +171,35 @@ var _ = Describe("Store", func() {
 			Expect(len(p)).To(Equal(2))
 		})
 
+		It("saves and updates the log attribute of a policy", func() {
+			policy := store.Policy{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Port:     8080,
+					Ports:    store.Ports{Start: 8080, End: 8080},
+				},
+				Log: true,
+			}
+
+			Expect(dataStore.Create([]store.Policy{policy})).To(Succeed())
+
+			p, err := dataStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(HaveLen(1))
+			Expect(p[0].Log).To(BeTrue())
+
+			By("creating the same policy without log")
+			policy.Log = false
+			Expect(dataStore.Create([]store.Policy{policy})).To(Succeed())
+
+			p, err = dataStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(HaveLen(1))
+			Expect(p[0].Log).To(BeFalse())
+		})
+
 		Context("when a transaction begin fails", func() {
 			var err error
 
@@ -188,7 +217,7 @@ var _ = Describe("Store", func() {
 		Context("when the createWithTx fails", func() {
 			It("rollsback the transaction", func() {
 				fakeGroup := &fakes.GroupRepo{}
-				fakeGroup.CreateReturns(-1, errors.New("failed to create group"))
+				fakeGroup.CreateManyReturns(nil, errors.New("failed to create group"))
 
 				dataStore := store.New(mockDb, fakeGroup, destination, policy, 2)
 
@@ -350,7 +379,7 @@ var _ = Describe("Store", func() {
 
 			BeforeEach(func() {
 				fakeGroup = &fakes.GroupRepo{}
-				fakeGroup.CreateReturns(-1, errors.New("some-insert-error"))
+				fakeGroup.CreateManyReturns(nil, errors.New("some-insert-error"))
 				migrateAndPopulateTags(realDb, 2)
 
 				dataStore = store.New(realDb, fakeGroup, destination, policy, 2)
@@ -373,44 +402,63 @@ var _ = Describe("Store", func() {
 
 		})
 
-		Context("when the second create group fails", func() {
+		Context("when creating many policies", func() {
 			var fakeGroup *fakes.GroupRepo
-			var err error
+			var fakeDestination *fakes.DestinationRepo
+			var fakePolicy *fakes.PolicyRepo
 
 			BeforeEach(func() {
 				fakeGroup = &fakes.GroupRepo{}
-				type response struct {
-					Id  int
-					Err error
-				}
-
-				responses := []response{
-					{2, nil},
-					{-1, errors.New("some-insert-error")},
-				}
-				fakeGroup.CreateStub = func(t db.Transaction, guid, groupType string) (int, error) {
-					response := responses[0]
-					responses = responses[1:]
-					return response.Id, response.Err
-				}
+				fakeGroup.CreateManyReturns(map[string]int{
+					"app-a": 1,
+					"app-b": 2,
+					"app-c": 3,
+				}, nil)
+				fakeDestination = &fakes.DestinationRepo{}
+				fakeDestination.CreateManyReturns(map[store.DestinationRow]int{
+					{GroupID: 2, Port: 8080, StartPort: 8080, EndPort: 8080, Protocol: "tcp"}: 11,
+					{GroupID: 3, Port: 9090, StartPort: 9090, EndPort: 9090, Protocol: "udp"}: 12,
+				}, nil)
+				fakePolicy = &fakes.PolicyRepo{}
 
 				migrateAndPopulateTags(realDb, 2)
-				dataStore = store.New(realDb, fakeGroup, destination, policy, 2)
+				dataStore = store.New(realDb, fakeGroup, fakeDestination, fakePolicy, 2)
 			})
 
-			It("returns the error", func() {
-				policies := []store.Policy{{
-					Source: store.Source{ID: "some-app-guid"},
-					Destination: store.Destination{
-						ID:       "some-other-app-guid",
-						Protocol: "tcp",
-						Port:     8080,
+			It("issues a single batched call to each repo", func() {
+				policies := []store.Policy{
+					{
+						Source:      store.Source{ID: "app-a"},
+						Destination: store.Destination{ID: "app-b", Protocol: "tcp", Port: 8080, Ports: store.Ports{Start: 8080, End: 8080}},
 					},
-				}}
+					{
+						Source:      store.Source{ID: "app-a"},
+						Destination: store.Destination{ID: "app-c", Protocol: "udp", Port: 9090, Ports: store.Ports{Start: 9090, End: 9090}},
+						Log:         true,
+					},
+				}
 
-				err = dataStore.Create(policies)
+				err := dataStore.Create(policies)
+				Expect(err).NotTo(HaveOccurred())
 
-				Expect(err).To(MatchError("creating group: some-insert-error"))
+				Expect(fakeGroup.CreateManyCallCount()).To(Equal(1))
+				_, guids, groupType := fakeGroup.CreateManyArgsForCall(0)
+				Expect(guids).To(Equal([]string{"app-a", "app-b", "app-a", "app-c"}))
+				Expect(groupType).To(Equal("app"))
+
+				Expect(fakeDestination.CreateManyCallCount()).To(Equal(1))
+				_, destinationRows := fakeDestination.CreateManyArgsForCall(0)
+				Expect(destinationRows).To(Equal([]store.DestinationRow{
+					{GroupID: 2, Port: 8080, StartPort: 8080, EndPort: 8080, Protocol: "tcp"},
+					{GroupID: 3, Port: 9090, StartPort: 9090, EndPort: 9090, Protocol: "udp"},
+				}))
+
+				Expect(fakePolicy.CreateManyCallCount()).To(Equal(1))
+				_, policyRows := fakePolicy.CreateManyArgsForCall(0)
+				Expect(policyRows).To(Equal([]store.PolicyRow{
+					{GroupID: 1, DestinationID: 11},
+					{GroupID: 1, DestinationID: 12, Log: true},
+				}))
 			})
 		})
 
@@ -420,7 +468,7 @@ var _ = Describe("Store", func() {
 
 			BeforeEach(func() {
 				fakeDestination = &fakes.DestinationRepo{}
-				fakeDestination.CreateReturns(-1, errors.New("some-insert-error"))
+				fakeDestination.CreateManyReturns(nil, errors.New("some-insert-error"))
 
 				migrateAndPopulateTags(realDb, 2)
 				dataStore = store.New(realDb, group, fakeDestination, policy, 2)
@@ -451,7 +499,7 @@ var _ = Describe("Store", func() {
 
 			BeforeEach(func() {
 				fakePolicy = &fakes.PolicyRepo{}
-				fakePolicy.CreateReturns(errors.New("some-insert-error"))
+				fakePolicy.CreateManyReturns(errors.New("some-insert-error"))
 
 				migrateAndPopulateTags(realDb, 2)
 				dataStore = store.New(realDb, group, destination, fakePolicy, 2)
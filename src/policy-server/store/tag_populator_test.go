@@ -2,7 +2,6 @@ package store_test
 
 import (
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 	"database/sql"
 	"errors"
@@ -30,7 +29,7 @@ var _ = Describe("Tag Populator", func() {
 
 		BeforeEach(func() {
 
-			dbConf = testsupport.GetDBConfig()
+			dbConf = testhelpers.GetDBConfig()
 			dbConf.DatabaseName = fmt.Sprintf("tag_populator_test_node_%d", time.Now().UnixNano())
 
 			testhelpers.CreateDatabase(dbConf)
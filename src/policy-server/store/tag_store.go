@@ -3,6 +3,8 @@ package store
 import (
 	"fmt"
 	"policy-server/db"
+	"policy-server/store/helpers"
+	"time"
 )
 
 //go:generate counterfeiter -o fakes/tag_store.go --fake-name TagStore . TagStore
@@ -11,15 +13,32 @@ type TagStore interface {
 	Tags() ([]Tag, error)
 }
 
+//go:generate counterfeiter -o fakes/tag_reclaim_store.go --fake-name TagReclaimStore . TagReclaimStore
+type TagReclaimStore interface {
+	TagUtilisation() (TagUtilisation, error)
+	UnreferencedTags() ([]Tag, error)
+	MarkOrphanedTags([]string, time.Time) error
+	ClearOrphanedTags([]string) error
+	ReclaimOrphanedTags(time.Time) ([]Tag, error)
+}
+
 type tagStore struct {
 	conn      Database
+	reader    Database
 	group     GroupRepo
 	tagLength int
 }
 
 func NewTagStore(dbConnectionPool Database, groupRepo GroupRepo, tagLength int) *tagStore {
+	return NewTagStoreWithReader(dbConnectionPool, dbConnectionPool, groupRepo, tagLength)
+}
+
+// NewTagStoreWithReader returns a tag store that serves Tags from reader and
+// allocates new tags through dbConnectionPool.
+func NewTagStoreWithReader(dbConnectionPool, reader Database, groupRepo GroupRepo, tagLength int) *tagStore {
 	return &tagStore{
 		conn:      dbConnectionPool,
+		reader:    reader,
 		group:     groupRepo,
 		tagLength: tagLength,
 	}
@@ -36,6 +55,15 @@ func (s *tagStore) CreateTag(groupGuid, groupType string) (Tag, error) {
 		return Tag{}, rollback(tx, err)
 	}
 
+	// a tag that is asked for again is in use, even if it has no policies
+	_, err = tx.Exec(tx.Rebind(`
+		UPDATE groups SET orphaned_at = NULL
+		WHERE id = ? AND orphaned_at IS NOT NULL
+	`), tagID)
+	if err != nil {
+		return Tag{}, rollback(tx, fmt.Errorf("clearing orphaned tag: %s", err))
+	}
+
 	err = commit(tx)
 	if err != nil {
 		return Tag{}, rollback(tx, err)
@@ -51,7 +79,7 @@ func (s *tagStore) CreateTag(groupGuid, groupType string) (Tag, error) {
 func (s *tagStore) Tags() ([]Tag, error) {
 	var tags []Tag
 
-	rows, err := s.conn.Query(`
+	rows, err := s.reader.Query(`
 		SELECT guid, id, type FROM groups
 		WHERE guid IS NOT NULL
 		ORDER BY id
@@ -85,10 +113,169 @@ func (s *tagStore) Tags() ([]Tag, error) {
 	return tags, nil
 }
 
+// TagUtilisation counts the rows of the groups table. Every row is one tag of
+// the tag space, allocated or not.
+func (s *tagStore) TagUtilisation() (TagUtilisation, error) {
+	var utilisation TagUtilisation
+	err := s.reader.QueryRow(`
+		SELECT COUNT(*), COUNT(guid), COUNT(orphaned_at) FROM groups
+	`).Scan(&utilisation.Total, &utilisation.Allocated, &utilisation.Orphaned)
+	if err != nil {
+		return TagUtilisation{}, fmt.Errorf("counting tags: %s", err)
+	}
+	return utilisation, nil
+}
+
+// UnreferencedTags returns the allocated tags that no c2c policy uses as a
+// source or destination.
+func (s *tagStore) UnreferencedTags() ([]Tag, error) {
+	rows, err := s.conn.Query(`
+		SELECT guid, id, type FROM groups
+		WHERE guid IS NOT NULL` + unreferencedGroup + `
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("listing unreferenced tags: %s", err)
+	}
+	defer rows.Close()
+
+	var tags []Tag
+	for rows.Next() {
+		var guid, groupType string
+		var id int
+		err = rows.Scan(&guid, &id, &groupType)
+		if err != nil {
+			return nil, fmt.Errorf("listing unreferenced tags: %s", err)
+		}
+		tags = append(tags, Tag{ID: guid, Tag: s.tagIntToString(id), Type: groupType})
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("listing unreferenced tags, getting next row: %s", err)
+	}
+	return tags, nil
+}
+
+// MarkOrphanedTags starts the quarantine of the given groups. Groups that are
+// already orphaned keep their original timestamp.
+func (s *tagStore) MarkOrphanedTags(guids []string, orphanedAt time.Time) error {
+	guids = uniqueStrings(guids)
+	for _, batch := range batches(len(guids)) {
+		args := []interface{}{orphanedAt}
+		for _, guid := range guids[batch.start:batch.end] {
+			args = append(args, guid)
+		}
+
+		_, err := s.conn.Exec(s.conn.Rebind(fmt.Sprintf(`
+			UPDATE groups SET orphaned_at = ?
+			WHERE orphaned_at IS NULL AND guid IN (%s)
+		`, helpers.QuestionMarks(batch.end-batch.start))), args...)
+		if err != nil {
+			return fmt.Errorf("marking orphaned tags: %s", err)
+		}
+	}
+	return nil
+}
+
+// ClearOrphanedTags ends the quarantine of the given groups and of any group
+// that a policy has started referencing since it was marked.
+func (s *tagStore) ClearOrphanedTags(guids []string) error {
+	guids = uniqueStrings(guids)
+	for _, batch := range batches(len(guids)) {
+		args := []interface{}{}
+		for _, guid := range guids[batch.start:batch.end] {
+			args = append(args, guid)
+		}
+
+		_, err := s.conn.Exec(s.conn.Rebind(fmt.Sprintf(`
+			UPDATE groups SET orphaned_at = NULL
+			WHERE orphaned_at IS NOT NULL AND guid IN (%s)
+		`, helpers.QuestionMarks(len(args)))), args...)
+		if err != nil {
+			return fmt.Errorf("clearing orphaned tags: %s", err)
+		}
+	}
+
+	_, err := s.conn.Exec(`
+		UPDATE groups SET orphaned_at = NULL
+		WHERE orphaned_at IS NOT NULL AND (
+			EXISTS (SELECT 1 FROM policies WHERE policies.group_id = groups.id)
+			OR EXISTS (SELECT 1 FROM destinations WHERE destinations.group_id = groups.id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("clearing orphaned tags: %s", err)
+	}
+	return nil
+}
+
+// ReclaimOrphanedTags releases the groups orphaned at or before the given
+// time, checking again that no policy references them, and returns the tags
+// that were released.
+func (s *tagStore) ReclaimOrphanedTags(orphanedBefore time.Time) ([]Tag, error) {
+	tx, err := s.conn.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %s", err)
+	}
+
+	rows, err := tx.Queryx(tx.Rebind(`
+		SELECT guid, id, type FROM groups
+		WHERE orphaned_at IS NOT NULL AND orphaned_at <= ?`+unreferencedGroup+`
+		ORDER BY id
+	`)+helpers.ForUpdate(tx.DriverName()), orphanedBefore)
+	if err != nil {
+		return nil, rollback(tx, fmt.Errorf("listing orphaned tags: %s", err))
+	}
+
+	var tags []Tag
+	var ids []int
+	for rows.Next() {
+		var guid, groupType string
+		var id int
+		err = rows.Scan(&guid, &id, &groupType)
+		if err != nil {
+			rows.Close()
+			return nil, rollback(tx, fmt.Errorf("listing orphaned tags: %s", err))
+		}
+		tags = append(tags, Tag{ID: guid, Tag: s.tagIntToString(id), Type: groupType})
+		ids = append(ids, id)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, rollback(tx, fmt.Errorf("listing orphaned tags, getting next row: %s", err))
+	}
+
+	for _, batch := range batches(len(ids)) {
+		args := []interface{}{}
+		for _, id := range ids[batch.start:batch.end] {
+			args = append(args, id)
+		}
+
+		_, err = tx.Exec(tx.Rebind(fmt.Sprintf(`
+			UPDATE groups SET guid = NULL, type = NULL, orphaned_at = NULL
+			WHERE id IN (%s)
+		`, helpers.QuestionMarks(len(args)))), args...)
+		if err != nil {
+			return nil, rollback(tx, fmt.Errorf("reclaiming orphaned tags: %s", err))
+		}
+	}
+
+	err = commit(tx)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+	return tags, nil
+}
+
 func (s *tagStore) tagIntToString(tag int) string {
 	return fmt.Sprintf("%"+fmt.Sprintf("0%d", s.tagLength*2)+"X", tag)
 }
 
+const unreferencedGroup = `
+	AND NOT EXISTS (SELECT 1 FROM policies WHERE policies.group_id = groups.id)
+	AND NOT EXISTS (SELECT 1 FROM destinations WHERE destinations.group_id = groups.id)`
+
 func commit(tx db.Transaction) error {
 	err := tx.Commit()
 	if err != nil {
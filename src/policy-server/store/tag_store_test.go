@@ -10,7 +10,7 @@ import (
 	"time"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"test-helpers"
 
 	"policy-server/db"
 
@@ -38,10 +38,10 @@ var _ = Describe("TagStore", func() {
 		tagLength = 1
 		mockDb = &fakes.Db{}
 
-		dbConf = testsupport.GetDBConfig()
+		dbConf = testhelpers.GetDBConfig()
 		dbConf.DatabaseName = fmt.Sprintf("tag_store_test_node_%d", time.Now().UnixNano())
 
-		testsupport.CreateDatabase(dbConf)
+		testhelpers.CreateDatabase(dbConf)
 
 		logger := lager.NewLogger("Tag Store Test")
 
@@ -62,7 +62,7 @@ var _ = Describe("TagStore", func() {
 		if realDb != nil {
 			Expect(realDb.Close()).To(Succeed())
 		}
-		testsupport.RemoveDatabase(dbConf)
+		testhelpers.RemoveDatabase(dbConf)
 	})
 
 	Describe("CreateTag", func() {
@@ -233,4 +233,95 @@ var _ = Describe("TagStore", func() {
 			})
 		})
 	})
+
+	Describe("tag reclamation", func() {
+		var reclaimStore store.TagReclaimStore
+
+		BeforeEach(func() {
+			tagStore = store.NewTagStore(realDb, group, tagLength)
+			reclaimStore = store.NewTagStore(realDb, group, tagLength)
+			dataStore = store.New(realDb, group, destination, policy, 1)
+
+			err := dataStore.Create([]store.Policy{{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Port:     8080,
+				},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tagStore.CreateTag("lonely-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the tag space", func() {
+			utilisation, err := reclaimStore.TagUtilisation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(utilisation).To(Equal(store.TagUtilisation{Total: 255, Allocated: 3, Orphaned: 0}))
+		})
+
+		It("lists the tags that no policy references", func() {
+			tags, err := reclaimStore.UnreferencedTags()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(Equal([]store.Tag{{ID: "lonely-app-guid", Tag: "03", Type: "app"}}))
+		})
+
+		It("marks and clears orphaned tags", func() {
+			Expect(reclaimStore.MarkOrphanedTags([]string{"lonely-app-guid"}, time.Now().UTC())).To(Succeed())
+
+			utilisation, err := reclaimStore.TagUtilisation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(utilisation.Orphaned).To(Equal(1))
+
+			Expect(reclaimStore.ClearOrphanedTags([]string{"lonely-app-guid"})).To(Succeed())
+
+			utilisation, err = reclaimStore.TagUtilisation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(utilisation.Orphaned).To(Equal(0))
+		})
+
+		It("clears the orphaned mark when the tag is created again", func() {
+			Expect(reclaimStore.MarkOrphanedTags([]string{"lonely-app-guid"}, time.Now().UTC())).To(Succeed())
+
+			_, err := tagStore.CreateTag("lonely-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+
+			utilisation, err := reclaimStore.TagUtilisation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(utilisation.Orphaned).To(Equal(0))
+		})
+
+		It("reclaims tags orphaned before the given time", func() {
+			orphanedAt := time.Now().UTC().Add(-2 * time.Hour)
+			Expect(reclaimStore.MarkOrphanedTags([]string{"lonely-app-guid"}, orphanedAt)).To(Succeed())
+
+			reclaimed, err := reclaimStore.ReclaimOrphanedTags(orphanedAt.Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed).To(BeEmpty())
+
+			reclaimed, err = reclaimStore.ReclaimOrphanedTags(orphanedAt.Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed).To(Equal([]store.Tag{{ID: "lonely-app-guid", Tag: "03", Type: "app"}}))
+
+			utilisation, err := reclaimStore.TagUtilisation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(utilisation).To(Equal(store.TagUtilisation{Total: 255, Allocated: 2, Orphaned: 0}))
+		})
+
+		It("does not reclaim tags that a policy references", func() {
+			Expect(reclaimStore.MarkOrphanedTags([]string{"some-app-guid"}, time.Now().UTC().Add(-time.Hour))).To(Succeed())
+
+			reclaimed, err := reclaimStore.ReclaimOrphanedTags(time.Now().UTC())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed).To(BeEmpty())
+
+			By("clearing the mark of referenced tags")
+			Expect(reclaimStore.ClearOrphanedTags(nil)).To(Succeed())
+			utilisation, err := reclaimStore.TagUtilisation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(utilisation.Orphaned).To(Equal(0))
+		})
+	})
 })
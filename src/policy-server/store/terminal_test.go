@@ -10,7 +10,6 @@ import (
 	"time"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 
 	uuid "github.com/nu7hatch/gouuid"
@@ -29,7 +28,7 @@ var _ = Describe("Terminal Table", func() {
 
 		BeforeEach(func() {
 			var err error
-			dbConf = testsupport.GetDBConfig()
+			dbConf = testhelpers.GetDBConfig()
 			dbConf.DatabaseName = fmt.Sprintf("terminal_table_test_node_%d", time.Now().UnixNano())
 			dbConf.Timeout = 30
 			testhelpers.CreateDatabase(dbConf)
@@ -156,7 +156,7 @@ var _ = Describe("Client", func() {
 			}
 			returnedResponse = &http.Response{
 				StatusCode: 200,
-				Body:       ioutil.NopCloser(strings.NewReader(`{"scope":["network.admin"], "user_name":"some-user"}`)),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"scope":["network.admin"], "user_name":"some-user", "client_id":"some-client"}`)),
 			}
 			httpClient.DoReturns(returnedResponse, nil)
 		})
@@ -180,6 +180,7 @@ var _ = Describe("Client", func() {
 			Expect(contentType).To(Equal("application/x-www-form-urlencoded"))
 
 			Expect(tokenData.UserName).To(Equal("some-user"))
+			Expect(tokenData.ClientID).To(Equal("some-client"))
 			Expect(tokenData.Scope).To(Equal([]string{"network.admin"}))
 		})
 
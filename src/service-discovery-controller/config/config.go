@@ -24,6 +24,10 @@ type Config struct {
 	MetricsEmitSeconds        int          `json:"metrics_emit_seconds" validate:"min=1"`
 	ResumePruningDelaySeconds int          `json:"resume_pruning_delay_seconds" validate:"min=0"`
 	WarmDurationSeconds       int          `json:"warm_duration_seconds" validate:"min=0"`
+	ReadTimeoutSeconds        int          `json:"server_read_timeout_seconds" validate:"min=0"`
+	WriteTimeoutSeconds       int          `json:"server_write_timeout_seconds" validate:"min=0"`
+	IdleTimeoutSeconds        int          `json:"server_idle_timeout_seconds" validate:"min=0"`
+	DrainTimeoutSeconds       int          `json:"drain_timeout_seconds" validate:"min=0"`
 }
 
 type NatsConfig struct {
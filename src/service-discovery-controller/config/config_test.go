@@ -41,7 +41,11 @@ var _ = Describe("Config", func() {
 				"metrics_emit_seconds": 6,
 				"metron_port": 8080,
 				"resume_pruning_delay_seconds": 2,
-				"warm_duration_seconds": 5
+				"warm_duration_seconds": 5,
+				"server_read_timeout_seconds": 10,
+				"server_write_timeout_seconds": 20,
+				"server_idle_timeout_seconds": 30,
+				"drain_timeout_seconds": 15
 			}`)
 
 			parsedConfig, err := NewConfig(configJSON)
@@ -63,6 +67,10 @@ var _ = Describe("Config", func() {
 			Expect(parsedConfig.MetricsEmitSeconds).To(Equal(6))
 			Expect(parsedConfig.ResumePruningDelaySeconds).To(Equal(2))
 			Expect(parsedConfig.WarmDurationSeconds).To(Equal(5))
+			Expect(parsedConfig.ReadTimeoutSeconds).To(Equal(10))
+			Expect(parsedConfig.WriteTimeoutSeconds).To(Equal(20))
+			Expect(parsedConfig.IdleTimeoutSeconds).To(Equal(30))
+			Expect(parsedConfig.DrainTimeoutSeconds).To(Equal(15))
 		})
 	})
 
@@ -113,6 +121,7 @@ var _ = Describe("Config", func() {
 		Entry("invalid ca_cert", "ca_cert", "", "CACert: zero value"),
 		Entry("invalid resume_pruning_delay_seconds", "resume_pruning_delay_seconds", -1, "ResumePruningDelaySeconds: less than min"),
 		Entry("invalid warm_duration_seconds", "warm_duration_seconds", -1, "WarmDurationSeconds: less than min"),
+		Entry("invalid drain_timeout_seconds", "drain_timeout_seconds", -1, "DrainTimeoutSeconds: less than min"),
 	)
 })
 
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -108,12 +109,15 @@ func (s *Server) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 
 	serverAddress := fmt.Sprintf("%s:%s", s.config.Address, s.config.Port)
 	httpServer := &http.Server{
-		Addr:      serverAddress,
-		Handler:   mux,
-		TLSConfig: tlsConfig,
+		Addr:         serverAddress,
+		Handler:      mux,
+		TLSConfig:    tlsConfig,
+		ReadTimeout:  time.Duration(s.config.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(s.config.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(s.config.IdleTimeoutSeconds) * time.Second,
 	}
 
-	exited := make(chan error)
+	exited := make(chan error, 1)
 	go func() {
 		serveErr := httpServer.ListenAndServeTLS("", "")
 		s.logger.Info("server-exited")
@@ -131,13 +135,27 @@ func (s *Server) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			s.logger.Info(fmt.Sprintf("SDC http server exiting with: %v", err))
 			return err
 		case signal := <-signals:
-			httpServer.Close()
+			s.drain(httpServer)
 			s.logger.Info(fmt.Sprintf("SDC http server exiting with signal: %v", signal))
 			return nil
 		}
 	}
 }
 
+// drain stops accepting connections and waits for in-flight requests to
+// finish, closing whatever is left once the drain timeout expires.
+func (s *Server) drain(httpServer *http.Server) {
+	drainTimeout := time.Duration(s.config.DrainTimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	err := httpServer.Shutdown(ctx)
+	if err != nil {
+		s.logger.Error("drain-timed-out", err)
+		httpServer.Close()
+	}
+}
+
 func (s *Server) buildTLSServerConfig() (*tls.Config, error) {
 	caCert, err := ioutil.ReadFile(s.config.CACert)
 	if err != nil {
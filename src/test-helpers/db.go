@@ -14,6 +14,11 @@ import (
 )
 
 func CreateDatabase(config configHelper.Config) {
+	if config.Type == db.SQLite {
+		// the database file is created when it is first opened
+		return
+	}
+
 	config.Timeout = 120
 	dbToCreate := config.DatabaseName
 	config.DatabaseName = ""
@@ -34,6 +39,14 @@ func CreateDatabase(config configHelper.Config) {
 }
 
 func RemoveDatabase(config configHelper.Config) {
+	if config.Type == db.SQLite {
+		err := os.Remove(config.DatabaseName)
+		if err != nil {
+			fmt.Fprintln(ginkgo.GinkgoWriter, fmt.Sprintf("%+v", err))
+		}
+		return
+	}
+
 	config.Timeout = 120
 
 	dbToDrop := config.DatabaseName
@@ -80,6 +93,13 @@ func getMySQLDBConfig() configHelper.Config {
 	}
 }
 
+func getSQLiteDBConfig() configHelper.Config {
+	return configHelper.Config{
+		Type:    db.SQLite,
+		Timeout: DefaultDBTimeout,
+	}
+}
+
 func GetDBConfig() configHelper.Config {
 	dbEnv := os.Getenv("DB")
 	switch {
@@ -87,6 +107,8 @@ func GetDBConfig() configHelper.Config {
 		return getMySQLDBConfig()
 	case strings.HasPrefix(dbEnv, "postgres"):
 		return getPostgresDBConfig()
+	case strings.HasPrefix(dbEnv, "sqlite"):
+		return getSQLiteDBConfig()
 	default:
 		panic("unable to determine database to use.  Set environment variable DB")
 	}